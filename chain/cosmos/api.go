@@ -0,0 +1,50 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIGet sends a GET request for path, e.g. /cosmos/bank/v1beta1/balances/{address}, to the node's REST API server,
+// which ChainConfig.EnableAPI enables, and returns the JSON body of the response.
+func (tn *ChainNode) APIGet(ctx context.Context, path string) (json.RawMessage, error) {
+	if !tn.Chain.Config().EnableAPI {
+		return nil, errors.New("api server is not enabled, set EnableAPI in the chain config")
+	}
+	if tn.hostAPIPort == "" {
+		return nil, fmt.Errorf("node %s has not been started", tn.Name())
+	}
+
+	url := "http://" + tn.hostAPIPort + "/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("api get %s: %w", path, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("api get %s: read response: %w", path, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api get %s: status %d: %s", path, res.StatusCode, body)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("api get %s: response is not json: %s", path, body)
+	}
+	return body, nil
+}
+
+// APIGet sends a GET request for path to the REST API server of the chain's full node. See ChainNode.APIGet.
+func (c *CosmosChain) APIGet(ctx context.Context, path string) (json.RawMessage, error) {
+	return c.getFullNode().APIGet(ctx, path)
+}
@@ -0,0 +1,55 @@
+package cosmos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestChainNode_APIGet(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/base/tendermint/v1beta1/node_info":
+			_, _ = w.Write([]byte(`{"default_node_info":{"network":"test-1"}}`))
+		case "/not-json":
+			_, _ = w.Write([]byte("not json"))
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+			_, _ = w.Write([]byte(`{"code":12,"message":"Not Implemented"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	newNode := func(enableAPI bool) *ChainNode {
+		chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", EnableAPI: enableAPI}, 1, 0, zaptest.NewLogger(t))
+		return NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	}
+	ctx := context.Background()
+
+	_, err := newNode(false).APIGet(ctx, "/cosmos/base/tendermint/v1beta1/node_info")
+	require.EqualError(t, err, "api server is not enabled, set EnableAPI in the chain config")
+
+	tn := newNode(true)
+	_, err = tn.APIGet(ctx, "/cosmos/base/tendermint/v1beta1/node_info")
+	require.ErrorContains(t, err, "has not been started")
+
+	tn.hostAPIPort = strings.TrimPrefix(srv.URL, "http://")
+
+	res, err := tn.APIGet(ctx, "cosmos/base/tendermint/v1beta1/node_info")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"default_node_info":{"network":"test-1"}}`, string(res))
+
+	_, err = tn.APIGet(ctx, "/unknown")
+	require.EqualError(t, err, `api get /unknown: status 501: {"code":12,"message":"Not Implemented"}`)
+
+	_, err = tn.APIGet(ctx, "/not-json")
+	require.EqualError(t, err, "api get /not-json: response is not json: not json")
+}
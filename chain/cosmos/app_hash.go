@@ -0,0 +1,105 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+const (
+	// appHashWatchInterval is the interval at which WatchAppHashes queries the validators' app hashes,
+	// shorter than a block so that every height is compared.
+	appHashWatchInterval = 500 * time.Millisecond
+
+	// appHashHistory is the number of recent heights whose app hashes are kept for comparison,
+	// so that validators lagging behind are compared at the same heights.
+	appHashHistory = 100
+)
+
+// WatchAppHashes compares the app hash computed by each of the chain's validators at every height in the background,
+// until ctx is done or the test ends. Once validators diverge, e.g. due to nondeterminism in the state machine,
+// t fails with the height and both app hashes, and the returned context is canceled, so that the test
+// stops waiting on the chain rather than timing out later. Use the returned context for the rest of the test.
+func (c *CosmosChain) WatchAppHashes(ctx context.Context, t *testing.T) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+		tracker := newAppHashTracker()
+		ticker := time.NewTicker(appHashWatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			c.findTxMu.Lock()
+			validators := append(ChainNodes(nil), c.Validators...)
+			c.findTxMu.Unlock()
+
+			for _, v := range validators {
+				res, err := v.Client.ABCIInfo(ctx)
+				if err != nil {
+					// The validator may be stopped, e.g. by StopValidatorUntilJailed.
+					continue
+				}
+				if err := tracker.observe(v.Name(), res.Response.LastBlockHeight, res.Response.LastBlockAppHash); err != nil {
+					t.Errorf("app hashes of chain %s diverged: %v", c.cfg.ChainID, err)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return ctx
+}
+
+// nodeAppHash is the app hash computed by a node.
+type nodeAppHash struct {
+	node string
+	hash []byte
+}
+
+// appHashTracker compares the app hashes computed by nodes at each height.
+type appHashTracker struct {
+	hashes    map[int64]nodeAppHash
+	maxHeight int64
+}
+
+func newAppHashTracker() *appHashTracker {
+	return &appHashTracker{hashes: make(map[int64]nodeAppHash)}
+}
+
+// observe records the app hash computed by node at height,
+// returning an error if another node computed a different app hash at the height.
+func (t *appHashTracker) observe(node string, height int64, hash []byte) error {
+	if height <= 0 || height <= t.maxHeight-appHashHistory {
+		return nil
+	}
+	if seen, ok := t.hashes[height]; ok {
+		if !bytes.Equal(seen.hash, hash) {
+			return fmt.Errorf("at height %d, %s has app hash %X, and %s has app hash %X", height, seen.node, seen.hash, node, hash)
+		}
+		return nil
+	}
+	t.hashes[height] = nodeAppHash{node: node, hash: hash}
+
+	if height > t.maxHeight {
+		t.maxHeight = height
+		for h := range t.hashes {
+			if h <= t.maxHeight-appHashHistory {
+				delete(t.hashes, h)
+			}
+		}
+	}
+	return nil
+}
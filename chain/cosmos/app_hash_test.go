@@ -0,0 +1,24 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppHashTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newAppHashTracker()
+	require.NoError(t, tracker.observe("val-0", 10, []byte{0xAA}))
+	require.NoError(t, tracker.observe("val-1", 9, []byte{0x99}))
+	require.NoError(t, tracker.observe("val-1", 10, []byte{0xAA}))
+
+	err := tracker.observe("val-2", 9, []byte{0x98})
+	require.EqualError(t, err, "at height 9, val-1 has app hash 99, and val-2 has app hash 98")
+
+	// Heights older than the kept history are no longer compared.
+	require.NoError(t, tracker.observe("val-0", 10+appHashHistory, []byte{0x01}))
+	require.NoError(t, tracker.observe("val-2", 10, []byte{0xBB}))
+	require.Len(t, tracker.hashes, 1)
+}
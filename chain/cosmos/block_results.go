@@ -0,0 +1,77 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+)
+
+// finalizeBlockEvents returns the events of the block results at height of chains on CometBFT v0.38 (SDK v0.50),
+// which replace the begin and end block events with finalize_block_events. The RPC client of CometBFT v0.37
+// drops the field, so the block results are requested directly from the node's RPC server.
+// The events are split into the begin and end block events by the mode attribute set by the SDK.
+func (tn *ChainNode) finalizeBlockEvents(ctx context.Context, height int64) (beginBlock, endBlock []abcitypes.Event, err error) {
+	if tn.hostRPCPort == "" {
+		return nil, nil, fmt.Errorf("node %s has not been started", tn.Name())
+	}
+
+	url := "http://" + tn.hostRPCPort + "/block_results?height=" + strconv.FormatInt(height, 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get block results at height %d: %w", height, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get block results at height %d: read response: %w", height, err)
+	}
+
+	var rpcRes struct {
+		Result *struct {
+			FinalizeBlockEvents []abcitypes.Event `json:"finalize_block_events"`
+		} `json:"result"`
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &rpcRes); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal block results at height %d: %w", height, err)
+	}
+	if rpcRes.Result == nil {
+		return nil, nil, fmt.Errorf("get block results at height %d: %s", height, rpcRes.Error)
+	}
+
+	beginBlock, endBlock = splitFinalizeBlockEvents(rpcRes.Result.FinalizeBlockEvents)
+	return beginBlock, endBlock, nil
+}
+
+// splitFinalizeBlockEvents splits finalize block events into the events of end block, by their mode attribute,
+// and the events of begin block and of blockers preceding it.
+func splitFinalizeBlockEvents(events []abcitypes.Event) (beginBlock, endBlock []abcitypes.Event) {
+	for _, e := range events {
+		if finalizeBlockEventMode(e) == "EndBlock" {
+			endBlock = append(endBlock, e)
+		} else {
+			beginBlock = append(beginBlock, e)
+		}
+	}
+	return beginBlock, endBlock
+}
+
+// finalizeBlockEventMode returns the mode attribute of e, e.g. BeginBlock, if any.
+func finalizeBlockEventMode(e abcitypes.Event) string {
+	for _, attr := range e.Attributes {
+		if attr.Key == "mode" {
+			return attr.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package cosmos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestChainNode_finalizeBlockEvents(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/block_results" || r.URL.Query().Get("height") != "5" {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"height is not available"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"height":"5","finalize_block_events":[
+			{"type":"mint","attributes":[{"key":"amount","value":"10","index":true},{"key":"mode","value":"BeginBlock","index":true}]},
+			{"type":"complete_unbonding","attributes":[{"key":"mode","value":"EndBlock","index":true}]},
+			{"type":"upgrade","attributes":[{"key":"mode","value":"PreBlock","index":true}]}
+		]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	ctx := context.Background()
+
+	_, _, err := tn.finalizeBlockEvents(ctx, 5)
+	require.ErrorContains(t, err, "has not been started")
+
+	tn.hostRPCPort = strings.TrimPrefix(srv.URL, "http://")
+
+	beginBlock, endBlock, err := tn.finalizeBlockEvents(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, beginBlock, 2)
+	require.Equal(t, "mint", beginBlock[0].Type)
+	require.Equal(t, abcitypes.EventAttribute{Key: "amount", Value: "10", Index: true}, beginBlock[0].Attributes[0])
+	require.Equal(t, "upgrade", beginBlock[1].Type)
+	require.Len(t, endBlock, 1)
+	require.Equal(t, "complete_unbonding", endBlock[0].Type)
+
+	_, _, err = tn.finalizeBlockEvents(ctx, 6)
+	require.ErrorContains(t, err, "height is not available")
+}
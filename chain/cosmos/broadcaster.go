@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -89,7 +90,12 @@ func (b *Broadcaster) GetFactory(ctx context.Context, user User) (tx.Factory, er
 		return tx.Factory{}, err
 	}
 
-	f := b.defaultTxFactory(clientContext, accNumber.GetAccountNumber())
+	gasPrices, err := b.chain.TxGasPrices(ctx)
+	if err != nil {
+		return tx.Factory{}, err
+	}
+
+	f := b.defaultTxFactory(clientContext, accNumber.GetAccountNumber(), gasPrices)
 	for _, opt := range b.factoryOptions {
 		f = opt(f)
 	}
@@ -106,8 +112,14 @@ func (b *Broadcaster) GetClientContext(ctx context.Context, user User) (client.C
 	_, ok := b.keyrings[user]
 	if !ok {
 		localDir := b.t.TempDir()
-		containerKeyringDir := path.Join(cn.HomeDir(), "keyring-test")
-		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID())
+		containerKeyringDir := path.Join(cn.HomeDir(), cn.keyringDir())
+		var passphrase string
+		if cfg := chain.cfg.Keyring; cfg != nil {
+			passphrase = cfg.Passphrase
+		}
+		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID(),
+			cn.keyringBackend(), strings.NewReader(passphrase+"\n"), chain.cfg.EncodingConfig.Codec,
+		)
 		if err != nil {
 			return client.Context{}, err
 		}
@@ -165,15 +177,15 @@ func (b *Broadcaster) defaultClientContext(fromUser User, sdkAdd sdk.AccAddress)
 	// but that field no longer exists and the test against Broadcaster still passes without it.
 }
 
-// defaultTxFactory creates a new Factory with default configuration.
-func (b *Broadcaster) defaultTxFactory(clientCtx client.Context, accountNumber uint64) tx.Factory {
+// defaultTxFactory creates a new Factory with default configuration, paying gasPrices.
+func (b *Broadcaster) defaultTxFactory(clientCtx client.Context, accountNumber uint64, gasPrices string) tx.Factory {
 	chainConfig := b.chain.Config()
 	return tx.Factory{}.
 		WithAccountNumber(accountNumber).
 		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
 		WithGasAdjustment(chainConfig.GasAdjustment).
 		WithGas(flags.DefaultGasLimit).
-		WithGasPrices(chainConfig.GasPrices).
+		WithGasPrices(gasPrices).
 		WithMemo("interchaintest").
 		WithTxConfig(clientCtx.TxConfig).
 		WithAccountRetriever(clientCtx.AccountRetriever).
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/p2p"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
@@ -24,18 +25,20 @@ import (
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	"github.com/cosmos/cosmos-sdk/client"
-	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 )
 
 // ChainNode represents a node in the test network that is being created
@@ -49,6 +52,9 @@ type ChainNode struct {
 	Client       rpcclient.Client
 	TestName     string
 	Image        ibc.DockerImage
+	// Environment variables of the node's container, by default those of ChainConfig.Env and of
+	// ChainConfig.ValidatorEnv or ChainConfig.FullNodeEnv. Changes apply when the container is next created.
+	Env []string
 
 	lock sync.Mutex
 	log  *zap.Logger
@@ -58,6 +64,15 @@ type ChainNode struct {
 	// Ports set during StartContainer.
 	hostRPCPort  string
 	hostGRPCPort string
+	hostAPIPort  string
+	// Host port of the Rosetta API server, if served in-process by ChainConfig.Rosetta.
+	hostRosettaPort string
+	// Host port of the Prometheus metrics, if enabled by ChainConfig.EnableMetrics.
+	hostMetricsPort string
+
+	// Connection returned by GRPCConn.
+	grpcLock sync.Mutex
+	grpcConn *grpc.ClientConn
 
 	preStartListeners dockerutil.Listeners
 }
@@ -74,6 +89,7 @@ func NewChainNode(log *zap.Logger, validator bool, chain *CosmosChain, dockerCli
 		TestName:     testName,
 		Image:        image,
 		Index:        index,
+		Env:          nodeEnv(chain.cfg, validator, index),
 	}
 
 	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, tn.Name())
@@ -104,6 +120,22 @@ var (
 	}
 )
 
+// containerPorts returns the ports exposed by the node's container, the sentry ports and those of optional servers.
+func (tn *ChainNode) containerPorts() nat.PortSet {
+	cfg := tn.Chain.Config()
+	ports := make(nat.PortSet, len(sentryPorts)+2)
+	for p := range sentryPorts {
+		ports[p] = struct{}{}
+	}
+	if rosettaInProcess(cfg) {
+		ports[nat.Port(rosettaPort)] = struct{}{}
+	}
+	if cfg.EnableMetrics {
+		ports[nat.Port(metricsPort)] = struct{}{}
+	}
+	return ports
+}
+
 // NewClient creates and assigns a new Tendermint RPC client to the ChainNode
 func (tn *ChainNode) NewClient(addr string) error {
 	httpClient, err := libclient.DefaultHTTPClient(addr)
@@ -231,6 +263,9 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	blockT := (time.Duration(blockTime) * time.Second).String()
 	consensus["timeout_commit"] = blockT
 	consensus["timeout_propose"] = blockT
+	if cfg := tn.Chain.Config().Consensus; cfg != nil && cfg.TimeoutCommit > 0 {
+		consensus["timeout_commit"] = cfg.TimeoutCommit.String()
+	}
 
 	c["consensus"] = consensus
 
@@ -241,6 +276,10 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 
 	c["rpc"] = rpc
 
+	if tn.Chain.Config().EnableMetrics {
+		c = mergeToml(c, metricsConfig())
+	}
+
 	if err := testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
@@ -263,6 +302,36 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 
 	a["grpc"] = grpc
 
+	if tn.Chain.Config().EnableAPI {
+		api := make(testutil.Toml)
+
+		// Enable public REST API
+		api["enable"] = true
+		api["address"] = "tcp://0.0.0.0:1317"
+
+		a["api"] = api
+	}
+
+	if cfg := tn.Chain.Config().StateSync; cfg != nil {
+		a = mergeToml(a, stateSyncAppConfig(cfg))
+	}
+
+	if tn.Chain.Config().EnableMetrics {
+		a = mergeToml(a, telemetryAppConfig())
+	}
+
+	if rosettaInProcess(tn.Chain.Config()) {
+		a = mergeToml(a, rosettaAppConfig(tn.Chain.Config()))
+	}
+
+	if cfg := tn.pruning(); cfg != nil {
+		pruning, err := pruningAppConfig(*cfg)
+		if err != nil {
+			return fmt.Errorf("pruning of %s: %w", tn.Name(), err)
+		}
+		a = mergeToml(a, pruning)
+	}
+
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
@@ -303,8 +372,17 @@ func (tn *ChainNode) Height(ctx context.Context) (uint64, error) {
 	return uint64(height), nil
 }
 
-// FindTxs implements blockdb.BlockSaver.
+// FindTxs implements blockdb.TxFinder.
 func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	block, err := tn.FindBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return block.Txs, nil
+}
+
+// FindBlock implements blockdb.BlockFinder.
+func (tn *ChainNode) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
 	h := int64(height)
 	var eg errgroup.Group
 	var blockRes *coretypes.ResultBlockResults
@@ -317,114 +395,169 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 		block, err = tn.Client.Block(ctx, &h)
 		return err
 	})
+	var validators []blockdb.Validator
+	eg.Go(func() error {
+		var err error
+		// The validator set is supplementary, so don't fail saving the block without it.
+		if validators, err = tn.blockValidators(ctx, h); err != nil {
+			tn.logger().Info("Failed to find validators", zap.Uint64("height", height), zap.Error(err))
+		}
+		return nil
+	})
+	// The app version is also supplementary. It is the node's current version, which matches the block's version
+	// as long as blocks are saved shortly after they are committed.
+	// If the app does not report a version, fall back to the image version.
+	var version string
+	eg.Go(func() error {
+		info, err := tn.Client.ABCIInfo(ctx)
+		if err != nil {
+			tn.logger().Info("Failed to find app version", zap.Uint64("height", height), zap.Error(err))
+			return nil
+		}
+		version = info.Response.Version
+		if version == "" {
+			version = tn.Image.Version
+		}
+		return nil
+	})
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return blockdb.Block{}, err
 	}
+
+	beginBlockEvents, endBlockEvents := blockRes.BeginBlockEvents, blockRes.EndBlockEvents
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return blockdb.Block{}, err
+	}
+	if v.AtLeast(SDKVersion50) {
+		if beginBlockEvents, endBlockEvents, err = tn.finalizeBlockEvents(ctx, h); err != nil {
+			return blockdb.Block{}, err
+		}
+	}
+
 	interfaceRegistry := tn.Chain.Config().EncodingConfig.InterfaceRegistry
-	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+2)
+	txs := make([]blockdb.Tx, 0, len(block.Block.Txs))
 	for i, tx := range block.Block.Txs {
 		var newTx blockdb.Tx
-		newTx.Data = []byte(fmt.Sprintf(`{"data":"%s"}`, hex.EncodeToString(tx)))
+		// Keep the raw tx if it cannot be decoded, e.g. due to message types of custom modules,
+		// so that it may be decoded later with a TxDecoder.
+		newTx.Data = blockdb.RawTxData(tx)
+		newTx.Hash = fmt.Sprintf("%X", tx.Hash())
 
-		sdkTx, err := decodeTX(interfaceRegistry, tx)
-		if err != nil {
+		if sdkTx, err := decodeTX(interfaceRegistry, tx); err != nil {
 			tn.logger().Info("Failed to decode tx", zap.Uint64("height", height), zap.Error(err))
-			continue
-		}
-		b, err := encodeTxToJSON(interfaceRegistry, sdkTx)
-		if err != nil {
+		} else if b, err := encodeTxToJSON(interfaceRegistry, sdkTx); err != nil {
 			tn.logger().Info("Failed to marshal tx to json", zap.Uint64("height", height), zap.Error(err))
-			continue
-		}
-		newTx.Data = b
-
-		rTx := blockRes.TxsResults[i]
-
-		newTx.Events = make([]blockdb.Event, len(rTx.Events))
-		for j, e := range rTx.Events {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for k, attr := range e.Attributes {
-				attrs[k] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
-			}
-			newTx.Events[j] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
-			}
+		} else {
+			newTx.Data = b
 		}
+
+		txRes := blockRes.TxsResults[i]
+		newTx.GasWanted = txRes.GasWanted
+		newTx.GasUsed = txRes.GasUsed
+		newTx.Code = txRes.Code
+		newTx.Codespace = txRes.Codespace
+		newTx.Log = txRes.Log
+		newTx.Events = blockdbEvents(txRes.Events)
 		txs = append(txs, newTx)
 	}
-	if len(blockRes.BeginBlockEvents) > 0 {
-		beginBlockTx := blockdb.Tx{
-			Data: []byte(`{"data":"begin_block","note":"this is a transaction artificially created for debugging purposes"}`),
+
+	return blockdb.Block{
+		Txs:              txs,
+		BeginBlockEvents: blockdbEvents(beginBlockEvents),
+		EndBlockEvents:   blockdbEvents(endBlockEvents),
+		ProposerAddress:  block.Block.ProposerAddress.String(),
+		ValidatorsHash:   block.Block.ValidatorsHash.String(),
+		Validators:       validators,
+		Version:          version,
+		Image:            tn.Image.Ref(),
+	}, nil
+}
+
+// blockValidators returns the validator set of the block at height, paging through all validators.
+func (tn *ChainNode) blockValidators(ctx context.Context, height int64) ([]blockdb.Validator, error) {
+	// The maximum page size allowed by the RPC.
+	perPage := 100
+	var vals []blockdb.Validator
+	for page := 1; ; page++ {
+		page := page
+		res, err := tn.Client.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, err
 		}
-		beginBlockTx.Events = make([]blockdb.Event, len(blockRes.BeginBlockEvents))
-		for i, e := range blockRes.BeginBlockEvents {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for j, attr := range e.Attributes {
-				attrs[j] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
-			}
-			beginBlockTx.Events[i] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
-			}
+		for _, v := range res.Validators {
+			vals = append(vals, blockdb.Validator{
+				Address:     v.Address.String(),
+				VotingPower: v.VotingPower,
+			})
 		}
-		txs = append(txs, beginBlockTx)
-	}
-	if len(blockRes.EndBlockEvents) > 0 {
-		endBlockTx := blockdb.Tx{
-			Data: []byte(`{"data":"end_block","note":"this is a transaction artificially created for debugging purposes"}`),
+		if len(res.Validators) == 0 || len(vals) >= res.Total {
+			return vals, nil
 		}
-		endBlockTx.Events = make([]blockdb.Event, len(blockRes.EndBlockEvents))
-		for i, e := range blockRes.EndBlockEvents {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for j, attr := range e.Attributes {
-				attrs[j] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
-			}
-			endBlockTx.Events[i] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
+	}
+}
+
+func blockdbEvents(events []abcitypes.Event) []blockdb.Event {
+	out := make([]blockdb.Event, len(events))
+	for i, e := range events {
+		attrs := make([]blockdb.EventAttribute, len(e.Attributes))
+		for j, attr := range e.Attributes {
+			attrs[j] = blockdb.EventAttribute{
+				Key:   string(attr.Key),
+				Value: string(attr.Value),
 			}
 		}
-		txs = append(txs, endBlockTx)
+		out[i] = blockdb.Event{
+			Type:       e.Type,
+			Attributes: attrs,
+		}
 	}
-
-	return txs, nil
+	return out
 }
 
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
 // with the chain node binary.
 func (tn *ChainNode) TxCommand(keyName string, command ...string) []string {
+	return tn.txCommand(keyName, tn.Chain.Config().GasPrices, command...)
+}
+
+// txCommand returns the full command for broadcasting a tx paying gasPrices.
+func (tn *ChainNode) txCommand(keyName string, gasPrices string, command ...string) []string {
 	command = append([]string{"tx"}, command...)
 	return tn.NodeCommand(append(command,
 		"--from", keyName,
-		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-prices", gasPrices,
 		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
-		"--keyring-backend", keyring.BackendTest,
+		"--keyring-backend", tn.keyringBackend(),
 		"--output", "json",
 		"-y",
 	)...)
 }
 
 // ExecTx executes a transaction, waits for 2 blocks if successful, then returns the tx hash.
+// The tx fees are paid by the fee granter set on ctx with WithFeeGranter, if any.
+// On chains with a fee market, the tx pays the current base gas price, see CosmosChain.TxGasPrices.
 func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...string) (string, error) {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	stdout, _, err := tn.Exec(ctx, tn.TxCommand(keyName, command...), nil)
+	gasPrices, err := tn.txGasPrices(ctx)
 	if err != nil {
 		return "", err
 	}
+	command = append(command, feeGranterFlags(ctx)...)
+	stdout, _, err := tn.Exec(ctx, tn.txCommand(keyName, gasPrices, command...), nil)
+	if err != nil {
+		return "", err
+	}
+	return tn.txResult(ctx, stdout)
+}
+
+// txResult checks the json output stdout of broadcasting a tx, waits for 2 blocks if successful,
+// then returns the tx hash.
+func (tn *ChainNode) txResult(ctx context.Context, stdout []byte) (string, error) {
 	output := CosmosTx{}
-	err = json.Unmarshal([]byte(stdout), &output)
+	err := json.Unmarshal(stdout, &output)
 	if err != nil {
 		return "", err
 	}
@@ -437,6 +570,24 @@ func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...stri
 	return output.TxHash, nil
 }
 
+// getTransaction returns the committed tx with txHash.
+func (tn *ChainNode) getTransaction(txHash string) (*types.TxResponse, error) {
+	// Retry because sometimes the tx is not committed to state yet.
+	var txResp *types.TxResponse
+	err := retry.Do(func() error {
+		var err error
+		txResp, err = authTx.QueryTx(tn.CliContext(), txHash)
+		return err
+	},
+		// retry for total of 3 seconds
+		retry.Attempts(15),
+		retry.Delay(200*time.Millisecond),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+	)
+	return txResp, err
+}
+
 // NodeCommand is a helper to retrieve a full command for a chain node binary.
 // when interactions with the RPC endpoint are necessary.
 // For example, if chain node binary is `gaiad`, and desired command is `gaiad keys show key1`,
@@ -556,31 +707,39 @@ func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, erro
 	return gen, nil
 }
 
-// CreateKey creates a key in the keyring backend test for the given node
+// CreateKey creates a key in the keyring of the given node, of the test backend unless ChainConfig.Keyring is set.
 func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.ExecBin(ctx,
+	_, _, err := tn.ExecBin(ctx, append([]string{
 		"keys", "add", name,
 		"--coin-type", tn.Chain.Config().CoinType,
-		"--keyring-backend", keyring.BackendTest,
-	)
+		"--keyring-backend", tn.keyringBackend(),
+	}, tn.keyAlgoFlags()...)...)
 	return err
 }
 
 // RecoverKey restores a key from a given mnemonic.
 func (tn *ChainNode) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
-	command := []string{
-		"sh",
-		"-c",
-		fmt.Sprintf(`echo %q | %s keys add %s --recover --keyring-backend %s --coin-type %s --home %s --output json`, mnemonic, tn.Chain.Config().Bin, keyName, keyring.BackendTest, tn.Chain.Config().CoinType, tn.HomeDir()),
-	}
+	const mnemonicEnv = "MNEMONIC"
 
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.Exec(ctx, command, nil)
+	// The mnemonic is entered after the keyring passphrase, if any.
+	command := stdinCommand(tn.BinCommand(append([]string{
+		"keys", "add", keyName, "--recover",
+		"--keyring-backend", tn.keyringBackend(),
+		"--coin-type", tn.Chain.Config().CoinType,
+		"--output", "json",
+	}, tn.keyAlgoFlags()...)...), append(tn.keyringPassphraseEnvs(ctx), mnemonicEnv)...)
+	env := []string{mnemonicEnv + "=" + mnemonic}
+	if cfg := tn.Chain.Config().Keyring; cfg != nil {
+		env = append(env, keyringPassphraseEnv+"="+cfg.Passphrase)
+	}
+
+	_, _, err := tn.Exec(ctx, command, env)
 	return err
 }
 
@@ -602,13 +761,13 @@ func (tn *ChainNode) AddGenesisAccount(ctx context.Context, address string, gene
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	var command []string
-	if tn.Chain.Config().UsingNewGenesisCommand {
-		command = append(command, "genesis")
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return err
 	}
 
-	command = append(command, "add-genesis-account", address, amount)
-	_, _, err := tn.ExecBin(ctx, command...)
+	command := genesisCommand(v, tn.Chain.Config().UsingNewGenesisCommand, "add-genesis-account", address, amount)
+	_, _, err = tn.ExecBin(ctx, command...)
 
 	return err
 }
@@ -618,32 +777,34 @@ func (tn *ChainNode) Gentx(ctx context.Context, name string, genesisSelfDelegati
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	var command []string
-	if tn.Chain.Config().UsingNewGenesisCommand {
-		command = append(command, "genesis")
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return err
 	}
 
-	command = append(command, "gentx", valKey, fmt.Sprintf("%d%s", genesisSelfDelegation.Amount.Int64(), genesisSelfDelegation.Denom),
-		"--keyring-backend", keyring.BackendTest,
+	command := genesisCommand(v, tn.Chain.Config().UsingNewGenesisCommand,
+		"gentx", valKey, fmt.Sprintf("%d%s", genesisSelfDelegation.Amount.Int64(), genesisSelfDelegation.Denom),
+		"--keyring-backend", tn.keyringBackend(),
 		"--chain-id", tn.Chain.Config().ChainID)
 
-	_, _, err := tn.ExecBin(ctx, command...)
+	_, _, err = tn.ExecBin(ctx, command...)
 	return err
 }
 
 // CollectGentxs runs collect gentxs on the node's home folders
 func (tn *ChainNode) CollectGentxs(ctx context.Context) error {
-	command := []string{tn.Chain.Config().Bin}
-	if tn.Chain.Config().UsingNewGenesisCommand {
-		command = append(command, "genesis")
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return err
 	}
 
-	command = append(command, "collect-gentxs", "--home", tn.HomeDir())
+	command := append([]string{tn.Chain.Config().Bin},
+		genesisCommand(v, tn.Chain.Config().UsingNewGenesisCommand, "collect-gentxs", "--home", tn.HomeDir())...)
 
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.Exec(ctx, command, nil)
+	_, _, err = tn.Exec(ctx, command, nil)
 	return err
 }
 
@@ -720,26 +881,16 @@ func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName
 		return "", fmt.Errorf("writing contract file to docker volume: %w", err)
 	}
 
-	if _, err := tn.ExecTx(ctx, keyName, "wasm", "store", path.Join(tn.HomeDir(), file)); err != nil {
-		return "", err
-	}
-
-	err = testutil.WaitForBlocks(ctx, 5, tn.Chain)
-	if err != nil {
-		return "", fmt.Errorf("wait for blocks: %w", err)
-	}
-
-	stdout, _, err := tn.ExecQuery(ctx, "wasm", "list-code", "--reverse")
+	txResp, err := tn.execContractTx(ctx, keyName, "wasm", "store", path.Join(tn.HomeDir(), file), "--gas", "auto")
 	if err != nil {
 		return "", err
 	}
 
-	res := CodeInfosResponse{}
-	if err := json.Unmarshal([]byte(stdout), &res); err != nil {
-		return "", err
+	codeID, ok := tendermint.AttributeValue(txResp.Events, "store_code", "code_id")
+	if !ok {
+		return "", fmt.Errorf("code id not found in events of tx %s", txResp.TxHash)
 	}
-
-	return res.CodeInfos[0].CodeID, nil
+	return codeID, nil
 }
 
 // InstantiateContract takes a code id for a smart contract and initialization message and returns the instantiated contract address.
@@ -748,22 +899,16 @@ func (tn *ChainNode) InstantiateContract(ctx context.Context, keyName string, co
 	if needsNoAdminFlag {
 		command = append(command, "--no-admin")
 	}
-	_, err := tn.ExecTx(ctx, keyName, command...)
-	if err != nil {
-		return "", err
-	}
-
-	stdout, _, err := tn.ExecQuery(ctx, "wasm", "list-contract-by-code", codeID)
+	txResp, err := tn.execContractTx(ctx, keyName, command...)
 	if err != nil {
 		return "", err
 	}
 
-	contactsRes := QueryContractResponse{}
-	if err := json.Unmarshal([]byte(stdout), &contactsRes); err != nil {
-		return "", err
+	// The contract instantiated by the message comes first, before any instantiated by the contract.
+	contractAddress, ok := tendermint.AttributeValue(txResp.Events, "instantiate", "_contract_address")
+	if !ok {
+		return "", fmt.Errorf("contract address not found in events of tx %s", txResp.TxHash)
 	}
-
-	contractAddress := contactsRes.Contracts[len(contactsRes.Contracts)-1]
 	return contractAddress, nil
 }
 
@@ -775,6 +920,52 @@ func (tn *ChainNode) ExecuteContract(ctx context.Context, keyName string, contra
 	return err
 }
 
+// ExecuteContractTx executes a contract transaction with a message using it's address,
+// and returns the committed tx with the data returned by the contract.
+func (tn *ChainNode) ExecuteContractTx(ctx context.Context, keyName string, contractAddress string, message string) (*ContractTxResponse, error) {
+	txResp, err := tn.execContractTx(ctx, keyName,
+		"wasm", "execute", contractAddress, message,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newContractTxResponse(txResp)
+}
+
+// MigrateContract migrates a contract to the code with codeID, calling its migrate entry point with message.
+// The key must be the contract's admin.
+func (tn *ChainNode) MigrateContract(ctx context.Context, keyName string, contractAddress string, codeID string, message string) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"wasm", "migrate", contractAddress, codeID, message,
+	)
+	return err
+}
+
+// MigrateContractTx migrates a contract like MigrateContract,
+// and returns the committed tx with the data returned by the contract's migrate entry point.
+func (tn *ChainNode) MigrateContractTx(ctx context.Context, keyName string, contractAddress string, codeID string, message string) (*ContractTxResponse, error) {
+	txResp, err := tn.execContractTx(ctx, keyName,
+		"wasm", "migrate", contractAddress, codeID, message,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newContractTxResponse(txResp)
+}
+
+// execContractTx executes the tx command and returns the committed tx, whose events contain the results of the contract.
+func (tn *ChainNode) execContractTx(ctx context.Context, keyName string, command ...string) (*types.TxResponse, error) {
+	txHash, err := tn.ExecTx(ctx, keyName, command...)
+	if err != nil {
+		return nil, err
+	}
+	txResp, err := tn.getTransaction(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	return txResp, nil
+}
+
 // QueryContract performs a smart query, taking in a query struct and returning a error with the response struct populated.
 func (tn *ChainNode) QueryContract(ctx context.Context, contractAddress string, queryMsg any, response any) error {
 	query, err := json.Marshal(queryMsg)
@@ -789,6 +980,16 @@ func (tn *ChainNode) QueryContract(ctx context.Context, contractAddress string,
 	return err
 }
 
+// QueryContractSmart performs a smart query, taking in a query struct and populating response with the response of the contract.
+// Unlike QueryContract, response is the contract's response, rather than the query response wrapping it in a data field.
+func (tn *ChainNode) QueryContractSmart(ctx context.Context, contractAddress string, queryMsg any, response any) error {
+	var res json.RawMessage
+	if err := tn.QueryContract(ctx, contractAddress, queryMsg, &res); err != nil {
+		return err
+	}
+	return unwrapContractResponse(contractAddress, res, response)
+}
+
 // StoreClientContract takes a file path to a client smart contract and stores it on-chain. Returns the contracts code id.
 func (tn *ChainNode) StoreClientContract(ctx context.Context, keyName string, fileName string) (string, error) {
 	content, err := os.ReadFile(fileName)
@@ -845,31 +1046,25 @@ func (tn *ChainNode) QueryProposal(ctx context.Context, proposalID string) (*Pro
 
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
-	command := []string{
-		"gov", "submit-proposal",
-		"software-upgrade", prop.Name,
-		"--upgrade-height", strconv.FormatUint(prop.Height, 10),
-		"--title", prop.Title,
-		"--description", prop.Description,
-		"--deposit", prop.Deposit,
-	}
-
-	if prop.Info != "" {
-		command = append(command, "--upgrade-info", prop.Info)
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return "", err
 	}
-
-	return tn.ExecTx(ctx, keyName, command...)
+	return tn.ExecTx(ctx, keyName, upgradeProposalCommand(v, prop)...)
 }
 
 // TextProposal submits a text governance proposal to the chain.
 func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop TextProposal) (string, error) {
-	command := []string{
-		"gov", "submit-proposal",
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	command := append(legacyProposalCommand(v),
 		"--type", "text",
 		"--title", prop.Title,
 		"--description", prop.Description,
 		"--deposit", prop.Deposit,
-	}
+	)
 	if prop.Expedited {
 		command = append(command, "--is-expedited=true")
 	}
@@ -892,11 +1087,14 @@ func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, pr
 
 	proposalPath := filepath.Join(tn.HomeDir(), proposalFilename)
 
-	command := []string{
-		"gov", "submit-proposal",
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	command := append(legacyProposalCommand(v),
 		"param-change",
 		proposalPath,
-	}
+	)
 
 	return tn.ExecTx(ctx, keyName, command...)
 }
@@ -942,13 +1140,20 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 	chainCfg := tn.Chain.Config()
 
 	var cmd []string
-	if chainCfg.NoHostMount {
-		cmd = []string{"sh", "-c", fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt --x-crisis-skip-assert-invariants", tn.HomeDir(), tn.HomeDir(), chainCfg.Bin, tn.HomeDir())}
-	} else {
-		cmd = []string{chainCfg.Bin, "start", "--home", tn.HomeDir(), "--x-crisis-skip-assert-invariants"}
+	switch {
+	case chainCfg.Cosmovisor != nil:
+		if err := tn.setupCosmovisor(ctx); err != nil {
+			return err
+		}
+		cmd = tn.cosmovisorStartCmd()
+	case chainCfg.NoHostMount:
+		cmd = append([]string{"sh", "-c", fmt.Sprintf(`cp -r %s %s_nomnt && exec "$@"`, tn.HomeDir(), tn.HomeDir()), "_", chainCfg.Bin},
+			tn.startArgs(tn.HomeDir()+"_nomnt")...)
+	default:
+		cmd = append([]string{chainCfg.Bin}, tn.startArgs(tn.HomeDir())...)
 	}
 
-	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, sentryPorts, tn.Bind(), tn.HostName(), cmd)
+	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, tn.containerPorts(), tn.Bind(), tn.HostName(), cmd, tn.Env)
 }
 
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
@@ -957,11 +1162,15 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}
 
 	// Set the host ports once since they will not change after the container has started.
-	hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, rpcPort, grpcPort)
+	hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, rpcPort, grpcPort, apiPort, rosettaPort, metricsPort)
 	if err != nil {
 		return err
 	}
-	tn.hostRPCPort, tn.hostGRPCPort = hostPorts[0], hostPorts[1]
+	if err := tn.closeGRPCConn(); err != nil {
+		return err
+	}
+	tn.hostRPCPort, tn.hostGRPCPort, tn.hostAPIPort = hostPorts[0], hostPorts[1], hostPorts[2]
+	tn.hostRosettaPort, tn.hostMetricsPort = hostPorts[3], hostPorts[4]
 
 	err = tn.NewClient("tcp://" + tn.hostRPCPort)
 	if err != nil {
@@ -984,10 +1193,28 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 }
 
 func (tn *ChainNode) StopContainer(ctx context.Context) error {
+	if err := tn.closeGRPCConn(); err != nil {
+		return err
+	}
 	return tn.containerLifecycle.StopContainer(ctx)
 }
 
+// PauseContainer freezes the node's container, so that the node stops responding to its peers and to queries
+// without exiting, like a node that hangs. Its connections stay open, and it resumes where it left off
+// once unpaused with UnpauseContainer. Queries of a paused node block until their context is done.
+func (tn *ChainNode) PauseContainer(ctx context.Context) error {
+	return tn.containerLifecycle.PauseContainer(ctx)
+}
+
+// UnpauseContainer resumes the node's container paused by PauseContainer.
+func (tn *ChainNode) UnpauseContainer(ctx context.Context) error {
+	return tn.containerLifecycle.UnpauseContainer(ctx)
+}
+
 func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
+	if err := tn.closeGRPCConn(); err != nil {
+		return err
+	}
 	return tn.containerLifecycle.RemoveContainer(ctx)
 }
 
@@ -1024,7 +1251,7 @@ func (tn *ChainNode) NodeID(ctx context.Context) (string, error) {
 	// This used to call p2p.LoadNodeKey against the file on the host,
 	// but because we are transitioning to operating on Docker volumes,
 	// we only have to tmjson.Unmarshal the raw content.
-	j, err := tn.ReadFile(ctx, "config/node_key.json")
+	j, err := tn.ReadFile(ctx, nodeKeyFile)
 	if err != nil {
 		return "", fmt.Errorf("getting node_key.json content: %w", err)
 	}
@@ -1042,7 +1269,7 @@ func (tn *ChainNode) NodeID(ctx context.Context) (string, error) {
 func (tn *ChainNode) KeyBech32(ctx context.Context, name string, bech string) (string, error) {
 	command := []string{tn.Chain.Config().Bin, "keys", "show", "--address", name,
 		"--home", tn.HomeDir(),
-		"--keyring-backend", keyring.BackendTest,
+		"--keyring-backend", tn.keyringBackend(),
 	}
 
 	if bech != "" {
@@ -1105,6 +1332,7 @@ func (nodes ChainNodes) logger() *zap.Logger {
 }
 
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
+	cmd, env = tn.keyringPromptCommand(cmd, env)
 	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
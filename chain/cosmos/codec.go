@@ -7,10 +7,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/capability"
 	"github.com/cosmos/cosmos-sdk/x/consensus"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	feegrantmodule "github.com/cosmos/cosmos-sdk/x/feegrant/module"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
@@ -31,6 +33,7 @@ import (
 func DefaultEncoding() testutil.TestEncodingConfig {
 	return testutil.MakeTestEncodingConfig(
 		auth.AppModuleBasic{},
+		vesting.AppModuleBasic{},
 		genutil.NewAppModuleBasic(genutiltypes.DefaultMessageValidator),
 		bank.AppModuleBasic{},
 		capability.AppModuleBasic{},
@@ -46,6 +49,7 @@ func DefaultEncoding() testutil.TestEncodingConfig {
 		),
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
+		feegrantmodule.AppModuleBasic{},
 		upgrade.AppModuleBasic{},
 		consensus.AppModuleBasic{},
 		transfer.AppModuleBasic{},
@@ -63,3 +67,25 @@ func encodeTxToJSON(interfaceRegistry codectypes.InterfaceRegistry, tx sdk.Tx) (
 	cdc := codec.NewProtoCodec(interfaceRegistry)
 	return authTx.DefaultJSONTxEncoder(cdc)(tx)
 }
+
+// TxDecoder decodes raw transactions recorded in the block database into JSON.
+// It satisfies the block database's TxDecoder interface.
+type TxDecoder struct {
+	interfaceRegistry codectypes.InterfaceRegistry
+}
+
+// NewTxDecoder returns a TxDecoder using interfaceRegistry,
+// which should have the message types of any custom modules registered,
+// e.g. the InterfaceRegistry of DefaultEncoding after calling RegisterInterfaces of each custom module.
+func NewTxDecoder(interfaceRegistry codectypes.InterfaceRegistry) TxDecoder {
+	return TxDecoder{interfaceRegistry: interfaceRegistry}
+}
+
+// DecodeTx decodes the protobuf encoded tx into JSON.
+func (d TxDecoder) DecodeTx(raw []byte) ([]byte, error) {
+	tx, err := decodeTX(d.interfaceRegistry, raw)
+	if err != nil {
+		return nil, err
+	}
+	return encodeTxToJSON(d.interfaceRegistry, tx)
+}
@@ -0,0 +1,35 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxDecoder(t *testing.T) {
+	t.Parallel()
+
+	enc := cosmos.DefaultEncoding()
+
+	builder := enc.TxConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(&banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 7)),
+	}))
+	builder.SetMemo("hello")
+
+	raw, err := enc.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	got, err := cosmos.NewTxDecoder(enc.InterfaceRegistry).DecodeTx(raw)
+	require.NoError(t, err)
+	require.Contains(t, string(got), `"@type":"/cosmos.bank.v1beta1.MsgSend"`)
+	require.Contains(t, string(got), `"memo":"hello"`)
+
+	_, err = cosmos.NewTxDecoder(enc.InterfaceRegistry).DecodeTx([]byte("not a tx"))
+	require.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+const (
+	appTomlFile    = "config/app.toml"
+	configTomlFile = "config/config.toml"
+)
+
+var (
+	_ ibc.ConfigFileOverride = AppTomlOverrides{}
+	_ ibc.ConfigFileOverride = ConfigTomlOverrides{}
+)
+
+// AppTomlOverrides overrides settings of the app.toml of nodes, set with ChainConfig.TypedConfigFileOverrides.
+// Nil fields are left unchanged.
+type AppTomlOverrides struct {
+	MinGasPrices *string
+	HaltHeight   *uint64
+	API          *APITomlOverrides
+	GRPC         *GRPCTomlOverrides
+	Telemetry    *TelemetryTomlOverrides
+	// Extra overrides other keys, like ChainConfig.ConfigFileOverrides; the keys must exist in app.toml.
+	Extra testutil.Toml
+}
+
+// APITomlOverrides overrides settings of the REST API server in app.toml.
+type APITomlOverrides struct {
+	Enable            *bool
+	Address           *string
+	EnabledUnsafeCORS *bool
+}
+
+// GRPCTomlOverrides overrides settings of the gRPC server in app.toml.
+type GRPCTomlOverrides struct {
+	Enable  *bool
+	Address *string
+}
+
+// TelemetryTomlOverrides overrides settings of telemetry in app.toml.
+type TelemetryTomlOverrides struct {
+	Enabled                 *bool
+	PrometheusRetentionTime *int64
+}
+
+// ConfigFile implements ibc.ConfigFileOverride.
+func (o AppTomlOverrides) ConfigFile() string {
+	return appTomlFile
+}
+
+// TomlOverrides implements ibc.ConfigFileOverride.
+func (o AppTomlOverrides) TomlOverrides() map[string]any {
+	t := mergeToml(make(testutil.Toml), o.Extra)
+	setToml(t, "minimum-gas-prices", o.MinGasPrices)
+	setToml(t, "halt-height", o.HaltHeight)
+	if o.API != nil {
+		api := make(testutil.Toml)
+		setToml(api, "enable", o.API.Enable)
+		setToml(api, "address", o.API.Address)
+		setToml(api, "enabled-unsafe-cors", o.API.EnabledUnsafeCORS)
+		setTomlTable(t, "api", api)
+	}
+	if o.GRPC != nil {
+		grpc := make(testutil.Toml)
+		setToml(grpc, "enable", o.GRPC.Enable)
+		setToml(grpc, "address", o.GRPC.Address)
+		setTomlTable(t, "grpc", grpc)
+	}
+	if o.Telemetry != nil {
+		telemetry := make(testutil.Toml)
+		setToml(telemetry, "enabled", o.Telemetry.Enabled)
+		setToml(telemetry, "prometheus-retention-time", o.Telemetry.PrometheusRetentionTime)
+		setTomlTable(t, "telemetry", telemetry)
+	}
+	return t
+}
+
+// ConfigTomlOverrides overrides settings of the config.toml of nodes, set with ChainConfig.TypedConfigFileOverrides.
+// Nil fields are left unchanged.
+type ConfigTomlOverrides struct {
+	LogLevel  *string
+	Consensus *ConsensusTomlOverrides
+	P2P       *P2PTomlOverrides
+	RPC       *RPCTomlOverrides
+	Mempool   *MempoolTomlOverrides
+	// Extra overrides other keys, like ChainConfig.ConfigFileOverrides; the keys must exist in config.toml.
+	Extra testutil.Toml
+}
+
+// ConsensusTomlOverrides overrides consensus timeouts in config.toml.
+type ConsensusTomlOverrides struct {
+	TimeoutPropose *time.Duration
+	TimeoutCommit  *time.Duration
+}
+
+// P2PTomlOverrides overrides peer to peer settings in config.toml.
+type P2PTomlOverrides struct {
+	Seeds               *string
+	MaxNumInboundPeers  *int64
+	MaxNumOutboundPeers *int64
+}
+
+// RPCTomlOverrides overrides settings of the RPC server in config.toml.
+type RPCTomlOverrides struct {
+	CORSAllowedOrigins []string
+	MaxBodyBytes       *int64
+}
+
+// MempoolTomlOverrides overrides mempool settings in config.toml.
+type MempoolTomlOverrides struct {
+	Size       *int64
+	MaxTxBytes *int64
+}
+
+// ConfigFile implements ibc.ConfigFileOverride.
+func (o ConfigTomlOverrides) ConfigFile() string {
+	return configTomlFile
+}
+
+// TomlOverrides implements ibc.ConfigFileOverride.
+func (o ConfigTomlOverrides) TomlOverrides() map[string]any {
+	t := mergeToml(make(testutil.Toml), o.Extra)
+	setToml(t, "log_level", o.LogLevel)
+	if o.Consensus != nil {
+		consensus := make(testutil.Toml)
+		setTomlDuration(consensus, "timeout_propose", o.Consensus.TimeoutPropose)
+		setTomlDuration(consensus, "timeout_commit", o.Consensus.TimeoutCommit)
+		setTomlTable(t, "consensus", consensus)
+	}
+	if o.P2P != nil {
+		p2p := make(testutil.Toml)
+		setToml(p2p, "seeds", o.P2P.Seeds)
+		setToml(p2p, "max_num_inbound_peers", o.P2P.MaxNumInboundPeers)
+		setToml(p2p, "max_num_outbound_peers", o.P2P.MaxNumOutboundPeers)
+		setTomlTable(t, "p2p", p2p)
+	}
+	if o.RPC != nil {
+		rpc := make(testutil.Toml)
+		if o.RPC.CORSAllowedOrigins != nil {
+			rpc["cors_allowed_origins"] = o.RPC.CORSAllowedOrigins
+		}
+		setToml(rpc, "max_body_bytes", o.RPC.MaxBodyBytes)
+		setTomlTable(t, "rpc", rpc)
+	}
+	if o.Mempool != nil {
+		mempool := make(testutil.Toml)
+		setToml(mempool, "size", o.Mempool.Size)
+		setToml(mempool, "max_tx_bytes", o.Mempool.MaxTxBytes)
+		setTomlTable(t, "mempool", mempool)
+	}
+	return t
+}
+
+// setToml sets key of t to *v, if v is not nil.
+func setToml[T any](t testutil.Toml, key string, v *T) {
+	if v != nil {
+		t[key] = *v
+	}
+}
+
+// setTomlDuration sets key of t to *d, formatted like durations in config.toml, e.g. "1s", if d is not nil.
+func setTomlDuration(t testutil.Toml, key string, d *time.Duration) {
+	if d != nil {
+		t[key] = d.String()
+	}
+}
+
+// setTomlTable merges the table into the table at key of t, if it has any keys.
+func setTomlTable(t testutil.Toml, key string, table testutil.Toml) {
+	if len(table) == 0 {
+		return
+	}
+	if existing, ok := t[key].(testutil.Toml); ok {
+		table = mergeToml(existing, table)
+	}
+	t[key] = table
+}
+
+// overrideConfigFiles applies configFileOverrides, keyed by file path, to the node's config files,
+// followed by the chain's TypedConfigFileOverrides.
+func (tn *ChainNode) overrideConfigFiles(ctx context.Context, configFileOverrides map[string]any) error {
+	for configFile, modifiedConfig := range configFileOverrides {
+		modifiedToml, ok := modifiedConfig.(testutil.Toml)
+		if !ok {
+			return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
+		}
+		if err := testutil.ModifyTomlConfigFile(
+			ctx,
+			tn.logger(),
+			tn.DockerClient,
+			tn.TestName,
+			tn.VolumeName,
+			configFile,
+			modifiedToml,
+		); err != nil {
+			return err
+		}
+	}
+	for _, o := range tn.Chain.Config().TypedConfigFileOverrides {
+		if err := testutil.ModifyExistingTomlConfigFile(
+			ctx,
+			tn.logger(),
+			tn.DockerClient,
+			tn.TestName,
+			tn.VolumeName,
+			o.ConfigFile(),
+			testutil.Toml(o.TomlOverrides()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
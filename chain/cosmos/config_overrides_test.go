@@ -0,0 +1,48 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppTomlOverrides(t *testing.T) {
+	t.Parallel()
+
+	gasPrices := "0.01uatom"
+	enable := true
+	o := AppTomlOverrides{
+		MinGasPrices: &gasPrices,
+		API:          &APITomlOverrides{Enable: &enable},
+		GRPC:         &GRPCTomlOverrides{},
+		Extra:        testutil.Toml{"api": testutil.Toml{"swagger": true}, "index-events": []string{"tx.height"}},
+	}
+	require.Equal(t, "config/app.toml", o.ConfigFile())
+	require.Equal(t, map[string]any{
+		"minimum-gas-prices": "0.01uatom",
+		"api":                testutil.Toml{"enable": true, "swagger": true},
+		"index-events":       []string{"tx.height"},
+	}, o.TomlOverrides())
+
+	require.Empty(t, AppTomlOverrides{}.TomlOverrides())
+}
+
+func TestConfigTomlOverrides(t *testing.T) {
+	t.Parallel()
+
+	commit := 500 * time.Millisecond
+	size := int64(1000)
+	o := ConfigTomlOverrides{
+		Consensus: &ConsensusTomlOverrides{TimeoutCommit: &commit},
+		RPC:       &RPCTomlOverrides{CORSAllowedOrigins: []string{"*"}},
+		Mempool:   &MempoolTomlOverrides{Size: &size},
+	}
+	require.Equal(t, "config/config.toml", o.ConfigFile())
+	require.Equal(t, map[string]any{
+		"consensus": testutil.Toml{"timeout_commit": "500ms"},
+		"rpc":       testutil.Toml{"cors_allowed_origins": []string{"*"}},
+		"mempool":   testutil.Toml{"size": int64(1000)},
+	}, o.TomlOverrides())
+}
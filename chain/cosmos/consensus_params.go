@@ -0,0 +1,44 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// setGenesisConsensusParams sets the consensus params of cfg in the genesis genbz.
+// Params are encoded as strings, as Tendermint encodes int64 values in JSON.
+func setGenesisConsensusParams(genbz []byte, cfg *ibc.ConsensusConfig) ([]byte, error) {
+	g := make(map[string]interface{})
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	params := []struct {
+		section, key string
+		value        int64
+	}{
+		{"block", "max_gas", cfg.BlockMaxGas},
+		{"block", "max_bytes", cfg.BlockMaxBytes},
+		{"evidence", "max_age_num_blocks", cfg.EvidenceMaxAgeNumBlocks},
+		{"evidence", "max_age_duration", cfg.EvidenceMaxAgeDuration.Nanoseconds()},
+		{"evidence", "max_bytes", cfg.EvidenceMaxBytes},
+	}
+	for _, p := range params {
+		if p.value == 0 {
+			continue
+		}
+		if err := dyno.Set(g, strconv.FormatInt(p.value, 10), "consensus_params", p.section, p.key); err != nil {
+			return nil, fmt.Errorf("failed to set consensus param %s.%s in genesis json: %w", p.section, p.key, err)
+		}
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,36 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGenesisConsensusParams(t *testing.T) {
+	t.Parallel()
+
+	genbz := []byte(`{"chain_id":"test-1","consensus_params":{
+		"block":{"max_bytes":"22020096","max_gas":"-1"},
+		"evidence":{"max_age_num_blocks":"100000","max_age_duration":"172800000000000","max_bytes":"1048576"},
+		"validator":{"pub_key_types":["ed25519"]}
+	}}`)
+
+	out, err := setGenesisConsensusParams(genbz, &ibc.ConsensusConfig{
+		BlockMaxGas:            100_000_000,
+		EvidenceMaxAgeDuration: time.Hour,
+		EvidenceMaxBytes:       2048,
+		TimeoutCommit:          time.Second,
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"chain_id":"test-1","consensus_params":{
+		"block":{"max_bytes":"22020096","max_gas":"100000000"},
+		"evidence":{"max_age_num_blocks":"100000","max_age_duration":"3600000000000","max_bytes":"2048"},
+		"validator":{"pub_key_types":["ed25519"]}
+	}}`, string(out))
+
+	out, err = setGenesisConsensusParams(genbz, &ibc.ConsensusConfig{})
+	require.NoError(t, err)
+	require.JSONEq(t, string(genbz), string(out))
+}
@@ -0,0 +1,119 @@
+package cosmos
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ContractTxResponse is a committed tx executing or migrating a contract, returned by ExecuteContractTx and MigrateContractTx.
+type ContractTxResponse struct {
+	TxHash  string
+	Height  int64
+	GasUsed int64
+	// Data returned by the contract, e.g. set with set_data of its response. Empty if the contract returned none.
+	Data []byte
+	// Events of the tx, including the wasm events emitted by the contract.
+	Events []abcitypes.Event
+}
+
+// DecodeData decodes the JSON data returned by the contract into v.
+func (r *ContractTxResponse) DecodeData(v any) error {
+	if len(r.Data) == 0 {
+		return fmt.Errorf("contract returned no data in tx %s", r.TxHash)
+	}
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return fmt.Errorf("decode data returned by contract in tx %s: %w", r.TxHash, err)
+	}
+	return nil
+}
+
+// newContractTxResponse returns the ContractTxResponse of the committed tx txResp,
+// whose single message is a contract message like MsgExecuteContract.
+func newContractTxResponse(txResp *types.TxResponse) (*ContractTxResponse, error) {
+	data, err := contractTxData(txResp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decode data of tx %s: %w", txResp.TxHash, err)
+	}
+	return &ContractTxResponse{
+		TxHash:  txResp.TxHash,
+		Height:  txResp.Height,
+		GasUsed: txResp.GasUsed,
+		Data:    data,
+		Events:  txResp.Events,
+	}, nil
+}
+
+// contractTxData returns the data returned by the contract from the hex encoded TxMsgData txData of a tx.
+func contractTxData(txData string) ([]byte, error) {
+	bz, err := hex.DecodeString(txData)
+	if err != nil {
+		return nil, err
+	}
+	var msgData types.TxMsgData
+	if err := msgData.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	// The msg responses replace the deprecated msg data since v0.46.
+	var res []byte
+	switch {
+	case len(msgData.MsgResponses) > 0:
+		res = msgData.MsgResponses[0].Value
+	case len(msgData.Data) > 0:
+		res = msgData.Data[0].Data
+	default:
+		return nil, errors.New("tx has no msg responses")
+	}
+	return contractMsgResponseData(res)
+}
+
+// contractMsgResponseData returns the data field of a contract's msg response, like MsgExecuteContractResponse
+// and MsgMigrateContractResponse, which both have the data as field 1.
+func contractMsgResponseData(bz []byte) ([]byte, error) {
+	var data []byte
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		if num == 1 && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(bz)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			data = append([]byte(nil), v...)
+			bz = bz[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, bz)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bz = bz[n:]
+	}
+	return data, nil
+}
+
+// unwrapContractResponse decodes the contract's response of the smart query response bz into response,
+// without the data field wrapping it.
+func unwrapContractResponse(contractAddress string, bz []byte, response any) error {
+	var res struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(bz, &res); err != nil {
+		return fmt.Errorf("decode query response of contract %s: %w", contractAddress, err)
+	}
+	if len(res.Data) == 0 || string(res.Data) == "null" {
+		return fmt.Errorf("query response of contract %s has no data", contractAddress)
+	}
+	if err := json.Unmarshal(res.Data, response); err != nil {
+		return fmt.Errorf("decode response of contract %s: %w", contractAddress, err)
+	}
+	return nil
+}
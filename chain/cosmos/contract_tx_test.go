@@ -0,0 +1,73 @@
+package cosmos
+
+import (
+	"encoding/hex"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestUnwrapContractResponse(t *testing.T) {
+	t.Parallel()
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	require.NoError(t, unwrapContractResponse("cosmos1contract", []byte(`{"data": {"count": 3}}`), &res))
+	require.Equal(t, 3, res.Count)
+
+	for _, bz := range []string{`{}`, `{"data": null}`} {
+		require.EqualError(t, unwrapContractResponse("cosmos1contract", []byte(bz), &res),
+			"query response of contract cosmos1contract has no data")
+	}
+	require.ErrorContains(t, unwrapContractResponse("cosmos1contract", []byte(`{"data": "3"}`), &res),
+		"decode response of contract cosmos1contract")
+	require.ErrorContains(t, unwrapContractResponse("cosmos1contract", []byte(`Error: not found`), &res),
+		"decode query response of contract cosmos1contract")
+}
+
+func TestContractTxData(t *testing.T) {
+	t.Parallel()
+
+	// A MsgExecuteContractResponse with data, preceded by an unknown field.
+	var msgRes []byte
+	msgRes = protowire.AppendTag(msgRes, 2, protowire.VarintType)
+	msgRes = protowire.AppendVarint(msgRes, 7)
+	msgRes = protowire.AppendTag(msgRes, 1, protowire.BytesType)
+	msgRes = protowire.AppendBytes(msgRes, []byte(`{"count":3}`))
+
+	txData := func(msgData types.TxMsgData) string {
+		bz, err := msgData.Marshal()
+		require.NoError(t, err)
+		return hex.EncodeToString(bz)
+	}
+
+	data, err := contractTxData(txData(types.TxMsgData{
+		MsgResponses: []*codectypes.Any{{TypeUrl: "/cosmwasm.wasm.v1.MsgExecuteContractResponse", Value: msgRes}},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, `{"count":3}`, string(data))
+
+	// Chains before v0.46 return the deprecated msg data.
+	data, err = contractTxData(txData(types.TxMsgData{
+		Data: []*types.MsgData{{MsgType: "/cosmwasm.wasm.v1.MsgExecuteContract", Data: msgRes}},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, `{"count":3}`, string(data))
+
+	// Contracts returning no data.
+	data, err = contractTxData(txData(types.TxMsgData{
+		MsgResponses: []*codectypes.Any{{TypeUrl: "/cosmwasm.wasm.v1.MsgExecuteContractResponse"}},
+	}))
+	require.NoError(t, err)
+	require.Empty(t, data)
+	res := &ContractTxResponse{TxHash: "ABCD", Data: data}
+	var v struct{}
+	require.EqualError(t, res.DecodeData(&v), "contract returned no data in tx ABCD")
+
+	_, err = contractTxData(txData(types.TxMsgData{}))
+	require.EqualError(t, err, "tx has no msg responses")
+}
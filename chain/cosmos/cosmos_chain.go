@@ -9,18 +9,16 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/avast/retry-go/v4"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
-	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
 	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
 	dockertypes "github.com/docker/docker/api/types"
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -32,8 +30,6 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // CosmosChain is a local docker testnet for a Cosmos SDK chain.
@@ -49,6 +45,20 @@ type CosmosChain struct {
 	log      *zap.Logger
 	keyring  keyring.Keyring
 	findTxMu sync.Mutex
+
+	sdkVersionMu sync.Mutex
+	sdkVersion   *SDKVersion
+
+	tokenFactoryMu      sync.Mutex
+	tokenFactoryVariant TokenFactoryVariant
+
+	// Sidecar serving the Rosetta API, if configured by RosettaConfig.Sidecar.
+	rosetta *rosettaSidecar
+
+	// Provider of the chain, if it is an Interchain Security consumer chain, validated by the provider's validators.
+	Provider *CosmosChain
+	// Consumers of the chain, if it is an Interchain Security provider chain.
+	Consumers []*CosmosChain
 }
 
 func NewCosmosHeighlinerChainConfig(name string,
@@ -84,10 +94,17 @@ func NewCosmosChain(testName string, chainConfig ibc.ChainConfig, numValidators
 		chainConfig.EncodingConfig = &cfg
 	}
 
+	if chainConfig.SigningAlgorithm == ibc.SigningAlgorithmEthSecp256k1 {
+		registerEthSecp256k1(chainConfig.EncodingConfig.InterfaceRegistry)
+	}
+
 	registry := codectypes.NewInterfaceRegistry()
 	cryptocodec.RegisterInterfaces(registry)
+	registerEthSecp256k1(registry)
 	cdc := codec.NewProtoCodec(registry)
-	kr := keyring.NewInMemory(cdc)
+	kr := keyring.NewInMemory(cdc, func(options *keyring.Options) {
+		options.SupportedAlgos = append(options.SupportedAlgos, ethSecp256k1Algo)
+	})
 
 	return &CosmosChain{
 		testName:      testName,
@@ -106,59 +123,119 @@ func (c *CosmosChain) Nodes() ChainNodes {
 
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
+	_, err := c.addFullNodes(ctx, configFileOverrides, inc, nil)
+	return err
+}
+
+// addFullNodes adds inc fullnodes to the network and returns them.
+// If set, prepare is called with each node after its files are set up, before its container is created.
+func (c *CosmosChain) addFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int, prepare func(context.Context, *ChainNode) error) (ChainNodes, error) {
 	// Get peer string for existing nodes
 	peers := c.Nodes().PeerString(ctx)
 
 	// Get genesis.json
 	genbz, err := c.Validators[0].genesisFileContent(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	prevCount := c.numFullNodes
 	c.numFullNodes += inc
 	if err := c.initializeChainNodes(ctx, c.testName, c.getFullNode().DockerClient, c.getFullNode().NetworkID); err != nil {
-		return err
+		return nil, err
 	}
 
 	var eg errgroup.Group
 	for i := prevCount; i < c.numFullNodes; i++ {
-		i := i
+		fn := c.FullNodes[i]
 		eg.Go(func() error {
-			fn := c.FullNodes[i]
-			if err := fn.InitFullNodeFiles(ctx); err != nil {
-				return err
-			}
-			if err := fn.SetPeers(ctx, peers); err != nil {
-				return err
-			}
-			if err := fn.overwriteGenesisFile(ctx, genbz); err != nil {
-				return err
-			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					fn.logger(),
-					fn.DockerClient,
-					fn.TestName,
-					fn.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
-			}
-			if err := fn.CreateNodeContainer(ctx); err != nil {
-				return err
-			}
-			return fn.StartContainer(ctx)
+			return c.startAddedNode(ctx, fn, peers, genbz, configFileOverrides, prepare)
 		})
 	}
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return c.FullNodes[prevCount:], nil
+}
+
+// RemoveFullNode stops the fullnode at index i of FullNodes, removes its container and volume, and removes it from FullNodes.
+// The remaining nodes are reconfigured to no longer peer with it, from their next restart.
+func (c *CosmosChain) RemoveFullNode(ctx context.Context, i int) error {
+	if i < 0 || i >= len(c.FullNodes) {
+		return fmt.Errorf("fullnode index %d is out of range of the %d fullnodes", i, len(c.FullNodes))
+	}
+	fn := c.FullNodes[i]
+
+	if err := fn.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop fullnode %s: %w", fn.Name(), err)
+	}
+	if err := fn.RemoveContainer(ctx); err != nil {
+		return fmt.Errorf("remove fullnode %s: %w", fn.Name(), err)
+	}
+	if err := fn.DockerClient.VolumeRemove(ctx, fn.VolumeName, true); err != nil {
+		return fmt.Errorf("remove volume of fullnode %s: %w", fn.Name(), err)
+	}
+
+	c.findTxMu.Lock()
+	c.FullNodes = append(c.FullNodes[:i:i], c.FullNodes[i+1:]...)
+	c.numFullNodes--
+	c.findTxMu.Unlock()
+
+	nodes := c.Nodes()
+	peers := nodes.PeerString(ctx)
+	for _, n := range nodes {
+		if err := n.SetPeers(ctx, peers); err != nil {
+			return fmt.Errorf("set peers of %s: %w", n.Name(), err)
+		}
+	}
+	return nil
+}
+
+// RestartFullNode stops the fullnode at index i of FullNodes, reconfigures it to peer with the chain's current nodes,
+// then starts it again, returning once it has caught up with the chain.
+func (c *CosmosChain) RestartFullNode(ctx context.Context, i int) error {
+	if i < 0 || i >= len(c.FullNodes) {
+		return fmt.Errorf("fullnode index %d is out of range of the %d fullnodes", i, len(c.FullNodes))
+	}
+	fn := c.FullNodes[i]
+
+	if err := fn.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop fullnode %s: %w", fn.Name(), err)
+	}
+	if err := fn.SetPeers(ctx, c.Nodes().PeerString(ctx)); err != nil {
+		return fmt.Errorf("set peers of fullnode %s: %w", fn.Name(), err)
+	}
+	if err := fn.StartContainer(ctx); err != nil {
+		return fmt.Errorf("start fullnode %s: %w", fn.Name(), err)
+	}
+	return nil
+}
+
+// startAddedNode sets up the files of node n, added to the running chain, with the chain's genesis genbz,
+// peering with peers, then creates and starts its container.
+// If set, prepare is called with the node after its files are set up, before its container is created.
+func (c *CosmosChain) startAddedNode(ctx context.Context, n *ChainNode, peers string, genbz []byte, configFileOverrides map[string]any, prepare func(context.Context, *ChainNode) error) error {
+	if err := n.InitFullNodeFiles(ctx); err != nil {
+		return err
+	}
+	if err := n.SetPeers(ctx, peers); err != nil {
+		return err
+	}
+	if err := n.overwriteGenesisFile(ctx, genbz); err != nil {
+		return err
+	}
+	if err := n.overrideConfigFiles(ctx, configFileOverrides); err != nil {
+		return err
+	}
+	if prepare != nil {
+		if err := prepare(ctx, n); err != nil {
+			return err
+		}
+	}
+	if err := n.CreateNodeContainer(ctx); err != nil {
+		return err
+	}
+	return n.StartContainer(ctx)
 }
 
 // Implements Chain interface
@@ -168,6 +245,24 @@ func (c *CosmosChain) Config() ibc.ChainConfig {
 
 // Implements Chain interface
 func (c *CosmosChain) Initialize(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+	if err := validateSigningAlgorithm(c.cfg.SigningAlgorithm); err != nil {
+		return err
+	}
+	if err := validateKeyringConfig(c.cfg.Keyring); err != nil {
+		return err
+	}
+	if err := validateValidatorKeys(c.cfg.ValidatorKeys, c.numValidators); err != nil {
+		return err
+	}
+	if err := validateRosettaConfig(c.cfg.Rosetta); err != nil {
+		return err
+	}
+	if err := validateGenesisAccounts(c.cfg); err != nil {
+		return err
+	}
+	if err := validateStartedValidators(c.cfg, c.numValidators); err != nil {
+		return err
+	}
 	return c.initializeChainNodes(ctx, testName, cli, networkID)
 }
 
@@ -209,6 +304,12 @@ func (c *CosmosChain) GetHostGRPCAddress() string {
 	return c.getFullNode().hostGRPCPort
 }
 
+// GetHostAPIAddress returns the address of the REST API server, enabled by ChainConfig.EnableAPI, accessible by the host.
+// This will not return a valid address until the chain has been started.
+func (c *CosmosChain) GetHostAPIAddress() string {
+	return "http://" + c.getFullNode().hostAPIPort
+}
+
 // HomeDir implements ibc.Chain.
 func (c *CosmosChain) HomeDir() string {
 	return c.getFullNode().HomeDir()
@@ -270,7 +371,7 @@ func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (i
 		keyring.English,
 		hd.CreateHDPath(uint32(coinType), 0, 0).String(),
 		"", // Empty passphrase.
-		hd.Secp256k1,
+		signingAlgo(c.cfg),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create mnemonic: %w", err)
@@ -301,6 +402,11 @@ func (c *CosmosChain) SendIBCTransfer(
 	if err != nil {
 		return tx, fmt.Errorf("send ibc transfer: %w", err)
 	}
+	return c.packetTx(txHash)
+}
+
+// packetTx returns the tx with txHash, which sent a packet, with the packet parsed from its events.
+func (c *CosmosChain) packetTx(txHash string) (tx ibc.Tx, _ error) {
 	txResp, err := c.getTransaction(txHash)
 	if err != nil {
 		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
@@ -416,11 +522,35 @@ func (c *CosmosChain) ExecuteContract(ctx context.Context, keyName string, contr
 	return c.getFullNode().ExecuteContract(ctx, keyName, contractAddress, message)
 }
 
+// ExecuteContractTx executes a contract transaction with a message using it's address,
+// and returns the committed tx with the data returned by the contract.
+func (c *CosmosChain) ExecuteContractTx(ctx context.Context, keyName string, contractAddress string, message string) (*ContractTxResponse, error) {
+	return c.getFullNode().ExecuteContractTx(ctx, keyName, contractAddress, message)
+}
+
+// MigrateContract migrates a contract to the code with codeID, calling its migrate entry point with message.
+// The key must be the contract's admin.
+func (c *CosmosChain) MigrateContract(ctx context.Context, keyName string, contractAddress string, codeID string, message string) error {
+	return c.getFullNode().MigrateContract(ctx, keyName, contractAddress, codeID, message)
+}
+
+// MigrateContractTx migrates a contract like MigrateContract,
+// and returns the committed tx with the data returned by the contract's migrate entry point.
+func (c *CosmosChain) MigrateContractTx(ctx context.Context, keyName string, contractAddress string, codeID string, message string) (*ContractTxResponse, error) {
+	return c.getFullNode().MigrateContractTx(ctx, keyName, contractAddress, codeID, message)
+}
+
 // QueryContract performs a smart query, taking in a query struct and returning a error with the response struct populated.
 func (c *CosmosChain) QueryContract(ctx context.Context, contractAddress string, query any, response any) error {
 	return c.getFullNode().QueryContract(ctx, contractAddress, query, response)
 }
 
+// QueryContractSmart performs a smart query, taking in a query struct and populating response with the response of the contract.
+// Unlike QueryContract, response is the contract's response, rather than the query response wrapping it in a data field.
+func (c *CosmosChain) QueryContractSmart(ctx context.Context, contractAddress string, query any, response any) error {
+	return c.getFullNode().QueryContractSmart(ctx, contractAddress, query, response)
+}
+
 // DumpContractState dumps the state of a contract at a block height.
 func (c *CosmosChain) DumpContractState(ctx context.Context, contractAddress string, height int64) (*DumpContractStateResponse, error) {
 	return c.getFullNode().DumpContractState(ctx, contractAddress, height)
@@ -446,12 +576,10 @@ func (c *CosmosChain) ExportState(ctx context.Context, height int64) (string, er
 // Implements Chain interface
 func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
 	params := &bankTypes.QueryBalanceRequest{Address: address, Denom: denom}
-	grpcAddress := c.getFullNode().hostGRPCPort
-	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := c.GRPCConn(ctx)
 	if err != nil {
 		return 0, err
 	}
-	defer conn.Close()
 
 	queryClient := bankTypes.NewQueryClient(conn)
 	res, err := queryClient.Balance(ctx, params)
@@ -466,12 +594,10 @@ func (c *CosmosChain) GetBalance(ctx context.Context, address string, denom stri
 // AllBalances fetches an account address's balance for all denoms it holds
 func (c *CosmosChain) AllBalances(ctx context.Context, address string) (types.Coins, error) {
 	params := bankTypes.QueryAllBalancesRequest{Address: address}
-	grpcAddress := c.getFullNode().hostGRPCPort
-	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := c.GRPCConn(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	queryClient := bankTypes.NewQueryClient(conn)
 	res, err := queryClient.AllBalances(ctx, &params)
@@ -484,20 +610,7 @@ func (c *CosmosChain) AllBalances(ctx context.Context, address string) (types.Co
 }
 
 func (c *CosmosChain) getTransaction(txHash string) (*types.TxResponse, error) {
-	// Retry because sometimes the tx is not committed to state yet.
-	var txResp *types.TxResponse
-	err := retry.Do(func() error {
-		var err error
-		txResp, err = authTx.QueryTx(c.getFullNode().CliContext(), txHash)
-		return err
-	},
-		// retry for total of 3 seconds
-		retry.Attempts(15),
-		retry.Delay(200*time.Millisecond),
-		retry.DelayType(retry.FixedDelay),
-		retry.LastErrorOnly(true),
-	)
-	return txResp, err
+	return c.getFullNode().getTransaction(txHash)
 }
 
 func (c *CosmosChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
@@ -596,11 +709,15 @@ func (c *CosmosChain) initializeChainNodes(
 	newFullNodes := make(ChainNodes, c.numFullNodes)
 	copy(newFullNodes, c.FullNodes)
 
+	// Removed nodes leave gaps in the indexes; new nodes continue after the last one, so names do not collide.
+	valIndex, fnIndex := nextNodeIndex(c.Validators), nextNodeIndex(c.FullNodes)
+
 	eg, egCtx := errgroup.WithContext(ctx)
 	for i := len(c.Validators); i < c.numValidators; i++ {
 		i := i
+		index := valIndex + i - len(c.Validators)
 		eg.Go(func() error {
-			val, err := c.NewChainNode(egCtx, testName, cli, networkID, image, true, i)
+			val, err := c.NewChainNode(egCtx, testName, cli, networkID, image, true, index)
 			if err != nil {
 				return err
 			}
@@ -610,8 +727,9 @@ func (c *CosmosChain) initializeChainNodes(
 	}
 	for i := len(c.FullNodes); i < c.numFullNodes; i++ {
 		i := i
+		index := fnIndex + i - len(c.FullNodes)
 		eg.Go(func() error {
-			fn, err := c.NewChainNode(egCtx, testName, cli, networkID, image, false, i)
+			fn, err := c.NewChainNode(egCtx, testName, cli, networkID, image, false, index)
 			if err != nil {
 				return err
 			}
@@ -629,6 +747,17 @@ func (c *CosmosChain) initializeChainNodes(
 	return nil
 }
 
+// nextNodeIndex returns the index after the highest index of nodes.
+func nextNodeIndex(nodes ChainNodes) int {
+	next := 0
+	for _, n := range nodes {
+		if n.Index >= next {
+			next = n.Index + 1
+		}
+	}
+	return next
+}
+
 type GenesisValidatorPubKey struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
@@ -651,6 +780,16 @@ type ValidatorWithIntPower struct {
 
 // Bootstraps the chain and starts it from genesis
 func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
+	accountWallets, err := genesisAccountWallets(c.cfg)
+	if err != nil {
+		return err
+	}
+	additionalGenesisWallets = append(additionalGenesisWallets, accountWallets...)
+
+	if c.Provider != nil {
+		return c.startConsumer(ctx, additionalGenesisWallets)
+	}
+
 	chainCfg := c.Config()
 
 	genesisAmount := types.Coin{
@@ -676,22 +815,15 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 			if err := v.InitFullNodeFiles(ctx); err != nil {
 				return err
 			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					v.logger(),
-					v.DockerClient,
-					v.TestName,
-					v.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
+			if err := v.overrideConfigFiles(ctx, configFileOverrides); err != nil {
+				return err
+			}
+			if err := v.importValidatorKeys(ctx); err != nil {
+				return err
+			}
+			if chainCfg.ExportedGenesis != nil {
+				// The validators take over validators of the exported genesis, rather than creating their own.
+				return v.CreateKey(ctx, valKey)
 			}
 			return v.InitValidatorGenTx(ctx, &chainCfg, genesisAmounts, genesisSelfDelegation)
 		})
@@ -705,22 +837,8 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 			if err := n.InitFullNodeFiles(ctx); err != nil {
 				return err
 			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					n.logger(),
-					n.DockerClient,
-					n.TestName,
-					n.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
+			if err := n.overrideConfigFiles(ctx, configFileOverrides); err != nil {
+				return err
 			}
 			return nil
 		})
@@ -731,42 +849,115 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
-	// for the validators we need to collect the gentxs and the accounts
-	// to the first node's genesis file
 	validator0 := c.Validators[0]
-	for i := 1; i < len(c.Validators); i++ {
-		validatorN := c.Validators[i]
+	additionalGenesisWallets, denomTraces := ibcGenesisWallets(additionalGenesisWallets)
+	addrs, coins := genesisAccountCoins(additionalGenesisWallets)
 
-		bech32, err := validatorN.AccountKeyBech32(ctx, valKey)
+	var genbz []byte
+	if chainCfg.ExportedGenesis != nil {
+		genbz, err = c.exportedGenesis(ctx, validator0, genesisAmounts, addrs, coins)
 		if err != nil {
 			return err
 		}
+	} else {
+		// for the validators we need to collect the gentxs and the accounts
+		// to the first node's genesis file
+		for i := 1; i < len(c.Validators); i++ {
+			validatorN := c.Validators[i]
+
+			bech32, err := validatorN.AccountKeyBech32(ctx, valKey)
+			if err != nil {
+				return err
+			}
+
+			if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
+				return err
+			}
+
+			if err := validatorN.copyGentx(ctx, validator0); err != nil {
+				return err
+			}
+		}
+
+		for _, addr := range addrs {
+			if err := validator0.AddGenesisAccount(ctx, addr, coins[addr]); err != nil {
+				return err
+			}
+		}
+
+		if err := validator0.CollectGentxs(ctx); err != nil {
+			return err
+		}
+
+		genbz, err = validator0.genesisFileContent(ctx)
+		if err != nil {
+			return err
+		}
+
+		genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
+	}
+
+	if err := c.startWithGenesis(ctx, genbz, additionalGenesisWallets, denomTraces); err != nil {
+		return err
+	}
 
-		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
+	if len(c.Consumers) > 0 {
+		return c.addConsumers(ctx)
+	}
+	return nil
+}
+
+// startWithGenesis applies the configured modifications to genbz, and starts all nodes of the chain with it.
+func (c *CosmosChain) startWithGenesis(ctx context.Context, genbz []byte, additionalGenesisWallets []ibc.WalletAmount, denomTraces []transfertypes.DenomTrace) error {
+	chainCfg := c.Config()
+	var err error
+
+	if len(denomTraces) > 0 {
+		traces := make([]any, len(denomTraces))
+		for i, trace := range denomTraces {
+			traces[i] = trace
+		}
+		genbz, err = ModifyGenesis(AppendToArray("app_state.transfer.denom_traces", traces...))(chainCfg, genbz)
+		if err != nil {
 			return err
 		}
+	}
 
-		if err := validatorN.copyGentx(ctx, validator0); err != nil {
+	if c.cfg.Consensus != nil {
+		genbz, err = setGenesisConsensusParams(genbz, c.cfg.Consensus)
+		if err != nil {
 			return err
 		}
 	}
 
-	for _, wallet := range additionalGenesisWallets {
-		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []types.Coin{{Denom: wallet.Denom, Amount: types.NewInt(wallet.Amount)}}); err != nil {
+	if c.cfg.FeeMarket != nil {
+		genbz, err = setGenesisFeeMarketParams(genbz, c.cfg)
+		if err != nil {
 			return err
 		}
 	}
 
-	if err := validator0.CollectGentxs(ctx); err != nil {
-		return err
+	if len(c.cfg.ModuleBalances) > 0 || c.cfg.CommunityPool != "" {
+		genbz, err = setGenesisModuleBalances(genbz, c.cfg)
+		if err != nil {
+			return err
+		}
 	}
 
-	genbz, err := validator0.genesisFileContent(ctx)
-	if err != nil {
-		return err
+	if hasVestingWallets(additionalGenesisWallets) {
+		genbz, err = setGenesisVestingAccounts(codec.NewProtoCodec(c.cfg.EncodingConfig.InterfaceRegistry), genbz, additionalGenesisWallets)
+		if err != nil {
+			return err
+		}
 	}
 
-	genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
+	if len(c.Consumers) > 0 {
+		// The consumer-addition proposals must pass in the test, which ModifyGenesis may still override.
+		genbz, err = setGenesisVotingPeriod(genbz, providerVotingPeriod)
+		if err != nil {
+			return err
+		}
+	}
 
 	if c.cfg.ModifyGenesis != nil {
 		genbz, err = c.cfg.ModifyGenesis(chainCfg, genbz)
@@ -814,11 +1005,15 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	eg, egCtx = errgroup.WithContext(ctx)
 	for _, n := range chainNodes {
 		n := n
-		c.log.Info("Starting container", zap.String("container", n.Name()))
 		eg.Go(func() error {
 			if err := n.SetPeers(egCtx, peers); err != nil {
 				return err
 			}
+			if n.startDeferred() {
+				// Started later with StartValidators.
+				return nil
+			}
+			c.log.Info("Starting container", zap.String("container", n.Name()))
 			return n.StartContainer(egCtx)
 		})
 	}
@@ -826,8 +1021,28 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	if started := startedValidators(c.cfg, len(c.Validators)); !hasQuorum(started, len(c.Validators)) {
+		c.log.Info("Started chain without quorum",
+			zap.String("chain", c.cfg.ChainID),
+			zap.Int("started_validators", started),
+			zap.Int("validators", len(c.Validators)),
+		)
+		return nil
+	}
+
 	// Wait for 5 blocks before considering the chains "started"
-	return testutil.WaitForBlocks(ctx, 5, c.getFullNode())
+	if err := testutil.WaitForBlocks(ctx, 5, c.getFullNode()); err != nil {
+		return err
+	}
+
+	switch {
+	case c.cfg.Rosetta == nil:
+		return nil
+	case c.cfg.Rosetta.Sidecar:
+		return c.startRosettaSidecar(ctx)
+	default:
+		return c.waitForRosetta(ctx)
+	}
 }
 
 // Height implements ibc.Chain
@@ -900,7 +1115,7 @@ func (c *CosmosChain) Timeouts(ctx context.Context, height uint64) ([]ibc.Packet
 	return ibcTimeouts, nil
 }
 
-// FindTxs implements blockdb.BlockSaver.
+// FindTxs implements blockdb.TxFinder.
 func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
 	fn := c.getFullNode()
 	c.findTxMu.Lock()
@@ -908,6 +1123,19 @@ func (c *CosmosChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx,
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlock implements blockdb.BlockFinder.
+func (c *CosmosChain) FindBlock(ctx context.Context, height uint64) (blockdb.Block, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlock(ctx, height)
+}
+
+// FindGenesis implements blockdb.GenesisFinder.
+func (c *CosmosChain) FindGenesis(ctx context.Context) ([]byte, error) {
+	return c.getFullNode().genesisFileContent(ctx)
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
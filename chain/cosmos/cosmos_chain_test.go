@@ -0,0 +1,16 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextNodeIndex(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 0, nextNodeIndex(nil))
+	require.Equal(t, 2, nextNodeIndex(ChainNodes{{Index: 0}, {Index: 1}}))
+	// After a removed node, the index continues after the highest remaining one.
+	require.Equal(t, 3, nextNodeIndex(ChainNodes{{Index: 2}, {Index: 0}}))
+}
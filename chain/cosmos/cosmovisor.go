@@ -0,0 +1,132 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// cosmovisorDir is the directory of cosmovisor in a node's home directory, which is cosmovisor's DAEMON_HOME.
+const cosmovisorDir = "cosmovisor"
+
+// errNoCosmovisor is returned by cosmovisor helpers when the chain is not configured to run cosmovisor.
+var errNoCosmovisor = errors.New("chain is not configured to run nodes under cosmovisor; set ChainConfig.Cosmovisor")
+
+// cosmovisorBinDir returns the directory of the binary cosmovisor runs before the upgrade with upgradeName,
+// or from genesis if upgradeName is empty, relative to the node's home directory.
+func cosmovisorBinDir(upgradeName string) string {
+	if upgradeName == "" {
+		return path.Join(cosmovisorDir, "genesis", "bin")
+	}
+	// Cosmovisor escapes the upgrade name the same way.
+	return path.Join(cosmovisorDir, "upgrades", url.PathEscape(upgradeName), "bin")
+}
+
+// cosmovisorStartCmd returns the command starting the node under cosmovisor.
+func (tn *ChainNode) cosmovisorStartCmd() []string {
+	chainCfg := tn.Chain.Config()
+	cosmovisor := "cosmovisor"
+	if chainCfg.Cosmovisor.HostBinaryPath != "" {
+		cosmovisor = path.Join(tn.HomeDir(), cosmovisorDir, "cosmovisor")
+	}
+	// The defaults of the cosmovisor environment are set with env, unless set by the node's Env.
+	cmd := []string{"env"}
+	for _, v := range [][2]string{
+		{"DAEMON_NAME", chainCfg.Bin},
+		{"DAEMON_HOME", tn.HomeDir()},
+		{"DAEMON_ALLOW_DOWNLOAD_BINARIES", "false"},
+		{"DAEMON_RESTART_AFTER_UPGRADE", "true"},
+		// Backing up the data directory at each upgrade is slow, and unnecessary for a test chain.
+		{"UNSAFE_SKIP_BACKUP", "true"},
+	} {
+		if !hasEnv(tn.Env, v[0]) {
+			cmd = append(cmd, v[0]+"="+v[1])
+		}
+	}
+	cmd = append(cmd, cosmovisor, "run")
+	return append(cmd, tn.startArgs(tn.HomeDir())...)
+}
+
+// setupCosmovisor prepares the node's volume to run cosmovisor: it copies the cosmovisor binary from the host,
+// if configured, and the binary of the node's image as the genesis binary, unless already copied.
+func (tn *ChainNode) setupCosmovisor(ctx context.Context) error {
+	chainCfg := tn.Chain.Config()
+	if chainCfg.NoHostMount {
+		// The home directory is copied when the container starts, so binaries staged later would not be found.
+		return errors.New("cosmovisor is not supported with NoHostMount")
+	}
+
+	if p := chainCfg.Cosmovisor.HostBinaryPath; p != "" {
+		relPath := path.Join(cosmovisorDir, "cosmovisor")
+		if err := tn.CopyFile(ctx, p, relPath); err != nil {
+			return fmt.Errorf("copy cosmovisor binary: %w", err)
+		}
+		if _, _, err := tn.Exec(ctx, []string{"chmod", "+x", path.Join(tn.HomeDir(), relPath)}, nil); err != nil {
+			return fmt.Errorf("make cosmovisor binary executable: %w", err)
+		}
+	}
+
+	// Once cosmovisor has upgraded the node, the genesis binary must not be replaced.
+	const script = `set -e; mkdir -p "$2"; [ -e "$2/$1" ] || cp "$(command -v "$1")" "$2/"`
+	dir := path.Join(tn.HomeDir(), cosmovisorBinDir(""))
+	if _, _, err := tn.Exec(ctx, []string{"sh", "-c", script, "_", chainCfg.Bin, dir}, nil); err != nil {
+		return fmt.Errorf("copy genesis binary for cosmovisor: %w", err)
+	}
+	return nil
+}
+
+// StageUpgradeBinary copies the chain binary of image to the node's volume,
+// for cosmovisor to run once the upgrade with upgradeName is reached.
+// Expects the chain to be configured with ChainConfig.Cosmovisor.
+func (tn *ChainNode) StageUpgradeBinary(ctx context.Context, upgradeName string, image ibc.DockerImage) error {
+	chainCfg := tn.Chain.Config()
+	if chainCfg.Cosmovisor == nil {
+		return errNoCosmovisor
+	}
+	if upgradeName == "" {
+		return errors.New("upgrade name must not be empty")
+	}
+
+	const script = `set -e; mkdir -p "$2"; cp "$(command -v "$1")" "$2/"`
+	dir := path.Join(tn.HomeDir(), cosmovisorBinDir(upgradeName))
+	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, image.Repository, image.Version)
+	res := job.Run(ctx, []string{"sh", "-c", script, "_", chainCfg.Bin, dir}, dockerutil.ContainerOptions{
+		Binds: tn.Bind(),
+		// The volume is owned by the user of the node's image, which may differ from the user of image.
+		User: tn.Image.UidGid,
+	})
+	if res.Err != nil {
+		return fmt.Errorf("stage binary of %s for upgrade %s: %w", image.Ref(), upgradeName, res.Err)
+	}
+	return nil
+}
+
+// StageUpgrade copies the chain binary of image to the volume of every node, for cosmovisor to run
+// once the upgrade with upgradeName is reached, e.g. the name of a SoftwareUpgradeProposal.
+// Stage the upgrade before the upgrade height; the nodes then switch binaries without stopping their containers.
+//
+// The nodes keep running their containers of the old image, so the new binary must run in the old image.
+// Afterwards, call UpgradeVersion so that commands, such as txs and queries, run the new image.
+// Expects the chain to be configured with ChainConfig.Cosmovisor.
+func (c *CosmosChain) StageUpgrade(ctx context.Context, upgradeName string, image ibc.DockerImage) error {
+	if c.cfg.Cosmovisor == nil {
+		return errNoCosmovisor
+	}
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			if err := n.StageUpgradeBinary(ctx, upgradeName, image); err != nil {
+				return fmt.Errorf("node %s: %w", n.Name(), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
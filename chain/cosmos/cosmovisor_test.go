@@ -0,0 +1,52 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmovisorBinDir(t *testing.T) {
+	require.Equal(t, "cosmovisor/genesis/bin", cosmovisorBinDir(""))
+	require.Equal(t, "cosmovisor/upgrades/v2/bin", cosmovisorBinDir("v2"))
+	require.Equal(t, "cosmovisor/upgrades/v2%2Fbeta%20one/bin", cosmovisorBinDir("v2/beta one"))
+}
+
+func TestChainNode_CosmovisorStartCmd(t *testing.T) {
+	cfg := ibc.ChainConfig{Name: "gaia", Bin: "gaiad", Cosmovisor: &ibc.CosmovisorConfig{}}
+	tn := &ChainNode{Chain: &CosmosChain{cfg: cfg}}
+
+	env := []string{
+		"env",
+		"DAEMON_NAME=gaiad",
+		"DAEMON_HOME=/var/cosmos-chain/gaia",
+		"DAEMON_ALLOW_DOWNLOAD_BINARIES=false",
+		"DAEMON_RESTART_AFTER_UPGRADE=true",
+		"UNSAFE_SKIP_BACKUP=true",
+	}
+	start := []string{"run", "start", "--home", "/var/cosmos-chain/gaia", "--x-crisis-skip-assert-invariants"}
+
+	// From the image's PATH.
+	want := append(append(append([]string(nil), env...), "cosmovisor"), start...)
+	require.Equal(t, want, tn.cosmovisorStartCmd())
+
+	// Copied from the host.
+	tn.Chain.(*CosmosChain).cfg.Cosmovisor.HostBinaryPath = "/tmp/cosmovisor"
+	want = append(append(append([]string(nil), env...), "/var/cosmos-chain/gaia/cosmovisor/cosmovisor"), start...)
+	require.Equal(t, want, tn.cosmovisorStartCmd())
+}
+
+func TestChainNode_CosmovisorStartCmdEnv(t *testing.T) {
+	cfg := ibc.ChainConfig{Name: "gaia", Bin: "gaiad", Cosmovisor: &ibc.CosmovisorConfig{}}
+	tn := &ChainNode{Chain: &CosmosChain{cfg: cfg}, Env: []string{"DAEMON_RESTART_AFTER_UPGRADE=false", "UNSAFE_SKIP_BACKUP=false"}}
+
+	// The node's environment takes precedence over the defaults.
+	require.Equal(t, []string{
+		"env",
+		"DAEMON_NAME=gaiad",
+		"DAEMON_HOME=/var/cosmos-chain/gaia",
+		"DAEMON_ALLOW_DOWNLOAD_BINARIES=false",
+		"cosmovisor", "run", "start", "--home", "/var/cosmos-chain/gaia", "--x-crisis-skip-assert-invariants",
+	}, tn.cosmovisorStartCmd())
+}
@@ -0,0 +1,28 @@
+package cosmos
+
+import (
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// nodeEnv returns the environment variables of the container of the validator or fullnode index of a chain
+// configured by cfg.
+func nodeEnv(cfg ibc.ChainConfig, validator bool, index int) []string {
+	nodeEnv := cfg.FullNodeEnv
+	if validator {
+		nodeEnv = cfg.ValidatorEnv
+	}
+	env := append([]string(nil), cfg.Env...)
+	return append(env, nodeEnv[index]...)
+}
+
+// hasEnv reports whether env sets the environment variable name.
+func hasEnv(env []string, name string) bool {
+	for _, e := range env {
+		if strings.HasPrefix(e, name+"=") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNodeEnv(t *testing.T) {
+	t.Parallel()
+
+	cfg := ibc.ChainConfig{
+		Env:          []string{"RUST_LOG=info"},
+		ValidatorEnv: map[int][]string{1: {"RUST_LOG=debug"}},
+		FullNodeEnv:  map[int][]string{0: {"DAEMON_POLL_INTERVAL=100ms"}},
+	}
+	require.Equal(t, []string{"RUST_LOG=info"}, nodeEnv(cfg, true, 0))
+	require.Equal(t, []string{"RUST_LOG=info", "RUST_LOG=debug"}, nodeEnv(cfg, true, 1))
+	require.Equal(t, []string{"RUST_LOG=info", "DAEMON_POLL_INTERVAL=100ms"}, nodeEnv(cfg, false, 0))
+	require.Empty(t, nodeEnv(ibc.ChainConfig{}, false, 0))
+
+	chain := NewCosmosChain(t.Name(), cfg, 2, 1, zaptest.NewLogger(t))
+	node := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 1)
+	require.Equal(t, []string{"RUST_LOG=info", "RUST_LOG=debug"}, node.Env)
+
+	require.True(t, hasEnv(node.Env, "RUST_LOG"))
+	require.False(t, hasEnv(node.Env, "RUST"))
+}
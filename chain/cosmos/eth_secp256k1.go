@@ -0,0 +1,225 @@
+package cosmos
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"golang.org/x/crypto/sha3"
+)
+
+// The eth_secp256k1 keys of ethermint chains are implemented here, compatible with the proto types of ethermint,
+// rather than depending on ethermint, which replaces dependencies of the cosmos SDK.
+const (
+	// ethSecp256k1PubKeyName and ethSecp256k1PrivKeyName are the proto message names of the ethermint key types.
+	ethSecp256k1PubKeyName  = "ethermint.crypto.v1.ethsecp256k1.PubKey"
+	ethSecp256k1PrivKeyName = "ethermint.crypto.v1.ethsecp256k1.PrivKey"
+
+	ethSecp256k1PrivKeySize = 32
+	ethSignatureSize        = 65
+)
+
+var (
+	_ cryptotypes.PubKey  = (*ethSecp256k1PubKey)(nil)
+	_ cryptotypes.PrivKey = (*ethSecp256k1PrivKey)(nil)
+
+	// ethSecp256k1Algo is the eth_secp256k1 signing algorithm of keyrings. Keys are derived from mnemonics
+	// by BIP-32 like secp256k1 keys.
+	ethSecp256k1Algo keyring.SignatureAlgo = ethSecp256k1SignatureAlgo{}
+
+	// secp256k1HalfOrder is half the order of the secp256k1 curve, above which the S value of a signature is malleable.
+	secp256k1HalfOrder = new(big.Int).Rsh(secp256k1.S256().N, 1)
+)
+
+// registerEthSecp256k1 registers the eth_secp256k1 key types in registry, e.g. to decode txs and keys of ethermint chains.
+func registerEthSecp256k1(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*cryptotypes.PubKey)(nil), &ethSecp256k1PubKey{})
+	registry.RegisterImplementations((*cryptotypes.PrivKey)(nil), &ethSecp256k1PrivKey{})
+}
+
+// validateSigningAlgorithm returns an error if algo is not a supported signing algorithm of ChainConfig.
+func validateSigningAlgorithm(algo string) error {
+	switch algo {
+	case "", ibc.SigningAlgorithmSecp256k1, ibc.SigningAlgorithmEthSecp256k1:
+		return nil
+	default:
+		return fmt.Errorf("unknown signing algorithm %q", algo)
+	}
+}
+
+// signingAlgo returns the keyring signing algorithm of the keys of a chain configured by cfg.
+func signingAlgo(cfg ibc.ChainConfig) keyring.SignatureAlgo {
+	if cfg.SigningAlgorithm == ibc.SigningAlgorithmEthSecp256k1 {
+		return ethSecp256k1Algo
+	}
+	return hd.Secp256k1
+}
+
+// keyAlgoFlags returns the flags of the signing algorithm of keys added to the keyring of the node, if configured.
+func (tn *ChainNode) keyAlgoFlags() []string {
+	if algo := tn.Chain.Config().SigningAlgorithm; algo != "" {
+		return []string{"--algo", algo}
+	}
+	return nil
+}
+
+// ethSecp256k1SignatureAlgo implements keyring.SignatureAlgo for eth_secp256k1 keys.
+type ethSecp256k1SignatureAlgo struct{}
+
+func (ethSecp256k1SignatureAlgo) Name() hd.PubKeyType {
+	return hd.PubKeyType(ibc.SigningAlgorithmEthSecp256k1)
+}
+
+func (ethSecp256k1SignatureAlgo) Derive() hd.DeriveFn {
+	return hd.Secp256k1.Derive()
+}
+
+func (ethSecp256k1SignatureAlgo) Generate() hd.GenerateFn {
+	return func(bz []byte) cryptotypes.PrivKey {
+		key := make([]byte, ethSecp256k1PrivKeySize)
+		copy(key, bz)
+		return &ethSecp256k1PrivKey{Key: key}
+	}
+}
+
+// keccak256 returns the keccak256 hash of bz, as used by Ethereum.
+func keccak256(bz []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(bz)
+	return h.Sum(nil)
+}
+
+// ethSecp256k1PubKey is the compressed public key of an eth_secp256k1 key.
+type ethSecp256k1PubKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+// Address returns the Ethereum address of the key, the last 20 bytes of the keccak256 hash of the uncompressed key.
+func (k *ethSecp256k1PubKey) Address() cryptotypes.Address {
+	pub, err := secp256k1.ParsePubKey(k.Key)
+	if err != nil {
+		panic(fmt.Errorf("invalid eth_secp256k1 public key: %w", err))
+	}
+	return keccak256(pub.SerializeUncompressed()[1:])[12:]
+}
+
+func (k *ethSecp256k1PubKey) Bytes() []byte {
+	return k.Key
+}
+
+// VerifySignature reports whether sig, in the [R || S || V] format of Ethereum, is a signature of msg by the key.
+func (k *ethSecp256k1PubKey) VerifySignature(msg, sig []byte) bool {
+	if len(sig) != ethSignatureSize {
+		return false
+	}
+	pub, err := secp256k1.ParsePubKey(k.Key)
+	if err != nil {
+		return false
+	}
+	s := secp256k1.NewSignature(new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]))
+	if s.S.Cmp(secp256k1HalfOrder) > 0 {
+		return false
+	}
+	return s.Verify(keccak256(msg), pub)
+}
+
+func (k *ethSecp256k1PubKey) Equals(other cryptotypes.PubKey) bool {
+	return k.Type() == other.Type() && bytes.Equal(k.Bytes(), other.Bytes())
+}
+
+func (k *ethSecp256k1PubKey) Type() string {
+	return ibc.SigningAlgorithmEthSecp256k1
+}
+
+func (k *ethSecp256k1PubKey) Reset()                { *k = ethSecp256k1PubKey{} }
+func (k *ethSecp256k1PubKey) String() string        { return fmt.Sprintf("EthPubKeySecp256k1{%X}", k.Key) }
+func (*ethSecp256k1PubKey) ProtoMessage()           {}
+func (*ethSecp256k1PubKey) XXX_MessageName() string { return ethSecp256k1PubKeyName }
+
+func (k *ethSecp256k1PubKey) Marshal() ([]byte, error) { return marshalKeyProto(k.Key), nil }
+func (k *ethSecp256k1PubKey) Unmarshal(bz []byte) (err error) {
+	k.Key, err = unmarshalKeyProto(bz)
+	return err
+}
+
+// ethSecp256k1PrivKey is the private key of an eth_secp256k1 key.
+type ethSecp256k1PrivKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (k *ethSecp256k1PrivKey) Bytes() []byte {
+	return k.Key
+}
+
+// Sign returns the signature of the keccak256 hash of msg, in the [R || S || V] format of Ethereum.
+func (k *ethSecp256k1PrivKey) Sign(msg []byte) ([]byte, error) {
+	priv, _ := secp256k1.PrivKeyFromBytes(k.Key)
+	sig, err := secp256k1.SignCompact(priv, keccak256(msg), false)
+	if err != nil {
+		return nil, err
+	}
+	// The compact signature is [V || R || S], with V = 27 + the recovery ID.
+	return append(sig[1:], sig[0]-27), nil
+}
+
+func (k *ethSecp256k1PrivKey) PubKey() cryptotypes.PubKey {
+	_, pub := secp256k1.PrivKeyFromBytes(k.Key)
+	return &ethSecp256k1PubKey{Key: pub.SerializeCompressed()}
+}
+
+func (k *ethSecp256k1PrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	return k.Type() == other.Type() && subtle.ConstantTimeCompare(k.Bytes(), other.Bytes()) == 1
+}
+
+func (k *ethSecp256k1PrivKey) Type() string {
+	return ibc.SigningAlgorithmEthSecp256k1
+}
+
+func (k *ethSecp256k1PrivKey) Reset()                { *k = ethSecp256k1PrivKey{} }
+func (*ethSecp256k1PrivKey) String() string          { return "EthPrivKeySecp256k1{...}" }
+func (*ethSecp256k1PrivKey) ProtoMessage()           {}
+func (*ethSecp256k1PrivKey) XXX_MessageName() string { return ethSecp256k1PrivKeyName }
+
+func (k *ethSecp256k1PrivKey) Marshal() ([]byte, error) { return marshalKeyProto(k.Key), nil }
+func (k *ethSecp256k1PrivKey) Unmarshal(bz []byte) (err error) {
+	k.Key, err = unmarshalKeyProto(bz)
+	return err
+}
+
+// keyProtoTag is the tag of the key field of the key messages: field 1, length-delimited.
+const keyProtoTag = 0x0a
+
+// marshalKeyProto returns the proto encoding of a key message, whose only field is the key bytes.
+func marshalKeyProto(key []byte) []byte {
+	if len(key) == 0 {
+		return nil
+	}
+	bz := make([]byte, 1+binary.MaxVarintLen64)
+	bz[0] = keyProtoTag
+	n := binary.PutUvarint(bz[1:], uint64(len(key)))
+	return append(bz[:1+n], key...)
+}
+
+// unmarshalKeyProto returns the key bytes of the proto encoding of a key message.
+func unmarshalKeyProto(bz []byte) ([]byte, error) {
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	if bz[0] != keyProtoTag {
+		return nil, fmt.Errorf("unexpected field tag %#x of key message", bz[0])
+	}
+	size, n := binary.Uvarint(bz[1:])
+	if n <= 0 || uint64(len(bz)-1-n) != size {
+		return nil, errors.New("invalid length of key")
+	}
+	return append([]byte(nil), bz[1+n:]...), nil
+}
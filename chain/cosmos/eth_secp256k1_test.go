@@ -0,0 +1,124 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v2"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestEthSecp256k1Key(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(keccak256(nil)))
+
+	// The first Ethereum account of the mnemonic, as derived by Ethereum wallets.
+	priv, err := offlinePrivKey(testMnemonic, "60", ethSecp256k1Algo)
+	require.NoError(t, err)
+	pub := priv.PubKey()
+	require.Equal(t, "9858effd232b4033e47d90003d41ec34ecaeda94", hex.EncodeToString(pub.Address()))
+	require.Equal(t, ibc.SigningAlgorithmEthSecp256k1, pub.Type())
+
+	msg := []byte("sign bytes")
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, ethSignatureSize)
+	require.Contains(t, []byte{0, 1}, sig[64])
+	require.True(t, pub.VerifySignature(msg, sig))
+	require.False(t, pub.VerifySignature([]byte("other bytes"), sig))
+	require.False(t, pub.VerifySignature(msg, sig[:64]))
+
+	// Signatures with a high S value are malleable, and rejected like by ethermint.
+	highS := append([]byte(nil), sig...)
+	s := new(big.Int).Sub(secp256k1.S256().N, new(big.Int).SetBytes(sig[32:64]))
+	s.FillBytes(highS[32:64])
+	require.False(t, pub.VerifySignature(msg, highS))
+
+	// The keys round trip in Any, like in txs and keyrings.
+	registry := codectypes.NewInterfaceRegistry()
+	registerEthSecp256k1(registry)
+
+	pubAny, err := codectypes.NewAnyWithValue(pub)
+	require.NoError(t, err)
+	require.Equal(t, "/ethermint.crypto.v1.ethsecp256k1.PubKey", pubAny.TypeUrl)
+	var gotPub cryptotypes.PubKey
+	require.NoError(t, registry.UnpackAny(&codectypes.Any{TypeUrl: pubAny.TypeUrl, Value: pubAny.Value}, &gotPub))
+	require.True(t, pub.Equals(gotPub))
+
+	privAny, err := codectypes.NewAnyWithValue(priv)
+	require.NoError(t, err)
+	require.Equal(t, "/ethermint.crypto.v1.ethsecp256k1.PrivKey", privAny.TypeUrl)
+	var gotPriv cryptotypes.PrivKey
+	require.NoError(t, registry.UnpackAny(&codectypes.Any{TypeUrl: privAny.TypeUrl, Value: privAny.Value}, &gotPriv))
+	require.True(t, priv.Equals(gotPriv))
+
+	_, err = unmarshalKeyProto([]byte{0x0a, 5, 1})
+	require.EqualError(t, err, "invalid length of key")
+}
+
+func TestEthSecp256k1Chain(t *testing.T) {
+	t.Parallel()
+
+	// Signers of msgs are decoded with the global prefix of bech32 addresses.
+	cfg := ibc.ChainConfig{ChainID: "evmos_9000-1", Bech32Prefix: "cosmos", CoinType: "60", SigningAlgorithm: ibc.SigningAlgorithmEthSecp256k1}
+	chain := NewCosmosChain(t.Name(), cfg, 1, 0, zaptest.NewLogger(t))
+	txConfig := chain.cfg.EncodingConfig.TxConfig
+
+	node := &ChainNode{Chain: chain}
+	require.Equal(t, []string{"--algo", "eth_secp256k1"}, node.keyAlgoFlags())
+	require.Nil(t, (&ChainNode{Chain: &CosmosChain{}}).keyAlgoFlags())
+
+	require.NoError(t, validateSigningAlgorithm(cfg.SigningAlgorithm))
+	require.EqualError(t, validateSigningAlgorithm("ed25519"), `unknown signing algorithm "ed25519"`)
+
+	// Relayer wallets have Ethereum addresses.
+	wallet, err := chain.BuildRelayerWallet(context.Background(), "relayer")
+	require.NoError(t, err)
+	priv, err := offlinePrivKey(wallet.Mnemonic(), cfg.CoinType, ethSecp256k1Algo)
+	require.NoError(t, err)
+	require.Equal(t, []byte(priv.PubKey().Address()), wallet.Address())
+
+	from := types.MustBech32ifyAddressBytes(cfg.Bech32Prefix, wallet.Address())
+	builder := txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(&banktypes.MsgSend{
+		FromAddress: from,
+		ToAddress:   from,
+		Amount:      types.NewCoins(types.NewInt64Coin("aevmos", 5)),
+	}))
+	unsignedTx, err := txConfig.TxJSONEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	// Txs are signed offline with the eth_secp256k1 key, and decode with the chain's encoding.
+	accNum, seq := uint64(1), uint64(0)
+	signed, err := chain.SignOffline(context.Background(), wallet.Mnemonic(), unsignedTx, OfflineSignOptions{AccountNumber: &accNum, Sequence: &seq})
+	require.NoError(t, err)
+	require.Contains(t, string(signed), "/ethermint.crypto.v1.ethsecp256k1.PubKey")
+
+	sdkTx, err := txConfig.TxJSONDecoder()(signed)
+	require.NoError(t, err)
+	sigs, err := sdkTx.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, priv.PubKey().Equals(sigs[0].PubKey))
+
+	signBytes, err := txConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, authsigning.SignerData{
+		Address:       from,
+		ChainID:       cfg.ChainID,
+		AccountNumber: accNum,
+		Sequence:      seq,
+		PubKey:        priv.PubKey(),
+	}, sdkTx)
+	require.NoError(t, err)
+	require.True(t, sigs[0].PubKey.VerifySignature(signBytes, sigs[0].Data.(*signing.SingleSignatureData).Signature))
+}
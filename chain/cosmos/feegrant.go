@@ -0,0 +1,118 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// FeeAllowance is the fee allowance granted by GrantFeeAllowance. The zero value grants an unlimited allowance.
+type FeeAllowance struct {
+	// SpendLimit is the maximum amount of fees the grantee may spend, e.g. "1000uatom". Unlimited if empty.
+	SpendLimit string
+	// Expiration is the time after which the allowance expires. Never expires if zero.
+	Expiration time.Time
+	// Period and PeriodLimit make the allowance periodic: the grantee may spend up to PeriodLimit
+	// in fees within each period. Both must be set for a periodic allowance.
+	Period      time.Duration
+	PeriodLimit string
+	// AllowedMessages restricts the allowance to the given msg type URLs, e.g. "/cosmos.bank.v1beta1.MsgSend".
+	AllowedMessages []string
+}
+
+// flags returns the flags of the feegrant grant command for the allowance.
+func (a FeeAllowance) flags() []string {
+	var flags []string
+	if a.SpendLimit != "" {
+		flags = append(flags, "--spend-limit", a.SpendLimit)
+	}
+	if !a.Expiration.IsZero() {
+		flags = append(flags, "--expiration", a.Expiration.UTC().Format(time.RFC3339))
+	}
+	if a.Period > 0 {
+		flags = append(flags, "--period", strconv.FormatInt(int64(a.Period/time.Second), 10))
+	}
+	if a.PeriodLimit != "" {
+		flags = append(flags, "--period-limit", a.PeriodLimit)
+	}
+	if len(a.AllowedMessages) > 0 {
+		flags = append(flags, "--allowed-messages", strings.Join(a.AllowedMessages, ","))
+	}
+	return flags
+}
+
+type feeGranterKey struct{}
+
+// WithFeeGranter returns a context with which txs sent through ExecTx, and so all tx helpers of ChainNode and CosmosChain,
+// have their fees paid by the fee allowance granted by granter to the signer, e.g. with GrantFeeAllowance.
+func WithFeeGranter(ctx context.Context, granter string) context.Context {
+	return context.WithValue(ctx, feeGranterKey{}, granter)
+}
+
+// feeGranter returns the fee granter set on ctx by WithFeeGranter, if any.
+func feeGranter(ctx context.Context) string {
+	granter, _ := ctx.Value(feeGranterKey{}).(string)
+	return granter
+}
+
+// feeGranterFlags returns the tx flags for the fee granter set on ctx by WithFeeGranter, if any.
+func feeGranterFlags(ctx context.Context) []string {
+	granter := feeGranter(ctx)
+	if granter == "" {
+		return nil
+	}
+	return []string{"--fee-granter", granter}
+}
+
+// GrantFeeAllowance grants grantee an allowance to pay tx fees from the account of keyName.
+func (tn *ChainNode) GrantFeeAllowance(ctx context.Context, keyName string, grantee string, allowance FeeAllowance) error {
+	command := append([]string{"feegrant", "grant", keyName, grantee}, allowance.flags()...)
+	_, err := tn.ExecTx(ctx, keyName, command...)
+	return err
+}
+
+// RevokeFeeAllowance revokes the fee allowance granted to grantee by the account of keyName.
+func (tn *ChainNode) RevokeFeeAllowance(ctx context.Context, keyName string, grantee string) error {
+	_, err := tn.ExecTx(ctx, keyName, "feegrant", "revoke", keyName, grantee)
+	return err
+}
+
+// GrantFeeAllowance grants grantee an allowance to pay tx fees from the account of keyName.
+// Send txs using the allowance with a context returned by WithFeeGranter.
+func (c *CosmosChain) GrantFeeAllowance(ctx context.Context, keyName string, grantee string, allowance FeeAllowance) error {
+	if err := c.getFullNode().GrantFeeAllowance(ctx, keyName, grantee, allowance); err != nil {
+		return fmt.Errorf("failed to grant fee allowance: %w", err)
+	}
+	return nil
+}
+
+// RevokeFeeAllowance revokes the fee allowance granted to grantee by the account of keyName.
+func (c *CosmosChain) RevokeFeeAllowance(ctx context.Context, keyName string, grantee string) error {
+	if err := c.getFullNode().RevokeFeeAllowance(ctx, keyName, grantee); err != nil {
+		return fmt.Errorf("failed to revoke fee allowance: %w", err)
+	}
+	return nil
+}
+
+// QueryFeeAllowance returns the fee allowance granted to grantee by granter.
+func (c *CosmosChain) QueryFeeAllowance(ctx context.Context, granter string, grantee string) (feegrant.FeeAllowanceI, error) {
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient := feegrant.NewQueryClient(conn)
+	res, err := queryClient.Allowance(ctx, &feegrant.QueryAllowanceRequest{Granter: granter, Grantee: grantee})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := res.Allowance.UnpackInterfaces(c.cfg.EncodingConfig.InterfaceRegistry); err != nil {
+		return nil, fmt.Errorf("unpack fee allowance: %w", err)
+	}
+	return res.Allowance.GetGrant()
+}
@@ -0,0 +1,38 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeAllowanceFlags(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, FeeAllowance{}.flags())
+
+	expiration := time.Date(2023, 4, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60))
+	require.Equal(t, []string{
+		"--spend-limit", "1000stake",
+		"--expiration", "2023-04-01T10:00:00Z",
+		"--period", "3600",
+		"--period-limit", "100stake",
+		"--allowed-messages", "/cosmos.bank.v1beta1.MsgSend,/cosmos.gov.v1.MsgVote",
+	}, FeeAllowance{
+		SpendLimit:      "1000stake",
+		Expiration:      expiration,
+		Period:          time.Hour,
+		PeriodLimit:     "100stake",
+		AllowedMessages: []string{"/cosmos.bank.v1beta1.MsgSend", "/cosmos.gov.v1.MsgVote"},
+	}.flags())
+}
+
+func TestFeeGranterFlags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	require.Empty(t, feeGranterFlags(ctx))
+	require.Equal(t, []string{"--fee-granter", "cosmos1granter"}, feeGranterFlags(WithFeeGranter(ctx, "cosmos1granter")))
+}
@@ -0,0 +1,145 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// defaultGasPriceMultiplier is the factor of the base gas price that txs pay on chains with a fee market,
+// unless set by FeeMarketConfig.GasPriceMultiplier.
+const defaultGasPriceMultiplier = 1.5
+
+// feeMarketDenom returns the denom of fees of the fee market of cfg.
+func feeMarketDenom(cfg ibc.ChainConfig) string {
+	if cfg.FeeMarket != nil && cfg.FeeMarket.FeeDenom != "" {
+		return cfg.FeeMarket.FeeDenom
+	}
+	return cfg.Denom
+}
+
+// setGenesisFeeMarketParams enables the fee market in the genesis genbz, with the params of the chain's FeeMarket config.
+func setGenesisFeeMarketParams(genbz []byte, cfg ibc.ChainConfig) ([]byte, error) {
+	g := make(map[string]interface{})
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	fm := cfg.FeeMarket
+	baseGasPrice := fm.BaseGasPrice
+	if baseGasPrice == "" {
+		baseGasPrice = fm.MinBaseGasPrice
+	}
+	var maxBlockUtilization string
+	if fm.MaxBlockUtilization > 0 {
+		// Encoded as a string, as uint64 values are in proto JSON.
+		maxBlockUtilization = strconv.FormatUint(fm.MaxBlockUtilization, 10)
+	}
+
+	params := []struct {
+		path  []interface{}
+		value interface{}
+	}{
+		{[]interface{}{"params", "enabled"}, true},
+		{[]interface{}{"params", "fee_denom"}, feeMarketDenom(cfg)},
+		{[]interface{}{"params", "min_base_gas_price"}, fm.MinBaseGasPrice},
+		{[]interface{}{"params", "max_block_utilization"}, maxBlockUtilization},
+		{[]interface{}{"state", "base_gas_price"}, baseGasPrice},
+	}
+	for _, p := range params {
+		if p.value == "" {
+			continue
+		}
+		path := append([]interface{}{"app_state", "feemarket"}, p.path...)
+		if err := dyno.Set(g, p.value, path...); err != nil {
+			return nil, fmt.Errorf("failed to set feemarket %s.%s in genesis json: %w", p.path[0], p.path[1], err)
+		}
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// BaseGasPrice queries the current base gas price of the fee market in the chain's fee denom.
+func (tn *ChainNode) BaseGasPrice(ctx context.Context) (types.DecCoin, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "feemarket", "gas-price", feeMarketDenom(tn.Chain.Config()))
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+	var res struct {
+		Price types.DecCoin `json:"price"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return types.DecCoin{}, fmt.Errorf("failed to unmarshal gas price: %w", err)
+	}
+	return res.Price, nil
+}
+
+// txGasPrices returns the gas prices paid by txs: the chain's GasPrices, or on chains with a fee market,
+// the current base gas price by the GasPriceMultiplier of the FeeMarket config.
+func (tn *ChainNode) txGasPrices(ctx context.Context) (string, error) {
+	cfg := tn.Chain.Config()
+	if cfg.FeeMarket == nil {
+		return cfg.GasPrices, nil
+	}
+	price, err := tn.BaseGasPrice(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to query base gas price: %w", err)
+	}
+	return feeMarketGasPrices(price, cfg.FeeMarket.GasPriceMultiplier)
+}
+
+// feeMarketGasPrices returns the gas prices of txs paying base by multiplier, or by defaultGasPriceMultiplier if zero.
+func feeMarketGasPrices(base types.DecCoin, multiplier float64) (string, error) {
+	if multiplier == 0 {
+		multiplier = defaultGasPriceMultiplier
+	}
+	m, err := types.NewDecFromStr(strconv.FormatFloat(multiplier, 'f', -1, 64))
+	if err != nil {
+		return "", fmt.Errorf("invalid gas price multiplier %v: %w", multiplier, err)
+	}
+	return types.NewDecCoinFromDec(base.Denom, base.Amount.Mul(m)).String(), nil
+}
+
+// BaseGasPrice queries the current base gas price of the fee market of the chain. See ChainNode.BaseGasPrice.
+func (c *CosmosChain) BaseGasPrice(ctx context.Context) (types.DecCoin, error) {
+	return c.getFullNode().BaseGasPrice(ctx)
+}
+
+// TxGasPrices returns the gas prices paid by the chain's txs, for txs signed outside of the chain's tx helpers:
+// the chain's GasPrices, or on chains with a fee market, the current base gas price by the GasPriceMultiplier
+// of the FeeMarket config.
+func (c *CosmosChain) TxGasPrices(ctx context.Context) (string, error) {
+	return c.getFullNode().txGasPrices(ctx)
+}
+
+// FeesForGas returns the fees of a tx using gas at the chain's current gas prices, rounded up.
+func (c *CosmosChain) FeesForGas(ctx context.Context, gas uint64) (types.Coins, error) {
+	gasPrices, err := c.TxGasPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return feesForGas(gasPrices, gas)
+}
+
+// feesForGas returns the fees of a tx using gas at gasPrices, e.g. 0.01uatom, rounded up.
+func feesForGas(gasPrices string, gas uint64) (types.Coins, error) {
+	prices, err := types.ParseDecCoins(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gas prices %q: %w", gasPrices, err)
+	}
+	fees := make(types.Coins, len(prices))
+	g := types.NewDecFromInt(types.NewIntFromUint64(gas))
+	for i, p := range prices {
+		fees[i] = types.NewCoin(p.Denom, p.Amount.Mul(g).Ceil().RoundInt())
+	}
+	return fees.Sort(), nil
+}
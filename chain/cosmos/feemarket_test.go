@@ -0,0 +1,84 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSetGenesisFeeMarketParams(t *testing.T) {
+	t.Parallel()
+
+	genbz := []byte(`{"app_state":{"feemarket":{
+		"params":{"enabled":false,"fee_denom":"stake","min_base_gas_price":"1.000000000000000000","max_block_utilization":"30000000","window":"1"},
+		"state":{"base_gas_price":"1.000000000000000000","window":["0"]}
+	}}}`)
+
+	out, err := setGenesisFeeMarketParams(genbz, ibc.ChainConfig{
+		Denom: "uatom",
+		FeeMarket: &ibc.FeeMarketConfig{
+			MinBaseGasPrice:     "0.0025",
+			MaxBlockUtilization: 100_000_000,
+		},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app_state":{"feemarket":{
+		"params":{"enabled":true,"fee_denom":"uatom","min_base_gas_price":"0.0025","max_block_utilization":"100000000","window":"1"},
+		"state":{"base_gas_price":"0.0025","window":["0"]}
+	}}}`, string(out))
+
+	out, err = setGenesisFeeMarketParams(genbz, ibc.ChainConfig{
+		Denom:     "uatom",
+		FeeMarket: &ibc.FeeMarketConfig{BaseGasPrice: "2", FeeDenom: "ufee"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app_state":{"feemarket":{
+		"params":{"enabled":true,"fee_denom":"ufee","min_base_gas_price":"1.000000000000000000","max_block_utilization":"30000000","window":"1"},
+		"state":{"base_gas_price":"2","window":["0"]}
+	}}}`, string(out))
+
+	_, err = setGenesisFeeMarketParams([]byte(`{"app_state":{"bank":{}}}`), ibc.ChainConfig{FeeMarket: &ibc.FeeMarketConfig{}})
+	require.ErrorContains(t, err, "failed to set feemarket params.enabled")
+}
+
+func TestFeeMarketGasPrices(t *testing.T) {
+	t.Parallel()
+
+	base := types.NewDecCoinFromDec("uatom", types.MustNewDecFromStr("0.01"))
+
+	gasPrices, err := feeMarketGasPrices(base, 0)
+	require.NoError(t, err)
+	require.Equal(t, "0.015000000000000000uatom", gasPrices)
+
+	gasPrices, err = feeMarketGasPrices(base, 3)
+	require.NoError(t, err)
+	require.Equal(t, "0.030000000000000000uatom", gasPrices)
+}
+
+func TestChainNode_txGasPrices(t *testing.T) {
+	t.Parallel()
+
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{GasPrices: "0.01uatom"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+
+	gasPrices, err := tn.txGasPrices(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "0.01uatom", gasPrices)
+
+	chain.Validators = ChainNodes{tn}
+	fees, err := chain.FeesForGas(context.Background(), 150)
+	require.NoError(t, err)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("uatom", 2)), fees)
+}
+
+func TestFeeMarketDenom(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "uatom", feeMarketDenom(ibc.ChainConfig{Denom: "uatom"}))
+	require.Equal(t, "uatom", feeMarketDenom(ibc.ChainConfig{Denom: "uatom", FeeMarket: &ibc.FeeMarketConfig{}}))
+	require.Equal(t, "ufee", feeMarketDenom(ibc.ChainConfig{Denom: "uatom", FeeMarket: &ibc.FeeMarketConfig{FeeDenom: "ufee"}}))
+}
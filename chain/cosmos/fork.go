@@ -0,0 +1,221 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/icza/dyno"
+)
+
+// ConsensusPubKey returns the ed25519 consensus public key of the node, from its priv_validator_key.json.
+func (tn *ChainNode) ConsensusPubKey(ctx context.Context) (ed25519.PubKey, error) {
+	bz, err := tn.ReadFile(ctx, privValidatorKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read consensus key: %w", err)
+	}
+	var key struct {
+		PubKey struct {
+			Value string `json:"value"`
+		} `json:"pub_key"`
+	}
+	if err := json.Unmarshal(bz, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal consensus key: %w", err)
+	}
+	pubKey, err := base64.StdEncoding.DecodeString(key.PubKey.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode consensus pub key: %w", err)
+	}
+	if len(pubKey) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("consensus pub key has %d bytes, expected an ed25519 key of %d", len(pubKey), ed25519.PubKeySize)
+	}
+	return ed25519.PubKey(pubKey), nil
+}
+
+// exportedGenesis writes the exported genesis of the chain's ExportedGenesis config to the genesis file of validator0,
+// with the chain ID and validators substituted, and adds the accounts of the validators and of wallets to it,
+// returning the resulting genesis.
+func (c *CosmosChain) exportedGenesis(ctx context.Context, validator0 *ChainNode, genesisAmounts []types.Coin, addrs []string, coins map[string]types.Coins) ([]byte, error) {
+	genbz, err := os.ReadFile(c.cfg.ExportedGenesis.HostPath)
+	if err != nil {
+		return nil, fmt.Errorf("read exported genesis: %w", err)
+	}
+
+	pubKeys := make([]ed25519.PubKey, len(c.Validators))
+	for i, v := range c.Validators {
+		if pubKeys[i], err = v.ConsensusPubKey(ctx); err != nil {
+			return nil, fmt.Errorf("validator %s: %w", v.Name(), err)
+		}
+	}
+
+	genbz, err = substituteGenesisValidators(genbz, c.cfg.ChainID, c.cfg.Bech32Prefix, pubKeys)
+	if err != nil {
+		return nil, fmt.Errorf("substitute validators of exported genesis: %w", err)
+	}
+	if err := validator0.overwriteGenesisFile(ctx, genbz); err != nil {
+		return nil, err
+	}
+
+	for _, v := range c.Validators {
+		bech32, err := v.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range addrs {
+		if err := validator0.AddGenesisAccount(ctx, addr, coins[addr]); err != nil {
+			return nil, err
+		}
+	}
+
+	return validator0.genesisFileContent(ctx)
+}
+
+// substituteGenesisValidators replaces the validators with the most voting power in the exported genesis genbz
+// with validators of the consensus keys pubKeys, and its chain ID with chainID.
+// The voting power of the replaced validators must be more than 2/3 of the total, so that the new validators produce blocks.
+//
+// The consensus keys and addresses of the replaced validators are substituted in the validator set,
+// the staking validators, and their slashing signing info, so their operators, delegations, and voting power are kept.
+func substituteGenesisValidators(genbz []byte, chainID string, bech32Prefix string, pubKeys []ed25519.PubKey) ([]byte, error) {
+	g := make(map[string]interface{})
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	g["chain_id"] = chainID
+
+	validators, err := dyno.GetSlice(g, "validators")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators from genesis json: %w", err)
+	}
+	if len(validators) < len(pubKeys) {
+		return nil, fmt.Errorf("genesis has %d validators, fewer than the %d to substitute", len(validators), len(pubKeys))
+	}
+
+	powers := make([]int64, len(validators))
+	var totalPower int64
+	for i, v := range validators {
+		p, err := dyno.GetString(v, "power")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get power of validator %d from genesis json: %w", i, err)
+		}
+		if powers[i], err = strconv.ParseInt(p, 10, 64); err != nil {
+			return nil, fmt.Errorf("parse power of validator %d: %w", i, err)
+		}
+		totalPower += powers[i]
+	}
+
+	byPower := make([]int, len(validators))
+	for i := range byPower {
+		byPower[i] = i
+	}
+	sort.SliceStable(byPower, func(i, j int) bool { return powers[byPower[i]] > powers[byPower[j]] })
+
+	var substitutedPower int64
+	for _, i := range byPower[:len(pubKeys)] {
+		substitutedPower += powers[i]
+	}
+	if 3*substitutedPower <= 2*totalPower {
+		return nil, fmt.Errorf(
+			"the %d validators with the most voting power have %d of %d, which must be more than 2/3 to produce blocks",
+			len(pubKeys), substitutedPower, totalPower,
+		)
+	}
+
+	// Old consensus keys and addresses to new ones.
+	keys := make(map[string]string, len(pubKeys))
+	consAddrs := make(map[string]string, len(pubKeys))
+	valconsPrefix := bech32Prefix + "valcons"
+	for n, i := range byPower[:len(pubKeys)] {
+		v := validators[i]
+		oldKey, err := dyno.GetString(v, "pub_key", "value")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pub key of validator %d from genesis json: %w", i, err)
+		}
+		oldKeyBz, err := base64.StdEncoding.DecodeString(oldKey)
+		if err != nil {
+			return nil, fmt.Errorf("decode pub key of validator %d: %w", i, err)
+		}
+		oldConsAddr, err := types.Bech32ifyAddressBytes(valconsPrefix, ed25519.PubKey(oldKeyBz).Address())
+		if err != nil {
+			return nil, err
+		}
+		newConsAddr, err := types.Bech32ifyAddressBytes(valconsPrefix, pubKeys[n].Address())
+		if err != nil {
+			return nil, err
+		}
+
+		newKey := base64.StdEncoding.EncodeToString(pubKeys[n])
+		keys[oldKey] = newKey
+		consAddrs[oldConsAddr] = newConsAddr
+
+		if err := dyno.Set(v, pubKeys[n].Address().String(), "address"); err != nil {
+			return nil, fmt.Errorf("failed to set address of validator %d in genesis json: %w", i, err)
+		}
+		if err := dyno.Set(v, newKey, "pub_key", "value"); err != nil {
+			return nil, fmt.Errorf("failed to set pub key of validator %d in genesis json: %w", i, err)
+		}
+	}
+
+	stakingValidators, err := dyno.GetSlice(g, "app_state", "staking", "validators")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staking validators from genesis json: %w", err)
+	}
+	var substituted int
+	for _, v := range stakingValidators {
+		key, err := dyno.GetString(v, "consensus_pubkey", "key")
+		if err != nil {
+			continue
+		}
+		newKey, ok := keys[key]
+		if !ok {
+			continue
+		}
+		if err := dyno.Set(v, newKey, "consensus_pubkey", "key"); err != nil {
+			return nil, fmt.Errorf("failed to set consensus pub key of staking validator in genesis json: %w", err)
+		}
+		substituted++
+	}
+	if substituted != len(keys) {
+		return nil, fmt.Errorf("found %d of the %d substituted validators in the staking validators of genesis", substituted, len(keys))
+	}
+
+	// Signing infos are keyed by consensus address; without them the new validators could not be slashed, or unjailed.
+	if signingInfos, err := dyno.GetSlice(g, "app_state", "slashing", "signing_infos"); err == nil {
+		for _, info := range signingInfos {
+			replaceGenesisConsAddr(info, consAddrs, "address")
+			replaceGenesisConsAddr(info, consAddrs, "validator_signing_info", "address")
+		}
+	}
+	if missedBlocks, err := dyno.GetSlice(g, "app_state", "slashing", "missed_blocks"); err == nil {
+		for _, missed := range missedBlocks {
+			replaceGenesisConsAddr(missed, consAddrs, "address")
+		}
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// replaceGenesisConsAddr replaces the consensus address at path in v with its substitute in consAddrs, if any.
+func replaceGenesisConsAddr(v interface{}, consAddrs map[string]string, path ...interface{}) {
+	addr, err := dyno.GetString(v, path...)
+	if err != nil {
+		return
+	}
+	if newAddr, ok := consAddrs[addr]; ok {
+		_ = dyno.Set(v, newAddr, path...)
+	}
+}
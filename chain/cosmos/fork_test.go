@@ -0,0 +1,118 @@
+package cosmos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/icza/dyno"
+	"github.com/stretchr/testify/require"
+)
+
+// exportedGenesisJSON returns an exported genesis of validators with the consensus keys pubKeys and voting powers.
+func exportedGenesisJSON(pubKeys []ed25519.PubKey, powers []int64) []byte {
+	var validators, stakingValidators, signingInfos, missedBlocks []string
+	for i, pk := range pubKeys {
+		key := base64.StdEncoding.EncodeToString(pk)
+		consAddr := types.MustBech32ifyAddressBytes("cosmosvalcons", pk.Address())
+		validators = append(validators, fmt.Sprintf(
+			`{"address":%q,"pub_key":{"type":"tendermint/PubKeyEd25519","value":%q},"power":"%d","name":"val%d"}`,
+			pk.Address().String(), key, powers[i], i,
+		))
+		stakingValidators = append(stakingValidators, fmt.Sprintf(
+			`{"operator_address":"cosmosvaloper%d","consensus_pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":%q}}`,
+			i, key,
+		))
+		signingInfos = append(signingInfos, fmt.Sprintf(
+			`{"address":%q,"validator_signing_info":{"address":%q,"missed_blocks_counter":"0"}}`, consAddr, consAddr,
+		))
+		missedBlocks = append(missedBlocks, fmt.Sprintf(`{"address":%q,"missed_blocks":[]}`, consAddr))
+	}
+	return []byte(fmt.Sprintf(`{"chain_id":"mainnet-1","validators":[%s],"app_state":{
+		"staking":{"validators":[%s]},
+		"slashing":{"signing_infos":[%s],"missed_blocks":[%s]}
+	}}`,
+		strings.Join(validators, ","), strings.Join(stakingValidators, ","),
+		strings.Join(signingInfos, ","), strings.Join(missedBlocks, ","),
+	))
+}
+
+func TestSubstituteGenesisValidators(t *testing.T) {
+	t.Parallel()
+
+	mainnetKeys := []ed25519.PubKey{
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+	}
+	testKeys := []ed25519.PubKey{
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+	}
+	genbz := exportedGenesisJSON(mainnetKeys, []int64{20, 50, 30})
+
+	out, err := substituteGenesisValidators(genbz, "test-1", "cosmos", testKeys)
+	require.NoError(t, err)
+
+	g := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(out, &g))
+	require.Equal(t, "test-1", g["chain_id"])
+
+	// The validators with the most power, 50 and 30, are substituted in order of power.
+	want := []ed25519.PubKey{mainnetKeys[0], testKeys[0], testKeys[1]}
+	for i, pk := range want {
+		key := base64.StdEncoding.EncodeToString(pk)
+		consAddr := types.MustBech32ifyAddressBytes("cosmosvalcons", pk.Address())
+
+		addr, err := dyno.GetString(g, "validators", i, "address")
+		require.NoError(t, err)
+		require.Equal(t, pk.Address().String(), addr)
+		valKey, err := dyno.GetString(g, "validators", i, "pub_key", "value")
+		require.NoError(t, err)
+		require.Equal(t, key, valKey)
+
+		stakingKey, err := dyno.GetString(g, "app_state", "staking", "validators", i, "consensus_pubkey", "key")
+		require.NoError(t, err)
+		require.Equal(t, key, stakingKey)
+
+		for _, path := range [][]interface{}{
+			{"app_state", "slashing", "signing_infos", i, "address"},
+			{"app_state", "slashing", "signing_infos", i, "validator_signing_info", "address"},
+			{"app_state", "slashing", "missed_blocks", i, "address"},
+		} {
+			got, err := dyno.GetString(g, path...)
+			require.NoError(t, err)
+			require.Equal(t, consAddr, got, path)
+		}
+	}
+
+	power, err := dyno.GetString(g, "validators", 1, "power")
+	require.NoError(t, err)
+	require.Equal(t, "50", power)
+}
+
+func TestSubstituteGenesisValidatorsErrors(t *testing.T) {
+	t.Parallel()
+
+	mainnetKeys := []ed25519.PubKey{
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+	}
+	genbz := exportedGenesisJSON(mainnetKeys, []int64{60, 40})
+
+	_, err := substituteGenesisValidators(genbz, "test-1", "cosmos", []ed25519.PubKey{
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+	})
+	require.ErrorContains(t, err, "have 60 of 100, which must be more than 2/3")
+
+	_, err = substituteGenesisValidators(genbz, "test-1", "cosmos", []ed25519.PubKey{
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+		ed25519.GenPrivKey().PubKey().(ed25519.PubKey),
+	})
+	require.ErrorContains(t, err, "genesis has 2 validators, fewer than the 3 to substitute")
+}
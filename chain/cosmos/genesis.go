@@ -0,0 +1,258 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// GenesisOp modifies the decoded genesis g of a chain with config cfg.
+// Compose ops into a ChainConfig.ModifyGenesis func with ModifyGenesis.
+type GenesisOp func(cfg ibc.ChainConfig, g map[string]any) error
+
+// ModifyGenesis returns a func for ChainConfig.ModifyGenesis, which applies ops to the genesis in order, e.g.
+//
+//	cosmos.ModifyGenesis(
+//		cosmos.SetPath("app_state.gov.params.voting_period", "20s"),
+//		cosmos.AppendToArray("app_state.interchainaccounts.host_genesis_state.params.allow_messages", "*"),
+//	)
+//
+// Paths are keys separated by dots, e.g. "app_state.gov.params", where array elements are selected by index,
+// e.g. "app_state.gov.params.min_deposit.0.denom". An op fails if its path does not exist in the genesis,
+// rather than creating it, so that a mistyped path or the genesis of another chain version is caught.
+func ModifyGenesis(ops ...GenesisOp) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(cfg ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]any)
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		for _, op := range ops {
+			if err := op(cfg, g); err != nil {
+				return nil, fmt.Errorf("failed to modify genesis: %w", err)
+			}
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
+
+// SetPath replaces the value at path with value, which is encoded as JSON.
+func SetPath(path string, value any) GenesisOp {
+	return SetPathFunc(path, func(ibc.ChainConfig) any { return value })
+}
+
+// SetPathFunc replaces the value at path with the value returned by value for the chain's config,
+// e.g. for values depending on the chain's denom.
+func SetPathFunc(path string, value func(ibc.ChainConfig) any) GenesisOp {
+	return func(cfg ibc.ChainConfig, g map[string]any) error {
+		v, err := genesisValue(value(cfg))
+		if err != nil {
+			return fmt.Errorf("set %s: %w", path, err)
+		}
+		return setGenesisPath(g, path, func(any) (any, error) { return v, nil })
+	}
+}
+
+// AppendToArray appends values, which are encoded as JSON, to the array at path.
+func AppendToArray(path string, values ...any) GenesisOp {
+	return func(_ ibc.ChainConfig, g map[string]any) error {
+		v, err := genesisValue(values)
+		if err != nil {
+			return fmt.Errorf("append to %s: %w", path, err)
+		}
+		added, _ := v.([]any) // nil without values.
+		return setGenesisPath(g, path, func(old any) (any, error) {
+			arr, ok := old.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an array", path)
+			}
+			return append(arr, added...), nil
+		})
+	}
+}
+
+// MergeObject merges obj, which is encoded as JSON, into the object at path.
+// Nested objects are merged recursively; any other value of obj replaces the value at its key.
+func MergeObject(path string, obj any) GenesisOp {
+	return func(_ ibc.ChainConfig, g map[string]any) error {
+		v, err := genesisValue(obj)
+		if err != nil {
+			return fmt.Errorf("merge into %s: %w", path, err)
+		}
+		src, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("merge into %s: value is not an object", path)
+		}
+		return setGenesisPath(g, path, func(old any) (any, error) {
+			dst, ok := old.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object", path)
+			}
+			mergeGenesisObjects(dst, src)
+			return dst, nil
+		})
+	}
+}
+
+// DeletePath deletes the key or array element at path.
+func DeletePath(path string) GenesisOp {
+	return func(_ ibc.ChainConfig, g map[string]any) error {
+		parentPath, key := splitLastGenesisPath(path)
+		return setGenesisPath(g, parentPath, func(parent any) (any, error) {
+			switch p := parent.(type) {
+			case map[string]any:
+				if _, ok := p[key]; !ok {
+					return nil, missingGenesisKeyError(parentPath, key, p)
+				}
+				delete(p, key)
+				return p, nil
+			case []any:
+				i, err := genesisIndex(parentPath, key, p)
+				if err != nil {
+					return nil, err
+				}
+				return append(p[:i:i], p[i+1:]...), nil
+			default:
+				return nil, fmt.Errorf("%s is not an object or array", parentPath)
+			}
+		})
+	}
+}
+
+// setGenesisPath replaces the value at path in g with the value returned by update for the current value.
+// An empty path is g itself, which update may modify but not replace.
+func setGenesisPath(g map[string]any, path string, update func(old any) (any, error)) error {
+	if path == "" {
+		_, err := update(g)
+		return err
+	}
+
+	parentPath, key := splitLastGenesisPath(path)
+	parent, err := getGenesisPath(g, parentPath)
+	if err != nil {
+		return err
+	}
+	switch p := parent.(type) {
+	case map[string]any:
+		old, ok := p[key]
+		if !ok {
+			return missingGenesisKeyError(parentPath, key, p)
+		}
+		v, err := update(old)
+		if err != nil {
+			return err
+		}
+		p[key] = v
+		return nil
+	case []any:
+		i, err := genesisIndex(parentPath, key, p)
+		if err != nil {
+			return err
+		}
+		v, err := update(p[i])
+		if err != nil {
+			return err
+		}
+		p[i] = v
+		return nil
+	default:
+		return fmt.Errorf("%s is not an object or array", parentPath)
+	}
+}
+
+// getGenesisPath returns the value at path in g.
+func getGenesisPath(g map[string]any, path string) (any, error) {
+	var cur any = g
+	if path == "" {
+		return cur, nil
+	}
+	keys := strings.Split(path, ".")
+	for n, key := range keys {
+		curPath := strings.Join(keys[:n], ".")
+		switch c := cur.(type) {
+		case map[string]any:
+			v, ok := c[key]
+			if !ok {
+				return nil, missingGenesisKeyError(curPath, key, c)
+			}
+			cur = v
+		case []any:
+			i, err := genesisIndex(curPath, key, c)
+			if err != nil {
+				return nil, err
+			}
+			cur = c[i]
+		default:
+			return nil, fmt.Errorf("%s is not an object or array", curPath)
+		}
+	}
+	return cur, nil
+}
+
+// splitLastGenesisPath splits path into the path of its parent and its last key.
+func splitLastGenesisPath(path string) (parentPath string, key string) {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return "", path
+	}
+	return path[:i], path[i+1:]
+}
+
+// missingGenesisKeyError returns the error for key missing from the object at path, listing the keys the object has.
+func missingGenesisKeyError(path string, key string, obj map[string]any) error {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if path == "" {
+		path = "genesis"
+	}
+	return fmt.Errorf("%s has no key %q, it has keys: %s", path, key, strings.Join(keys, ", "))
+}
+
+// genesisIndex parses key as an index of the array arr at path.
+func genesisIndex(path string, key string, arr []any) (int, error) {
+	i, err := strconv.Atoi(key)
+	if err != nil {
+		return 0, fmt.Errorf("%s is an array, and %q is not an index", path, key)
+	}
+	if i < 0 || i >= len(arr) {
+		return 0, fmt.Errorf("index %d is out of range of %s, which has %d elements", i, path, len(arr))
+	}
+	return i, nil
+}
+
+// mergeGenesisObjects merges src into dst, recursively for nested objects.
+func mergeGenesisObjects(dst map[string]any, src map[string]any) {
+	for k, v := range src {
+		srcObj, srcIsObj := v.(map[string]any)
+		dstObj, dstIsObj := dst[k].(map[string]any)
+		if srcIsObj && dstIsObj {
+			mergeGenesisObjects(dstObj, srcObj)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// genesisValue returns v as decoded from its JSON encoding, like the rest of the genesis,
+// so that e.g. structs and typed slices can be set, and then modified by later ops.
+func genesisValue(v any) (any, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(bz, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,214 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// validateGenesisAccounts returns an error if the chain's GenesisAccounts, ModuleBalances or CommunityPool config is invalid.
+func validateGenesisAccounts(cfg ibc.ChainConfig) error {
+	if _, err := genesisAccountWallets(cfg); err != nil {
+		return err
+	}
+	_, err := moduleGenesisBalances(cfg)
+	return err
+}
+
+// genesisAccountWallets returns the genesis wallets of the accounts of the chain's GenesisAccounts config,
+// one for each of their coins.
+func genesisAccountWallets(cfg ibc.ChainConfig) ([]ibc.WalletAmount, error) {
+	var wallets []ibc.WalletAmount
+	for i, acc := range cfg.GenesisAccounts {
+		addr, err := genesisAccountAddress(cfg, acc)
+		if err != nil {
+			return nil, fmt.Errorf("genesis account %d: %w", i, err)
+		}
+		coins, err := types.ParseCoinsNormalized(acc.Coins)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coins of genesis account %s: %w", addr, err)
+		}
+		for _, coin := range coins {
+			if !coin.Amount.IsInt64() {
+				return nil, fmt.Errorf("amount of %s of genesis account %s exceeds int64", coin.Denom, addr)
+			}
+			wallets = append(wallets, ibc.WalletAmount{Address: addr, Denom: coin.Denom, Amount: coin.Amount.Int64()})
+		}
+	}
+	return wallets, nil
+}
+
+// genesisAccountAddress returns the address of the genesis account acc, derived from its mnemonic if not set.
+func genesisAccountAddress(cfg ibc.ChainConfig, acc ibc.GenesisAccount) (string, error) {
+	switch {
+	case acc.Address != "" && acc.Mnemonic != "":
+		return "", errors.New("set either the address or the mnemonic, not both")
+	case acc.Address != "":
+		if _, err := types.GetFromBech32(acc.Address, cfg.Bech32Prefix); err != nil {
+			return "", fmt.Errorf("invalid address %s: %w", acc.Address, err)
+		}
+		return acc.Address, nil
+	case acc.Mnemonic != "":
+		key, err := offlinePrivKey(acc.Mnemonic, cfg.CoinType, signingAlgo(cfg))
+		if err != nil {
+			return "", err
+		}
+		return types.Bech32ifyAddressBytes(cfg.Bech32Prefix, key.PubKey().Address())
+	default:
+		return "", errors.New("an address or mnemonic is required")
+	}
+}
+
+// moduleGenesisBalances returns the coins added to the balances of module accounts by the chain's ModuleBalances
+// and CommunityPool config, by module account address.
+func moduleGenesisBalances(cfg ibc.ChainConfig) (map[string]types.Coins, error) {
+	balances := make(map[string]types.Coins)
+	add := func(module, coinsStr string) error {
+		coins, err := types.ParseCoinsNormalized(coinsStr)
+		if err != nil {
+			return fmt.Errorf("invalid coins of module %s: %w", module, err)
+		}
+		addr, err := types.Bech32ifyAddressBytes(cfg.Bech32Prefix, authtypes.NewModuleAddress(module))
+		if err != nil {
+			return err
+		}
+		balances[addr] = balances[addr].Add(coins...)
+		return nil
+	}
+	for module, coins := range cfg.ModuleBalances {
+		if err := add(module, coins); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.CommunityPool != "" {
+		if err := add(distrtypes.ModuleName, cfg.CommunityPool); err != nil {
+			return nil, err
+		}
+	}
+	return balances, nil
+}
+
+// setGenesisModuleBalances adds the balances of module accounts of the chain's ModuleBalances and CommunityPool config
+// to the bank balances and supply of the genesis genbz, and the community pool to the distribution fee pool.
+// The module accounts are not added, as they are created by the modules at genesis.
+func setGenesisModuleBalances(genbz []byte, cfg ibc.ChainConfig) ([]byte, error) {
+	g := make(map[string]interface{})
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	added, err := moduleGenesisBalances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(added))
+	for addr := range added {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	balances, err := dyno.GetSlice(g, "app_state", "bank", "balances")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balances from genesis json: %w", err)
+	}
+	var total types.Coins
+	for _, addr := range addrs {
+		balances, err = addGenesisBalance(balances, addr, added[addr])
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(added[addr]...)
+	}
+	if err := dyno.Set(g, balances, "app_state", "bank", "balances"); err != nil {
+		return nil, fmt.Errorf("failed to set balances in genesis json: %w", err)
+	}
+
+	// The supply is computed from the balances at genesis when empty, and must match them otherwise.
+	var supply types.Coins
+	if err := getGenesisJSON(g, &supply, "app_state", "bank", "supply"); err != nil {
+		return nil, err
+	}
+	if len(supply) > 0 {
+		if err := setGenesisJSON(g, supply.Add(total...), "app_state", "bank", "supply"); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.CommunityPool != "" {
+		coins, err := types.ParseCoinsNormalized(cfg.CommunityPool)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coins of community pool: %w", err)
+		}
+		var pool types.DecCoins
+		if err := getGenesisJSON(g, &pool, "app_state", "distribution", "fee_pool", "community_pool"); err != nil {
+			return nil, err
+		}
+		if err := setGenesisJSON(g, pool.Add(types.NewDecCoinsFromCoins(coins...)...), "app_state", "distribution", "fee_pool", "community_pool"); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// addGenesisBalance adds coins to the balance of addr in the genesis balances, adding the balance if missing.
+func addGenesisBalance(balances []interface{}, addr string, coins types.Coins) ([]interface{}, error) {
+	for _, b := range balances {
+		if a, err := dyno.GetString(b, "address"); err != nil || a != addr {
+			continue
+		}
+		var existing types.Coins
+		if err := getGenesisJSON(b, &existing, "coins"); err != nil {
+			return nil, err
+		}
+		if err := setGenesisJSON(b, existing.Add(coins...), "coins"); err != nil {
+			return nil, err
+		}
+		return balances, nil
+	}
+	balance, err := genesisValue(banktypes.Balance{Address: addr, Coins: coins})
+	if err != nil {
+		return nil, err
+	}
+	return append(balances, balance), nil
+}
+
+// getGenesisJSON decodes the genesis value at path of g into v, leaving v unchanged if the value is missing.
+func getGenesisJSON(g interface{}, v any, path ...interface{}) error {
+	value, err := dyno.Get(g, path...)
+	if err != nil {
+		return nil
+	}
+	bz, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %v of genesis json: %w", path, err)
+	}
+	return nil
+}
+
+// setGenesisJSON sets the genesis value at path of g to the JSON encoding of v.
+func setGenesisJSON(g interface{}, v any, path ...interface{}) error {
+	value, err := genesisValue(v)
+	if err != nil {
+		return err
+	}
+	if err := dyno.Set(g, value, path...); err != nil {
+		return fmt.Errorf("failed to set %v in genesis json: %w", path, err)
+	}
+	return nil
+}
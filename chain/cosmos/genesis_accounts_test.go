@@ -0,0 +1,108 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisAccountWallets(t *testing.T) {
+	t.Parallel()
+
+	cfg := ibc.ChainConfig{Bech32Prefix: "cosmos", CoinType: "118"}
+	const addr = "cosmos1hsk6jryyqjfhp5dhc55tc9jtckygx0eph6dd02"
+
+	cfg.GenesisAccounts = []ibc.GenesisAccount{
+		{Address: addr, Coins: "100uatom,5stake"},
+		{Mnemonic: testMnemonic, Coins: "7transfer/channel-0/uosmo"},
+	}
+	wallets, err := genesisAccountWallets(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []ibc.WalletAmount{
+		{Address: addr, Denom: "stake", Amount: 5},
+		{Address: addr, Denom: "uatom", Amount: 100},
+		{Address: "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", Denom: "transfer/channel-0/uosmo", Amount: 7},
+	}, wallets)
+	require.NoError(t, validateGenesisAccounts(cfg))
+
+	for _, tt := range []struct {
+		acc ibc.GenesisAccount
+		err string
+	}{
+		{ibc.GenesisAccount{Coins: "1uatom"}, "genesis account 0: an address or mnemonic is required"},
+		{ibc.GenesisAccount{Address: addr, Mnemonic: testMnemonic, Coins: "1uatom"}, "genesis account 0: set either the address or the mnemonic, not both"},
+		{ibc.GenesisAccount{Address: "osmo1hsk6jryyqjfhp5dhc55tc9jtckygx0epl5j2dr", Coins: "1uatom"}, "genesis account 0: invalid address osmo1hsk6jryyqjfhp5dhc55tc9jtckygx0epl5j2dr"},
+		{ibc.GenesisAccount{Address: addr, Coins: "uatom"}, "invalid coins of genesis account " + addr},
+		{ibc.GenesisAccount{Address: addr, Coins: "10000000000000000000uatom"}, "amount of uatom of genesis account " + addr + " exceeds int64"},
+	} {
+		cfg.GenesisAccounts = []ibc.GenesisAccount{tt.acc}
+		require.ErrorContains(t, validateGenesisAccounts(cfg), tt.err)
+	}
+}
+
+func TestSetGenesisModuleBalances(t *testing.T) {
+	t.Parallel()
+
+	moduleAddr := func(name string) string {
+		return types.MustBech32ifyAddressBytes("cosmos", authtypes.NewModuleAddress(name))
+	}
+	distrAddr, feeCollectorAddr := moduleAddr("distribution"), moduleAddr("fee_collector")
+
+	genbz := []byte(`{"app_state": {
+		"bank": {
+			"balances": [{"address": "` + distrAddr + `", "coins": [{"denom": "uatom", "amount": "10"}]}],
+			"supply": [{"denom": "uatom", "amount": "1000"}]
+		},
+		"distribution": {"fee_pool": {"community_pool": [{"denom": "uatom", "amount": "10.000000000000000000"}]}}
+	}}`)
+	cfg := ibc.ChainConfig{
+		Bech32Prefix:   "cosmos",
+		ModuleBalances: map[string]string{"fee_collector": "3uatom,4stake"},
+		CommunityPool:  "5uatom",
+	}
+	out, err := setGenesisModuleBalances(genbz, cfg)
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Bank struct {
+				Balances []struct {
+					Address string      `json:"address"`
+					Coins   types.Coins `json:"coins"`
+				} `json:"balances"`
+				Supply types.Coins `json:"supply"`
+			} `json:"bank"`
+			Distribution struct {
+				FeePool struct {
+					CommunityPool types.DecCoins `json:"community_pool"`
+				} `json:"fee_pool"`
+			} `json:"distribution"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+
+	balances := make(map[string]types.Coins)
+	for _, b := range g.AppState.Bank.Balances {
+		balances[b.Address] = b.Coins
+	}
+	require.Len(t, balances, 2)
+	require.Equal(t, "15uatom", balances[distrAddr].String())
+	require.Equal(t, "4stake,3uatom", balances[feeCollectorAddr].String())
+	require.Equal(t, "4stake,1008uatom", g.AppState.Bank.Supply.String())
+	require.Equal(t, "15.000000000000000000uatom", g.AppState.Distribution.FeePool.CommunityPool.String())
+
+	// An empty supply is computed from the balances at genesis, so it is left empty.
+	genbz = []byte(`{"app_state": {"bank": {"balances": [], "supply": []}}}`)
+	cfg.CommunityPool = ""
+	out, err = setGenesisModuleBalances(genbz, cfg)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &g))
+	require.Empty(t, g.AppState.Bank.Supply)
+
+	cfg.ModuleBalances = map[string]string{"fee_collector": "-1uatom"}
+	require.ErrorContains(t, validateGenesisAccounts(cfg), "invalid coins of module fee_collector")
+}
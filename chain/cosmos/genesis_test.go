@@ -0,0 +1,222 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+const testGenesis = `{
+	"chain_id": "test-1",
+	"app_state": {
+		"gov": {
+			"params": {
+				"min_deposit": [{"denom": "stake", "amount": "10000000"}],
+				"max_deposit_period": "172800s",
+				"voting_period": "172800s"
+			}
+		},
+		"interchainaccounts": {
+			"host_genesis_state": {"params": {"host_enabled": true, "allow_messages": ["/cosmos.bank.v1beta1.MsgSend"]}}
+		}
+	}
+}`
+
+func TestModifyGenesis(t *testing.T) {
+	t.Parallel()
+
+	cfg := ibc.ChainConfig{Denom: "uatom"}
+	modify := ModifyGenesis(
+		SetPath("app_state.gov.params.voting_period", "20s"),
+		SetPathFunc("app_state.gov.params.min_deposit.0.denom", func(cfg ibc.ChainConfig) any { return cfg.Denom }),
+		AppendToArray("app_state.interchainaccounts.host_genesis_state.params.allow_messages", "/cosmos.staking.v1beta1.MsgDelegate"),
+		MergeObject("app_state.interchainaccounts", map[string]any{
+			"host_genesis_state":       map[string]any{"params": map[string]any{"host_enabled": false}},
+			"controller_genesis_state": map[string]any{"params": map[string]any{"controller_enabled": true}},
+		}),
+		DeletePath("app_state.gov.params.max_deposit_period"),
+		DeletePath("chain_id"),
+	)
+
+	out, err := modify(cfg, []byte(testGenesis))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"app_state": {
+			"gov": {
+				"params": {
+					"min_deposit": [{"denom": "uatom", "amount": "10000000"}],
+					"voting_period": "20s"
+				}
+			},
+			"interchainaccounts": {
+				"host_genesis_state": {"params": {"host_enabled": false, "allow_messages": ["/cosmos.bank.v1beta1.MsgSend", "/cosmos.staking.v1beta1.MsgDelegate"]}},
+				"controller_genesis_state": {"params": {"controller_enabled": true}}
+			}
+		}
+	}`, string(out))
+}
+
+func TestModifyGenesis_Values(t *testing.T) {
+	t.Parallel()
+
+	type coin struct {
+		Denom  string `json:"denom"`
+		Amount string `json:"amount"`
+	}
+	modify := ModifyGenesis(
+		// Typed values are encoded as JSON, so that later ops can modify them.
+		SetPath("app_state.gov.params.min_deposit", []coin{{Denom: "uatom", Amount: "1"}}),
+		AppendToArray("app_state.gov.params.min_deposit", coin{Denom: "uosmo", Amount: "2"}),
+		SetPath("app_state.gov.params.min_deposit.1.amount", "3"),
+		AppendToArray("app_state.gov.params.min_deposit"),
+	)
+
+	out, err := modify(ibc.ChainConfig{}, []byte(testGenesis))
+	require.NoError(t, err)
+
+	got, err := getGenesisPath(decodeGenesis(t, out), "app_state.gov.params.min_deposit")
+	require.NoError(t, err)
+	require.Equal(t, []any{
+		map[string]any{"denom": "uatom", "amount": "1"},
+		map[string]any{"denom": "uosmo", "amount": "3"},
+	}, got)
+}
+
+func TestModifyGenesis_Errors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		op  GenesisOp
+		err string
+	}{
+		"missing key": {
+			op:  SetPath("app_state.gov.voting_params.voting_period", "20s"),
+			err: `app_state.gov has no key "voting_params", it has keys: params`,
+		},
+		"missing last key": {
+			op:  SetPath("app_state.gov.params.voting_perod", "20s"),
+			err: `app_state.gov.params has no key "voting_perod", it has keys: max_deposit_period, min_deposit, voting_period`,
+		},
+		"missing top level key": {
+			op:  SetPath("genesis_time", "2023-01-01T00:00:00Z"),
+			err: `genesis has no key "genesis_time", it has keys: app_state, chain_id`,
+		},
+		"index out of range": {
+			op:  SetPath("app_state.gov.params.min_deposit.1.denom", "uatom"),
+			err: "index 1 is out of range of app_state.gov.params.min_deposit, which has 1 elements",
+		},
+		"not an index": {
+			op:  SetPath("app_state.gov.params.min_deposit.denom", "uatom"),
+			err: `app_state.gov.params.min_deposit is an array, and "denom" is not an index`,
+		},
+		"path through value": {
+			op:  SetPath("chain_id.name", "test"),
+			err: "chain_id is not an object or array",
+		},
+		"append to object": {
+			op:  AppendToArray("app_state.gov.params", "x"),
+			err: "app_state.gov.params is not an array",
+		},
+		"merge into array": {
+			op:  MergeObject("app_state.gov.params.min_deposit", map[string]any{"denom": "uatom"}),
+			err: "app_state.gov.params.min_deposit is not an object",
+		},
+		"merge value": {
+			op:  MergeObject("app_state.gov.params", "20s"),
+			err: "merge into app_state.gov.params: value is not an object",
+		},
+		"delete missing key": {
+			op:  DeletePath("app_state.gov.params.quorum"),
+			err: `app_state.gov.params has no key "quorum", it has keys: max_deposit_period, min_deposit, voting_period`,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ModifyGenesis(tc.op)(ibc.ChainConfig{}, []byte(testGenesis))
+			require.EqualError(t, err, "failed to modify genesis: "+tc.err)
+		})
+	}
+}
+
+// TestGenesisOps_Paths runs each op on a present path, and on paths missing their last or an intermediate key,
+// which are errors rather than created, unlike dyno.Set.
+func TestGenesisOps_Paths(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		op      func(path string) GenesisOp
+		present string
+		want    any
+		missing string
+		err     string
+		deleted bool
+	}{
+		{
+			name:    "SetPath",
+			op:      func(path string) GenesisOp { return SetPath(path, "20s") },
+			present: "app_state.gov.params.voting_period",
+			want:    "20s",
+			missing: "app_state.gov.params.voting_periodx",
+			err:     `app_state.gov.params has no key "voting_periodx", it has keys: max_deposit_period, min_deposit, voting_period`,
+		},
+		{
+			name:    "AppendToArray",
+			op:      func(path string) GenesisOp { return AppendToArray(path, "/cosmos.staking.v1beta1.MsgDelegate") },
+			present: "app_state.interchainaccounts.host_genesis_state.params.allow_messages",
+			want:    []any{"/cosmos.bank.v1beta1.MsgSend", "/cosmos.staking.v1beta1.MsgDelegate"},
+			missing: "app_state.interchainaccounts.host_genesis_state.params.allow_queries",
+			err:     `app_state.interchainaccounts.host_genesis_state.params has no key "allow_queries", it has keys: allow_messages, host_enabled`,
+		},
+		{
+			name:    "MergeObject",
+			op:      func(path string) GenesisOp { return MergeObject(path, map[string]any{"host_enabled": false}) },
+			present: "app_state.interchainaccounts.host_genesis_state.params",
+			want:    map[string]any{"host_enabled": false, "allow_messages": []any{"/cosmos.bank.v1beta1.MsgSend"}},
+			missing: "app_state.interchainaccounts.controller_genesis_state.params",
+			err:     `app_state.interchainaccounts has no key "controller_genesis_state", it has keys: host_genesis_state`,
+		},
+		{
+			name:    "DeletePath",
+			op:      DeletePath,
+			present: "app_state.gov.params.max_deposit_period",
+			deleted: true,
+			missing: "app_state.gov.params.quorum",
+			err:     `app_state.gov.params has no key "quorum", it has keys: max_deposit_period, min_deposit, voting_period`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := ModifyGenesis(tc.op(tc.present))(ibc.ChainConfig{}, []byte(testGenesis))
+			require.NoError(t, err)
+			got, err := getGenesisPath(decodeGenesis(t, out), tc.present)
+			if tc.deleted {
+				require.ErrorContains(t, err, "has no key")
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+
+			_, err = ModifyGenesis(tc.op(tc.missing))(ibc.ChainConfig{}, []byte(testGenesis))
+			require.EqualError(t, err, "failed to modify genesis: "+tc.err)
+
+			// Intermediate keys are not created either.
+			_, err = ModifyGenesis(tc.op("app_state.staking.params.max_validators"))(ibc.ChainConfig{}, []byte(testGenesis))
+			require.EqualError(t, err, `failed to modify genesis: app_state has no key "staking", it has keys: gov, interchainaccounts`)
+		})
+	}
+}
+
+func decodeGenesis(t *testing.T, genbz []byte) map[string]any {
+	t.Helper()
+
+	var g map[string]any
+	require.NoError(t, json.Unmarshal(genbz, &g))
+	return g
+}
@@ -0,0 +1,42 @@
+package cosmos
+
+import (
+	"github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// ibcGenesisWallets returns wallets with the IBC denom traces of their denoms, e.g. transfer/channel-0/uatom,
+// replaced by the IBC denoms of the vouchers, along with the traces, which must be added to the genesis of the transfer module.
+func ibcGenesisWallets(wallets []ibc.WalletAmount) ([]ibc.WalletAmount, []transfertypes.DenomTrace) {
+	out := make([]ibc.WalletAmount, len(wallets))
+	var traces []transfertypes.DenomTrace
+	seen := make(map[string]bool)
+	for i, wallet := range wallets {
+		out[i] = wallet
+		trace := transfertypes.ParseDenomTrace(wallet.Denom)
+		if trace.Path == "" {
+			continue
+		}
+		out[i].Denom = trace.IBCDenom()
+		if !seen[out[i].Denom] {
+			seen[out[i].Denom] = true
+			traces = append(traces, trace)
+		}
+	}
+	return out, traces
+}
+
+// genesisAccountCoins returns the addresses of wallets, in order, and the coins of all wallets of each address,
+// since a genesis account can only be added once.
+func genesisAccountCoins(wallets []ibc.WalletAmount) ([]string, map[string]types.Coins) {
+	var addrs []string
+	coins := make(map[string]types.Coins)
+	for _, wallet := range wallets {
+		if _, ok := coins[wallet.Address]; !ok {
+			addrs = append(addrs, wallet.Address)
+		}
+		coins[wallet.Address] = coins[wallet.Address].Add(types.Coin{Denom: wallet.Denom, Amount: types.NewInt(wallet.Amount)})
+	}
+	return addrs, coins
+}
@@ -0,0 +1,50 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBCGenesisWallets(t *testing.T) {
+	t.Parallel()
+
+	const voucher = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	wallets := []ibc.WalletAmount{
+		{Address: "cosmos1a", Denom: "uatom", Amount: 1},
+		{Address: "cosmos1a", Denom: "transfer/channel-0/uatom", Amount: 2},
+		{Address: "cosmos1b", Denom: "transfer/channel-0/uatom", Amount: 3},
+		{Address: "cosmos1b", Denom: voucher, Amount: 4},
+		{Address: "cosmos1b", Denom: "factory/cosmos1a/utest", Amount: 5},
+	}
+
+	got, traces := ibcGenesisWallets(wallets)
+	require.Equal(t, []ibc.WalletAmount{
+		{Address: "cosmos1a", Denom: "uatom", Amount: 1},
+		{Address: "cosmos1a", Denom: voucher, Amount: 2},
+		{Address: "cosmos1b", Denom: voucher, Amount: 3},
+		{Address: "cosmos1b", Denom: voucher, Amount: 4},
+		{Address: "cosmos1b", Denom: "factory/cosmos1a/utest", Amount: 5},
+	}, got)
+	require.Equal(t, []transfertypes.DenomTrace{{Path: "transfer/channel-0", BaseDenom: "uatom"}}, traces)
+	require.Equal(t, "transfer/channel-0/uatom", wallets[1].Denom, "wallets must not be modified")
+}
+
+func TestGenesisAccountCoins(t *testing.T) {
+	t.Parallel()
+
+	addrs, coins := genesisAccountCoins([]ibc.WalletAmount{
+		{Address: "cosmos1b", Denom: "uatom", Amount: 1},
+		{Address: "cosmos1a", Denom: "utest", Amount: 2},
+		{Address: "cosmos1b", Denom: "utest", Amount: 3},
+		{Address: "cosmos1b", Denom: "uatom", Amount: 4},
+	})
+	require.Equal(t, []string{"cosmos1b", "cosmos1a"}, addrs)
+	require.Equal(t, map[string]types.Coins{
+		"cosmos1a": types.NewCoins(types.NewInt64Coin("utest", 2)),
+		"cosmos1b": types.NewCoins(types.NewInt64Coin("uatom", 5), types.NewInt64Coin("utest", 3)),
+	}, coins)
+}
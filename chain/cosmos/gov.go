@@ -0,0 +1,153 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// SubmitProposal submits a gov v1 proposal to the chain, signed by keyName.
+func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop ProposalV1) (string, error) {
+	return tn.submitProposal(ctx, keyName, prop)
+}
+
+// submitProposal submits a gov v1 proposal to the chain, signed by keyName, with additional tx flags, e.g. of gas.
+func (tn *ChainNode) submitProposal(ctx context.Context, keyName string, prop ProposalV1, flags ...string) (string, error) {
+	content, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+	proposalFilename := fmt.Sprintf("%x.json", hash)
+	err = tn.WriteFile(ctx, content, proposalFilename)
+	if err != nil {
+		return "", fmt.Errorf("writing proposal: %w", err)
+	}
+
+	proposalPath := filepath.Join(tn.HomeDir(), proposalFilename)
+
+	command := []string{
+		"gov", "submit-proposal",
+		proposalPath,
+	}
+
+	return tn.ExecTx(ctx, keyName, append(command, flags...)...)
+}
+
+// newProposalV1 returns a proposal executing msgs, encoded by cdc, with a title and summary describing msgs.
+func newProposalV1(cdc codec.Codec, msgs []types.Msg, deposit string) (ProposalV1, error) {
+	if len(msgs) == 0 {
+		return ProposalV1{}, errors.New("proposal must have at least one message")
+	}
+
+	prop := ProposalV1{Deposit: deposit}
+	typeURLs := make([]string, len(msgs))
+	for i, msg := range msgs {
+		bz, err := cdc.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return ProposalV1{}, fmt.Errorf("encode message %d (%T): %w", i, msg, err)
+		}
+		prop.Messages = append(prop.Messages, bz)
+		typeURLs[i] = types.MsgTypeURL(msg)
+	}
+
+	// The title is limited to the length of the metadata, so it names only the first message.
+	prop.Title = "Execute " + typeURLs[0]
+	if len(msgs) > 1 {
+		prop.Title += fmt.Sprintf(" and %d more", len(msgs)-1)
+	}
+	prop.Summary = "Executes " + strings.Join(typeURLs, ", ")
+	return prop, nil
+}
+
+// GovAuthority returns the address of the gov module account,
+// the authority of messages such as MsgUpdateParams that modules only accept from governance.
+func (c *CosmosChain) GovAuthority() string {
+	return types.MustBech32ifyAddressBytes(c.cfg.Bech32Prefix, authtypes.NewModuleAddress(govtypes.ModuleName))
+}
+
+// SubmitProposal submits a gov v1 proposal executing msgs to the chain, signed by proposer, with deposit, e.g. "10000000stake".
+// The messages of any module can be proposed, as long as the chain's EncodingConfig has the message type registered.
+// Use GovAuthority as the authority or signer of the messages.
+func (c *CosmosChain) SubmitProposal(ctx context.Context, proposer string, msgs []types.Msg, deposit string) (tx TxProposal, _ error) {
+	prop, err := newProposalV1(c.cfg.EncodingConfig.Codec, msgs, deposit)
+	if err != nil {
+		return tx, err
+	}
+	txHash, err := c.getFullNode().SubmitProposal(ctx, proposer, prop)
+	if err != nil {
+		return tx, fmt.Errorf("failed to submit proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// VoteAll submits a vote, such as ProposalVoteYes, from every validator for the specified proposal.
+func (c *CosmosChain) VoteAll(ctx context.Context, proposalID string, vote string) error {
+	var eg errgroup.Group
+	for _, n := range c.Validators {
+		n := n
+		eg.Go(func() error {
+			if err := n.VoteOnProposal(ctx, valKey, proposalID, vote); err != nil {
+				return fmt.Errorf("node %s: %w", n.Name(), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// QueryProposalV1 returns the state and details of a gov v1 proposal, including its messages.
+func (c *CosmosChain) QueryProposalV1(ctx context.Context, proposalID string) (*govv1.Proposal, error) {
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal id %q: %w", proposalID, err)
+	}
+
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient := govv1.NewQueryClient(conn)
+	res, err := queryClient.Proposal(ctx, &govv1.QueryProposalRequest{ProposalId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Proposal, nil
+}
+
+// WaitForProposalStatus polls the gov v1 proposal for up to the given number of blocks,
+// until its status matches status, such as ProposalStatusPassed.
+func (c *CosmosChain) WaitForProposalStatus(ctx context.Context, proposalID string, status string, blocks uint64) (*govv1.Proposal, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height: %w", err)
+	}
+	doPoll := func(ctx context.Context, height uint64) (*govv1.Proposal, error) {
+		p, err := c.QueryProposalV1(ctx, proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if p.Status.String() != status {
+			return nil, fmt.Errorf("proposal status (%s) does not match expected: (%s)", p.Status, status)
+		}
+		return p, nil
+	}
+	bp := testutil.BlockPoller[*govv1.Proposal]{CurrentHeight: c.Height, PollFunc: doPoll}
+	return bp.DoPoll(ctx, height, height+blocks)
+}
@@ -0,0 +1,84 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProposalV1(t *testing.T) {
+	t.Parallel()
+
+	cdc := DefaultEncoding().Codec
+
+	send := &banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      types.NewCoins(types.NewInt64Coin("stake", 5)),
+	}
+	params := &banktypes.MsgUpdateParams{
+		Authority: "cosmos1gov",
+		Params:    banktypes.DefaultParams(),
+	}
+
+	t.Run("one message", func(t *testing.T) {
+		prop, err := newProposalV1(cdc, []types.Msg{send}, "10stake")
+		require.NoError(t, err)
+
+		require.Equal(t, "10stake", prop.Deposit)
+		require.Equal(t, "Execute /cosmos.bank.v1beta1.MsgSend", prop.Title)
+		require.Equal(t, "Executes /cosmos.bank.v1beta1.MsgSend", prop.Summary)
+		require.Len(t, prop.Messages, 1)
+
+		var msg map[string]any
+		require.NoError(t, json.Unmarshal(prop.Messages[0], &msg))
+		require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", msg["@type"])
+		require.Equal(t, "cosmos1from", msg["from_address"])
+
+		// The chain decodes the messages of the proposal file with its codec.
+		var decoded types.Msg
+		require.NoError(t, cdc.UnmarshalInterfaceJSON(prop.Messages[0], &decoded))
+		require.Equal(t, send, decoded)
+	})
+
+	t.Run("many messages", func(t *testing.T) {
+		prop, err := newProposalV1(cdc, []types.Msg{params, send}, "10stake")
+		require.NoError(t, err)
+
+		require.Equal(t, "Execute /cosmos.bank.v1beta1.MsgUpdateParams and 1 more", prop.Title)
+		require.Equal(t, "Executes /cosmos.bank.v1beta1.MsgUpdateParams, /cosmos.bank.v1beta1.MsgSend", prop.Summary)
+		require.Len(t, prop.Messages, 2)
+
+		bz, err := json.Marshal(prop)
+		require.NoError(t, err)
+		var file map[string]any
+		require.NoError(t, json.Unmarshal(bz, &file))
+		require.Len(t, file, 5)
+		for _, k := range []string{"messages", "metadata", "deposit", "title", "summary"} {
+			require.Contains(t, file, k)
+		}
+	})
+
+	t.Run("no messages", func(t *testing.T) {
+		_, err := newProposalV1(cdc, nil, "10stake")
+		require.EqualError(t, err, "proposal must have at least one message")
+	})
+
+	t.Run("unregistered message", func(t *testing.T) {
+		_, err := newProposalV1(cdc, []types.Msg{&authz.MsgRevoke{}}, "10stake")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "encode message 0 (*authz.MsgRevoke)")
+	})
+}
+
+func TestCosmosChain_GovAuthority(t *testing.T) {
+	t.Parallel()
+
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{Bech32Prefix: "cosmos"}, 1, 0, nil)
+	require.Equal(t, "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn", chain.GovAuthority())
+}
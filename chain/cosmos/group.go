@@ -0,0 +1,279 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/group"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/tendermint"
+)
+
+// GroupMember is a member of a group of the group module, with a weight of its votes, e.g. "1".
+type GroupMember struct {
+	Address  string `json:"address"`
+	Weight   string `json:"weight"`
+	Metadata string `json:"metadata"`
+}
+
+// GroupDecisionPolicy is the decision policy of a group policy, which accepts a proposal once the weight of
+// its yes votes reaches Threshold, or the share of yes votes reaches Percentage. Exactly one must be set.
+type GroupDecisionPolicy struct {
+	// Threshold is the weight of yes votes accepting a proposal, e.g. "2", for a threshold decision policy.
+	Threshold string
+	// Percentage is the share of the group's weight in yes votes accepting a proposal, e.g. "0.5",
+	// for a percentage decision policy.
+	Percentage string
+	// VotingPeriod is how long proposals can be voted on after they are submitted.
+	VotingPeriod time.Duration
+	// MinExecutionPeriod is how long after a proposal is submitted it can be executed at the earliest.
+	MinExecutionPeriod time.Duration
+}
+
+// decisionPolicyJSON returns the JSON of the decision policy file of the create-group-policy command.
+func (p GroupDecisionPolicy) decisionPolicyJSON() ([]byte, error) {
+	if (p.Threshold == "") == (p.Percentage == "") {
+		return nil, errors.New("group decision policy must have either a threshold or a percentage")
+	}
+	if p.VotingPeriod <= 0 {
+		return nil, errors.New("group decision policy must have a voting period")
+	}
+	policy := map[string]any{
+		"windows": map[string]string{
+			"voting_period":        protoDuration(p.VotingPeriod),
+			"min_execution_period": protoDuration(p.MinExecutionPeriod),
+		},
+	}
+	if p.Threshold != "" {
+		policy["@type"] = "/cosmos.group.v1.ThresholdDecisionPolicy"
+		policy["threshold"] = p.Threshold
+	} else {
+		policy["@type"] = "/cosmos.group.v1.PercentageDecisionPolicy"
+		policy["percentage"] = p.Percentage
+	}
+	return json.Marshal(policy)
+}
+
+// protoDuration returns d in the JSON encoding of a protobuf Duration, e.g. "1.5s".
+func protoDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// GroupProposal defines a proposal of a group policy, in the format of the proposal file of the group submit-proposal command.
+type GroupProposal struct {
+	GroupPolicyAddress string `json:"group_policy_address"`
+	// Messages executed by the group policy if the proposal is accepted, each encoded as JSON by the chain's codec.
+	Messages  []json.RawMessage `json:"messages"`
+	Metadata  string            `json:"metadata"`
+	Proposers []string          `json:"proposers"`
+	Title     string            `json:"title"`
+	Summary   string            `json:"summary"`
+}
+
+// newGroupProposal returns a proposal of the group policy at policyAddress executing msgs, encoded by cdc,
+// proposed by proposer.
+func newGroupProposal(cdc codec.Codec, policyAddress, proposer string, msgs []types.Msg, title, summary string) (GroupProposal, error) {
+	if len(msgs) == 0 {
+		return GroupProposal{}, errors.New("group proposal must have at least one message")
+	}
+	prop := GroupProposal{
+		GroupPolicyAddress: policyAddress,
+		Proposers:          []string{proposer},
+		Title:              title,
+		Summary:            summary,
+	}
+	for i, msg := range msgs {
+		bz, err := cdc.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return GroupProposal{}, fmt.Errorf("encode message %d (%T): %w", i, msg, err)
+		}
+		prop.Messages = append(prop.Messages, bz)
+	}
+	return prop, nil
+}
+
+// writeGroupFile writes content, a JSON file of a group command, to the home directory of the node, and returns its path.
+func (tn *ChainNode) writeGroupFile(ctx context.Context, content []byte) (string, error) {
+	hash := sha256.Sum256(content)
+	filename := fmt.Sprintf("%x.json", hash)
+	if err := tn.WriteFile(ctx, content, filename); err != nil {
+		return "", err
+	}
+	return filepath.Join(tn.HomeDir(), filename), nil
+}
+
+// CreateGroup creates a group with members, administered by the account of keyName.
+func (tn *ChainNode) CreateGroup(ctx context.Context, keyName string, metadata string, members []GroupMember) (string, error) {
+	content, err := json.Marshal(map[string][]GroupMember{"members": members})
+	if err != nil {
+		return "", err
+	}
+	membersPath, err := tn.writeGroupFile(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("writing group members: %w", err)
+	}
+	return tn.ExecTx(ctx, keyName, "group", "create-group", keyName, metadata, membersPath)
+}
+
+// CreateGroupPolicy creates a group policy of the group with groupID, administered by the account of keyName,
+// which executes the proposals accepted by policy.
+func (tn *ChainNode) CreateGroupPolicy(ctx context.Context, keyName string, groupID uint64, metadata string, policy GroupDecisionPolicy) (string, error) {
+	content, err := policy.decisionPolicyJSON()
+	if err != nil {
+		return "", err
+	}
+	policyPath, err := tn.writeGroupFile(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("writing group decision policy: %w", err)
+	}
+	return tn.ExecTx(ctx, keyName,
+		"group", "create-group-policy", keyName, strconv.FormatUint(groupID, 10), metadata, policyPath,
+	)
+}
+
+// SubmitGroupProposal submits a group proposal, signed by keyName, which must be its first proposer.
+// If exec is true, the proposal is executed right after it is submitted, if the votes of its proposers accept it.
+func (tn *ChainNode) SubmitGroupProposal(ctx context.Context, keyName string, prop GroupProposal, exec bool) (string, error) {
+	content, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+	proposalPath, err := tn.writeGroupFile(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("writing group proposal: %w", err)
+	}
+	command := []string{"group", "submit-proposal", proposalPath}
+	if exec {
+		command = append(command, "--exec", "try")
+	}
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// GroupVote votes option on the group proposal with proposalID, from the group member of keyName.
+// If exec is true, the proposal is executed right after the vote, if the votes accept it.
+func (tn *ChainNode) GroupVote(ctx context.Context, keyName string, proposalID uint64, option group.VoteOption, exec bool) (string, error) {
+	command := []string{"group", "vote", strconv.FormatUint(proposalID, 10), keyName, option.String(), ""}
+	if exec {
+		command = append(command, "--exec", "try")
+	}
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// GroupExec executes the accepted group proposal with proposalID, signed by keyName.
+func (tn *ChainNode) GroupExec(ctx context.Context, keyName string, proposalID uint64) (string, error) {
+	return tn.ExecTx(ctx, keyName, "group", "exec", strconv.FormatUint(proposalID, 10))
+}
+
+// groupEventAttribute returns the value of the attribute attrKey of the typed event eventType of the group module,
+// whose values are encoded as JSON, e.g. the group_id of cosmos.group.v1.EventCreateGroup.
+func groupEventAttribute(events []abcitypes.Event, eventType, attrKey string) (string, error) {
+	value, ok := tendermint.AttributeValue(events, eventType, attrKey)
+	if !ok {
+		return "", fmt.Errorf("no %s attribute of event %s", attrKey, eventType)
+	}
+	var s string
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return "", fmt.Errorf("failed to unmarshal %s attribute %s of event %s: %w", attrKey, value, eventType, err)
+	}
+	return s, nil
+}
+
+// groupTxEventAttribute returns the value of the attribute attrKey of the typed event eventType in the tx with txHash.
+func (c *CosmosChain) groupTxEventAttribute(txHash, eventType, attrKey string) (string, error) {
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	return groupEventAttribute(txResp.Events, eventType, attrKey)
+}
+
+// CreateGroup creates a group with members, administered by the account of keyName, and returns the ID of the group.
+func (c *CosmosChain) CreateGroup(ctx context.Context, keyName string, metadata string, members []GroupMember) (uint64, error) {
+	txHash, err := c.getFullNode().CreateGroup(ctx, keyName, metadata, members)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create group: %w", err)
+	}
+	id, err := c.groupTxEventAttribute(txHash, "cosmos.group.v1.EventCreateGroup", "group_id")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(id, 10, 64)
+}
+
+// CreateGroupPolicy creates a group policy of the group with groupID, administered by the account of keyName,
+// and returns the address of the group policy, which executes the messages of the proposals accepted by policy.
+// Use it as the signer of the messages of group proposals, and fund it to pay for them.
+func (c *CosmosChain) CreateGroupPolicy(ctx context.Context, keyName string, groupID uint64, metadata string, policy GroupDecisionPolicy) (string, error) {
+	txHash, err := c.getFullNode().CreateGroupPolicy(ctx, keyName, groupID, metadata, policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to create group policy: %w", err)
+	}
+	return c.groupTxEventAttribute(txHash, "cosmos.group.v1.EventCreateGroupPolicy", "address")
+}
+
+// SubmitGroupProposal submits a proposal of the group policy at policyAddress executing msgs, proposed by the group member
+// of keyName, and returns the ID of the proposal. If exec is true, the proposal is executed right after it is submitted,
+// if the vote of its proposer accepts it. The messages must be registered in the chain's EncodingConfig.
+func (c *CosmosChain) SubmitGroupProposal(ctx context.Context, keyName string, policyAddress string, msgs []types.Msg, title, summary string, exec bool) (uint64, error) {
+	tn := c.getFullNode()
+	proposer, err := tn.AccountKeyBech32(ctx, keyName)
+	if err != nil {
+		return 0, err
+	}
+	prop, err := newGroupProposal(c.cfg.EncodingConfig.Codec, policyAddress, proposer, msgs, title, summary)
+	if err != nil {
+		return 0, err
+	}
+	txHash, err := tn.SubmitGroupProposal(ctx, keyName, prop, exec)
+	if err != nil {
+		return 0, fmt.Errorf("failed to submit group proposal: %w", err)
+	}
+	id, err := c.groupTxEventAttribute(txHash, "cosmos.group.v1.EventSubmitProposal", "proposal_id")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(id, 10, 64)
+}
+
+// GroupVote votes option on the group proposal with proposalID, from the group member of keyName.
+// If exec is true, the proposal is executed right after the vote, if the votes accept it.
+func (c *CosmosChain) GroupVote(ctx context.Context, keyName string, proposalID uint64, option group.VoteOption, exec bool) error {
+	if _, err := c.getFullNode().GroupVote(ctx, keyName, proposalID, option, exec); err != nil {
+		return fmt.Errorf("failed to vote on group proposal %d: %w", proposalID, err)
+	}
+	return nil
+}
+
+// GroupExec executes the accepted group proposal with proposalID, signed by keyName.
+// The tx succeeds even if executing the messages of the proposal fails; check the executor result with QueryGroupProposal.
+func (c *CosmosChain) GroupExec(ctx context.Context, keyName string, proposalID uint64) error {
+	if _, err := c.getFullNode().GroupExec(ctx, keyName, proposalID); err != nil {
+		return fmt.Errorf("failed to execute group proposal %d: %w", proposalID, err)
+	}
+	return nil
+}
+
+// QueryGroupProposal returns the group proposal with proposalID, including its status and executor result.
+// Proposals are pruned once executed successfully, or once their voting period ended.
+func (c *CosmosChain) QueryGroupProposal(ctx context.Context, proposalID uint64) (*group.Proposal, error) {
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient := group.NewQueryClient(conn)
+	res, err := queryClient.Proposal(ctx, &group.QueryProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Proposal, nil
+}
@@ -0,0 +1,85 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupDecisionPolicyJSON(t *testing.T) {
+	t.Parallel()
+
+	bz, err := GroupDecisionPolicy{Threshold: "2", VotingPeriod: 2 * time.Minute}.decisionPolicyJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"@type":"/cosmos.group.v1.ThresholdDecisionPolicy",
+		"threshold":"2",
+		"windows":{"voting_period":"120s","min_execution_period":"0s"}
+	}`, string(bz))
+
+	bz, err = GroupDecisionPolicy{Percentage: "0.5", VotingPeriod: time.Hour, MinExecutionPeriod: 1500 * time.Millisecond}.decisionPolicyJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"@type":"/cosmos.group.v1.PercentageDecisionPolicy",
+		"percentage":"0.5",
+		"windows":{"voting_period":"3600s","min_execution_period":"1.5s"}
+	}`, string(bz))
+
+	_, err = GroupDecisionPolicy{VotingPeriod: time.Hour}.decisionPolicyJSON()
+	require.EqualError(t, err, "group decision policy must have either a threshold or a percentage")
+	_, err = GroupDecisionPolicy{Threshold: "1", Percentage: "0.5", VotingPeriod: time.Hour}.decisionPolicyJSON()
+	require.EqualError(t, err, "group decision policy must have either a threshold or a percentage")
+	_, err = GroupDecisionPolicy{Threshold: "1"}.decisionPolicyJSON()
+	require.EqualError(t, err, "group decision policy must have a voting period")
+}
+
+func TestNewGroupProposal(t *testing.T) {
+	t.Parallel()
+
+	send := &banktypes.MsgSend{
+		FromAddress: "cosmos1policy",
+		ToAddress:   "cosmos1to",
+		Amount:      types.NewCoins(types.NewInt64Coin("stake", 5)),
+	}
+	prop, err := newGroupProposal(DefaultEncoding().Codec, "cosmos1policy", "cosmos1member", []types.Msg{send}, "Send", "Sends 5stake")
+	require.NoError(t, err)
+
+	bz, err := json.Marshal(prop)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"group_policy_address":"cosmos1policy",
+		"messages":[{
+			"@type":"/cosmos.bank.v1beta1.MsgSend",
+			"from_address":"cosmos1policy","to_address":"cosmos1to","amount":[{"denom":"stake","amount":"5"}]
+		}],
+		"metadata":"",
+		"proposers":["cosmos1member"],
+		"title":"Send",
+		"summary":"Sends 5stake"
+	}`, string(bz))
+
+	_, err = newGroupProposal(DefaultEncoding().Codec, "cosmos1policy", "cosmos1member", nil, "", "")
+	require.EqualError(t, err, "group proposal must have at least one message")
+}
+
+func TestGroupEventAttribute(t *testing.T) {
+	t.Parallel()
+
+	events := []abcitypes.Event{{
+		Type: "cosmos.group.v1.EventCreateGroup",
+		Attributes: []abcitypes.EventAttribute{
+			{Key: "group_id", Value: `"3"`},
+		},
+	}}
+	id, err := groupEventAttribute(events, "cosmos.group.v1.EventCreateGroup", "group_id")
+	require.NoError(t, err)
+	require.Equal(t, "3", id)
+
+	_, err = groupEventAttribute(events, "cosmos.group.v1.EventSubmitProposal", "proposal_id")
+	require.EqualError(t, err, "no proposal_id attribute of event cosmos.group.v1.EventSubmitProposal")
+}
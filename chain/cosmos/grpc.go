@@ -0,0 +1,88 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GRPCConn returns a connection to the gRPC server of the node, through its host port, dialed on first use.
+// The connection is shared by all callers, so do not close it. It is closed when the node's container is stopped
+// or removed, and dialed again on next use, since the host port changes when the container is restarted.
+func (tn *ChainNode) GRPCConn(ctx context.Context) (*grpc.ClientConn, error) {
+	tn.grpcLock.Lock()
+	defer tn.grpcLock.Unlock()
+
+	if tn.grpcConn != nil {
+		return tn.grpcConn, nil
+	}
+	if tn.hostGRPCPort == "" {
+		return nil, fmt.Errorf("node %s has not been started", tn.Name())
+	}
+	conn, err := grpc.DialContext(ctx, tn.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial grpc of node %s: %w", tn.Name(), err)
+	}
+	tn.grpcConn = conn
+	return conn, nil
+}
+
+// closeGRPCConn closes the connection returned by GRPCConn, if any.
+func (tn *ChainNode) closeGRPCConn() error {
+	tn.grpcLock.Lock()
+	defer tn.grpcLock.Unlock()
+
+	if tn.grpcConn == nil {
+		return nil
+	}
+	err := tn.grpcConn.Close()
+	tn.grpcConn = nil
+	return err
+}
+
+// QueryClients are gRPC query clients of common modules of a node.
+type QueryClients struct {
+	Bank          banktypes.QueryClient
+	Staking       stakingtypes.QueryClient
+	Gov           govv1.QueryClient
+	IBCClient     clienttypes.QueryClient
+	IBCConnection connectiontypes.QueryClient
+	IBCChannel    chantypes.QueryClient
+	IBCTransfer   transfertypes.QueryClient
+}
+
+// QueryClients returns gRPC query clients of common modules, using the connection returned by GRPCConn.
+func (tn *ChainNode) QueryClients(ctx context.Context) (*QueryClients, error) {
+	conn, err := tn.GRPCConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryClients{
+		Bank:          banktypes.NewQueryClient(conn),
+		Staking:       stakingtypes.NewQueryClient(conn),
+		Gov:           govv1.NewQueryClient(conn),
+		IBCClient:     clienttypes.NewQueryClient(conn),
+		IBCConnection: connectiontypes.NewQueryClient(conn),
+		IBCChannel:    chantypes.NewQueryClient(conn),
+		IBCTransfer:   transfertypes.NewQueryClient(conn),
+	}, nil
+}
+
+// GRPCConn returns a connection to the gRPC server of the chain's full node. Do not close it; see ChainNode.GRPCConn.
+func (c *CosmosChain) GRPCConn(ctx context.Context) (*grpc.ClientConn, error) {
+	return c.getFullNode().GRPCConn(ctx)
+}
+
+// QueryClients returns gRPC query clients of common modules of the chain's full node.
+func (c *CosmosChain) QueryClients(ctx context.Context) (*QueryClients, error) {
+	return c.getFullNode().QueryClients(ctx)
+}
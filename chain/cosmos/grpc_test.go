@@ -0,0 +1,59 @@
+package cosmos
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+)
+
+// testBankServer answers bank balance queries with an empty response.
+type testBankServer struct {
+	banktypes.UnimplementedQueryServer
+}
+
+func (*testBankServer) Balance(context.Context, *banktypes.QueryBalanceRequest) (*banktypes.QueryBalanceResponse, error) {
+	return &banktypes.QueryBalanceResponse{}, nil
+}
+
+func TestChainNode_GRPCConn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+
+	_, err := tn.GRPCConn(ctx)
+	require.ErrorContains(t, err, "has not been started")
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	banktypes.RegisterQueryServer(srv, &testBankServer{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	tn.hostGRPCPort = lis.Addr().String()
+
+	conn, err := tn.GRPCConn(ctx)
+	require.NoError(t, err)
+	again, err := tn.GRPCConn(ctx)
+	require.NoError(t, err)
+	require.Same(t, conn, again, "connection must be shared")
+
+	clients, err := tn.QueryClients(ctx)
+	require.NoError(t, err)
+	_, err = clients.Bank.Balance(ctx, &banktypes.QueryBalanceRequest{Address: "cosmos1test", Denom: "uatom"})
+	require.NoError(t, err)
+
+	require.NoError(t, tn.closeGRPCConn())
+	require.NoError(t, tn.closeGRPCConn())
+	redialed, err := tn.GRPCConn(ctx)
+	require.NoError(t, err)
+	require.NotSame(t, conn, redialed, "closed connection must be dialed again")
+	require.NoError(t, tn.closeGRPCConn())
+}
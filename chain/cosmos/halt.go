@@ -0,0 +1,121 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+// haltPollInterval is the interval at which StopAtHeight polls the chain's height while waiting for it to halt.
+const haltPollInterval = time.Second
+
+// setHaltHeight sets the halt-height of the node's app.toml, which takes effect when the node is next started.
+// A halt height of 0 disables halting.
+func (tn *ChainNode) setHaltHeight(ctx context.Context, height uint64) error {
+	return testutil.ModifyExistingTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		appTomlFile,
+		AppTomlOverrides{HaltHeight: &height}.TomlOverrides(),
+	)
+}
+
+// setAllHaltHeights sets the halt-height of all nodes.
+func (c *CosmosChain) setAllHaltHeights(ctx context.Context, height uint64) error {
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			if err := n.setHaltHeight(ctx, height); err != nil {
+				return fmt.Errorf("set halt height of %s: %w", n.Name(), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// StopAtHeight restarts all nodes with the halt-height of app.toml set to height, waits for the chain to halt
+// once it has committed the block at height, then stops and removes the containers of all nodes.
+// Start the nodes again with RestartWithImage, e.g. to switch to the binary of a software upgrade.
+//
+// Chains halting at a software upgrade do not need StopAtHeight; wait for the upgrade height, then call StopAllNodes.
+func (c *CosmosChain) StopAtHeight(ctx context.Context, height uint64) error {
+	cur, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get height: %w", err)
+	}
+	if height <= cur {
+		return fmt.Errorf("halt height %d must be above the current height %d", height, cur)
+	}
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return fmt.Errorf("stop nodes: %w", err)
+	}
+	if err := c.setAllHaltHeights(ctx, height); err != nil {
+		return err
+	}
+	if err := c.StartAllNodes(ctx); err != nil {
+		return fmt.Errorf("start nodes with halt height %d: %w", height, err)
+	}
+
+	if err := c.waitForHalt(ctx, height); err != nil {
+		return err
+	}
+	return c.StopAllNodes(ctx)
+}
+
+// waitForHalt polls the chain's height until it reaches the halt height.
+// Nodes exit once they halt, so the height may be unavailable once the chain is about to halt.
+func (c *CosmosChain) waitForHalt(ctx context.Context, height uint64) error {
+	ticker := time.NewTicker(haltPollInterval)
+	defer ticker.Stop()
+	var last uint64
+	for {
+		cur, err := c.Height(ctx)
+		if err == nil {
+			last = cur
+		}
+		if last >= height || (err != nil && last+1 >= height) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("chain did not halt at height %d, last height %d: %w", height, last, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// useImage switches all nodes to image for their next containers and commands, and pulls it.
+func (c *CosmosChain) useImage(ctx context.Context, image ibc.DockerImage) {
+	c.cfg.Images[0] = image
+	for _, n := range c.Nodes() {
+		n.Image = image
+	}
+	// The image may be of a later SDK version, e.g. of a software upgrade.
+	c.resetSDKVersion()
+	c.pullImages(ctx, c.getFullNode().DockerClient)
+}
+
+// RestartWithImage switches all nodes to image, e.g. the image of a software upgrade, and starts them,
+// after the chain was stopped with StopAtHeight or StopAllNodes. The halt height set by StopAtHeight is cleared.
+func (c *CosmosChain) RestartWithImage(ctx context.Context, image ibc.DockerImage) error {
+	c.useImage(ctx, image)
+
+	if err := c.setAllHaltHeights(ctx, 0); err != nil {
+		return err
+	}
+	if err := c.StartAllNodes(ctx); err != nil {
+		return fmt.Errorf("start nodes with image %s: %w", image.Ref(), err)
+	}
+	// Wait for consensus to resume before returning.
+	return testutil.WaitForBlocks(ctx, 2, c.getFullNode())
+}
@@ -0,0 +1,53 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// haltingClient is an RPC client of a node reporting each of heights in turn, then failing as the node has exited.
+type haltingClient struct {
+	rpcclient.Client
+
+	mu      sync.Mutex
+	heights []int64
+}
+
+func (c *haltingClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.heights) == 0 {
+		return nil, errors.New("connection refused")
+	}
+	res := &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.heights[0]}}
+	c.heights = c.heights[1:]
+	return res, nil
+}
+
+func TestWaitForHalt(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Halted at the halt height.
+	chain := &CosmosChain{Validators: ChainNodes{{Client: &haltingClient{heights: []int64{8, 9, 10}}}}}
+	require.NoError(t, chain.waitForHalt(ctx, 10))
+
+	// Exited before the halt height was reported.
+	chain = &CosmosChain{Validators: ChainNodes{{Client: &haltingClient{heights: []int64{9}}}}}
+	require.NoError(t, chain.waitForHalt(ctx, 10))
+
+	// Stopped before the halt height.
+	chain = &CosmosChain{Validators: ChainNodes{{Client: &haltingClient{heights: []int64{5}}}}}
+	shortCtx, shortCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer shortCancel()
+	require.ErrorContains(t, chain.waitForHalt(shortCtx, 10), "chain did not halt at height 10, last height 5")
+}
@@ -0,0 +1,132 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// ibcHookSenderPrefix is the prefix of the hash deriving the intermediate sender of ibc-hooks.
+const ibcHookSenderPrefix = "ibc-wasm-hook-intermediary"
+
+// WasmHookMemo is the memo of an ICS-20 transfer for the ibc-hooks middleware
+// (https://github.com/osmosis-labs/osmosis/tree/main/x/ibc-hooks), which executes contracts on receiving the transfer,
+// and calls back contracts on its acknowledgement.
+type WasmHookMemo struct {
+	// Contract executed with Msg on the receiving chain with the funds of the transfer,
+	// by the intermediate sender of the transfer (see IBCHookSender). The receiver of the transfer must be Contract.
+	Contract string
+	// Msg is the execute message of Contract, encoded as JSON.
+	Msg any
+	// Contract on the sending chain, which is called with the ibc_lifecycle_complete sudo message
+	// once the transfer is acknowledged or times out.
+	Callback string
+}
+
+// Memo returns the JSON of the memo, e.g. {"wasm":{"contract":"osmo1...","msg":{"increment":{}}}}.
+func (m WasmHookMemo) Memo() (string, error) {
+	if m.Contract == "" && m.Callback == "" {
+		return "", errors.New("wasm hook memo must have a contract or a callback")
+	}
+	memo := make(map[string]any)
+	if m.Contract != "" {
+		if m.Msg == nil {
+			return "", fmt.Errorf("wasm hook memo of contract %s must have a msg", m.Contract)
+		}
+		memo["wasm"] = map[string]any{"contract": m.Contract, "msg": m.Msg}
+	}
+	if m.Callback != "" {
+		memo["ibc_callback"] = m.Callback
+	}
+	bz, err := json.Marshal(memo)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal wasm hook memo: %w", err)
+	}
+	return string(bz), nil
+}
+
+// SendWasmHookTransfer sends an ICS-20 transfer of amount on channelID, signed by keyName, with memo.
+// The receiver defaults to the contract of memo, which ibc-hooks require to receive the transfer.
+func (c *CosmosChain) SendWasmHookTransfer(
+	ctx context.Context,
+	channelID string,
+	keyName string,
+	amount ibc.WalletAmount,
+	memo WasmHookMemo,
+	options ibc.TransferOptions,
+) (ibc.Tx, error) {
+	if memo.Contract != "" {
+		if amount.Address == "" {
+			amount.Address = memo.Contract
+		} else if amount.Address != memo.Contract {
+			return ibc.Tx{}, fmt.Errorf("receiver %s of wasm hook transfer must be its contract %s", amount.Address, memo.Contract)
+		}
+	}
+	m, err := memo.Memo()
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	options.Memo = m
+	return c.SendIBCTransfer(ctx, channelID, keyName, amount, options)
+}
+
+// IBCHookSender returns the intermediate sender, with bech32Prefix, by which ibc-hooks execute contracts
+// for originalSender, an address of the sending chain, of transfers received on channelID of the receiving chain.
+func IBCHookSender(channelID, originalSender, bech32Prefix string) (string, error) {
+	hash := address.Hash(ibcHookSenderPrefix, []byte(channelID+"/"+originalSender))
+	return types.Bech32ifyAddressBytes(bech32Prefix, hash)
+}
+
+// IBCHookSender returns the intermediate sender on the chain, by which ibc-hooks execute contracts
+// for originalSender of transfers received on channelID of the chain. See IBCHookSender.
+func (c *CosmosChain) IBCHookSender(channelID, originalSender string) (string, error) {
+	return IBCHookSender(channelID, originalSender, c.cfg.Bech32Prefix)
+}
+
+// wasmHookAck is the result of the acknowledgement of ibc-hooks, once the contract was executed.
+type wasmHookAck struct {
+	ContractResult []byte `json:"contract_result"`
+	IBCAck         []byte `json:"ibc_ack"`
+}
+
+// ParseWasmHookAck returns the data of the response of the contract executed by ibc-hooks with a transfer,
+// from the acknowledgement of the transfer. It errors if the acknowledgement is an error, e.g. as the contract failed.
+func ParseWasmHookAck(ack ibc.PacketAcknowledgement) ([]byte, error) {
+	var res struct {
+		Result []byte `json:"result"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(ack.Acknowledgement, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal acknowledgement: %w", err)
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("error acknowledgement: %s", res.Error)
+	}
+
+	var hookAck wasmHookAck
+	if err := json.Unmarshal(res.Result, &hookAck); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wasm hook acknowledgement %s: %w", res.Result, err)
+	}
+	return hookAck.ContractResult, nil
+}
+
+// WaitForWasmHookResult polls the chain, which sent the wasm hook transfer tx, for up to the given number of blocks
+// for the acknowledgement of the transfer, and returns the data of the response of the contract on the receiving chain.
+// The transfer must be relayed.
+func (c *CosmosChain) WaitForWasmHookResult(ctx context.Context, tx ibc.Tx, blocks uint64) ([]byte, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height: %w", err)
+	}
+	ack, err := testutil.PollForAck(ctx, c, tx.Height, height+blocks, tx.Packet)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWasmHookAck(ack)
+}
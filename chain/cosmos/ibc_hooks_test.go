@@ -0,0 +1,76 @@
+package cosmos
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWasmHookMemo(t *testing.T) {
+	t.Parallel()
+
+	memo, err := WasmHookMemo{
+		Contract: "osmo1contract",
+		Msg:      map[string]any{"increment": map[string]any{}},
+	}.Memo()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"wasm":{"contract":"osmo1contract","msg":{"increment":{}}}}`, memo)
+
+	memo, err = WasmHookMemo{
+		Contract: "osmo1contract",
+		Msg:      map[string]any{"increment": map[string]any{}},
+		Callback: "cosmos1callback",
+	}.Memo()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"wasm":{"contract":"osmo1contract","msg":{"increment":{}}},"ibc_callback":"cosmos1callback"}`, memo)
+
+	memo, err = WasmHookMemo{Callback: "cosmos1callback"}.Memo()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"ibc_callback":"cosmos1callback"}`, memo)
+
+	_, err = WasmHookMemo{}.Memo()
+	require.EqualError(t, err, "wasm hook memo must have a contract or a callback")
+
+	_, err = WasmHookMemo{Contract: "osmo1contract"}.Memo()
+	require.EqualError(t, err, "wasm hook memo of contract osmo1contract must have a msg")
+}
+
+func TestIBCHookSender(t *testing.T) {
+	t.Parallel()
+
+	sender, err := IBCHookSender("channel-0", "cosmos1sender", "osmo")
+	require.NoError(t, err)
+
+	prefixHash := sha256.Sum256([]byte(ibcHookSenderPrefix))
+	want := sha256.Sum256(append(prefixHash[:], []byte("channel-0/cosmos1sender")...))
+	hrp, bz, err := bech32.DecodeAndConvert(sender)
+	require.NoError(t, err)
+	require.Equal(t, "osmo", hrp)
+	require.Equal(t, want[:], bz)
+
+	other, err := IBCHookSender("channel-1", "cosmos1sender", "osmo")
+	require.NoError(t, err)
+	require.NotEqual(t, sender, other)
+}
+
+func TestParseWasmHookAck(t *testing.T) {
+	t.Parallel()
+
+	hookAck := `{"contract_result":"` + base64.StdEncoding.EncodeToString([]byte(`{"count":1}`)) + `","ibc_ack":"eyJyZXN1bHQiOiJBUT09In0="}`
+	ack := ibc.PacketAcknowledgement{
+		Acknowledgement: []byte(`{"result":"` + base64.StdEncoding.EncodeToString([]byte(hookAck)) + `"}`),
+	}
+	res, err := ParseWasmHookAck(ack)
+	require.NoError(t, err)
+	require.Equal(t, `{"count":1}`, string(res))
+
+	_, err = ParseWasmHookAck(ibc.PacketAcknowledgement{Acknowledgement: []byte(`{"error":"ABCI code: 5: error handling packet: see events for details"}`)})
+	require.EqualError(t, err, "error acknowledgement: ABCI code: 5: error handling packet: see events for details")
+
+	_, err = ParseWasmHookAck(ibc.PacketAcknowledgement{Acknowledgement: []byte(`{"result":"AQ=="}`)})
+	require.ErrorContains(t, err, "failed to unmarshal wasm hook acknowledgement")
+}
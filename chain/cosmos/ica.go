@@ -0,0 +1,125 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	controllertypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// SendICAPacket submits a tx sending packetData, encoded as JSON, over the channel of the interchain account on connectionID
+// owned by the account of keyName, with a packet timeout relative to now.
+// Requires the ICA controller module of ibc-go v6 or later.
+func (tn *ChainNode) SendICAPacket(ctx context.Context, keyName string, connectionID string, packetData []byte, timeout time.Duration) (string, error) {
+	hash := sha256.Sum256(packetData)
+	packetFilename := fmt.Sprintf("%x.json", hash)
+	if err := tn.WriteFile(ctx, packetData, packetFilename); err != nil {
+		return "", fmt.Errorf("writing ica packet data: %w", err)
+	}
+
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "send-tx",
+		connectionID, filepath.Join(tn.HomeDir(), packetFilename),
+		"--relative-packet-timeout", strconv.FormatInt(timeout.Nanoseconds(), 10),
+	)
+}
+
+// icaPacketData returns the packet data, encoded as JSON, of an interchain account tx executing msgs on the host chain.
+func icaPacketData(cdc *codec.ProtoCodec, msgs []types.Msg) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("ica tx must have at least one message")
+	}
+	protoMsgs := make([]proto.Message, len(msgs))
+	for i, msg := range msgs {
+		protoMsgs[i] = msg
+	}
+	data, err := icatypes.SerializeCosmosTx(cdc, protoMsgs)
+	if err != nil {
+		return nil, fmt.Errorf("serialize ica tx: %w", err)
+	}
+	packetData := icatypes.InterchainAccountPacketData{
+		Type: icatypes.EXECUTE_TX,
+		Data: data,
+	}
+	return cdc.MarshalJSON(&packetData)
+}
+
+// RegisterICA registers an interchain account on connectionID, owned by the account of keyName.
+// The account is created once a relayer completes the handshake of its channel; then query its address with QueryICAAddress.
+// Requires the ICA controller module of ibc-go v6 or later.
+func (c *CosmosChain) RegisterICA(ctx context.Context, keyName string, connectionID string) error {
+	_, err := c.getFullNode().ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "register", connectionID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register interchain account: %w", err)
+	}
+	return nil
+}
+
+// QueryICAAddress returns the address on the host chain of the interchain account on connectionID owned by ownerAddr.
+func (c *CosmosChain) QueryICAAddress(ctx context.Context, ownerAddr string, connectionID string) (string, error) {
+	stdout, _, err := c.getFullNode().ExecQuery(ctx,
+		"interchain-accounts", "controller", "interchain-account", ownerAddr, connectionID,
+	)
+	if err != nil {
+		return "", err
+	}
+	var res controllertypes.QueryInterchainAccountResponse
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return "", err
+	}
+	return res.Address, nil
+}
+
+// SendICATx sends a packet executing msgs with the interchain account on connectionID owned by the account of keyName.
+// The messages are executed on the host chain, with the interchain account as their signer.
+// Returns the tx with the sent packet, e.g. for WaitForICAAck. The packet times out after 10 minutes.
+func (c *CosmosChain) SendICATx(ctx context.Context, keyName string, connectionID string, msgs []types.Msg) (tx ibc.Tx, _ error) {
+	packetData, err := icaPacketData(codec.NewProtoCodec(c.cfg.EncodingConfig.InterfaceRegistry), msgs)
+	if err != nil {
+		return tx, err
+	}
+	timeout := time.Duration(icatypes.DefaultRelativePacketTimeoutTimestamp)
+	txHash, err := c.getFullNode().SendICAPacket(ctx, keyName, connectionID, packetData, timeout)
+	if err != nil {
+		return tx, fmt.Errorf("send ica tx: %w", err)
+	}
+	return c.packetTx(txHash)
+}
+
+// WaitForICAAck polls for up to the given number of blocks for the acknowledgement of the packet sent by tx,
+// as returned by SendICATx, and returns the acknowledgement, or an error if the host chain failed to execute the messages.
+// The packet must be relayed, e.g. by a started relayer.
+func (c *CosmosChain) WaitForICAAck(ctx context.Context, tx ibc.Tx, blocks uint64) (*chanTypes.Acknowledgement, error) {
+	found, err := testutil.PollForAck(ctx, c, tx.Height, tx.Height+blocks, tx.Packet)
+	if err != nil {
+		return nil, err
+	}
+	return decodeICAAck(found.Acknowledgement)
+}
+
+// decodeICAAck decodes the acknowledgement of an interchain account packet, returning an error for an error acknowledgement.
+func decodeICAAck(bz []byte) (*chanTypes.Acknowledgement, error) {
+	var ack chanTypes.Acknowledgement
+	if err := chanTypes.SubModuleCdc.UnmarshalJSON(bz, &ack); err != nil {
+		return nil, fmt.Errorf("decode acknowledgement: %w", err)
+	}
+	if !ack.Success() {
+		return &ack, fmt.Errorf("ica tx failed on host chain: %s", ack.GetError())
+	}
+	return &ack, nil
+}
@@ -0,0 +1,55 @@
+package cosmos
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icatypes "github.com/cosmos/ibc-go/v7/modules/apps/27-interchain-accounts/types"
+	chanTypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICAPacketData(t *testing.T) {
+	t.Parallel()
+
+	cdc := codec.NewProtoCodec(DefaultEncoding().InterfaceRegistry)
+	send := &banktypes.MsgSend{
+		FromAddress: "cosmos1ica",
+		ToAddress:   "cosmos1to",
+		Amount:      types.NewCoins(types.NewInt64Coin("stake", 5)),
+	}
+
+	bz, err := icaPacketData(cdc, []types.Msg{send})
+	require.NoError(t, err)
+
+	// The controller's send-tx command decodes the packet data like so.
+	var packetData icatypes.InterchainAccountPacketData
+	require.NoError(t, cdc.UnmarshalJSON(bz, &packetData))
+	require.Equal(t, icatypes.EXECUTE_TX, packetData.Type)
+
+	msgs, err := icatypes.DeserializeCosmosTx(cdc, packetData.Data)
+	require.NoError(t, err)
+	require.Equal(t, []types.Msg{send}, msgs)
+
+	_, err = icaPacketData(cdc, nil)
+	require.EqualError(t, err, "ica tx must have at least one message")
+}
+
+func TestDecodeICAAck(t *testing.T) {
+	t.Parallel()
+
+	ack, err := decodeICAAck(chanTypes.NewResultAcknowledgement([]byte("result")).Acknowledgement())
+	require.NoError(t, err)
+	require.Equal(t, []byte("result"), ack.GetResult())
+
+	ack, err = decodeICAAck(chanTypes.NewErrorAcknowledgement(errors.New("boom")).Acknowledgement())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ica tx failed on host chain: ")
+	require.False(t, ack.Success())
+
+	_, err = decodeICAAck([]byte("not json"))
+	require.ErrorContains(t, err, "decode acknowledgement")
+}
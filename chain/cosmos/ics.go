@@ -0,0 +1,303 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// providerVotingPeriod is the voting period of provider chains, short enough to pass the consumer-addition proposals.
+	providerVotingPeriod = "20s"
+
+	// consumerAdditionDeposit is the deposit of the consumer-addition proposals, the default min deposit of gov.
+	consumerAdditionDeposit = 10_000_000
+
+	// consumerAdditionBlocks is the number of blocks the consumer-addition proposals are polled for until they pass.
+	consumerAdditionBlocks = 50
+
+	// consumerGenesisBlocks is the number of blocks the provider is polled for the genesis of a consumer,
+	// once its consumer-addition proposal passed.
+	consumerGenesisBlocks = 10
+)
+
+// ConsumerAdditionProposal defines a legacy proposal of an Interchain Security provider chain to add a consumer chain,
+// in the format of the proposal file of the consumer-addition command.
+type ConsumerAdditionProposal struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ChainID     string `json:"chain_id"`
+	// Initial height of the provider's client of the consumer.
+	InitialHeight clienttypes.Height `json:"initial_height"`
+	GenesisHash   []byte             `json:"genesis_hash"`
+	BinaryHash    []byte             `json:"binary_hash"`
+	// SpawnTime is when the provider creates the client of the consumer and the consumer's genesis.
+	SpawnTime                         time.Time     `json:"spawn_time"`
+	UnbondingPeriod                   time.Duration `json:"unbonding_period"`
+	CCVTimeoutPeriod                  time.Duration `json:"ccv_timeout_period"`
+	TransferTimeoutPeriod             time.Duration `json:"transfer_timeout_period"`
+	ConsumerRedistributionFraction    string        `json:"consumer_redistribution_fraction"`
+	BlocksPerDistributionTransmission int64         `json:"blocks_per_distribution_transmission"`
+	HistoricalEntries                 int64         `json:"historical_entries"`
+	Deposit                           string        `json:"deposit"`
+}
+
+// NewConsumerAdditionProposal returns a proposal adding the consumer chain with config consumer at spawnTime,
+// with deposit, e.g. "10000000uatom", and the defaults of Interchain Security for the other parameters.
+func NewConsumerAdditionProposal(consumer ibc.ChainConfig, spawnTime time.Time, deposit string) ConsumerAdditionProposal {
+	genesisHash := sha256.Sum256([]byte(consumer.ChainID + " genesis"))
+	binaryHash := sha256.Sum256([]byte(consumer.ChainID + " binary"))
+	return ConsumerAdditionProposal{
+		Title:                             "Add consumer chain " + consumer.ChainID,
+		Description:                       "Adds the consumer chain " + consumer.ChainID + " secured by the provider's validators",
+		ChainID:                           consumer.ChainID,
+		InitialHeight:                     clienttypes.NewHeight(clienttypes.ParseChainID(consumer.ChainID), 1),
+		GenesisHash:                       genesisHash[:],
+		BinaryHash:                        binaryHash[:],
+		SpawnTime:                         spawnTime,
+		UnbondingPeriod:                   20 * 24 * time.Hour,
+		CCVTimeoutPeriod:                  28 * 24 * time.Hour,
+		TransferTimeoutPeriod:             time.Hour,
+		ConsumerRedistributionFraction:    "0.75",
+		BlocksPerDistributionTransmission: 1000,
+		HistoricalEntries:                 10000,
+		Deposit:                           deposit,
+	}
+}
+
+// ConsumerAdditionProposal submits a consumer-addition proposal to the provider chain, signed by keyName.
+func (tn *ChainNode) ConsumerAdditionProposal(ctx context.Context, keyName string, prop ConsumerAdditionProposal) (string, error) {
+	content, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+	proposalFilename := fmt.Sprintf("%x.json", hash)
+	err = tn.WriteFile(ctx, content, proposalFilename)
+	if err != nil {
+		return "", fmt.Errorf("writing consumer-addition proposal: %w", err)
+	}
+
+	proposalPath := filepath.Join(tn.HomeDir(), proposalFilename)
+
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	command := append(legacyProposalCommand(v),
+		"consumer-addition",
+		proposalPath,
+	)
+
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// ConsumerGenesis returns the genesis state of the ccvconsumer module of the consumer chain with chainID,
+// which the provider chain creates at the spawn time of the consumer.
+func (tn *ChainNode) ConsumerGenesis(ctx context.Context, chainID string) (json.RawMessage, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "provider", "consumer-genesis", chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(stdout) {
+		return nil, fmt.Errorf("consumer genesis of %s is not json: %s", chainID, stdout)
+	}
+	return stdout, nil
+}
+
+// ConsumerAdditionProposal submits a consumer-addition proposal to the provider chain, signed by keyName.
+// Consumer chains added with AddProviderConsumerLink of an Interchain are proposed when the provider starts.
+func (c *CosmosChain) ConsumerAdditionProposal(ctx context.Context, keyName string, prop ConsumerAdditionProposal) (tx TxProposal, _ error) {
+	txHash, err := c.getFullNode().ConsumerAdditionProposal(ctx, keyName, prop)
+	if err != nil {
+		return tx, fmt.Errorf("failed to submit consumer-addition proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// ConsumerGenesis returns the genesis state of the ccvconsumer module of the consumer chain with chainID.
+func (c *CosmosChain) ConsumerGenesis(ctx context.Context, chainID string) (json.RawMessage, error) {
+	return c.getFullNode().ConsumerGenesis(ctx, chainID)
+}
+
+// addConsumers adds each consumer of the provider chain by a consumer-addition proposal,
+// which all validators vote for, and waits for the proposals to pass.
+func (c *CosmosChain) addConsumers(ctx context.Context) error {
+	spawnTime := time.Now()
+	deposit := fmt.Sprintf("%d%s", consumerAdditionDeposit, c.cfg.Denom)
+	proposalIDs := make([]string, len(c.Consumers))
+	for i, consumer := range c.Consumers {
+		chainID := consumer.Config().ChainID
+		txHash, err := c.Validators[0].ConsumerAdditionProposal(ctx, valKey, NewConsumerAdditionProposal(consumer.Config(), spawnTime, deposit))
+		if err != nil {
+			return fmt.Errorf("failed to submit consumer-addition proposal of %s: %w", chainID, err)
+		}
+		tx, err := c.txProposal(txHash)
+		if err != nil {
+			return err
+		}
+		if err := c.VoteOnProposalAllValidators(ctx, tx.ProposalID, ProposalVoteYes); err != nil {
+			return fmt.Errorf("failed to vote on consumer-addition proposal of %s: %w", chainID, err)
+		}
+		proposalIDs[i] = tx.ProposalID
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get height: %w", err)
+	}
+	for i, id := range proposalIDs {
+		if _, err := PollForProposalStatus(ctx, c, height, height+consumerAdditionBlocks, id, ProposalStatusPassed); err != nil {
+			return fmt.Errorf("consumer-addition proposal of %s did not pass: %w", c.Consumers[i].Config().ChainID, err)
+		}
+	}
+	return nil
+}
+
+// waitForConsumerGenesis polls the provider chain for up to the given number of blocks
+// for the genesis of the consumer chain with chainID.
+func (c *CosmosChain) waitForConsumerGenesis(ctx context.Context, chainID string, blocks uint64) (json.RawMessage, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height: %w", err)
+	}
+	bp := testutil.BlockPoller[json.RawMessage]{
+		CurrentHeight: c.Height,
+		PollFunc: func(ctx context.Context, _ uint64) (json.RawMessage, error) {
+			return c.ConsumerGenesis(ctx, chainID)
+		},
+	}
+	return bp.DoPoll(ctx, height, height+blocks)
+}
+
+// startConsumer starts the consumer chain once its provider chain started and added it.
+// Rather than creating their own, the validators of the consumer take over the consensus keys of the provider's
+// validators, which validate the consumer, and the genesis of the consumer has the ccvconsumer state from the provider.
+func (c *CosmosChain) startConsumer(ctx context.Context, additionalGenesisWallets []ibc.WalletAmount) error {
+	chainCfg := c.Config()
+	if len(c.Validators) != len(c.Provider.Validators) {
+		return fmt.Errorf(
+			"consumer chain %s must have as many validators as its provider %s (%d), has %d",
+			chainCfg.ChainID, c.Provider.Config().ChainID, len(c.Provider.Validators), len(c.Validators),
+		)
+	}
+
+	genesisAmounts := []types.Coin{{
+		Amount: types.NewInt(10_000_000_000_000),
+		Denom:  chainCfg.Denom,
+	}}
+
+	configFileOverrides := chainCfg.ConfigFileOverrides
+
+	eg := new(errgroup.Group)
+	for i, v := range c.Validators {
+		v := v
+		providerVal := c.Provider.Validators[i]
+		v.Validator = true
+		eg.Go(func() error {
+			if err := v.InitFullNodeFiles(ctx); err != nil {
+				return err
+			}
+			if err := v.overrideConfigFiles(ctx, configFileOverrides); err != nil {
+				return err
+			}
+			key, err := providerVal.ReadFile(ctx, privValidatorKeyFile)
+			if err != nil {
+				return fmt.Errorf("read consensus key of provider validator %s: %w", providerVal.Name(), err)
+			}
+			if err := v.WriteFile(ctx, key, privValidatorKeyFile); err != nil {
+				return fmt.Errorf("copy consensus key of provider validator %s: %w", providerVal.Name(), err)
+			}
+			return v.CreateKey(ctx, valKey)
+		})
+	}
+
+	for _, n := range c.FullNodes {
+		n := n
+		n.Validator = false
+		eg.Go(func() error {
+			if err := n.InitFullNodeFiles(ctx); err != nil {
+				return err
+			}
+			return n.overrideConfigFiles(ctx, configFileOverrides)
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	validator0 := c.Validators[0]
+	additionalGenesisWallets, denomTraces := ibcGenesisWallets(additionalGenesisWallets)
+	addrs, coins := genesisAccountCoins(additionalGenesisWallets)
+
+	for _, v := range c.Validators {
+		bech32, err := v.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return err
+		}
+		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts); err != nil {
+			return err
+		}
+	}
+
+	for _, addr := range addrs {
+		if err := validator0.AddGenesisAccount(ctx, addr, coins[addr]); err != nil {
+			return err
+		}
+	}
+
+	genbz, err := validator0.genesisFileContent(ctx)
+	if err != nil {
+		return err
+	}
+	genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
+
+	ccv, err := c.Provider.waitForConsumerGenesis(ctx, chainCfg.ChainID, consumerGenesisBlocks)
+	if err != nil {
+		return fmt.Errorf("failed to get consumer genesis of %s from provider %s: %w", chainCfg.ChainID, c.Provider.Config().ChainID, err)
+	}
+	genbz, err = setGenesisConsumerState(genbz, ccv)
+	if err != nil {
+		return err
+	}
+
+	return c.startWithGenesis(ctx, genbz, additionalGenesisWallets, denomTraces)
+}
+
+// setGenesisConsumerState sets the state of the ccvconsumer module in the genesis of a consumer chain to ccv,
+// the consumer genesis created by the provider chain.
+func setGenesisConsumerState(genbz []byte, ccv json.RawMessage) ([]byte, error) {
+	return ModifyGenesis(SetPath("app_state.ccvconsumer", ccv))(ibc.ChainConfig{}, genbz)
+}
+
+// setGenesisVotingPeriod sets the voting period of gov in genbz, e.g. to "20s".
+func setGenesisVotingPeriod(genbz []byte, period string) ([]byte, error) {
+	var g struct {
+		AppState struct {
+			Gov struct {
+				Params json.RawMessage `json:"params"`
+			} `json:"gov"`
+		} `json:"app_state"`
+	}
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	path := "app_state.gov.params.voting_period"
+	if g.AppState.Gov.Params == nil {
+		// The gov params are split into deposit, voting and tally params before SDK v0.47.
+		path = "app_state.gov.voting_params.voting_period"
+	}
+	return ModifyGenesis(SetPath(path, period))(ibc.ChainConfig{}, genbz)
+}
@@ -0,0 +1,64 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsumerAdditionProposal(t *testing.T) {
+	t.Parallel()
+
+	spawnTime := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	prop := NewConsumerAdditionProposal(ibc.ChainConfig{ChainID: "consumer-2"}, spawnTime, "10000000uatom")
+	bz, err := json.Marshal(prop)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(bz, &got))
+	require.Equal(t, "consumer-2", got["chain_id"])
+	require.Equal(t, map[string]any{"revision_number": float64(2), "revision_height": float64(1)}, got["initial_height"])
+	require.Equal(t, "2023-06-01T12:00:00Z", got["spawn_time"])
+	require.Equal(t, float64(20*24*time.Hour), got["unbonding_period"])
+	require.Equal(t, float64(time.Hour), got["transfer_timeout_period"])
+	require.Equal(t, "0.75", got["consumer_redistribution_fraction"])
+	require.Equal(t, "10000000uatom", got["deposit"])
+	require.NotEmpty(t, got["genesis_hash"])
+	require.NotEmpty(t, got["binary_hash"])
+
+	// Chain IDs without a revision number start at revision 0.
+	prop = NewConsumerAdditionProposal(ibc.ChainConfig{ChainID: "consumer"}, spawnTime, "10000000uatom")
+	require.Zero(t, prop.InitialHeight.RevisionNumber)
+	require.Equal(t, uint64(1), prop.InitialHeight.RevisionHeight)
+}
+
+func TestSetGenesisConsumerState(t *testing.T) {
+	t.Parallel()
+
+	genbz := []byte(`{"chain_id":"consumer-1","app_state":{"ccvconsumer":{"params":{"enabled":false}},"bank":{}}}`)
+	ccv := json.RawMessage(`{"params":{"enabled":true},"new_chain":true,"provider_client_state":{"chain_id":"provider-1"}}`)
+	out, err := setGenesisConsumerState(genbz, ccv)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"chain_id":"consumer-1","app_state":{
+		"ccvconsumer":{"params":{"enabled":true},"new_chain":true,"provider_client_state":{"chain_id":"provider-1"}},
+		"bank":{}
+	}}`, string(out))
+
+	_, err = setGenesisConsumerState([]byte(`{"app_state":{"bank":{}}}`), ccv)
+	require.ErrorContains(t, err, "ccvconsumer")
+}
+
+func TestSetGenesisVotingPeriod(t *testing.T) {
+	t.Parallel()
+
+	out, err := setGenesisVotingPeriod([]byte(`{"app_state":{"gov":{"params":{"voting_period":"172800s"}}}}`), "20s")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app_state":{"gov":{"params":{"voting_period":"20s"}}}}`, string(out))
+
+	out, err = setGenesisVotingPeriod([]byte(`{"app_state":{"gov":{"voting_params":{"voting_period":"172800s"}}}}`), "20s")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app_state":{"gov":{"voting_params":{"voting_period":"20s"}}}}`, string(out))
+}
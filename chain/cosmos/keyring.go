@@ -0,0 +1,98 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+const (
+	// minKeyringPassphraseLength is the minimum length of passphrases accepted by the file keyring.
+	minKeyringPassphraseLength = 8
+
+	// keyringPassphraseEnv is the environment variable holding the passphrase of the file keyring
+	// entered at the prompts of commands.
+	keyringPassphraseEnv = "KEYRING_PASSPHRASE"
+)
+
+// validateKeyringConfig returns an error if cfg, if not nil, is not a supported keyring configuration.
+func validateKeyringConfig(cfg *ibc.KeyringConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	switch cfg.Backend {
+	case "", ibc.KeyringBackendTest, ibc.KeyringBackendOS:
+	case ibc.KeyringBackendFile:
+		if len(cfg.Passphrase) < minKeyringPassphraseLength {
+			return fmt.Errorf("file keyring passphrase must be at least %d characters", minKeyringPassphraseLength)
+		}
+	default:
+		return fmt.Errorf("unknown keyring backend %q", cfg.Backend)
+	}
+	return nil
+}
+
+// keyringBackend returns the keyring backend of the keys of nodes of a chain configured by cfg.
+func keyringBackend(cfg ibc.ChainConfig) string {
+	if cfg.Keyring == nil || cfg.Keyring.Backend == "" {
+		return ibc.KeyringBackendTest
+	}
+	return cfg.Keyring.Backend
+}
+
+// keyringBackend returns the keyring backend of the keys of the node.
+func (tn *ChainNode) keyringBackend() string {
+	return keyringBackend(tn.Chain.Config())
+}
+
+// keyringDir returns the directory of the keyring of the node, relative to its home directory.
+func (tn *ChainNode) keyringDir() string {
+	return "keyring-" + tn.keyringBackend()
+}
+
+// stdinCommand returns cmd run by a shell with the values of the environment variables envNames on stdin, one per line.
+func stdinCommand(cmd []string, envNames ...string) []string {
+	vars := make([]string, len(envNames))
+	for i, name := range envNames {
+		vars[i] = fmt.Sprintf(`"$%s"`, name)
+	}
+	script := fmt.Sprintf(`printf '%%s\n' %s | "$@"`, strings.Join(vars, " "))
+	return append([]string{"sh", "-c", script, "_"}, cmd...)
+}
+
+// keyringPromptCommand returns cmd and env, entering the keyring passphrase at the prompts of cmd
+// if it is a command of the node's binary using the file keyring.
+func (tn *ChainNode) keyringPromptCommand(cmd []string, env []string) ([]string, []string) {
+	cfg := tn.Chain.Config()
+	if keyringBackend(cfg) != ibc.KeyringBackendFile || len(cmd) == 0 || cmd[0] != cfg.Bin || !usesKeyring(cmd) {
+		return cmd, env
+	}
+	// The passphrase is entered twice when the keyring is created, and once afterwards.
+	return stdinCommand(cmd, keyringPassphraseEnv, keyringPassphraseEnv),
+		append(env, keyringPassphraseEnv+"="+cfg.Keyring.Passphrase)
+}
+
+// usesKeyring reports whether cmd has the keyring backend flag.
+func usesKeyring(cmd []string) bool {
+	for _, arg := range cmd {
+		if arg == "--keyring-backend" || strings.HasPrefix(arg, "--keyring-backend=") {
+			return true
+		}
+	}
+	return false
+}
+
+// keyringPassphraseEnvs returns the environment variables to enter, in order, at the passphrase prompts
+// of a command of the node's binary using its keyring, that reads further input from stdin afterwards.
+func (tn *ChainNode) keyringPassphraseEnvs(ctx context.Context) []string {
+	if tn.keyringBackend() != ibc.KeyringBackendFile {
+		return nil
+	}
+	if _, err := tn.ReadFile(ctx, tn.keyringDir()+"/keyhash"); err != nil {
+		// The keyring is created by the command, which asks to re-enter the passphrase.
+		return []string{keyringPassphraseEnv, keyringPassphraseEnv}
+	}
+	return []string{keyringPassphraseEnv}
+}
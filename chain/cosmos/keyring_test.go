@@ -0,0 +1,54 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKeyringConfig(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateKeyringConfig(nil))
+	require.NoError(t, validateKeyringConfig(&ibc.KeyringConfig{Backend: ibc.KeyringBackendOS}))
+	require.NoError(t, validateKeyringConfig(&ibc.KeyringConfig{Backend: ibc.KeyringBackendFile, Passphrase: "12345678"}))
+
+	require.EqualError(t, validateKeyringConfig(&ibc.KeyringConfig{Backend: ibc.KeyringBackendFile, Passphrase: "1234567"}),
+		"file keyring passphrase must be at least 8 characters")
+	require.EqualError(t, validateKeyringConfig(&ibc.KeyringConfig{Backend: "memory"}), `unknown keyring backend "memory"`)
+}
+
+func TestKeyringPromptCommand(t *testing.T) {
+	t.Parallel()
+
+	node := &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{Bin: "simd"}}}
+	require.Equal(t, "test", node.keyringBackend())
+	require.Equal(t, "keyring-test", node.keyringDir())
+
+	cmd := []string{"simd", "keys", "show", "key1", "--keyring-backend", "test"}
+	gotCmd, gotEnv := node.keyringPromptCommand(cmd, []string{"A=1"})
+	require.Equal(t, cmd, gotCmd)
+	require.Equal(t, []string{"A=1"}, gotEnv)
+
+	node = &ChainNode{Chain: &CosmosChain{cfg: ibc.ChainConfig{
+		Bin:     "simd",
+		Keyring: &ibc.KeyringConfig{Backend: ibc.KeyringBackendFile, Passphrase: "passphrase"},
+	}}}
+	require.Equal(t, "keyring-file", node.keyringDir())
+
+	cmd = []string{"simd", "keys", "show", "key1", "--keyring-backend", "file"}
+	gotCmd, gotEnv = node.keyringPromptCommand(cmd, []string{"A=1"})
+	require.Equal(t, append([]string{"sh", "-c", `printf '%s\n' "$KEYRING_PASSPHRASE" "$KEYRING_PASSPHRASE" | "$@"`, "_"}, cmd...), gotCmd)
+	require.Equal(t, []string{"A=1", "KEYRING_PASSPHRASE=passphrase"}, gotEnv)
+
+	// Commands not using the keyring, or already reading stdin, are left unchanged.
+	for _, cmd := range [][]string{
+		{"simd", "tx", "broadcast", "tx.json"},
+		{"sh", "-c", "simd keys show key1 --keyring-backend file"},
+	} {
+		gotCmd, gotEnv = node.keyringPromptCommand(cmd, nil)
+		require.Equal(t, cmd, gotCmd)
+		require.Nil(t, gotEnv)
+	}
+}
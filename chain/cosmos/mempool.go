@@ -0,0 +1,45 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+// maxUnconfirmedTxs is the maximum number of txs returned by the unconfirmed_txs RPC.
+const maxUnconfirmedTxs = 100
+
+// UnconfirmedTxs returns the txs in the node's mempool, up to 100 of them.
+func (tn *ChainNode) UnconfirmedTxs(ctx context.Context) (types.Txs, error) {
+	limit := maxUnconfirmedTxs
+	res, err := tn.Client.UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint rpc unconfirmed txs: %w", err)
+	}
+	return res.Txs, nil
+}
+
+// NumUnconfirmedTxs returns the number of txs in the node's mempool.
+func (tn *ChainNode) NumUnconfirmedTxs(ctx context.Context) (int, error) {
+	res, err := tn.Client.NumUnconfirmedTxs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("tendermint rpc num unconfirmed txs: %w", err)
+	}
+	return res.Total, nil
+}
+
+// HasUnconfirmedTx reports whether the tx with txHash, e.g. as returned by ExecTx, is in the node's mempool.
+func (tn *ChainNode) HasUnconfirmedTx(ctx context.Context, txHash string) (bool, error) {
+	txs, err := tn.UnconfirmedTxs(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, tx := range txs {
+		if strings.EqualFold(fmt.Sprintf("%X", tx.Hash()), txHash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,59 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+// mempoolClient is an RPC client serving the unconfirmed txs of a mempool of txs.
+type mempoolClient struct {
+	rpcclient.Client
+	txs types.Txs
+}
+
+func (c mempoolClient) UnconfirmedTxs(_ context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	txs := c.txs
+	if limit != nil && len(txs) > *limit {
+		txs = txs[:*limit]
+	}
+	return &coretypes.ResultUnconfirmedTxs{Count: len(txs), Total: len(c.txs), Txs: txs}, nil
+}
+
+func (c mempoolClient) NumUnconfirmedTxs(context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
+	return &coretypes.ResultUnconfirmedTxs{Count: len(c.txs), Total: len(c.txs)}, nil
+}
+
+func TestUnconfirmedTxs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stuck, other := types.Tx("stuck"), types.Tx("other")
+	tn := &ChainNode{Client: mempoolClient{txs: types.Txs{stuck, other}}}
+
+	txs, err := tn.UnconfirmedTxs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{stuck, other}, txs)
+
+	n, err := tn.NumUnconfirmedTxs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	has, err := tn.HasUnconfirmedTx(ctx, fmt.Sprintf("%X", stuck.Hash()))
+	require.NoError(t, err)
+	require.True(t, has)
+
+	has, err = tn.HasUnconfirmedTx(ctx, fmt.Sprintf("%X", types.Tx("confirmed").Hash()))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	tn = &ChainNode{Client: mempoolClient{}}
+	n, err = tn.NumUnconfirmedTxs(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
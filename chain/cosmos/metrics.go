@@ -0,0 +1,117 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+const (
+	metricsPort = "26660/tcp"
+
+	// metricsNamespace is the namespace of the CometBFT metrics of nodes, set explicitly since
+	// Tendermint used the tendermint namespace by default.
+	metricsNamespace = "cometbft"
+
+	// telemetryRetentionTime is the retention time in seconds of the app's telemetry metrics,
+	// which must be positive for the app to export its metrics to Prometheus.
+	telemetryRetentionTime = 600
+)
+
+// Names of common CometBFT metrics of nodes, enabled by ChainConfig.EnableMetrics.
+const (
+	// MetricMempoolSize is a gauge of the number of uncommitted txs in the mempool.
+	MetricMempoolSize = metricsNamespace + "_mempool_size"
+	// MetricConsensusRounds is a gauge of the number of rounds of the current height.
+	MetricConsensusRounds = metricsNamespace + "_consensus_rounds"
+	// MetricConsensusHeight is a gauge of the current height.
+	MetricConsensusHeight = metricsNamespace + "_consensus_height"
+	// MetricP2PPeers is a gauge of the number of connected peers.
+	MetricP2PPeers = metricsNamespace + "_p2p_peers"
+)
+
+// metricsConfig returns the config.toml config of nodes serving Prometheus metrics.
+func metricsConfig() testutil.Toml {
+	return testutil.Toml{
+		"instrumentation": testutil.Toml{
+			"prometheus":             true,
+			"prometheus_listen_addr": ":26660",
+			"namespace":              metricsNamespace,
+		},
+	}
+}
+
+// telemetryAppConfig returns the app.toml config of nodes exporting the app's telemetry metrics,
+// which are served with the CometBFT metrics.
+func telemetryAppConfig() testutil.Toml {
+	return testutil.Toml{
+		"telemetry": testutil.Toml{
+			"enabled":                   true,
+			"prometheus-retention-time": telemetryRetentionTime,
+		},
+	}
+}
+
+// ScrapeMetrics returns the Prometheus metric families of the node by name, e.g. MetricMempoolSize,
+// which ChainConfig.EnableMetrics enables. They include the CometBFT metrics and the telemetry metrics of the app.
+func (tn *ChainNode) ScrapeMetrics(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	if !tn.Chain.Config().EnableMetrics {
+		return nil, errors.New("metrics are not enabled, set EnableMetrics in the chain config")
+	}
+	if tn.hostMetricsPort == "" {
+		return nil, fmt.Errorf("node %s has not been started", tn.Name())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+tn.hostMetricsPort+"/metrics", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape metrics of %s: %w", tn.Name(), err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scrape metrics of %s: status %d", tn.Name(), res.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics of %s: %w", tn.Name(), err)
+	}
+	return families, nil
+}
+
+// ScrapeMetrics returns the Prometheus metric families of the chain's full node by name. See ChainNode.ScrapeMetrics.
+func (c *CosmosChain) ScrapeMetrics(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	return c.getFullNode().ScrapeMetrics(ctx)
+}
+
+// MetricValue returns the value of the gauge, counter, or untyped metric name in families, e.g. from ScrapeMetrics,
+// summed over its label values, such as the chain_id label of CometBFT metrics.
+func MetricValue(families map[string]*dto.MetricFamily, name string) (float64, error) {
+	family, ok := families[name]
+	if !ok {
+		return 0, fmt.Errorf("metric %s not found", name)
+	}
+	var sum float64
+	for _, m := range family.GetMetric() {
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			sum += m.GetGauge().GetValue()
+		case dto.MetricType_COUNTER:
+			sum += m.GetCounter().GetValue()
+		case dto.MetricType_UNTYPED:
+			sum += m.GetUntyped().GetValue()
+		default:
+			return 0, fmt.Errorf("metric %s is a %s, not a single value", name, family.GetType())
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,98 @@
+package cosmos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testMetrics = `# HELP cometbft_mempool_size Size of the mempool (number of uncommitted transactions).
+# TYPE cometbft_mempool_size gauge
+cometbft_mempool_size{chain_id="test-1"} 3
+# HELP cometbft_p2p_peers Number of peers.
+# TYPE cometbft_p2p_peers gauge
+cometbft_p2p_peers{chain_id="test-1"} 2
+# HELP cometbft_consensus_total_txs Total number of transactions.
+# TYPE cometbft_consensus_total_txs counter
+cometbft_consensus_total_txs{chain_id="test-1",shard="a"} 4
+cometbft_consensus_total_txs{chain_id="test-1",shard="b"} 5
+# HELP cometbft_consensus_block_interval_seconds Time between this and the last block.
+# TYPE cometbft_consensus_block_interval_seconds histogram
+cometbft_consensus_block_interval_seconds_bucket{chain_id="test-1",le="+Inf"} 1
+cometbft_consensus_block_interval_seconds_sum{chain_id="test-1"} 2
+cometbft_consensus_block_interval_seconds_count{chain_id="test-1"} 1
+`
+
+func TestChainNode_ScrapeMetrics(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testMetrics))
+	}))
+	t.Cleanup(srv.Close)
+
+	newNode := func(enableMetrics bool) *ChainNode {
+		chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", EnableMetrics: enableMetrics}, 1, 0, zaptest.NewLogger(t))
+		return NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	}
+	ctx := context.Background()
+
+	tn := newNode(false)
+	require.NotContains(t, tn.containerPorts(), nat.Port(metricsPort))
+	_, err := tn.ScrapeMetrics(ctx)
+	require.EqualError(t, err, "metrics are not enabled, set EnableMetrics in the chain config")
+
+	tn = newNode(true)
+	require.Contains(t, tn.containerPorts(), nat.Port(metricsPort))
+	_, err = tn.ScrapeMetrics(ctx)
+	require.ErrorContains(t, err, "has not been started")
+
+	tn.hostMetricsPort = strings.TrimPrefix(srv.URL, "http://")
+	families, err := tn.ScrapeMetrics(ctx)
+	require.NoError(t, err)
+
+	v, err := MetricValue(families, MetricMempoolSize)
+	require.NoError(t, err)
+	require.Equal(t, float64(3), v)
+
+	v, err = MetricValue(families, MetricP2PPeers)
+	require.NoError(t, err)
+	require.Equal(t, float64(2), v)
+
+	// Values are summed over labels.
+	v, err = MetricValue(families, "cometbft_consensus_total_txs")
+	require.NoError(t, err)
+	require.Equal(t, float64(9), v)
+
+	_, err = MetricValue(families, "cometbft_consensus_block_interval_seconds")
+	require.EqualError(t, err, "metric cometbft_consensus_block_interval_seconds is a HISTOGRAM, not a single value")
+
+	_, err = MetricValue(families, MetricConsensusRounds)
+	require.EqualError(t, err, "metric cometbft_consensus_rounds not found")
+}
+
+func TestMetricsConfig(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, testutil.Toml{
+		"prometheus":             true,
+		"prometheus_listen_addr": ":26660",
+		"namespace":              "cometbft",
+	}, metricsConfig()["instrumentation"])
+	require.Equal(t, testutil.Toml{
+		"enabled":                   true,
+		"prometheus-retention-time": telemetryRetentionTime,
+	}, telemetryAppConfig()["telemetry"])
+}
@@ -0,0 +1,95 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// msgsTxGas is the gas limit of txs sent with execMsgsTx.
+const msgsTxGas = flags.DefaultGasLimit
+
+// execMsgsTx signs a tx of msgs with the key keyName and broadcasts it, waits for 2 blocks if successful,
+// then returns the tx hash. Messages are encoded as proto JSON with their type URL as "@type", which lets
+// txs send messages of modules that the chain's CLI has no command for, and that are not registered with
+// the chain's EncodingConfig. The fees are paid like those of ExecTx.
+func (tn *ChainNode) execMsgsTx(ctx context.Context, keyName string, msgs ...json.RawMessage) (string, error) {
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	gasPrices, err := tn.txGasPrices(ctx)
+	if err != nil {
+		return "", err
+	}
+	fees, err := feesForGas(gasPrices, msgsTxGas)
+	if err != nil {
+		return "", err
+	}
+	tx, err := unsignedTxJSON(msgs, fees, feeGranter(ctx))
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(tx)
+	unsignedFile := fmt.Sprintf("tx-%x.json", hash)
+	signedFile := fmt.Sprintf("tx-%x-signed.json", hash)
+	if err := tn.WriteFile(ctx, tx, unsignedFile); err != nil {
+		return "", fmt.Errorf("writing unsigned tx: %w", err)
+	}
+
+	if _, _, err := tn.Exec(ctx, tn.NodeCommand(
+		"tx", "sign", filepath.Join(tn.HomeDir(), unsignedFile),
+		"--from", keyName,
+		"--keyring-backend", tn.keyringBackend(),
+		"--output-document", filepath.Join(tn.HomeDir(), signedFile),
+	), nil); err != nil {
+		return "", fmt.Errorf("signing tx: %w", err)
+	}
+
+	stdout, _, err := tn.Exec(ctx, tn.NodeCommand(
+		"tx", "broadcast", filepath.Join(tn.HomeDir(), signedFile),
+		"--output", "json",
+	), nil)
+	if err != nil {
+		return "", err
+	}
+	return tn.txResult(ctx, stdout)
+}
+
+// unsignedTxJSON returns the JSON of an unsigned tx of msgs, paying fees, granted by granter if not empty,
+// in the format of the output of the generate-only flag of tx commands.
+func unsignedTxJSON(msgs []json.RawMessage, fees types.Coins, granter string) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("tx must have at least one message")
+	}
+	tx := map[string]interface{}{
+		"body": map[string]interface{}{
+			"messages":                       msgs,
+			"memo":                           "",
+			"timeout_height":                 "0",
+			"extension_options":              []interface{}{},
+			"non_critical_extension_options": []interface{}{},
+		},
+		"auth_info": map[string]interface{}{
+			"signer_infos": []interface{}{},
+			"fee": map[string]interface{}{
+				"amount":    fees,
+				"gas_limit": strconv.FormatUint(msgsTxGas, 10),
+				"payer":     "",
+				"granter":   granter,
+			},
+		},
+		"signatures": []interface{}{},
+	}
+	bz, err := json.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tx: %w", err)
+	}
+	return bz, nil
+}
@@ -0,0 +1,31 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsignedTxJSON(t *testing.T) {
+	t.Parallel()
+
+	msg := json.RawMessage(`{"@type":"/osmosis.tokenfactory.v1beta1.MsgMint","sender":"osmo1abc","amount":{"denom":"factory/osmo1abc/token","amount":"10"}}`)
+	tx, err := unsignedTxJSON([]json.RawMessage{msg}, types.NewCoins(types.NewInt64Coin("uosmo", 2000)), "osmo1granter")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"body":{
+			"messages":[{"@type":"/osmosis.tokenfactory.v1beta1.MsgMint","sender":"osmo1abc","amount":{"denom":"factory/osmo1abc/token","amount":"10"}}],
+			"memo":"","timeout_height":"0","extension_options":[],"non_critical_extension_options":[]
+		},
+		"auth_info":{
+			"signer_infos":[],
+			"fee":{"amount":[{"denom":"uosmo","amount":"2000"}],"gas_limit":"200000","payer":"","granter":"osmo1granter"}
+		},
+		"signatures":[]
+	}`, string(tx))
+
+	_, err = unsignedTxJSON(nil, nil, "")
+	require.EqualError(t, err, "tx must have at least one message")
+}
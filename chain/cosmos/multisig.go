@@ -0,0 +1,157 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// multisigSignMode is the sign mode of the signatures of legacy multisig accounts, which do not support direct signing.
+const multisigSignMode = "amino-json"
+
+// CreateMultisigKey adds a key name of a legacy multisig account to the keyring of the node, of the keys keyNames
+// in the keyring, of which threshold must sign its txs.
+func (tn *ChainNode) CreateMultisigKey(ctx context.Context, name string, threshold int, keyNames ...string) error {
+	if threshold < 1 || threshold > len(keyNames) {
+		return fmt.Errorf("multisig threshold %d must be between 1 and the number of keys %d", threshold, len(keyNames))
+	}
+
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	_, _, err := tn.ExecBin(ctx,
+		"keys", "add", name,
+		"--multisig", strings.Join(keyNames, ","),
+		"--multisig-threshold", strconv.Itoa(threshold),
+		"--keyring-backend", tn.keyringBackend(),
+	)
+	return err
+}
+
+// GenerateTx returns the unsigned tx of a tx command, e.g. ("bank", "send", multisigAddr, toAddr, "10stake"),
+// sent from keyName, which may be a multisig key, without signing or broadcasting it.
+// The tx pays fees like those sent with ExecTx, for the default gas limit.
+func (tn *ChainNode) GenerateTx(ctx context.Context, keyName string, command ...string) ([]byte, error) {
+	gasPrices, err := tn.txGasPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	command = append(command, feeGranterFlags(ctx)...)
+	command = append(command, "--generate-only")
+	stdout, _, err := tn.Exec(ctx, tn.txCommand(keyName, gasPrices, command...), nil)
+	if err != nil {
+		return nil, err
+	}
+	return stdout, nil
+}
+
+// writeTxFile writes tx, e.g. an unsigned tx or signature, named by kind, to the home directory of the node,
+// and returns its path.
+func (tn *ChainNode) writeTxFile(ctx context.Context, kind string, tx []byte) (string, error) {
+	hash := sha256.Sum256(tx)
+	filename := fmt.Sprintf("%s-%x.json", kind, hash)
+	if err := tn.WriteFile(ctx, tx, filename); err != nil {
+		return "", fmt.Errorf("writing %s: %w", kind, err)
+	}
+	return filepath.Join(tn.HomeDir(), filename), nil
+}
+
+// SignMultisigTx returns the signature by keyName, a key of the multisig account multisigAddr, of unsignedTx,
+// e.g. from GenerateTx. The multisig account must exist on chain, e.g. by having received funds.
+// Combine the signatures of enough keys with MultisignTx.
+func (tn *ChainNode) SignMultisigTx(ctx context.Context, keyName string, multisigAddr string, unsignedTx []byte) ([]byte, error) {
+	unsignedPath, err := tn.writeTxFile(ctx, "unsigned-tx", unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	stdout, _, err := tn.Exec(ctx, tn.NodeCommand(
+		"tx", "sign", unsignedPath,
+		"--from", keyName,
+		"--multisig", multisigAddr,
+		"--sign-mode", multisigSignMode,
+		"--keyring-backend", tn.keyringBackend(),
+	), nil)
+	if err != nil {
+		return nil, fmt.Errorf("signing multisig tx: %w", err)
+	}
+	return stdout, nil
+}
+
+// MultisignTx returns unsignedTx signed by the multisig key multisigName, combining the signatures of its keys,
+// e.g. from SignMultisigTx, of which there must be at least its threshold. Broadcast it with BroadcastSignedTx.
+func (tn *ChainNode) MultisignTx(ctx context.Context, multisigName string, unsignedTx []byte, signatures ...[]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, errors.New("multisig tx must have at least one signature")
+	}
+	unsignedPath, err := tn.writeTxFile(ctx, "unsigned-tx", unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	command := []string{"tx", "multisign", unsignedPath, multisigName}
+	for _, sig := range signatures {
+		sigPath, err := tn.writeTxFile(ctx, "signature", sig)
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, sigPath)
+	}
+	command = append(command,
+		"--sign-mode", multisigSignMode,
+		"--keyring-backend", tn.keyringBackend(),
+	)
+	stdout, _, err := tn.Exec(ctx, tn.NodeCommand(command...), nil)
+	if err != nil {
+		return nil, fmt.Errorf("combining multisig signatures: %w", err)
+	}
+	return stdout, nil
+}
+
+// BroadcastSignedTx broadcasts signedTx, e.g. from MultisignTx, waits for 2 blocks if successful, then returns the tx hash.
+func (tn *ChainNode) BroadcastSignedTx(ctx context.Context, signedTx []byte) (string, error) {
+	signedPath, err := tn.writeTxFile(ctx, "signed-tx", signedTx)
+	if err != nil {
+		return "", err
+	}
+	stdout, _, err := tn.Exec(ctx, tn.NodeCommand(
+		"tx", "broadcast", signedPath,
+		"--output", "json",
+	), nil)
+	if err != nil {
+		return "", err
+	}
+	return tn.txResult(ctx, stdout)
+}
+
+// CreateMultisigKey adds a key name of a legacy multisig account of the keys keyNames, e.g. of test wallets,
+// of which threshold must sign its txs, and returns the address of the account.
+func (c *CosmosChain) CreateMultisigKey(ctx context.Context, name string, threshold int, keyNames ...string) (string, error) {
+	tn := c.getFullNode()
+	if err := tn.CreateMultisigKey(ctx, name, threshold, keyNames...); err != nil {
+		return "", fmt.Errorf("failed to create multisig key: %w", err)
+	}
+	return tn.AccountKeyBech32(ctx, name)
+}
+
+// GenerateTx returns the unsigned tx of a tx command, sent from keyName, without signing or broadcasting it.
+func (c *CosmosChain) GenerateTx(ctx context.Context, keyName string, command ...string) ([]byte, error) {
+	return c.getFullNode().GenerateTx(ctx, keyName, command...)
+}
+
+// SignMultisigTx returns the signature by keyName, a key of the multisig account multisigAddr, of unsignedTx.
+func (c *CosmosChain) SignMultisigTx(ctx context.Context, keyName string, multisigAddr string, unsignedTx []byte) ([]byte, error) {
+	return c.getFullNode().SignMultisigTx(ctx, keyName, multisigAddr, unsignedTx)
+}
+
+// MultisignTx returns unsignedTx signed by the multisig key multisigName, combining the signatures of its keys.
+func (c *CosmosChain) MultisignTx(ctx context.Context, multisigName string, unsignedTx []byte, signatures ...[]byte) ([]byte, error) {
+	return c.getFullNode().MultisignTx(ctx, multisigName, unsignedTx, signatures...)
+}
+
+// BroadcastSignedTx broadcasts signedTx, waits for 2 blocks if successful, then returns the tx hash.
+func (c *CosmosChain) BroadcastSignedTx(ctx context.Context, signedTx []byte) (string, error) {
+	return c.getFullNode().BroadcastSignedTx(ctx, signedTx)
+}
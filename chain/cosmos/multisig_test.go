@@ -0,0 +1,24 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMultisigValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+
+	require.EqualError(t, tn.CreateMultisigKey(ctx, "multisig", 0, "a", "b"), "multisig threshold 0 must be between 1 and the number of keys 2")
+	require.EqualError(t, tn.CreateMultisigKey(ctx, "multisig", 3, "a", "b"), "multisig threshold 3 must be between 1 and the number of keys 2")
+
+	_, err := tn.MultisignTx(ctx, "multisig", []byte(`{}`))
+	require.EqualError(t, err, "multisig tx must have at least one signature")
+}
@@ -0,0 +1,214 @@
+package cosmos
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	// nodeLogsWatchInterval is the interval at which WatchNodeLogs looks for new nodes,
+	// and for the containers of nodes that were stopped or recreated.
+	nodeLogsWatchInterval = time.Second
+
+	// nodeFailureContextLines is the number of log lines before and after a node failure reported with it,
+	// e.g. the stack trace of a panic.
+	nodeFailureContextLines = 20
+
+	// nodeFailureContextWait is how long to wait for the log lines after a node failure.
+	nodeFailureContextWait = 2 * time.Second
+
+	// maxNodeLogLineSize is the size of the longest log line scanned, e.g. of a large tx.
+	maxNodeLogLineSize = 1 << 20
+)
+
+// nodeFailureLogs are the log messages of node failures, by kind, in the order they are matched.
+// CometBFT logs the wrong block errors when a node cannot apply a block, e.g. after computing a different app hash.
+var nodeFailureLogs = []struct {
+	kind    string
+	message string
+}{
+	{"consensus failure", "CONSENSUS FAILURE!!!"},
+	{"app hash mismatch", "wrong Block.Header.AppHash"},
+	{"wrong block", "wrong Block.Header."},
+	{"wrong block", "wrong Block.LastCommit"},
+}
+
+// nodeFailureKind returns the kind of node failure logged by line, if any.
+func nodeFailureKind(line string) (string, bool) {
+	// Panics are printed by the go runtime at the start of a line, unlike the panics recovered and logged by the app.
+	if strings.HasPrefix(line, "panic: ") {
+		return "panic", true
+	}
+	for _, l := range nodeFailureLogs {
+		if strings.Contains(line, l.message) {
+			return l.kind, true
+		}
+	}
+	return "", false
+}
+
+// nodeFailure is a node failure found in the node's logs.
+type nodeFailure struct {
+	kind string
+	// Log lines around the failure, including the line logging it.
+	lines []string
+}
+
+// scanNodeFailure scans the log lines of r until a node failure, which it returns with the lines around it,
+// or returns nil once r ends.
+func scanNodeFailure(r io.Reader) *nodeFailure {
+	lines := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), maxNodeLogLineSize)
+		for sc.Scan() {
+			select {
+			case lines <- sc.Text():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var recent []string
+	for line := range lines {
+		kind, ok := nodeFailureKind(line)
+		if !ok {
+			recent = append(recent, line)
+			if len(recent) > nodeFailureContextLines {
+				recent = recent[1:]
+			}
+			continue
+		}
+
+		f := &nodeFailure{kind: kind, lines: append(recent, line)}
+		timeout := time.After(nodeFailureContextWait)
+		for i := 0; i < nodeFailureContextLines; i++ {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					return f
+				}
+				f.lines = append(f.lines, line)
+			case <-timeout:
+				return f
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// scanContainerLogs follows the logs of the node's container containerID since the unix timestamp since, if any,
+// until a node failure or the container stops.
+func (tn *ChainNode) scanContainerLogs(ctx context.Context, containerID string, since string) *nodeFailure {
+	rc, err := tn.DockerClient.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Since:      since,
+	})
+	if err != nil {
+		// The container may have been removed, e.g. to recreate it with another image.
+		return nil
+	}
+	defer rc.Close()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, pw, rc)
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+	return scanNodeFailure(pr)
+}
+
+// watchLogs follows the logs of the node's containers, including those recreated later, until a node failure,
+// which it returns, or until ctx is done.
+func (tn *ChainNode) watchLogs(ctx context.Context) *nodeFailure {
+	var containerID, since string
+	ticker := time.NewTicker(nodeLogsWatchInterval)
+	defer ticker.Stop()
+	for {
+		if lc := tn.containerLifecycle; lc != nil && lc.ContainerID() != "" {
+			if id := lc.ContainerID(); id != containerID {
+				// The logs of a new container are scanned from its start.
+				containerID, since = id, ""
+			}
+			if f := tn.scanContainerLogs(ctx, containerID, since); f != nil {
+				return f
+			}
+			// The container stopped, and may be started again.
+			since = strconv.FormatInt(time.Now().Unix(), 10)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchNodeLogs scans the logs of each of the chain's nodes in the background, including nodes added later,
+// until ctx is done or the test ends. Once a node logs a failure, e.g. a panic, a consensus failure, or an app hash
+// mismatch, t fails with the log lines around the failure, and the returned context is canceled, so that the test
+// stops waiting on the chain rather than timing out later. Use the returned context for the rest of the test.
+func (c *CosmosChain) WatchNodeLogs(ctx context.Context, t *testing.T) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		watched := make(map[*ChainNode]bool)
+		ticker := time.NewTicker(nodeLogsWatchInterval)
+		defer ticker.Stop()
+		for {
+			c.findTxMu.Lock()
+			nodes := append(append(ChainNodes(nil), c.Validators...), c.FullNodes...)
+			c.findTxMu.Unlock()
+
+			for _, n := range nodes {
+				if watched[n] {
+					continue
+				}
+				watched[n] = true
+
+				n := n
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if f := n.watchLogs(ctx); f != nil {
+						t.Errorf("node %s of chain %s logged a %s:\n%s", n.Name(), c.cfg.ChainID, f.kind, strings.Join(f.lines, "\n"))
+						cancel()
+					}
+				}()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ctx
+}
@@ -0,0 +1,65 @@
+package cosmos
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeFailureKind(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		line string
+		kind string
+	}{
+		{`panic: runtime error: invalid memory address or nil pointer dereference`, "panic"},
+		{`ERR CONSENSUS FAILURE!!! err="index out of range" module=consensus stack="goroutine 1 [running]:"`, "consensus failure"},
+		{`ERR prevote step: ProposalBlock is invalid err="wrong Block.Header.AppHash.  Expected 9A3F, got 1B2C" module=consensus`, "app hash mismatch"},
+		{`ERR error on block validation err="wrong Block.Header.LastResultsHash.  Expected 01, got 02"`, "wrong block"},
+		{`ERR error validating block err="wrong Block.LastCommit.Height"`, "wrong block"},
+		{`INF committed state app_hash=9A3F height=5 module=state num_txs=0`, ""},
+		{`ERR tx failed: recovered panic: out of gas`, ""},
+	} {
+		kind, ok := nodeFailureKind(tt.line)
+		require.Equal(t, tt.kind, kind, tt.line)
+		require.Equal(t, tt.kind != "", ok, tt.line)
+	}
+}
+
+func TestScanNodeFailure(t *testing.T) {
+	t.Parallel()
+
+	var logs []string
+	for i := 0; i < 30; i++ {
+		logs = append(logs, fmt.Sprintf("INF committed state height=%d", i))
+	}
+	require.Nil(t, scanNodeFailure(strings.NewReader(strings.Join(logs, "\n"))))
+
+	// The failure is reported with the lines before and after it, e.g. a stack trace.
+	logs = append(logs, "panic: boom", "", "goroutine 1 [running]:")
+	for i := 0; i < 30; i++ {
+		logs = append(logs, fmt.Sprintf("main.main() line %d", i))
+	}
+	f := scanNodeFailure(strings.NewReader(strings.Join(logs, "\n")))
+	require.NotNil(t, f)
+	require.Equal(t, "panic", f.kind)
+	require.Len(t, f.lines, 2*nodeFailureContextLines+1)
+	require.Equal(t, "INF committed state height=10", f.lines[0])
+	require.Equal(t, "panic: boom", f.lines[nodeFailureContextLines])
+	require.Equal(t, "goroutine 1 [running]:", f.lines[nodeFailureContextLines+2])
+
+	// The failure is reported without waiting for lines of nodes that stopped logging.
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { _ = pw.Close() })
+	go func() {
+		_, _ = pw.Write([]byte("INF starting\nERR CONSENSUS FAILURE!!! err=boom\nINF stopping\n"))
+	}()
+	f = scanNodeFailure(pr)
+	require.NotNil(t, f)
+	require.Equal(t, "consensus failure", f.kind)
+	require.Equal(t, []string{"INF starting", "ERR CONSENSUS FAILURE!!! err=boom", "INF stopping"}, f.lines)
+}
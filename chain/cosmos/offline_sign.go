@@ -0,0 +1,131 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// OfflineSignOptions configures SignOffline.
+type OfflineSignOptions struct {
+	// SignMode of the signature. Defaults to direct signing.
+	SignMode signing.SignMode
+	// AccountNumber and Sequence of the signer, if not nil, e.g. to test txs signed with a wrong or future sequence.
+	// Both are queried from the chain by default.
+	AccountNumber, Sequence *uint64
+}
+
+// SignOffline signs unsignedTx, e.g. generated by ChainNode.GenerateTx with the signer's address as the key name,
+// in the test process with the key of mnemonic, so that the key never lives in a container, and returns the signed tx.
+// Broadcast it on any node with ChainNode.BroadcastSignedTx. The key of mnemonic must be a signer of the tx,
+// e.g. of a wallet from BuildRelayerWallet, whose key is only kept in the test process.
+func (c *CosmosChain) SignOffline(ctx context.Context, mnemonic string, unsignedTx []byte, opts OfflineSignOptions) ([]byte, error) {
+	priv, err := offlinePrivKey(mnemonic, c.cfg.CoinType, signingAlgo(c.cfg))
+	if err != nil {
+		return nil, err
+	}
+	addr := types.AccAddress(priv.PubKey().Address())
+	signer, err := types.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	txConfig := c.cfg.EncodingConfig.TxConfig
+	sdkTx, err := txConfig.TxJSONDecoder()(unsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode unsigned tx: %w", err)
+	}
+	builder, err := txConfig.WrapTxBuilder(sdkTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap unsigned tx: %w", err)
+	}
+	if !isTxSigner(builder.GetTx().GetSigners(), addr) {
+		return nil, fmt.Errorf("%s is not a signer of the tx", signer)
+	}
+
+	accNum, seq, err := c.offlineSignerAccount(addr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	signMode := opts.SignMode
+	if signMode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		signMode = signing.SignMode_SIGN_MODE_DIRECT
+	}
+
+	// Direct signing signs the signer infos, so the signature is set empty first, like tx.Sign does.
+	if err := builder.SetSignatures(signing.SignatureV2{
+		PubKey:   priv.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signMode},
+		Sequence: seq,
+	}); err != nil {
+		return nil, err
+	}
+	sig, err := tx.SignWithPrivKey(signMode, authsigning.SignerData{
+		Address:       signer,
+		ChainID:       c.cfg.ChainID,
+		AccountNumber: accNum,
+		Sequence:      seq,
+		PubKey:        priv.PubKey(),
+	}, builder, priv, txConfig, seq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+
+	return txConfig.TxJSONEncoder()(builder.GetTx())
+}
+
+// offlinePrivKey returns the key of mnemonic of the signing algorithm algo, derived with coinType
+// like the keys of the chain's wallets.
+func offlinePrivKey(mnemonic string, coinType string, algo keyring.SignatureAlgo) (cryptotypes.PrivKey, error) {
+	ct, err := strconv.ParseUint(coinType, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type: %w", err)
+	}
+	derived, err := algo.Derive()(mnemonic, "", hd.CreateHDPath(uint32(ct), 0, 0).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key from mnemonic: %w", err)
+	}
+	return algo.Generate()(derived), nil
+}
+
+// isTxSigner reports whether addr is one of signers.
+func isTxSigner(signers []types.AccAddress, addr types.AccAddress) bool {
+	for _, s := range signers {
+		if s.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// offlineSignerAccount returns the account number and sequence of addr set in opts, or else queried from the chain.
+func (c *CosmosChain) offlineSignerAccount(addr types.AccAddress, opts OfflineSignOptions) (uint64, uint64, error) {
+	if opts.AccountNumber != nil && opts.Sequence != nil {
+		return *opts.AccountNumber, *opts.Sequence, nil
+	}
+	clientCtx := c.getFullNode().CliContext().WithCodec(c.cfg.EncodingConfig.Codec)
+	accNum, seq, err := authtypes.AccountRetriever{}.GetAccountNumberSequence(clientCtx, addr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get account of signer: %w", err)
+	}
+	if opts.AccountNumber != nil {
+		accNum = *opts.AccountNumber
+	}
+	if opts.Sequence != nil {
+		seq = *opts.Sequence
+	}
+	return accNum, seq, nil
+}
@@ -0,0 +1,85 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestSignOffline(t *testing.T) {
+	t.Parallel()
+
+	enc := DefaultEncoding()
+	cfg := ibc.ChainConfig{ChainID: "offline-1", Bech32Prefix: "cosmos", CoinType: "118", EncodingConfig: &enc}
+	chain := NewCosmosChain(t.Name(), cfg, 1, 0, zaptest.NewLogger(t))
+	txConfig := cfg.EncodingConfig.TxConfig
+
+	priv, err := offlinePrivKey(testMnemonic, cfg.CoinType, hd.Secp256k1)
+	require.NoError(t, err)
+	from := types.AccAddress(priv.PubKey().Address())
+
+	unsignedTx := func(t *testing.T, from types.AccAddress) []byte {
+		builder := txConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(&banktypes.MsgSend{
+			FromAddress: types.MustBech32ifyAddressBytes("cosmos", from),
+			ToAddress:   "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+			Amount:      types.NewCoins(types.NewInt64Coin("stake", 5)),
+		}))
+		builder.SetGasLimit(200_000)
+		bz, err := txConfig.TxJSONEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return bz
+	}
+
+	accNum, seq := uint64(7), uint64(3)
+	for _, mode := range []signing.SignMode{signing.SignMode_SIGN_MODE_UNSPECIFIED, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON} {
+		signed, err := chain.SignOffline(context.Background(), testMnemonic, unsignedTx(t, from), OfflineSignOptions{
+			SignMode:      mode,
+			AccountNumber: &accNum,
+			Sequence:      &seq,
+		})
+		require.NoError(t, err)
+
+		sdkTx, err := txConfig.TxJSONDecoder()(signed)
+		require.NoError(t, err)
+		sigTx := sdkTx.(authsigning.SigVerifiableTx)
+		sigs, err := sigTx.GetSignaturesV2()
+		require.NoError(t, err)
+		require.Len(t, sigs, 1)
+		require.Equal(t, seq, sigs[0].Sequence)
+		require.True(t, priv.PubKey().Equals(sigs[0].PubKey))
+
+		data := sigs[0].Data.(*signing.SingleSignatureData)
+		wantMode := mode
+		if mode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
+			wantMode = signing.SignMode_SIGN_MODE_DIRECT
+		}
+		require.Equal(t, wantMode, data.SignMode)
+
+		signBytes, err := txConfig.SignModeHandler().GetSignBytes(wantMode, authsigning.SignerData{
+			Address:       types.MustBech32ifyAddressBytes("cosmos", from),
+			ChainID:       cfg.ChainID,
+			AccountNumber: accNum,
+			Sequence:      seq,
+			PubKey:        priv.PubKey(),
+		}, sdkTx)
+		require.NoError(t, err)
+		require.True(t, priv.PubKey().VerifySignature(signBytes, data.Signature))
+	}
+
+	other, err := offlinePrivKey(testMnemonic, "330", hd.Secp256k1)
+	require.NoError(t, err)
+	otherAddr := types.AccAddress(other.PubKey().Address())
+	_, err = chain.SignOffline(context.Background(), testMnemonic, unsignedTx(t, otherAddr), OfflineSignOptions{AccountNumber: &accNum, Sequence: &seq})
+	require.EqualError(t, err, types.MustBech32ifyAddressBytes("cosmos", from)+" is not a signer of the tx")
+}
@@ -0,0 +1,201 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// PacketForwardMemo is the memo of an ICS-20 transfer forwarded by the packet-forward middleware
+// (https://github.com/strangelove-ventures/packet-forward-middleware) along Hops, e.g. from chain A through
+// chains B and C to D. The transfer is sent to the chain of the first hop, which forwards it on the hop's channel.
+type PacketForwardMemo struct {
+	Hops []ForwardHop
+	// Memo of the transfer to the final receiver, e.g. of a WasmHookMemo, which must be a JSON object.
+	Next string
+	// Encode the memo of each next hop as a JSON string rather than an object, for versions of the middleware before v4.
+	NextAsString bool
+}
+
+// ForwardHop is a hop of a transfer forwarded by the packet-forward middleware.
+type ForwardHop struct {
+	// Chain forwarding the transfer, which received it from the previous hop.
+	// Only used by SendMultiHopTransfer, to poll for the acknowledgement of the forwarded transfer.
+	Chain *CosmosChain
+	// Receiver of the forwarded transfer on the next chain.
+	Receiver string
+	// Port of Channel. Defaults to transfer.
+	Port string
+	// Channel on Chain that the transfer is forwarded on.
+	Channel string
+	// Timeout of the forwarded transfer, relative to when it is forwarded. Zero for the default of the middleware.
+	Timeout time.Duration
+	// Number of times the forwarded transfer is retried when it times out, if not nil.
+	Retries *uint8
+}
+
+// forwardMetadata is the forward object of the memo of the packet-forward middleware.
+type forwardMetadata struct {
+	Receiver string        `json:"receiver"`
+	Port     string        `json:"port"`
+	Channel  string        `json:"channel"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+	Retries  *uint8        `json:"retries,omitempty"`
+	Next     any           `json:"next,omitempty"`
+}
+
+// Memo returns the JSON of the memo, in which each hop is nested in the next of the previous hop,
+// e.g. {"forward":{"receiver":"cosmos1...","port":"transfer","channel":"channel-1","next":{"forward":{...}}}}.
+func (m PacketForwardMemo) Memo() (string, error) {
+	if len(m.Hops) == 0 {
+		return "", errors.New("packet forward memo must have at least one hop")
+	}
+
+	var next any
+	if m.Next != "" {
+		if !json.Valid([]byte(m.Next)) {
+			return "", fmt.Errorf("next memo is not json: %s", m.Next)
+		}
+		next = json.RawMessage(m.Next)
+	}
+	for i := len(m.Hops) - 1; i >= 0; i-- {
+		h := m.Hops[i]
+		if h.Receiver == "" || h.Channel == "" {
+			return "", fmt.Errorf("hop %d must have a receiver and a channel", i)
+		}
+		port := h.Port
+		if port == "" {
+			port = "transfer"
+		}
+		if m.NextAsString && next != nil {
+			bz, err := json.Marshal(next)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal memo of hop %d: %w", i+1, err)
+			}
+			next = string(bz)
+		}
+		next = map[string]any{"forward": forwardMetadata{
+			Receiver: h.Receiver,
+			Port:     port,
+			Channel:  h.Channel,
+			Timeout:  h.Timeout,
+			Retries:  h.Retries,
+			Next:     next,
+		}}
+	}
+
+	bz, err := json.Marshal(next)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal packet forward memo: %w", err)
+	}
+	return string(bz), nil
+}
+
+// SendMultiHopTransfer sends an ICS-20 transfer of amount on channelID, signed by keyName, forwarded along memo's hops,
+// to amount.Address on the chain of the first hop. It then polls the chain of each hop, if set, for up to the given
+// number of blocks, for the acknowledgement of the transfer it forwarded, and finally the chain for the acknowledgement
+// of the transfer, which the middleware acknowledges once the transfer reached the final receiver, or failed.
+// The transfers must be relayed. An error names the hop whose transfer failed.
+func (c *CosmosChain) SendMultiHopTransfer(
+	ctx context.Context,
+	channelID string,
+	keyName string,
+	amount ibc.WalletAmount,
+	memo PacketForwardMemo,
+	blocks uint64,
+) (ibc.Tx, error) {
+	m, err := memo.Memo()
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+
+	// Start polling the hops at their heights before the transfer is sent.
+	startHeights := make([]uint64, len(memo.Hops))
+	for i, h := range memo.Hops {
+		if h.Chain == nil {
+			continue
+		}
+		if startHeights[i], err = h.Chain.Height(ctx); err != nil {
+			return ibc.Tx{}, fmt.Errorf("failed to get height of hop %d: %w", i, err)
+		}
+	}
+
+	tx, err := c.SendIBCTransfer(ctx, channelID, keyName, amount, ibc.TransferOptions{Memo: m})
+	if err != nil {
+		return tx, err
+	}
+
+	for i, h := range memo.Hops {
+		if h.Chain == nil {
+			continue
+		}
+		port := h.Port
+		if port == "" {
+			port = "transfer"
+		}
+		ack, err := pollForForwardedAck(ctx, h.Chain, startHeights[i], startHeights[i]+blocks, port, h.Channel, h.Receiver)
+		if err != nil {
+			return tx, fmt.Errorf("hop %d (%s on %s): %w", i, h.Channel, h.Chain.Config().ChainID, err)
+		}
+		if err := transferAckError(ack); err != nil {
+			return tx, fmt.Errorf("hop %d (%s on %s): %w", i, h.Channel, h.Chain.Config().ChainID, err)
+		}
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return tx, fmt.Errorf("failed to get height: %w", err)
+	}
+	ack, err := testutil.PollForAck(ctx, c, tx.Height, height+blocks, tx.Packet)
+	if err != nil {
+		return tx, err
+	}
+	if err := transferAckError(ack); err != nil {
+		return tx, fmt.Errorf("transfer on %s: %w", channelID, err)
+	}
+	return tx, nil
+}
+
+// pollForForwardedAck polls the chain from startHeight up to maxHeight for the acknowledgement of a transfer
+// it sent on port and channel to receiver.
+func pollForForwardedAck(ctx context.Context, c *CosmosChain, startHeight, maxHeight uint64, port, channel, receiver string) (ibc.PacketAcknowledgement, error) {
+	doPoll := func(ctx context.Context, height uint64) (ibc.PacketAcknowledgement, error) {
+		acks, err := c.Acknowledgements(ctx, height)
+		if err != nil {
+			return ibc.PacketAcknowledgement{}, err
+		}
+		for _, ack := range acks {
+			if ack.Packet.SourcePort != port || ack.Packet.SourceChannel != channel {
+				continue
+			}
+			var data struct {
+				Receiver string `json:"receiver"`
+			}
+			if err := json.Unmarshal(ack.Packet.Data, &data); err == nil && data.Receiver == receiver {
+				return ack, nil
+			}
+		}
+		return ibc.PacketAcknowledgement{}, fmt.Errorf("no acknowledgement of a transfer to %s", receiver)
+	}
+	bp := testutil.BlockPoller[ibc.PacketAcknowledgement]{CurrentHeight: c.Height, PollFunc: doPoll}
+	return bp.DoPoll(ctx, startHeight, maxHeight)
+}
+
+// transferAckError returns the error of ack, if it is an error acknowledgement.
+func transferAckError(ack ibc.PacketAcknowledgement) error {
+	var res struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(ack.Acknowledgement, &res); err != nil {
+		return fmt.Errorf("failed to unmarshal acknowledgement: %w", err)
+	}
+	if res.Error != "" {
+		return fmt.Errorf("error acknowledgement: %s", res.Error)
+	}
+	return nil
+}
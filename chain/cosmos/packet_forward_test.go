@@ -0,0 +1,64 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketForwardMemo(t *testing.T) {
+	t.Parallel()
+
+	retries := uint8(2)
+	hops := []ForwardHop{
+		{Receiver: "cosmos1c", Channel: "channel-1", Timeout: 10 * time.Minute, Retries: &retries},
+		{Receiver: "cosmos1d", Port: "transfer", Channel: "channel-2"},
+	}
+
+	memo, err := PacketForwardMemo{Hops: hops}.Memo()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"forward":{
+		"receiver":"cosmos1c","port":"transfer","channel":"channel-1","timeout":600000000000,"retries":2,
+		"next":{"forward":{"receiver":"cosmos1d","port":"transfer","channel":"channel-2"}}
+	}}`, memo)
+
+	memo, err = PacketForwardMemo{Hops: hops, Next: `{"wasm":{"contract":"cosmos1contract","msg":{}}}`}.Memo()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"forward":{
+		"receiver":"cosmos1c","port":"transfer","channel":"channel-1","timeout":600000000000,"retries":2,
+		"next":{"forward":{"receiver":"cosmos1d","port":"transfer","channel":"channel-2","next":{"wasm":{"contract":"cosmos1contract","msg":{}}}}}
+	}}`, memo)
+
+	memo, err = PacketForwardMemo{Hops: hops, NextAsString: true}.Memo()
+	require.NoError(t, err)
+	var m struct {
+		Forward struct {
+			Next string `json:"next"`
+		} `json:"forward"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(memo), &m))
+	require.JSONEq(t, `{"forward":{"receiver":"cosmos1d","port":"transfer","channel":"channel-2"}}`, m.Forward.Next)
+
+	_, err = PacketForwardMemo{}.Memo()
+	require.EqualError(t, err, "packet forward memo must have at least one hop")
+
+	_, err = PacketForwardMemo{Hops: []ForwardHop{{Receiver: "cosmos1c"}}}.Memo()
+	require.EqualError(t, err, "hop 0 must have a receiver and a channel")
+
+	_, err = PacketForwardMemo{Hops: hops, Next: "not json"}.Memo()
+	require.EqualError(t, err, "next memo is not json: not json")
+}
+
+func TestTransferAckError(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, transferAckError(ibc.PacketAcknowledgement{Acknowledgement: []byte(`{"result":"AQ=="}`)}))
+	require.EqualError(t,
+		transferAckError(ibc.PacketAcknowledgement{Acknowledgement: []byte(`{"error":"packet-forward-middleware error: giving up on packet"}`)}),
+		"error acknowledgement: packet-forward-middleware error: giving up on packet",
+	)
+	require.ErrorContains(t, transferAckError(ibc.PacketAcknowledgement{Acknowledgement: []byte{1}}), "failed to unmarshal acknowledgement")
+}
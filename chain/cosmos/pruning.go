@@ -0,0 +1,65 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// minPruningInterval is the minimum interval of custom pruning accepted by the SDK.
+const minPruningInterval = 10
+
+// pruningAppConfig returns the app.toml settings for a node to prune as configured by cfg.
+func pruningAppConfig(cfg ibc.PruningConfig) (testutil.Toml, error) {
+	a := make(testutil.Toml)
+	switch cfg.Strategy {
+	case ibc.PruningDefault, ibc.PruningNothing, ibc.PruningEverything:
+		a["pruning"] = cfg.Strategy
+	case ibc.PruningCustom:
+		interval := cfg.Interval
+		if interval == 0 {
+			interval = minPruningInterval
+		}
+		if interval < minPruningInterval {
+			return nil, fmt.Errorf("custom pruning interval %d must be at least %d", interval, minPruningInterval)
+		}
+		a["pruning"] = cfg.Strategy
+		a["pruning-keep-recent"] = strconv.FormatUint(cfg.KeepRecent, 10)
+		a["pruning-interval"] = strconv.FormatUint(interval, 10)
+	default:
+		return nil, fmt.Errorf("unknown pruning strategy %q", cfg.Strategy)
+	}
+	return a, nil
+}
+
+// pruning returns the pruning strategy of the node, set by ChainConfig.FullNodePruning or ChainConfig.Pruning, if any.
+func (tn *ChainNode) pruning() *ibc.PruningConfig {
+	cfg := tn.Chain.Config()
+	if !tn.Validator {
+		if p, ok := cfg.FullNodePruning[tn.Index]; ok {
+			return &p
+		}
+	}
+	return cfg.Pruning
+}
+
+// SetPruning sets the pruning strategy of the node, which takes effect when the node is next started.
+// Heights already pruned remain unavailable.
+func (tn *ChainNode) SetPruning(ctx context.Context, cfg ibc.PruningConfig) error {
+	a, err := pruningAppConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return testutil.ModifyTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		"config/app.toml",
+		a,
+	)
+}
@@ -0,0 +1,47 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruningAppConfig(t *testing.T) {
+	t.Parallel()
+
+	got, err := pruningAppConfig(ibc.PruningConfig{Strategy: ibc.PruningNothing})
+	require.NoError(t, err)
+	require.Equal(t, testutil.Toml{"pruning": "nothing"}, got)
+
+	got, err = pruningAppConfig(ibc.PruningConfig{Strategy: ibc.PruningCustom, KeepRecent: 100})
+	require.NoError(t, err)
+	require.Equal(t, testutil.Toml{"pruning": "custom", "pruning-keep-recent": "100", "pruning-interval": "10"}, got)
+
+	_, err = pruningAppConfig(ibc.PruningConfig{Strategy: ibc.PruningCustom, KeepRecent: 100, Interval: 5})
+	require.ErrorContains(t, err, "custom pruning interval 5 must be at least 10")
+
+	_, err = pruningAppConfig(ibc.PruningConfig{Strategy: "archive"})
+	require.ErrorContains(t, err, `unknown pruning strategy "archive"`)
+}
+
+func TestNodePruning(t *testing.T) {
+	t.Parallel()
+
+	chain := &CosmosChain{cfg: ibc.ChainConfig{
+		Pruning:         &ibc.PruningConfig{Strategy: ibc.PruningEverything},
+		FullNodePruning: map[int]ibc.PruningConfig{1: {Strategy: ibc.PruningNothing}},
+	}}
+
+	val := &ChainNode{Chain: chain, Validator: true, Index: 1}
+	require.Equal(t, ibc.PruningEverything, val.pruning().Strategy)
+
+	fn0 := &ChainNode{Chain: chain, Index: 0}
+	require.Equal(t, ibc.PruningEverything, fn0.pruning().Strategy)
+
+	fn1 := &ChainNode{Chain: chain, Index: 1}
+	require.Equal(t, ibc.PruningNothing, fn1.pruning().Strategy)
+
+	require.Nil(t, (&ChainNode{Chain: &CosmosChain{}}).pruning())
+}
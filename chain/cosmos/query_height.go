@@ -0,0 +1,91 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ContextAtHeight returns ctx with the gRPC header querying the state of the chain at height, rather than the latest state.
+// Queries of heights pruned by the node fail, unlike those of archive nodes; see ChainConfig.Pruning.
+func ContextAtHeight(ctx context.Context, height int64) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+}
+
+// checkHeightHeader returns an error unless the height of the response header md, if any, is height,
+// e.g. if the query was answered from the latest state.
+func checkHeightHeader(md metadata.MD, height int64) error {
+	hs := md.Get(grpctypes.GRPCBlockHeightHeader)
+	if len(hs) == 0 {
+		return nil
+	}
+	if hs[0] != strconv.FormatInt(height, 10) {
+		return fmt.Errorf("query at height %d answered at height %s", height, hs[0])
+	}
+	return nil
+}
+
+// QueryBalanceAtHeight returns the balance of denom of address in the state of the chain at height.
+func (tn *ChainNode) QueryBalanceAtHeight(ctx context.Context, address, denom string, height int64) (sdk.Coin, error) {
+	clients, err := tn.QueryClients(ctx)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	var md metadata.MD
+	res, err := clients.Bank.Balance(ContextAtHeight(ctx, height), &banktypes.QueryBalanceRequest{Address: address, Denom: denom}, grpc.Header(&md))
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("query balance of %s at height %d: %w", address, height, err)
+	}
+	if err := checkHeightHeader(md, height); err != nil {
+		return sdk.Coin{}, err
+	}
+	return *res.Balance, nil
+}
+
+// QueryDelegationsAtHeight returns the delegations of delegator in the state of the chain at height.
+func (tn *ChainNode) QueryDelegationsAtHeight(ctx context.Context, delegator string, height int64) (stakingtypes.DelegationResponses, error) {
+	clients, err := tn.QueryClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var delegations stakingtypes.DelegationResponses
+	var nextKey []byte
+	for {
+		var md metadata.MD
+		res, err := clients.Staking.DelegatorDelegations(ContextAtHeight(ctx, height), &stakingtypes.QueryDelegatorDelegationsRequest{
+			DelegatorAddr: delegator,
+			Pagination:    &query.PageRequest{Key: nextKey},
+		}, grpc.Header(&md))
+		if err != nil {
+			return nil, fmt.Errorf("query delegations of %s at height %d: %w", delegator, height, err)
+		}
+		if err := checkHeightHeader(md, height); err != nil {
+			return nil, err
+		}
+		delegations = append(delegations, res.DelegationResponses...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return delegations, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// QueryBalanceAtHeight returns the balance of denom of address in the state of the chain at height,
+// queried from the chain's full node.
+func (c *CosmosChain) QueryBalanceAtHeight(ctx context.Context, address, denom string, height int64) (sdk.Coin, error) {
+	return c.getFullNode().QueryBalanceAtHeight(ctx, address, denom, height)
+}
+
+// QueryDelegationsAtHeight returns the delegations of delegator in the state of the chain at height,
+// queried from the chain's full node.
+func (c *CosmosChain) QueryDelegationsAtHeight(ctx context.Context, delegator string, height int64) (stakingtypes.DelegationResponses, error) {
+	return c.getFullNode().QueryDelegationsAtHeight(ctx, delegator, height)
+}
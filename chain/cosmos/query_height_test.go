@@ -0,0 +1,124 @@
+package cosmos
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// testPrunedHeight is pruned by the test node, and testLatestHeight is the height of its latest state,
+// with which it answers queries of testStaleHeight, like a node ignoring the height header.
+const (
+	testPrunedHeight = 1
+	testStaleHeight  = 7
+	testLatestHeight = 10
+)
+
+// testHeightBankServer and testHeightStakingServer answer queries with the height they query as amounts.
+type testHeightBankServer struct {
+	banktypes.UnimplementedQueryServer
+}
+
+type testHeightStakingServer struct {
+	stakingtypes.UnimplementedQueryServer
+}
+
+// queryHeight returns the height queried by ctx, and sets it in the response header.
+func queryHeight(ctx context.Context) (int64, error) {
+	height := int64(testLatestHeight)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if hs := md.Get(grpctypes.GRPCBlockHeightHeader); len(hs) > 0 {
+			h, err := strconv.ParseInt(hs[0], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			height = h
+		}
+	}
+	switch height {
+	case testPrunedHeight:
+		return 0, status.Error(codes.InvalidArgument, "version does not exist")
+	case testStaleHeight:
+		height = testLatestHeight
+	}
+	return height, grpc.SetHeader(ctx, metadata.Pairs(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10)))
+}
+
+func (*testHeightBankServer) Balance(ctx context.Context, req *banktypes.QueryBalanceRequest) (*banktypes.QueryBalanceResponse, error) {
+	height, err := queryHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	coin := sdk.NewInt64Coin(req.Denom, height)
+	return &banktypes.QueryBalanceResponse{Balance: &coin}, nil
+}
+
+// DelegatorDelegations answers with two pages of one delegation each.
+func (*testHeightStakingServer) DelegatorDelegations(ctx context.Context, req *stakingtypes.QueryDelegatorDelegationsRequest) (*stakingtypes.QueryDelegatorDelegationsResponse, error) {
+	height, err := queryHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &stakingtypes.QueryDelegatorDelegationsResponse{Pagination: &query.PageResponse{}}
+	validator := "cosmosvaloper1a"
+	if string(req.Pagination.GetKey()) == "next" {
+		validator = "cosmosvaloper1b"
+	} else {
+		res.Pagination.NextKey = []byte("next")
+	}
+	res.DelegationResponses = stakingtypes.DelegationResponses{
+		stakingtypes.NewDelegationResp(sdk.AccAddress("delegator"), sdk.ValAddress(validator), sdk.NewDec(height), sdk.NewInt64Coin("stake", height)),
+	}
+	return res, nil
+}
+
+func TestChainNode_QueryAtHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	banktypes.RegisterQueryServer(srv, &testHeightBankServer{})
+	stakingtypes.RegisterQueryServer(srv, &testHeightStakingServer{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	tn.hostGRPCPort = lis.Addr().String()
+	t.Cleanup(func() { _ = tn.closeGRPCConn() })
+
+	balance, err := tn.QueryBalanceAtHeight(ctx, "cosmos1test", "stake", 5)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin("stake", 5), balance)
+
+	_, err = tn.QueryBalanceAtHeight(ctx, "cosmos1test", "stake", testPrunedHeight)
+	require.ErrorContains(t, err, "version does not exist")
+
+	_, err = tn.QueryBalanceAtHeight(ctx, "cosmos1test", "stake", testStaleHeight)
+	require.EqualError(t, err, "query at height 7 answered at height 10")
+
+	delegations, err := tn.QueryDelegationsAtHeight(ctx, "cosmos1test", 5)
+	require.NoError(t, err)
+	require.Len(t, delegations, 2)
+	require.Equal(t, sdk.ValAddress("cosmosvaloper1b").String(), delegations[1].Delegation.ValidatorAddress)
+	require.Equal(t, sdk.NewInt64Coin("stake", 5), delegations[0].Balance)
+
+	_, err = tn.QueryDelegationsAtHeight(ctx, "cosmos1test", testPrunedHeight)
+	require.ErrorContains(t, err, "version does not exist")
+}
@@ -0,0 +1,81 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// validateStartedValidators returns an error if the chain's StartedValidators config is invalid for numValidators validators.
+func validateStartedValidators(cfg ibc.ChainConfig, numValidators int) error {
+	n := cfg.StartedValidators
+	switch {
+	case n < 0 || n > numValidators:
+		return fmt.Errorf("%d started validators for %d validators", n, numValidators)
+	case cfg.Rosetta != nil && !hasQuorum(startedValidators(cfg, numValidators), numValidators):
+		// The Rosetta API server only serves requests once the chain produces blocks.
+		return errors.New("rosetta requires the chain to start with a quorum of validators")
+	}
+	return nil
+}
+
+// startedValidators returns the number of the numValidators validators started with the chain configured by cfg.
+func startedValidators(cfg ibc.ChainConfig, numValidators int) int {
+	if cfg.StartedValidators == 0 {
+		return numValidators
+	}
+	return cfg.StartedValidators
+}
+
+// hasQuorum reports whether started of numValidators validators of equal voting power have more than 2/3 of it,
+// as required for the chain to produce blocks.
+func hasQuorum(started, numValidators int) bool {
+	return 3*started > 2*numValidators
+}
+
+// startDeferred reports whether the node is a validator not started with the chain, as configured by StartedValidators.
+func (tn *ChainNode) startDeferred() bool {
+	cfg := tn.Chain.Config()
+	return tn.Validator && cfg.StartedValidators > 0 && tn.Index >= cfg.StartedValidators
+}
+
+// StartValidators starts the validators at the indexes of Validators that were not started with the chain,
+// as configured by ChainConfig.StartedValidators, or all of them if no indexes are given. Once validators
+// with more than 2/3 of the voting power run, the chain produces blocks, e.g. after halting for lack of quorum.
+func (c *CosmosChain) StartValidators(ctx context.Context, indexes ...int) error {
+	var vals ChainNodes
+	if len(indexes) == 0 {
+		for _, v := range c.Validators {
+			if v.startDeferred() && v.hostRPCPort == "" {
+				vals = append(vals, v)
+			}
+		}
+	}
+	for _, i := range indexes {
+		if i < 0 || i >= len(c.Validators) {
+			return fmt.Errorf("validator index %d is out of range of the %d validators", i, len(c.Validators))
+		}
+		v := c.Validators[i]
+		if v.hostRPCPort != "" {
+			return fmt.Errorf("validator %s is already started", v.Name())
+		}
+		vals = append(vals, v)
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, v := range vals {
+		v := v
+		c.log.Info("Starting container", zap.String("container", v.Name()))
+		eg.Go(func() error {
+			if err := v.StartContainer(egCtx); err != nil {
+				return fmt.Errorf("start validator %s: %w", v.Name(), err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,47 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestValidateStartedValidators(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateStartedValidators(ibc.ChainConfig{}, 4))
+	require.NoError(t, validateStartedValidators(ibc.ChainConfig{StartedValidators: 2}, 4))
+	require.EqualError(t, validateStartedValidators(ibc.ChainConfig{StartedValidators: 5}, 4), "5 started validators for 4 validators")
+	require.EqualError(t, validateStartedValidators(ibc.ChainConfig{StartedValidators: -1}, 4), "-1 started validators for 4 validators")
+	require.NoError(t, validateStartedValidators(ibc.ChainConfig{StartedValidators: 3, Rosetta: &ibc.RosettaConfig{}}, 4))
+	require.EqualError(t, validateStartedValidators(ibc.ChainConfig{StartedValidators: 2, Rosetta: &ibc.RosettaConfig{}}, 4),
+		"rosetta requires the chain to start with a quorum of validators")
+
+	// More than 2/3 of the voting power is required.
+	require.True(t, hasQuorum(3, 4))
+	require.False(t, hasQuorum(2, 3))
+	require.True(t, hasQuorum(1, 1))
+}
+
+func TestStartValidators(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1", StartedValidators: 1}, 2, 0, zaptest.NewLogger(t))
+	for i := 0; i < 2; i++ {
+		chain.Validators = append(chain.Validators, NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, i))
+	}
+	require.False(t, chain.Validators[0].startDeferred())
+	require.True(t, chain.Validators[1].startDeferred())
+
+	chain.Validators[0].hostRPCPort = "127.0.0.1:26657"
+	require.ErrorContains(t, chain.StartValidators(ctx, 0), "is already started")
+	require.EqualError(t, chain.StartValidators(ctx, 2), "validator index 2 is out of range of the 2 validators")
+
+	// Nothing is left to start once the deferred validators run.
+	chain.Validators[1].hostRPCPort = "127.0.0.1:26658"
+	require.NoError(t, chain.StartValidators(ctx))
+}
@@ -0,0 +1,304 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+const (
+	rosettaPort    = "8080/tcp"
+	rosettaAddress = "0.0.0.0:8080"
+)
+
+// RosettaNetworkIdentifier identifies the chain in requests to its Rosetta API server.
+type RosettaNetworkIdentifier struct {
+	Blockchain string `json:"blockchain"`
+	Network    string `json:"network"`
+}
+
+// RosettaBlockIdentifier identifies a block in the Rosetta API.
+type RosettaBlockIdentifier struct {
+	Index int64  `json:"index"`
+	Hash  string `json:"hash"`
+}
+
+// RosettaAccountIdentifier identifies an account in the Rosetta API by its bech32 address.
+type RosettaAccountIdentifier struct {
+	Address string `json:"address"`
+}
+
+// RosettaCurrency is a denom in the Rosetta API.
+type RosettaCurrency struct {
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
+}
+
+// RosettaAmount is an amount of a denom in the Rosetta API, whose value is a signed integer.
+type RosettaAmount struct {
+	Value    string          `json:"value"`
+	Currency RosettaCurrency `json:"currency"`
+}
+
+// RosettaOperation is a balance change of an account by a transaction, e.g. of a transfer or fee payment.
+type RosettaOperation struct {
+	OperationIdentifier struct {
+		Index int64 `json:"index"`
+	} `json:"operation_identifier"`
+	Type    string                    `json:"type"`
+	Status  string                    `json:"status"`
+	Account *RosettaAccountIdentifier `json:"account"`
+	Amount  *RosettaAmount            `json:"amount"`
+}
+
+// RosettaTransaction is a transaction of a block in the Rosetta API, including the balance changes
+// of the begin and end blockers, which have their own hashes.
+type RosettaTransaction struct {
+	TransactionIdentifier struct {
+		Hash string `json:"hash"`
+	} `json:"transaction_identifier"`
+	Operations []RosettaOperation `json:"operations"`
+}
+
+// RosettaBlock is the result of the /block endpoint of the Rosetta API.
+type RosettaBlock struct {
+	BlockIdentifier       RosettaBlockIdentifier `json:"block_identifier"`
+	ParentBlockIdentifier RosettaBlockIdentifier `json:"parent_block_identifier"`
+	// Unix time of the block in milliseconds.
+	Timestamp    int64                `json:"timestamp"`
+	Transactions []RosettaTransaction `json:"transactions"`
+}
+
+// RosettaAccountBalance is the result of the /account/balance endpoint of the Rosetta API.
+type RosettaAccountBalance struct {
+	BlockIdentifier RosettaBlockIdentifier `json:"block_identifier"`
+	Balances        []RosettaAmount        `json:"balances"`
+}
+
+// rosettaSidecar is the container of the Rosetta API server of a chain configured with RosettaConfig.Sidecar.
+type rosettaSidecar struct {
+	containerLifecycle *dockerutil.ContainerLifecycle
+	hostPort           string
+}
+
+// validateRosettaConfig returns an error if cfg, if not nil, is not a valid Rosetta configuration.
+func validateRosettaConfig(cfg *ibc.RosettaConfig) error {
+	if cfg != nil && cfg.SidecarImage != nil && !cfg.Sidecar {
+		return errors.New("rosetta sidecar image is set, but the sidecar is not enabled")
+	}
+	return nil
+}
+
+// rosettaInProcess reports whether the chain's nodes serve the Rosetta API in-process, as configured by cfg.
+func rosettaInProcess(cfg ibc.ChainConfig) bool {
+	return cfg.Rosetta != nil && !cfg.Rosetta.Sidecar
+}
+
+// rosettaNetwork returns the network identifier of the chain configured by cfg in requests to its Rosetta API server.
+func rosettaNetwork(cfg ibc.ChainConfig) RosettaNetworkIdentifier {
+	id := RosettaNetworkIdentifier{Blockchain: cfg.Name, Network: cfg.ChainID}
+	if cfg.Rosetta != nil {
+		if cfg.Rosetta.Blockchain != "" {
+			id.Blockchain = cfg.Rosetta.Blockchain
+		}
+		if cfg.Rosetta.Network != "" {
+			id.Network = cfg.Rosetta.Network
+		}
+	}
+	return id
+}
+
+// rosettaAppConfig returns the app.toml config of nodes serving the Rosetta API in-process.
+func rosettaAppConfig(cfg ibc.ChainConfig) testutil.Toml {
+	network := rosettaNetwork(cfg)
+	return testutil.Toml{
+		"rosetta": testutil.Toml{
+			"enable":     true,
+			"address":    rosettaAddress,
+			"blockchain": network.Blockchain,
+			"network":    network.Network,
+		},
+	}
+}
+
+// rosettaSidecarCmd returns the command of the Rosetta sidecar of the chain, serving the Rosetta API of node.
+func (c *CosmosChain) rosettaSidecarCmd(node *ChainNode) []string {
+	network := rosettaNetwork(c.cfg)
+	var cmd []string
+	if c.cfg.Rosetta.SidecarImage != nil {
+		cmd = []string{"rosetta"}
+	} else {
+		cmd = []string{c.cfg.Bin, "rosetta"}
+	}
+	return append(cmd,
+		"--blockchain", network.Blockchain,
+		"--network", network.Network,
+		"--tendermint", node.HostName()+":26657",
+		"--grpc", node.HostName()+":9090",
+		"--addr", rosettaAddress,
+	)
+}
+
+// startRosettaSidecar starts the Rosetta sidecar of the chain, connected to its first full node,
+// and waits for it to serve the Rosetta API.
+func (c *CosmosChain) startRosettaSidecar(ctx context.Context) error {
+	fn := c.getFullNode()
+	image := fn.Image
+	if c.cfg.Rosetta.SidecarImage != nil {
+		image = *c.cfg.Rosetta.SidecarImage
+	}
+
+	name := fmt.Sprintf("%s-rosetta-%s", c.cfg.ChainID, dockerutil.SanitizeContainerName(fn.TestName))
+	sidecar := &rosettaSidecar{containerLifecycle: dockerutil.NewContainerLifecycle(c.log, fn.DockerClient, name)}
+	if err := sidecar.containerLifecycle.CreateContainer(
+		ctx, fn.TestName, fn.NetworkID, image, nat.PortSet{nat.Port(rosettaPort): {}}, nil,
+		dockerutil.CondenseHostName(name), c.rosettaSidecarCmd(fn), nil,
+	); err != nil {
+		return fmt.Errorf("failed to create rosetta sidecar: %w", err)
+	}
+	if err := sidecar.containerLifecycle.StartContainer(ctx); err != nil {
+		return fmt.Errorf("failed to start rosetta sidecar: %w", err)
+	}
+	hostPorts, err := sidecar.containerLifecycle.GetHostPorts(ctx, rosettaPort)
+	if err != nil {
+		return err
+	}
+	sidecar.hostPort = hostPorts[0]
+	c.rosetta = sidecar
+
+	return c.waitForRosetta(ctx)
+}
+
+// waitForRosetta waits for the chain's Rosetta API server to serve its network, which it does once connected to a node.
+func (c *CosmosChain) waitForRosetta(ctx context.Context) error {
+	err := retry.Do(func() error {
+		var res json.RawMessage
+		return c.RosettaPost(ctx, "/network/status", struct {
+			NetworkIdentifier RosettaNetworkIdentifier `json:"network_identifier"`
+		}{rosettaNetwork(c.cfg)}, &res)
+	}, retry.Context(ctx), retry.Attempts(30), retry.Delay(time.Second), retry.DelayType(retry.FixedDelay), retry.LastErrorOnly(true))
+	if err != nil {
+		return fmt.Errorf("rosetta api server of %s is not serving: %w", c.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// GetHostRosettaAddress returns the address of the Rosetta API server, enabled by ChainConfig.Rosetta, accessible by the host.
+// This will not return a valid address until the chain has been started.
+func (c *CosmosChain) GetHostRosettaAddress() string {
+	if c.rosetta != nil {
+		return "http://" + c.rosetta.hostPort
+	}
+	return "http://" + c.getFullNode().hostRosettaPort
+}
+
+// RosettaPost sends req as the JSON body of a request for path, e.g. /network/status, to the chain's Rosetta API server,
+// which ChainConfig.Rosetta enables, and unmarshals the JSON body of the response into res.
+func (c *CosmosChain) RosettaPost(ctx context.Context, path string, req any, res any) error {
+	if c.cfg.Rosetta == nil {
+		return errors.New("rosetta api is not enabled, set Rosetta in the chain config")
+	}
+	if (c.rosetta == nil || c.rosetta.hostPort == "") && c.getFullNode().hostRosettaPort == "" {
+		return errors.New("rosetta api server has not been started")
+	}
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	url := c.GetHostRosettaAddress() + "/" + strings.TrimPrefix(path, "/")
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpRes, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("rosetta %s: %w", path, err)
+	}
+	defer httpRes.Body.Close()
+
+	body, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fmt.Errorf("rosetta %s: read response: %w", path, err)
+	}
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("rosetta %s: status %d: %s", path, httpRes.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, res); err != nil {
+		return fmt.Errorf("rosetta %s: failed to unmarshal response: %w", path, err)
+	}
+	return nil
+}
+
+// rosettaPartialBlockIdentifier identifies a block by height in requests, or the latest block if Index is nil.
+type rosettaPartialBlockIdentifier struct {
+	Index *int64 `json:"index,omitempty"`
+}
+
+// rosettaBlockAt returns the identifier of the block at height in requests, of the latest block if height is 0.
+func rosettaBlockAt(height int64) rosettaPartialBlockIdentifier {
+	if height == 0 {
+		return rosettaPartialBlockIdentifier{}
+	}
+	return rosettaPartialBlockIdentifier{Index: &height}
+}
+
+// RosettaBlock returns the block at height, or the latest block if height is 0, from the chain's Rosetta API server.
+func (c *CosmosChain) RosettaBlock(ctx context.Context, height int64) (*RosettaBlock, error) {
+	req := struct {
+		NetworkIdentifier RosettaNetworkIdentifier      `json:"network_identifier"`
+		BlockIdentifier   rosettaPartialBlockIdentifier `json:"block_identifier"`
+	}{rosettaNetwork(c.cfg), rosettaBlockAt(height)}
+	var res struct {
+		Block *RosettaBlock `json:"block"`
+	}
+	if err := c.RosettaPost(ctx, "/block", req, &res); err != nil {
+		return nil, err
+	}
+	if res.Block == nil {
+		return nil, fmt.Errorf("rosetta /block: no block at height %d", height)
+	}
+	return res.Block, nil
+}
+
+// RosettaAccountBalance returns the balances of the account address at height, or at the latest block if height is 0,
+// from the chain's Rosetta API server.
+func (c *CosmosChain) RosettaAccountBalance(ctx context.Context, address string, height int64) (*RosettaAccountBalance, error) {
+	req := struct {
+		NetworkIdentifier RosettaNetworkIdentifier       `json:"network_identifier"`
+		AccountIdentifier RosettaAccountIdentifier       `json:"account_identifier"`
+		BlockIdentifier   *rosettaPartialBlockIdentifier `json:"block_identifier,omitempty"`
+	}{NetworkIdentifier: rosettaNetwork(c.cfg), AccountIdentifier: RosettaAccountIdentifier{Address: address}}
+	if height != 0 {
+		at := rosettaBlockAt(height)
+		req.BlockIdentifier = &at
+	}
+	var res RosettaAccountBalance
+	if err := c.RosettaPost(ctx, "/account/balance", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Balance returns the value of the balance of denom, or "0" if the account has none.
+func (b *RosettaAccountBalance) Balance(denom string) string {
+	for _, amount := range b.Balances {
+		if amount.Currency.Symbol == denom {
+			return amount.Value
+		}
+	}
+	return "0"
+}
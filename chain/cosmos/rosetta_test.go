@@ -0,0 +1,161 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRosettaConfig(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateRosettaConfig(nil))
+	require.NoError(t, validateRosettaConfig(&ibc.RosettaConfig{Sidecar: true, SidecarImage: &ibc.DockerImage{Repository: "rosetta"}}))
+	require.EqualError(t, validateRosettaConfig(&ibc.RosettaConfig{SidecarImage: &ibc.DockerImage{Repository: "rosetta"}}),
+		"rosetta sidecar image is set, but the sidecar is not enabled")
+
+	cfg := ibc.ChainConfig{Name: "gaia", ChainID: "cosmoshub-1", Bin: "gaiad", Rosetta: &ibc.RosettaConfig{}}
+	require.Equal(t, RosettaNetworkIdentifier{Blockchain: "gaia", Network: "cosmoshub-1"}, rosettaNetwork(cfg))
+
+	cfg.Rosetta = &ibc.RosettaConfig{Blockchain: "cosmos", Network: "mainnet"}
+	require.Equal(t, RosettaNetworkIdentifier{Blockchain: "cosmos", Network: "mainnet"}, rosettaNetwork(cfg))
+	require.Equal(t, testutil.Toml{
+		"enable":     true,
+		"address":    "0.0.0.0:8080",
+		"blockchain": "cosmos",
+		"network":    "mainnet",
+	}, rosettaAppConfig(cfg)["rosetta"])
+
+	newNode := func(cfg ibc.ChainConfig) (*CosmosChain, *ChainNode) {
+		chain := NewCosmosChain(t.Name(), cfg, 1, 1, zaptest.NewLogger(t))
+		return chain, NewChainNode(zaptest.NewLogger(t), false, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	}
+
+	// Nodes expose the Rosetta API only when serving it in-process.
+	_, tn := newNode(cfg)
+	require.Contains(t, tn.containerPorts(), nat.Port(rosettaPort))
+	require.Len(t, tn.containerPorts(), len(sentryPorts)+1)
+	require.NotContains(t, sentryPorts, nat.Port(rosettaPort))
+
+	cfg.Rosetta = &ibc.RosettaConfig{Sidecar: true}
+	chain, tn := newNode(cfg)
+	require.Equal(t, sentryPorts, tn.containerPorts())
+	require.Equal(t, []string{
+		"gaiad", "rosetta",
+		"--blockchain", "gaia",
+		"--network", "cosmoshub-1",
+		"--tendermint", tn.HostName() + ":26657",
+		"--grpc", tn.HostName() + ":9090",
+		"--addr", "0.0.0.0:8080",
+	}, chain.rosettaSidecarCmd(tn))
+
+	cfg.Rosetta.SidecarImage = &ibc.DockerImage{Repository: "rosetta"}
+	chain, tn = newNode(cfg)
+	require.Equal(t, "rosetta", chain.rosettaSidecarCmd(tn)[0])
+}
+
+func TestCosmosChain_Rosetta(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		NetworkIdentifier RosettaNetworkIdentifier  `json:"network_identifier"`
+		BlockIdentifier   json.RawMessage           `json:"block_identifier"`
+		AccountIdentifier *RosettaAccountIdentifier `json:"account_identifier"`
+	}
+	reqs := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req request
+		if r.Method != http.MethodPost || json.Unmarshal(body, &req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reqs <- req
+
+		switch r.URL.Path {
+		case "/block":
+			_, _ = w.Write([]byte(`{"block":{
+				"block_identifier":{"index":5,"hash":"AB"},
+				"parent_block_identifier":{"index":4,"hash":"CD"},
+				"timestamp":1700000000000,
+				"transactions":[{"transaction_identifier":{"hash":"EF"},"operations":[{
+					"operation_identifier":{"index":0},
+					"type":"/cosmos.bank.v1beta1.MsgSend",
+					"status":"Success",
+					"account":{"address":"cosmos1abc"},
+					"amount":{"value":"-5","currency":{"symbol":"uatom","decimals":0}}
+				}]}]
+			}}`))
+		case "/account/balance":
+			_, _ = w.Write([]byte(`{"block_identifier":{"index":5,"hash":"AB"},"balances":[{"value":"100","currency":{"symbol":"uatom","decimals":0}}]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":1,"message":"not implemented","retriable":false}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	cfg := ibc.ChainConfig{Name: "gaia", ChainID: "cosmoshub-1"}
+
+	chain := NewCosmosChain(t.Name(), cfg, 1, 0, zaptest.NewLogger(t))
+	_, err := chain.RosettaBlock(ctx, 5)
+	require.EqualError(t, err, "rosetta api is not enabled, set Rosetta in the chain config")
+
+	cfg.Rosetta = &ibc.RosettaConfig{Sidecar: true}
+	chain = NewCosmosChain(t.Name(), cfg, 1, 0, zaptest.NewLogger(t))
+	chain.Validators = ChainNodes{NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)}
+	_, err = chain.RosettaBlock(ctx, 5)
+	require.EqualError(t, err, "rosetta api server has not been started")
+
+	chain.rosetta = &rosettaSidecar{hostPort: strings.TrimPrefix(srv.URL, "http://")}
+	require.Equal(t, srv.URL, chain.GetHostRosettaAddress())
+	network := RosettaNetworkIdentifier{Blockchain: "gaia", Network: "cosmoshub-1"}
+
+	block, err := chain.RosettaBlock(ctx, 5)
+	require.NoError(t, err)
+	req := <-reqs
+	require.Equal(t, network, req.NetworkIdentifier)
+	require.JSONEq(t, `{"index":5}`, string(req.BlockIdentifier))
+	require.Equal(t, RosettaBlockIdentifier{Index: 5, Hash: "AB"}, block.BlockIdentifier)
+	require.Equal(t, RosettaBlockIdentifier{Index: 4, Hash: "CD"}, block.ParentBlockIdentifier)
+	require.Equal(t, int64(1700000000000), block.Timestamp)
+	require.Len(t, block.Transactions, 1)
+	require.Equal(t, "EF", block.Transactions[0].TransactionIdentifier.Hash)
+	op := block.Transactions[0].Operations[0]
+	require.Equal(t, "cosmos1abc", op.Account.Address)
+	require.Equal(t, RosettaAmount{Value: "-5", Currency: RosettaCurrency{Symbol: "uatom"}}, *op.Amount)
+
+	// The latest block is requested without an index.
+	_, err = chain.RosettaBlock(ctx, 0)
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string((<-reqs).BlockIdentifier))
+
+	balance, err := chain.RosettaAccountBalance(ctx, "cosmos1abc", 0)
+	require.NoError(t, err)
+	req = <-reqs
+	require.Equal(t, network, req.NetworkIdentifier)
+	require.Equal(t, &RosettaAccountIdentifier{Address: "cosmos1abc"}, req.AccountIdentifier)
+	require.Nil(t, req.BlockIdentifier)
+	require.Equal(t, int64(5), balance.BlockIdentifier.Index)
+	require.Equal(t, "100", balance.Balance("uatom"))
+	require.Equal(t, "0", balance.Balance("stake"))
+
+	_, err = chain.RosettaAccountBalance(ctx, "cosmos1abc", 3)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"index":3}`, string((<-reqs).BlockIdentifier))
+
+	var res json.RawMessage
+	err = chain.RosettaPost(ctx, "/network/options", struct{}{}, &res)
+	require.EqualError(t, err, `rosetta /network/options: status 500: {"code":1,"message":"not implemented","retriable":false}`)
+}
@@ -0,0 +1,245 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// SDKVersion is the major and minor version of the Cosmos SDK of a chain's binary,
+// which selects the CLI commands and RPC results that changed between SDK versions.
+type SDKVersion struct {
+	Major, Minor int
+}
+
+var (
+	SDKVersion45 = SDKVersion{Major: 0, Minor: 45}
+	SDKVersion46 = SDKVersion{Major: 0, Minor: 46}
+	SDKVersion47 = SDKVersion{Major: 0, Minor: 47}
+	SDKVersion50 = SDKVersion{Major: 0, Minor: 50}
+)
+
+// defaultSDKVersion is the SDK version assumed for chains whose version can neither be parsed from
+// ChainConfig.SDKVersion nor detected, which keeps the commands used before versions were distinguished.
+var defaultSDKVersion = SDKVersion45
+
+// ParseSDKVersion parses the SDK version of s, e.g. v0.47, 0.50 or v0.47.3-rc1, ignoring the patch version.
+func ParseSDKVersion(s string) (SDKVersion, error) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".", 3)
+	if len(parts) < 2 {
+		return SDKVersion{}, fmt.Errorf("invalid sdk version %q, expected e.g. v0.47", s)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return SDKVersion{}, fmt.Errorf("invalid major version of sdk version %q: %w", s, err)
+	}
+	// Versions like v0.50-rc1 have suffixes on the minor version.
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return SDKVersion{}, fmt.Errorf("invalid minor version of sdk version %q: %w", s, err)
+	}
+	return SDKVersion{Major: major, Minor: minor}, nil
+}
+
+// String returns the version formatted like v0.47.
+func (v SDKVersion) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// AtLeast reports whether v is other or a later version.
+func (v SDKVersion) AtLeast(other SDKVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	return v.Minor >= other.Minor
+}
+
+// SDKVersion returns the SDK version of the chain, set by ChainConfig.SDKVersion or detected from the version
+// info of the binary of the chain's full node. If the version cannot be detected, v0.45 is assumed.
+func (c *CosmosChain) SDKVersion(ctx context.Context) (SDKVersion, error) {
+	return c.sdkVersionOf(ctx, c.getFullNode())
+}
+
+// sdkVersionOf returns the SDK version of the chain, detected with the binary of tn if not configured.
+// The detected version is cached until the chain switches images.
+func (c *CosmosChain) sdkVersionOf(ctx context.Context, tn *ChainNode) (SDKVersion, error) {
+	if c.cfg.SDKVersion != "" {
+		return ParseSDKVersion(c.cfg.SDKVersion)
+	}
+
+	c.sdkVersionMu.Lock()
+	defer c.sdkVersionMu.Unlock()
+	if c.sdkVersion != nil {
+		return *c.sdkVersion, nil
+	}
+	v, err := tn.detectSDKVersion(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return SDKVersion{}, ctx.Err()
+		}
+		tn.logger().Info("Failed to detect sdk version, assuming "+defaultSDKVersion.String(), zap.Error(err))
+		v = defaultSDKVersion
+	}
+	c.sdkVersion = &v
+	return v, nil
+}
+
+// resetSDKVersion clears the detected SDK version, e.g. once the chain switches to the image of a software upgrade.
+func (c *CosmosChain) resetSDKVersion() {
+	c.sdkVersionMu.Lock()
+	defer c.sdkVersionMu.Unlock()
+	c.sdkVersion = nil
+}
+
+// sdkVersion returns the SDK version of the node's chain.
+func (tn *ChainNode) sdkVersion(ctx context.Context) (SDKVersion, error) {
+	if c, ok := tn.Chain.(*CosmosChain); ok {
+		return c.sdkVersionOf(ctx, tn)
+	}
+	if v := tn.Chain.Config().SDKVersion; v != "" {
+		return ParseSDKVersion(v)
+	}
+	return defaultSDKVersion, nil
+}
+
+// detectSDKVersion returns the SDK version reported by the version command of the node's binary.
+func (tn *ChainNode) detectSDKVersion(ctx context.Context) (SDKVersion, error) {
+	stdout, stderr, err := tn.ExecBin(ctx, "version", "--long", "--output", "json")
+	if err != nil {
+		return SDKVersion{}, err
+	}
+	// Some binaries print the version info to stderr.
+	if len(strings.TrimSpace(string(stdout))) == 0 {
+		stdout = stderr
+	}
+	return parseVersionInfo(stdout)
+}
+
+// parseVersionInfo returns the SDK version of the json output of the version --long command.
+func parseVersionInfo(out []byte) (SDKVersion, error) {
+	var info struct {
+		CosmosSDKVersion string `json:"cosmos_sdk_version"`
+	}
+	if err := json.Unmarshal(out, &info); err != nil {
+		return SDKVersion{}, fmt.Errorf("failed to unmarshal version info: %w", err)
+	}
+	if info.CosmosSDKVersion == "" {
+		return SDKVersion{}, fmt.Errorf("version info has no cosmos_sdk_version: %s", out)
+	}
+	return ParseSDKVersion(info.CosmosSDKVersion)
+}
+
+// genesisCommand returns command, e.g. add-genesis-account, as a subcommand of the genesis command
+// if the chain uses the genesis command (https://github.com/cosmos/cosmos-sdk/pull/14149),
+// whose top level aliases were removed in v0.50.
+func genesisCommand(v SDKVersion, usingNewGenesisCommand bool, command ...string) []string {
+	if usingNewGenesisCommand || v.AtLeast(SDKVersion50) {
+		return append([]string{"genesis"}, command...)
+	}
+	return command
+}
+
+// legacyProposalCommand returns the command submitting legacy proposal content, e.g. a text proposal,
+// which moved from submit-proposal to submit-legacy-proposal in v0.46.
+func legacyProposalCommand(v SDKVersion) []string {
+	if v.AtLeast(SDKVersion46) {
+		return []string{"gov", "submit-legacy-proposal"}
+	}
+	return []string{"gov", "submit-proposal"}
+}
+
+// upgradeProposalCommand returns the command submitting prop.
+// Since v0.50, upgrades are proposed with a MsgSoftwareUpgrade by the upgrade module's own command,
+// which takes a summary rather than a description.
+func upgradeProposalCommand(v SDKVersion, prop SoftwareUpgradeProposal) []string {
+	var command []string
+	if v.AtLeast(SDKVersion50) {
+		command = []string{
+			"upgrade", "software-upgrade", prop.Name,
+			"--upgrade-height", strconv.FormatUint(prop.Height, 10),
+			"--title", prop.Title,
+			"--summary", prop.Description,
+			"--deposit", prop.Deposit,
+			// Skip checking that the upgrade info lists binaries that can be downloaded.
+			"--no-validate",
+		}
+	} else {
+		command = append(legacyProposalCommand(v),
+			"software-upgrade", prop.Name,
+			"--upgrade-height", strconv.FormatUint(prop.Height, 10),
+			"--title", prop.Title,
+			"--description", prop.Description,
+			"--deposit", prop.Deposit,
+		)
+	}
+
+	if prop.Info != "" {
+		command = append(command, "--upgrade-info", prop.Info)
+	}
+	return command
+}
+
+// createValidatorFileName is the path of the validator file of the create-validator command,
+// relative to the node's home directory.
+const createValidatorFileName = "create-validator.json"
+
+// createValidatorFile is the validator file of the create-validator command since v0.50,
+// which replaced the flags describing the validator.
+type createValidatorFile struct {
+	PubKey                  json.RawMessage `json:"pubkey"`
+	Amount                  string          `json:"amount"`
+	Moniker                 string          `json:"moniker"`
+	CommissionRate          string          `json:"commission-rate"`
+	CommissionMaxRate       string          `json:"commission-max-rate"`
+	CommissionMaxChangeRate string          `json:"commission-max-change-rate"`
+	MinSelfDelegation       string          `json:"min-self-delegation"`
+}
+
+// createValidatorCommand returns the command creating a validator with the consensus key of pubKeyJSON,
+// self delegating selfDelegation. Since v0.50, the validator is read from a file, written to the node as
+// validatorFile; earlier versions take it from flags, and validatorFile is nil.
+func (tn *ChainNode) createValidatorCommand(v SDKVersion, pubKeyJSON []byte, selfDelegation types.Coin) (command []string, validatorFile []byte, err error) {
+	const (
+		commissionRate          = "0.1"
+		commissionMaxRate       = "0.2"
+		commissionMaxChangeRate = "0.01"
+		minSelfDelegation       = "1"
+	)
+	moniker := CondenseMoniker(tn.Name())
+
+	if !v.AtLeast(SDKVersion50) {
+		return []string{
+			"staking", "create-validator",
+			"--amount", selfDelegation.String(),
+			"--pubkey", string(pubKeyJSON),
+			"--moniker", moniker,
+			"--commission-rate", commissionRate,
+			"--commission-max-rate", commissionMaxRate,
+			"--commission-max-change-rate", commissionMaxChangeRate,
+			"--min-self-delegation", minSelfDelegation,
+		}, nil, nil
+	}
+
+	validatorFile, err = json.Marshal(createValidatorFile{
+		PubKey:                  pubKeyJSON,
+		Amount:                  selfDelegation.String(),
+		Moniker:                 moniker,
+		CommissionRate:          commissionRate,
+		CommissionMaxRate:       commissionMaxRate,
+		CommissionMaxChangeRate: commissionMaxChangeRate,
+		MinSelfDelegation:       minSelfDelegation,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal validator file: %w", err)
+	}
+	return []string{
+		"staking", "create-validator",
+		filepath.Join(tn.HomeDir(), createValidatorFileName),
+	}, validatorFile, nil
+}
@@ -0,0 +1,153 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestParseSDKVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		in   string
+		want SDKVersion
+	}{
+		{"v0.47", SDKVersion47},
+		{"0.50", SDKVersion50},
+		{"v0.45.16-ics", SDKVersion45},
+		{"v0.47.3", SDKVersion47},
+		{"v0.50-rc1", SDKVersion50},
+		{" v1.2.0\n", SDKVersion{Major: 1, Minor: 2}},
+	} {
+		v, err := ParseSDKVersion(tt.in)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, v, tt.in)
+	}
+
+	for _, in := range []string{"", "v0", "latest", "v0.x.1"} {
+		_, err := ParseSDKVersion(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestSDKVersion_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, SDKVersion47.AtLeast(SDKVersion47))
+	require.True(t, SDKVersion50.AtLeast(SDKVersion46))
+	require.False(t, SDKVersion45.AtLeast(SDKVersion46))
+	require.True(t, SDKVersion{Major: 1}.AtLeast(SDKVersion50))
+	require.False(t, SDKVersion50.AtLeast(SDKVersion{Major: 1}))
+	require.Equal(t, "v0.50", SDKVersion50.String())
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	t.Parallel()
+
+	v, err := parseVersionInfo([]byte(`{"name":"gaia","version":"v10.0.0","cosmos_sdk_version":"v0.46.13"}`))
+	require.NoError(t, err)
+	require.Equal(t, SDKVersion46, v)
+
+	_, err = parseVersionInfo([]byte(`{"name":"gaia"}`))
+	require.ErrorContains(t, err, "no cosmos_sdk_version")
+
+	_, err = parseVersionInfo([]byte("v10.0.0"))
+	require.ErrorContains(t, err, "failed to unmarshal version info")
+}
+
+func TestChainNode_sdkVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{SDKVersion: "v0.50.1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	v, err := tn.sdkVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SDKVersion50, v)
+
+	chain = NewCosmosChain(t.Name(), ibc.ChainConfig{SDKVersion: "latest"}, 1, 0, zaptest.NewLogger(t))
+	tn = NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	_, err = tn.sdkVersion(ctx)
+	require.ErrorContains(t, err, "invalid sdk version")
+
+	// A detected version is cached until reset.
+	chain = NewCosmosChain(t.Name(), ibc.ChainConfig{}, 1, 0, zaptest.NewLogger(t))
+	chain.sdkVersion = &SDKVersion47
+	tn = NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	v, err = tn.sdkVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SDKVersion47, v)
+	chain.resetSDKVersion()
+	require.Nil(t, chain.sdkVersion)
+}
+
+func TestGenesisCommand(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"gentx", "validator"}, genesisCommand(SDKVersion47, false, "gentx", "validator"))
+	require.Equal(t, []string{"genesis", "gentx", "validator"}, genesisCommand(SDKVersion47, true, "gentx", "validator"))
+	require.Equal(t, []string{"genesis", "gentx", "validator"}, genesisCommand(SDKVersion50, false, "gentx", "validator"))
+}
+
+func TestUpgradeProposalCommand(t *testing.T) {
+	t.Parallel()
+
+	prop := SoftwareUpgradeProposal{
+		Deposit:     "10stake",
+		Title:       "upgrade",
+		Name:        "v2",
+		Description: "upgrade to v2",
+		Height:      100,
+	}
+
+	require.Equal(t, []string{
+		"gov", "submit-proposal", "software-upgrade", "v2",
+		"--upgrade-height", "100", "--title", "upgrade", "--description", "upgrade to v2", "--deposit", "10stake",
+	}, upgradeProposalCommand(SDKVersion45, prop))
+
+	require.Equal(t, []string{
+		"gov", "submit-legacy-proposal", "software-upgrade", "v2",
+		"--upgrade-height", "100", "--title", "upgrade", "--description", "upgrade to v2", "--deposit", "10stake",
+	}, upgradeProposalCommand(SDKVersion47, prop))
+
+	prop.Info = "info"
+	require.Equal(t, []string{
+		"upgrade", "software-upgrade", "v2",
+		"--upgrade-height", "100", "--title", "upgrade", "--summary", "upgrade to v2", "--deposit", "10stake",
+		"--no-validate", "--upgrade-info", "info",
+	}, upgradeProposalCommand(SDKVersion50, prop))
+}
+
+func TestChainNode_createValidatorCommand(t *testing.T) {
+	t.Parallel()
+
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	pubKey := []byte(`{"@type":"/cosmos.crypto.ed25519.PubKey","key":"AAAA"}`)
+	selfDelegation := types.NewInt64Coin("stake", 100)
+
+	command, validatorFile, err := tn.createValidatorCommand(SDKVersion47, pubKey, selfDelegation)
+	require.NoError(t, err)
+	require.Nil(t, validatorFile)
+	require.Contains(t, command, "--pubkey")
+	require.Contains(t, command, string(pubKey))
+
+	command, validatorFile, err = tn.createValidatorCommand(SDKVersion50, pubKey, selfDelegation)
+	require.NoError(t, err)
+	require.Equal(t, []string{"staking", "create-validator", filepath.Join(tn.HomeDir(), createValidatorFileName)}, command)
+
+	var file map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(validatorFile, &file))
+	require.JSONEq(t, string(pubKey), string(file["pubkey"]))
+	require.JSONEq(t, `"100stake"`, string(file["amount"]))
+	require.JSONEq(t, `"0.1"`, string(file["commission-rate"]))
+	require.JSONEq(t, `"1"`, string(file["min-self-delegation"]))
+}
@@ -0,0 +1,256 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+// privValidatorKeyFile is the file of a node's consensus key, relative to its home directory.
+const privValidatorKeyFile = "config/priv_validator_key.json"
+
+// ValidatorAddress returns the operator address of the node's validator, e.g. cosmosvaloper1...
+func (tn *ChainNode) ValidatorAddress(ctx context.Context) (string, error) {
+	return tn.KeyBech32(ctx, valKey, "val")
+}
+
+// Unjail submits a MsgUnjail for the node's validator, which requires the validator's jail duration to have passed.
+func (tn *ChainNode) Unjail(ctx context.Context) error {
+	_, err := tn.ExecTx(ctx, valKey, "slashing", "unjail")
+	return err
+}
+
+// QueryValidator returns the validator with the operator address valAddr.
+func (c *CosmosChain) QueryValidator(ctx context.Context, valAddr string) (*stakingtypes.Validator, error) {
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+	res, err := queryClient.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddr})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unpack the consensus public key, so that the validator's consensus address can be derived.
+	if err := res.Validator.UnpackInterfaces(c.cfg.EncodingConfig.InterfaceRegistry); err != nil {
+		return nil, fmt.Errorf("unpack validator: %w", err)
+	}
+	return &res.Validator, nil
+}
+
+// QuerySigningInfo returns the signing info of the validator with the operator address valAddr,
+// such as the blocks it missed and until when it is jailed.
+func (c *CosmosChain) QuerySigningInfo(ctx context.Context, valAddr string) (*slashingtypes.ValidatorSigningInfo, error) {
+	val, err := c.QueryValidator(ctx, valAddr)
+	if err != nil {
+		return nil, err
+	}
+	consAddr, err := val.GetConsAddr()
+	if err != nil {
+		return nil, fmt.Errorf("consensus address of validator %s: %w", valAddr, err)
+	}
+
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient := slashingtypes.NewQueryClient(conn)
+	res, err := queryClient.SigningInfo(ctx, &slashingtypes.QuerySigningInfoRequest{
+		ConsAddress: types.MustBech32ifyAddressBytes(c.cfg.Bech32Prefix+"valcons", consAddr),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res.ValSigningInfo, nil
+}
+
+// StopValidatorUntilJailed stops the container of validator val, so that it misses blocks,
+// and waits for up to the given number of blocks until the validator is jailed for downtime.
+// The other validators must have more than 2/3 of the voting power to keep producing blocks.
+// The validator is not restarted; start it with StartContainer, then call Unjail once the jail duration has passed.
+//
+// The downtime needed to be jailed is set by the slashing params signed_blocks_window and min_signed_per_window,
+// which tests usually lower in genesis, along with downtime_jail_duration.
+func (c *CosmosChain) StopValidatorUntilJailed(ctx context.Context, val *ChainNode, blocks uint64) (*stakingtypes.Validator, error) {
+	if !val.Validator {
+		return nil, fmt.Errorf("node %s is not a validator", val.Name())
+	}
+	if val == c.getFullNode() {
+		// The chain's queries would fail once the node is stopped.
+		return nil, errors.New("cannot stop the node the chain is queried through; add a full node to the chain")
+	}
+	valAddr, err := val.ValidatorAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := val.StopContainer(ctx); err != nil {
+		return nil, fmt.Errorf("stop validator %s: %w", val.Name(), err)
+	}
+	return c.WaitForJailed(ctx, valAddr, blocks)
+}
+
+// PauseValidator pauses the container of the validator at index i of Validators, so that it freezes without exiting:
+// it misses blocks until resumed with ResumeValidator, while its peers keep their connections to it, and its
+// in-flight votes and txs are delayed rather than lost, unlike with a stopped validator. Wait for it to be jailed
+// for downtime with WaitForJailed, or for timeouts of the other validators, while it is paused.
+// The other validators must have more than 2/3 of the voting power to keep producing blocks.
+func (c *CosmosChain) PauseValidator(ctx context.Context, i int) error {
+	val, err := c.pausableValidator(i)
+	if err != nil {
+		return err
+	}
+	if err := val.PauseContainer(ctx); err != nil {
+		return fmt.Errorf("pause validator %s: %w", val.Name(), err)
+	}
+	return nil
+}
+
+// ResumeValidator resumes the validator at index i of Validators paused by PauseValidator.
+// The validator catches up with the chain from where it froze; call Unjail if it was jailed meanwhile.
+func (c *CosmosChain) ResumeValidator(ctx context.Context, i int) error {
+	val, err := c.pausableValidator(i)
+	if err != nil {
+		return err
+	}
+	if err := val.UnpauseContainer(ctx); err != nil {
+		return fmt.Errorf("resume validator %s: %w", val.Name(), err)
+	}
+	return nil
+}
+
+// pausableValidator returns the validator at index i of Validators, unless the chain is queried through it.
+func (c *CosmosChain) pausableValidator(i int) (*ChainNode, error) {
+	if i < 0 || i >= len(c.Validators) {
+		return nil, fmt.Errorf("validator index %d is out of range of the %d validators", i, len(c.Validators))
+	}
+	val := c.Validators[i]
+	if val == c.getFullNode() {
+		// The chain's queries would block while the node is paused.
+		return nil, errors.New("cannot pause the node the chain is queried through; add a full node to the chain")
+	}
+	return val, nil
+}
+
+// WaitForJailed polls the validator with the operator address valAddr for up to the given number of blocks,
+// until the validator is jailed.
+func (c *CosmosChain) WaitForJailed(ctx context.Context, valAddr string, blocks uint64) (*stakingtypes.Validator, error) {
+	return c.pollValidator(ctx, valAddr, blocks, checkJailed)
+}
+
+// WaitForBonded polls the validator with the operator address valAddr for up to the given number of blocks,
+// until the validator is bonded, e.g. after Unjail.
+func (c *CosmosChain) WaitForBonded(ctx context.Context, valAddr string, blocks uint64) (*stakingtypes.Validator, error) {
+	return c.pollValidator(ctx, valAddr, blocks, checkBonded)
+}
+
+// checkJailed returns an error unless val is jailed.
+func checkJailed(val *stakingtypes.Validator) error {
+	if !val.Jailed {
+		return fmt.Errorf("validator %s is not jailed", val.OperatorAddress)
+	}
+	return nil
+}
+
+// checkBonded returns an error unless val is bonded.
+func checkBonded(val *stakingtypes.Validator) error {
+	if !val.IsBonded() {
+		return fmt.Errorf("validator %s status (%s) does not match expected: (%s)", val.OperatorAddress, val.Status, stakingtypes.Bonded)
+	}
+	return nil
+}
+
+// pollValidator polls the validator with the operator address valAddr for up to the given number of blocks,
+// until check returns nil.
+func (c *CosmosChain) pollValidator(ctx context.Context, valAddr string, blocks uint64, check func(*stakingtypes.Validator) error) (*stakingtypes.Validator, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height: %w", err)
+	}
+	bp := testutil.BlockPoller[*stakingtypes.Validator]{CurrentHeight: c.Height, PollFunc: validatorPollFunc(c.QueryValidator, valAddr, check)}
+	return bp.DoPoll(ctx, height, height+blocks)
+}
+
+// validatorPollFunc returns the poll func of a testutil.BlockPoller, which queries the validator
+// with the operator address valAddr with query, and returns it once check returns nil.
+func validatorPollFunc(
+	query func(ctx context.Context, valAddr string) (*stakingtypes.Validator, error),
+	valAddr string,
+	check func(*stakingtypes.Validator) error,
+) func(context.Context, uint64) (*stakingtypes.Validator, error) {
+	return func(ctx context.Context, _ uint64) (*stakingtypes.Validator, error) {
+		val, err := query(ctx, valAddr)
+		if err != nil {
+			return nil, err
+		}
+		if err := check(val); err != nil {
+			return nil, err
+		}
+		return val, nil
+	}
+}
+
+// StartDoubleSigner adds and starts a fullnode signing with a copy of the consensus key of validator val,
+// so that val double signs once the node has caught up: both nodes vote in consensus, and their votes conflict,
+// e.g. when each proposes its own block. The evidence of the double signing gets val slashed by the
+// slash_fraction_double_sign param, jailed, and tombstoned; wait for it with WaitForTombstoned.
+//
+// The double signer syncs blocks from genesis like any added fullnode, and is added to the chain's FullNodes.
+// Stop its container once val is tombstoned.
+func (c *CosmosChain) StartDoubleSigner(ctx context.Context, val *ChainNode) (*ChainNode, error) {
+	if !val.Validator {
+		return nil, fmt.Errorf("node %s is not a validator", val.Name())
+	}
+	key, err := val.ReadFile(ctx, privValidatorKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read consensus key of validator %s: %w", val.Name(), err)
+	}
+	if _, err := parsePrivValidatorKey(key); err != nil {
+		return nil, fmt.Errorf("consensus key of validator %s: %w", val.Name(), err)
+	}
+
+	nodes, err := c.addFullNodes(ctx, nil, 1, func(ctx context.Context, fn *ChainNode) error {
+		return copyConsensusKey(ctx, fn.WriteFile, val.Name(), key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// copyConsensusKey writes the consensus key of the validator named valName to the privValidatorKeyFile
+// of a double signer with write, the WriteFile of the double signer, before the double signer starts.
+func copyConsensusKey(ctx context.Context, write func(ctx context.Context, content []byte, relPath string) error, valName string, key []byte) error {
+	if err := write(ctx, key, privValidatorKeyFile); err != nil {
+		return fmt.Errorf("copy consensus key of validator %s: %w", valName, err)
+	}
+	return nil
+}
+
+// WaitForTombstoned polls the signing info of the validator with the operator address valAddr
+// for up to the given number of blocks, until the validator is tombstoned for double signing.
+func (c *CosmosChain) WaitForTombstoned(ctx context.Context, valAddr string, blocks uint64) (*slashingtypes.ValidatorSigningInfo, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height: %w", err)
+	}
+	doPoll := func(ctx context.Context, height uint64) (*slashingtypes.ValidatorSigningInfo, error) {
+		info, err := c.QuerySigningInfo(ctx, valAddr)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Tombstoned {
+			return nil, fmt.Errorf("validator %s is not tombstoned", valAddr)
+		}
+		return info, nil
+	}
+	bp := testutil.BlockPoller[*slashingtypes.ValidatorSigningInfo]{CurrentHeight: c.Height, PollFunc: doPoll}
+	return bp.DoPoll(ctx, height, height+blocks)
+}
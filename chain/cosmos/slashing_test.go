@@ -0,0 +1,118 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPauseValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 2, 0, zaptest.NewLogger(t))
+	for i := 0; i < 2; i++ {
+		c.Validators = append(c.Validators, NewChainNode(zaptest.NewLogger(t), true, c, nil, "", t.Name(), ibc.DockerImage{}, i))
+	}
+
+	require.EqualError(t, c.PauseValidator(ctx, 2), "validator index 2 is out of range of the 2 validators")
+	require.EqualError(t, c.ResumeValidator(ctx, -1), "validator index -1 is out of range of the 2 validators")
+
+	// Without full nodes, the chain is queried through its first validator.
+	require.ErrorContains(t, c.PauseValidator(ctx, 0), "cannot pause the node the chain is queried through")
+
+	val, err := c.pausableValidator(1)
+	require.NoError(t, err)
+	require.Same(t, c.Validators[1], val)
+}
+
+func TestValidatorChecks(t *testing.T) {
+	t.Parallel()
+
+	val := &stakingtypes.Validator{OperatorAddress: "cosmosvaloper1val", Status: stakingtypes.Bonded}
+	require.NoError(t, checkBonded(val))
+	require.EqualError(t, checkJailed(val), "validator cosmosvaloper1val is not jailed")
+
+	val.Jailed = true
+	val.Status = stakingtypes.Unbonding
+	require.NoError(t, checkJailed(val))
+	require.EqualError(t, checkBonded(val), "validator cosmosvaloper1val status (BOND_STATUS_UNBONDING) does not match expected: (BOND_STATUS_BONDED)")
+}
+
+func TestValidatorPollFunc(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var vals []*stakingtypes.Validator
+	query := func(_ context.Context, valAddr string) (*stakingtypes.Validator, error) {
+		require.Equal(t, "cosmosvaloper1val", valAddr)
+		if len(vals) == 0 {
+			return nil, errors.New("validator not found")
+		}
+		val := vals[0]
+		vals = vals[1:]
+		return val, nil
+	}
+	poll := validatorPollFunc(query, "cosmosvaloper1val", checkJailed)
+
+	_, err := poll(ctx, 1)
+	require.EqualError(t, err, "validator not found")
+
+	vals = []*stakingtypes.Validator{
+		{OperatorAddress: "cosmosvaloper1val"},
+		{OperatorAddress: "cosmosvaloper1val", Jailed: true},
+	}
+	_, err = poll(ctx, 2)
+	require.EqualError(t, err, "validator cosmosvaloper1val is not jailed")
+
+	val, err := poll(ctx, 3)
+	require.NoError(t, err)
+	require.Empty(t, vals)
+	require.True(t, val.Jailed)
+}
+
+func TestStartDoubleSigner_NotValidator(t *testing.T) {
+	t.Parallel()
+
+	c := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 1, zaptest.NewLogger(t))
+	fn := NewChainNode(zaptest.NewLogger(t), false, c, nil, "", t.Name(), ibc.DockerImage{}, 0)
+
+	_, err := c.StartDoubleSigner(context.Background(), fn)
+	require.EqualError(t, err, "node "+fn.Name()+" is not a validator")
+}
+
+func TestCopyConsensusKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := testValidatorKeys(t).PrivValidatorKey
+	files := make(map[string][]byte)
+	write := func(_ context.Context, content []byte, relPath string) error {
+		files[relPath] = content
+		return nil
+	}
+	require.NoError(t, copyConsensusKey(ctx, write, "val-0", key))
+	require.Equal(t, map[string][]byte{"config/priv_validator_key.json": key}, files)
+
+	write = func(context.Context, []byte, string) error { return errors.New("container not found") }
+	require.EqualError(t, copyConsensusKey(ctx, write, "val-0", key), "copy consensus key of validator val-0: container not found")
+}
+
+func TestParsePrivValidatorKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := parsePrivValidatorKey(testValidatorKeys(t).PrivValidatorKey)
+	require.NoError(t, err)
+
+	_, err = parsePrivValidatorKey([]byte("{"))
+	require.ErrorContains(t, err, "invalid priv_validator_key.json")
+
+	// An emptied key file, e.g. of a node with a remote signer.
+	_, err = parsePrivValidatorKey([]byte("{}"))
+	require.EqualError(t, err, "priv_validator_key.json has a mismatched address, public, and private key")
+}
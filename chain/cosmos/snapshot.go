@@ -0,0 +1,203 @@
+package cosmos
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+)
+
+// snapshotArchiveMetadata is the name of the entry of a snapshot archive, as written by the snapshots dump command,
+// holding the snapshot. The other entries hold the chunks, named by their index.
+const snapshotArchiveMetadata = "snapshot"
+
+// snapshotListRegexp matches a snapshot listed by the snapshots list command.
+var snapshotListRegexp = regexp.MustCompile(`height: (\d+) format: (\d+) chunks: (\d+)`)
+
+// parseSnapshotList returns the snapshots listed in the output of the snapshots list command.
+func parseSnapshotList(out []byte) ([]snapshottypes.Snapshot, error) {
+	var snapshots []snapshottypes.Snapshot
+	for _, m := range snapshotListRegexp.FindAllSubmatch(out, -1) {
+		height, err := strconv.ParseUint(string(m[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot height %q: %w", m[1], err)
+		}
+		format, err := strconv.ParseUint(string(m[2]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot format %q: %w", m[2], err)
+		}
+		chunks, err := strconv.ParseUint(string(m[3]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot chunks %q: %w", m[3], err)
+		}
+		snapshots = append(snapshots, snapshottypes.Snapshot{Height: height, Format: uint32(format), Chunks: uint32(chunks)})
+	}
+	return snapshots, nil
+}
+
+// ExportSnapshot exports a snapshot of the application state at height, which the node must still have,
+// and copies the snapshot archive out of the node's volume. Returns the path of the archive on the host,
+// in a new temporary directory owned by the caller.
+// Stop the node first, as the snapshots commands open the node's database.
+func (tn *ChainNode) ExportSnapshot(ctx context.Context, height int64) (string, error) {
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	h := strconv.FormatInt(height, 10)
+	if _, _, err := tn.ExecBin(ctx, "snapshots", "export", "--height", h); err != nil {
+		return "", fmt.Errorf("export snapshot: %w", err)
+	}
+
+	// The format of the snapshot depends on the SDK version of the chain.
+	stdout, stderr, err := tn.ExecBin(ctx, "snapshots", "list")
+	if err != nil {
+		return "", fmt.Errorf("list snapshots: %w", err)
+	}
+	snapshots, err := parseSnapshotList(append(stdout, stderr...))
+	if err != nil {
+		return "", err
+	}
+	var snapshot *snapshottypes.Snapshot
+	for i := range snapshots {
+		if snapshots[i].Height == uint64(height) {
+			snapshot = &snapshots[i]
+		}
+	}
+	if snapshot == nil {
+		return "", fmt.Errorf("exported snapshot at height %d not listed", height)
+	}
+
+	format := strconv.FormatUint(uint64(snapshot.Format), 10)
+	archiveName := fmt.Sprintf("snapshot-%s-%s.tar.gz", h, format)
+	if _, _, err := tn.ExecBin(ctx, "snapshots", "dump", h, format, "--output", path.Join(tn.HomeDir(), archiveName)); err != nil {
+		return "", fmt.Errorf("dump snapshot: %w", err)
+	}
+
+	archive, err := tn.ReadFile(ctx, archiveName)
+	if err != nil {
+		return "", fmt.Errorf("read snapshot archive from docker volume: %w", err)
+	}
+	dir, err := os.MkdirTemp("", "interchaintest-snapshot-")
+	if err != nil {
+		return "", err
+	}
+	archivePath := filepath.Join(dir, archiveName)
+	if err := os.WriteFile(archivePath, archive, 0o644); err != nil {
+		return "", err
+	}
+	return archivePath, nil
+}
+
+// RestoreSnapshot restores the application state from the snapshot archive at archivePath on the host,
+// as returned by ExportSnapshot, after verifying the archive with ReadSnapshotArchive.
+// Stop the node first, as the snapshots commands open the node's database.
+//
+// Only the application state is restored. Before starting the node, bootstrap its CometBFT state at the
+// height of the snapshot too, e.g. with the bootstrap-state command of chains built with SDK v0.47 or later.
+func (tn *ChainNode) RestoreSnapshot(ctx context.Context, archivePath string) error {
+	snapshot, err := ReadSnapshotArchive(archivePath)
+	if err != nil {
+		return err
+	}
+
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	_, archiveName := filepath.Split(archivePath)
+	if err := tn.CopyFile(ctx, archivePath, archiveName); err != nil {
+		return fmt.Errorf("writing snapshot archive to docker volume: %w", err)
+	}
+
+	if _, _, err := tn.ExecBin(ctx, "snapshots", "load", path.Join(tn.HomeDir(), archiveName)); err != nil {
+		return fmt.Errorf("load snapshot: %w", err)
+	}
+
+	h := strconv.FormatUint(snapshot.Height, 10)
+	format := strconv.FormatUint(uint64(snapshot.Format), 10)
+	if _, _, err := tn.ExecBin(ctx, "snapshots", "restore", h, format); err != nil {
+		return fmt.Errorf("restore snapshot at height %s: %w", h, err)
+	}
+	return nil
+}
+
+// ReadSnapshotArchive returns the snapshot in the snapshot archive at archivePath, as returned by ExportSnapshot.
+// It verifies the integrity of the archive: every chunk of the snapshot must be present and match its hash.
+func ReadSnapshotArchive(archivePath string) (*snapshottypes.Snapshot, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readSnapshotArchive(f)
+}
+
+func readSnapshotArchive(r io.Reader) (*snapshottypes.Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot archive: %w", err)
+	}
+	defer gz.Close()
+
+	var (
+		snapshot *snapshottypes.Snapshot
+		chunks   = make(map[uint64][]byte)
+	)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot archive: %w", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot archive entry %s: %w", hdr.Name, err)
+		}
+
+		if hdr.Name == snapshotArchiveMetadata {
+			snapshot = new(snapshottypes.Snapshot)
+			if err := snapshot.Unmarshal(content); err != nil {
+				return nil, fmt.Errorf("decode snapshot: %w", err)
+			}
+			continue
+		}
+		index, err := strconv.ParseUint(hdr.Name, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected snapshot archive entry %s", hdr.Name)
+		}
+		chunks[index] = content
+	}
+
+	if snapshot == nil {
+		return nil, errors.New("snapshot archive has no snapshot")
+	}
+	if len(snapshot.Metadata.ChunkHashes) != int(snapshot.Chunks) {
+		return nil, fmt.Errorf("snapshot has %d chunks but %d chunk hashes", snapshot.Chunks, len(snapshot.Metadata.ChunkHashes))
+	}
+	if len(chunks) != int(snapshot.Chunks) {
+		return nil, fmt.Errorf("snapshot archive has %d chunks, expected %d", len(chunks), snapshot.Chunks)
+	}
+	for i, want := range snapshot.Metadata.ChunkHashes {
+		chunk, ok := chunks[uint64(i)]
+		if !ok {
+			return nil, fmt.Errorf("snapshot archive is missing chunk %d", i)
+		}
+		if got := sha256.Sum256(chunk); !bytes.Equal(got[:], want) {
+			return nil, fmt.Errorf("chunk %d does not match its hash", i)
+		}
+	}
+	return snapshot, nil
+}
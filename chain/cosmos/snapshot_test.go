@@ -0,0 +1,100 @@
+package cosmos
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSnapshotList(t *testing.T) {
+	t.Parallel()
+
+	out := []byte("height: 10 format: 3 chunks: 1\nheight: 20 format: 3 chunks: 2\n")
+	snapshots, err := parseSnapshotList(out)
+	require.NoError(t, err)
+	require.Equal(t, []snapshottypes.Snapshot{
+		{Height: 10, Format: 3, Chunks: 1},
+		{Height: 20, Format: 3, Chunks: 2},
+	}, snapshots)
+
+	snapshots, err = parseSnapshotList(nil)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
+
+// snapshotArchive returns a snapshot archive, in the format of the snapshots dump command, of a snapshot with chunks.
+func snapshotArchive(t *testing.T, snapshot snapshottypes.Snapshot, chunks [][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	write := func(name string, content []byte) {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	for i, chunk := range chunks {
+		write(strconv.Itoa(i), chunk)
+	}
+	bz, err := snapshot.Marshal()
+	require.NoError(t, err)
+	write(snapshotArchiveMetadata, bz)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestReadSnapshotArchive(t *testing.T) {
+	t.Parallel()
+
+	chunks := [][]byte{[]byte("chunk 0"), []byte("chunk 1")}
+	snapshot := snapshottypes.Snapshot{Height: 20, Format: 3, Chunks: 2, Hash: []byte{1, 2, 3}}
+	for _, chunk := range chunks {
+		hash := sha256.Sum256(chunk)
+		snapshot.Metadata.ChunkHashes = append(snapshot.Metadata.ChunkHashes, hash[:])
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+		require.NoError(t, os.WriteFile(archivePath, snapshotArchive(t, snapshot, chunks), 0o644))
+
+		got, err := ReadSnapshotArchive(archivePath)
+		require.NoError(t, err)
+		require.Equal(t, &snapshot, got)
+	})
+
+	t.Run("missing chunk", func(t *testing.T) {
+		_, err := readSnapshotArchive(bytes.NewReader(snapshotArchive(t, snapshot, chunks[:1])))
+		require.EqualError(t, err, "snapshot archive has 1 chunks, expected 2")
+	})
+
+	t.Run("corrupt chunk", func(t *testing.T) {
+		corrupt := [][]byte{chunks[0], []byte("chunk 2")}
+		_, err := readSnapshotArchive(bytes.NewReader(snapshotArchive(t, snapshot, corrupt)))
+		require.EqualError(t, err, "chunk 1 does not match its hash")
+	})
+
+	t.Run("no snapshot", func(t *testing.T) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		require.NoError(t, tar.NewWriter(gz).Close())
+		require.NoError(t, gz.Close())
+
+		_, err := readSnapshotArchive(&buf)
+		require.EqualError(t, err, "snapshot archive has no snapshot")
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		_, err := readSnapshotArchive(bytes.NewReader([]byte("not gzip")))
+		require.ErrorContains(t, err, "open snapshot archive")
+	})
+}
@@ -0,0 +1,34 @@
+package cosmos
+
+import "github.com/strangelove-ventures/interchaintest/v7/ibc"
+
+// startConfig returns the start command config of the node, set by ChainConfig.ValidatorStart,
+// ChainConfig.FullNodeStart, or ChainConfig.Start, if any.
+func (tn *ChainNode) startConfig() ibc.StartConfig {
+	cfg := tn.Chain.Config()
+	nodeStart := cfg.FullNodeStart
+	if tn.Validator {
+		nodeStart = cfg.ValidatorStart
+	}
+	if s, ok := nodeStart[tn.Index]; ok {
+		return s
+	}
+	if cfg.Start != nil {
+		return *cfg.Start
+	}
+	return ibc.StartConfig{}
+}
+
+// startArgs returns the arguments of the binary starting the node with the home directory home.
+func (tn *ChainNode) startArgs(home string) []string {
+	s := tn.startConfig()
+	args := append([]string(nil), s.Subcommand...)
+	if len(args) == 0 {
+		args = []string{"start"}
+	}
+	args = append(args, "--home", home)
+	if !s.NoDefaultFlags {
+		args = append(args, "--x-crisis-skip-assert-invariants")
+	}
+	return append(args, s.Flags...)
+}
@@ -0,0 +1,36 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStartArgs(t *testing.T) {
+	t.Parallel()
+
+	const home = "/var/cosmos-chain/gaia"
+	require.Equal(t,
+		[]string{"start", "--home", home, "--x-crisis-skip-assert-invariants"},
+		(&ChainNode{Chain: &CosmosChain{}}).startArgs(home),
+	)
+
+	chain := &CosmosChain{cfg: ibc.ChainConfig{
+		Start:          &ibc.StartConfig{Flags: []string{"--log_level", "debug"}},
+		ValidatorStart: map[int]ibc.StartConfig{1: {Subcommand: []string{"node", "start"}, NoDefaultFlags: true}},
+		FullNodeStart:  map[int]ibc.StartConfig{0: {Flags: []string{"--grpc-only"}}},
+	}}
+
+	val0 := &ChainNode{Chain: chain, Validator: true, Index: 0}
+	require.Equal(t, []string{"start", "--home", home, "--x-crisis-skip-assert-invariants", "--log_level", "debug"}, val0.startArgs(home))
+
+	val1 := &ChainNode{Chain: chain, Validator: true, Index: 1}
+	require.Equal(t, []string{"node", "start", "--home", home}, val1.startArgs(home))
+
+	fn0 := &ChainNode{Chain: chain, Index: 0}
+	require.Equal(t, []string{"start", "--home", home, "--x-crisis-skip-assert-invariants", "--grpc-only"}, fn0.startArgs(home))
+
+	fn1 := &ChainNode{Chain: chain, Index: 1}
+	require.Equal(t, []string{"start", "--home", home, "--x-crisis-skip-assert-invariants", "--log_level", "debug"}, fn1.startArgs(home))
+}
@@ -0,0 +1,144 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+const (
+	defaultSnapshotInterval   = 10
+	defaultSnapshotKeepRecent = 2
+)
+
+// errNoStateSync is returned by state sync helpers when the chain is not configured to take snapshots.
+var errNoStateSync = errors.New("chain is not configured to take state sync snapshots; set ChainConfig.StateSync")
+
+// snapshotInterval returns the snapshot interval of cfg, which must not be nil.
+func snapshotInterval(cfg *ibc.StateSyncConfig) uint64 {
+	if cfg.SnapshotInterval == 0 {
+		return defaultSnapshotInterval
+	}
+	return cfg.SnapshotInterval
+}
+
+// stateSyncAppConfig returns the app.toml settings for a node to take snapshots as configured by cfg.
+func stateSyncAppConfig(cfg *ibc.StateSyncConfig) testutil.Toml {
+	interval := snapshotInterval(cfg)
+	keepRecent := cfg.SnapshotKeepRecent
+	if keepRecent == 0 {
+		keepRecent = defaultSnapshotKeepRecent
+	}
+
+	a := make(testutil.Toml)
+
+	stateSync := make(testutil.Toml)
+	stateSync["snapshot-interval"] = interval
+	stateSync["snapshot-keep-recent"] = keepRecent
+	a["state-sync"] = stateSync
+
+	// SDK versions before v0.46 require the snapshot interval to be a multiple of pruning-keep-every,
+	// which defaults to 100; later versions ignore it. Otherwise, prune like the default strategy.
+	a["pruning"] = "custom"
+	a["pruning-keep-recent"] = "362880"
+	a["pruning-keep-every"] = strconv.FormatUint(interval, 10)
+	a["pruning-interval"] = "10"
+
+	return a
+}
+
+// stateSyncConfig returns the config.toml settings for a node to join with state sync,
+// trusting the block with trustHash at trustHeight, and verifying light blocks from rpcServers.
+func stateSyncConfig(trustHeight int64, trustHash string, rpcServers []string) testutil.Toml {
+	stateSync := make(testutil.Toml)
+	stateSync["enable"] = true
+	stateSync["trust_height"] = trustHeight
+	stateSync["trust_hash"] = trustHash
+	stateSync["rpc_servers"] = strings.Join(rpcServers, ",")
+
+	c := make(testutil.Toml)
+	c["statesync"] = stateSync
+	return c
+}
+
+// AddStateSyncFullNodes adds inc fullnodes to the network, like AddFullNodes, which join with state sync
+// from snapshots of the existing nodes rather than by replaying blocks from genesis.
+// If the chain has not yet taken two snapshots, it first waits for them.
+// The new nodes are started but may not be in sync yet; wait with testutil.WaitForInSync.
+// Expects the chain to be configured with ChainConfig.StateSync.
+func (c *CosmosChain) AddStateSyncFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
+	if c.cfg.StateSync == nil {
+		return errNoStateSync
+	}
+	interval := snapshotInterval(c.cfg.StateSync)
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get height: %w", err)
+	}
+	if height < 2*interval {
+		if err := testutil.WaitForBlocks(ctx, int(2*interval-height), c); err != nil {
+			return fmt.Errorf("wait for snapshots: %w", err)
+		}
+		if height, err = c.Height(ctx); err != nil {
+			return fmt.Errorf("failed to get height: %w", err)
+		}
+	}
+
+	// Trust a block before the latest snapshot, which the light client verifies forwards from.
+	trustHeight := int64(height - interval)
+	node := c.getFullNode()
+	block, err := node.Client.Block(ctx, &trustHeight)
+	if err != nil {
+		return fmt.Errorf("failed to get trusted block at height %d: %w", trustHeight, err)
+	}
+
+	// State sync requires at least two RPC servers, though they may be the same.
+	var rpcServers []string
+	for _, n := range c.Nodes() {
+		rpcServers = append(rpcServers, fmt.Sprintf("tcp://%s:26657", n.HostName()))
+	}
+	if len(rpcServers) == 1 {
+		rpcServers = append(rpcServers, rpcServers[0])
+	}
+
+	overrides := make(map[string]any, len(configFileOverrides)+1)
+	for configFile, modifiedConfig := range configFileOverrides {
+		overrides[configFile] = modifiedConfig
+	}
+	configToml := stateSyncConfig(trustHeight, hex.EncodeToString(block.BlockID.Hash), rpcServers)
+	if modifiedConfig, ok := overrides["config/config.toml"]; ok {
+		modifiedToml, ok := modifiedConfig.(testutil.Toml)
+		if !ok {
+			return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", "config/config.toml", modifiedConfig)
+		}
+		configToml = mergeToml(modifiedToml, configToml)
+	}
+	overrides["config/config.toml"] = configToml
+
+	return c.AddFullNodes(ctx, overrides, inc)
+}
+
+// mergeToml returns a copy of dst with the settings of src, merging tables present in both.
+func mergeToml(dst, src testutil.Toml) testutil.Toml {
+	merged := make(testutil.Toml, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		dstTable, ok1 := merged[k].(testutil.Toml)
+		srcTable, ok2 := v.(testutil.Toml)
+		if ok1 && ok2 {
+			merged[k] = mergeToml(dstTable, srcTable)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
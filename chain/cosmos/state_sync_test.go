@@ -0,0 +1,60 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSyncAppConfig(t *testing.T) {
+	t.Parallel()
+
+	got := stateSyncAppConfig(&ibc.StateSyncConfig{})
+	require.Equal(t, testutil.Toml{"snapshot-interval": uint64(10), "snapshot-keep-recent": uint32(2)}, got["state-sync"])
+	require.Equal(t, "10", got["pruning-keep-every"])
+
+	got = stateSyncAppConfig(&ibc.StateSyncConfig{SnapshotInterval: 25, SnapshotKeepRecent: 5})
+	require.Equal(t, testutil.Toml{"snapshot-interval": uint64(25), "snapshot-keep-recent": uint32(5)}, got["state-sync"])
+	require.Equal(t, "custom", got["pruning"])
+	require.Equal(t, "25", got["pruning-keep-every"])
+}
+
+func TestStateSyncConfig(t *testing.T) {
+	t.Parallel()
+
+	got := stateSyncConfig(20, "ABCD", []string{"tcp://val-0:26657", "tcp://val-1:26657"})
+	require.Equal(t, testutil.Toml{
+		"statesync": testutil.Toml{
+			"enable":       true,
+			"trust_height": int64(20),
+			"trust_hash":   "ABCD",
+			"rpc_servers":  "tcp://val-0:26657,tcp://val-1:26657",
+		},
+	}, got)
+}
+
+func TestMergeToml(t *testing.T) {
+	t.Parallel()
+
+	dst := testutil.Toml{
+		"moniker":   "node",
+		"statesync": testutil.Toml{"chunk_fetchers": 8, "enable": false},
+		"p2p":       testutil.Toml{"seeds": ""},
+	}
+	src := testutil.Toml{
+		"statesync": testutil.Toml{"enable": true},
+		"p2p":       "replaced",
+	}
+
+	got := mergeToml(dst, src)
+	require.Equal(t, testutil.Toml{
+		"moniker":   "node",
+		"statesync": testutil.Toml{"chunk_fetchers": 8, "enable": true},
+		"p2p":       "replaced",
+	}, got)
+
+	// dst is not modified.
+	require.Equal(t, false, dst["statesync"].(testutil.Toml)["enable"])
+}
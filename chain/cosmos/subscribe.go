@@ -0,0 +1,105 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"go.uber.org/zap"
+)
+
+// Event is an event published by a node to a subscription.
+type Event struct {
+	// Query of the subscription.
+	Query string `json:"query"`
+	// Attribute values of the event, by composite key "type.key", e.g. "tx.hash", "tx.height" or "transfer.recipient".
+	Events map[string][]string `json:"events"`
+	// JSON of the event data, e.g. {"type":"tendermint/event/Tx","value":{"TxResult":{...}}}.
+	// The data is not decoded, as its fields vary across CometBFT versions.
+	Data json.RawMessage `json:"data"`
+}
+
+// Attribute returns the first value of the attribute with composite key "type.key", e.g. "tx.height".
+func (e Event) Attribute(key string) (string, bool) {
+	if vs := e.Events[key]; len(vs) > 0 {
+		return vs[0], true
+	}
+	return "", false
+}
+
+// decodeEvent decodes the result of a websocket response into the event published to a subscription.
+// The response to the subscribe request itself has an empty result, for which ok is false.
+func decodeEvent(result json.RawMessage) (ev Event, ok bool, err error) {
+	if err := json.Unmarshal(result, &ev); err != nil {
+		return Event{}, false, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return ev, ev.Query != "", nil
+}
+
+// Subscribe subscribes to the events of the node matching query, e.g. "tm.event='Tx' AND tx.hash='...'",
+// "tm.event='NewBlock'" or "tm.event='Tx' AND transfer.recipient='...'", over the node's RPC websocket.
+// The events are sent until ctx is done, after which the channel is closed. Events must be received promptly,
+// as the node cancels subscriptions of slow clients. Subscribe before sending a tx to await its events.
+func (tn *ChainNode) Subscribe(ctx context.Context, query string) (<-chan Event, error) {
+	if tn.hostRPCPort == "" {
+		return nil, fmt.Errorf("node %s has not been started", tn.Name())
+	}
+
+	var ws *jsonrpcclient.WSClient
+	ws, err := jsonrpcclient.NewWS("tcp://"+tn.hostRPCPort, "/websocket", jsonrpcclient.OnReconnect(func() {
+		// Subscriptions are dropped with the connection, e.g. if the node restarts.
+		if err := ws.Subscribe(ctx, query); err != nil {
+			tn.logger().Info("Failed to resubscribe to node events", zap.String("query", query), zap.Error(err))
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if err := ws.Start(); err != nil {
+		return nil, fmt.Errorf("failed to connect to websocket of node %s: %w", tn.Name(), err)
+	}
+	if err := ws.Subscribe(ctx, query); err != nil {
+		_ = ws.Stop()
+		return nil, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer func() { _ = ws.Stop() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case res, ok := <-ws.ResponsesCh:
+				if !ok {
+					return
+				}
+				if res.Error != nil {
+					tn.logger().Info("Node event subscription error", zap.String("query", query), zap.Error(res.Error))
+					continue
+				}
+				ev, ok, err := decodeEvent(res.Result)
+				if err != nil {
+					tn.logger().Info("Failed to decode node event", zap.String("query", query), zap.Error(err))
+					continue
+				}
+				if !ok {
+					continue
+				}
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// Subscribe subscribes to the events of the chain's full node matching query; see ChainNode.Subscribe.
+func (c *CosmosChain) Subscribe(ctx context.Context, query string) (<-chan Event, error) {
+	return c.getFullNode().Subscribe(ctx, query)
+}
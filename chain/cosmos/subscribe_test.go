@@ -0,0 +1,100 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	ev, ok, err := decodeEvent(json.RawMessage(`{}`))
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Empty(t, ev)
+
+	ev, ok, err = decodeEvent(json.RawMessage(`{
+		"query": "tm.event='Tx'",
+		"data": {"type": "tendermint/event/Tx", "value": {"TxResult": {"height": "5"}}},
+		"events": {"tx.hash": ["ABCD"], "tx.height": ["5"], "transfer.amount": ["1stake", "2stake"]}
+	}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "tm.event='Tx'", ev.Query)
+	require.JSONEq(t, `{"type": "tendermint/event/Tx", "value": {"TxResult": {"height": "5"}}}`, string(ev.Data))
+
+	v, ok := ev.Attribute("transfer.amount")
+	require.True(t, ok)
+	require.Equal(t, "1stake", v)
+	_, ok = ev.Attribute("transfer.recipient")
+	require.False(t, ok)
+
+	_, _, err = decodeEvent(json.RawMessage(`[]`))
+	require.ErrorContains(t, err, "failed to unmarshal event")
+}
+
+func TestChainNode_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	// The server publishes two events to each subscription, like the node's RPC server.
+	funcs := map[string]*rpcserver.RPCFunc{
+		"subscribe": rpcserver.NewWSRPCFunc(func(ctx *rpctypes.Context, query string) (*coretypes.ResultSubscribe, error) {
+			go func() {
+				for i := 0; i < 2; i++ {
+					res := rpctypes.NewRPCSuccessResponse(ctx.JSONReq.ID, coretypes.ResultEvent{
+						Query:  query,
+						Events: map[string][]string{"tx.height": {strconv.Itoa(i + 1)}},
+					})
+					if err := ctx.WSConn.WriteRPCResponse(ctx.WSConn.Context(), res); err != nil {
+						return
+					}
+				}
+			}()
+			return &coretypes.ResultSubscribe{}, nil
+		}, "query"),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", rpcserver.NewWebsocketManager(funcs).WebsocketHandler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := tn.Subscribe(ctx, "tm.event='Tx'")
+	require.ErrorContains(t, err, "has not been started")
+
+	tn.hostRPCPort = strings.TrimPrefix(srv.URL, "http://")
+	subCtx, subCancel := context.WithCancel(ctx)
+	events, err := tn.Subscribe(subCtx, "tm.event='Tx'")
+	require.NoError(t, err)
+
+	for _, height := range []string{"1", "2"} {
+		ev := <-events
+		require.Equal(t, "tm.event='Tx'", ev.Query)
+		v, ok := ev.Attribute("tx.height")
+		require.True(t, ok)
+		require.Equal(t, height, v)
+	}
+
+	// The channel is closed once the subscription's context is done.
+	subCancel()
+	for range events {
+	}
+}
@@ -0,0 +1,181 @@
+package cosmos
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// TokenFactoryVariant is the implementation of the tokenfactory module of a chain, whose commands differ.
+type TokenFactoryVariant int
+
+const (
+	// TokenFactoryOsmosis is the module of Osmosis, whose mint and burn commands mint to and burn from the sender.
+	TokenFactoryOsmosis TokenFactoryVariant = iota + 1
+	// TokenFactoryWasmd is the module of github.com/CosmWasm/tokenfactory used by wasmd chains, e.g. Juno,
+	// which has mint-to and burn-from commands.
+	TokenFactoryWasmd
+)
+
+func (v TokenFactoryVariant) String() string {
+	switch v {
+	case TokenFactoryOsmosis:
+		return "osmosis"
+	case TokenFactoryWasmd:
+		return "wasmd"
+	default:
+		return fmt.Sprintf("TokenFactoryVariant(%d)", int(v))
+	}
+}
+
+// Type URLs of the messages of the tokenfactory module, shared by both variants.
+const (
+	tokenFactoryMsgMintTypeURL             = "/osmosis.tokenfactory.v1beta1.MsgMint"
+	tokenFactoryMsgBurnTypeURL             = "/osmosis.tokenfactory.v1beta1.MsgBurn"
+	tokenFactoryMsgSetDenomMetadataTypeURL = "/osmosis.tokenfactory.v1beta1.MsgSetDenomMetadata"
+)
+
+// TokenFactoryVariant returns the variant of the chain's tokenfactory module, detected from the tx commands
+// of the chain's binary, and cached.
+func (c *CosmosChain) TokenFactoryVariant(ctx context.Context) (TokenFactoryVariant, error) {
+	c.tokenFactoryMu.Lock()
+	defer c.tokenFactoryMu.Unlock()
+	if c.tokenFactoryVariant != 0 {
+		return c.tokenFactoryVariant, nil
+	}
+
+	stdout, _, err := c.getFullNode().ExecBin(ctx, "tx", "tokenfactory", "--help")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tokenfactory commands: %w", err)
+	}
+	v, err := parseTokenFactoryVariant(stdout)
+	if err != nil {
+		return 0, err
+	}
+	c.tokenFactoryVariant = v
+	return v, nil
+}
+
+// parseTokenFactoryVariant returns the variant of the tokenfactory module by the available commands
+// in help, the output of the help flag of the module's tx command.
+func parseTokenFactoryVariant(help []byte) (TokenFactoryVariant, error) {
+	cmds := make(map[string]bool)
+	var available bool
+	s := bufio.NewScanner(bytes.NewReader(help))
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case strings.HasPrefix(line, "Available Commands:"):
+			available = true
+		case available && strings.TrimSpace(line) == "":
+			available = false
+		case available:
+			cmds[strings.Fields(line)[0]] = true
+		}
+	}
+
+	if !cmds["create-denom"] {
+		return 0, errors.New("chain has no tokenfactory module")
+	}
+	if cmds["mint-to"] {
+		return TokenFactoryWasmd, nil
+	}
+	return TokenFactoryOsmosis, nil
+}
+
+// TokenFactoryDenom returns the denom of the tokenfactory subdenom created by creator, e.g. factory/osmo1.../token.
+func TokenFactoryDenom(creator, subdenom string) string {
+	return "factory/" + creator + "/" + subdenom
+}
+
+// CreateTokenFactoryDenom creates the tokenfactory subdenom, administered by the account of keyName,
+// and returns its denom. The creation fee of the tokenfactory params is paid by the account.
+func (c *CosmosChain) CreateTokenFactoryDenom(ctx context.Context, keyName, subdenom string) (string, error) {
+	tn := c.getFullNode()
+	creator, err := tn.AccountKeyBech32(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tn.ExecTx(ctx, keyName, "tokenfactory", "create-denom", subdenom); err != nil {
+		return "", err
+	}
+	return TokenFactoryDenom(creator, subdenom), nil
+}
+
+// MintTo mints amount of a tokenfactory denom administered by the account of keyName to the account toAddr.
+func (c *CosmosChain) MintTo(ctx context.Context, keyName, toAddr string, amount types.Coin) error {
+	return c.tokenFactorySupplyTx(ctx, keyName, toAddr, amount, "mint", "mint-to", tokenFactoryMsgMintTypeURL, "mintToAddress")
+}
+
+// BurnFrom burns amount of a tokenfactory denom administered by the account of keyName from the account fromAddr.
+func (c *CosmosChain) BurnFrom(ctx context.Context, keyName, fromAddr string, amount types.Coin) error {
+	return c.tokenFactorySupplyTx(ctx, keyName, fromAddr, amount, "burn", "burn-from", tokenFactoryMsgBurnTypeURL, "burnFromAddress")
+}
+
+// tokenFactorySupplyTx mints or burns amount for the account addr, signed by the admin keyName:
+// with the command of the sender, e.g. mint, if addr is the admin's, or the command for other accounts
+// of the wasmd variant, e.g. mint-to. Lacking such a command, the Osmosis variant sends the message with
+// typeURL, whose field addrField sets the account.
+func (c *CosmosChain) tokenFactorySupplyTx(ctx context.Context, keyName, addr string, amount types.Coin, senderCmd, addrCmd, typeURL, addrField string) error {
+	tn := c.getFullNode()
+	sender, err := tn.AccountKeyBech32(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	if addr == sender {
+		_, err := tn.ExecTx(ctx, keyName, "tokenfactory", senderCmd, amount.String())
+		return err
+	}
+
+	v, err := c.TokenFactoryVariant(ctx)
+	if err != nil {
+		return err
+	}
+	if v == TokenFactoryWasmd {
+		_, err := tn.ExecTx(ctx, keyName, "tokenfactory", addrCmd, addr, amount.String())
+		return err
+	}
+
+	msg, err := json.Marshal(map[string]interface{}{
+		"@type":   typeURL,
+		"sender":  sender,
+		"amount":  amount,
+		addrField: addr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", typeURL, err)
+	}
+	_, err = tn.execMsgsTx(ctx, keyName, msg)
+	return err
+}
+
+// SetDenomMetadata sets the bank metadata of a tokenfactory denom administered by the account of keyName,
+// e.g. its display denom and exponent.
+func (c *CosmosChain) SetDenomMetadata(ctx context.Context, keyName string, metadata banktypes.Metadata) error {
+	tn := c.getFullNode()
+	sender, err := tn.AccountKeyBech32(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	metadataJSON, err := c.cfg.EncodingConfig.Codec.MarshalJSON(&metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata of %s: %w", metadata.Base, err)
+	}
+	msg, err := json.Marshal(map[string]interface{}{
+		"@type":    tokenFactoryMsgSetDenomMetadataTypeURL,
+		"sender":   sender,
+		"metadata": json.RawMessage(metadataJSON),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", tokenFactoryMsgSetDenomMetadataTypeURL, err)
+	}
+	_, err = tn.execMsgsTx(ctx, keyName, msg)
+	return err
+}
@@ -0,0 +1,63 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTokenFactoryVariant(t *testing.T) {
+	t.Parallel()
+
+	const osmosisHelp = `Transactions commands for the tokenfactory module
+
+Usage:
+  osmosisd tx tokenfactory [flags]
+  osmosisd tx tokenfactory [command]
+
+Available Commands:
+  burn         Burn tokens from an address. Must have admin authority to do so.
+  change-admin Changes the admin address for a factory-created denom. Must have admin authority to do so.
+  create-denom create a new denom from an account
+  mint         Mint a denom to an address. Must have admin authority to do so.
+
+Flags:
+  -h, --help   help for tokenfactory
+`
+	v, err := parseTokenFactoryVariant([]byte(osmosisHelp))
+	require.NoError(t, err)
+	require.Equal(t, TokenFactoryOsmosis, v)
+
+	const wasmdHelp = `Transactions commands for the tokenfactory module
+
+Usage:
+  junod tx tokenfactory [flags]
+  junod tx tokenfactory [command]
+
+Available Commands:
+  burn            Burn tokens from an address. Must have admin authority to do so.
+  burn-from       Burn tokens from an address. Must have admin authority to do so.
+  change-admin    Changes the admin address for a factory-created denom. Must have admin authority to do so.
+  create-denom    create a new denom from an account
+  force-transfer  Force transfer tokens from one address to another address. Must have admin authority to do so.
+  mint            Mint a denom to your address. Must have admin authority to do so.
+  mint-to         Mint a denom to an address. Must have admin authority to do so.
+  modify-metadata Changes the base data for frontends to query the data of.
+
+Flags:
+  -h, --help   help for tokenfactory
+`
+	v, err = parseTokenFactoryVariant([]byte(wasmdHelp))
+	require.NoError(t, err)
+	require.Equal(t, TokenFactoryWasmd, v)
+	require.Equal(t, "wasmd", v.String())
+
+	_, err = parseTokenFactoryVariant([]byte("Error: unknown command \"tokenfactory\" for \"gaiad tx\"\n"))
+	require.EqualError(t, err, "chain has no tokenfactory module")
+}
+
+func TestTokenFactoryDenom(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "factory/osmo1abc/token", TokenFactoryDenom("osmo1abc", "token"))
+}
@@ -1,5 +1,7 @@
 package cosmos
 
+import "encoding/json"
+
 const (
 	ProposalVoteYes        = "yes"
 	ProposalVoteNo         = "no"
@@ -49,6 +51,16 @@ type SoftwareUpgradeProposal struct {
 	Info        string // optional
 }
 
+// ProposalV1 defines a gov v1 proposal, in the format of the proposal file of the submit-proposal command.
+type ProposalV1 struct {
+	// Messages executed if the proposal passes, each encoded as JSON by the chain's codec.
+	Messages []json.RawMessage `json:"messages"`
+	Metadata string            `json:"metadata"` // optional
+	Deposit  string            `json:"deposit"`
+	Title    string            `json:"title"`
+	Summary  string            `json:"summary"`
+}
+
 // ProposalResponse is the proposal query response.
 type ProposalResponse struct {
 	ProposalID       string                   `json:"proposal_id"`
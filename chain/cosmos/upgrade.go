@@ -0,0 +1,183 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"go.uber.org/zap"
+)
+
+// upgradeBlocksAfter is the number of blocks the chain must produce after the upgrade height for UpgradeChain to succeed.
+const upgradeBlocksAfter = 2
+
+// UpgradeChain runs a software upgrade of chain to newImage at height end to end: proposer submits the upgrade proposal
+// named upgradeName with the min deposit, all validators vote yes, and once the proposal passes and the chain halts
+// at height, all nodes are restarted with newImage. The upgrade succeeded once the chain produces blocks, and the
+// upgrade handler of the new binary applied the plan at height. Errors name the phase that failed.
+//
+// The proposal must pass before height, so the voting period must end within the blocks until height,
+// e.g. by lowering it in genesis. Chains configured with ChainConfig.Cosmovisor switch binaries without restarting:
+// the new binary is staged before the proposal is submitted, and must run in the chain's current image.
+// Limit the time to wait for each phase with ctx.
+func UpgradeChain(ctx context.Context, chain *CosmosChain, proposer, upgradeName string, height uint64, newImage ibc.DockerImage) error {
+	cur, err := chain.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("upgrade %s: failed to get height: %w", upgradeName, err)
+	}
+	if height <= cur {
+		return fmt.Errorf("upgrade %s: upgrade height %d must be above the current height %d", upgradeName, height, cur)
+	}
+	oldVersion, err := chain.appVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("upgrade %s: %w", upgradeName, err)
+	}
+
+	if chain.cfg.Cosmovisor != nil {
+		if err := chain.StageUpgrade(ctx, upgradeName, newImage); err != nil {
+			return fmt.Errorf("upgrade %s: stage binary: %w", upgradeName, err)
+		}
+	}
+
+	deposit, err := chain.minDeposit(ctx)
+	if err != nil {
+		return fmt.Errorf("upgrade %s: %w", upgradeName, err)
+	}
+	tx, err := chain.UpgradeProposal(ctx, proposer, SoftwareUpgradeProposal{
+		Deposit:     deposit,
+		Title:       "Upgrade " + upgradeName,
+		Name:        upgradeName,
+		Description: fmt.Sprintf("Upgrade to %s at height %d", newImage.Ref(), height),
+		Height:      height,
+	})
+	if err != nil {
+		return fmt.Errorf("upgrade %s: submit proposal: %w", upgradeName, err)
+	}
+	if err := chain.VoteAll(ctx, tx.ProposalID, ProposalVoteYes); err != nil {
+		return fmt.Errorf("upgrade %s: vote on proposal %s: %w", upgradeName, tx.ProposalID, err)
+	}
+	if cur, err = chain.Height(ctx); err != nil {
+		return fmt.Errorf("upgrade %s: failed to get height: %w", upgradeName, err)
+	}
+	if height <= cur {
+		return fmt.Errorf("upgrade %s: upgrade height %d reached before proposal %s passed", upgradeName, height, tx.ProposalID)
+	}
+	if _, err := chain.WaitForProposalStatus(ctx, tx.ProposalID, ProposalStatusPassed, height-cur-1); err != nil {
+		return fmt.Errorf("upgrade %s: proposal %s did not pass before upgrade height %d: %w", upgradeName, tx.ProposalID, height, err)
+	}
+
+	if chain.cfg.Cosmovisor != nil {
+		if err := chain.waitForHeight(ctx, height+upgradeBlocksAfter); err != nil {
+			return fmt.Errorf("upgrade %s: chain did not produce blocks after upgrade: %w", upgradeName, err)
+		}
+		// Run later commands with the new binary.
+		chain.useImage(ctx, newImage)
+	} else {
+		if err := chain.waitForHalt(ctx, height); err != nil {
+			return fmt.Errorf("upgrade %s: %w", upgradeName, err)
+		}
+		if err := chain.StopAllNodes(ctx); err != nil {
+			return fmt.Errorf("upgrade %s: stop nodes: %w", upgradeName, err)
+		}
+		if err := chain.RestartWithImage(ctx, newImage); err != nil {
+			return fmt.Errorf("upgrade %s: restart nodes: %w", upgradeName, err)
+		}
+		if err := chain.waitForHeight(ctx, height+upgradeBlocksAfter); err != nil {
+			return fmt.Errorf("upgrade %s: chain did not produce blocks after upgrade: %w", upgradeName, err)
+		}
+	}
+
+	if err := chain.checkAppliedPlan(ctx, upgradeName, height); err != nil {
+		return fmt.Errorf("upgrade %s: %w", upgradeName, err)
+	}
+	newVersion, err := chain.appVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("upgrade %s: %w", upgradeName, err)
+	}
+	chain.log.Info("Upgraded chain",
+		zap.String("chain", chain.cfg.ChainID),
+		zap.String("upgrade", upgradeName),
+		zap.Uint64("height", height),
+		zap.String("old_version", oldVersion),
+		zap.String("new_version", newVersion),
+	)
+	return nil
+}
+
+// minDeposit returns the min deposit of gov proposals, e.g. "10000000uatom".
+func (c *CosmosChain) minDeposit(ctx context.Context) (string, error) {
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	res, err := govv1.NewQueryClient(conn).Params(ctx, &govv1.QueryParamsRequest{ParamsType: govv1.ParamDeposit})
+	if err != nil {
+		return "", fmt.Errorf("query gov params: %w", err)
+	}
+	// The params are returned by type before v0.47, and all at once since.
+	var minDeposit types.Coins
+	switch {
+	case res.Params != nil:
+		minDeposit = res.Params.MinDeposit
+	case res.DepositParams != nil:
+		minDeposit = res.DepositParams.MinDeposit
+	}
+	if minDeposit.Empty() {
+		return "", errors.New("gov params have no min deposit")
+	}
+	return minDeposit.String(), nil
+}
+
+// checkAppliedPlan returns an error unless the upgrade plan named upgradeName was applied at height.
+func (c *CosmosChain) checkAppliedPlan(ctx context.Context, upgradeName string, height uint64) error {
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := upgradetypes.NewQueryClient(conn).AppliedPlan(ctx, &upgradetypes.QueryAppliedPlanRequest{Name: upgradeName})
+	if err != nil {
+		return fmt.Errorf("query applied plan: %w", err)
+	}
+	if res.Height != int64(height) {
+		if res.Height == 0 {
+			return errors.New("plan not applied by the new binary")
+		}
+		return fmt.Errorf("plan applied at height %d, not %d", res.Height, height)
+	}
+	return nil
+}
+
+// appVersion returns the version of the app run by the chain's full node, e.g. v8.0.0.
+func (c *CosmosChain) appVersion(ctx context.Context) (string, error) {
+	info, err := c.getFullNode().Client.ABCIInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("query app version: %w", err)
+	}
+	return info.Response.Version, nil
+}
+
+// waitForHeight polls the chain's height until it reaches height. Unlike testutil.WaitForBlocks,
+// failures to get the height are retried, e.g. while the nodes restart.
+func (c *CosmosChain) waitForHeight(ctx context.Context, height uint64) error {
+	ticker := time.NewTicker(haltPollInterval)
+	defer ticker.Stop()
+	var last uint64
+	for {
+		if cur, err := c.Height(ctx); err == nil {
+			last = cur
+		}
+		if last >= height {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("height %d not reached, last height %d: %w", height, last, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cosmos
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+)
+
+// testUpgradeGovServer answers gov params queries like a chain before v0.47, by params type.
+type testUpgradeGovServer struct {
+	govv1.UnimplementedQueryServer
+}
+
+func (*testUpgradeGovServer) Params(_ context.Context, req *govv1.QueryParamsRequest) (*govv1.QueryParamsResponse, error) {
+	res := &govv1.QueryParamsResponse{}
+	if req.ParamsType == govv1.ParamDeposit {
+		res.DepositParams = &govv1.DepositParams{MinDeposit: sdk.NewCoins(sdk.NewInt64Coin("uatom", 10_000_000))}
+	}
+	return res, nil
+}
+
+// testUpgradeServer answers that the plan named "v2" was applied at height 20.
+type testUpgradeServer struct {
+	upgradetypes.UnimplementedQueryServer
+}
+
+func (*testUpgradeServer) AppliedPlan(_ context.Context, req *upgradetypes.QueryAppliedPlanRequest) (*upgradetypes.QueryAppliedPlanResponse, error) {
+	if req.Name != "v2" {
+		return &upgradetypes.QueryAppliedPlanResponse{}, nil
+	}
+	return &upgradetypes.QueryAppliedPlanResponse{Height: 20}, nil
+}
+
+func TestUpgradeChainQueries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 1, 0, zaptest.NewLogger(t))
+	tn := NewChainNode(zaptest.NewLogger(t), true, chain, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	chain.Validators = ChainNodes{tn}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	govv1.RegisterQueryServer(srv, &testUpgradeGovServer{})
+	upgradetypes.RegisterQueryServer(srv, &testUpgradeServer{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	tn.hostGRPCPort = lis.Addr().String()
+	t.Cleanup(func() { _ = tn.closeGRPCConn() })
+
+	deposit, err := chain.minDeposit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "10000000uatom", deposit)
+
+	require.NoError(t, chain.checkAppliedPlan(ctx, "v2", 20))
+	require.EqualError(t, chain.checkAppliedPlan(ctx, "v2", 15), "plan applied at height 20, not 15")
+	require.EqualError(t, chain.checkAppliedPlan(ctx, "v3", 20), "plan not applied by the new binary")
+}
@@ -0,0 +1,153 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// nodeKeyFile is the file of a node's p2p key, relative to its home directory.
+const nodeKeyFile = "config/node_key.json"
+
+// validateValidatorKeys returns an error if keys is not valid for the numValidators validators of a chain.
+func validateValidatorKeys(keys []ibc.ValidatorKeys, numValidators int) error {
+	if len(keys) > numValidators {
+		return fmt.Errorf("%d validator keys for %d validators", len(keys), numValidators)
+	}
+	for i, k := range keys {
+		if len(k.PrivValidatorKey) > 0 {
+			if _, err := parsePrivValidatorKey(k.PrivValidatorKey); err != nil {
+				return fmt.Errorf("validator %d: %w", i, err)
+			}
+		}
+		if len(k.NodeKey) > 0 {
+			var nodeKey p2p.NodeKey
+			if err := tmjson.Unmarshal(k.NodeKey, &nodeKey); err != nil {
+				return fmt.Errorf("validator %d: invalid node_key.json: %w", i, err)
+			}
+			if nodeKey.PrivKey == nil {
+				return fmt.Errorf("validator %d: node_key.json has no private key", i)
+			}
+		}
+	}
+	return nil
+}
+
+// parsePrivValidatorKey parses the priv_validator_key.json file bz, and checks that its address and keys match.
+func parsePrivValidatorKey(bz []byte) (privval.FilePVKey, error) {
+	var pvKey privval.FilePVKey
+	if err := tmjson.Unmarshal(bz, &pvKey); err != nil {
+		return pvKey, fmt.Errorf("invalid priv_validator_key.json: %w", err)
+	}
+	if pvKey.PrivKey == nil || !pvKey.PrivKey.PubKey().Equals(pvKey.PubKey) || !bytes.Equal(pvKey.PubKey.Address(), pvKey.Address) {
+		return pvKey, errors.New("priv_validator_key.json has a mismatched address, public, and private key")
+	}
+	return pvKey, nil
+}
+
+// importValidatorKeys overwrites the key files of the validator, generated by InitFullNodeFiles,
+// with its keys in ChainConfig.ValidatorKeys, if any.
+func (tn *ChainNode) importValidatorKeys(ctx context.Context) error {
+	keys := tn.Chain.Config().ValidatorKeys
+	if tn.Index >= len(keys) {
+		return nil
+	}
+	if k := keys[tn.Index].PrivValidatorKey; len(k) > 0 {
+		if err := tn.WriteFile(ctx, k, privValidatorKeyFile); err != nil {
+			return fmt.Errorf("import consensus key of validator %s: %w", tn.Name(), err)
+		}
+	}
+	if k := keys[tn.Index].NodeKey; len(k) > 0 {
+		if err := tn.WriteFile(ctx, k, nodeKeyFile); err != nil {
+			return fmt.Errorf("import node key of validator %s: %w", tn.Name(), err)
+		}
+	}
+	return nil
+}
+
+// ValidatorKeys returns the key files of the node, which reproduce its consensus key and node ID
+// when set in ChainConfig.ValidatorKeys of another chain.
+func (tn *ChainNode) ValidatorKeys(ctx context.Context) (ibc.ValidatorKeys, error) {
+	pvKey, err := tn.ReadFile(ctx, privValidatorKeyFile)
+	if err != nil {
+		return ibc.ValidatorKeys{}, fmt.Errorf("read consensus key: %w", err)
+	}
+	nodeKey, err := tn.ReadFile(ctx, nodeKeyFile)
+	if err != nil {
+		return ibc.ValidatorKeys{}, fmt.Errorf("read node key: %w", err)
+	}
+	return ibc.ValidatorKeys{PrivValidatorKey: pvKey, NodeKey: nodeKey}, nil
+}
+
+// ExportValidatorKeys returns the key files of the chain's validators by index,
+// e.g. to run a chain with the same validator set by setting them in ChainConfig.ValidatorKeys.
+func (c *CosmosChain) ExportValidatorKeys(ctx context.Context) ([]ibc.ValidatorKeys, error) {
+	keys := make([]ibc.ValidatorKeys, len(c.Validators))
+	for i, v := range c.Validators {
+		k, err := v.ValidatorKeys(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export keys of validator %s: %w", v.Name(), err)
+		}
+		keys[i] = k
+	}
+	return keys, nil
+}
+
+// SaveValidatorKeys writes the key files of keys to dir on the host, in a subdirectory per validator index,
+// e.g. dir/0/priv_validator_key.json and dir/0/node_key.json. Empty keys are not written.
+func SaveValidatorKeys(dir string, keys []ibc.ValidatorKeys) error {
+	for i, k := range keys {
+		valDir := filepath.Join(dir, strconv.Itoa(i))
+		if err := os.MkdirAll(valDir, 0o700); err != nil {
+			return err
+		}
+		for name, bz := range map[string][]byte{
+			filepath.Base(privValidatorKeyFile): k.PrivValidatorKey,
+			filepath.Base(nodeKeyFile):          k.NodeKey,
+		} {
+			if len(bz) == 0 {
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(valDir, name), bz, 0o600); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadValidatorKeys reads the key files of validators written by SaveValidatorKeys to dir on the host,
+// for ChainConfig.ValidatorKeys. Missing key files are left empty.
+func LoadValidatorKeys(dir string) ([]ibc.ValidatorKeys, error) {
+	var keys []ibc.ValidatorKeys
+	for i := 0; ; i++ {
+		valDir := filepath.Join(dir, strconv.Itoa(i))
+		if _, err := os.Stat(valDir); errors.Is(err, os.ErrNotExist) {
+			return keys, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var k ibc.ValidatorKeys
+		for name, dst := range map[string]*[]byte{
+			filepath.Base(privValidatorKeyFile): &k.PrivValidatorKey,
+			filepath.Base(nodeKeyFile):          &k.NodeKey,
+		} {
+			bz, err := os.ReadFile(filepath.Join(valDir, name))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			*dst = bz
+		}
+		keys = append(keys, k)
+	}
+}
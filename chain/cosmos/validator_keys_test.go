@@ -0,0 +1,54 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidatorKeys(t *testing.T) ibc.ValidatorKeys {
+	pvKey, err := tmjson.Marshal(privval.NewFilePV(ed25519.GenPrivKey(), "", "").Key)
+	require.NoError(t, err)
+	nodeKey, err := tmjson.Marshal(p2p.NodeKey{PrivKey: ed25519.GenPrivKey()})
+	require.NoError(t, err)
+	return ibc.ValidatorKeys{PrivValidatorKey: pvKey, NodeKey: nodeKey}
+}
+
+func TestValidateValidatorKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := []ibc.ValidatorKeys{testValidatorKeys(t), {}}
+	require.NoError(t, validateValidatorKeys(nil, 1))
+	require.NoError(t, validateValidatorKeys(keys, 2))
+
+	require.EqualError(t, validateValidatorKeys(keys, 1), "2 validator keys for 1 validators")
+
+	priv := ed25519.GenPrivKey()
+	mismatched, err := tmjson.Marshal(privval.FilePVKey{Address: priv.PubKey().Address(), PubKey: priv.PubKey(), PrivKey: ed25519.GenPrivKey()})
+	require.NoError(t, err)
+	require.EqualError(t, validateValidatorKeys([]ibc.ValidatorKeys{{PrivValidatorKey: mismatched}}, 1),
+		"validator 0: priv_validator_key.json has a mismatched address, public, and private key")
+
+	require.ErrorContains(t, validateValidatorKeys([]ibc.ValidatorKeys{{}, {NodeKey: []byte("{")}}, 2), "validator 1: invalid node_key.json")
+}
+
+func TestSaveLoadValidatorKeys(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keys := []ibc.ValidatorKeys{testValidatorKeys(t), {NodeKey: testValidatorKeys(t).NodeKey}}
+	require.NoError(t, SaveValidatorKeys(dir, keys))
+
+	got, err := LoadValidatorKeys(dir)
+	require.NoError(t, err)
+	require.Equal(t, keys, got)
+
+	got, err = LoadValidatorKeys(t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,219 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+)
+
+const (
+	// validatorFeeFunds is the amount of the chain's denom, in addition to the self delegation, that AddValidator
+	// funds a new validator with, for the fees of its txs.
+	validatorFeeFunds = 100_000_000
+
+	// validatorChangeBlocks is the number of blocks that AddValidator and RemoveValidator wait for up to,
+	// for a change of the validator set to take effect.
+	validatorChangeBlocks = 10
+)
+
+// CreateValidator submits a MsgCreateValidator for a validator with the node's consensus key,
+// operated by the account of the node's validator key, which self delegates selfDelegation.
+func (tn *ChainNode) CreateValidator(ctx context.Context, selfDelegation types.Coin) error {
+	pubKey, err := tn.ConsensusPubKey(ctx)
+	if err != nil {
+		return err
+	}
+	pubKeyJSON, err := tn.Chain.Config().EncodingConfig.Codec.MarshalInterfaceJSON(&ed25519.PubKey{Key: []byte(pubKey)})
+	if err != nil {
+		return fmt.Errorf("marshal consensus pub key: %w", err)
+	}
+	v, err := tn.sdkVersion(ctx)
+	if err != nil {
+		return err
+	}
+	command, validatorFile, err := tn.createValidatorCommand(v, pubKeyJSON, selfDelegation)
+	if err != nil {
+		return err
+	}
+	if validatorFile != nil {
+		if err := tn.WriteFile(ctx, validatorFile, createValidatorFileName); err != nil {
+			return fmt.Errorf("write validator file: %w", err)
+		}
+	}
+	_, err = tn.ExecTx(ctx, valKey, command...)
+	return err
+}
+
+// AddValidator adds a validator to the running chain: it starts a new node, funds the node's validator key
+// from the validator key of the first validator with selfDelegation and fees, and submits a MsgCreateValidator
+// self delegating selfDelegation. It returns the node, added to the chain's Validators,
+// once the validator is bonded and signs blocks.
+//
+// The validator only joins the active set if selfDelegation exceeds the stake of the
+// last validator in the set once the set is full, by the staking param max_validators.
+func (c *CosmosChain) AddValidator(ctx context.Context, selfDelegation types.Coin) (*ChainNode, error) {
+	peers := c.Nodes().PeerString(ctx)
+	genbz, err := c.Validators[0].genesisFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fullNode := c.getFullNode()
+	val, err := c.NewChainNode(ctx, c.testName, fullNode.DockerClient, fullNode.NetworkID, c.Config().Images[0], true, nextNodeIndex(c.Validators))
+	if err != nil {
+		return nil, err
+	}
+	err = c.startAddedNode(ctx, val, peers, genbz, c.cfg.ConfigFileOverrides, func(ctx context.Context, val *ChainNode) error {
+		return val.CreateKey(ctx, valKey)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start validator %s: %w", val.Name(), err)
+	}
+
+	c.findTxMu.Lock()
+	c.Validators = append(c.Validators, val)
+	c.numValidators++
+	c.findTxMu.Unlock()
+
+	if err := c.fundValidator(ctx, val, selfDelegation); err != nil {
+		return nil, fmt.Errorf("fund validator %s: %w", val.Name(), err)
+	}
+	if err := val.CreateValidator(ctx, selfDelegation); err != nil {
+		return nil, fmt.Errorf("create validator %s: %w", val.Name(), err)
+	}
+
+	valAddr, err := val.ValidatorAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.WaitForBonded(ctx, valAddr, validatorChangeBlocks); err != nil {
+		return nil, err
+	}
+	if err := c.waitForSigning(ctx, val, validatorChangeBlocks); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// fundValidator funds the validator key of val with selfDelegation, and the fees of its txs.
+func (c *CosmosChain) fundValidator(ctx context.Context, val *ChainNode, selfDelegation types.Coin) error {
+	addr, err := val.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+	_, err = c.Validators[0].ExecTx(ctx, valKey, fundValidatorCommand(addr, selfDelegation, c.cfg.Denom)...)
+	return err
+}
+
+// fundValidatorCommand returns the tx command sending addr selfDelegation and validatorFeeFunds of denom.
+// The amounts are sent as coins, rather than the int64 amounts of ibc.WalletAmount,
+// as self delegations of 18 decimal denoms often exceed int64.
+func fundValidatorCommand(addr string, selfDelegation types.Coin, denom string) []string {
+	funds := types.NewCoins(selfDelegation).Add(types.NewInt64Coin(denom, validatorFeeFunds))
+	return []string{"bank", "send", valKey, addr, funds.String()}
+}
+
+// waitForSigning polls blocks for up to the given number of blocks, until the commit of a block is signed by val.
+func (c *CosmosChain) waitForSigning(ctx context.Context, val *ChainNode, blocks uint64) error {
+	pubKey, err := val.ConsensusPubKey(ctx)
+	if err != nil {
+		return err
+	}
+	consAddr := pubKey.Address()
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get height: %w", err)
+	}
+	doPoll := func(ctx context.Context, height uint64) (any, error) {
+		h := int64(height)
+		res, err := c.getFullNode().Client.Block(ctx, &h)
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range res.Block.LastCommit.Signatures {
+			if bytes.Equal(sig.ValidatorAddress, consAddr) {
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("block %d is not signed by validator %s", height, val.Name())
+	}
+	bp := testutil.BlockPoller[any]{CurrentHeight: c.Height, PollFunc: doPoll}
+	_, err = bp.DoPoll(ctx, height, height+blocks)
+	return err
+}
+
+// RemoveValidator removes validator val from the running chain: it unbonds the validator's self delegation,
+// waits for the validator to leave the active set, then stops and removes the node's container,
+// and removes the node from the chain's Validators.
+// The other validators must have more than 2/3 of the voting power to keep producing blocks.
+func (c *CosmosChain) RemoveValidator(ctx context.Context, val *ChainNode) error {
+	if !val.Validator {
+		return fmt.Errorf("node %s is not a validator", val.Name())
+	}
+	if val == c.getFullNode() {
+		// The chain's queries would fail once the node is removed.
+		return errors.New("cannot remove the node the chain is queried through; add a full node to the chain")
+	}
+
+	valAddr, err := val.ValidatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+	delegator, err := val.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+	conn, err := c.GRPCConn(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := stakingtypes.NewQueryClient(conn).Delegation(ctx, &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: delegator,
+		ValidatorAddr: valAddr,
+	})
+	if err != nil {
+		return fmt.Errorf("query self delegation of validator %s: %w", val.Name(), err)
+	}
+
+	if _, err := val.ExecTx(ctx, valKey, "staking", "unbond", valAddr, res.DelegationResponse.Balance.String()); err != nil {
+		return fmt.Errorf("unbond validator %s: %w", val.Name(), err)
+	}
+	_, err = c.pollValidator(ctx, valAddr, validatorChangeBlocks, func(v *stakingtypes.Validator) error {
+		if v.IsBonded() {
+			return fmt.Errorf("validator %s is still bonded", valAddr)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// The validator set update takes effect 2 blocks after the validator is unbonded.
+	if err := testutil.WaitForBlocks(ctx, 2, c.getFullNode()); err != nil {
+		return err
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop validator %s: %w", val.Name(), err)
+	}
+	if err := val.RemoveContainer(ctx); err != nil {
+		return fmt.Errorf("remove validator %s: %w", val.Name(), err)
+	}
+
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	for i, v := range c.Validators {
+		if v == val {
+			c.Validators = append(c.Validators[:i:i], c.Validators[i+1:]...)
+			c.numValidators--
+			break
+		}
+	}
+	return nil
+}
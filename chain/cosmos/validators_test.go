@@ -0,0 +1,24 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundValidatorCommand(t *testing.T) {
+	t.Parallel()
+
+	// A self delegation of 10^12 tokens of an 18 decimal denom exceeds int64.
+	amount, ok := types.NewIntFromString("1000000000000000000000000000000")
+	require.True(t, ok)
+	selfDelegation := types.NewCoin("aevmos", amount)
+	require.False(t, selfDelegation.Amount.IsInt64())
+
+	require.Equal(t, []string{"bank", "send", valKey, "evmos1val", "1000000000000000000000100000000aevmos"},
+		fundValidatorCommand("evmos1val", selfDelegation, "aevmos"))
+
+	require.Equal(t, []string{"bank", "send", valKey, "cosmos1val", "100000000stake,100uatom"},
+		fundValidatorCommand("cosmos1val", types.NewInt64Coin("uatom", 100), "stake"))
+}
@@ -0,0 +1,126 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/icza/dyno"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+)
+
+// vestingAccount returns the vesting account of a genesis wallet with a vesting schedule.
+func vestingAccount(wallet ibc.WalletAmount) (authtypes.GenesisAccount, error) {
+	schedule := wallet.Vesting
+	baseAcc := &authtypes.BaseAccount{Address: wallet.Address}
+	coins := func(amount int64) types.Coins {
+		return types.NewCoins(types.NewInt64Coin(wallet.Denom, amount))
+	}
+
+	var acc authtypes.GenesisAccount
+	var vestingAmount int64
+	switch schedule.Type {
+	case ibc.ContinuousVesting:
+		if schedule.StartTime.IsZero() || schedule.EndTime.IsZero() {
+			return nil, errors.New("continuous vesting requires a start and end time")
+		}
+		vestingAmount = schedule.Amount
+		acc = vestingtypes.NewContinuousVestingAccount(baseAcc, coins(vestingAmount), schedule.StartTime.Unix(), schedule.EndTime.Unix())
+	case ibc.DelayedVesting:
+		if schedule.EndTime.IsZero() {
+			return nil, errors.New("delayed vesting requires an end time")
+		}
+		vestingAmount = schedule.Amount
+		acc = vestingtypes.NewDelayedVestingAccount(baseAcc, coins(vestingAmount), schedule.EndTime.Unix())
+	case ibc.PeriodicVesting:
+		if schedule.StartTime.IsZero() || len(schedule.Periods) == 0 {
+			return nil, errors.New("periodic vesting requires a start time and periods")
+		}
+		periods := make(vestingtypes.Periods, len(schedule.Periods))
+		for i, p := range schedule.Periods {
+			if p.Length <= 0 || p.Amount <= 0 {
+				return nil, fmt.Errorf("vesting period %d must have a positive length and amount", i)
+			}
+			periods[i] = vestingtypes.Period{Length: int64(p.Length.Seconds()), Amount: coins(p.Amount)}
+			vestingAmount += p.Amount
+		}
+		acc = vestingtypes.NewPeriodicVestingAccount(baseAcc, coins(vestingAmount), schedule.StartTime.Unix(), periods)
+	default:
+		return nil, fmt.Errorf("unsupported vesting type %d", schedule.Type)
+	}
+
+	if vestingAmount <= 0 || vestingAmount > wallet.Amount {
+		return nil, fmt.Errorf("vesting amount %d must be positive and at most the wallet amount %d", vestingAmount, wallet.Amount)
+	}
+	if err := acc.Validate(); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// hasVestingWallets reports whether any of the genesis wallets has a vesting schedule.
+func hasVestingWallets(wallets []ibc.WalletAmount) bool {
+	for _, wallet := range wallets {
+		if wallet.Vesting != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// setGenesisVestingAccounts replaces the accounts of the wallets with a vesting schedule in the genesis genbz,
+// added by add-genesis-account, with vesting accounts.
+func setGenesisVestingAccounts(cdc codec.JSONCodec, genbz []byte, wallets []ibc.WalletAmount) ([]byte, error) {
+	g := make(map[string]interface{})
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	accounts, err := dyno.GetSlice(g, "app_state", "auth", "accounts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts from genesis json: %w", err)
+	}
+
+	for _, wallet := range wallets {
+		if wallet.Vesting == nil {
+			continue
+		}
+		acc, err := vestingAccount(wallet)
+		if err != nil {
+			return nil, fmt.Errorf("vesting account %s: %w", wallet.Address, err)
+		}
+		bz, err := cdc.MarshalInterfaceJSON(acc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal vesting account %s: %w", wallet.Address, err)
+		}
+		var accJSON map[string]interface{}
+		if err := json.Unmarshal(bz, &accJSON); err != nil {
+			return nil, err
+		}
+
+		i := genesisAccountIndex(accounts, wallet.Address)
+		if i < 0 {
+			return nil, fmt.Errorf("genesis has no account %s", wallet.Address)
+		}
+		accounts[i] = accJSON
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// genesisAccountIndex returns the index of the base account with address in the genesis accounts, or -1.
+func genesisAccountIndex(accounts []interface{}, address string) int {
+	for i, acc := range accounts {
+		if addr, err := dyno.GetString(acc, "address"); err == nil && addr == address {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,168 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1680000000, 0)
+	end := start.Add(time.Hour)
+	wallet := func(schedule ibc.VestingSchedule) ibc.WalletAmount {
+		return ibc.WalletAmount{Address: "cosmos1vesting", Denom: "uatom", Amount: 1000, Vesting: &schedule}
+	}
+	coins := func(amount int64) types.Coins {
+		return types.NewCoins(types.NewInt64Coin("uatom", amount))
+	}
+
+	t.Run("continuous", func(t *testing.T) {
+		acc, err := vestingAccount(wallet(ibc.VestingSchedule{Type: ibc.ContinuousVesting, Amount: 600, StartTime: start, EndTime: end}))
+		require.NoError(t, err)
+		cva, ok := acc.(*vestingtypes.ContinuousVestingAccount)
+		require.True(t, ok)
+		require.Equal(t, "cosmos1vesting", cva.Address)
+		require.Equal(t, coins(600), cva.OriginalVesting)
+		require.Equal(t, start.Unix(), cva.StartTime)
+		require.Equal(t, end.Unix(), cva.EndTime)
+	})
+
+	t.Run("delayed", func(t *testing.T) {
+		acc, err := vestingAccount(wallet(ibc.VestingSchedule{Type: ibc.DelayedVesting, Amount: 1000, EndTime: end}))
+		require.NoError(t, err)
+		dva, ok := acc.(*vestingtypes.DelayedVestingAccount)
+		require.True(t, ok)
+		require.Equal(t, coins(1000), dva.OriginalVesting)
+		require.Equal(t, end.Unix(), dva.EndTime)
+	})
+
+	t.Run("periodic", func(t *testing.T) {
+		acc, err := vestingAccount(wallet(ibc.VestingSchedule{
+			Type:      ibc.PeriodicVesting,
+			StartTime: start,
+			Periods: []ibc.VestingPeriod{
+				{Length: time.Minute, Amount: 100},
+				{Length: time.Hour, Amount: 200},
+			},
+		}))
+		require.NoError(t, err)
+		pva, ok := acc.(*vestingtypes.PeriodicVestingAccount)
+		require.True(t, ok)
+		require.Equal(t, coins(300), pva.OriginalVesting)
+		require.Equal(t, start.Unix(), pva.StartTime)
+		require.Equal(t, start.Add(time.Minute+time.Hour).Unix(), pva.EndTime)
+		require.Equal(t, []vestingtypes.Period{
+			{Length: 60, Amount: coins(100)},
+			{Length: 3600, Amount: coins(200)},
+		}, pva.VestingPeriods)
+	})
+
+	for name, tc := range map[string]struct {
+		schedule ibc.VestingSchedule
+		err      string
+	}{
+		"continuous without end time": {
+			schedule: ibc.VestingSchedule{Type: ibc.ContinuousVesting, Amount: 100, StartTime: start},
+			err:      "continuous vesting requires a start and end time",
+		},
+		"continuous ending before start": {
+			schedule: ibc.VestingSchedule{Type: ibc.ContinuousVesting, Amount: 100, StartTime: end, EndTime: start},
+			err:      "vesting start-time cannot be before end-time",
+		},
+		"delayed without end time": {
+			schedule: ibc.VestingSchedule{Type: ibc.DelayedVesting, Amount: 100},
+			err:      "delayed vesting requires an end time",
+		},
+		"periodic without periods": {
+			schedule: ibc.VestingSchedule{Type: ibc.PeriodicVesting, StartTime: start},
+			err:      "periodic vesting requires a start time and periods",
+		},
+		"empty period": {
+			schedule: ibc.VestingSchedule{Type: ibc.PeriodicVesting, StartTime: start, Periods: []ibc.VestingPeriod{{Length: time.Minute}}},
+			err:      "vesting period 0 must have a positive length and amount",
+		},
+		"more than wallet amount": {
+			schedule: ibc.VestingSchedule{Type: ibc.DelayedVesting, Amount: 1001, EndTime: end},
+			err:      "vesting amount 1001 must be positive and at most the wallet amount 1000",
+		},
+		"no amount": {
+			schedule: ibc.VestingSchedule{Type: ibc.ContinuousVesting, StartTime: start, EndTime: end},
+			err:      "vesting amount 0 must be positive and at most the wallet amount 1000",
+		},
+		"invalid type": {
+			schedule: ibc.VestingSchedule{Type: ibc.VestingType(7), Amount: 100, EndTime: end},
+			err:      "unsupported vesting type 7",
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := vestingAccount(wallet(tc.schedule))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestSetGenesisVestingAccounts(t *testing.T) {
+	t.Parallel()
+
+	cdc := codec.NewProtoCodec(DefaultEncoding().InterfaceRegistry)
+	genbz := []byte(`{"app_state":{"auth":{"accounts":[
+		{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"cosmos1plain","pub_key":null,"account_number":"0","sequence":"0"},
+		{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"cosmos1vesting","pub_key":null,"account_number":"0","sequence":"0"}
+	]}}}`)
+
+	end := time.Unix(1680000000, 0)
+	wallets := []ibc.WalletAmount{
+		{Address: "cosmos1plain", Denom: "uatom", Amount: 1000},
+		{Address: "cosmos1vesting", Denom: "uatom", Amount: 1000, Vesting: &ibc.VestingSchedule{Type: ibc.DelayedVesting, Amount: 500, EndTime: end}},
+	}
+	require.True(t, hasVestingWallets(wallets))
+	require.False(t, hasVestingWallets(wallets[:1]))
+
+	out, err := setGenesisVestingAccounts(cdc, genbz, wallets)
+	require.NoError(t, err)
+
+	var g struct {
+		AppState struct {
+			Auth struct {
+				Accounts []json.RawMessage `json:"accounts"`
+			} `json:"auth"`
+		} `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(out, &g))
+	accounts := g.AppState.Auth.Accounts
+	require.Len(t, accounts, 2)
+	require.JSONEq(t, `{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"cosmos1plain","pub_key":null,"account_number":"0","sequence":"0"}`, string(accounts[0]))
+
+	var acc vestingtypes.DelayedVestingAccount
+	require.NoError(t, cdc.UnmarshalJSON(stripAnyType(t, accounts[1], "/cosmos.vesting.v1beta1.DelayedVestingAccount"), &acc))
+	require.Equal(t, "cosmos1vesting", acc.Address)
+	require.Equal(t, types.NewCoins(types.NewInt64Coin("uatom", 500)), acc.OriginalVesting)
+	require.Equal(t, end.Unix(), acc.EndTime)
+
+	_, err = setGenesisVestingAccounts(cdc, genbz, []ibc.WalletAmount{
+		{Address: "cosmos1missing", Denom: "uatom", Amount: 1000, Vesting: wallets[1].Vesting},
+	})
+	require.EqualError(t, err, "genesis has no account cosmos1missing")
+}
+
+// stripAnyType checks that the JSON of an Any has the type URL typeURL, and returns the JSON of its message.
+func stripAnyType(t *testing.T, bz []byte, typeURL string) []byte {
+	t.Helper()
+
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(bz, &m))
+	require.JSONEq(t, `"`+typeURL+`"`, string(m["@type"]))
+	delete(m, "@type")
+	out, err := json.Marshal(m)
+	require.NoError(t, err)
+	return out
+}
@@ -0,0 +1,264 @@
+package cosmos
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/internal/tendermint"
+)
+
+// The messages and states of 08-wasm light clients (https://github.com/cosmos/ibc-go/tree/main/modules/light-clients/08-wasm)
+// are encoded as JSON here, rather than depending on the 08-wasm module, which is not part of ibc-go v7.
+// Unlike StoreClientContract, which pushes contracts with the tx of the feat/wasm-client branch of ibc-go,
+// the 08-wasm module stores contracts by gov proposals.
+const (
+	wasmStoreCodeTypeURL      = "/ibc.lightclients.wasm.v1.MsgStoreCode"
+	wasmClientStateTypeURL    = "/ibc.lightclients.wasm.v1.ClientState"
+	wasmConsensusStateTypeURL = "/ibc.lightclients.wasm.v1.ConsensusState"
+
+	// wasmStoreCodeDeposit is the deposit of proposals storing light client contracts, in the chain's denom.
+	wasmStoreCodeDeposit = 10_000_000
+
+	// wasmStoreCodeBlocks is the number of blocks the proposals storing light client contracts are polled for until they pass.
+	wasmStoreCodeBlocks = 50
+
+	// wasmClientMaxClockDrift is the max clock drift of tendermint light clients created by TendermintWasmClient,
+	// like the default of relayers.
+	wasmClientMaxClockDrift = 10 * time.Minute
+)
+
+// WasmChecksum returns the checksum identifying the light client contract wasmCode, which may be gzipped,
+// once stored on a chain with StoreClientContractByGov: the hex-encoded SHA-256 hash of the uncompressed code.
+func WasmChecksum(wasmCode []byte) (string, error) {
+	if bytes.HasPrefix(wasmCode, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(wasmCode))
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress wasm code: %w", err)
+		}
+		wasmCode, err = io.ReadAll(zr)
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress wasm code: %w", err)
+		}
+	}
+	hash := sha256.Sum256(wasmCode)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// wasmStoreCodeProposal returns the proposal storing the light client contract wasmCode, by the gov module authority.
+func wasmStoreCodeProposal(authority string, wasmCode []byte, checksum string, deposit string) (ProposalV1, error) {
+	msg, err := json.Marshal(map[string]any{
+		"@type":          wasmStoreCodeTypeURL,
+		"signer":         authority,
+		"wasm_byte_code": wasmCode,
+	})
+	if err != nil {
+		return ProposalV1{}, err
+	}
+	return ProposalV1{
+		Messages: []json.RawMessage{msg},
+		Deposit:  deposit,
+		Title:    "Store 08-wasm light client " + checksum[:8],
+		Summary:  "Stores the 08-wasm light client contract with checksum " + checksum,
+	}, nil
+}
+
+// StoreClientContractByGov stores the 08-wasm light client contract at the local path fileName by a gov proposal
+// submitted by keyName, for which all validators vote, and returns its checksum once the proposal passed.
+// Contracts larger than the max tx size of the chain, about 1MB by default, must be gzipped.
+// Create clients of the contract with CreateWasmClient.
+func (c *CosmosChain) StoreClientContractByGov(ctx context.Context, keyName string, fileName string) (string, error) {
+	wasmCode, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read light client contract: %w", err)
+	}
+	checksum, err := WasmChecksum(wasmCode)
+	if err != nil {
+		return "", err
+	}
+	prop, err := wasmStoreCodeProposal(c.GovAuthority(), wasmCode, checksum, fmt.Sprintf("%d%s", wasmStoreCodeDeposit, c.cfg.Denom))
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := c.getFullNode().submitProposal(ctx, keyName, prop, "--gas", "auto")
+	if err != nil {
+		return "", fmt.Errorf("failed to submit proposal storing light client contract: %w", err)
+	}
+	tx, err := c.txProposal(txHash)
+	if err != nil {
+		return "", err
+	}
+	if err := c.VoteAll(ctx, tx.ProposalID, ProposalVoteYes); err != nil {
+		return "", fmt.Errorf("failed to vote on proposal storing light client contract: %w", err)
+	}
+	if _, err := c.WaitForProposalStatus(ctx, tx.ProposalID, ProposalStatusPassed, wasmStoreCodeBlocks); err != nil {
+		return "", fmt.Errorf("proposal storing light client contract did not pass: %w", err)
+	}
+
+	checksums, err := c.QueryClientContractChecksums(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, cs := range checksums {
+		if cs == checksum {
+			return checksum, nil
+		}
+	}
+	return "", fmt.Errorf("light client contract %s not stored by proposal %s", checksum, tx.ProposalID)
+}
+
+// QueryClientContractChecksums returns the checksums of the 08-wasm light client contracts stored on the chain.
+func (c *CosmosChain) QueryClientContractChecksums(ctx context.Context) ([]string, error) {
+	stdout, _, err := c.getFullNode().ExecQuery(ctx, "ibc-wasm", "checksums")
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Checksums []string `json:"checksums"`
+	}
+	if err := json.Unmarshal(stdout, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal light client contract checksums: %w", err)
+	}
+	return res.Checksums, nil
+}
+
+// WasmClient is the initial state of an 08-wasm light client, created with CreateWasmClient.
+type WasmClient struct {
+	// Checksum of the light client contract, from StoreClientContractByGov.
+	Checksum string
+	// Client and consensus states of the light client, encoded for the contract,
+	// e.g. the proto-encoded tendermint states of a tendermint light client contract.
+	ClientStateData    []byte
+	ConsensusStateData []byte
+	// Height of the counterparty chain of the consensus state.
+	LatestHeight clienttypes.Height
+}
+
+// clientStateJSON returns the wasm client state of the client, encoded as JSON like by the chain's codec.
+func (w WasmClient) clientStateJSON() ([]byte, error) {
+	checksum, err := hex.DecodeString(w.Checksum)
+	if err != nil {
+		return nil, fmt.Errorf("invalid light client contract checksum %q: %w", w.Checksum, err)
+	}
+	return json.Marshal(map[string]any{
+		"@type":    wasmClientStateTypeURL,
+		"data":     w.ClientStateData,
+		"checksum": checksum,
+		"latest_height": map[string]string{
+			"revision_number": fmt.Sprint(w.LatestHeight.RevisionNumber),
+			"revision_height": fmt.Sprint(w.LatestHeight.RevisionHeight),
+		},
+	})
+}
+
+// consensusStateJSON returns the wasm consensus state of the client, encoded as JSON like by the chain's codec.
+func (w WasmClient) consensusStateJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"@type": wasmConsensusStateTypeURL,
+		"data":  w.ConsensusStateData,
+	})
+}
+
+// CreateWasmClient creates the 08-wasm light client, signed by keyName, and returns its client ID.
+// Relayers use it once set as the client of a path with ibc.PathClientsUpdater, before creating connections,
+// e.g. as the source client ID if the chain is the source chain of the path.
+func (c *CosmosChain) CreateWasmClient(ctx context.Context, keyName string, client WasmClient) (string, error) {
+	clientState, err := client.clientStateJSON()
+	if err != nil {
+		return "", err
+	}
+	consensusState, err := client.consensusStateJSON()
+	if err != nil {
+		return "", err
+	}
+
+	tn := c.getFullNode()
+	clientStateFile, consensusStateFile := "wasm_client_state.json", "wasm_consensus_state.json"
+	if err := tn.WriteFile(ctx, clientState, clientStateFile); err != nil {
+		return "", fmt.Errorf("writing client state file to docker volume: %w", err)
+	}
+	if err := tn.WriteFile(ctx, consensusState, consensusStateFile); err != nil {
+		return "", fmt.Errorf("writing consensus state file to docker volume: %w", err)
+	}
+
+	txResp, err := tn.execContractTx(ctx, keyName, "ibc", "client", "create",
+		path.Join(tn.HomeDir(), clientStateFile), path.Join(tn.HomeDir(), consensusStateFile), "--gas", "auto")
+	if err != nil {
+		return "", fmt.Errorf("failed to create wasm client: %w", err)
+	}
+	clientID, ok := tendermint.AttributeValue(txResp.Events, "create_client", "client_id")
+	if !ok {
+		return "", fmt.Errorf("client id not found in events of tx %s", txResp.TxHash)
+	}
+	return clientID, nil
+}
+
+// TendermintWasmClient returns the initial state of an 08-wasm light client of counterparty, a tendermint chain,
+// at its latest height, for a light client contract with checksum implementing the tendermint light client,
+// whose states are encoded like those of 07-tendermint clients. The trusting period is 2/3 of the unbonding period
+// of counterparty, like the default of relayers.
+func TendermintWasmClient(ctx context.Context, counterparty *CosmosChain, checksum string) (WasmClient, error) {
+	conn, err := counterparty.GRPCConn(ctx)
+	if err != nil {
+		return WasmClient{}, err
+	}
+	params, err := stakingtypes.NewQueryClient(conn).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return WasmClient{}, fmt.Errorf("failed to query staking params of %s: %w", counterparty.Config().ChainID, err)
+	}
+
+	commit, err := counterparty.getFullNode().Client.Commit(ctx, nil)
+	if err != nil {
+		return WasmClient{}, fmt.Errorf("failed to get latest header of %s: %w", counterparty.Config().ChainID, err)
+	}
+	header := commit.SignedHeader.Header
+
+	return tendermintWasmClient(counterparty.Config().ChainID, checksum, params.Params.UnbondingTime,
+		header.Height, header.Time, header.AppHash, header.NextValidatorsHash)
+}
+
+// tendermintWasmClient returns the initial state of an 08-wasm tendermint light client of chainID
+// at the header of height.
+func tendermintWasmClient(
+	chainID, checksum string,
+	unbondingPeriod time.Duration,
+	height int64, timestamp time.Time, appHash, nextValidatorsHash []byte,
+) (WasmClient, error) {
+	latestHeight := clienttypes.NewHeight(clienttypes.ParseChainID(chainID), uint64(height))
+	clientState := ibctm.NewClientState(
+		chainID, ibctm.DefaultTrustLevel,
+		unbondingPeriod*2/3, unbondingPeriod, wasmClientMaxClockDrift,
+		latestHeight, commitmenttypes.GetSDKSpecs(),
+		[]string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState},
+	)
+	consensusState := ibctm.NewConsensusState(timestamp, commitmenttypes.NewMerkleRoot(appHash), nextValidatorsHash)
+
+	clientStateData, err := clientState.Marshal()
+	if err != nil {
+		return WasmClient{}, err
+	}
+	consensusStateData, err := consensusState.Marshal()
+	if err != nil {
+		return WasmClient{}, err
+	}
+	return WasmClient{
+		Checksum:           checksum,
+		ClientStateData:    clientStateData,
+		ConsensusStateData: consensusStateData,
+		LatestHeight:       latestHeight,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package cosmos
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	"github.com/stretchr/testify/require"
+)
+
+// testWasmCode is the smallest valid wasm module: its magic number and version.
+var testWasmCode = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+const testWasmChecksum = "93a44bbb96c751218e4c00d479e4c14358122a389acca16205b1e4d0dc5f9476"
+
+func TestWasmChecksum(t *testing.T) {
+	t.Parallel()
+
+	checksum, err := WasmChecksum(testWasmCode)
+	require.NoError(t, err)
+	require.Equal(t, testWasmChecksum, checksum)
+
+	// Gzipped contracts are identified by the checksum of the uncompressed code.
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = zw.Write(testWasmCode)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	checksum, err = WasmChecksum(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, testWasmChecksum, checksum)
+
+	_, err = WasmChecksum(buf.Bytes()[:12])
+	require.ErrorContains(t, err, "failed to decompress wasm code")
+}
+
+func TestWasmStoreCodeProposal(t *testing.T) {
+	t.Parallel()
+
+	prop, err := wasmStoreCodeProposal("cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn", testWasmCode, testWasmChecksum, "10000000stake")
+	require.NoError(t, err)
+	require.Equal(t, "10000000stake", prop.Deposit)
+	require.Equal(t, "Store 08-wasm light client 93a44bbb", prop.Title)
+	require.Len(t, prop.Messages, 1)
+
+	var msg map[string]string
+	require.NoError(t, json.Unmarshal(prop.Messages[0], &msg))
+	require.Equal(t, map[string]string{
+		"@type":          "/ibc.lightclients.wasm.v1.MsgStoreCode",
+		"signer":         "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn",
+		"wasm_byte_code": base64.StdEncoding.EncodeToString(testWasmCode),
+	}, msg)
+}
+
+func TestWasmClient_JSON(t *testing.T) {
+	t.Parallel()
+
+	client := WasmClient{
+		Checksum:           testWasmChecksum,
+		ClientStateData:    []byte("client"),
+		ConsensusStateData: []byte("consensus"),
+		LatestHeight:       clienttypes.NewHeight(1, 42),
+	}
+
+	bz, err := client.clientStateJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"@type": "/ibc.lightclients.wasm.v1.ClientState",
+		"data": "Y2xpZW50",
+		"checksum": "k6RLu5bHUSGOTADUeeTBQ1gSKjiazKFiBbHk0NxflHY=",
+		"latest_height": {"revision_number": "1", "revision_height": "42"}
+	}`, string(bz))
+
+	bz, err = client.consensusStateJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"@type": "/ibc.lightclients.wasm.v1.ConsensusState", "data": "Y29uc2Vuc3Vz"}`, string(bz))
+
+	client.Checksum = "not-hex"
+	_, err = client.clientStateJSON()
+	require.ErrorContains(t, err, `invalid light client contract checksum "not-hex"`)
+}
+
+func TestTendermintWasmClient(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	appHash, nextValsHash := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+	client, err := tendermintWasmClient("gaia-2", testWasmChecksum, 21*24*time.Hour, 30, timestamp, appHash, nextValsHash)
+	require.NoError(t, err)
+	require.Equal(t, testWasmChecksum, client.Checksum)
+	require.Equal(t, clienttypes.NewHeight(2, 30), client.LatestHeight)
+
+	var clientState ibctm.ClientState
+	require.NoError(t, clientState.Unmarshal(client.ClientStateData))
+	require.NoError(t, clientState.Validate())
+	require.Equal(t, "gaia-2", clientState.ChainId)
+	require.Equal(t, 14*24*time.Hour, clientState.TrustingPeriod)
+	require.Equal(t, 21*24*time.Hour, clientState.UnbondingPeriod)
+	require.Equal(t, client.LatestHeight, clientState.LatestHeight)
+	require.Equal(t, []string{"upgrade", "upgradedIBCState"}, clientState.UpgradePath)
+
+	var consensusState ibctm.ConsensusState
+	require.NoError(t, consensusState.Unmarshal(client.ConsensusStateData))
+	require.NoError(t, consensusState.ValidateBasic())
+	require.True(t, timestamp.Equal(consensusState.Timestamp))
+	require.Equal(t, appHash, consensusState.Root.Hash)
+	require.Equal(t, nextValsHash, []byte(consensusState.NextValidatorsHash))
+}
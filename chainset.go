@@ -2,13 +2,15 @@ package interchaintest
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"go.uber.org/multierr"
@@ -27,8 +29,10 @@ type chainSet struct {
 
 	// The following fields are set during TrackBlocks, and used in Close.
 	trackerEg  *errgroup.Group
-	db         *sql.DB
+	store      blockdb.Store
+	testCase   *blockdb.TestCase
 	collectors []*blockdb.Collector
+	writers    []*blockdb.BatchWriter
 }
 
 func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
@@ -101,57 +105,83 @@ func (cs *chainSet) CreateCommonAccount(ctx context.Context, keyName string) (fa
 }
 
 // Start concurrently calls Start against each chain in the set.
+// Interchain Security consumer chains are started once the other chains, including their providers, have started.
 func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount) error {
-	eg, egCtx := errgroup.WithContext(ctx)
+	for _, consumers := range []bool{false, true} {
+		eg, egCtx := errgroup.WithContext(ctx)
 
-	for c := range cs.chains {
-		c := c
-		eg.Go(func() error {
-			if err := c.Start(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
-				return fmt.Errorf("failed to start chain %s: %w", c.Config().Name, err)
+		for c := range cs.chains {
+			c := c
+			if isConsumerChain(c) != consumers {
+				continue
 			}
+			eg.Go(func() error {
+				if err := c.Start(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
+					return fmt.Errorf("failed to start chain %s: %w", c.Config().Name, err)
+				}
 
-			return nil
-		})
+				return nil
+			})
+		}
+
+		if err := eg.Wait(); err != nil {
+			return err
+		}
 	}
 
-	return eg.Wait()
+	return nil
+}
+
+// isConsumerChain reports whether c is an Interchain Security consumer chain.
+func isConsumerChain(c ibc.Chain) bool {
+	cc, ok := c.(*cosmos.CosmosChain)
+	return ok && cc.Provider != nil
 }
 
 // TrackBlocks initializes database tables and polls for transactions to be saved in the database.
+// It returns the ID of the test case created in the database.
 // This method is a nop if dbPath is blank.
+// The dbPath is the path to a sqlite file, or a postgres connection URL to save to a central database.
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
+// Before creating the test case, older test cases exceeding retention are pruned from the database.
+// If compressTxs is true, large txs are compressed; see blockdb.Chain.CompressTxs.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs *chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string, retention BlockDatabaseRetention, compressTxs bool) (int64, error) {
 	if len(dbPath) == 0 {
 		// nop
-		return nil
+		return 0, nil
 	}
 
-	db, err := blockdb.ConnectDB(ctx, dbPath)
-	if err != nil {
-		return fmt.Errorf("connect to sqlite database %s: %w", dbPath, err)
-	}
-	cs.db = db
-
 	if len(gitSha) == 0 {
 		gitSha = "unknown"
 	}
 
-	if err := blockdb.Migrate(db, gitSha); err != nil {
-		return fmt.Errorf("migrate sqlite database %s; deleting file recommended: %w", dbPath, err)
+	store, err := blockdb.OpenStore(ctx, dbPath, gitSha)
+	if err != nil {
+		// Error already includes the sqlite path, and never a postgres password.
+		return 0, err
+	}
+	cs.store = store
+
+	pruned, err := store.Prune(ctx, retention)
+	if err != nil {
+		return 0, fmt.Errorf("prune block database %s: %w", redactBlockDatabase(dbPath), err)
+	}
+	if pruned > 0 {
+		cs.log.Info("Pruned test cases from block database", zap.String("path", redactBlockDatabase(dbPath)), zap.Int("count", pruned))
 	}
 
-	testCase, err := blockdb.CreateTestCase(ctx, db, testName, gitSha)
+	testCase, err := store.CreateTestCase(ctx, testName, gitSha)
 	if err != nil {
-		_ = db.Close()
-		return fmt.Errorf("create test case in sqlite database: %w", err)
+		return 0, fmt.Errorf("create test case in block database: %w", err)
 	}
+	cs.testCase = testCase
 
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
 	cs.trackerEg = new(errgroup.Group)
 	cs.collectors = make([]*blockdb.Collector, len(cs.chains))
+	cs.writers = make([]*blockdb.BatchWriter, len(cs.chains))
 	i := 0
 	for c := range cs.chains {
 		c := c
@@ -159,7 +189,7 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		finder, ok := c.(blockdb.TxFinder)
 		if !ok {
 			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height uint64) ([][]byte, error)"`+"\n", id)
-			return nil
+			return testCase.ID(), nil
 		}
 		j := i // Avoid closure on loop variable.
 		cs.trackerEg.Go(func() error {
@@ -168,8 +198,17 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 				fmt.Fprintf(os.Stderr, "Failed to add chain %s to database: %v", id, err)
 				return nil
 			}
+			if compressTxs {
+				chaindb.CompressTxs(blockdb.DefaultCompressTxMinSize)
+			}
+			if err := saveChainFixture(ctx, c, chaindb); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to save chain %s config and genesis to database: %v\n", id, err)
+			}
 			log := cs.log.With(zap.String("chain_id", id))
-			collector := blockdb.NewCollector(log, finder, chaindb, 100*time.Millisecond)
+			// Save blocks in batches, so that writing to the database does not slow collecting blocks.
+			writer := blockdb.NewBatchWriter(log, chaindb, 100)
+			cs.writers[j] = writer
+			collector := blockdb.NewCollector(log, finder, writer, 100*time.Millisecond)
 			cs.collectors[j] = collector
 			collector.Collect(ctx)
 			return nil
@@ -177,7 +216,28 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		i++
 	}
 
-	return nil
+	return testCase.ID(), nil
+}
+
+// saveChainFixture saves the config of c, and its genesis if c implements blockdb.GenesisFinder,
+// so that the test case can be exported as a fixture.
+func saveChainFixture(ctx context.Context, c ibc.Chain, chaindb *blockdb.Chain) error {
+	config, err := json.Marshal(c.Config())
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	if err := chaindb.SaveConfig(ctx, config); err != nil {
+		return err
+	}
+	finder, ok := c.(blockdb.GenesisFinder)
+	if !ok {
+		return nil
+	}
+	genesis, err := finder.FindGenesis(ctx)
+	if err != nil {
+		return fmt.Errorf("find genesis: %w", err)
+	}
+	return chaindb.SaveGenesis(ctx, genesis)
 }
 
 // Close frees any resources associated with the chainSet.
@@ -195,8 +255,30 @@ func (cs *chainSet) Close() error {
 	if cs.trackerEg != nil {
 		multierr.AppendInto(&err, cs.trackerEg.Wait())
 	}
-	if cs.db != nil {
-		multierr.AppendInto(&err, cs.db.Close())
+	// The collectors are stopped, so flush the blocks they queued before closing the store.
+	for _, w := range cs.writers {
+		if w != nil {
+			multierr.AppendInto(&err, w.Close())
+		}
+	}
+	// Mark the test case finished, so that other test runs saving to the database may prune it.
+	if cs.testCase != nil {
+		multierr.AppendInto(&err, cs.testCase.Finish(context.Background()))
+	}
+	if cs.store != nil {
+		multierr.AppendInto(&err, cs.store.Close())
 	}
 	return err
 }
+
+// redactBlockDatabase returns dbPath with the password of a postgres connection URL redacted, for logging.
+func redactBlockDatabase(dbPath string) string {
+	if !blockdb.IsPostgresURL(dbPath) {
+		return dbPath
+	}
+	u, err := url.Parse(dbPath)
+	if err != nil {
+		return "postgres database"
+	}
+	return u.Redacted()
+}
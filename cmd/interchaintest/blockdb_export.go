@@ -0,0 +1,40 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"go.uber.org/multierr"
+)
+
+// runBlockDBExport exports a test case of the block database to a directory as a fixture bundle,
+// so that a failed CI run can be reproduced locally, e.g. with interchaintest.ChainSpecFromFixture.
+func runBlockDBExport(ctx context.Context, dbPath string, args []string) (err error) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	testCaseID := fs.Int64("test-case", 0, "Test case primary key, as listed by query test-cases. Required.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *testCaseID <= 0 {
+		return errors.New("-test-case is required")
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected one output directory, got %d arguments", fs.NArg())
+	}
+
+	db, err := openBlockDB(ctx, dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { err = multierr.Append(err, db.Close()) }()
+
+	if err := blockdb.ExportTestCase(ctx, db, *testCaseID, fs.Arg(0)); err != nil {
+		return fmt.Errorf("export test case %d: %w", *testCaseID, err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported test case %d to %s\n", *testCaseID, fs.Arg(0))
+	return nil
+}
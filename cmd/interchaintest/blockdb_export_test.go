@@ -0,0 +1,42 @@
+package interchaintest
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBlockDBExport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "blocks.db")
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, blockdb.Migrate(db, "abc123"))
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestExport", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []blockdb.Tx{{Data: []byte(`{"tx":1}`), Hash: "AA11"}}))
+
+	out := filepath.Join(dir, "fixture")
+	require.NoError(t, runBlockDB(ctx, nil, dbPath, []string{"export", "-test-case", strconv.FormatInt(tc.ID(), 10), out}))
+
+	bundle, err := blockdb.LoadBundle(out)
+	require.NoError(t, err)
+	require.Equal(t, "TestExport", bundle.Name)
+	got, ok := bundle.Chain("chain-a")
+	require.True(t, ok)
+	require.Len(t, got.Blocks, 1)
+	require.Equal(t, `{"tx":1}`, string(got.Blocks[0].Txs[0].Data))
+
+	require.ErrorContains(t, runBlockDB(ctx, nil, dbPath, []string{"export", out}), "-test-case is required")
+	require.Error(t, runBlockDB(ctx, nil, dbPath, []string{"export", "-test-case", "1"}))
+	require.ErrorContains(t, runBlockDB(ctx, nil, dbPath, []string{"export", "-test-case", "999", out}), "not found")
+}
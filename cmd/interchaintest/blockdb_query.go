@@ -0,0 +1,321 @@
+package interchaintest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+	"go.uber.org/multierr"
+)
+
+const blockDBUsage = `Usage:
+  interchaintest blockdb [-block-db path] query KIND [flags]
+  interchaintest blockdb [-block-db path] import-state -chain ID -height N FILE
+  interchaintest blockdb [-block-db path] export -test-case ID DIR
+  interchaintest blockdb [-block-db path] migrate
+
+Query kinds:
+  test-cases [-limit N]               List the most recent test cases and their chains.
+  blocks -chain ID [-from N] [-to M]  Dump blocks, with their transactions, of the chain with primary key ID.
+  tx HASH                             Show transactions with the hex HASH.
+  gas -test-case ID                   Show gas statistics per chain and message type of the test case ID.
+`
+
+// runBlockDB runs the blockdb command in args, e.g. "query" or "export", against the sqlite block database at dbPath.
+func runBlockDB(ctx context.Context, out io.Writer, dbPath string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected a command\n%s", blockDBUsage)
+	}
+	switch args[0] {
+	case "query":
+		return runBlockDBQuery(ctx, out, dbPath, args[1:])
+	case "import-state":
+		return runBlockDBImportState(ctx, dbPath, args[1:])
+	case "export":
+		return runBlockDBExport(ctx, dbPath, args[1:])
+	case "migrate":
+		return runBlockDBMigrate(ctx, out, dbPath)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], blockDBUsage)
+	}
+}
+
+// openBlockDB connects to and migrates the existing sqlite block database at dbPath.
+func openBlockDB(ctx context.Context, dbPath string) (*sql.DB, error) {
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates a sqlite file.
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, err
+	}
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database %s: %w", dbPath, err)
+	}
+
+	if err = blockdb.Migrate(db, version.GitSha); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate database %s: %w", dbPath, err)
+	}
+	return db, nil
+}
+
+// runBlockDBMigrate migrates the database to the latest schema, reporting the schema versions to out.
+// Other commands migrate the database implicitly.
+func runBlockDBMigrate(ctx context.Context, out io.Writer, dbPath string) (err error) {
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates a sqlite file.
+	if _, err := os.Stat(dbPath); err != nil {
+		return err
+	}
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	if err != nil {
+		return fmt.Errorf("connect to database %s: %w", dbPath, err)
+	}
+	defer func() { err = multierr.Append(err, db.Close()) }()
+
+	from, err := blockdb.SchemaVersion(ctx, db)
+	if err != nil {
+		return fmt.Errorf("query schema version: %w", err)
+	}
+	if err = blockdb.Migrate(db, version.GitSha); err != nil {
+		return fmt.Errorf("migrate database %s: %w", dbPath, err)
+	}
+	if from == blockdb.LatestSchemaVersion() {
+		fmt.Fprintf(out, "Database %s is already at schema version %d\n", dbPath, from)
+		return nil
+	}
+	fmt.Fprintf(out, "Migrated database %s from schema version %d to %d\n", dbPath, from, blockdb.LatestSchemaVersion())
+	return nil
+}
+
+// runBlockDBQuery runs the query, the KIND and its flags in args,
+// writing the results to out as indented JSON.
+func runBlockDBQuery(ctx context.Context, out io.Writer, dbPath string, args []string) (err error) {
+	if len(args) == 0 {
+		return fmt.Errorf("expected query KIND\n%s", blockDBUsage)
+	}
+	kind, args := args[0], args[1:]
+
+	var run func(q *blockdb.Query) (any, error)
+	switch kind {
+	case "test-cases":
+		fs := flag.NewFlagSet(kind, flag.ContinueOnError)
+		limit := fs.Int("limit", 100, "Number of most recent test cases to list.")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		run = func(q *blockdb.Query) (any, error) { return queryTestCases(ctx, q, *limit) }
+	case "blocks":
+		fs := flag.NewFlagSet(kind, flag.ContinueOnError)
+		chainPkey := fs.Int64("chain", 0, "Chain primary key, as listed by test-cases. Required.")
+		from := fs.Int64("from", 1, "First block height to dump.")
+		to := fs.Int64("to", 0, "Last block height to dump. Defaults to the latest block.")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if *chainPkey <= 0 {
+			return errors.New("-chain is required")
+		}
+		run = func(q *blockdb.Query) (any, error) { return queryBlocks(ctx, q, *chainPkey, *from, *to) }
+	case "tx":
+		if len(args) != 1 {
+			return fmt.Errorf("expected one tx hash, got %d arguments", len(args))
+		}
+		run = func(q *blockdb.Query) (any, error) { return queryTxsByHash(ctx, q, args[0]) }
+	case "gas":
+		fs := flag.NewFlagSet(kind, flag.ContinueOnError)
+		testCaseID := fs.Int64("test-case", 0, "Test case id, as listed by test-cases. Required.")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if *testCaseID <= 0 {
+			return errors.New("-test-case is required")
+		}
+		run = func(q *blockdb.Query) (any, error) { return queryGas(ctx, q, *testCaseID) }
+	default:
+		return fmt.Errorf("unknown query %q\n%s", kind, blockDBUsage)
+	}
+
+	db, err := openBlockDB(ctx, dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { err = multierr.Append(err, db.Close()) }()
+
+	v, err := run(blockdb.NewQuery(db))
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type queryTestCase struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	GitSha    string       `json:"git_sha"`
+	CreatedAt time.Time    `json:"created_at"`
+	Chains    []queryChain `json:"chains"`
+}
+
+type queryChain struct {
+	ID        int64  `json:"id"`
+	ChainID   string `json:"chain_id"`
+	ChainType string `json:"chain_type"`
+	Height    int64  `json:"height"`
+	TxTotal   int64  `json:"tx_total"`
+}
+
+func queryTestCases(ctx context.Context, q *blockdb.Query, limit int) ([]queryTestCase, error) {
+	results, err := q.RecentTestCases(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent test cases: %w", err)
+	}
+	tcs := []queryTestCase{}
+	for _, res := range results {
+		if len(tcs) == 0 || tcs[len(tcs)-1].ID != res.ID {
+			tcs = append(tcs, queryTestCase{
+				ID:        res.ID,
+				Name:      res.Name,
+				GitSha:    res.GitSha,
+				CreatedAt: res.CreatedAt,
+			})
+		}
+		tc := &tcs[len(tcs)-1]
+		tc.Chains = append(tc.Chains, queryChain{
+			ID:        res.ChainPKey,
+			ChainID:   res.ChainID,
+			ChainType: res.ChainType,
+			Height:    res.ChainHeight.Int64,
+			TxTotal:   res.TxTotal.Int64,
+		})
+	}
+	return tcs, nil
+}
+
+type queryBlock struct {
+	Height          int64     `json:"height"`
+	CreatedAt       time.Time `json:"created_at"`
+	ProposerAddress string    `json:"proposer_address"`
+	ValidatorsHash  string    `json:"validators_hash"`
+	Txs             []queryTx `json:"txs"`
+}
+
+type queryTx struct {
+	ID     int64           `json:"id"`
+	Height int64           `json:"height"`
+	Hash   string          `json:"hash,omitempty"`
+	Tx     json.RawMessage `json:"tx"`
+
+	// Code is 0 if the tx succeeded.
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace,omitempty"`
+	RawLog    string `json:"raw_log,omitempty"`
+
+	// Only set when querying by hash, which may match txs of many chains.
+	TestCaseID int64  `json:"test_case_id,omitempty"`
+	ChainPKey  int64  `json:"chain_pkey,omitempty"`
+	ChainID    string `json:"chain_id,omitempty"`
+}
+
+func newQueryTx(res blockdb.TxResult) queryTx {
+	data := json.RawMessage(res.Tx)
+	if !json.Valid(data) {
+		// Marshaling a string should never fail.
+		data, _ = json.Marshal(string(res.Tx))
+	}
+	return queryTx{ID: res.ID, Height: res.Height, Hash: res.Hash, Tx: data, Code: res.Code, Codespace: res.Codespace, RawLog: res.RawLog}
+}
+
+// queryBlocks returns the blocks of the chain from height from to height to inclusive.
+// A to of 0 means no upper limit.
+func queryBlocks(ctx context.Context, q *blockdb.Query, chainPkey, from, to int64) ([]queryBlock, error) {
+	inRange := func(height int64) bool {
+		return height >= from && (to == 0 || height <= to)
+	}
+
+	blocks, err := q.Blocks(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query blocks: %w", err)
+	}
+	txs, err := q.Transactions(ctx, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	byHeight := make(map[int64][]queryTx)
+	for _, tx := range txs {
+		if inRange(tx.Height) {
+			byHeight[tx.Height] = append(byHeight[tx.Height], newQueryTx(tx))
+		}
+	}
+
+	res := []queryBlock{}
+	for _, b := range blocks {
+		if !inRange(b.Height) {
+			continue
+		}
+		qb := queryBlock{
+			Height:          b.Height,
+			CreatedAt:       b.CreatedAt,
+			ProposerAddress: b.ProposerAddress,
+			ValidatorsHash:  b.ValidatorsHash,
+			Txs:             byHeight[b.Height],
+		}
+		if qb.Txs == nil {
+			qb.Txs = []queryTx{}
+		}
+		res = append(res, qb)
+	}
+	return res, nil
+}
+
+func queryTxsByHash(ctx context.Context, q *blockdb.Query, hash string) ([]queryTx, error) {
+	results, err := q.TxsByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("query tx %s: %w", hash, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no tx found with hash %s", hash)
+	}
+	txs := make([]queryTx, len(results))
+	for i, res := range results {
+		txs[i] = newQueryTx(res.TxResult)
+		txs[i].TestCaseID = res.TestCaseID
+		txs[i].ChainPKey = res.ChainPKey
+		txs[i].ChainID = res.ChainID
+	}
+	return txs, nil
+}
+
+type queryGasStats struct {
+	ChainPKey      int64   `json:"chain_pkey"`
+	ChainID        string  `json:"chain_id"`
+	MsgType        string  `json:"msg_type"`
+	TxCount        int64   `json:"tx_count"`
+	GasWantedTotal int64   `json:"gas_wanted_total"`
+	GasUsedTotal   int64   `json:"gas_used_total"`
+	GasUsedAvg     float64 `json:"gas_used_avg"`
+	GasUsedMin     int64   `json:"gas_used_min"`
+	GasUsedMax     int64   `json:"gas_used_max"`
+}
+
+func queryGas(ctx context.Context, q *blockdb.Query, testCaseID int64) ([]queryGasStats, error) {
+	results, err := q.GasStats(ctx, testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query gas stats: %w", err)
+	}
+	stats := make([]queryGasStats, len(results))
+	for i, res := range results {
+		stats[i] = queryGasStats(res)
+	}
+	return stats, nil
+}
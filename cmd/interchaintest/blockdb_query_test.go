@@ -0,0 +1,128 @@
+package interchaintest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBlockDBQuery(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "blocks.db")
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, blockdb.Migrate(db, "abc123"))
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestQuery", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []blockdb.Tx{{
+		Data:      []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}`),
+		Hash:      "aa11",
+		GasWanted: 200,
+		GasUsed:   150,
+	}}))
+	require.NoError(t, chain.SaveBlock(ctx, 2, nil))
+	require.NoError(t, chain.SaveBlock(ctx, 3, []blockdb.Tx{{Data: []byte("not json"), Hash: "BB22"}}))
+	require.NoError(t, db.Close())
+
+	run := func(t *testing.T, args ...string) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		require.NoError(t, runBlockDB(ctx, &buf, dbPath, append([]string{"query"}, args...)))
+		return buf.Bytes()
+	}
+
+	var tcs []queryTestCase
+	require.NoError(t, json.Unmarshal(run(t, "test-cases"), &tcs))
+	require.Len(t, tcs, 1)
+	require.Equal(t, "TestQuery", tcs[0].Name)
+	require.Len(t, tcs[0].Chains, 1)
+	require.Equal(t, "chain-a", tcs[0].Chains[0].ChainID)
+	require.EqualValues(t, 3, tcs[0].Chains[0].Height)
+	require.EqualValues(t, 2, tcs[0].Chains[0].TxTotal)
+	chainPkey := strconv.FormatInt(tcs[0].Chains[0].ID, 10)
+
+	t.Run("blocks", func(t *testing.T) {
+		var blocks []queryBlock
+		require.NoError(t, json.Unmarshal(run(t, "blocks", "-chain", chainPkey, "-from", "2"), &blocks))
+		require.Len(t, blocks, 2)
+		require.EqualValues(t, 2, blocks[0].Height)
+		require.Empty(t, blocks[0].Txs)
+		require.EqualValues(t, 3, blocks[1].Height)
+		require.Len(t, blocks[1].Txs, 1)
+		require.Equal(t, "BB22", blocks[1].Txs[0].Hash)
+		require.JSONEq(t, `"not json"`, string(blocks[1].Txs[0].Tx))
+
+		require.NoError(t, json.Unmarshal(run(t, "blocks", "-chain", chainPkey, "-to", "1"), &blocks))
+		require.Len(t, blocks, 1)
+		require.EqualValues(t, 1, blocks[0].Height)
+		require.Equal(t, "AA11", blocks[0].Txs[0].Hash)
+	})
+
+	t.Run("tx", func(t *testing.T) {
+		var txs []queryTx
+		require.NoError(t, json.Unmarshal(run(t, "tx", "aa11"), &txs))
+		require.Len(t, txs, 1)
+		require.Equal(t, "AA11", txs[0].Hash)
+		require.EqualValues(t, 1, txs[0].Height)
+		require.Equal(t, tc.ID(), txs[0].TestCaseID)
+		require.Equal(t, "chain-a", txs[0].ChainID)
+
+		err := runBlockDB(ctx, new(bytes.Buffer), dbPath, []string{"query", "tx", "CC33"})
+		require.ErrorContains(t, err, "no tx found")
+	})
+
+	t.Run("gas", func(t *testing.T) {
+		var stats []queryGasStats
+		require.NoError(t, json.Unmarshal(run(t, "gas", "-test-case", strconv.FormatInt(tc.ID(), 10)), &stats))
+		require.Equal(t, []queryGasStats{{
+			ChainPKey:      tcs[0].Chains[0].ID,
+			ChainID:        "chain-a",
+			MsgType:        "/cosmos.bank.v1beta1.MsgSend",
+			TxCount:        1,
+			GasWantedTotal: 200,
+			GasUsedTotal:   150,
+			GasUsedAvg:     150,
+			GasUsedMin:     150,
+			GasUsedMax:     150,
+		}}, stats)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.Error(t, runBlockDB(ctx, &buf, dbPath, nil))
+		require.Error(t, runBlockDB(ctx, &buf, dbPath, []string{"query", "unknown"}))
+		require.Error(t, runBlockDB(ctx, &buf, dbPath, []string{"unknown"}))
+		require.ErrorContains(t, runBlockDB(ctx, &buf, dbPath, []string{"query", "blocks"}), "-chain is required")
+		require.ErrorContains(t, runBlockDB(ctx, &buf, dbPath, []string{"query", "gas"}), "-test-case is required")
+		require.Error(t, runBlockDB(ctx, &buf, filepath.Join(t.TempDir(), "missing.db"), []string{"query", "test-cases"}))
+	})
+}
+
+func TestRunBlockDBMigrate(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "blocks.db")
+
+	// An empty database, as if not yet migrated.
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	var buf bytes.Buffer
+	require.NoError(t, runBlockDB(ctx, &buf, dbPath, []string{"migrate"}))
+	require.Contains(t, buf.String(), "from schema version 0 to "+strconv.Itoa(blockdb.LatestSchemaVersion()))
+
+	buf.Reset()
+	require.NoError(t, runBlockDB(ctx, &buf, dbPath, []string{"migrate"}))
+	require.Contains(t, buf.String(), "already at schema version "+strconv.Itoa(blockdb.LatestSchemaVersion()))
+
+	require.Error(t, runBlockDB(ctx, &buf, filepath.Join(t.TempDir(), "missing.db"), []string{"migrate"}))
+}
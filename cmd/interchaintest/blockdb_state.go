@@ -0,0 +1,50 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"go.uber.org/multierr"
+)
+
+// runBlockDBImportState saves the state export file, such as written from ibc.Chain.ExportState,
+// to a chain of the block database, so that the debug UI can diff the chain's state between heights.
+func runBlockDBImportState(ctx context.Context, dbPath string, args []string) (err error) {
+	fs := flag.NewFlagSet("import-state", flag.ContinueOnError)
+	chainPkey := fs.Int64("chain", 0, "Chain primary key, as listed by query test-cases. Required.")
+	height := fs.Int64("height", 0, "Height at which the state was exported. Required.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *chainPkey <= 0 || *height <= 0 {
+		return errors.New("-chain and -height are required")
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected one state export file, got %d arguments", fs.NArg())
+	}
+
+	export, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	db, err := openBlockDB(ctx, dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() { err = multierr.Append(err, db.Close()) }()
+
+	chain, err := blockdb.FindChain(ctx, db, *chainPkey)
+	if err != nil {
+		return err
+	}
+	if err := chain.SaveStateExport(ctx, *height, export); err != nil {
+		return fmt.Errorf("save state export %s: %w", fs.Arg(0), err)
+	}
+	fmt.Fprintf(os.Stderr, "Imported state of chain %d at height %d from %s\n", *chainPkey, *height, fs.Arg(0))
+	return nil
+}
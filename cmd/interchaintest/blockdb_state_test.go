@@ -0,0 +1,45 @@
+package interchaintest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBlockDBImportState(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "blocks.db")
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, blockdb.Migrate(db, "abc123"))
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestImport", "abc123")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	tcs, err := blockdb.NewQuery(db).RecentTestCases(ctx, 1)
+	require.NoError(t, err)
+	chainPkey := tcs[0].ChainPKey
+
+	exportPath := filepath.Join(dir, "export.json")
+	require.NoError(t, os.WriteFile(exportPath, []byte(`{"app_state":{"bank":{"supply":[]}}}`), 0644))
+
+	args := []string{"import-state", "-chain", strconv.FormatInt(chainPkey, 10), "-height", "25", exportPath}
+	require.NoError(t, runBlockDB(ctx, nil, dbPath, args))
+
+	state, err := blockdb.NewQuery(db).StateExport(ctx, chainPkey, 25)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"supply":[]}`, string(state["bank"]))
+
+	require.ErrorContains(t, runBlockDB(ctx, nil, dbPath, []string{"import-state", exportPath}), "-chain and -height are required")
+	require.Error(t, runBlockDB(ctx, nil, dbPath, []string{"import-state", "-chain", "999", "-height", "25", exportPath}))
+	require.Error(t, runBlockDB(ctx, nil, dbPath, []string{"import-state", "-chain", "1", "-height", "25", filepath.Join(dir, "missing.json")}))
+}
@@ -18,7 +18,22 @@ type mainFlags struct {
 	LogLevel          string
 	MatrixFile        string
 	ReportFile        string
+	JUnitFile         string
+	HTMLFile          string
+	TAPFile           string
+	GitHubAnnotations bool
+	StatusAddr        string
+	PushGatewayURL    string
+	SlackWebhookURL   string
+	OTLPEndpoint      string
 	BlockDatabaseFile string
+	BlockDatabaseURL  string
+	BlockDBRetention  interchaintest.BlockDatabaseRetention
+	ServeAddr         string
+	MetricsAddr       string
+	MergeOutputFile   string
+	DiffThreshold     float64
+	DiffMinIncrease   time.Duration
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
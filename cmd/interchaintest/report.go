@@ -0,0 +1,70 @@
+package interchaintest
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"go.uber.org/multierr"
+)
+
+// mergeReports merges the report files at paths into a single report written to out.
+func mergeReports(out io.Writer, paths []string) (err error) {
+	if len(paths) == 0 {
+		return fmt.Errorf("at least one report file is required")
+	}
+
+	readers := make([]io.Reader, len(paths))
+	for i, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { err = multierr.Append(err, f.Close()) }()
+		readers[i] = f
+	}
+
+	msgs, err := testreporter.MergeMessages(readers...)
+	if err != nil {
+		return err
+	}
+	return testreporter.WriteMessages(out, msgs)
+}
+
+// diffReports writes the difference between the old and new report files in paths to out,
+// and reports whether the new report has regressed.
+func diffReports(out io.Writer, paths []string, opts testreporter.DiffOptions) (bool, error) {
+	if len(paths) != 2 {
+		return false, fmt.Errorf("expected two report files (old and new), got %d", len(paths))
+	}
+
+	reps := make([]*testreporter.Report, len(paths))
+	for i, p := range paths {
+		rep, err := readReportFile(p)
+		if err != nil {
+			return false, err
+		}
+		reps[i] = rep
+	}
+
+	d := testreporter.Diff(reps[0], reps[1], opts)
+	if err := d.WriteText(out); err != nil {
+		return false, err
+	}
+	return d.Regressed(), nil
+}
+
+func readReportFile(path string) (*testreporter.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rep, err := testreporter.ReadReport(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+	return rep, nil
+}
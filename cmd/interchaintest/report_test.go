@@ -0,0 +1,79 @@
+package interchaintest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func writeReportFile(t *testing.T, dir, name string, msgs ...testreporter.Message) string {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, testreporter.WriteMessages(buf, msgs))
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, buf.Bytes(), 0644))
+	return p
+}
+
+func TestMergeReports(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	a := writeReportFile(t, dir, "a.json",
+		testreporter.BeginSuiteMessage{StartedAt: now},
+		testreporter.BeginTestMessage{Name: "TestA", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestA", FinishedAt: now.Add(time.Second)},
+		testreporter.FinishSuiteMessage{FinishedAt: now.Add(time.Second)},
+	)
+	b := writeReportFile(t, dir, "b.json",
+		testreporter.BeginSuiteMessage{StartedAt: now},
+		testreporter.BeginTestMessage{Name: "TestB", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestB", FinishedAt: now.Add(time.Second), Failed: true},
+		testreporter.FinishSuiteMessage{FinishedAt: now.Add(2 * time.Second)},
+	)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, mergeReports(out, []string{a, b}))
+
+	rep, err := testreporter.ReadReport(out)
+	require.NoError(t, err)
+	require.True(t, rep.Complete())
+	require.Len(t, rep.Tests, 2)
+	require.True(t, rep.Tests[1].Failed)
+
+	require.Error(t, mergeReports(out, nil))
+	require.Error(t, mergeReports(out, []string{filepath.Join(dir, "missing.json")}))
+}
+
+func TestDiffReports(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	oldPath := writeReportFile(t, dir, "old.json",
+		testreporter.BeginTestMessage{Name: "TestA", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestA", FinishedAt: now.Add(time.Second)},
+	)
+	newPath := writeReportFile(t, dir, "new.json",
+		testreporter.BeginTestMessage{Name: "TestA", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestA", FinishedAt: now.Add(time.Second), Failed: true},
+	)
+
+	out := new(bytes.Buffer)
+	regressed, err := diffReports(out, []string{oldPath, newPath}, testreporter.DiffOptions{})
+	require.NoError(t, err)
+	require.True(t, regressed)
+	require.Contains(t, out.String(), "Newly failing (1):\n  TestA\n")
+
+	regressed, err = diffReports(out, []string{oldPath, oldPath}, testreporter.DiffOptions{})
+	require.NoError(t, err)
+	require.False(t, regressed)
+
+	_, err = diffReports(out, []string{oldPath}, testreporter.DiffOptions{})
+	require.Error(t, err)
+}
@@ -118,7 +118,7 @@ func requireCapabilities(t *testing.T, rep *testreporter.Reporter, rf interchain
 	missing := missingCapabilities(rf, reqCaps...)
 
 	if len(missing) > 0 {
-		rep.TrackSkip(t, "skipping due to missing relayer capabilities +%s", missing)
+		rep.TrackSkipCategory(t, testreporter.SkipUnsupportedRelayer, "skipping due to missing relayer capabilities +%s", missing)
 	}
 }
 
@@ -335,10 +335,14 @@ func TestChainPair(
 		// funds relayer src and dst wallets on respective chain in genesis.
 		// creates a faucet account on the both chains (separate fullnode).
 		// funds faucet accounts in genesis.
+		doneStartChains := rep.TrackPhase(t, "start-chains")
 		relayerImpl, err = interchaintest.StartChainPair(t, ctx, rep, client, network, srcChain, dstChain, rf, preRelayerStartFuncs)
 		req.NoError(err, "failed to StartChainPair")
+		doneStartChains()
 	}
 
+	doneStartRelayer := rep.TrackPhase(t, "start-relayer")
+
 	// execute the pre relayer start functions, then start the relayer.
 	channels, err := interchaintest.StopStartRelayerWithPreStartFuncs(
 		t,
@@ -350,6 +354,7 @@ func TestChainPair(
 		pathNames...,
 	)
 	req.NoError(err, "failed to StopStartRelayerWithPreStartFuncs")
+	doneStartRelayer()
 
 	t.Run("post_relayer_start", func(t *testing.T) {
 		for _, testCase := range testCases {
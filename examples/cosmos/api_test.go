@@ -0,0 +1,67 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRESTAPI queries a balance through the REST API server.
+func TestRESTAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:        "gaia",
+			ChainName:   "gaia",
+			Version:     gaiaVersion,
+			ChainConfig: ibc.ChainConfig{EnableAPI: true},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
+	user := users[0]
+	denom := chain.Config().Denom
+
+	res, err := chain.APIGet(ctx, "/cosmos/bank/v1beta1/balances/"+user.FormattedAddress()+"/by_denom?denom="+denom)
+	require.NoError(t, err)
+
+	var balance banktypes.QueryBalanceResponse
+	require.NoError(t, json.Unmarshal(res, &balance))
+	require.Equal(t, denom, balance.Balance.Denom)
+	require.Equal(t, userFunds, balance.Balance.Amount.Int64())
+}
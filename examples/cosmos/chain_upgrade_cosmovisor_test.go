@@ -0,0 +1,122 @@
+package cosmos_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestJunoCosmovisorUpgrade upgrades juno with a software upgrade proposal, as on a live network:
+// the nodes run under cosmovisor, which switches to the staged binary at the upgrade height without restarting containers.
+// Requires a statically linked linux build of cosmovisor, e.g. built with CGO_ENABLED=0 GOOS=linux:
+//
+//	COSMOVISOR_BINARY=path/to/cosmovisor go test -run TestJunoCosmovisorUpgrade
+func TestJunoCosmovisorUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	cosmovisorBinary := os.Getenv("COSMOVISOR_BINARY")
+	if cosmovisorBinary == "" {
+		t.Skip("COSMOVISOR_BINARY not set")
+	}
+
+	t.Parallel()
+
+	const (
+		chainName      = "juno"
+		initialVersion = "v6.0.0"
+		upgradeRepo    = "ghcr.io/strangelove-ventures/heighliner/juno"
+		upgradeVersion = "v8.0.0"
+		upgradeName    = "multiverse"
+	)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      chainName,
+			ChainName: chainName,
+			Version:   initialVersion,
+			ChainConfig: ibc.ChainConfig{
+				ModifyGenesis: modifyGenesisShortProposals(votingPeriod, maxDepositPeriod),
+				Cosmovisor:    &ibc.CosmovisorConfig{HostBinaryPath: cosmovisorBinary},
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
+	chainUser := users[0]
+
+	// Stage the new binary before the upgrade height, as a validator would.
+	upgradeImage := ibc.DockerImage{Repository: upgradeRepo, Version: upgradeVersion, UidGid: chain.Config().Images[0].UidGid}
+	require.NoError(t, chain.StageUpgrade(ctx, upgradeName, upgradeImage), "error staging upgrade binary")
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err, "error fetching height before submit upgrade proposal")
+
+	haltHeight := height + haltHeightDelta
+
+	proposal := cosmos.SoftwareUpgradeProposal{
+		Deposit:     "500000000" + chain.Config().Denom, // greater than min deposit
+		Title:       "Chain Upgrade 1",
+		Name:        upgradeName,
+		Description: "First chain software upgrade",
+		Height:      haltHeight,
+	}
+
+	upgradeTx, err := chain.UpgradeProposal(ctx, chainUser.KeyName(), proposal)
+	require.NoError(t, err, "error submitting software upgrade proposal tx")
+
+	err = chain.VoteOnProposalAllValidators(ctx, upgradeTx.ProposalID, cosmos.ProposalVoteYes)
+	require.NoError(t, err, "failed to submit votes")
+
+	_, err = cosmos.PollForProposalStatus(ctx, chain, height, height+haltHeightDelta, upgradeTx.ProposalID, cosmos.ProposalStatusPassed)
+	require.NoError(t, err, "proposal status did not change to passed in expected number of blocks")
+
+	// Cosmovisor switches binaries at the halt height, so blocks continue past it without stopping the nodes.
+	timeoutCtx, timeoutCtxCancel := context.WithTimeout(ctx, time.Minute*2)
+	defer timeoutCtxCancel()
+
+	height, err = chain.Height(ctx)
+	require.NoError(t, err, "error fetching height before upgrade")
+
+	err = testutil.WaitForBlocks(timeoutCtx, int(haltHeight-height+blocksAfterUpgrade), chain)
+	require.NoError(t, err, "chain did not produce blocks after upgrade")
+
+	height, err = chain.Height(ctx)
+	require.NoError(t, err, "error fetching height after upgrade")
+
+	require.GreaterOrEqual(t, height, haltHeight+blocksAfterUpgrade, "height did not increment enough after upgrade")
+
+	// Run later commands with the new binary.
+	chain.UpgradeVersion(ctx, client, upgradeRepo, upgradeVersion)
+}
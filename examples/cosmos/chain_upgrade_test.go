@@ -133,6 +133,63 @@ func CosmosChainUpgradeTest(t *testing.T, chainName, initialVersion, upgradeCont
 	require.GreaterOrEqual(t, height, haltHeight+blocksAfterUpgrade, "height did not increment enough after upgrade")
 }
 
+// TestJunoUpgradeChain runs the upgrade of TestJunoUpgrade with cosmos.UpgradeChain.
+func TestJunoUpgradeChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "juno",
+			ChainName: "juno",
+			Version:   "v6.0.0",
+			ChainConfig: ibc.ChainConfig{
+				ModifyGenesis: modifyGenesisShortProposals(votingPeriod, maxDepositPeriod),
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, chain)
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	haltHeight := height + haltHeightDelta
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	image := chain.Config().Images[0]
+	image.Repository, image.Version = "ghcr.io/strangelove-ventures/heighliner/juno", "v8.0.0"
+	require.NoError(t, cosmos.UpgradeChain(ctx, chain, users[0].KeyName(), "multiverse", haltHeight, image))
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, int(blocksAfterUpgrade), chain))
+}
+
 func modifyGenesisShortProposals(votingPeriod string, maxDepositPeriod string) func(ibc.ChainConfig, []byte) ([]byte, error) {
 	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
 		g := make(map[string]interface{})
@@ -0,0 +1,80 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestTypedConfigFileOverrides starts a chain with typed overrides of the nodes' app.toml and config.toml.
+func TestTypedConfigFileOverrides(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	haltHeight := uint64(1_000_000)
+	mempoolSize := int64(1000)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				TypedConfigFileOverrides: []ibc.ConfigFileOverride{
+					cosmos.AppTomlOverrides{HaltHeight: &haltHeight},
+					cosmos.ConfigTomlOverrides{Mempool: &cosmos.MempoolTomlOverrides{Size: &mempoolSize}},
+				},
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	for _, n := range chain.Nodes() {
+		bz, err := n.ReadFile(ctx, "config/app.toml")
+		require.NoError(t, err)
+		var app testutil.Toml
+		require.NoError(t, toml.Unmarshal(bz, &app))
+		require.EqualValues(t, haltHeight, app["halt-height"])
+
+		bz, err = n.ReadFile(ctx, "config/config.toml")
+		require.NoError(t, err)
+		var config struct {
+			Mempool struct {
+				Size int64 `toml:"size"`
+			} `toml:"mempool"`
+		}
+		require.NoError(t, toml.Unmarshal(bz, &config))
+		require.Equal(t, mempoolSize, config.Mempool.Size)
+	}
+}
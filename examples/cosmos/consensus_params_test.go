@@ -0,0 +1,70 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestConsensusConfig starts a chain with consensus params and a block time set by ChainConfig.Consensus.
+func TestConsensusConfig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	consensus := &ibc.ConsensusConfig{
+		BlockMaxGas:            50_000_000,
+		BlockMaxBytes:          1_000_000,
+		EvidenceMaxAgeDuration: 24 * time.Hour,
+		TimeoutCommit:          time.Second,
+	}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:        "gaia",
+			ChainName:   "gaia",
+			Version:     gaiaVersion,
+			ChainConfig: ibc.ChainConfig{Consensus: consensus},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	res, err := chain.Validators[0].Client.ConsensusParams(ctx, nil)
+	require.NoError(t, err)
+	params := res.ConsensusParams
+	require.Equal(t, consensus.BlockMaxGas, params.Block.MaxGas)
+	require.Equal(t, consensus.BlockMaxBytes, params.Block.MaxBytes)
+	require.Equal(t, consensus.EvidenceMaxAgeDuration, params.Evidence.MaxAgeDuration)
+}
@@ -0,0 +1,78 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestDoubleSign runs a copy of a validator's consensus key until the validator is tombstoned for double signing.
+func TestDoubleSign(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// The other validators keep more than 2/3 of the voting power once the double signer is jailed.
+	nv, nf := 4, 1
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	val := chain.Validators[len(chain.Validators)-1]
+	valAddr, err := val.ValidatorAddress(ctx)
+	require.NoError(t, err)
+	before, err := chain.QueryValidator(ctx, valAddr)
+	require.NoError(t, err)
+
+	doubleSigner, err := chain.StartDoubleSigner(ctx, val)
+	require.NoError(t, err)
+
+	info, err := chain.WaitForTombstoned(ctx, valAddr, 50)
+	require.NoError(t, err, "validator was not tombstoned for double signing")
+	require.NoError(t, doubleSigner.StopContainer(ctx))
+	require.True(t, info.Tombstoned)
+
+	after, err := chain.QueryValidator(ctx, valAddr)
+	require.NoError(t, err)
+	require.True(t, after.Jailed)
+
+	// The default slash_fraction_double_sign param slashes 5% of the stake.
+	slashed := sdk.NewDecFromInt(before.Tokens.Sub(after.Tokens)).Quo(sdk.NewDecFromInt(before.Tokens))
+	require.True(t, slashed.Sub(sdk.MustNewDecFromStr("0.05")).Abs().LTE(sdk.MustNewDecFromStr("0.0001")), "slashed fraction %s", slashed)
+}
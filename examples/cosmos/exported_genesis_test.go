@@ -0,0 +1,92 @@
+package cosmos_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestExportedGenesis exports the state of a chain, and starts a new chain from the exported genesis,
+// whose validators take over the validators of the exported chain.
+func TestExportedGenesis(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia", Version: gaiaVersion},
+	})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	user := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)[0]
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.StopAllNodes(ctx))
+
+	exported, err := chain.ExportState(ctx, int64(height))
+	require.NoError(t, err)
+	genesisPath := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, os.WriteFile(genesisPath, []byte(exported), 0600))
+
+	cf = interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "forked",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ChainID:         "forked-1",
+				ExportedGenesis: &ibc.ExportedGenesisConfig{HostPath: genesisPath},
+			},
+		},
+	})
+	chains, err = cf.Chains(t.Name())
+	require.NoError(t, err)
+	forked := chains[0].(*cosmos.CosmosChain)
+
+	forkedIC := interchaintest.NewInterchain().AddChain(forked)
+	require.NoError(t, forkedIC.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = forkedIC.Close()
+	})
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, forked))
+
+	// State of the exported chain carries over.
+	bal, err := forked.GetBalance(ctx, user.FormattedAddress(), forked.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, userFunds, bal)
+}
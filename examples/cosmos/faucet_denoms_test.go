@@ -0,0 +1,73 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFaucetDenoms funds a test user with a test denom and an IBC voucher held by the faucet from genesis.
+func TestFaucetDenoms(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	const (
+		testDenom    = "utest"
+		voucherTrace = "transfer/channel-0/uosmo"
+	)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				FaucetDenoms: []string{testDenom, voucherTrace},
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000)
+	users := interchaintest.GetAndFundTestUsersWithDenoms(t, ctx, t.Name(), userFunds, chain)
+	user := users[0]
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	voucher := transfertypes.ParseDenomTrace(voucherTrace).IBCDenom()
+	for _, denom := range []string{chain.Config().Denom, testDenom, voucher} {
+		bal, err := chain.GetBalance(ctx, user.FormattedAddress(), denom)
+		require.NoError(t, err)
+		require.Equal(t, userFunds, bal, "balance of %s", denom)
+	}
+}
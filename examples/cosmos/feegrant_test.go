@@ -0,0 +1,96 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFeeGrant sends funds from an account whose fees are paid by a fee allowance, then revokes the allowance.
+func TestFeeGrant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain)
+	granter, grantee := users[0], users[1]
+	denom := chain.Config().Denom
+
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin(denom, 1_000_000))
+	require.NoError(t, chain.GrantFeeAllowance(ctx, granter.KeyName(), grantee.FormattedAddress(), cosmos.FeeAllowance{
+		SpendLimit: spendLimit.String(),
+	}))
+
+	granterBal, err := chain.GetBalance(ctx, granter.FormattedAddress(), denom)
+	require.NoError(t, err)
+
+	const transferAmount = 10000
+	require.NoError(t, chain.SendFunds(cosmos.WithFeeGranter(ctx, granter.FormattedAddress()), grantee.KeyName(), ibc.WalletAmount{
+		Address: granter.FormattedAddress(),
+		Denom:   denom,
+		Amount:  transferAmount,
+	}))
+
+	// The grantee only paid the sent amount; the granter paid the fees.
+	granteeBal, err := chain.GetBalance(ctx, grantee.FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, userFunds-transferAmount, granteeBal)
+
+	newGranterBal, err := chain.GetBalance(ctx, granter.FormattedAddress(), denom)
+	require.NoError(t, err)
+	fees := granterBal + transferAmount - newGranterBal
+	require.Positive(t, fees)
+
+	allowance, err := chain.QueryFeeAllowance(ctx, granter.FormattedAddress(), grantee.FormattedAddress())
+	require.NoError(t, err)
+	basic, ok := allowance.(*feegrant.BasicAllowance)
+	require.True(t, ok, "unexpected fee allowance type %T", allowance)
+	require.Equal(t, spendLimit.Sub(sdk.NewInt64Coin(denom, fees)), basic.SpendLimit)
+
+	require.NoError(t, chain.RevokeFeeAllowance(ctx, granter.KeyName(), grantee.FormattedAddress()))
+	_, err = chain.QueryFeeAllowance(ctx, granter.FormattedAddress(), grantee.FormattedAddress())
+	require.Error(t, err, "fee allowance was not revoked")
+
+	err = chain.SendFunds(cosmos.WithFeeGranter(ctx, granter.FormattedAddress()), grantee.KeyName(), ibc.WalletAmount{
+		Address: granter.FormattedAddress(),
+		Denom:   denom,
+		Amount:  transferAmount,
+	})
+	require.Error(t, err, "fees were paid by a revoked fee allowance")
+}
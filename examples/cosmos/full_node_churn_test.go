@@ -0,0 +1,74 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFullNodeChurn removes and restarts fullnodes of a running chain, and adds a fullnode in place of a removed one.
+func TestFullNodeChurn(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 1, 2
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, chain.RemoveFullNode(ctx, 0))
+	require.Len(t, chain.FullNodes, 1)
+
+	// The added fullnode does not reuse the name of the removed one.
+	require.NoError(t, chain.AddFullNodes(ctx, nil, 1))
+	require.Len(t, chain.FullNodes, 2)
+	require.NotEqual(t, chain.FullNodes[0].Name(), chain.FullNodes[1].Name())
+
+	// A restarted fullnode catches up with the blocks produced while it was down.
+	fn := chain.FullNodes[1]
+	require.NoError(t, fn.StopContainer(ctx))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 5, chain.Validators[0]))
+	require.NoError(t, chain.RestartFullNode(ctx, 1))
+
+	syncCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	require.NoError(t, testutil.WaitForInSync(syncCtx, chain, fn))
+}
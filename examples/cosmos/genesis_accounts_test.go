@@ -0,0 +1,87 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGenesisAccounts starts a chain with accounts of a known address and mnemonic, and a community pool, funded at genesis.
+func TestGenesisAccounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	const (
+		address  = "cosmos1hsk6jryyqjfhp5dhc55tc9jtckygx0eph6dd02"
+		mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				GenesisAccounts: []ibc.GenesisAccount{
+					{Address: address, Coins: "1000uatom,5utest"},
+					{Mnemonic: mnemonic, Coins: "10000000uatom"},
+				},
+				CommunityPool: "1000000uatom",
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	balances, err := chain.AllBalances(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, "5utest,1000uatom", balances.String())
+
+	// The account of the mnemonic signs once its key is recovered, without funding by the faucet.
+	user, err := chain.BuildWallet(ctx, "genesis-user", mnemonic)
+	require.NoError(t, err)
+	require.NoError(t, chain.SendFunds(ctx, user.KeyName(), ibc.WalletAmount{
+		Address: address,
+		Denom:   chain.Config().Denom,
+		Amount:  100,
+	}))
+	balance, err := chain.GetBalance(ctx, address, chain.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(1100), balance)
+
+	conn, err := chain.GRPCConn(ctx)
+	require.NoError(t, err)
+	pool, err := distrtypes.NewQueryClient(conn).CommunityPool(ctx, &distrtypes.QueryCommunityPoolRequest{})
+	require.NoError(t, err)
+	// The community tax of the blocks since genesis is added to the pool.
+	require.GreaterOrEqual(t, pool.Pool.AmountOf(chain.Config().Denom).TruncateInt64(), int64(1_000_000))
+}
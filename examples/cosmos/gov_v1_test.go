@@ -0,0 +1,108 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/icza/dyno"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGovV1Proposal exercises a gov v1 proposal executing a module's MsgUpdateParams on an SDK v0.47 chain.
+func TestGovV1Proposal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "ibc-go-simd",
+			ChainName: "ibc-go-simd",
+			Version:   "andrew-47-rc1",
+			ChainConfig: ibc.ChainConfig{
+				ModifyGenesis: modifyGenesisShortProposalsV1(votingPeriod, maxDepositPeriod),
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain)
+	chainUser := users[0]
+
+	params := banktypes.DefaultParams()
+	params.DefaultSendEnabled = false
+	msg := &banktypes.MsgUpdateParams{
+		Authority: chain.GovAuthority(),
+		Params:    params,
+	}
+
+	proposalTx, err := chain.SubmitProposal(ctx, chainUser.KeyName(), []sdk.Msg{msg}, "500000000"+chain.Config().Denom)
+	require.NoError(t, err, "error submitting proposal tx")
+
+	err = chain.VoteAll(ctx, proposalTx.ProposalID, cosmos.ProposalVoteYes)
+	require.NoError(t, err, "failed to submit votes")
+
+	_, err = chain.WaitForProposalStatus(ctx, proposalTx.ProposalID, cosmos.ProposalStatusPassed, 20)
+	require.NoError(t, err, "proposal status did not change to passed in expected number of blocks")
+
+	proposal, err := chain.QueryProposalV1(ctx, proposalTx.ProposalID)
+	require.NoError(t, err)
+	require.Len(t, proposal.Messages, 1)
+	require.Equal(t, sdk.MsgTypeURL(msg), proposal.Messages[0].TypeUrl)
+}
+
+// modifyGenesisShortProposalsV1 is modifyGenesisShortProposals for chains with gov v1 params.
+func modifyGenesisShortProposalsV1(votingPeriod string, maxDepositPeriod string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]interface{})
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if err := dyno.Set(g, votingPeriod, "app_state", "gov", "params", "voting_period"); err != nil {
+			return nil, fmt.Errorf("failed to set voting period in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, maxDepositPeriod, "app_state", "gov", "params", "max_deposit_period"); err != nil {
+			return nil, fmt.Errorf("failed to set max deposit period in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, chainConfig.Denom, "app_state", "gov", "params", "min_deposit", 0, "denom"); err != nil {
+			return nil, fmt.Errorf("failed to set min deposit denom in genesis json: %w", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
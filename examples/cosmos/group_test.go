@@ -0,0 +1,97 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/group"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGroupProposal sends funds from a group policy, as a 2-of-3 multisig of group members, by a group proposal.
+func TestGroupProposal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "ibc-go-simd", Version: "andrew-47-rc1"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain, chain)
+	denom := chain.Config().Denom
+
+	members := make([]cosmos.GroupMember, len(users))
+	for i, u := range users {
+		members[i] = cosmos.GroupMember{Address: u.FormattedAddress(), Weight: "1"}
+	}
+	groupID, err := chain.CreateGroup(ctx, users[0].KeyName(), "", members)
+	require.NoError(t, err)
+
+	policyAddr, err := chain.CreateGroupPolicy(ctx, users[0].KeyName(), groupID, "", cosmos.GroupDecisionPolicy{
+		Threshold:    "2",
+		VotingPeriod: time.Minute,
+	})
+	require.NoError(t, err)
+
+	const policyFunds = 1_000_000
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: policyAddr,
+		Denom:   denom,
+		Amount:  policyFunds,
+	}))
+
+	const sendAmount = 10_000
+	send := &banktypes.MsgSend{
+		FromAddress: policyAddr,
+		ToAddress:   users[2].FormattedAddress(),
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin(denom, sendAmount)),
+	}
+	// The proposer's vote alone does not reach the threshold, so the proposal is not executed yet.
+	proposalID, err := chain.SubmitGroupProposal(ctx, users[0].KeyName(), policyAddr, []sdk.Msg{send}, "Send", "Sends funds of the group", true)
+	require.NoError(t, err)
+
+	prop, err := chain.QueryGroupProposal(ctx, proposalID)
+	require.NoError(t, err)
+	require.Equal(t, group.PROPOSAL_STATUS_SUBMITTED, prop.Status)
+
+	// The second yes vote accepts the proposal, which the exec then executes.
+	require.NoError(t, chain.GroupVote(ctx, users[1].KeyName(), proposalID, group.VOTE_OPTION_YES, false))
+	require.NoError(t, chain.GroupExec(ctx, users[1].KeyName(), proposalID))
+
+	policyBal, err := chain.GetBalance(ctx, policyAddr, denom)
+	require.NoError(t, err)
+	require.Equal(t, int64(policyFunds-sendAmount), policyBal)
+}
@@ -0,0 +1,61 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestHaltRestart halts a chain at a height with the halt-height of app.toml, and restarts it.
+// Software upgrades restart with the image of the upgrade instead.
+func TestHaltRestart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	haltHeight := height + 10
+
+	require.NoError(t, chain.StopAtHeight(ctx, haltHeight))
+
+	require.NoError(t, chain.RestartWithImage(ctx, chain.Config().Images[0]))
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 5, chain))
+	height, err = chain.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, height, haltHeight)
+}
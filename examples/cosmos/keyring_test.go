@@ -0,0 +1,83 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestFileKeyring runs a chain whose nodes keep their keys in the passphrase-encrypted file keyring,
+// and sends txs with keys created and recovered in it, both from the binary and the host.
+func TestFileKeyring(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				Keyring: &ibc.KeyringConfig{Backend: ibc.KeyringBackendFile, Passphrase: "correct horse battery staple"},
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Test users are recovered from mnemonics into the file keyring, then funded by the faucet key of the binary.
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain)
+	denom := chain.Config().Denom
+
+	const sendAmount = 10_000
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: users[1].FormattedAddress(),
+		Denom:   denom,
+		Amount:  sendAmount,
+	}))
+
+	bal, err := chain.GetBalance(ctx, users[1].FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, userFunds+sendAmount, bal)
+
+	// The broadcaster signs on the host with a copy of the file keyring.
+	b := cosmos.NewBroadcaster(t, chain)
+	resp, err := cosmos.BroadcastTx(ctx, b, users[1].(*cosmos.CosmosWallet), banktypes.NewMsgSend(
+		sdk.MustAccAddressFromBech32(users[1].FormattedAddress()),
+		sdk.MustAccAddressFromBech32(users[0].FormattedAddress()),
+		sdk.NewCoins(sdk.NewInt64Coin(denom, sendAmount)),
+	))
+	require.NoError(t, err)
+	require.Zero(t, resp.Code, resp.RawLog)
+}
@@ -0,0 +1,76 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMetrics scrapes the Prometheus metrics of a node, connected to all other nodes of the chain.
+func TestMetrics(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 2, 1
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			ChainConfig:   ibc.ChainConfig{EnableMetrics: true},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	families, err := chain.ScrapeMetrics(ctx)
+	require.NoError(t, err)
+
+	peers, err := cosmos.MetricValue(families, cosmos.MetricP2PPeers)
+	require.NoError(t, err)
+	require.Equal(t, float64(nv+nf-1), peers)
+
+	height, err := cosmos.MetricValue(families, cosmos.MetricConsensusHeight)
+	require.NoError(t, err)
+	require.Positive(t, height)
+
+	_, err = cosmos.MetricValue(families, cosmos.MetricMempoolSize)
+	require.NoError(t, err)
+
+	// Every node serves its own metrics.
+	for _, n := range chain.Nodes() {
+		_, err := n.ScrapeMetrics(ctx)
+		require.NoError(t, err)
+	}
+}
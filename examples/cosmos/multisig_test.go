@@ -0,0 +1,90 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestMultisig sends funds from a 2-of-3 legacy multisig account of test wallets, signing the tx offline by two of them.
+func TestMultisig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain, chain)
+	denom := chain.Config().Denom
+
+	const multisigName = "multisig"
+	multisigAddr, err := chain.CreateMultisigKey(ctx, multisigName, 2, users[0].KeyName(), users[1].KeyName(), users[2].KeyName())
+	require.NoError(t, err)
+
+	// Fund the multisig account, so it exists on chain and can pay fees.
+	const multisigFunds = 1_000_000
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: multisigAddr,
+		Denom:   denom,
+		Amount:  multisigFunds,
+	}))
+
+	const sendAmount = 10_000
+	unsignedTx, err := chain.GenerateTx(ctx, multisigName,
+		"bank", "send", multisigAddr, users[2].FormattedAddress(), fmt.Sprintf("%d%s", sendAmount, denom),
+	)
+	require.NoError(t, err)
+
+	sig0, err := chain.SignMultisigTx(ctx, users[0].KeyName(), multisigAddr, unsignedTx)
+	require.NoError(t, err)
+	sig1, err := chain.SignMultisigTx(ctx, users[1].KeyName(), multisigAddr, unsignedTx)
+	require.NoError(t, err)
+
+	signedTx, err := chain.MultisignTx(ctx, multisigName, unsignedTx, sig0, sig1)
+	require.NoError(t, err)
+
+	txHash, err := chain.BroadcastSignedTx(ctx, signedTx)
+	require.NoError(t, err)
+	require.NotEmpty(t, txHash)
+
+	bal, err := chain.GetBalance(ctx, users[2].FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, userFunds+sendAmount, bal)
+
+	multisigBal, err := chain.GetBalance(ctx, multisigAddr, denom)
+	require.NoError(t, err)
+	require.Less(t, multisigBal, int64(multisigFunds-sendAmount)) // The multisig account also paid the fees.
+}
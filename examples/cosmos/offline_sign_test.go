@@ -0,0 +1,92 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestSignOffline generates a tx on a validator, signs it in the test process with a key that is not in any container,
+// and broadcasts it on a full node.
+func TestSignOffline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia", Version: gaiaVersion},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain)
+	denom := chain.Config().Denom
+
+	// The key of the offline wallet is only kept in the test process.
+	offline, err := chain.BuildRelayerWallet(ctx, "offline")
+	require.NoError(t, err)
+	offlineAddr := offline.FormattedAddress()
+
+	const offlineFunds = 1_000_000
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: offlineAddr,
+		Denom:   denom,
+		Amount:  offlineFunds,
+	}))
+
+	const sendAmount = 10_000
+	unsignedTx, err := chain.Validators[0].GenerateTx(ctx, offlineAddr,
+		"bank", "send", offlineAddr, users[1].FormattedAddress(), fmt.Sprintf("%d%s", sendAmount, denom),
+	)
+	require.NoError(t, err)
+
+	// A signature for a wrong sequence is rejected.
+	wrongSeq := uint64(5)
+	signedTx, err := chain.SignOffline(ctx, offline.Mnemonic(), unsignedTx, cosmos.OfflineSignOptions{Sequence: &wrongSeq})
+	require.NoError(t, err)
+	_, err = chain.FullNodes[0].BroadcastSignedTx(ctx, signedTx)
+	require.Error(t, err)
+
+	signedTx, err = chain.SignOffline(ctx, offline.Mnemonic(), unsignedTx, cosmos.OfflineSignOptions{})
+	require.NoError(t, err)
+	txHash, err := chain.FullNodes[0].BroadcastSignedTx(ctx, signedTx)
+	require.NoError(t, err)
+	require.NotEmpty(t, txHash)
+
+	bal, err := chain.GetBalance(ctx, users[1].FormattedAddress(), denom)
+	require.NoError(t, err)
+	require.Equal(t, userFunds+sendAmount, bal)
+
+	offlineBal, err := chain.GetBalance(ctx, offlineAddr, denom)
+	require.NoError(t, err)
+	require.Less(t, offlineBal, int64(offlineFunds-sendAmount)) // The offline wallet also paid the fees.
+}
@@ -0,0 +1,86 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPruning runs an archive fullnode next to pruned nodes, and queries a pruned height on each, by the CLI and gRPC.
+func TestPruning(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 1, 2
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				Pruning:         &ibc.PruningConfig{Strategy: ibc.PruningEverything},
+				FullNodePruning: map[int]ibc.PruningConfig{0: {Strategy: ibc.PruningNothing}},
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Pruning runs every 10 blocks, after which only the last 2 heights remain on pruned nodes.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 15, chain))
+
+	archive, pruned := chain.FullNodes[0], chain.FullNodes[1]
+	queryAtHeight2 := []string{"bank", "total", "--height", "2"}
+
+	_, _, err = archive.ExecQuery(ctx, queryAtHeight2...)
+	require.NoError(t, err, "archive node pruned height 2")
+
+	_, _, err = pruned.ExecQuery(ctx, queryAtHeight2...)
+	require.Error(t, err, "pruned node kept height 2")
+
+	// The same holds for gRPC queries at height 2, e.g. of the validator's genesis self-delegation.
+	validator, err := chain.Validators[0].AccountKeyBech32(ctx, "validator")
+	require.NoError(t, err)
+
+	delegations, err := archive.QueryDelegationsAtHeight(ctx, validator, 2)
+	require.NoError(t, err, "archive node pruned height 2")
+	require.Len(t, delegations, 1)
+
+	_, err = archive.QueryBalanceAtHeight(ctx, validator, chain.Config().Denom, 2)
+	require.NoError(t, err, "archive node pruned height 2")
+
+	_, err = pruned.QueryDelegationsAtHeight(ctx, validator, 2)
+	require.Error(t, err, "pruned node kept height 2")
+}
@@ -0,0 +1,79 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestStartWithoutQuorum starts 2 of 4 validators, so that the chain halts for lack of quorum,
+// and produces blocks once the other validators are started.
+func TestStartWithoutQuorum(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	numVals, numFullNodes := 4, 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+			ChainConfig: ibc.ChainConfig{
+				StartedValidators: 2,
+			},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// No blocks are produced with half of the voting power.
+	time.Sleep(10 * time.Second)
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	// A third validator brings the voting power above 2/3.
+	require.NoError(t, chain.StartValidators(ctx, 2))
+	waitCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	require.NoError(t, testutil.WaitForBlocks(waitCtx, 5, chain))
+
+	require.NoError(t, chain.StartValidators(ctx))
+	nodes := make([]testutil.ChainHeighter, len(chain.Validators))
+	for i, v := range chain.Validators {
+		nodes[i] = v
+	}
+	require.NoError(t, testutil.WaitForInSync(waitCtx, chain, nodes...))
+}
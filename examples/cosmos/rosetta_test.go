@@ -0,0 +1,90 @@
+package cosmos_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestRosetta queries the balances and blocks of a chain from its Rosetta API, served in-process by its nodes,
+// or by a sidecar container.
+func TestRosetta(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	for name, cfg := range map[string]ibc.RosettaConfig{
+		"in-process": {},
+		"sidecar":    {Sidecar: true},
+	} {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+				{
+					Name:        "gaia",
+					ChainName:   "gaia",
+					Version:     gaiaVersion,
+					ChainConfig: ibc.ChainConfig{Rosetta: &cfg},
+				},
+			})
+
+			chains, err := cf.Chains(t.Name())
+			require.NoError(t, err)
+
+			chain := chains[0].(*cosmos.CosmosChain)
+
+			ic := interchaintest.NewInterchain().
+				AddChain(chain)
+
+			ctx := context.Background()
+			client, network := interchaintest.DockerSetup(t)
+
+			require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+				TestName:          t.Name(),
+				Client:            client,
+				NetworkID:         network,
+				BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+				SkipPathCreation:  true,
+			}))
+			t.Cleanup(func() {
+				_ = ic.Close()
+			})
+
+			const userFunds = int64(10_000_000_000)
+			users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain)
+			user, recipient := users[0], users[1]
+
+			require.NoError(t, chain.SendFunds(ctx, user.KeyName(), ibc.WalletAmount{
+				Address: recipient.FormattedAddress(),
+				Denom:   chain.Config().Denom,
+				Amount:  100,
+			}))
+			height, err := chain.Height(ctx)
+			require.NoError(t, err)
+
+			balance, err := chain.RosettaAccountBalance(ctx, recipient.FormattedAddress(), int64(height))
+			require.NoError(t, err)
+			require.Equal(t, int64(height), balance.BlockIdentifier.Index)
+			require.Equal(t, strconv.FormatInt(userFunds+100, 10), balance.Balance(chain.Config().Denom))
+
+			block, err := chain.RosettaBlock(ctx, int64(height))
+			require.NoError(t, err)
+			require.Equal(t, int64(height), block.BlockIdentifier.Index)
+			require.Equal(t, int64(height)-1, block.ParentBlockIdentifier.Index)
+
+			latest, err := chain.RosettaBlock(ctx, 0)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, latest.BlockIdentifier.Index, int64(height))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestStartFlags starts a fullnode with an additional start flag raising its minimum gas prices,
+// so that it rejects txs that the validators accept.
+func TestStartFlags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nf := 2
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				FullNodeStart: map[int]ibc.StartConfig{1: {Flags: []string{"--minimum-gas-prices", "1000uatom"}}},
+			},
+			NumFullNodes: &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chain, chain)
+	user, recipient := users[0], users[1]
+
+	// The test users are funded, and their keys kept, by the first fullnode.
+	fn1 := chain.FullNodes[1]
+	require.NoError(t, fn1.RecoverKey(ctx, user.KeyName(), user.Mnemonic()))
+	_, err = fn1.ExecTx(ctx, user.KeyName(), "bank", "send", user.KeyName(), recipient.FormattedAddress(), "100uatom")
+	require.ErrorContains(t, err, "insufficient fee")
+
+	_, err = chain.FullNodes[0].ExecTx(ctx, user.KeyName(), "bank", "send", user.KeyName(), recipient.FormattedAddress(), "100uatom")
+	require.NoError(t, err)
+}
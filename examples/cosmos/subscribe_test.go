@@ -0,0 +1,82 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestSubscribe awaits the events of a bank send and of new blocks over the websocket of a node.
+func TestSubscribe(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), 10_000_000_000, chain, chain)
+	user, recipient := users[0], users[1]
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	blocks, err := chain.Subscribe(ctx, "tm.event='NewBlock'")
+	require.NoError(t, err)
+	ev := <-blocks
+	require.Equal(t, "tm.event='NewBlock'", ev.Query)
+	require.NotEmpty(t, ev.Data)
+
+	// Subscribe before sending the tx, so that its events are not missed.
+	txs, err := chain.Subscribe(ctx, fmt.Sprintf("tm.event='Tx' AND transfer.recipient='%s'", recipient.FormattedAddress()))
+	require.NoError(t, err)
+
+	require.NoError(t, chain.SendFunds(ctx, user.KeyName(), ibc.WalletAmount{
+		Address: recipient.FormattedAddress(),
+		Denom:   chain.Config().Denom,
+		Amount:  100,
+	}))
+
+	ev, ok := <-txs
+	require.True(t, ok, "no tx event before timeout")
+	// The tx's transfers include the fee, paid before the send.
+	require.Contains(t, ev.Events["transfer.amount"], "100"+chain.Config().Denom)
+	_, ok = ev.Attribute("tx.hash")
+	require.True(t, ok)
+}
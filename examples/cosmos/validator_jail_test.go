@@ -0,0 +1,114 @@
+package cosmos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/icza/dyno"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	signedBlocksWindow   = "10"
+	downtimeJailDuration = "10s"
+)
+
+// TestValidatorJail takes a validator offline until it is jailed for downtime, then unjails it.
+func TestValidatorJail(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// The other validators keep more than 2/3 of the voting power while one is offline.
+	nv, nf := 4, 1
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ModifyGenesis: modifyGenesisShortDowntime(signedBlocksWindow, downtimeJailDuration),
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	val := chain.Validators[len(chain.Validators)-1]
+	valAddr, err := val.ValidatorAddress(ctx)
+	require.NoError(t, err)
+
+	// Half of the signed blocks window must be missed to be jailed.
+	jailed, err := chain.StopValidatorUntilJailed(ctx, val, 30)
+	require.NoError(t, err, "validator was not jailed for downtime")
+	require.True(t, jailed.Jailed)
+
+	info, err := chain.QuerySigningInfo(ctx, valAddr)
+	require.NoError(t, err)
+	require.False(t, info.JailedUntil.IsZero(), "jailed validator has no jail duration")
+
+	require.NoError(t, val.StartContainer(ctx))
+	syncCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	require.NoError(t, testutil.WaitForInSync(syncCtx, chain, val))
+
+	// The validator can only be unjailed once its jail duration has passed.
+	time.Sleep(time.Until(info.JailedUntil))
+	require.NoError(t, val.Unjail(ctx))
+
+	_, err = chain.WaitForBonded(ctx, valAddr, 10)
+	require.NoError(t, err, "validator was not bonded after unjailing")
+}
+
+func modifyGenesisShortDowntime(signedBlocksWindow string, downtimeJailDuration string) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		g := make(map[string]interface{})
+		if err := json.Unmarshal(genbz, &g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+		}
+		if err := dyno.Set(g, signedBlocksWindow, "app_state", "slashing", "params", "signed_blocks_window"); err != nil {
+			return nil, fmt.Errorf("failed to set signed blocks window in genesis json: %w", err)
+		}
+		if err := dyno.Set(g, downtimeJailDuration, "app_state", "slashing", "params", "downtime_jail_duration"); err != nil {
+			return nil, fmt.Errorf("failed to set downtime jail duration in genesis json: %w", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,83 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestValidatorKeys exports the validator keys of a chain, and starts another chain with the same validator set from them.
+func TestValidatorKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	startChain := func(chainCfg ibc.ChainConfig) *cosmos.CosmosChain {
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "gaia", ChainName: chainCfg.ChainID, Version: gaiaVersion, ChainConfig: chainCfg},
+		})
+
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+
+		chain := chains[0].(*cosmos.CosmosChain)
+
+		ic := interchaintest.NewInterchain().
+			AddChain(chain)
+
+		require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+			TestName:          t.Name(),
+			Client:            client,
+			NetworkID:         network,
+			BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+			SkipPathCreation:  true,
+		}))
+		t.Cleanup(func() {
+			_ = ic.Close()
+		})
+		return chain
+	}
+
+	chain1 := startChain(ibc.ChainConfig{ChainID: "gaia-1"})
+
+	keys, err := chain1.ExportValidatorKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, len(chain1.Validators))
+
+	// Keys round trip through files on the host, e.g. to reuse them in later runs.
+	dir := t.TempDir()
+	require.NoError(t, cosmos.SaveValidatorKeys(dir, keys))
+	keys, err = cosmos.LoadValidatorKeys(dir)
+	require.NoError(t, err)
+
+	chain2 := startChain(ibc.ChainConfig{ChainID: "gaia-2", ValidatorKeys: keys})
+
+	for i, v1 := range chain1.Validators {
+		v2 := chain2.Validators[i]
+
+		pubKey1, err := v1.ConsensusPubKey(ctx)
+		require.NoError(t, err)
+		pubKey2, err := v2.ConsensusPubKey(ctx)
+		require.NoError(t, err)
+		require.Equal(t, pubKey1, pubKey2)
+
+		nodeID1, err := v1.NodeID(ctx)
+		require.NoError(t, err)
+		nodeID2, err := v2.NodeID(ctx)
+		require.NoError(t, err)
+		require.Equal(t, nodeID1, nodeID2)
+	}
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain2))
+}
@@ -0,0 +1,87 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestValidatorPause freezes a validator until it is jailed for downtime, then resumes and unjails it.
+func TestValidatorPause(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// The other validators keep more than 2/3 of the voting power while one is paused.
+	nv, nf := 4, 1
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ModifyGenesis: modifyGenesisShortDowntime(signedBlocksWindow, downtimeJailDuration),
+			},
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	i := len(chain.Validators) - 1
+	val := chain.Validators[i]
+	valAddr, err := val.ValidatorAddress(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, chain.PauseValidator(ctx, i))
+
+	// Half of the signed blocks window must be missed to be jailed.
+	jailed, err := chain.WaitForJailed(ctx, valAddr, 30)
+	require.NoError(t, err, "paused validator was not jailed for downtime")
+	require.True(t, jailed.Jailed)
+
+	info, err := chain.QuerySigningInfo(ctx, valAddr)
+	require.NoError(t, err)
+
+	require.NoError(t, chain.ResumeValidator(ctx, i))
+	syncCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	require.NoError(t, testutil.WaitForInSync(syncCtx, chain, val))
+
+	time.Sleep(time.Until(info.JailedUntil))
+	require.NoError(t, val.Unjail(ctx))
+
+	_, err = chain.WaitForBonded(ctx, valAddr, 10)
+	require.NoError(t, err, "validator was not bonded after unjailing")
+}
@@ -0,0 +1,79 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestValidatorSetChange adds a validator to a running chain, then removes it.
+func TestValidatorSetChange(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 2, 1
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &nv,
+			NumFullNodes:  &nf,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Fail as soon as the validators diverge, or a node fails, while the validator set changes.
+	ctx = chain.WatchAppHashes(ctx, t)
+	ctx = chain.WatchNodeLogs(ctx, t)
+
+	// A small validator, so that the others keep more than 2/3 of the voting power when it is removed.
+	selfDelegation := sdk.NewInt64Coin(chain.Config().Denom, 1_000_000_000_000)
+	val, err := chain.AddValidator(ctx, selfDelegation)
+	require.NoError(t, err)
+	require.Len(t, chain.Validators, nv+1)
+
+	valAddr, err := val.ValidatorAddress(ctx)
+	require.NoError(t, err)
+	bonded, err := chain.QueryValidator(ctx, valAddr)
+	require.NoError(t, err)
+	require.Equal(t, selfDelegation.Amount, bonded.Tokens)
+
+	require.NoError(t, chain.RemoveValidator(ctx, val))
+	require.Len(t, chain.Validators, nv)
+
+	removed, err := chain.QueryValidator(ctx, valAddr)
+	require.NoError(t, err)
+	require.Equal(t, stakingtypes.Unbonding, removed.Status)
+}
@@ -0,0 +1,115 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGenesisVestingAccount starts a chain with a continuous vesting account in genesis,
+// and delegates its vesting coins, which cannot be sent.
+func TestGenesisVestingAccount(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 1, 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "gaia", Version: gaiaVersion, NumValidators: &nv, NumFullNodes: &nf},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	// The vesting account's key must exist before genesis, to know its address.
+	const keyName = "vesting"
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	kr := keyring.NewInMemory(codec.NewProtoCodec(registry))
+	record, mnemonic, err := kr.NewMnemonic(
+		keyName,
+		keyring.English,
+		hd.CreateHDPath(sdk.CoinType, 0, 0).String(),
+		"", // Empty passphrase.
+		hd.Secp256k1,
+	)
+	require.NoError(t, err)
+	addrBz, err := record.GetAddress()
+	require.NoError(t, err)
+	addr := sdk.MustBech32ifyAddressBytes(chain.Config().Bech32Prefix, addrBz)
+
+	const (
+		walletAmount  = int64(10_000_000)
+		vestingAmount = int64(9_000_000)
+	)
+	denom := chain.Config().Denom
+	start := time.Now()
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain, ibc.WalletAmount{
+			Address: addr,
+			Denom:   denom,
+			Amount:  walletAmount,
+			Vesting: &ibc.VestingSchedule{
+				Type:      ibc.ContinuousVesting,
+				Amount:    vestingAmount,
+				StartTime: start,
+				EndTime:   start.Add(24 * time.Hour),
+			},
+		})
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+		SkipPathCreation:  true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	val := chain.Validators[0]
+	require.NoError(t, val.RecoverKey(ctx, keyName, mnemonic))
+
+	// Only the coins that have not vested yet are locked.
+	err = val.SendFunds(ctx, keyName, ibc.WalletAmount{
+		Address: addr,
+		Denom:   denom,
+		Amount:  walletAmount - vestingAmount + 1_000_000,
+	})
+	require.Error(t, err, "sent locked vesting coins")
+
+	// Vesting coins can be delegated.
+	valAddr, err := val.ValidatorAddress(ctx)
+	require.NoError(t, err)
+	const delegation = int64(5_000_000)
+	_, err = val.ExecTx(ctx, keyName, "staking", "delegate", valAddr, fmt.Sprintf("%d%s", delegation, denom))
+	require.NoError(t, err)
+
+	bal, err := chain.GetBalance(ctx, addr, denom)
+	require.NoError(t, err)
+	require.Less(t, bal, walletAmount-delegation)
+	require.Greater(t, bal, walletAmount-delegation-1_000_000)
+}
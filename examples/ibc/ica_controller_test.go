@@ -0,0 +1,131 @@
+package ibc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestICAController registers an interchain account with the ICA controller module of ibc-go,
+// and executes a bank send with it on the host chain.
+func TestICAController(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "ibc-go-simd", ChainName: "controller", Version: "andrew-47-rc1"},
+		{Name: "ibc-go-simd", ChainName: "host", Version: "andrew-47-rc1"},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	controller, host := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.StartupFlags("-b", "100"),
+		relayer.CustomDockerImage("ghcr.io/cosmos/relayer", "andrew-tendermint_v0.37", rly.RlyDefaultUidGid),
+	).Build(t, client, network)
+
+	const pathName = "ica-path"
+	const relayerName = "relayer"
+
+	ic := interchaintest.NewInterchain().
+		AddChain(controller).
+		AddChain(host).
+		AddRelayer(r, relayerName).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  controller,
+			Chain2:  host,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, controller, host)
+	controllerUser, hostUser := users[0], users[1]
+
+	connections, err := r.GetConnections(ctx, eRep, controller.Config().ChainID)
+	require.NoError(t, err)
+	connectionID := connections[0].ID
+
+	require.NoError(t, controller.RegisterICA(ctx, controllerUser.KeyName(), connectionID))
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occured while stopping the relayer: %s", err)
+		}
+	})
+
+	// The account is created once the relayer completes the channel handshake.
+	var icaAddr string
+	require.Eventually(t, func() bool {
+		icaAddr, err = controller.QueryICAAddress(ctx, controllerUser.FormattedAddress(), connectionID)
+		return err == nil && icaAddr != ""
+	}, 2*time.Minute, time.Second, "interchain account was not created")
+
+	const transferAmount = 10000
+	require.NoError(t, host.SendFunds(ctx, hostUser.KeyName(), ibc.WalletAmount{
+		Address: icaAddr,
+		Denom:   host.Config().Denom,
+		Amount:  transferAmount,
+	}))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, host))
+
+	hostUserBal, err := host.GetBalance(ctx, hostUser.FormattedAddress(), host.Config().Denom)
+	require.NoError(t, err)
+
+	icaTx, err := controller.SendICATx(ctx, controllerUser.KeyName(), connectionID, []sdk.Msg{
+		&banktypes.MsgSend{
+			FromAddress: icaAddr,
+			ToAddress:   hostUser.FormattedAddress(),
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin(host.Config().Denom, transferAmount)),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = controller.WaitForICAAck(ctx, icaTx, 20)
+	require.NoError(t, err)
+
+	newHostUserBal, err := host.GetBalance(ctx, hostUser.FormattedAddress(), host.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, hostUserBal+transferAmount, newHostUserBal)
+
+	icaBal, err := host.GetBalance(ctx, icaAddr, host.Config().Denom)
+	require.NoError(t, err)
+	require.Zero(t, icaBal)
+}
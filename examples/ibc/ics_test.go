@@ -0,0 +1,132 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestInterchainSecurity spins up an Interchain Security provider chain and a consumer chain validated by it,
+// and asserts the CCV channel between them is open.
+func TestInterchainSecurity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	dockerImage := ibc.DockerImage{
+		Repository: "ghcr.io/strangelove-ventures/heighliner/ics",
+		Version:    "v3.1.0",
+		UidGid:     dockerutil.GetHeighlinerUserString(),
+	}
+
+	// The consumer must have as many validators as the provider, whose consensus keys it takes over.
+	numVals := 2
+	numFullNodes := 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainName:     "provider",
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+			ChainConfig: ibc.ChainConfig{
+				Type:           "cosmos",
+				Name:           "provider",
+				ChainID:        "provider-1",
+				Images:         []ibc.DockerImage{dockerImage},
+				Bin:            "interchain-security-pd",
+				Bech32Prefix:   "cosmos",
+				Denom:          "uatom",
+				GasPrices:      "0.00uatom",
+				TrustingPeriod: "96h",
+				GasAdjustment:  1.5,
+			}},
+		{
+			ChainName:     "consumer",
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+			ChainConfig: ibc.ChainConfig{
+				Type:           "cosmos",
+				Name:           "consumer",
+				ChainID:        "consumer-1",
+				Images:         []ibc.DockerImage{dockerImage},
+				Bin:            "interchain-security-cd",
+				Bech32Prefix:   "cosmos",
+				Denom:          "ustake",
+				GasPrices:      "0.00ustake",
+				TrustingPeriod: "96h",
+				GasAdjustment:  1.5,
+			}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	provider, consumer := chains[0], chains[1]
+
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.StartupFlags("-b", "100"),
+	).Build(t, client, network)
+
+	const pathName = "ics-path"
+	const relayerName = "relayer"
+
+	ic := interchaintest.NewInterchain().
+		AddChain(provider).
+		AddChain(consumer).
+		AddRelayer(r, relayerName).
+		AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+			Provider: provider,
+			Consumer: consumer,
+			Relayer:  r,
+			Path:     pathName,
+		})
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occurred while stopping the relayer: %s", err)
+		}
+	})
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 10, provider, consumer))
+
+	channels, err := r.GetChannels(ctx, eRep, consumer.Config().ChainID)
+	require.NoError(t, err)
+
+	var ccvChannel *ibc.ChannelOutput
+	for i, c := range channels {
+		if c.PortID == "consumer" {
+			ccvChannel = &channels[i]
+		}
+	}
+	require.NotNil(t, ccvChannel, "no ccv channel on consumer")
+	require.Equal(t, "provider", ccvChannel.Counterparty.PortID)
+	require.Subset(t, []string{"STATE_OPEN", "Open"}, []string{ccvChannel.State})
+	require.Equal(t, "ORDER_ORDERED", ccvChannel.Ordering)
+}
@@ -0,0 +1,139 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer"
+	"github.com/strangelove-ventures/interchaintest/v7/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestPacketForwardMemo forwards a transfer from chain A through chain B to chain C,
+// with the memo built by cosmos.PacketForwardMemo and sent with SendMultiHopTransfer.
+func TestPacketForwardMemo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	client, network := interchaintest.DockerSetup(t)
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+
+	chainID_A, chainID_B, chainID_C := "chain-a", "chain-b", "chain-c"
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v8.0.0-rc3", ChainConfig: ibc.ChainConfig{ChainID: chainID_A, GasPrices: "0.0uatom"}},
+		{Name: "gaia", Version: "v8.0.0-rc3", ChainConfig: ibc.ChainConfig{ChainID: chainID_B, GasPrices: "0.0uatom"}},
+		{Name: "gaia", Version: "v8.0.0-rc3", ChainConfig: ibc.ChainConfig{ChainID: chainID_C, GasPrices: "0.0uatom"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chainA, chainB, chainC := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain), chains[2].(*cosmos.CosmosChain)
+
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		// TODO remove this line once default rly version includes https://github.com/cosmos/relayer/pull/1038
+		relayer.CustomDockerImage("ghcr.io/cosmos/relayer", "main", rly.RlyDefaultUidGid),
+	).Build(t, client, network)
+
+	const pathAB = "ab"
+	const pathBC = "bc"
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chainA).
+		AddChain(chainB).
+		AddChain(chainC).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainA,
+			Chain2:  chainB,
+			Relayer: r,
+			Path:    pathAB,
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chainB,
+			Chain2:  chainC,
+			Relayer: r,
+			Path:    pathBC,
+		})
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		Client:            client,
+		NetworkID:         network,
+		BlockDatabaseFile: interchaintest.DefaultBlockDatabaseFilepath(),
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const userFunds = int64(10_000_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), userFunds, chainA, chainB, chainC)
+	userA, userB, userC := users[0], users[1], users[2]
+
+	abChan, err := ibc.GetTransferChannel(ctx, r, eRep, chainID_A, chainID_B)
+	require.NoError(t, err)
+	baChan := abChan.Counterparty
+
+	cbChan, err := ibc.GetTransferChannel(ctx, r, eRep, chainID_C, chainID_B)
+	require.NoError(t, err)
+	bcChan := cbChan.Counterparty
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathAB, pathBC))
+	t.Cleanup(func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("an error occured while stopping the relayer: %s", err)
+		}
+	})
+
+	const transferAmount int64 = 100000
+	transfer := ibc.WalletAmount{
+		Address: userB.FormattedAddress(),
+		Denom:   chainA.Config().Denom,
+		Amount:  transferAmount,
+	}
+	memo := cosmos.PacketForwardMemo{
+		Hops: []cosmos.ForwardHop{
+			{Chain: chainB, Receiver: userC.FormattedAddress(), Channel: bcChan.ChannelID, Port: bcChan.PortID},
+		},
+		// The middleware of gaia v8 decodes the next memo from a string.
+		NextAsString: true,
+	}
+	_, err = chainA.SendMultiHopTransfer(ctx, abChan.ChannelID, userA.KeyName(), transfer, memo, 30)
+	require.NoError(t, err)
+
+	firstHopDenom := transfertypes.GetPrefixedDenom(baChan.PortID, baChan.ChannelID, chainA.Config().Denom)
+	secondHopDenom := transfertypes.GetPrefixedDenom(cbChan.PortID, cbChan.ChannelID, firstHopDenom)
+	firstHopIBCDenom := transfertypes.ParseDenomTrace(firstHopDenom).IBCDenom()
+	secondHopIBCDenom := transfertypes.ParseDenomTrace(secondHopDenom).IBCDenom()
+
+	chainABalance, err := chainA.GetBalance(ctx, userA.FormattedAddress(), chainA.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, userFunds-transferAmount, chainABalance)
+
+	// The intermediate receiver only holds the vouchers until they are forwarded.
+	chainBBalance, err := chainB.GetBalance(ctx, userB.FormattedAddress(), firstHopIBCDenom)
+	require.NoError(t, err)
+	require.Zero(t, chainBBalance)
+
+	chainCBalance, err := chainC.GetBalance(ctx, userC.FormattedAddress(), secondHopIBCDenom)
+	require.NoError(t, err)
+	require.Equal(t, transferAmount, chainCBalance)
+
+	escrowBalance, err := chainB.GetBalance(ctx, transfertypes.GetEscrowAddress(bcChan.PortID, bcChan.ChannelID).String(), firstHopIBCDenom)
+	require.NoError(t, err)
+	require.Equal(t, transferAmount, escrowBalance)
+}
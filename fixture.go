@@ -0,0 +1,112 @@
+package interchaintest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// fixtureKeyedModules are the app state modules whose state depends on the keys of a chain's validators and wallets.
+// A chain started from a fixture keeps the state of these modules from its own genesis, as the keys are new.
+var fixtureKeyedModules = map[string]bool{
+	"auth":         true,
+	"bank":         true,
+	"distribution": true,
+	"genutil":      true,
+	"slashing":     true,
+	"staking":      true,
+}
+
+// ChainSpecFromFixture returns a ChainSpec to start a chain like the chain with chainID of a test case
+// exported to dir, e.g. by `interchaintest blockdb export`, such as to reproduce a failed CI run locally.
+//
+// The chain has the same config, and starts from the exported genesis: its consensus params and the state of modules
+// such as gov and ibc. The state of modules that depend on the keys of the validators and wallets,
+// such as auth, bank, and staking, is from the chain's own genesis.
+// The exported blocks are not replayed; compare them to the new chain's blocks with blockdb.LoadBundle.
+//
+// The keyring passphrase and the mnemonics of genesis accounts are not exported: set ChainConfig.Keyring.Passphrase
+// of the returned spec to start a chain with a file keyring. Genesis accounts funded by mnemonic are omitted.
+func ChainSpecFromFixture(dir, chainID string) (*ChainSpec, error) {
+	bundle, err := blockdb.LoadBundle(dir)
+	if err != nil {
+		return nil, fmt.Errorf("load fixture: %w", err)
+	}
+	chain, ok := bundle.Chain(chainID)
+	if !ok {
+		return nil, fmt.Errorf("fixture has no chain %s", chainID)
+	}
+	if chain.Config == nil {
+		return nil, fmt.Errorf("fixture has no config for chain %s", chainID)
+	}
+	var cfg ibc.ChainConfig
+	if err := json.Unmarshal(chain.Config, &cfg); err != nil {
+		return nil, fmt.Errorf("decode chain %s config: %w", chainID, err)
+	}
+
+	// Drop the genesis accounts whose mnemonic, without which they have no address, was not exported.
+	var accounts []ibc.GenesisAccount
+	for _, acc := range cfg.GenesisAccounts {
+		if acc.Address != "" {
+			accounts = append(accounts, acc)
+		}
+	}
+	cfg.GenesisAccounts = accounts
+
+	if chain.Genesis != nil {
+		exported := chain.Genesis
+		cfg.ModifyGenesis = func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+			return fixtureGenesis(genbz, exported)
+		}
+	}
+	return &ChainSpec{
+		Name:        cfg.Name,
+		ChainName:   cfg.Name,
+		ChainConfig: cfg,
+	}, nil
+}
+
+// fixtureGenesis returns genbz with the exported genesis fields and app state, except for the validators,
+// the genesis time, and the state of fixtureKeyedModules.
+func fixtureGenesis(genbz, exported []byte) ([]byte, error) {
+	var gen, exp map[string]json.RawMessage
+	if err := json.Unmarshal(genbz, &gen); err != nil {
+		return nil, fmt.Errorf("decode genesis: %w", err)
+	}
+	if err := json.Unmarshal(exported, &exp); err != nil {
+		return nil, fmt.Errorf("decode fixture genesis: %w", err)
+	}
+
+	for k, v := range exp {
+		switch k {
+		case "genesis_time", "validators", "app_state":
+		default:
+			gen[k] = v
+		}
+	}
+
+	var appState, expAppState map[string]json.RawMessage
+	if err := json.Unmarshal(gen["app_state"], &appState); err != nil {
+		return nil, fmt.Errorf("decode genesis app_state: %w", err)
+	}
+	if err := json.Unmarshal(exp["app_state"], &expAppState); err != nil {
+		return nil, fmt.Errorf("decode fixture genesis app_state: %w", err)
+	}
+	if appState == nil {
+		appState = make(map[string]json.RawMessage)
+	}
+	for module, state := range expAppState {
+		if !fixtureKeyedModules[module] {
+			appState[module] = state
+		}
+	}
+	b, err := json.Marshal(appState)
+	if err != nil {
+		return nil, err
+	}
+	gen["app_state"] = b
+
+	return json.Marshal(gen)
+}
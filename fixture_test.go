@@ -0,0 +1,97 @@
+package interchaintest_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v7"
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestChainSpecFromFixture(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	db, err := blockdb.ConnectDB(ctx, filepath.Join(tmp, "blocks.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, blockdb.Migrate(db, "abc123"))
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestFixture", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "mychain-123", "cosmos")
+	require.NoError(t, err)
+
+	cfg := ibc.ChainConfig{
+		Type:    "cosmos",
+		Name:    "mychain",
+		ChainID: "mychain-123",
+		Images: []ibc.DockerImage{
+			{Repository: "docker.example.com", Version: "v1.2.3", UidGid: "1025:1025"},
+		},
+		Bin:            "/bin/true",
+		Bech32Prefix:   "foo",
+		Denom:          "bar",
+		GasPrices:      "1bar",
+		GasAdjustment:  2,
+		TrustingPeriod: "24h",
+		Keyring:        &ibc.KeyringConfig{Backend: ibc.KeyringBackendFile, Passphrase: "secret passphrase"},
+		GenesisAccounts: []ibc.GenesisAccount{
+			{Address: "foo1addr", Coins: "100bar"},
+			{Mnemonic: "secret mnemonic", Coins: "200bar"},
+		},
+		ModifyGenesis: func(ibc.ChainConfig, []byte) ([]byte, error) {
+			panic("not exported")
+		},
+	}
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "secret passphrase")
+	require.NotContains(t, string(b), "secret mnemonic")
+	require.NoError(t, chain.SaveConfig(ctx, b))
+	require.NoError(t, chain.SaveGenesis(ctx, []byte(`{
+  "genesis_time": "2023-01-01T00:00:00Z",
+  "chain_id": "mychain-123",
+  "consensus_params": {"block": {"max_gas": "100"}},
+  "validators": [{"name": "old"}],
+  "app_state": {"bank": {"balances": ["old"]}, "gov": {"voting_params": {"voting_period": "10s"}}}
+}`)))
+
+	dir := filepath.Join(tmp, "bundle")
+	require.NoError(t, blockdb.ExportTestCase(ctx, db, tc.ID(), dir))
+
+	spec, err := interchaintest.ChainSpecFromFixture(dir, "mychain-123")
+	require.NoError(t, err)
+	got, err := spec.Config(zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, "mychain", got.Name)
+	require.Equal(t, "mychain-123", got.ChainID)
+	require.Equal(t, cfg.Images, got.Images)
+	require.Equal(t, cfg.GasAdjustment, got.GasAdjustment)
+	require.Equal(t, &ibc.KeyringConfig{Backend: ibc.KeyringBackendFile}, got.Keyring)
+	require.Equal(t, []ibc.GenesisAccount{{Address: "foo1addr", Coins: "100bar"}}, got.GenesisAccounts)
+	require.NotNil(t, got.ModifyGenesis)
+
+	genbz, err := got.ModifyGenesis(*got, []byte(`{
+  "genesis_time": "2024-01-01T00:00:00Z",
+  "chain_id": "mychain-123",
+  "consensus_params": {"block": {"max_gas": "-1"}},
+  "validators": [],
+  "app_state": {"bank": {"balances": ["new"]}, "gov": {}, "mint": {}}
+}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "genesis_time": "2024-01-01T00:00:00Z",
+  "chain_id": "mychain-123",
+  "consensus_params": {"block": {"max_gas": "100"}},
+  "validators": [],
+  "app_state": {"bank": {"balances": ["new"]}, "gov": {"voting_params": {"voting_period": "10s"}}, "mint": {}}
+}`, string(genbz))
+
+	_, err = interchaintest.ChainSpecFromFixture(dir, "other-1")
+	require.ErrorContains(t, err, "no chain other-1")
+}
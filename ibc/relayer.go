@@ -95,6 +95,13 @@ type Relayer interface {
 	Exec(ctx context.Context, rep RelayerExecReporter, cmd []string, env []string) RelayerExecResult
 }
 
+// PathClientsUpdater is implemented by relayers that can set the existing clients a path relays on,
+// e.g. the clients an ICS consumer chain and its provider create for each other.
+type PathClientsUpdater interface {
+	// UpdatePathClients sets the IDs of the clients of the path on its source and destination chain.
+	UpdatePathClients(ctx context.Context, rep RelayerExecReporter, pathName, srcClientID, dstClientID string) error
+}
+
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
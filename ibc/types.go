@@ -3,6 +3,7 @@ package ibc
 import (
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	ibcexported "github.com/cosmos/ibc-go/v7/modules/core/03-connection/types"
@@ -26,6 +27,12 @@ type ChainConfig struct {
 	Denom string `yaml:"denom"`
 	// Coin type
 	CoinType string `default:"118" yaml:"coin-type"`
+	// Signing algorithm of the chain's keys, e.g. SigningAlgorithmEthSecp256k1 for ethermint chains like Evmos,
+	// which also use coin type 60. Defaults to SigningAlgorithmSecp256k1. Used for cosmos chains only.
+	SigningAlgorithm string `yaml:"signing-algorithm"`
+	// Additional denoms held by the faucet from genesis, e.g. test tokens, or IBC vouchers by their denom trace,
+	// like transfer/channel-0/uatom. Fund test users with them with GetAndFundTestUsersWithDenoms.
+	FaucetDenoms []string `yaml:"faucet-denoms"`
 	// Minimum gas prices for sending transactions, in native currency denom.
 	GasPrices string `yaml:"gas-prices"`
 	// Adjustment multiplier for gas fees.
@@ -34,14 +41,243 @@ type ChainConfig struct {
 	TrustingPeriod string `yaml:"trusting-period"`
 	// Do not use docker host mount.
 	NoHostMount bool `yaml:"no-host-mount"`
+	// Enable the REST API server of nodes, for cosmos chains.
+	EnableAPI bool `yaml:"enable-api"`
+	// Enable the Prometheus metrics of nodes, of CometBFT and of the app's telemetry, for cosmos chains.
+	EnableMetrics bool `yaml:"enable-metrics"`
 	// When provided, genesis file contents will be altered before sharing for genesis.
-	ModifyGenesis func(ChainConfig, []byte) ([]byte, error)
-	// Override config parameters for files at filepath.
+	ModifyGenesis func(ChainConfig, []byte) ([]byte, error) `json:"-"`
+	// Override config parameters for files at filepath. Keys missing from the files are silently added,
+	// so prefer TypedConfigFileOverrides, which catches mistyped keys.
 	ConfigFileOverrides map[string]any
+	// Typed overrides of the nodes' config files, e.g. cosmos.AppTomlOverrides and cosmos.ConfigTomlOverrides,
+	// applied after ConfigFileOverrides. Unlike ConfigFileOverrides, keys missing from the files are an error,
+	// rather than silently added. Used for cosmos chains only.
+	TypedConfigFileOverrides []ConfigFileOverride `json:"-" yaml:"-"`
 	// Non-nil will override the encoding config, used for cosmos chains only.
-	EncodingConfig *testutil.TestEncodingConfig
+	EncodingConfig *testutil.TestEncodingConfig `json:"-"`
 	// Required when the chain uses the new sub commands for genesis (https://github.com/cosmos/cosmos-sdk/pull/14149)
 	UsingNewGenesisCommand bool `yaml:"using-new-genesis-command"`
+	// When provided, nodes run under cosmovisor, which switches binaries at software upgrades. Used for cosmos chains only.
+	Cosmovisor *CosmovisorConfig `yaml:"cosmovisor"`
+	// When provided, nodes serve state sync snapshots, so full nodes can be added with state sync. Used for cosmos chains only.
+	StateSync *StateSyncConfig `yaml:"state-sync"`
+	// When provided, overrides consensus parameters in genesis and the nodes' consensus config. Used for cosmos chains only.
+	Consensus *ConsensusConfig `yaml:"consensus"`
+	// When provided, the chain starts from an exported genesis, e.g. of mainnet, rather than a new one. Used for cosmos chains only.
+	ExportedGenesis *ExportedGenesisConfig `yaml:"exported-genesis"`
+	// When provided, sets the pruning strategy of the chain's nodes. Used for cosmos chains only.
+	Pruning *PruningConfig `yaml:"pruning"`
+	// Pruning strategies of individual fullnodes by their index, overriding Pruning,
+	// e.g. {0: {Strategy: PruningNothing}} for an archive node. Used for cosmos chains only.
+	FullNodePruning map[int]PruningConfig `yaml:"full-node-pruning"`
+	// Cosmos SDK version of the chain's binary, e.g. v0.47, which selects the commands and RPC results used for the chain.
+	// Detected from the version info of the binary if empty. Used for cosmos chains only.
+	SDKVersion string `yaml:"sdk-version"`
+	// When provided, the chain uses the feemarket module, whose genesis params are set,
+	// and txs pay fees by the current base gas price rather than GasPrices. Used for cosmos chains only.
+	FeeMarket *FeeMarketConfig `yaml:"fee-market"`
+	// When provided, selects the keyring backend of the keys in the nodes' containers, rather than the test backend.
+	// Used for cosmos chains only.
+	Keyring *KeyringConfig `yaml:"keyring"`
+	// Pre-generated keys of the validators by index, e.g. exported from an earlier run, so that the chain has
+	// the same validator set across runs. Validators without keys generate their own. Used for cosmos chains only,
+	// other than consumer chains, whose validators use the consensus keys of the provider chain's validators.
+	ValidatorKeys []ValidatorKeys `json:"-" yaml:"-"`
+	// When provided, overrides the start command of the chain's nodes, e.g. to add flags. Used for cosmos chains only.
+	Start *StartConfig `yaml:"start"`
+	// Start commands of individual validators and fullnodes by their index, overriding Start,
+	// e.g. {0: {Flags: []string{"--grpc-only"}}}. Used for cosmos chains only.
+	ValidatorStart map[int]StartConfig `yaml:"validator-start"`
+	FullNodeStart  map[int]StartConfig `yaml:"full-node-start"`
+	// Environment variables of the containers of the chain's nodes, e.g. RUST_LOG=debug. Used for cosmos chains only.
+	Env []string `yaml:"env"`
+	// Environment variables of the containers of individual validators and fullnodes by their index,
+	// in addition to Env, e.g. {0: {"DAEMON_POLL_INTERVAL=100ms"}}. Used for cosmos chains only.
+	ValidatorEnv map[int][]string `yaml:"validator-env"`
+	FullNodeEnv  map[int][]string `yaml:"full-node-env"`
+	// When provided, serves the Rosetta API (https://www.rosetta-api.org) of the chain, e.g. for the integration tests
+	// of exchanges. Used for cosmos chains only.
+	Rosetta *RosettaConfig `yaml:"rosetta"`
+	// Accounts funded at genesis, in addition to the wallets passed to Interchain.AddChain, e.g. known addresses
+	// of mnemonics used by the test, which do not need funding by the faucet. Used for cosmos chains only.
+	GenesisAccounts []GenesisAccount `yaml:"genesis-accounts"`
+	// Balances of module accounts at genesis by module name, e.g. {"fee_collector": "1000uatom"}, as coins like "100uatom,5stake",
+	// which are added to the supply. Modules whose state tracks their balance, like staking and gov, fail their invariants
+	// with other balances; set the community pool with CommunityPool. Used for cosmos chains only.
+	ModuleBalances map[string]string `yaml:"module-balances"`
+	// Coins of the community pool at genesis, e.g. "1000000uatom", added to the distribution module account's balance
+	// and the supply. Used for cosmos chains only.
+	CommunityPool string `yaml:"community-pool"`
+	// Number of validators started with the chain, e.g. 2 of 4 validators to start the chain without the quorum
+	// of more than 2/3 of the voting power, so that it halts until the others are started with CosmosChain.StartValidators.
+	// All validators are started if zero. Used for cosmos chains only.
+	StartedValidators int `yaml:"started-validators"`
+}
+
+// GenesisAccount is an account funded at genesis. Used for cosmos chains only.
+type GenesisAccount struct {
+	// Bech32 address of the account. Leave empty to derive it from Mnemonic.
+	Address string `yaml:"address"`
+	// Mnemonic of the account's key, with the chain's coin type and signing algorithm, if Address is empty.
+	// Recover the key into the keyring of the chain's nodes with BuildWallet to sign with the account.
+	// Omitted from the JSON of the config, such as the config saved to the block database and exported fixtures.
+	Mnemonic string `json:"-" yaml:"mnemonic"`
+	// Coins of the account, like "100uatom,5stake". IBC vouchers can be given by their denom trace,
+	// like transfer/channel-0/uatom, which is added to the genesis of the transfer module.
+	Coins string `yaml:"coins"`
+}
+
+// ConfigFileOverride is a typed override of a config file of chain nodes.
+type ConfigFileOverride interface {
+	// ConfigFile returns the path of the overridden file, relative to the node's home directory, e.g. config/app.toml.
+	ConfigFile() string
+	// TomlOverrides returns the overridden keys of the file, with nested tables for sections.
+	TomlOverrides() map[string]any
+}
+
+// CosmovisorConfig configures running chain nodes under cosmovisor (https://docs.cosmos.network/main/tooling/cosmovisor).
+type CosmovisorConfig struct {
+	// Path on the host of a cosmovisor binary, v1.0.0 or later, copied to each node's volume.
+	// The binary must run in the chain's images, e.g. a statically linked linux build.
+	// If empty, cosmovisor must be on the PATH of the chain's images.
+	HostBinaryPath string `yaml:"host-binary-path"`
+}
+
+// StateSyncConfig configures chain nodes to take state sync snapshots,
+// so that full nodes added later can join with state sync rather than by replaying blocks from genesis.
+type StateSyncConfig struct {
+	// Interval in blocks between snapshots. Defaults to 10.
+	SnapshotInterval uint64 `yaml:"snapshot-interval"`
+	// Number of recent snapshots kept by each node. Defaults to 2.
+	SnapshotKeepRecent uint32 `yaml:"snapshot-keep-recent"`
+}
+
+// FeeMarketConfig configures a chain using the feemarket module (https://github.com/skip-mev/feemarket),
+// whose base gas price rises and falls with block utilization, like EIP-1559. Zero fields are left unchanged in genesis.
+type FeeMarketConfig struct {
+	// Minimum base gas price, a decimal amount of FeeDenom, e.g. "0.0025".
+	MinBaseGasPrice string `yaml:"min-base-gas-price"`
+	// Base gas price at genesis, e.g. "0.0025". Defaults to MinBaseGasPrice.
+	BaseGasPrice string `yaml:"base-gas-price"`
+	// Maximum gas of the txs in a block, by which block utilization is measured.
+	MaxBlockUtilization uint64 `yaml:"max-block-utilization"`
+	// Denom of fees. Defaults to the chain's denom.
+	FeeDenom string `yaml:"fee-denom"`
+	// Factor of the base gas price that txs pay, so that txs remain valid when the base gas price
+	// rises before they are included in a block. Defaults to 1.5.
+	GasPriceMultiplier float64 `yaml:"gas-price-multiplier"`
+}
+
+// ConsensusConfig overrides consensus parameters of a chain. Zero fields are left unchanged.
+type ConsensusConfig struct {
+	// Maximum gas of the txs in a block; -1 for unlimited.
+	BlockMaxGas int64 `yaml:"block-max-gas"`
+	// Maximum size of a block in bytes.
+	BlockMaxBytes int64 `yaml:"block-max-bytes"`
+	// Maximum age of evidence, in blocks. Evidence is valid until it is older than both max ages.
+	EvidenceMaxAgeNumBlocks int64 `yaml:"evidence-max-age-num-blocks"`
+	// Maximum age of evidence, in time.
+	EvidenceMaxAgeDuration time.Duration `yaml:"evidence-max-age-duration"`
+	// Maximum size in bytes of the evidence in a block.
+	EvidenceMaxBytes int64 `yaml:"evidence-max-bytes"`
+	// How long nodes wait after committing a block before starting the next height,
+	// which sets the block time. Defaults to 2 seconds.
+	TimeoutCommit time.Duration `yaml:"timeout-commit"`
+}
+
+// ExportedGenesisConfig configures starting a chain from an exported genesis, e.g. the output of the export command of a node.
+// The test validators replace the validators with the most voting power in the exported genesis, taking over their
+// consensus keys and voting power, so that they can produce blocks. The chain ID of the genesis is replaced
+// with the chain's, and the validators' and genesis wallets' accounts are added to it.
+type ExportedGenesisConfig struct {
+	// Path on the host of the exported genesis file.
+	HostPath string `yaml:"host-path"`
+}
+
+// Signing algorithms of ChainConfig.
+const (
+	// SigningAlgorithmSecp256k1 is the signing algorithm of the keys of most cosmos chains.
+	SigningAlgorithmSecp256k1 = "secp256k1"
+	// SigningAlgorithmEthSecp256k1 is the signing algorithm of ethermint chains, whose account addresses
+	// are derived like Ethereum addresses, from the keccak256 hash of the public key.
+	SigningAlgorithmEthSecp256k1 = "eth_secp256k1"
+)
+
+// Keyring backends of KeyringConfig.
+const (
+	// KeyringBackendTest stores keys unencrypted on disk, without passphrase prompts.
+	KeyringBackendTest = "test"
+	// KeyringBackendFile stores keys encrypted on disk with a passphrase, entered at the prompts of the binary.
+	KeyringBackendFile = "file"
+	// KeyringBackendOS stores keys in the keyring of the container's operating system,
+	// which must be available in the chain's images and persist across the containers of a node's commands.
+	KeyringBackendOS = "os"
+)
+
+// KeyringConfig configures the keyring backend of the keys in chain nodes' containers,
+// e.g. to test a chain's binary with the file backend that users run.
+type KeyringConfig struct {
+	// One of KeyringBackendTest, KeyringBackendFile, or KeyringBackendOS. Defaults to KeyringBackendTest.
+	Backend string `yaml:"backend"`
+	// Passphrase of the KeyringBackendFile keyring, at least 8 characters,
+	// which is entered at the passphrase prompts of the binary's commands.
+	// Omitted from the JSON of the config, such as the config saved to the block database and exported fixtures.
+	Passphrase string `json:"-" yaml:"passphrase"`
+}
+
+// StartConfig configures the command starting chain nodes, by default "start --x-crisis-skip-assert-invariants".
+type StartConfig struct {
+	// Subcommand of the binary starting the node, replacing start, e.g. []string{"start-node"}.
+	Subcommand []string `yaml:"subcommand"`
+	// Additional flags of the start subcommand, e.g. --grpc-only.
+	Flags []string `yaml:"flags"`
+	// Omits the default --x-crisis-skip-assert-invariants flag, e.g. for binaries without the crisis module.
+	NoDefaultFlags bool `yaml:"no-default-flags"`
+}
+
+// RosettaConfig configures the Rosetta API server of a chain, served by its nodes in-process,
+// or by a sidecar container connected to the chain's first full node.
+type RosettaConfig struct {
+	// Serve the Rosetta API from a sidecar container, rather than in-process by the nodes, which the cosmos SDK
+	// only supports up to v0.47.
+	Sidecar bool `yaml:"sidecar"`
+	// Image of the sidecar container, e.g. of the standalone rosetta server of github.com/cosmos/rosetta,
+	// whose command is rosetta. Defaults to the chain's image, running the rosetta command of the chain's binary.
+	SidecarImage *DockerImage `yaml:"sidecar-image"`
+	// Blockchain and Network identifying the chain in requests. Default to the chain's name and chain ID.
+	Blockchain string `yaml:"blockchain"`
+	Network    string `yaml:"network"`
+}
+
+// ValidatorKeys are the key files of a validator node.
+type ValidatorKeys struct {
+	// Contents of priv_validator_key.json, the consensus key of the validator. Generated if empty.
+	PrivValidatorKey []byte
+	// Contents of node_key.json, the p2p key of the node, which sets its node ID. Generated if empty.
+	NodeKey []byte
+}
+
+// Pruning strategies of PruningConfig.
+const (
+	// PruningDefault keeps the last 362880 heights, pruning every 10 blocks.
+	PruningDefault = "default"
+	// PruningNothing keeps all heights, as for an archive node.
+	PruningNothing = "nothing"
+	// PruningEverything keeps only the last 2 heights, pruning every 10 blocks.
+	PruningEverything = "everything"
+	// PruningCustom keeps the last KeepRecent heights, pruning every Interval blocks.
+	PruningCustom = "custom"
+)
+
+// PruningConfig configures how a node prunes historical app state, which limits the heights it can be queried at.
+type PruningConfig struct {
+	// One of PruningDefault, PruningNothing, PruningEverything, or PruningCustom.
+	Strategy string `yaml:"strategy"`
+	// Number of recent heights kept by PruningCustom.
+	KeepRecent uint64 `yaml:"keep-recent"`
+	// Interval in blocks between prunings of PruningCustom, at least 10. Defaults to 10.
+	Interval uint64 `yaml:"interval"`
 }
 
 func (c ChainConfig) Clone() ChainConfig {
@@ -49,6 +285,46 @@ func (c ChainConfig) Clone() ChainConfig {
 	images := make([]DockerImage, len(c.Images))
 	copy(images, c.Images)
 	x.Images = images
+	x.FaucetDenoms = append([]string(nil), c.FaucetDenoms...)
+	x.ValidatorKeys = append([]ValidatorKeys(nil), c.ValidatorKeys...)
+	if c.ValidatorStart != nil {
+		x.ValidatorStart = make(map[int]StartConfig, len(c.ValidatorStart))
+		for i, s := range c.ValidatorStart {
+			x.ValidatorStart[i] = s
+		}
+	}
+	if c.FullNodeStart != nil {
+		x.FullNodeStart = make(map[int]StartConfig, len(c.FullNodeStart))
+		for i, s := range c.FullNodeStart {
+			x.FullNodeStart[i] = s
+		}
+	}
+	x.Env = append([]string(nil), c.Env...)
+	if c.ValidatorEnv != nil {
+		x.ValidatorEnv = make(map[int][]string, len(c.ValidatorEnv))
+		for i, env := range c.ValidatorEnv {
+			x.ValidatorEnv[i] = append([]string(nil), env...)
+		}
+	}
+	if c.FullNodeEnv != nil {
+		x.FullNodeEnv = make(map[int][]string, len(c.FullNodeEnv))
+		for i, env := range c.FullNodeEnv {
+			x.FullNodeEnv[i] = append([]string(nil), env...)
+		}
+	}
+	if c.FullNodePruning != nil {
+		x.FullNodePruning = make(map[int]PruningConfig, len(c.FullNodePruning))
+		for i, p := range c.FullNodePruning {
+			x.FullNodePruning[i] = p
+		}
+	}
+	x.GenesisAccounts = append([]GenesisAccount(nil), c.GenesisAccounts...)
+	if c.ModuleBalances != nil {
+		x.ModuleBalances = make(map[string]string, len(c.ModuleBalances))
+		for name, coins := range c.ModuleBalances {
+			x.ModuleBalances[name] = coins
+		}
+	}
 	return x
 }
 
@@ -108,6 +384,14 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.CoinType = other.CoinType
 	}
 
+	if other.SigningAlgorithm != "" {
+		c.SigningAlgorithm = other.SigningAlgorithm
+	}
+
+	if len(other.FaucetDenoms) > 0 {
+		c.FaucetDenoms = append([]string(nil), other.FaucetDenoms...)
+	}
+
 	if other.GasPrices != "" {
 		c.GasPrices = other.GasPrices
 	}
@@ -122,6 +406,14 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 
 	// Skip NoHostMount so that false can be distinguished.
 
+	if other.EnableAPI {
+		c.EnableAPI = true
+	}
+
+	if other.EnableMetrics {
+		c.EnableMetrics = true
+	}
+
 	if other.ModifyGenesis != nil {
 		c.ModifyGenesis = other.ModifyGenesis
 	}
@@ -130,10 +422,98 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.ConfigFileOverrides = other.ConfigFileOverrides
 	}
 
+	if other.TypedConfigFileOverrides != nil {
+		c.TypedConfigFileOverrides = other.TypedConfigFileOverrides
+	}
+
 	if other.EncodingConfig != nil {
 		c.EncodingConfig = other.EncodingConfig
 	}
 
+	if other.Cosmovisor != nil {
+		c.Cosmovisor = other.Cosmovisor
+	}
+
+	if other.StateSync != nil {
+		c.StateSync = other.StateSync
+	}
+
+	if other.Consensus != nil {
+		c.Consensus = other.Consensus
+	}
+
+	if other.ExportedGenesis != nil {
+		c.ExportedGenesis = other.ExportedGenesis
+	}
+
+	if other.Pruning != nil {
+		c.Pruning = other.Pruning
+	}
+
+	if other.FullNodePruning != nil {
+		c.FullNodePruning = other.FullNodePruning
+	}
+
+	if other.SDKVersion != "" {
+		c.SDKVersion = other.SDKVersion
+	}
+
+	if other.FeeMarket != nil {
+		c.FeeMarket = other.FeeMarket
+	}
+
+	if other.Keyring != nil {
+		c.Keyring = other.Keyring
+	}
+
+	if other.ValidatorKeys != nil {
+		c.ValidatorKeys = other.ValidatorKeys
+	}
+
+	if other.Start != nil {
+		c.Start = other.Start
+	}
+
+	if other.ValidatorStart != nil {
+		c.ValidatorStart = other.ValidatorStart
+	}
+
+	if other.FullNodeStart != nil {
+		c.FullNodeStart = other.FullNodeStart
+	}
+
+	if other.Env != nil {
+		c.Env = other.Env
+	}
+
+	if other.ValidatorEnv != nil {
+		c.ValidatorEnv = other.ValidatorEnv
+	}
+
+	if other.FullNodeEnv != nil {
+		c.FullNodeEnv = other.FullNodeEnv
+	}
+
+	if other.Rosetta != nil {
+		c.Rosetta = other.Rosetta
+	}
+
+	if other.GenesisAccounts != nil {
+		c.GenesisAccounts = other.GenesisAccounts
+	}
+
+	if other.ModuleBalances != nil {
+		c.ModuleBalances = other.ModuleBalances
+	}
+
+	if other.CommunityPool != "" {
+		c.CommunityPool = other.CommunityPool
+	}
+
+	if other.StartedValidators != 0 {
+		c.StartedValidators = other.StartedValidators
+	}
+
 	return c
 }
 
@@ -171,6 +551,59 @@ type WalletAmount struct {
 	Address string
 	Denom   string
 	Amount  int64
+
+	// Vesting, if set, makes a wallet added at genesis a vesting account.
+	// Only supported by cosmos chains.
+	Vesting *VestingSchedule
+}
+
+// VestingType is the type of a vesting account.
+type VestingType int
+
+const (
+	// ContinuousVesting vests linearly from the schedule's StartTime until its EndTime.
+	ContinuousVesting VestingType = iota
+	// DelayedVesting vests all at once at the schedule's EndTime.
+	DelayedVesting
+	// PeriodicVesting vests the amount of each of the schedule's Periods at its end, starting at StartTime.
+	PeriodicVesting
+)
+
+// String returns the lowercase string representation of the VestingType.
+func (t VestingType) String() string {
+	switch t {
+	case ContinuousVesting:
+		return "continuous"
+	case DelayedVesting:
+		return "delayed"
+	case PeriodicVesting:
+		return "periodic"
+	default:
+		return "invalid"
+	}
+}
+
+// VestingSchedule is the schedule by which the amount of a vesting account vests.
+type VestingSchedule struct {
+	Type VestingType
+
+	// Amount is the part of the wallet's Amount that vests by a continuous or delayed schedule;
+	// the rest is spendable from genesis. A periodic schedule vests the sum of the amounts of its Periods instead.
+	Amount int64
+
+	// StartTime is when a continuous or periodic schedule starts vesting.
+	StartTime time.Time
+	// EndTime is when a continuous or delayed schedule has vested its whole Amount.
+	EndTime time.Time
+
+	// Periods are the consecutive periods of a periodic schedule.
+	Periods []VestingPeriod
+}
+
+// VestingPeriod is a period of a periodic vesting schedule, at the end of which its Amount vests.
+type VestingPeriod struct {
+	Length time.Duration
+	Amount int64
 }
 
 type IBCTimeout struct {
@@ -440,6 +440,38 @@ func TestInterchain_ConflictRejection(t *testing.T) {
 	})
 }
 
+func TestInterchain_AddProviderConsumerLink(t *testing.T) {
+	newChain := func(name string) *cosmos.CosmosChain {
+		return cosmos.NewCosmosChain(t.Name(), ibc.ChainConfig{Name: name, ChainID: name + "-1"}, 1, 0, zap.NewNop())
+	}
+	provider, consumer, other := newChain("provider"), newChain("consumer"), newChain("other")
+	var r rly.CosmosRelayer
+
+	ic := interchaintest.NewInterchain().
+		AddChain(provider).
+		AddChain(consumer).
+		AddChain(other).
+		AddRelayer(&r, "r").
+		AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+			Provider: provider,
+			Consumer: consumer,
+			Relayer:  &r,
+			Path:     "ics",
+		})
+	require.Same(t, provider, consumer.Provider)
+	require.Equal(t, []*cosmos.CosmosChain{consumer}, provider.Consumers)
+
+	require.PanicsWithError(t, "consumer chain consumer already has a provider", func() {
+		_ = ic.AddProviderConsumerLink(interchaintest.ProviderConsumerLink{Provider: other, Consumer: consumer, Relayer: &r, Path: "ics2"})
+	})
+	require.PanicsWithError(t, "provider chain consumer is a consumer chain", func() {
+		_ = ic.AddProviderConsumerLink(interchaintest.ProviderConsumerLink{Provider: consumer, Consumer: other, Relayer: &r, Path: "ics2"})
+	})
+	require.Panics(t, func() {
+		_ = ic.AddLink(interchaintest.InterchainLink{Chain1: provider, Chain2: other, Relayer: &r, Path: "ics"})
+	})
+}
+
 func TestInterchain_AddNil(t *testing.T) {
 	require.PanicsWithError(t, "cannot add nil chain", func() {
 		_ = interchaintest.NewInterchain().AddChain(nil)
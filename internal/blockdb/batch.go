@@ -0,0 +1,211 @@
+package blockdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// BatchSaver saves many blocks at once, such as *Chain.
+type BatchSaver interface {
+	FullBlockSaver
+	SaveFullBlocks(ctx context.Context, blocks []HeightBlock) error
+}
+
+// errBatchWriterClosed is returned when saving a block to a closed BatchWriter.
+var errBatchWriterClosed = errors.New("batch writer is closed")
+
+// batchRetryInterval is the time between retries of blocks that failed to write, while no new blocks are queued.
+const batchRetryInterval = time.Second
+
+// BatchWriter is a BlockSaver that saves blocks asynchronously and in batches, so that a Collector
+// is not slowed by a database write per block. Blocks are queued, up to the batch size, and written in order
+// by a single goroutine. Whenever the goroutine is ready to write, it writes all queued blocks in one batch,
+// so blocks are written as soon as possible, in larger batches the faster they are produced.
+// If the queue is full, saving a block blocks until there is room, bounding memory use.
+//
+// Blocks that fail to write are retried with the next batch, or after batchRetryInterval. Until they are written,
+// SaveBlock returns the error of their write, so the Collector retries its current block rather than moving on.
+// Call Close to write the remaining queued blocks, e.g. when the test ends.
+type BatchWriter struct {
+	log   *zap.Logger
+	saver BatchSaver
+	size  int
+
+	queue chan HeightBlock
+	done  chan struct{}
+
+	// mu guards closed. Saves hold the read lock while queueing, so Close waits for in-progress saves.
+	mu     sync.RWMutex
+	closed bool
+	stop   chan struct{}
+
+	// errMu guards err, the error of the blocks that failed to write and are pending a retry,
+	// or that failed their final write once closed.
+	errMu sync.Mutex
+	err   error
+}
+
+// NewBatchWriter returns a BatchWriter of saver, writing up to size blocks per batch, and starts its goroutine.
+// The size must be positive; 100 is typically sufficient.
+func NewBatchWriter(log *zap.Logger, saver BatchSaver, size int) *BatchWriter {
+	if size <= 0 {
+		panic(fmt.Errorf("batch size must be positive, got %d", size))
+	}
+	w := &BatchWriter{
+		log:   log,
+		saver: saver,
+		size:  size,
+		queue: make(chan HeightBlock, size),
+		done:  make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// SaveBlock implements BlockSaver.
+func (w *BatchWriter) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
+	return w.SaveFullBlock(ctx, height, Block{Txs: txs})
+}
+
+// SaveFullBlock implements FullBlockSaver, queueing the block to write.
+// It returns an error if the context is done before the block is queued, if the writer is closed,
+// or if earlier blocks failed to write and are pending a retry, in which case the block is not queued.
+func (w *BatchWriter) SaveFullBlock(ctx context.Context, height uint64, block Block) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return errBatchWriterClosed
+	}
+	if err := w.writeErr(); err != nil {
+		return fmt.Errorf("retrying failed writes of earlier blocks: %w", err)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case w.queue <- HeightBlock{Height: height, Block: block}:
+		return nil
+	}
+}
+
+// Close writes the queued blocks, and the blocks pending a retry, and stops the writer.
+// It returns the errors of the blocks that failed to write.
+// Close is safe to call multiple times.
+func (w *BatchWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.stop)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+
+	return w.writeErr()
+}
+
+func (w *BatchWriter) writeErr() error {
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	return w.err
+}
+
+func (w *BatchWriter) setWriteErr(err error) {
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	w.err = err
+}
+
+func (w *BatchWriter) run() {
+	defer close(w.done)
+	// Blocks that failed to write are retried first, to write blocks in order where possible.
+	var failed []HeightBlock
+	batch := make([]HeightBlock, 0, w.size)
+
+	// The retry timer runs while blocks are pending a retry, from when the first of them failed,
+	// so that queued blocks do not postpone the retry.
+	retry := time.NewTimer(batchRetryInterval)
+	retry.Stop()
+	defer retry.Stop()
+	var retrying bool
+	setFailed := func(blocks []HeightBlock, err error) {
+		failed = blocks
+		w.setWriteErr(err)
+		switch {
+		case len(failed) > 0 && !retrying:
+			retry.Reset(batchRetryInterval)
+			retrying = true
+		case len(failed) == 0 && retrying:
+			if !retry.Stop() {
+				<-retry.C
+			}
+			retrying = false
+		}
+	}
+
+	for {
+		var retryC <-chan time.Time
+		if retrying {
+			retryC = retry.C
+		}
+		select {
+		case b := <-w.queue:
+			setFailed(w.write(w.fill(append(append(batch[:0], failed...), b))))
+		case <-retryC:
+			retrying = false
+			setFailed(w.write(w.fill(append(batch[:0], failed...))))
+		case <-w.stop:
+			// Nothing is queued after stop, so write the remaining blocks once and return.
+			var errs error
+			for batch = w.fill(append(batch[:0], failed...)); len(batch) > 0; batch = w.fill(batch[:0]) {
+				_, err := w.write(batch)
+				errs = multierr.Append(errs, err)
+			}
+			w.setWriteErr(errs)
+			return
+		}
+	}
+}
+
+// fill appends queued blocks to batch, up to the batch size, without waiting for more.
+func (w *BatchWriter) fill(batch []HeightBlock) []HeightBlock {
+	for len(batch) < w.size {
+		select {
+		case b := <-w.queue:
+			batch = append(batch, b)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// write saves the batch. If the batch fails, each block is saved individually,
+// so that one bad block does not lose the rest of the batch.
+// It returns the blocks that failed to save, and their errors.
+func (w *BatchWriter) write(batch []HeightBlock) ([]HeightBlock, error) {
+	ctx := context.Background()
+	err := w.saver.SaveFullBlocks(ctx, batch)
+	if err == nil {
+		return nil, nil
+	}
+	w.log.Info("Failed to save batch of blocks; saving blocks individually", zap.Error(err), zap.Int("blocks", len(batch)))
+	var (
+		failed []HeightBlock
+		errs   error
+	)
+	for _, b := range batch {
+		if err := w.saver.SaveFullBlock(ctx, b.Height, b.Block); err != nil {
+			w.log.Info("Failed to save block", zap.Error(err), zap.Uint64("height", b.Height))
+			failed = append(failed, b)
+			errs = multierr.Append(errs, fmt.Errorf("save block at height %d: %w", b.Height, err))
+		}
+	}
+	return failed, errs
+}
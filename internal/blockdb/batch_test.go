@@ -0,0 +1,236 @@
+package blockdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mockBatchSaver records the heights of saved blocks and batches.
+type mockBatchSaver struct {
+	mu      sync.Mutex
+	batches [][]uint64
+	saved   []uint64
+
+	blockBatch chan struct{} // If set, batches wait to receive from blockBatch.
+	failBatch  bool
+	failHeight uint64
+}
+
+func (s *mockBatchSaver) SaveFullBlock(ctx context.Context, height uint64, block Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height == s.failHeight {
+		return errors.New("boom")
+	}
+	s.saved = append(s.saved, height)
+	return nil
+}
+
+func (s *mockBatchSaver) SaveFullBlocks(ctx context.Context, blocks []HeightBlock) error {
+	if s.blockBatch != nil {
+		<-s.blockBatch
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failBatch {
+		return errors.New("batch boom")
+	}
+	var heights []uint64
+	for _, b := range blocks {
+		heights = append(heights, b.Height)
+	}
+	s.batches = append(s.batches, heights)
+	s.saved = append(s.saved, heights...)
+	return nil
+}
+
+func TestBatchWriter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	nopLog := zap.NewNop()
+
+	t.Run("happy path", func(t *testing.T) {
+		saver := &mockBatchSaver{blockBatch: make(chan struct{})}
+		w := NewBatchWriter(nopLog, saver, 3)
+
+		// The first block is written alone, as nothing else is queued.
+		require.NoError(t, w.SaveBlock(ctx, 1, nil))
+		require.Eventually(t, func() bool { return len(w.queue) == 0 }, 5*time.Second, time.Millisecond)
+		for i := uint64(2); i <= 8; i++ {
+			if i == 5 {
+				// The queue is full, so let the batches finish.
+				close(saver.blockBatch)
+			}
+			require.NoError(t, w.SaveFullBlock(ctx, i, Block{}))
+		}
+
+		require.NoError(t, w.Close())
+		require.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, saver.saved)
+		require.Equal(t, []uint64{1}, saver.batches[0])
+		require.Equal(t, []uint64{2, 3, 4}, saver.batches[1])
+		for _, batch := range saver.batches {
+			require.LessOrEqual(t, len(batch), 3)
+		}
+
+		// Idempotent.
+		require.NoError(t, w.Close())
+
+		err := w.SaveBlock(ctx, 9, nil)
+		require.ErrorIs(t, err, errBatchWriterClosed)
+	})
+
+	t.Run("failed batch", func(t *testing.T) {
+		saver := &mockBatchSaver{blockBatch: make(chan struct{}), failBatch: true, failHeight: 2}
+		w := NewBatchWriter(nopLog, saver, 10)
+
+		for i := uint64(1); i <= 3; i++ {
+			require.NoError(t, w.SaveFullBlock(ctx, i, Block{}))
+		}
+		close(saver.blockBatch)
+
+		err := w.Close()
+		require.Error(t, err)
+		require.EqualError(t, err, "save block at height 2: boom")
+		require.Equal(t, []uint64{1, 3}, saver.saved)
+	})
+
+	t.Run("retried block", func(t *testing.T) {
+		saver := &mockBatchSaver{failBatch: true, failHeight: 1}
+		w := NewBatchWriter(nopLog, saver, 10)
+
+		require.NoError(t, w.SaveFullBlock(ctx, 1, Block{}))
+
+		// Later blocks are rejected until the failed block is written, so the Collector retries them.
+		require.Eventually(t, func() bool {
+			return w.SaveFullBlock(ctx, 2, Block{}) != nil
+		}, 5*time.Second, time.Millisecond)
+		err := w.SaveFullBlock(ctx, 2, Block{})
+		require.EqualError(t, err, "retrying failed writes of earlier blocks: save block at height 1: boom")
+
+		saver.mu.Lock()
+		saver.failBatch, saver.failHeight = false, 0
+		saver.mu.Unlock()
+		require.Eventually(t, func() bool {
+			return w.SaveFullBlock(ctx, 2, Block{}) == nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, w.Close())
+		require.Equal(t, []uint64{1, 2}, saver.saved)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		saver := &mockBatchSaver{blockBatch: make(chan struct{})}
+		w := NewBatchWriter(nopLog, saver, 1)
+
+		require.NoError(t, w.SaveBlock(ctx, 1, nil))
+		require.Eventually(t, func() bool { return len(w.queue) == 0 }, 5*time.Second, time.Millisecond)
+		require.NoError(t, w.SaveBlock(ctx, 2, nil))
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := w.SaveBlock(cctx, 3, nil)
+		require.ErrorIs(t, err, context.Canceled)
+
+		close(saver.blockBatch)
+		require.NoError(t, w.Close())
+		require.Equal(t, []uint64{1, 2}, saver.saved)
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewBatchWriter(nopLog, &mockBatchSaver{}, 0)
+		})
+	})
+}
+
+func TestBatchWriter_Chain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+	w := NewBatchWriter(zap.NewNop(), chain, 10)
+	for i := uint64(1); i <= 25; i++ {
+		block := Block{Txs: []Tx{{Data: []byte(fmt.Sprintf(`{"height":%d}`, i))}}}
+		require.NoError(t, w.SaveFullBlock(ctx, i, block))
+	}
+	require.NoError(t, w.Close())
+
+	var count, maxHeight int
+	require.NoError(t, db.QueryRow(`SELECT count(*), max(height) FROM block WHERE fk_chain_id = ?`, chain.id).Scan(&count, &maxHeight))
+	require.Equal(t, 25, count)
+	require.Equal(t, 25, maxHeight)
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM tx`).Scan(&count))
+	require.Equal(t, 25, count)
+}
+
+// BenchmarkCollector_SaveBlocks compares saving the blocks of a test with 500 blocks one at a time,
+// as the Collector did before BatchWriter, to saving them with a BatchWriter.
+func BenchmarkCollector_SaveBlocks(b *testing.B) {
+	const blocks = 500
+
+	ctx := context.Background()
+
+	db, err := ConnectDB(ctx, filepath.Join(b.TempDir(), "blocks.db"))
+	require.NoError(b, err)
+	defer db.Close()
+	require.NoError(b, Migrate(db, "bench"))
+
+	block := Block{
+		BeginBlockEvents: []Event{{Type: "rewards", Attributes: []EventAttribute{{Key: "amount", Value: "1stake"}}}},
+		Txs: []Tx{
+			{Data: []byte(`{"test":0}`), Events: []Event{{Type: "transfer", Attributes: []EventAttribute{{Key: "amount", Value: "1stake"}}}}},
+		},
+	}
+
+	var n int
+	newChain := func(b *testing.B) *Chain {
+		b.Helper()
+		b.StopTimer()
+		defer b.StartTimer()
+		// Test cases are unique by name and creation time in seconds.
+		n++
+		tc, err := CreateTestCase(ctx, db, fmt.Sprintf("%s-%d", b.Name(), n), "bench")
+		require.NoError(b, err)
+		chain, err := tc.AddChain(ctx, "chain1", "cosmos")
+		require.NoError(b, err)
+		return chain
+	}
+
+	b.Run("sync", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			chain := newChain(b)
+			for h := uint64(1); h <= blocks; h++ {
+				if err := chain.SaveFullBlock(ctx, h, block); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			w := NewBatchWriter(zap.NewNop(), newChain(b), 100)
+			for h := uint64(1); h <= blocks; h++ {
+				if err := w.SaveFullBlock(ctx, h, block); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
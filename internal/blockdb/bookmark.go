@@ -0,0 +1,90 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BookmarkResult is a bookmarked block or tx, e.g. bookmarked while reviewing a test case in the TUI.
+type BookmarkResult struct {
+	ID        int64
+	ChainPKey int64  // chain primary key
+	ChainID   string // E.g. osmosis-1001
+	Height    int64
+	TxID      sql.NullInt64 // tx primary key. Null if the block is bookmarked.
+	TxHash    string        // Upper case hex. Empty if a block is bookmarked or the hash is unknown.
+	// Always set to user's local time zone.
+	CreatedAt time.Time
+}
+
+// ToggleBookmark bookmarks the block at height of the chain, or if txID is not 0, the tx with primary key txID
+// of the block. If already bookmarked, the bookmark is removed instead.
+// It reports whether the block or tx is bookmarked after the toggle.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) ToggleBookmark(ctx context.Context, chainPkey, height, txID int64) (bool, error) {
+	tx := sql.NullInt64{Int64: txID, Valid: txID != 0}
+
+	dbTx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	var id int64
+	err = dbTx.QueryRowContext(ctx, `SELECT id FROM bookmark WHERE fk_chain_id = ? AND height = ? AND fk_tx_id IS ?`,
+		chainPkey, height, tx).Scan(&id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO bookmark(height, created_at, fk_chain_id, fk_tx_id) VALUES (?, ?, ?, ?)`,
+			height, nowRFC3339(), chainPkey, tx)
+		if err != nil {
+			return false, fmt.Errorf("insert into bookmark: %w", err)
+		}
+		return true, dbTx.Commit()
+	case err != nil:
+		return false, fmt.Errorf("query bookmark: %w", err)
+	}
+
+	if _, err := dbTx.ExecContext(ctx, `DELETE FROM bookmark WHERE id = ?`, id); err != nil {
+		return false, fmt.Errorf("delete from bookmark: %w", err)
+	}
+	return false, dbTx.Commit()
+}
+
+// Bookmarks returns the bookmarks of the test case's chains, ordered by chain, height, and for txs, bookmark time.
+// A bookmark of a block precedes the bookmarks of its txs.
+// testCaseID is the test case primary key "test_case.id".
+func (q *Query) Bookmarks(ctx context.Context, testCaseID int64) ([]BookmarkResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        bookmark.id, chain.id, chain.chain_id, bookmark.height, bookmark.fk_tx_id, COALESCE(tx.hash, ''), bookmark.created_at
+    FROM bookmark
+    INNER JOIN chain ON bookmark.fk_chain_id = chain.id
+    LEFT JOIN tx ON bookmark.fk_tx_id = tx.id
+    WHERE chain.fk_test_id = ?
+    ORDER BY chain.chain_id ASC, bookmark.height ASC, bookmark.fk_tx_id IS NOT NULL, bookmark.id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []BookmarkResult
+	for rows.Next() {
+		var (
+			res       BookmarkResult
+			createdAt string
+		)
+		if err := rows.Scan(&res.ID, &res.ChainPKey, &res.ChainID, &res.Height, &res.TxID, &res.TxHash, &createdAt); err != nil {
+			return nil, err
+		}
+		t, err := timeToLocal(createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse createdAt: %w", err)
+		}
+		res.CreatedAt = t
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,88 @@
+package blockdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_ToggleBookmark(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chainA.SaveBlock(ctx, 5, []Tx{{Data: []byte(`{}`), Hash: "aa11"}}))
+
+	q := NewQuery(db)
+	txs, err := q.Transactions(ctx, chainA.id)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	txID := txs[0].ID
+
+	for _, b := range []struct {
+		chainPkey, height, txID int64
+	}{
+		{chainA.id, 5, txID},
+		{chainB.id, 2, 0},
+		{chainA.id, 5, 0},
+		{chainA.id, 3, 0},
+	} {
+		bookmarked, err := q.ToggleBookmark(ctx, b.chainPkey, b.height, b.txID)
+		require.NoError(t, err)
+		require.True(t, bookmarked)
+	}
+
+	got, err := q.Bookmarks(ctx, tc.ID())
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	type bookmark struct {
+		ChainID string
+		Height  int64
+		TxHash  string
+	}
+	var bookmarks []bookmark
+	for _, res := range got {
+		require.False(t, res.CreatedAt.IsZero())
+		require.Equal(t, res.TxHash != "", res.TxID.Valid)
+		bookmarks = append(bookmarks, bookmark{res.ChainID, res.Height, res.TxHash})
+	}
+	require.Equal(t, []bookmark{
+		{"chain-a", 3, ""},
+		{"chain-a", 5, ""},
+		{"chain-a", 5, "AA11"},
+		{"chain-b", 2, ""},
+	}, bookmarks)
+	require.Equal(t, txID, got[2].TxID.Int64)
+	require.Equal(t, chainB.id, got[3].ChainPKey)
+
+	// Toggling again removes the bookmark of the block, but not of its tx.
+	bookmarked, err := q.ToggleBookmark(ctx, chainA.id, 5, 0)
+	require.NoError(t, err)
+	require.False(t, bookmarked)
+
+	got, err = q.Bookmarks(ctx, tc.ID())
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, "AA11", got[1].TxHash)
+
+	// Bookmarks of txs are removed with the tx, e.g. when the block is saved again.
+	require.NoError(t, chainA.SaveBlock(ctx, 5, nil))
+	got, err = q.Bookmarks(ctx, tc.ID())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	got, err = q.Bookmarks(ctx, 999)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -3,26 +3,82 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
+	"strings"
 
 	"golang.org/x/sync/singleflight"
 )
 
 // Chain tracks its blocks and a block's transactions.
 type Chain struct {
-	db     *sql.DB
-	id     int64
-	single singleflight.Group
+	db      *sql.DB
+	dialect dialect
+	id      int64
+	single  singleflight.Group
+
+	// compressTxMinSize is the size at or above which tx data is compressed, or 0 to not compress.
+	compressTxMinSize int
+}
+
+// FindChain returns an existing chain of a sqlite db, such as to save data collected after the test case finished.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func FindChain(ctx context.Context, db *sql.DB, chainPkey int64) (*Chain, error) {
+	var id int64
+	if err := db.QueryRowContext(ctx, `SELECT id FROM chain WHERE id = ?`, chainPkey).Scan(&id); err != nil {
+		return nil, fmt.Errorf("find chain %d: %w", chainPkey, err)
+	}
+	return &Chain{db: db, dialect: dialectSQLite, id: id}, nil
+}
+
+// CompressTxs compresses the data of txs saved afterwards with zstd if the data has at least minSize bytes,
+// e.g. DefaultCompressTxMinSize, to keep large txs such as CosmWasm store code txs from bloating the database.
+// A minSize of 0 disables compression. Only sqlite databases are compressed, as postgres compresses large values itself.
+//
+// Query decompresses txs transparently. Compressed txs are not in the full-text index of SearchTransactions,
+// and their messages are not in the v_cosmos_messages view.
+func (chain *Chain) CompressTxs(minSize int) {
+	if chain.dialect != dialectSQLite {
+		return
+	}
+	chain.compressTxMinSize = minSize
+}
+
+// SaveConfig saves the chain's config as JSON, e.g. a marshaled ibc.ChainConfig, for ExportTestCase.
+func (chain *Chain) SaveConfig(ctx context.Context, config []byte) error {
+	if !json.Valid(config) {
+		return errors.New("chain config is not valid json")
+	}
+	if _, err := chain.dialect.exec(ctx, chain.db, `UPDATE chain SET config = ? WHERE id = ?`, string(config), chain.id); err != nil {
+		return fmt.Errorf("update chain config: %w", err)
+	}
+	return nil
 }
 
-type transactions []Tx
+// SaveGenesis saves the chain's genesis file, as the chain's nodes started with it, for ExportTestCase.
+func (chain *Chain) SaveGenesis(ctx context.Context, genesis []byte) error {
+	if _, err := chain.dialect.exec(ctx, chain.db, `UPDATE chain SET genesis = ? WHERE id = ?`, string(genesis), chain.id); err != nil {
+		return fmt.Errorf("update chain genesis: %w", err)
+	}
+	return nil
+}
 
-func (txs transactions) Hash() []byte {
+func blockHash(block Block) []byte {
 	h := fnv.New32()
-	for _, tx := range txs {
+	for _, tx := range block.Txs {
 		h.Write(tx.Data)
 	}
+	for _, events := range [][]Event{block.BeginBlockEvents, block.EndBlockEvents} {
+		for _, e := range events {
+			h.Write([]byte(e.Type))
+		}
+	}
+	h.Write([]byte(block.ProposerAddress))
+	h.Write([]byte(block.ValidatorsHash))
+	h.Write([]byte(block.Version))
+	h.Write([]byte(block.Image))
 	return h.Sum(nil)
 }
 
@@ -30,58 +86,206 @@ func (txs transactions) Hash() []byte {
 // This method is idempotent and can be safely called multiple times with the same arguments.
 // The txs should be human-readable.
 func (chain *Chain) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
-	k := fmt.Sprintf("%d-%x", height, transactions(txs).Hash())
+	return chain.SaveFullBlock(ctx, height, Block{Txs: txs})
+}
+
+// SaveFullBlock is like SaveBlock, additionally saving the block's begin and end block events.
+func (chain *Chain) SaveFullBlock(ctx context.Context, height uint64, block Block) error {
+	k := fmt.Sprintf("%d-%x", height, blockHash(block))
 	_, err, _ := chain.single.Do(k, func() (any, error) {
-		return nil, chain.saveBlock(ctx, height, txs)
+		return nil, chain.saveBlock(ctx, height, block)
 	})
 	return err
 }
 
-func (chain *Chain) saveBlock(ctx context.Context, height uint64, txs transactions) error {
+// HeightBlock is a block at a height, e.g. to save many blocks at once with SaveFullBlocks.
+type HeightBlock struct {
+	Height uint64
+	Block  Block
+}
+
+// SaveFullBlocks is like SaveFullBlock for many blocks, saving the blocks in order within a single transaction.
+// Saving blocks in batches is much faster than saving each block in its own transaction.
+// If saving any block fails, none of the blocks are saved.
+func (chain *Chain) SaveFullBlocks(ctx context.Context, blocks []HeightBlock) error {
 	dbTx, err := chain.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = dbTx.Rollback() }()
 
-	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at) VALUES (?, ?, ?)`, height, chain.id, nowRFC3339())
+	for _, b := range blocks {
+		if err := chain.insertBlock(ctx, dbTx, b.Height, b.Block); err != nil {
+			return fmt.Errorf("height %d: %w", b.Height, err)
+		}
+	}
+	return dbTx.Commit()
+}
+
+func (chain *Chain) saveBlock(ctx context.Context, height uint64, block Block) error {
+	dbTx, err := chain.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("insert into block: %w", err)
+		return err
 	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	if err := chain.insertBlock(ctx, dbTx, height, block); err != nil {
+		return err
+	}
+	return dbTx.Commit()
+}
 
-	blockID, err := res.LastInsertId()
+// insertBlock saves the block at height, replacing any block previously saved at height, within dbTx.
+func (chain *Chain) insertBlock(ctx context.Context, dbTx *sql.Tx, height uint64, block Block) error {
+	d := chain.dialect
+	insertBlock := `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at, proposer_address, validators_hash, version) VALUES (?, ?, ?, ?, ?, ?)`
+	if d == dialectPostgres {
+		// Postgres has no INSERT OR REPLACE. Deleting the block cascades to its txs and events.
+		if _, err := d.exec(ctx, dbTx, `DELETE FROM block WHERE height = ? AND fk_chain_id = ?`, height, chain.id); err != nil {
+			return fmt.Errorf("delete from block: %w", err)
+		}
+		insertBlock = `INSERT INTO block(height, fk_chain_id, created_at, proposer_address, validators_hash, version) VALUES (?, ?, ?, ?, ?, ?)`
+	}
+	blockID, err := d.insert(ctx, dbTx, insertBlock, height, chain.id, nowRFC3339(), block.ProposerAddress, block.ValidatorsHash, block.Version)
 	if err != nil {
+		return fmt.Errorf("insert into block: %w", err)
+	}
+
+	if block.Version != "" {
+		if err := chain.saveUpgrade(ctx, dbTx, blockID, height, block); err != nil {
+			return err
+		}
+	}
+
+	if block.Validators != nil {
+		if err := chain.saveValidatorUpdates(ctx, dbTx, blockID, height, block.Validators); err != nil {
+			return err
+		}
+	}
+
+	if err := saveABCIEvents(ctx, dbTx, d, blockID, nil, abciEventBeginBlock, block.BeginBlockEvents); err != nil {
 		return err
 	}
-	for _, tx := range txs {
-		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, fk_block_id) VALUES (?, ?)`, string(tx.Data), blockID)
+	for _, tx := range block.Txs {
+		data, encoding := encodeTxData(tx.Data, chain.compressTxMinSize)
+		txID, err := d.insert(ctx, dbTx, `INSERT INTO tx(data, data_encoding, hash, gas_wanted, gas_used, code, codespace, raw_log, fk_block_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			data, encoding, strings.ToUpper(tx.Hash), tx.GasWanted, tx.GasUsed, tx.Code, tx.Codespace, tx.Log, blockID)
 		if err != nil {
 			return fmt.Errorf("insert into tx: %w", err)
 		}
-		txID, err := txRes.LastInsertId()
-		if err != nil {
+
+		if err := saveABCIEvents(ctx, dbTx, d, blockID, txID, abciEventTx, tx.Events); err != nil {
 			return err
 		}
 
+		// Tx events are also saved in the original event tables, for existing queries of them.
 		for _, e := range tx.Events {
-			eventRes, err := dbTx.ExecContext(ctx, `INSERT INTO tendermint_event(type, fk_tx_id) VALUES (?, ?)`, e.Type, txID)
+			eventID, err := d.insert(ctx, dbTx, `INSERT INTO tendermint_event(type, fk_tx_id) VALUES (?, ?)`, e.Type, txID)
 			if err != nil {
 				return fmt.Errorf("insert into tendermint_event: %w", err)
 			}
 
-			eventID, err := eventRes.LastInsertId()
-			if err != nil {
-				return err
-			}
-
 			for _, attr := range e.Attributes {
-				_, err := dbTx.ExecContext(ctx, `INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES (?, ?, ?)`, attr.Key, attr.Value, eventID)
+				_, err := d.exec(ctx, dbTx, `INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES (?, ?, ?)`, attr.Key, attr.Value, eventID)
 				if err != nil {
 					return fmt.Errorf("insert into tendermint_event_attr: %w", err)
 				}
 			}
+
+			if pe, ok := parsePacketEvent(e); ok {
+				_, err := d.exec(ctx, dbTx, `INSERT INTO ibc_packet_event(type, sequence, src_port, src_channel, dst_port, dst_channel, timeout_height, timeout_timestamp, fk_tx_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, pe.Type, pe.Sequence, pe.SrcPort, pe.SrcChannel, pe.DstPort, pe.DstChannel, pe.TimeoutHeight, pe.TimeoutTimestamp, txID)
+				if err != nil {
+					return fmt.Errorf("insert into ibc_packet_event: %w", err)
+				}
+			}
 		}
 	}
 
-	return dbTx.Commit()
+	return saveABCIEvents(ctx, dbTx, d, blockID, nil, abciEventEndBlock, block.EndBlockEvents)
+}
+
+// saveUpgrade saves an upgrade if the block's version differs from the version of the previous saved block.
+func (chain *Chain) saveUpgrade(ctx context.Context, dbTx *sql.Tx, blockID int64, height uint64, block Block) error {
+	d := chain.dialect
+	var prev string
+	err := dbTx.QueryRowContext(ctx, d.rebind(`SELECT version FROM block
+    WHERE fk_chain_id = ? AND height < ? AND version != ''
+    ORDER BY height DESC LIMIT 1`), chain.id, height).Scan(&prev)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// The first known version is not an upgrade.
+		return nil
+	case err != nil:
+		return fmt.Errorf("query previous block version: %w", err)
+	case prev == block.Version:
+		return nil
+	}
+	_, err = d.exec(ctx, dbTx, `INSERT INTO chain_upgrade(old_version, new_version, image, fk_block_id) VALUES (?, ?, ?, ?)`,
+		prev, block.Version, block.Image, blockID)
+	if err != nil {
+		return fmt.Errorf("insert into chain_upgrade: %w", err)
+	}
+	return nil
+}
+
+// saveValidatorUpdates saves the differences between validators and the validator set before height.
+func (chain *Chain) saveValidatorUpdates(ctx context.Context, dbTx *sql.Tx, blockID int64, height uint64, validators []Validator) error {
+	prev, err := validatorSet(ctx, dbTx, chain.dialect, chain.id, int64(height)-1)
+	if err != nil {
+		return fmt.Errorf("query previous validator set: %w", err)
+	}
+	prevPower := make(map[string]int64, len(prev))
+	for _, v := range prev {
+		prevPower[v.Address] = v.VotingPower
+	}
+
+	var updates []Validator
+	for _, v := range validators {
+		if power, ok := prevPower[v.Address]; !ok || power != v.VotingPower {
+			updates = append(updates, v)
+		}
+		delete(prevPower, v.Address)
+	}
+	// Validators that left the set.
+	for _, v := range prev {
+		if _, ok := prevPower[v.Address]; ok {
+			updates = append(updates, Validator{Address: v.Address})
+		}
+	}
+
+	for _, v := range updates {
+		_, err := chain.dialect.exec(ctx, dbTx, `INSERT INTO validator_update(address, voting_power, fk_block_id) VALUES (?, ?, ?)`, v.Address, v.VotingPower, blockID)
+		if err != nil {
+			return fmt.Errorf("insert into validator_update: %w", err)
+		}
+	}
+	return nil
+}
+
+// Phases of a block that emit ABCI events, as saved in the abci_event table.
+const (
+	abciEventBeginBlock = "begin_block"
+	abciEventTx         = "tx"
+	abciEventEndBlock   = "end_block"
+)
+
+// saveABCIEvents saves events emitted during phase of the block. The txID is nil for events outside of txs.
+func saveABCIEvents(ctx context.Context, dbTx *sql.Tx, d dialect, blockID int64, txID any, phase string, events []Event) error {
+	for i, e := range events {
+		attrs := e.Attributes
+		if attrs == nil {
+			attrs = []EventAttribute{}
+		}
+		attrsJSON, err := json.Marshal(attrs)
+		if err != nil {
+			return fmt.Errorf("marshal %s event attributes: %w", phase, err)
+		}
+		_, err = d.exec(ctx, dbTx, `INSERT INTO abci_event(phase, event_n, type, attributes, fk_block_id, fk_tx_id) VALUES (?, ?, ?, ?, ?, ?)`,
+			phase, i, e.Type, string(attrsJSON), blockID, txID)
+		if err != nil {
+			return fmt.Errorf("insert %s event into abci_event: %w", phase, err)
+		}
+	}
+	return nil
 }
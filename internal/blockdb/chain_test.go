@@ -20,6 +20,25 @@ func validChain(t *testing.T, db *sql.DB) *Chain {
 	return c
 }
 
+func TestFindChain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+
+	found, err := FindChain(ctx, db, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, chain.id, found.id)
+	require.NoError(t, found.SaveBlock(ctx, 1, nil))
+
+	_, err = FindChain(ctx, db, chain.id+1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestChain_SaveBlock(t *testing.T) {
 	t.Parallel()
 
@@ -183,3 +202,179 @@ ORDER BY tendermint_event_attr.id`)
 		require.Zero(t, count)
 	})
 }
+
+func TestChain_SaveFullBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+
+	block := Block{
+		BeginBlockEvents: []Event{
+			{Type: "slash", Attributes: []EventAttribute{{Key: "address", Value: "val1"}, {Key: "reason", Value: "double_sign"}}},
+			{Type: "rewards"},
+		},
+		Txs: []Tx{
+			{Data: []byte(`{"test":0}`), Events: []Event{{Type: "transfer", Attributes: []EventAttribute{{Key: "amount", Value: "1stake"}}}}},
+		},
+		EndBlockEvents: []Event{
+			{Type: "complete_unbonding", Attributes: []EventAttribute{{Key: "amount", Value: "2stake"}}},
+		},
+	}
+	require.NoError(t, chain.SaveFullBlock(ctx, 5, block))
+	// Idempotent.
+	require.NoError(t, chain.SaveFullBlock(ctx, 5, block))
+
+	rows, err := db.Query(`SELECT phase, event_n, type, attributes, fk_tx_id FROM abci_event ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type row struct {
+		Phase      string
+		Index      int
+		Type       string
+		Attributes string
+		TxID       sql.NullInt64
+	}
+	var got []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(&r.Phase, &r.Index, &r.Type, &r.Attributes, &r.TxID))
+		got = append(got, r)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Len(t, got, 4)
+
+	require.Equal(t, "begin_block", got[0].Phase)
+	require.Equal(t, 0, got[0].Index)
+	require.Equal(t, "slash", got[0].Type)
+	require.JSONEq(t, `[{"key":"address","value":"val1"},{"key":"reason","value":"double_sign"}]`, got[0].Attributes)
+	require.False(t, got[0].TxID.Valid)
+
+	require.Equal(t, "begin_block", got[1].Phase)
+	require.Equal(t, 1, got[1].Index)
+	require.JSONEq(t, `[]`, got[1].Attributes)
+
+	require.Equal(t, "tx", got[2].Phase)
+	require.Equal(t, "transfer", got[2].Type)
+	require.True(t, got[2].TxID.Valid)
+
+	require.Equal(t, "end_block", got[3].Phase)
+	require.Equal(t, "complete_unbonding", got[3].Type)
+	require.False(t, got[3].TxID.Valid)
+
+	// Tx events are still saved in the original event tables.
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM tendermint_event`).Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func TestChain_SaveFullBlock_Validators(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+	q := NewQuery(db)
+
+	valA := Validator{Address: "AAAA", VotingPower: 10}
+	valB := Validator{Address: "BBBB", VotingPower: 5}
+	valC := Validator{Address: "CCCC", VotingPower: 1}
+
+	require.NoError(t, chain.SaveFullBlock(ctx, 1, Block{ProposerAddress: "AAAA", ValidatorsHash: "H1", Validators: []Validator{valA, valB}}))
+	// Unchanged.
+	require.NoError(t, chain.SaveFullBlock(ctx, 2, Block{ProposerAddress: "BBBB", ValidatorsHash: "H1", Validators: []Validator{valB, valA}}))
+	// Unknown validators are not a change.
+	require.NoError(t, chain.SaveFullBlock(ctx, 3, Block{ProposerAddress: "AAAA", ValidatorsHash: "H1"}))
+	// B is jailed, C joins, and A's power changes.
+	require.NoError(t, chain.SaveFullBlock(ctx, 4, Block{ProposerAddress: "AAAA", ValidatorsHash: "H2", Validators: []Validator{
+		{Address: "AAAA", VotingPower: 12}, valC,
+	}}))
+
+	updates, err := q.ValidatorUpdates(ctx, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, []ValidatorUpdateResult{
+		{Height: 1, Address: "AAAA", VotingPower: 10},
+		{Height: 1, Address: "BBBB", VotingPower: 5},
+		{Height: 4, Address: "AAAA", VotingPower: 12},
+		{Height: 4, Address: "CCCC", VotingPower: 1},
+		{Height: 4, Address: "BBBB", VotingPower: 0},
+	}, updates)
+
+	for _, tt := range []struct {
+		Height int64
+		Want   []ValidatorResult
+	}{
+		{0, nil},
+		{1, []ValidatorResult{{"AAAA", 10}, {"BBBB", 5}}},
+		{3, []ValidatorResult{{"AAAA", 10}, {"BBBB", 5}}},
+		{4, []ValidatorResult{{"AAAA", 12}, {"CCCC", 1}}},
+		{100, []ValidatorResult{{"AAAA", 12}, {"CCCC", 1}}},
+	} {
+		got, err := q.ValidatorSet(ctx, chain.id, tt.Height)
+		require.NoError(t, err)
+		require.Equal(t, tt.Want, got, tt.Height)
+	}
+
+	blocks, err := q.Blocks(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, blocks, 4)
+	require.Equal(t, "BBBB", blocks[1].ProposerAddress)
+	require.Equal(t, "H2", blocks[3].ValidatorsHash)
+
+	// Saving a block again replaces its updates.
+	require.NoError(t, chain.SaveFullBlock(ctx, 4, Block{ValidatorsHash: "H3", Validators: []Validator{valA, valB, valC}}))
+	got, err := q.ValidatorSet(ctx, chain.id, 4)
+	require.NoError(t, err)
+	require.Equal(t, []ValidatorResult{{"AAAA", 10}, {"BBBB", 5}, {"CCCC", 1}}, got)
+}
+
+func TestChain_SaveFullBlock_Upgrades(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+	q := NewQuery(db)
+
+	const (
+		imageV7 = "ghcr.io/strangelove-ventures/heighliner/gaia:v7.0.0"
+		imageV8 = "ghcr.io/strangelove-ventures/heighliner/gaia:v8.0.0"
+	)
+	for _, b := range []struct {
+		Height  uint64
+		Version string
+		Image   string
+	}{
+		{1, "v7.0.0", imageV7},
+		{2, "v7.0.0", imageV7},
+		// Unknown versions are ignored.
+		{3, "", ""},
+		{4, "v8.0.0", imageV8},
+		{5, "v8.0.0", imageV8},
+	} {
+		require.NoError(t, chain.SaveFullBlock(ctx, b.Height, Block{Version: b.Version, Image: b.Image}))
+	}
+
+	want := []UpgradeResult{{Height: 4, OldVersion: "v7.0.0", NewVersion: "v8.0.0", Image: imageV8}}
+	got, err := q.Upgrades(ctx, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	// Saving the upgrade block again replaces the upgrade.
+	require.NoError(t, chain.SaveFullBlock(ctx, 4, Block{Version: "v8.0.0", Image: imageV8, Txs: []Tx{{Data: []byte(`{}`)}}}))
+	got, err = q.Upgrades(ctx, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
@@ -14,6 +14,20 @@ type Tx struct {
 	// Otherwise, this should be a human-readable format if possible.
 	Data []byte
 
+	// Hex encoded hash of the transaction, e.g. as used by the tendermint RPC, if applicable.
+	// Saved upper case.
+	Hash string
+
+	// Gas requested and consumed by the transaction, if applicable. Zero if unknown.
+	GasWanted int64
+	GasUsed   int64
+
+	// Result of executing the transaction, if applicable. A Code of 0 means the transaction succeeded.
+	// Codespace is the module namespace of a failed transaction's code, and Log is its raw log.
+	Code      uint32
+	Codespace string
+	Log       string
+
 	// Events associated with the transaction, if applicable.
 	Events []Event
 }
@@ -30,8 +44,40 @@ type Event struct {
 	// "The index flag notifies the Tendermint indexer to index the attribute. The value of the index flag is non-deterministic and may vary across different nodes in the network."
 }
 
+// EventAttribute is a key and value of an Event.
+// The JSON tags are the representation of attributes in the abci_event table.
 type EventAttribute struct {
-	Key, Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Block is a block's transactions and the events emitted outside of transactions.
+type Block struct {
+	Txs []Tx
+
+	// Events emitted before and after the block's transactions, e.g. by slashing and distribution.
+	BeginBlockEvents []Event
+	EndBlockEvents   []Event
+
+	// Hex encoded address of the validator that proposed the block, and hash of the block's validator set.
+	ProposerAddress string
+	ValidatorsHash  string
+
+	// The validator set of the block, or nil if unknown.
+	// Only changes from the validator set of the previous saved block are saved.
+	Validators []Validator
+
+	// Version of the chain software that produced the block, and the image it ran from, if known.
+	// A block with a different version than the previous saved block is saved as an upgrade of the chain.
+	Version string
+	Image   string // E.g. ghcr.io/strangelove-ventures/heighliner/gaia:v8.0.0
+}
+
+// Validator is a member of a block's validator set.
+// The JSON tags are the representation of validators in a bundle written by ExportTestCase.
+type Validator struct {
+	Address     string `json:"address"` // Hex encoded consensus address.
+	VotingPower int64  `json:"voting_power"`
 }
 
 // TxFinder finds transactions given block at height.
@@ -39,11 +85,24 @@ type TxFinder interface {
 	FindTxs(ctx context.Context, height uint64) ([]Tx, error)
 }
 
+// BlockFinder finds the transactions and events of a block at height.
+// If the TxFinder of a Collector implements BlockFinder, the Collector uses FindBlock instead of FindTxs.
+type BlockFinder interface {
+	FindBlock(ctx context.Context, height uint64) (Block, error)
+}
+
 // BlockSaver saves transactions for block at height.
 type BlockSaver interface {
 	SaveBlock(ctx context.Context, height uint64, txs []Tx) error
 }
 
+// FullBlockSaver saves the transactions and events of a block at height.
+// If the BlockSaver of a Collector implements FullBlockSaver,
+// the Collector saves blocks found by a BlockFinder with SaveFullBlock.
+type FullBlockSaver interface {
+	SaveFullBlock(ctx context.Context, height uint64, block Block) error
+}
+
 // Collector saves block transactions at regular intervals.
 type Collector struct {
 	finder TxFinder
@@ -103,6 +162,19 @@ func (p *Collector) Stop() {
 }
 
 func (p *Collector) saveTxsForHeight(ctx context.Context, height uint64) error {
+	blockFinder, okFinder := p.finder.(BlockFinder)
+	blockSaver, okSaver := p.saver.(FullBlockSaver)
+	if okFinder && okSaver {
+		block, err := blockFinder.FindBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("find block: %w", err)
+		}
+		if err := blockSaver.SaveFullBlock(ctx, height, block); err != nil {
+			return fmt.Errorf("save block: %w", err)
+		}
+		return nil
+	}
+
 	txs, err := p.finder.FindTxs(ctx, height)
 	if err != nil {
 		return fmt.Errorf("find txs: %w", err)
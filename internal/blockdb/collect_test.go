@@ -27,6 +27,28 @@ func (f mockBlockSaver) SaveBlock(ctx context.Context, height uint64, txs []Tx)
 	return f(ctx, height, txs)
 }
 
+// mockBlockFinder finds blocks with FindBlock, and panics if FindTxs is called.
+type mockBlockFinder func(ctx context.Context, height uint64) (Block, error)
+
+func (f mockBlockFinder) FindTxs(ctx context.Context, height uint64) ([]Tx, error) {
+	panic("FindTxs called instead of FindBlock")
+}
+
+func (f mockBlockFinder) FindBlock(ctx context.Context, height uint64) (Block, error) {
+	return f(ctx, height)
+}
+
+// mockFullBlockSaver saves blocks with SaveFullBlock, and panics if SaveBlock is called.
+type mockFullBlockSaver func(ctx context.Context, height uint64, block Block) error
+
+func (f mockFullBlockSaver) SaveBlock(ctx context.Context, height uint64, txs []Tx) error {
+	panic("SaveBlock called instead of SaveFullBlock")
+}
+
+func (f mockFullBlockSaver) SaveFullBlock(ctx context.Context, height uint64, block Block) error {
+	return f(ctx, height, block)
+}
+
 func TestCollector_Collect(t *testing.T) {
 	nopLog := zap.NewNop()
 
@@ -87,6 +109,42 @@ func TestCollector_Collect(t *testing.T) {
 		require.Equal(t, "3", string(savedTxs[2][0].Data))
 	})
 
+	t.Run("full blocks", func(t *testing.T) {
+		finder := mockBlockFinder(func(ctx context.Context, height uint64) (Block, error) {
+			return Block{
+				Txs:              []Tx{{Data: []byte(strconv.FormatUint(height, 10))}},
+				BeginBlockEvents: []Event{{Type: "begin"}},
+				EndBlockEvents:   []Event{{Type: "end"}},
+			}, nil
+		})
+		ch := make(chan Block)
+		saver := mockFullBlockSaver(func(ctx context.Context, height uint64, block Block) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case ch <- block:
+				return nil
+			}
+		})
+
+		collector := NewCollector(nopLog, finder, saver, time.Nanosecond)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			collector.Collect(context.Background())
+		}()
+
+		block := <-ch
+		require.Equal(t, "1", string(block.Txs[0].Data))
+		require.Equal(t, []Event{{Type: "begin"}}, block.BeginBlockEvents)
+		require.Equal(t, []Event{{Type: "end"}}, block.EndBlockEvents)
+		block = <-ch
+		require.Equal(t, "2", string(block.Txs[0].Data))
+
+		collector.Stop()
+		<-done
+	})
+
 	t.Run("find error", func(t *testing.T) {
 		ch := make(chan int)
 		finder := mockTxFinder(func(ctx context.Context, height uint64) ([]Tx, error) {
@@ -0,0 +1,62 @@
+package blockdb
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Encodings of tx data, saved in the column tx.data_encoding.
+const (
+	txEncodingNone = ""
+	txEncodingZstd = "zstd"
+)
+
+// DefaultCompressTxMinSize is the size in bytes of tx data at or above which Chain.CompressTxs typically compresses txs.
+// Most txs are much smaller, so only large txs such as CosmWasm store code txs are compressed.
+const DefaultCompressTxMinSize = 64 << 10
+
+// The encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll.
+var (
+	zstdEncoder = func() *zstd.Encoder {
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			// Only returned for invalid options.
+			panic(err)
+		}
+		return enc
+	}()
+	zstdDecoder = func() *zstd.Decoder {
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			// Only returned for invalid options.
+			panic(err)
+		}
+		return dec
+	}()
+)
+
+// encodeTxData returns the tx data to save and its encoding, compressing data of at least minSize bytes.
+// A minSize of 0 never compresses.
+func encodeTxData(data []byte, minSize int) (any, string) {
+	if minSize <= 0 || len(data) < minSize {
+		return string(data), txEncodingNone
+	}
+	return zstdEncoder.EncodeAll(data, nil), txEncodingZstd
+}
+
+// decodeTxData returns the tx data saved with encoding.
+func decodeTxData(data []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case txEncodingNone:
+		return data, nil
+	case txEncodingZstd:
+		b, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompress tx data: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown tx data encoding %q", encoding)
+	}
+}
@@ -0,0 +1,125 @@
+package blockdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_CompressTxs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestCompress", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	const minSize = 256
+	chain.CompressTxs(minSize)
+
+	large := []byte(fmt.Sprintf(`{"body":{"messages":[{"@type":"/cosmwasm.wasm.v1.MsgStoreCode","wasm_byte_code":"%s"}]}}`,
+		bytes.Repeat([]byte("A"), 4*minSize)))
+	small := []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}`)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{{Data: large, Hash: "AA"}, {Data: small, Hash: "BB"}}))
+
+	rows, err := db.Query(`SELECT data, data_encoding FROM tx ORDER BY id`)
+	require.NoError(t, err)
+	var saved []struct {
+		data     []byte
+		encoding string
+	}
+	for rows.Next() {
+		var s struct {
+			data     []byte
+			encoding string
+		}
+		require.NoError(t, rows.Scan(&s.data, &s.encoding))
+		saved = append(saved, s)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, saved, 2)
+	require.Equal(t, txEncodingZstd, saved[0].encoding)
+	require.Less(t, len(saved[0].data), len(large))
+	require.Equal(t, txEncodingNone, saved[1].encoding)
+	require.Equal(t, small, saved[1].data)
+
+	q := NewQuery(db)
+
+	txs, err := q.Transactions(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, large, txs[0].Tx)
+	require.Equal(t, small, txs[1].Tx)
+
+	tx, err := q.Tx(ctx, txs[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, large, tx.Tx)
+
+	byHash, err := q.TxsByHash(ctx, "aa")
+	require.NoError(t, err)
+	require.Len(t, byHash, 1)
+	require.Equal(t, large, byHash[0].Tx)
+
+	// Compressed txs are not in the views' messages nor the full-text index.
+	msgs, err := q.CosmosMessages(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", msgs[0].Type)
+
+	found, err := q.SearchTransactions(ctx, tc.ID(), "MsgStoreCode")
+	require.NoError(t, err)
+	require.Empty(t, found)
+	found, err = q.SearchTransactions(ctx, tc.ID(), "MsgSend")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	// Bundles contain the decompressed data.
+	dir := filepath.Join(t.TempDir(), "bundle")
+	require.NoError(t, ExportTestCase(ctx, db, tc.ID(), dir))
+	bundle, err := LoadBundle(dir)
+	require.NoError(t, err)
+	require.Len(t, bundle.Chains, 1)
+	require.Len(t, bundle.Chains[0].Blocks, 1)
+	require.Equal(t, large, []byte(bundle.Chains[0].Blocks[0].Txs[0].Data))
+
+	// Saving the block again deletes the compressed tx.
+	require.NoError(t, chain.SaveBlock(ctx, 1, nil))
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM tx`).Scan(&count))
+	require.Zero(t, count)
+}
+
+func TestDecodeTxData(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"body":{}}`)
+
+	got, err := decodeTxData(data, txEncodingNone)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	enc, encoding := encodeTxData(data, 1)
+	require.Equal(t, txEncodingZstd, encoding)
+	got, err = decodeTxData(enc.([]byte), encoding)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	enc, encoding = encodeTxData(data, 0)
+	require.Equal(t, txEncodingNone, encoding)
+	require.Equal(t, string(data), enc)
+
+	_, err = decodeTxData(data, txEncodingZstd)
+	require.Error(t, err)
+
+	_, err = decodeTxData(data, "gzip")
+	require.EqualError(t, err, `unknown tx data encoding "gzip"`)
+}
@@ -0,0 +1,62 @@
+package blockdb
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// TxDecoder decodes the raw bytes of a transaction into human-readable JSON,
+// e.g. a protobuf encoded Cosmos transaction into JSON with readable message type URLs and fields.
+// Implementations may be backed by an interface registry that includes custom modules.
+type TxDecoder interface {
+	DecodeTx(raw []byte) ([]byte, error)
+}
+
+// rawTx is the fallback format of Tx.Data, for transactions that could not be decoded when collected.
+type rawTx struct {
+	Data string `json:"data"`
+}
+
+// RawTxData formats the raw bytes of a transaction that could not be decoded into JSON,
+// so that the raw bytes can be decoded later through DecodeTx.
+func RawTxData(raw []byte) []byte {
+	b, err := json.Marshal(rawTx{Data: hex.EncodeToString(raw)})
+	if err != nil {
+		// Marshaling a string field should never fail.
+		panic(err)
+	}
+	return b
+}
+
+// rawTxBytes returns the raw bytes of data formatted by RawTxData, and whether data was in that format.
+func rawTxBytes(data []byte) ([]byte, bool) {
+	var outer map[string]json.RawMessage
+	if err := json.Unmarshal(data, &outer); err != nil || len(outer) != 1 {
+		return nil, false
+	}
+	var tx rawTx
+	if err := json.Unmarshal(data, &tx); err != nil || tx.Data == "" {
+		return nil, false
+	}
+	raw, err := hex.DecodeString(tx.Data)
+	if err != nil {
+		return nil, false
+	}
+	return raw, true
+}
+
+// DecodeTx returns tx with its data decoded by dec, if tx was saved in the raw format of RawTxData.
+// Otherwise, tx was already decoded when collected and DecodeTx returns tx as-is.
+func DecodeTx(tx TxResult, dec TxDecoder) (TxResult, error) {
+	raw, ok := rawTxBytes(tx.Tx)
+	if !ok {
+		return tx, nil
+	}
+	b, err := dec.DecodeTx(raw)
+	if err != nil {
+		return tx, fmt.Errorf("decode tx %d: %w", tx.ID, err)
+	}
+	tx.Tx = b
+	return tx, nil
+}
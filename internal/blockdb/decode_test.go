@@ -0,0 +1,80 @@
+package blockdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type upperDecoder struct{}
+
+func (upperDecoder) DecodeTx(raw []byte) ([]byte, error) {
+	if string(raw) == "bad" {
+		return nil, errors.New("boom")
+	}
+	return []byte(`{"decoded":"` + strings.ToUpper(string(raw)) + `"}`), nil
+}
+
+func TestDecodeTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("raw", func(t *testing.T) {
+		data := RawTxData([]byte("abc"))
+		require.JSONEq(t, `{"data":"616263"}`, string(data))
+
+		got, err := DecodeTx(TxResult{ID: 1, Height: 2, Tx: data}, upperDecoder{})
+		require.NoError(t, err)
+		require.Equal(t, TxResult{ID: 1, Height: 2, Tx: []byte(`{"decoded":"ABC"}`)}, got)
+	})
+
+	t.Run("already decoded", func(t *testing.T) {
+		for _, data := range []string{
+			`{"body":{"messages":[]}}`,
+			`{"data":"begin_block","note":"this is a transaction artificially created for debugging purposes"}`,
+			`{"data":"not hex"}`,
+			`not json`,
+		} {
+			got, err := DecodeTx(TxResult{Tx: []byte(data)}, upperDecoder{})
+			require.NoError(t, err)
+			require.Equal(t, data, string(got.Tx))
+		}
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		data := RawTxData([]byte("bad"))
+		got, err := DecodeTx(TxResult{ID: 7, Tx: data}, upperDecoder{})
+		require.EqualError(t, err, "decode tx 7: boom")
+		require.Equal(t, data, got.Tx)
+	})
+}
+
+func TestQuery_DecodedTx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 3, []Tx{{Data: RawTxData([]byte("custom"))}}))
+
+	q := NewQuery(db)
+	txs, err := q.Transactions(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+
+	got, err := q.DecodedTx(ctx, txs[0].ID, upperDecoder{})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, got.Height)
+	require.Equal(t, `{"decoded":"CUSTOM"}`, string(got.Tx))
+
+	_, err = q.DecodedTx(ctx, txs[0].ID+100, upperDecoder{})
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// dialect is the SQL dialect of a database backend.
+// Statements are written for sqlite, with ? placeholders, and rebound for other dialects.
+// The zero value is sqlite.
+type dialect int
+
+const (
+	dialectSQLite dialect = iota
+	dialectPostgres
+)
+
+// execQueryer is satisfied by *sql.DB and *sql.Tx.
+type execQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rebind replaces the ? placeholders of query with the placeholders of the dialect.
+// The query must not contain ? other than placeholders, e.g. in string literals.
+func (d dialect) rebind(query string) string {
+	if d != dialectPostgres {
+		return query
+	}
+	var (
+		b strings.Builder
+		n int
+	)
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+func (d dialect) exec(ctx context.Context, db execQueryer, query string, args ...any) (sql.Result, error) {
+	return db.ExecContext(ctx, d.rebind(query), args...)
+}
+
+// insert executes the insert statement query and returns the id of the inserted row.
+func (d dialect) insert(ctx context.Context, db execQueryer, query string, args ...any) (int64, error) {
+	if d == dialectPostgres {
+		// lib/pq does not support LastInsertId.
+		var id int64
+		err := db.QueryRowContext(ctx, d.rebind(query)+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
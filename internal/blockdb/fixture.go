@@ -0,0 +1,455 @@
+package blockdb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GenesisFinder finds the genesis file that a chain's nodes started with.
+// If a chain implements GenesisFinder, its genesis is saved with its blocks, so that ExportTestCase includes it.
+type GenesisFinder interface {
+	FindGenesis(ctx context.Context) ([]byte, error)
+}
+
+// Files of a bundle written by ExportTestCase. Each chain's files are in a directory named by its chain ID.
+const (
+	bundleTestCaseFile = "test_case.json"
+	bundleConfigFile   = "config.json"
+	bundleGenesisFile  = "genesis.json"
+	bundleBlocksFile   = "blocks.jsonl" // One block per line, ordered by height.
+)
+
+// Bundle is a test case exported by ExportTestCase, as loaded by LoadBundle.
+type Bundle struct {
+	Name      string
+	GitSha    string
+	CreatedAt time.Time
+	Chains    []BundleChain
+}
+
+// Chain returns the chain of the bundle with chainID, e.g. osmosis-1001.
+func (b *Bundle) Chain(chainID string) (BundleChain, bool) {
+	for _, c := range b.Chains {
+		if c.ChainID == chainID {
+			return c, true
+		}
+	}
+	return BundleChain{}, false
+}
+
+// BundleChain is a chain of a Bundle.
+type BundleChain struct {
+	ChainID   string
+	ChainType string
+
+	// The config and genesis saved with SaveConfig and SaveGenesis, or nil if not saved.
+	Config  json.RawMessage
+	Genesis []byte
+
+	Blocks []BundleBlock
+}
+
+// BundleBlock is a block of a BundleChain.
+// The Block's Validators is the validator set at the block if it changed since the previous block, otherwise nil.
+// Its Image is always empty.
+type BundleBlock struct {
+	Height    uint64
+	CreatedAt time.Time
+	Block
+}
+
+// bundleTestCase is the JSON representation of test_case.json.
+type bundleTestCase struct {
+	Name      string        `json:"name"`
+	GitSha    string        `json:"git_sha"`
+	CreatedAt time.Time     `json:"created_at"`
+	Chains    []bundleChain `json:"chains"`
+}
+
+type bundleChain struct {
+	ChainID   string `json:"chain_id"`
+	ChainType string `json:"chain_type"`
+}
+
+// bundleBlock is the JSON representation of a line of blocks.jsonl.
+// Tx data is saved as a string, so that it is exported byte for byte.
+type bundleBlock struct {
+	Height           uint64        `json:"height"`
+	CreatedAt        time.Time     `json:"created_at"`
+	ProposerAddress  string        `json:"proposer_address,omitempty"`
+	ValidatorsHash   string        `json:"validators_hash,omitempty"`
+	Version          string        `json:"version,omitempty"`
+	Validators       []Validator   `json:"validators,omitempty"`
+	BeginBlockEvents []bundleEvent `json:"begin_block_events,omitempty"`
+	Txs              []bundleTx    `json:"txs,omitempty"`
+	EndBlockEvents   []bundleEvent `json:"end_block_events,omitempty"`
+}
+
+type bundleTx struct {
+	Hash      string        `json:"hash,omitempty"`
+	Data      string        `json:"data"`
+	GasWanted int64         `json:"gas_wanted,omitempty"`
+	GasUsed   int64         `json:"gas_used,omitempty"`
+	Code      uint32        `json:"code,omitempty"`
+	Codespace string        `json:"codespace,omitempty"`
+	RawLog    string        `json:"raw_log,omitempty"`
+	Events    []bundleEvent `json:"events,omitempty"`
+}
+
+type bundleEvent struct {
+	Type       string           `json:"type"`
+	Attributes []EventAttribute `json:"attributes"`
+}
+
+// ExportTestCase writes the test case of a sqlite db to dir as a bundle of files, creating dir if necessary:
+// the test case, and for each chain its config, genesis, and blocks with their transactions and events.
+// Load the bundle with LoadBundle, e.g. to reproduce a failed CI run locally.
+// testCaseID is the test case primary key "test_case.id".
+func ExportTestCase(ctx context.Context, db *sql.DB, testCaseID int64, dir string) error {
+	var (
+		tc        bundleTestCase
+		createdAt string
+	)
+	err := db.QueryRowContext(ctx, `SELECT name, git_sha, created_at FROM test_case WHERE id = ?`, testCaseID).
+		Scan(&tc.Name, &tc.GitSha, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("test case %d not found", testCaseID)
+	}
+	if err != nil {
+		return fmt.Errorf("query test case: %w", err)
+	}
+	if tc.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return fmt.Errorf("parse test case created_at: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT id, chain_id, chain_type, config, genesis FROM chain WHERE fk_test_id = ? ORDER BY chain_id ASC`, testCaseID)
+	if err != nil {
+		return fmt.Errorf("query chains: %w", err)
+	}
+	type chainRow struct {
+		id              int64
+		config, genesis string
+	}
+	var chains []chainRow
+	for rows.Next() {
+		var (
+			c     bundleChain
+			chain chainRow
+		)
+		if err := rows.Scan(&chain.id, &c.ChainID, &c.ChainType, &chain.config, &chain.genesis); err != nil {
+			rows.Close()
+			return err
+		}
+		if c.ChainID == "." || c.ChainID == ".." || strings.ContainsAny(c.ChainID, `/\`) {
+			rows.Close()
+			return fmt.Errorf("chain id %q is not a valid directory name", c.ChainID)
+		}
+		tc.Chains = append(tc.Chains, c)
+		chains = append(chains, chain)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(tc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, bundleTestCaseFile), b, 0o644); err != nil {
+		return err
+	}
+
+	for i, chain := range chains {
+		chainDir := filepath.Join(dir, tc.Chains[i].ChainID)
+		if err := os.MkdirAll(chainDir, 0o755); err != nil {
+			return err
+		}
+		for file, content := range map[string]string{bundleConfigFile: chain.config, bundleGenesisFile: chain.genesis} {
+			if content == "" {
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(chainDir, file), []byte(content), 0o644); err != nil {
+				return err
+			}
+		}
+		blocks, err := exportBlocks(ctx, db, chain.id)
+		if err != nil {
+			return fmt.Errorf("export blocks of chain %s: %w", tc.Chains[i].ChainID, err)
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		for _, block := range blocks {
+			if err := enc.Encode(block); err != nil {
+				return err
+			}
+		}
+		if err := os.WriteFile(filepath.Join(chainDir, bundleBlocksFile), buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportBlocks returns the blocks of the chain ordered by height.
+func exportBlocks(ctx context.Context, db *sql.DB, chainPkey int64) ([]*bundleBlock, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, height, created_at, proposer_address, validators_hash, version
+    FROM block WHERE fk_chain_id = ? ORDER BY height ASC`, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query blocks: %w", err)
+	}
+	var blocks []*bundleBlock
+	byID := make(map[int64]*bundleBlock)
+	for rows.Next() {
+		var (
+			id        int64
+			block     bundleBlock
+			createdAt string
+		)
+		if err := rows.Scan(&id, &block.Height, &createdAt, &block.ProposerAddress, &block.ValidatorsHash, &block.Version); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if block.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("parse block created_at: %w", err)
+		}
+		blocks = append(blocks, &block)
+		byID[id] = &block
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT tx.id, tx.fk_block_id, tx.data, tx.data_encoding, tx.hash, tx.gas_wanted, tx.gas_used, tx.code, tx.codespace, tx.raw_log
+    FROM tx INNER JOIN block ON tx.fk_block_id = block.id
+    WHERE block.fk_chain_id = ? ORDER BY tx.id ASC`, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query txs: %w", err)
+	}
+	// The block and index within the block of each tx.
+	type txPos struct {
+		block *bundleBlock
+		i     int
+	}
+	txs := make(map[int64]txPos)
+	for rows.Next() {
+		var (
+			id, blockID int64
+			tx          bundleTx
+			data        []byte
+			encoding    string
+		)
+		if err := rows.Scan(&id, &blockID, &data, &encoding, &tx.Hash, &tx.GasWanted, &tx.GasUsed, &tx.Code, &tx.Codespace, &tx.RawLog); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		// Bundles are plain JSON, so compressed txs are exported decompressed.
+		if data, err = decodeTxData(data, encoding); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("tx %d: %w", id, err)
+		}
+		tx.Data = string(data)
+		block := byID[blockID]
+		block.Txs = append(block.Txs, tx)
+		txs[id] = txPos{block: block, i: len(block.Txs) - 1}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT abci_event.fk_block_id, abci_event.fk_tx_id, abci_event.phase, abci_event.type, abci_event.attributes
+    FROM abci_event INNER JOIN block ON abci_event.fk_block_id = block.id
+    WHERE block.fk_chain_id = ? ORDER BY abci_event.id ASC`, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	for rows.Next() {
+		var (
+			blockID int64
+			txID    sql.NullInt64
+			phase   string
+			e       bundleEvent
+			attrs   string
+		)
+		if err := rows.Scan(&blockID, &txID, &phase, &e.Type, &attrs); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(attrs), &e.Attributes); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("decode event attributes: %w", err)
+		}
+		block := byID[blockID]
+		switch phase {
+		case abciEventBeginBlock:
+			block.BeginBlockEvents = append(block.BeginBlockEvents, e)
+		case abciEventEndBlock:
+			block.EndBlockEvents = append(block.EndBlockEvents, e)
+		case abciEventTx:
+			if pos, ok := txs[txID.Int64]; ok {
+				tx := &pos.block.Txs[pos.i]
+				tx.Events = append(tx.Events, e)
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var updated []uint64
+	rows, err = db.QueryContext(ctx, `SELECT DISTINCT block.height FROM validator_update
+    INNER JOIN block ON validator_update.fk_block_id = block.id
+    WHERE block.fk_chain_id = ? ORDER BY block.height ASC`, chainPkey)
+	if err != nil {
+		return nil, fmt.Errorf("query validator updates: %w", err)
+	}
+	for rows.Next() {
+		var height uint64
+		if err := rows.Scan(&height); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		updated = append(updated, height)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	byHeight := make(map[uint64]*bundleBlock, len(blocks))
+	for _, block := range blocks {
+		byHeight[block.Height] = block
+	}
+	for _, height := range updated {
+		vals, err := validatorSet(ctx, db, dialectSQLite, chainPkey, int64(height))
+		if err != nil {
+			return nil, fmt.Errorf("query validator set: %w", err)
+		}
+		block := byHeight[height]
+		block.Validators = make([]Validator, len(vals))
+		for i, v := range vals {
+			block.Validators[i] = Validator{Address: v.Address, VotingPower: v.VotingPower}
+		}
+	}
+
+	return blocks, nil
+}
+
+// LoadBundle loads a bundle written by ExportTestCase.
+func LoadBundle(dir string) (*Bundle, error) {
+	b, err := os.ReadFile(filepath.Join(dir, bundleTestCaseFile))
+	if err != nil {
+		return nil, err
+	}
+	var tc bundleTestCase
+	if err := json.Unmarshal(b, &tc); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", bundleTestCaseFile, err)
+	}
+
+	bundle := &Bundle{
+		Name:      tc.Name,
+		GitSha:    tc.GitSha,
+		CreatedAt: tc.CreatedAt,
+		Chains:    make([]BundleChain, len(tc.Chains)),
+	}
+	for i, c := range tc.Chains {
+		chain, err := loadBundleChain(filepath.Join(dir, c.ChainID))
+		if err != nil {
+			return nil, fmt.Errorf("load chain %s: %w", c.ChainID, err)
+		}
+		chain.ChainID = c.ChainID
+		chain.ChainType = c.ChainType
+		bundle.Chains[i] = chain
+	}
+	return bundle, nil
+}
+
+func loadBundleChain(dir string) (BundleChain, error) {
+	var chain BundleChain
+	for file, dst := range map[string]*[]byte{bundleConfigFile: (*[]byte)(&chain.Config), bundleGenesisFile: &chain.Genesis} {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return chain, err
+		}
+		*dst = b
+	}
+
+	f, err := os.Open(filepath.Join(dir, bundleBlocksFile))
+	if err != nil {
+		return chain, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	// Blocks may be much larger than the default limit of a line.
+	scanner.Buffer(nil, 1<<30)
+	for scanner.Scan() {
+		var b bundleBlock
+		if err := json.Unmarshal(scanner.Bytes(), &b); err != nil {
+			return chain, fmt.Errorf("decode %s: %w", bundleBlocksFile, err)
+		}
+		chain.Blocks = append(chain.Blocks, BundleBlock{
+			Height:    b.Height,
+			CreatedAt: b.CreatedAt,
+			Block: Block{
+				Txs:              loadBundleTxs(b.Txs),
+				BeginBlockEvents: loadBundleEvents(b.BeginBlockEvents),
+				EndBlockEvents:   loadBundleEvents(b.EndBlockEvents),
+				ProposerAddress:  b.ProposerAddress,
+				ValidatorsHash:   b.ValidatorsHash,
+				Validators:       b.Validators,
+				Version:          b.Version,
+			},
+		})
+	}
+	return chain, scanner.Err()
+}
+
+func loadBundleTxs(txs []bundleTx) []Tx {
+	if txs == nil {
+		return nil
+	}
+	out := make([]Tx, len(txs))
+	for i, tx := range txs {
+		out[i] = Tx{
+			Data:      []byte(tx.Data),
+			Hash:      tx.Hash,
+			GasWanted: tx.GasWanted,
+			GasUsed:   tx.GasUsed,
+			Code:      tx.Code,
+			Codespace: tx.Codespace,
+			Log:       tx.RawLog,
+			Events:    loadBundleEvents(tx.Events),
+		}
+	}
+	return out
+}
+
+func loadBundleEvents(events []bundleEvent) []Event {
+	if events == nil {
+		return nil
+	}
+	out := make([]Event, len(events))
+	for i, e := range events {
+		out[i] = Event{Type: e.Type, Attributes: e.Attributes}
+	}
+	return out
+}
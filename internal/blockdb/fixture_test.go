@@ -0,0 +1,110 @@
+package blockdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTestCase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "TestExport", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	const genesis = `{"chain_id": "chain-a",  "app_state": {}}`
+	require.NoError(t, chainA.SaveConfig(ctx, []byte(`{"Denom":"uatom"}`)))
+	require.NoError(t, chainA.SaveGenesis(ctx, []byte(genesis)))
+	require.Error(t, chainA.SaveConfig(ctx, []byte(`not json`)))
+
+	blocks := []Block{
+		{
+			BeginBlockEvents: []Event{{Type: "mint", Attributes: []EventAttribute{{Key: "amount", Value: "10"}}}},
+			ProposerAddress:  "AA",
+			ValidatorsHash:   "V1",
+			Validators:       []Validator{{Address: "AA", VotingPower: 10}, {Address: "BB", VotingPower: 5}},
+			Version:          "v1.0.0",
+		},
+		{
+			Txs: []Tx{
+				{
+					Data:      []byte(`{"body": {"memo": "<first>"}}`),
+					Hash:      "A1",
+					GasWanted: 200,
+					GasUsed:   150,
+					Events:    []Event{{Type: "transfer", Attributes: []EventAttribute{{Key: "sender", Value: "me"}}}},
+				},
+				{Data: RawTxData([]byte{0, 1, 2}), Hash: "B2", Code: 11, Codespace: "sdk", Log: "out of gas"},
+			},
+			EndBlockEvents:  []Event{{Type: "complete_unbonding", Attributes: []EventAttribute{}}},
+			ProposerAddress: "BB",
+			ValidatorsHash:  "V1",
+			Version:         "v1.0.0",
+		},
+		{
+			ProposerAddress: "AA",
+			ValidatorsHash:  "V2",
+			Validators:      []Validator{{Address: "AA", VotingPower: 10}},
+			Version:         "v2.0.0",
+		},
+	}
+	for i, block := range blocks {
+		require.NoError(t, chainA.SaveFullBlock(ctx, uint64(i+1), block))
+	}
+	require.NoError(t, chainB.SaveBlock(ctx, 1, nil))
+
+	dir := filepath.Join(t.TempDir(), "bundle")
+	require.NoError(t, ExportTestCase(ctx, db, tc.ID(), dir))
+
+	// The genesis is exported byte for byte.
+	b, err := os.ReadFile(filepath.Join(dir, "chain-a", "genesis.json"))
+	require.NoError(t, err)
+	require.Equal(t, genesis, string(b))
+	require.NoFileExists(t, filepath.Join(dir, "chain-b", "genesis.json"))
+
+	bundle, err := LoadBundle(dir)
+	require.NoError(t, err)
+	require.Equal(t, "TestExport", bundle.Name)
+	require.Equal(t, "abc123", bundle.GitSha)
+	require.False(t, bundle.CreatedAt.IsZero())
+	require.Len(t, bundle.Chains, 2)
+
+	gotA, ok := bundle.Chain("chain-a")
+	require.True(t, ok)
+	require.Equal(t, "cosmos", gotA.ChainType)
+	require.JSONEq(t, `{"Denom":"uatom"}`, string(gotA.Config))
+	require.Equal(t, genesis, string(gotA.Genesis))
+	require.Len(t, gotA.Blocks, len(blocks))
+	for i, want := range blocks {
+		got := gotA.Blocks[i]
+		require.EqualValues(t, i+1, got.Height)
+		require.False(t, got.CreatedAt.IsZero())
+		require.Equal(t, want, got.Block, "block %d", got.Height)
+	}
+
+	gotB, ok := bundle.Chain("chain-b")
+	require.True(t, ok)
+	require.Nil(t, gotB.Config)
+	require.Nil(t, gotB.Genesis)
+	require.Len(t, gotB.Blocks, 1)
+
+	_, ok = bundle.Chain("chain-c")
+	require.False(t, ok)
+
+	require.ErrorContains(t, ExportTestCase(ctx, db, 999, t.TempDir()), "not found")
+
+	_, err = LoadBundle(t.TempDir())
+	require.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package blockdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsWindow is how recently a chain must have saved a block to be reported by MetricsCollector,
+// and the window over which its block rate is averaged.
+const metricsWindow = 5 * time.Minute
+
+// ChainStatsResult summarizes the blocks saved by a chain.
+type ChainStatsResult struct {
+	TestCaseID   int64
+	TestCaseName string
+	ChainPKey    int64  // chain primary key
+	ChainID      string // E.g. osmosis-1001
+	Height       int64  // Height of the latest block.
+	Blocks       int64
+	Txs          int64
+	RecentBlocks int64 // Blocks saved at or after the time passed to ChainStats.
+}
+
+// ChainStats returns stats for each chain that saved a block at or after since, e.g. chains of running tests,
+// ordered by test case and chain.
+func (q *Query) ChainStats(ctx context.Context, since time.Time) ([]ChainStatsResult, error) {
+	sinceStr := since.UTC().Format(time.RFC3339)
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        test_case.id
+        , test_case.name
+        , chain.id
+        , chain.chain_id
+        , MAX(block.height)
+        , COUNT(block.id)
+        , (SELECT COUNT(*) FROM tx INNER JOIN block AS txblock ON tx.fk_block_id = txblock.id WHERE txblock.fk_chain_id = chain.id)
+        , SUM(block.created_at >= ?)
+    FROM chain
+    INNER JOIN test_case ON chain.fk_test_id = test_case.id
+    INNER JOIN block ON block.fk_chain_id = chain.id
+    GROUP BY chain.id
+    HAVING MAX(block.created_at) >= ?
+    ORDER BY test_case.id ASC, chain.chain_id ASC`, sinceStr, sinceStr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []ChainStatsResult
+	for rows.Next() {
+		var res ChainStatsResult
+		if err := rows.Scan(
+			&res.TestCaseID,
+			&res.TestCaseName,
+			&res.ChainPKey,
+			&res.ChainID,
+			&res.Height,
+			&res.Blocks,
+			&res.Txs,
+			&res.RecentBlocks,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// MetricsCollector is a prometheus.Collector reporting stats of the chains in a block database
+// that saved a block in the last 5 minutes, e.g. to drive dashboards of soak tests.
+// The database is queried on every scrape, so the metrics follow a database written by a running test.
+//
+// The metrics, labeled by test_case_id, test_case, and chain_id, are:
+//   - interchaintest_blockdb_block_height, a gauge of the height of the latest block
+//   - interchaintest_blockdb_blocks_total, a counter of saved blocks
+//   - interchaintest_blockdb_block_rate, a gauge of blocks saved per second over the last 5 minutes
+//   - interchaintest_blockdb_txs_total, a counter of saved txs
+//   - interchaintest_blockdb_packet_backlog, a gauge of IBC packets sent by the chain without an observed ack or timeout
+type MetricsCollector struct {
+	q   *Query
+	now func() time.Time
+
+	height, blocks, blockRate, txs, packetBacklog *prometheus.Desc
+}
+
+// NewMetricsCollector returns a MetricsCollector querying through q.
+func NewMetricsCollector(q *Query) *MetricsCollector {
+	labels := []string{"test_case_id", "test_case", "chain_id"}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("interchaintest", "blockdb", name), help, labels, nil)
+	}
+	return &MetricsCollector{
+		q:   q,
+		now: time.Now,
+
+		height:        desc("block_height", "Height of the latest block saved by the chain."),
+		blocks:        desc("blocks_total", "Count of blocks saved by the chain."),
+		blockRate:     desc("block_rate", "Blocks saved by the chain per second, averaged over the last 5 minutes."),
+		txs:           desc("txs_total", "Count of txs saved by the chain."),
+		packetBacklog: desc("packet_backlog", "IBC packets sent by the chain without an observed ack or timeout."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.height
+	ch <- c.blocks
+	ch <- c.blockRate
+	ch <- c.txs
+	ch <- c.packetBacklog
+}
+
+// Collect implements prometheus.Collector.
+// A failed query is reported as an invalid metric, failing the scrape.
+func (c *MetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stats, err := c.q.ChainStats(ctx, c.now().Add(-metricsWindow))
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.height, fmt.Errorf("query chain stats: %w", err))
+		return
+	}
+
+	backlog := make(map[int64]int) // By chain primary key.
+	for i, s := range stats {
+		if i > 0 && stats[i-1].TestCaseID == s.TestCaseID {
+			continue
+		}
+		packets, err := c.q.Packets(ctx, s.TestCaseID)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.packetBacklog, fmt.Errorf("query packets of test case %d: %w", s.TestCaseID, err))
+			return
+		}
+		for _, p := range packets {
+			if !p.AckHeight.Valid && !p.TimeoutHeight.Valid {
+				backlog[p.ChainPKey]++
+			}
+		}
+	}
+
+	for _, s := range stats {
+		labels := []string{strconv.FormatInt(s.TestCaseID, 10), s.TestCaseName, s.ChainID}
+		ch <- prometheus.MustNewConstMetric(c.height, prometheus.GaugeValue, float64(s.Height), labels...)
+		ch <- prometheus.MustNewConstMetric(c.blocks, prometheus.CounterValue, float64(s.Blocks), labels...)
+		ch <- prometheus.MustNewConstMetric(c.blockRate, prometheus.GaugeValue, float64(s.RecentBlocks)/metricsWindow.Seconds(), labels...)
+		ch <- prometheus.MustNewConstMetric(c.txs, prometheus.CounterValue, float64(s.Txs), labels...)
+		ch <- prometheus.MustNewConstMetric(c.packetBacklog, prometheus.GaugeValue, float64(backlog[s.ChainPKey]), labels...)
+	}
+}
+
+// NewMetricsHandler returns a handler serving the metrics of a MetricsCollector querying through q
+// in the Prometheus exposition format.
+func NewMetricsHandler(q *Query) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewMetricsCollector(q))
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
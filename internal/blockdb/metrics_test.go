@@ -0,0 +1,132 @@
+package blockdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	old, err := CreateTestCase(ctx, db, "OldTest", "abc123")
+	require.NoError(t, err)
+	oldChain, err := old.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, oldChain.SaveBlock(ctx, 1, nil))
+
+	tc, err := CreateTestCase(ctx, db, "SoakTest", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-c", "cosmos")
+	require.NoError(t, err)
+
+	// Sequence 1 of chain A is acknowledged, 2 times out, 3 is in flight. Chain B's sequence 1 is in flight.
+	require.NoError(t, chainA.SaveBlock(ctx, 10, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventSend, "1", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventSend, "2", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventSend, "3", "channel-0", "channel-0"),
+	}}}))
+	require.NoError(t, chainA.SaveBlock(ctx, 11, nil))
+	require.NoError(t, chainA.SaveBlock(ctx, 12, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventAck, "1", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventTimeout, "2", "channel-0", "channel-0"),
+	}}}))
+	require.NoError(t, chainB.SaveBlock(ctx, 20, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventRecv, "1", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventSend, "1", "channel-0", "channel-0"),
+	}}, {Data: []byte(`{}`)}}))
+
+	// The old test case stopped saving blocks, and chain A's first block is older than the rate's window.
+	hourAgo := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	_, err = db.Exec(`UPDATE block SET created_at = ? WHERE fk_chain_id = ? OR (fk_chain_id = ? AND height = 10)`,
+		hourAgo, oldChain.id, chainA.id)
+	require.NoError(t, err)
+
+	c := NewMetricsCollector(NewQuery(db))
+	require.Equal(t, 10, testutil.CollectAndCount(c))
+
+	err = testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP interchaintest_blockdb_block_height Height of the latest block saved by the chain.
+# TYPE interchaintest_blockdb_block_height gauge
+interchaintest_blockdb_block_height{chain_id="chain-a",test_case="SoakTest",test_case_id="2"} 12
+interchaintest_blockdb_block_height{chain_id="chain-b",test_case="SoakTest",test_case_id="2"} 20
+# HELP interchaintest_blockdb_block_rate Blocks saved by the chain per second, averaged over the last 5 minutes.
+# TYPE interchaintest_blockdb_block_rate gauge
+interchaintest_blockdb_block_rate{chain_id="chain-a",test_case="SoakTest",test_case_id="2"} 0.006666666666666667
+interchaintest_blockdb_block_rate{chain_id="chain-b",test_case="SoakTest",test_case_id="2"} 0.0033333333333333335
+# HELP interchaintest_blockdb_blocks_total Count of blocks saved by the chain.
+# TYPE interchaintest_blockdb_blocks_total counter
+interchaintest_blockdb_blocks_total{chain_id="chain-a",test_case="SoakTest",test_case_id="2"} 3
+interchaintest_blockdb_blocks_total{chain_id="chain-b",test_case="SoakTest",test_case_id="2"} 1
+# HELP interchaintest_blockdb_packet_backlog IBC packets sent by the chain without an observed ack or timeout.
+# TYPE interchaintest_blockdb_packet_backlog gauge
+interchaintest_blockdb_packet_backlog{chain_id="chain-a",test_case="SoakTest",test_case_id="2"} 1
+interchaintest_blockdb_packet_backlog{chain_id="chain-b",test_case="SoakTest",test_case_id="2"} 1
+# HELP interchaintest_blockdb_txs_total Count of txs saved by the chain.
+# TYPE interchaintest_blockdb_txs_total counter
+interchaintest_blockdb_txs_total{chain_id="chain-a",test_case="SoakTest",test_case_id="2"} 2
+interchaintest_blockdb_txs_total{chain_id="chain-b",test_case="SoakTest",test_case_id="2"} 2
+`))
+	require.NoError(t, err)
+
+	// Later, every chain stopped saving blocks.
+	c.now = func() time.Time { return time.Now().Add(time.Hour) }
+	require.Zero(t, testutil.CollectAndCount(c))
+}
+
+func TestNewMetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "SoakTest", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 7, nil))
+
+	srv := httptest.NewServer(NewMetricsHandler(NewQuery(db)))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `interchaintest_blockdb_block_height{chain_id="chain-a",test_case="SoakTest",test_case_id="1"} 7`)
+
+	// Failed queries fail the scrape.
+	require.NoError(t, db.Close())
+	res, err = http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func TestServeMetrics(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "blocks.db")
+	require.Error(t, ServeMetrics(context.Background(), dbPath, "localhost:0"), "database must exist")
+}
@@ -1,6 +1,7 @@
 package blockdb
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -9,8 +10,43 @@ import (
 	"modernc.org/sqlite"
 )
 
-// Migrate migrates db in an idempotent manner.
-// If an error is returned, it's acceptable to delete the database and start over.
+// ErrSchemaTooNew is returned by Migrate if the database was migrated by a newer version of interchaintest.
+var ErrSchemaTooNew = errors.New("database schema is newer than supported")
+
+// schemaMigration is a versioned step of migrating the schema.
+type schemaMigration struct {
+	Name    string
+	Migrate func(tx *sql.Tx) error
+}
+
+// schemaMigrations are applied in order. A database's schema version, stored as its user_version pragma,
+// is the number of migrations applied to it, so a database written by an older version only runs the newer migrations.
+// Append a migration to change the schema; never edit, remove, or reorder a migration once released.
+var schemaMigrations = []schemaMigration{
+	{"baseline", migrateBaseline},
+	{"chain upgrades", migrateChainUpgrades},
+	{"chain fixtures", migrateChainFixtures},
+	{"bookmarks", migrateBookmarks},
+	{"tx results", migrateTxResults},
+	{"tx compression", migrateTxCompression},
+	{"test case finish", migrateTestCaseFinish},
+}
+
+// SchemaVersion returns the version of the db's schema, i.e. the number of migrations applied to it.
+// Databases written before migrations were versioned, or not yet migrated, are version 0.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version)
+	return version, err
+}
+
+// LatestSchemaVersion is the version of the schema after Migrate.
+func LatestSchemaVersion() int { return len(schemaMigrations) }
+
+// Migrate applies the migrations not yet applied to db, then recreates the views.
+// It is safe to call many times, and databases written by older versions of interchaintest are migrated
+// to the latest schema. If the database was written by a newer version, ErrSchemaTooNew is returned.
+// Otherwise, if an error is returned, it's acceptable to delete the database and start over.
 // The basic ERD is as follows:
 //
 //	┌────────────────────┐          ┌────────────────────┐         ┌────────────────────┐          ┌────────────────────┐
@@ -23,28 +59,19 @@ import (
 //
 // The gitSha ensures we can trace back to the version of the codebase that produced the schema.
 // Warning: Typical best practice wraps each migration step into its own transaction. For simplicity given
-// this is an embedded database, we omit transactions.
+// this is an embedded database, all migrations run in a single transaction.
 func Migrate(db *sql.DB, gitSha string) error {
-	// If a timeout is encountered, sleep and try again,
-	// up until the provided number of milliseconds.
-	// A 3000ms timeout worked fine on my workstation but failed the concurrency test on CI.
-	//
-	// Setting this makes it practical to have multiple test instances
-	// writing to the same database file.
-	//
-	// https://www.sqlite.org/pragma.html#pragma_busy_timeout
-	_, err := db.Exec(`PRAGMA busy_timeout = 4000`)
-	if err != nil {
-		return fmt.Errorf("pragma busy_timeout: %w", err)
-	}
+	return migrate(db, gitSha, schemaMigrations)
+}
 
-	// Only setting the busy_timeout pragma is insufficient to get the concurrency test to pass.
+func migrate(db *sql.DB, gitSha string, migrations []schemaMigration) error {
+	// Only setting the busy_timeout pragma, see ConnectDB, is insufficient to get the concurrency test to pass.
 	// The WAL journal mode, supported by SQLite version 3.7.0 (2010-07-21) or later,
 	// is more forgiving about concurrent reads and writes:
 	// "WAL provides more concurrency as readers do not block writers and a writer does not block readers."
 	//
 	// https://www.sqlite.org/pragma.html#pragma_journal_mode
-	_, err = db.Exec(`PRAGMA journal_mode = WAL`)
+	_, err := db.Exec(`PRAGMA journal_mode = WAL`)
 	if err != nil {
 		return fmt.Errorf("pragma journal_mode: %w", err)
 	}
@@ -81,7 +108,41 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("upsert schema_version with git sha %s: %w", gitSha, err)
 	}
 
-	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS test_case (
+	var version int
+	if err := tx.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("pragma user_version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("%w: database schema version %d, latest supported version %d", ErrSchemaTooNew, version, len(migrations))
+	}
+	for i := version; i < len(migrations); i++ {
+		m := migrations[i]
+		if err := m.Migrate(tx); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", i+1, m.Name, err)
+		}
+		// The pragma does not accept bound parameters.
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			return fmt.Errorf("pragma user_version: %w", err)
+		}
+	}
+
+	// Creating views should be last migration step.
+	if err := upsertViews(tx); err != nil {
+		// Error already wrapped.
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing migrations: %w", err)
+	}
+
+	return nil
+}
+
+// migrateBaseline migrates a database written before migrations were versioned, in an idempotent manner,
+// because such a database may have the schema of any earlier version.
+func migrateBaseline(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS test_case (
     id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL CHECK ( length(name) > 0 ),
     git_sha TEXT NOT NULL CHECK ( length(git_sha) > 0 ),
@@ -126,6 +187,64 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 	if errIgnoreDuplicateColumn(err, "chain_type") != nil {
 		return fmt.Errorf("alter table chain add chain_type: %w", err)
 	}
+	// Empty if unknown, e.g. for txs saved before the column existed.
+	_, err = tx.Exec(`ALTER TABLE tx ADD COLUMN hash TEXT NOT NULL DEFAULT ''`)
+	if errIgnoreDuplicateColumn(err, "hash") != nil {
+		return fmt.Errorf("alter table tx add hash: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX IF NOT EXISTS tx_hash ON tx(hash)`)
+	if err != nil {
+		return fmt.Errorf("create index tx_hash: %w", err)
+	}
+	// Zero if unknown, e.g. for txs saved before the columns existed.
+	_, err = tx.Exec(`ALTER TABLE tx ADD COLUMN gas_wanted INTEGER NOT NULL DEFAULT 0`)
+	if errIgnoreDuplicateColumn(err, "gas_wanted") != nil {
+		return fmt.Errorf("alter table tx add gas_wanted: %w", err)
+	}
+	_, err = tx.Exec(`ALTER TABLE tx ADD COLUMN gas_used INTEGER NOT NULL DEFAULT 0`)
+	if errIgnoreDuplicateColumn(err, "gas_used") != nil {
+		return fmt.Errorf("alter table tx add gas_used: %w", err)
+	}
+	// Empty if unknown, e.g. for blocks saved before the columns existed.
+	_, err = tx.Exec(`ALTER TABLE block ADD COLUMN proposer_address TEXT NOT NULL DEFAULT ''`)
+	if errIgnoreDuplicateColumn(err, "proposer_address") != nil {
+		return fmt.Errorf("alter table block add proposer_address: %w", err)
+	}
+	_, err = tx.Exec(`ALTER TABLE block ADD COLUMN validators_hash TEXT NOT NULL DEFAULT ''`)
+	if errIgnoreDuplicateColumn(err, "validators_hash") != nil {
+		return fmt.Errorf("alter table block add validators_hash: %w", err)
+	}
+	// Changes to a chain's validator set, saved with the first block having the change.
+	// The validator set at a height is the latest update of each validator at or before the height,
+	// excluding validators whose latest voting power is 0, i.e. that left the set.
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS validator_update (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    address TEXT NOT NULL CHECK (length(address) > 0),
+    voting_power INTEGER NOT NULL,
+    fk_block_id INTEGER NOT NULL,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table validator_update: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX IF NOT EXISTS validator_update_block ON validator_update(fk_block_id)`)
+	if err != nil {
+		return fmt.Errorf("create index validator_update_block: %w", err)
+	}
+
+	// Exported app state of a chain at a height, one row per module, to compare state across heights.
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS state_export (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    height INTEGER NOT NULL CHECK (height > 0),
+    module TEXT NOT NULL CHECK (length(module) > 0),
+    data TEXT NOT NULL CHECK (length(data) > 0),
+    fk_chain_id INTEGER NOT NULL,
+    FOREIGN KEY(fk_chain_id) REFERENCES chain(id) ON DELETE CASCADE,
+    UNIQUE(height,module,fk_chain_id)
+)`)
+	if err != nil {
+		return fmt.Errorf("create table state_export: %w", err)
+	}
 
 	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS tendermint_event (
     id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
@@ -148,16 +267,287 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
-	// Creating views should be last migration step.
-	if err := upsertViews(tx); err != nil {
-		// Error already wrapped.
-		return err
+	// Full-text index over tx data, an external content table kept in sync with tx through triggers.
+	// Databases created before the index existed are backfilled once, when the index is created.
+	//
+	// https://www.sqlite.org/fts5.html#external_content_tables
+	var ftsCount int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'tx_fts'`).Scan(&ftsCount)
+	if err != nil {
+		return fmt.Errorf("check table tx_fts: %w", err)
+	}
+	_, err = tx.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS tx_fts USING fts5(data, content='tx', content_rowid='id')`)
+	if err != nil {
+		return fmt.Errorf("create table tx_fts: %w", err)
+	}
+	if ftsCount == 0 {
+		_, err = tx.Exec(`INSERT INTO tx_fts(tx_fts) VALUES('rebuild')`)
+		if err != nil {
+			return fmt.Errorf("rebuild tx_fts: %w", err)
+		}
+	}
+	_, err = tx.Exec(`CREATE TRIGGER IF NOT EXISTS tx_fts_insert AFTER INSERT ON tx BEGIN
+    INSERT INTO tx_fts(rowid, data) VALUES (new.id, new.data);
+END`)
+	if err != nil {
+		return fmt.Errorf("create trigger tx_fts_insert: %w", err)
+	}
+	_, err = tx.Exec(`CREATE TRIGGER IF NOT EXISTS tx_fts_delete AFTER DELETE ON tx BEGIN
+    INSERT INTO tx_fts(tx_fts, rowid, data) VALUES ('delete', old.id, old.data);
+END`)
+	if err != nil {
+		return fmt.Errorf("create trigger tx_fts_delete: %w", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing migrations: %w", err)
+	// ABCI events of all phases of a block, with attributes as a JSON array of {"key":..., "value":...} objects.
+	// Events of txs are also in the older tendermint_event tables; begin and end block events are only in this table.
+	// Databases created before the table existed are backfilled once from tendermint_event, when the table is created.
+	var abciEventCount int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'abci_event'`).Scan(&abciEventCount)
+	if err != nil {
+		return fmt.Errorf("check table abci_event: %w", err)
+	}
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS abci_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    phase TEXT NOT NULL CHECK (phase IN ('begin_block', 'tx', 'end_block')),
+    event_n INTEGER NOT NULL, -- event position within the phase, or within the tx
+    type TEXT NOT NULL,
+    attributes TEXT NOT NULL CHECK (json_valid(attributes)),
+    fk_block_id INTEGER NOT NULL,
+    fk_tx_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE,
+    FOREIGN KEY(fk_tx_id) REFERENCES tx(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table abci_event: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX IF NOT EXISTS abci_event_block ON abci_event(fk_block_id)`)
+	if err != nil {
+		return fmt.Errorf("create index abci_event_block: %w", err)
+	}
+	if abciEventCount == 0 {
+		if err := backfillABCIEvents(tx); err != nil {
+			// Error already wrapped.
+			return err
+		}
+	}
+
+	// IBC packet events, parsed from the tendermint events of txs, so a packet's lifecycle is queryable across chains.
+	// A packet is identified by its source port, source channel, and sequence.
+	// Databases created before the table existed are backfilled once, when the table is created.
+	var packetCount int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'ibc_packet_event'`).Scan(&packetCount)
+	if err != nil {
+		return fmt.Errorf("check table ibc_packet_event: %w", err)
+	}
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS ibc_packet_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    sequence INTEGER NOT NULL,
+    src_port TEXT NOT NULL,
+    src_channel TEXT NOT NULL,
+    dst_port TEXT NOT NULL,
+    dst_channel TEXT NOT NULL,
+    timeout_height TEXT NOT NULL,
+    timeout_timestamp TEXT NOT NULL,
+    fk_tx_id INTEGER,
+    FOREIGN KEY(fk_tx_id) REFERENCES tx(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table ibc_packet_event: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX IF NOT EXISTS ibc_packet_event_packet ON ibc_packet_event(src_channel, sequence)`)
+	if err != nil {
+		return fmt.Errorf("create index ibc_packet_event_packet: %w", err)
+	}
+	if packetCount == 0 {
+		if err := backfillPacketEvents(tx); err != nil {
+			// Error already wrapped.
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateChainUpgrades adds the version of the chain software to blocks,
+// and upgrades of the chain software, saved with the first block of the new version.
+func migrateChainUpgrades(tx *sql.Tx) error {
+	// Empty if unknown, e.g. for blocks saved before the column existed.
+	_, err := tx.Exec(`ALTER TABLE block ADD COLUMN version TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("alter table block add version: %w", err)
+	}
+	_, err = tx.Exec(`CREATE TABLE chain_upgrade (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    old_version TEXT NOT NULL,
+    new_version TEXT NOT NULL,
+    image TEXT NOT NULL,
+    fk_block_id INTEGER NOT NULL,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table chain_upgrade: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX chain_upgrade_block ON chain_upgrade(fk_block_id)`)
+	if err != nil {
+		return fmt.Errorf("create index chain_upgrade_block: %w", err)
 	}
+	return nil
+}
 
+// migrateChainFixtures adds the config and genesis of chains, so that a test case may be exported as a fixture.
+func migrateChainFixtures(tx *sql.Tx) error {
+	// Empty if unknown, e.g. for chains saved before the columns existed.
+	_, err := tx.Exec(`ALTER TABLE chain ADD COLUMN config TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("alter table chain add config: %w", err)
+	}
+	_, err = tx.Exec(`ALTER TABLE chain ADD COLUMN genesis TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("alter table chain add genesis: %w", err)
+	}
+	return nil
+}
+
+// migrateBookmarks adds bookmarks of blocks and txs, e.g. made while reviewing a test case in the TUI.
+func migrateBookmarks(tx *sql.Tx) error {
+	// The tx is null for bookmarks of blocks.
+	_, err := tx.Exec(`CREATE TABLE bookmark (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    height INTEGER NOT NULL CHECK (height > 0),
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    fk_chain_id INTEGER NOT NULL,
+    fk_tx_id INTEGER,
+    FOREIGN KEY(fk_chain_id) REFERENCES chain(id) ON DELETE CASCADE,
+    FOREIGN KEY(fk_tx_id) REFERENCES tx(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table bookmark: %w", err)
+	}
+	_, err = tx.Exec(`CREATE INDEX bookmark_chain ON bookmark(fk_chain_id, height)`)
+	if err != nil {
+		return fmt.Errorf("create index bookmark_chain: %w", err)
+	}
+	return nil
+}
+
+// migrateTxResults adds the result code, codespace, and raw log of txs, so that failed txs may be queried.
+func migrateTxResults(tx *sql.Tx) error {
+	// Code 0, i.e. success, for txs saved before the columns existed, as failures were not recorded.
+	_, err := tx.Exec(`ALTER TABLE tx ADD COLUMN code INTEGER NOT NULL DEFAULT 0`)
+	if err != nil {
+		return fmt.Errorf("alter table tx add code: %w", err)
+	}
+	_, err = tx.Exec(`ALTER TABLE tx ADD COLUMN codespace TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("alter table tx add codespace: %w", err)
+	}
+	_, err = tx.Exec(`ALTER TABLE tx ADD COLUMN raw_log TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("alter table tx add raw_log: %w", err)
+	}
+	return nil
+}
+
+// migrateTxCompression adds the encoding of tx data, so that large txs may be compressed; see Chain.CompressTxs.
+// Compressed data is not human-readable, so it is excluded from the full-text index.
+func migrateTxCompression(tx *sql.Tx) error {
+	// Empty if the data is not compressed.
+	_, err := tx.Exec(`ALTER TABLE tx ADD COLUMN data_encoding TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("alter table tx add data_encoding: %w", err)
+	}
+	_, err = tx.Exec(`DROP TRIGGER tx_fts_insert`)
+	if err != nil {
+		return fmt.Errorf("drop trigger tx_fts_insert: %w", err)
+	}
+	_, err = tx.Exec(`CREATE TRIGGER tx_fts_insert AFTER INSERT ON tx WHEN new.data_encoding = '' BEGIN
+    INSERT INTO tx_fts(rowid, data) VALUES (new.id, new.data);
+END`)
+	if err != nil {
+		return fmt.Errorf("create trigger tx_fts_insert: %w", err)
+	}
+	_, err = tx.Exec(`DROP TRIGGER tx_fts_delete`)
+	if err != nil {
+		return fmt.Errorf("drop trigger tx_fts_delete: %w", err)
+	}
+	_, err = tx.Exec(`CREATE TRIGGER tx_fts_delete AFTER DELETE ON tx WHEN old.data_encoding = '' BEGIN
+    INSERT INTO tx_fts(tx_fts, rowid, data) VALUES ('delete', old.id, old.data);
+END`)
+	if err != nil {
+		return fmt.Errorf("create trigger tx_fts_delete: %w", err)
+	}
+	return nil
+}
+
+// migrateTestCaseFinish adds when a test case finished, so that Prune keeps test cases still running
+// in other processes sharing the database; see TestCase.Finish.
+func migrateTestCaseFinish(tx *sql.Tx) error {
+	// Null until the test case finishes.
+	_, err := tx.Exec(`ALTER TABLE test_case ADD COLUMN finished_at TEXT`)
+	if err != nil {
+		return fmt.Errorf("alter table test_case add finished_at: %w", err)
+	}
+	return nil
+}
+
+// backfillABCIEvents copies the previously saved tendermint events of txs.
+// Begin and end block events used to be saved as events of artificial txs; they are copied without the tx.
+func backfillABCIEvents(tx *sql.Tx) error {
+	_, err := tx.Exec(`INSERT INTO abci_event(phase, event_n, type, attributes, fk_block_id, fk_tx_id)
+SELECT
+    phase
+    , (SELECT COUNT(*) FROM tendermint_event AS prev WHERE prev.fk_tx_id = event.fk_tx_id AND prev.id < event.id)
+    , event.type
+    , (SELECT json_group_array(json_object('key', key, 'value', value)) FROM (
+        SELECT key, value FROM tendermint_event_attr WHERE fk_event_id = event.id ORDER BY id ASC
+    ))
+    , fk_block_id
+    , CASE phase WHEN 'tx' THEN event.fk_tx_id END
+FROM (
+    SELECT
+        event.*
+        , tx.fk_block_id
+        , CASE
+            WHEN tx.data LIKE '{"data":"begin_block",%' THEN 'begin_block'
+            WHEN tx.data LIKE '{"data":"end_block",%' THEN 'end_block'
+            ELSE 'tx'
+        END AS phase
+    FROM tendermint_event AS event
+    INNER JOIN tx ON tx.id = event.fk_tx_id
+) AS event
+ORDER BY event.id ASC`)
+	if err != nil {
+		return fmt.Errorf("backfill abci_event: %w", err)
+	}
+	return nil
+}
+
+// backfillPacketEvents parses the IBC packet events of previously saved tendermint events, like Chain.SaveBlock does.
+func backfillPacketEvents(tx *sql.Tx) error {
+	_, err := tx.Exec(`INSERT INTO ibc_packet_event(type, sequence, src_port, src_channel, dst_port, dst_channel, timeout_height, timeout_timestamp, fk_tx_id)
+SELECT type, CAST(sequence AS INTEGER), src_port, src_channel, dst_port, dst_channel, COALESCE(timeout_height, ''), COALESCE(timeout_timestamp, ''), fk_tx_id
+FROM (
+    SELECT
+        event.id
+        , event.type
+        , event.fk_tx_id
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_sequence') AS sequence
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_src_port') AS src_port
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_src_channel') AS src_channel
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_dst_port') AS dst_port
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_dst_channel') AS dst_channel
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_timeout_height') AS timeout_height
+        , (SELECT value FROM tendermint_event_attr WHERE fk_event_id = event.id AND key = 'packet_timeout_timestamp') AS timeout_timestamp
+    FROM tendermint_event AS event
+    WHERE event.type IN ('send_packet', 'recv_packet', 'acknowledge_packet', 'timeout_packet')
+)
+WHERE sequence GLOB '[0-9]*' AND sequence NOT GLOB '*[^0-9]*' AND src_port IS NOT NULL AND src_channel IS NOT NULL AND dst_port IS NOT NULL AND dst_channel IS NOT NULL
+ORDER BY id ASC`)
+	if err != nil {
+		return fmt.Errorf("backfill ibc_packet_event: %w", err)
+	}
 	return nil
 }
 
@@ -185,6 +575,10 @@ SELECT
   , block.height as block_height
   , tx.id as tx_id
   , tx.data as tx
+  , tx.data_encoding as tx_data_encoding -- empty unless tx is compressed, e.g. zstd
+  , tx.code as tx_code
+  , tx.codespace as tx_codespace
+  , tx.raw_log as tx_raw_log
 FROM tx
 LEFT JOIN block ON tx.fk_block_id = block.id
 LEFT JOIN chain ON block.fk_chain_id = chain.id
@@ -207,6 +601,9 @@ SELECT
   , block_id
   , block_height
   , tx_id
+  , tx_code -- 0 if the tx succeeded
+  , tx_codespace
+  , tx_raw_log
   , key as msg_n -- message position within the tx
   , json_extract(value, "$.@type") as type
   , json_extract(value, "$.client_state.chain_id") as client_chain_id
@@ -237,12 +634,74 @@ SELECT
       json_extract(value, "$.packet.destination_channel")       -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
     ) as counterparty_channel_id
   , value as raw
-FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
+-- Compressed txs are not readable JSON, so have no messages.
+FROM v_tx_flattened, json_each(IIF(v_tx_flattened.tx_data_encoding = '', v_tx_flattened.tx, NULL), "$.body.messages")
 `)
 	if err != nil {
 		return fmt.Errorf("create v_cosmos_messages view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_abci_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_abci_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_abci_events AS
+SELECT
+  test_case.id as test_case_id
+  , chain.id as chain_kid
+  , chain.chain_id as chain_id
+  , block.id as block_id
+  , block.height as block_height
+  , abci_event.fk_tx_id as tx_id
+  , abci_event.id as event_id
+  , abci_event.phase as phase
+  , abci_event.event_n as event_n
+  , abci_event.type as type
+  , abci_event.attributes as attributes
+FROM abci_event
+LEFT JOIN block ON abci_event.fk_block_id = block.id
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_abci_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packet_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packet_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packet_events AS
+SELECT
+  test_case.id as test_case_id
+  , chain.id as chain_kid
+  , chain.chain_id as chain_id
+  , block.height as block_height
+  , block.created_at as block_created_at
+  , tx.id as tx_id
+  , tx.data as tx
+  , tx.data_encoding as tx_data_encoding
+  , ibc_packet_event.id as event_id
+  , ibc_packet_event.type as type
+  , ibc_packet_event.sequence as sequence
+  , ibc_packet_event.src_port as src_port
+  , ibc_packet_event.src_channel as src_channel
+  , ibc_packet_event.dst_port as dst_port
+  , ibc_packet_event.dst_channel as dst_channel
+  , ibc_packet_event.timeout_height as timeout_height
+  , ibc_packet_event.timeout_timestamp as timeout_timestamp
+FROM ibc_packet_event
+LEFT JOIN tx ON ibc_packet_event.fk_tx_id = tx.id
+LEFT JOIN block ON tx.fk_block_id = block.id
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packet_events view: %w", err)
+	}
+
 	_, err = tx.Exec(`DROP VIEW IF EXISTS v_tx_agg`)
 	if err != nil {
 		return fmt.Errorf("drop old v_tx_agg view: %w", err)
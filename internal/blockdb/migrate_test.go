@@ -1,11 +1,22 @@
 package blockdb
 
 import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+func testSchemaVersion(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var v int
+	require.NoError(t, db.QueryRow(`PRAGMA user_version`).Scan(&v))
+	return v
+}
+
 func TestMigrate(t *testing.T) {
 	t.Parallel()
 
@@ -42,4 +53,121 @@ func TestMigrate(t *testing.T) {
 
 	require.NoError(t, err)
 	require.Equal(t, "new-sha", gotSha)
+
+	require.Equal(t, len(schemaMigrations), testSchemaVersion(t, db))
+}
+
+func TestMigrate_Versioned(t *testing.T) {
+	t.Parallel()
+
+	db := migratedDB()
+	defer db.Close()
+
+	// Not idempotent, so it must only run once.
+	migrations := append(schemaMigrations[:len(schemaMigrations):len(schemaMigrations)], schemaMigration{
+		"add tx note", func(tx *sql.Tx) error {
+			_, err := tx.Exec(`ALTER TABLE tx ADD COLUMN note TEXT NOT NULL DEFAULT ''`)
+			return err
+		},
+	})
+	require.NoError(t, migrate(db, "new-sha", migrations))
+	require.NoError(t, migrate(db, "new-sha", migrations))
+	require.Equal(t, len(migrations), testSchemaVersion(t, db))
+
+	// A failed migration is rolled back.
+	failing := append(migrations[:len(migrations):len(migrations)], schemaMigration{
+		"fail", func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`CREATE TABLE rolled_back (id INTEGER)`); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		},
+	})
+	err := migrate(db, "new-sha", failing)
+	require.ErrorContains(t, err, "(fail): boom")
+	require.Equal(t, len(migrations), testSchemaVersion(t, db))
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'rolled_back'`).Scan(&n))
+	require.Zero(t, n)
+
+	// Older versions refuse to migrate the newer schema.
+	err = Migrate(db, "old-sha")
+	require.ErrorIs(t, err, ErrSchemaTooNew)
+}
+
+//go:embed testdata/schemas
+var schemaFixtures embed.FS
+
+func TestMigrate_OldSchemas(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		Fixture    string
+		ChainType  string
+		NumTxs     int
+		NumPackets int
+	}{
+		{"initial.sql", "unknown", 2, 0},
+		// Includes the artificial begin block tx.
+		{"unversioned.sql", "cosmos", 3, 1},
+	} {
+		tt := tt
+		t.Run(tt.Fixture, func(t *testing.T) {
+			t.Parallel()
+
+			fixture, err := schemaFixtures.ReadFile("testdata/schemas/" + tt.Fixture)
+			require.NoError(t, err)
+
+			db := emptyDB()
+			defer db.Close()
+			_, err = db.Exec(string(fixture))
+			require.NoError(t, err)
+			require.Zero(t, testSchemaVersion(t, db))
+
+			require.NoError(t, Migrate(db, "new-sha"))
+			require.Equal(t, len(schemaMigrations), testSchemaVersion(t, db))
+			// Migrating again is a nop.
+			require.NoError(t, Migrate(db, "new-sha"))
+
+			q := NewQuery(db)
+			tcs, err := q.RecentTestCases(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, tcs, 1)
+			tc := tcs[0]
+			require.Equal(t, "gaia-1", tc.ChainID)
+			require.Equal(t, tt.ChainType, tc.ChainType)
+			require.EqualValues(t, 2, tc.ChainHeight.Int64)
+
+			txs, err := q.Transactions(ctx, tc.ChainPKey)
+			require.NoError(t, err)
+			require.Len(t, txs, tt.NumTxs)
+			require.Empty(t, txs[0].Hash)
+
+			msgs, err := q.CosmosMessages(ctx, tc.ChainPKey)
+			require.NoError(t, err)
+			require.Len(t, msgs, 2)
+
+			results, err := q.SearchTransactions(ctx, tc.ID, "needle")
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			packets, err := q.Packets(ctx, tc.ID)
+			require.NoError(t, err)
+			require.Len(t, packets, tt.NumPackets)
+
+			// New data is saved alongside the old.
+			chain, err := FindChain(ctx, db, tc.ChainPKey)
+			require.NoError(t, err)
+			require.NoError(t, chain.SaveFullBlock(ctx, 3, Block{
+				Txs:             []Tx{{Data: []byte(`{}`), Hash: "ABC", GasWanted: 2, GasUsed: 1}},
+				ProposerAddress: "proposer",
+			}))
+			blocks, err := q.Blocks(ctx, tc.ChainPKey)
+			require.NoError(t, err)
+			require.Len(t, blocks, 3)
+			require.Equal(t, "proposer", blocks[2].ProposerAddress)
+		})
+	}
 }
@@ -0,0 +1,58 @@
+package blockdb
+
+import "strconv"
+
+// IBC packet event types parsed into the ibc_packet_event table.
+const (
+	PacketEventSend    = "send_packet"
+	PacketEventRecv    = "recv_packet"
+	PacketEventAck     = "acknowledge_packet"
+	PacketEventTimeout = "timeout_packet"
+)
+
+// packetEvent is an IBC packet event parsed from a tendermint event.
+type packetEvent struct {
+	Type     string
+	Sequence uint64
+
+	SrcPort, SrcChannel string
+	DstPort, DstChannel string
+
+	TimeoutHeight, TimeoutTimestamp string
+}
+
+// parsePacketEvent returns the packet event of e,
+// or false if e is not one of the packet event types or lacks the attributes identifying the packet.
+func parsePacketEvent(e Event) (packetEvent, bool) {
+	switch e.Type {
+	case PacketEventSend, PacketEventRecv, PacketEventAck, PacketEventTimeout:
+	default:
+		return packetEvent{}, false
+	}
+
+	attrs := make(map[string]string, len(e.Attributes))
+	for _, attr := range e.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+
+	for _, k := range []string{"packet_src_port", "packet_src_channel", "packet_dst_port", "packet_dst_channel"} {
+		if _, ok := attrs[k]; !ok {
+			return packetEvent{}, false
+		}
+	}
+	seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+	if err != nil {
+		return packetEvent{}, false
+	}
+
+	return packetEvent{
+		Type:             e.Type,
+		Sequence:         seq,
+		SrcPort:          attrs["packet_src_port"],
+		SrcChannel:       attrs["packet_src_channel"],
+		DstPort:          attrs["packet_dst_port"],
+		DstChannel:       attrs["packet_dst_channel"],
+		TimeoutHeight:    attrs["packet_timeout_height"],
+		TimeoutTimestamp: attrs["packet_timeout_timestamp"],
+	}, true
+}
@@ -0,0 +1,63 @@
+package blockdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// packetTestEvent returns a packet event of type typ with the attributes emitted by ibc-go.
+func packetTestEvent(typ string, seq, srcChannel, dstChannel string) Event {
+	return Event{
+		Type: typ,
+		Attributes: []EventAttribute{
+			{Key: "packet_timeout_height", Value: "1-1500"},
+			{Key: "packet_timeout_timestamp", Value: "1690000000000000000"},
+			{Key: "packet_sequence", Value: seq},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: srcChannel},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_dst_channel", Value: dstChannel},
+			{Key: "packet_channel_ordering", Value: "ORDER_UNORDERED"},
+		},
+	}
+}
+
+func TestParsePacketEvent(t *testing.T) {
+	t.Parallel()
+
+	for _, typ := range []string{PacketEventSend, PacketEventRecv, PacketEventAck, PacketEventTimeout} {
+		got, ok := parsePacketEvent(packetTestEvent(typ, "7", "channel-0", "channel-1"))
+		require.True(t, ok, typ)
+		require.Equal(t, packetEvent{
+			Type:             typ,
+			Sequence:         7,
+			SrcPort:          "transfer",
+			SrcChannel:       "channel-0",
+			DstPort:          "transfer",
+			DstChannel:       "channel-1",
+			TimeoutHeight:    "1-1500",
+			TimeoutTimestamp: "1690000000000000000",
+		}, got)
+	}
+
+	t.Run("not a packet event", func(t *testing.T) {
+		_, ok := parsePacketEvent(packetTestEvent("write_acknowledgement", "7", "channel-0", "channel-1"))
+		require.False(t, ok)
+		_, ok = parsePacketEvent(Event{Type: "transfer"})
+		require.False(t, ok)
+	})
+
+	t.Run("invalid sequence", func(t *testing.T) {
+		_, ok := parsePacketEvent(packetTestEvent(PacketEventSend, "abc", "channel-0", "channel-1"))
+		require.False(t, ok)
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		_, ok := parsePacketEvent(Event{
+			Type:       PacketEventSend,
+			Attributes: []EventAttribute{{Key: "packet_sequence", Value: "1"}, {Key: "packet_src_channel", Value: "channel-0"}},
+		})
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,207 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore is a Store of a postgres database, typically shared by many hosts.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+// OpenPostgresStore connects to and migrates the postgres database at dataSourceName,
+// a connection URL or key=value connection string as accepted by github.com/lib/pq.
+// Errors never include dataSourceName, which may contain a password.
+func OpenPostgresStore(ctx context.Context, dataSourceName, gitSha string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres database: %w", err)
+	}
+	// Many hosts share the database, so don't hold idle connections for long.
+	db.SetConnMaxIdleTime(time.Minute)
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping postgres database: %w", err)
+	}
+	if err := MigratePostgres(ctx, db, gitSha); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate postgres database: %w", err)
+	}
+	return &PostgresStore{db: db}, nil
+}
+
+func (s *PostgresStore) CreateTestCase(ctx context.Context, testName, gitSha string) (*TestCase, error) {
+	return createTestCase(ctx, s.db, dialectPostgres, testName, gitSha)
+}
+
+// Prune is like Store.Prune. The policy's MaxSize is unsupported, because the database is shared.
+func (s *PostgresStore) Prune(ctx context.Context, policy RetentionPolicy) (int, error) {
+	return prune(ctx, s.db, dialectPostgres, policy)
+}
+
+func (s *PostgresStore) Close() error { return s.db.Close() }
+
+// postgresMigrateLock is the key of the advisory lock held while migrating,
+// so hosts starting at the same time do not race to create tables.
+const postgresMigrateLock = 0x626c6f636b6462 // "blockdb"
+
+// MigratePostgres migrates a postgres db in an idempotent manner.
+// The tables mirror those of Migrate, without the sqlite views and full-text search index used by Query.
+func MigratePostgres(ctx context.Context, db *sql.DB, gitSha string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		// If commit succeeded, rollback will return nil;
+		// if a step failed, the returned error is more meaningful.
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresMigrateLock); err != nil {
+		return fmt.Errorf("lock: %w", err)
+	}
+
+	for _, step := range []struct {
+		Name string
+		SQL  string
+	}{
+		{"create table schema_version", `CREATE TABLE IF NOT EXISTS schema_version(
+    id BIGSERIAL PRIMARY KEY,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    git_sha TEXT NOT NULL CHECK (length(git_sha) > 0),
+    UNIQUE(git_sha)
+)`},
+		{"create table test_case", `CREATE TABLE IF NOT EXISTS test_case (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL CHECK ( length(name) > 0 ),
+    git_sha TEXT NOT NULL CHECK ( length(git_sha) > 0 ),
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    UNIQUE(name,created_at)
+)`},
+		{"create table chain", `CREATE TABLE IF NOT EXISTS chain (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id TEXT NOT NULL CHECK ( length(chain_id) > 0 ),
+    chain_type TEXT NOT NULL CHECK ( length(chain_type) > 0 ) DEFAULT 'unknown',
+    fk_test_id BIGINT REFERENCES test_case(id) ON DELETE CASCADE,
+    UNIQUE(chain_id,fk_test_id)
+)`},
+		{"create table block", `CREATE TABLE IF NOT EXISTS block (
+    id BIGSERIAL PRIMARY KEY,
+    height BIGINT NOT NULL CHECK (height > 0),
+    fk_chain_id BIGINT REFERENCES chain(id) ON DELETE CASCADE,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    UNIQUE(height,fk_chain_id)
+)`},
+		{"create table tx", `CREATE TABLE IF NOT EXISTS tx (
+    id BIGSERIAL PRIMARY KEY,
+    data TEXT NOT NULL CHECK (length(data) > 0),
+    fk_block_id BIGINT REFERENCES block(id) ON DELETE CASCADE
+)`},
+		{"create table tendermint_event", `CREATE TABLE IF NOT EXISTS tendermint_event (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    fk_tx_id BIGINT REFERENCES tx(id) ON DELETE CASCADE
+)`},
+		{"create table tendermint_event_attr", `CREATE TABLE IF NOT EXISTS tendermint_event_attr (
+    id BIGSERIAL PRIMARY KEY,
+    key TEXT NOT NULL CHECK (length(key) > 0),
+    value TEXT NOT NULL,
+    fk_event_id BIGINT REFERENCES tendermint_event(id) ON DELETE CASCADE
+)`},
+		{"create table abci_event", `CREATE TABLE IF NOT EXISTS abci_event (
+    id BIGSERIAL PRIMARY KEY,
+    phase TEXT NOT NULL CHECK (phase IN ('begin_block', 'tx', 'end_block')),
+    event_n INTEGER NOT NULL, -- event position within the phase, or within the tx
+    type TEXT NOT NULL,
+    attributes JSONB NOT NULL,
+    fk_block_id BIGINT NOT NULL REFERENCES block(id) ON DELETE CASCADE,
+    fk_tx_id BIGINT REFERENCES tx(id) ON DELETE CASCADE
+)`},
+		{"create index abci_event_block", `CREATE INDEX IF NOT EXISTS abci_event_block ON abci_event(fk_block_id)`},
+		{"create table ibc_packet_event", `CREATE TABLE IF NOT EXISTS ibc_packet_event (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    sequence BIGINT NOT NULL,
+    src_port TEXT NOT NULL,
+    src_channel TEXT NOT NULL,
+    dst_port TEXT NOT NULL,
+    dst_channel TEXT NOT NULL,
+    timeout_height TEXT NOT NULL,
+    timeout_timestamp TEXT NOT NULL,
+    fk_tx_id BIGINT REFERENCES tx(id) ON DELETE CASCADE
+)`},
+		{"alter table tx add hash", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS hash TEXT NOT NULL DEFAULT ''`},
+		{"create index tx_hash", `CREATE INDEX IF NOT EXISTS tx_hash ON tx(hash)`},
+		{"alter table tx add gas_wanted", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS gas_wanted BIGINT NOT NULL DEFAULT 0`},
+		{"alter table tx add gas_used", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS gas_used BIGINT NOT NULL DEFAULT 0`},
+		{"alter table block add proposer_address", `ALTER TABLE block ADD COLUMN IF NOT EXISTS proposer_address TEXT NOT NULL DEFAULT ''`},
+		{"alter table block add validators_hash", `ALTER TABLE block ADD COLUMN IF NOT EXISTS validators_hash TEXT NOT NULL DEFAULT ''`},
+		{"create table validator_update", `CREATE TABLE IF NOT EXISTS validator_update (
+    id BIGSERIAL PRIMARY KEY,
+    address TEXT NOT NULL CHECK (length(address) > 0),
+    voting_power BIGINT NOT NULL,
+    fk_block_id BIGINT NOT NULL REFERENCES block(id) ON DELETE CASCADE
+)`},
+		{"create index validator_update_block", `CREATE INDEX IF NOT EXISTS validator_update_block ON validator_update(fk_block_id)`},
+		{"alter table block add version", `ALTER TABLE block ADD COLUMN IF NOT EXISTS version TEXT NOT NULL DEFAULT ''`},
+		{"create table chain_upgrade", `CREATE TABLE IF NOT EXISTS chain_upgrade (
+    id BIGSERIAL PRIMARY KEY,
+    old_version TEXT NOT NULL,
+    new_version TEXT NOT NULL,
+    image TEXT NOT NULL,
+    fk_block_id BIGINT NOT NULL REFERENCES block(id) ON DELETE CASCADE
+)`},
+		{"create index chain_upgrade_block", `CREATE INDEX IF NOT EXISTS chain_upgrade_block ON chain_upgrade(fk_block_id)`},
+		{"alter table chain add config", `ALTER TABLE chain ADD COLUMN IF NOT EXISTS config TEXT NOT NULL DEFAULT ''`},
+		{"alter table chain add genesis", `ALTER TABLE chain ADD COLUMN IF NOT EXISTS genesis TEXT NOT NULL DEFAULT ''`},
+		{"create table bookmark", `CREATE TABLE IF NOT EXISTS bookmark (
+    id BIGSERIAL PRIMARY KEY,
+    height BIGINT NOT NULL CHECK (height > 0),
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    fk_chain_id BIGINT NOT NULL REFERENCES chain(id) ON DELETE CASCADE,
+    fk_tx_id BIGINT REFERENCES tx(id) ON DELETE CASCADE
+)`},
+		{"create index bookmark_chain", `CREATE INDEX IF NOT EXISTS bookmark_chain ON bookmark(fk_chain_id, height)`},
+		{"alter table tx add code", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS code BIGINT NOT NULL DEFAULT 0`},
+		{"alter table tx add codespace", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS codespace TEXT NOT NULL DEFAULT ''`},
+		{"alter table tx add raw_log", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS raw_log TEXT NOT NULL DEFAULT ''`},
+		{"alter table tx add data_encoding", `ALTER TABLE tx ADD COLUMN IF NOT EXISTS data_encoding TEXT NOT NULL DEFAULT ''`},
+		{"alter table test_case add finished_at", `ALTER TABLE test_case ADD COLUMN IF NOT EXISTS finished_at TEXT`},
+		{"create table state_export", `CREATE TABLE IF NOT EXISTS state_export (
+    id BIGSERIAL PRIMARY KEY,
+    height BIGINT NOT NULL CHECK (height > 0),
+    module TEXT NOT NULL CHECK (length(module) > 0),
+    data TEXT NOT NULL CHECK (length(data) > 0),
+    fk_chain_id BIGINT NOT NULL REFERENCES chain(id) ON DELETE CASCADE,
+    UNIQUE(height,module,fk_chain_id)
+)`},
+		{"create index ibc_packet_event_packet", `CREATE INDEX IF NOT EXISTS ibc_packet_event_packet ON ibc_packet_event(src_channel, sequence)`},
+		// Indexes on foreign keys, which postgres does not create implicitly, keep cascading deletes fast when pruning.
+		{"create index block_chain", `CREATE INDEX IF NOT EXISTS block_chain ON block(fk_chain_id)`},
+		{"create index tx_block", `CREATE INDEX IF NOT EXISTS tx_block ON tx(fk_block_id)`},
+		{"create index tendermint_event_tx", `CREATE INDEX IF NOT EXISTS tendermint_event_tx ON tendermint_event(fk_tx_id)`},
+		{"create index tendermint_event_attr_event", `CREATE INDEX IF NOT EXISTS tendermint_event_attr_event ON tendermint_event_attr(fk_event_id)`},
+		{"create index abci_event_tx", `CREATE INDEX IF NOT EXISTS abci_event_tx ON abci_event(fk_tx_id)`},
+		{"create index ibc_packet_event_tx", `CREATE INDEX IF NOT EXISTS ibc_packet_event_tx ON ibc_packet_event(fk_tx_id)`},
+	} {
+		if _, err := tx.ExecContext(ctx, step.SQL); err != nil {
+			return fmt.Errorf("%s: %w", step.Name, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_version(created_at, git_sha) VALUES ($1, $2)
+ON CONFLICT(git_sha) DO NOTHING`, nowRFC3339(), gitSha)
+	if err != nil {
+		return fmt.Errorf("upsert schema_version with git sha %s: %w", gitSha, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,138 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// unfinishedRetention is how long after creation an unfinished test case is kept regardless of the policy.
+// The test may still be running, e.g. in another process saving to the same database,
+// whereas a test that crashed never finishes its test case.
+const unfinishedRetention = 24 * time.Hour
+
+// RetentionPolicy limits how much history a database retains.
+// Test cases are pruned oldest first, along with their chains, blocks, and transactions.
+// A zero value for any field means no limit.
+//
+// Test cases that are not finished, see TestCase.Finish, are kept for a day after they were created,
+// so that a process does not prune the test cases of tests still running in other processes.
+type RetentionPolicy struct {
+	// MaxTestCases is the number of most recent test cases kept.
+	MaxTestCases int
+	// MaxAge is how long test cases are kept after they were created.
+	MaxAge time.Duration
+	// MaxSize is the number of bytes the database may use.
+	// Because size is measured in whole pages, the database may be slightly smaller than MaxSize after pruning.
+	MaxSize int64
+}
+
+// IsZero returns true if the policy has no limits, i.e. retains all test cases.
+func (p RetentionPolicy) IsZero() bool {
+	return p == RetentionPolicy{}
+}
+
+// Prune deletes test cases exceeding the policy and returns the number of test cases deleted.
+// If any test case is deleted, Prune vacuums the database so the file shrinks.
+// Expects a migrated sqlite database.
+func Prune(ctx context.Context, db *sql.DB, policy RetentionPolicy) (int, error) {
+	return prune(ctx, db, dialectSQLite, policy)
+}
+
+func prune(ctx context.Context, db *sql.DB, d dialect, policy RetentionPolicy) (int, error) {
+	if policy.IsZero() {
+		return 0, nil
+	}
+	if policy.MaxSize > 0 && d != dialectSQLite {
+		return 0, errors.New("max size is only supported by sqlite databases")
+	}
+
+	if d == dialectSQLite {
+		// Deleting test cases relies on cascading deletes, which sqlite enables per connection.
+		if _, err := db.ExecContext(ctx, `PRAGMA foreign_keys = ON`); err != nil {
+			return 0, fmt.Errorf("pragma foreign_keys: %w", err)
+		}
+	}
+
+	var deleted int64
+
+	// Only finished or abandoned test cases may be deleted.
+	const prunable = `(finished_at IS NOT NULL OR created_at < ?)`
+	unfinishedCutoff := time.Now().Add(-unfinishedRetention).UTC().Format(time.RFC3339)
+
+	if policy.MaxAge > 0 {
+		cutoff := time.Now().Add(-policy.MaxAge).UTC().Format(time.RFC3339)
+		n, err := execRowsAffected(ctx, db, d, `DELETE FROM test_case WHERE created_at < ? AND `+prunable, cutoff, unfinishedCutoff)
+		if err != nil {
+			return 0, fmt.Errorf("prune test cases older than %s: %w", policy.MaxAge, err)
+		}
+		deleted += n
+	}
+
+	if policy.MaxTestCases > 0 {
+		n, err := execRowsAffected(ctx, db, d, `DELETE FROM test_case WHERE id NOT IN (
+    SELECT id FROM test_case ORDER BY id DESC LIMIT ?
+) AND `+prunable, policy.MaxTestCases, unfinishedCutoff)
+		if err != nil {
+			return 0, fmt.Errorf("prune test cases beyond the %d most recent: %w", policy.MaxTestCases, err)
+		}
+		deleted += n
+	}
+
+	if policy.MaxSize > 0 {
+		for {
+			size, err := usedSize(ctx, db)
+			if err != nil {
+				return 0, err
+			}
+			if size <= policy.MaxSize {
+				break
+			}
+			n, err := execRowsAffected(ctx, db, d, `DELETE FROM test_case WHERE id = (SELECT MIN(id) FROM test_case WHERE `+prunable+`)`,
+				unfinishedCutoff)
+			if err != nil {
+				return 0, fmt.Errorf("prune oldest test case: %w", err)
+			}
+			if n == 0 {
+				// No prunable test cases left; the remaining size is unfinished test cases, schema, and other overhead.
+				break
+			}
+			deleted += n
+		}
+	}
+
+	if deleted > 0 && d == dialectSQLite {
+		// Deleted rows only free pages for reuse; vacuum to return them to the file system.
+		// Postgres vacuums automatically.
+		if _, err := db.ExecContext(ctx, `VACUUM`); err != nil {
+			return 0, fmt.Errorf("vacuum: %w", err)
+		}
+	}
+
+	return int(deleted), nil
+}
+
+func execRowsAffected(ctx context.Context, db *sql.DB, d dialect, query string, args ...any) (int64, error) {
+	res, err := d.exec(ctx, db, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// usedSize returns the bytes used by the database, excluding free pages.
+func usedSize(ctx context.Context, db *sql.DB) (int64, error) {
+	var pageCount, freeCount, pageSize int64
+	if err := db.QueryRowContext(ctx, `PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("pragma page_count: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, `PRAGMA freelist_count`).Scan(&freeCount); err != nil {
+		return 0, fmt.Errorf("pragma freelist_count: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("pragma page_size: %w", err)
+	}
+	return (pageCount - freeCount) * pageSize, nil
+}
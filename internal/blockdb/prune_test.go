@@ -0,0 +1,195 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createPruneTestCases creates n finished test cases, each with a chain of one block with a tx of txSize bytes.
+func createPruneTestCases(t *testing.T, db *sql.DB, n, txSize int) []*TestCase {
+	t.Helper()
+
+	ctx := context.Background()
+	tcs := make([]*TestCase, n)
+	for i := range tcs {
+		tc, err := CreateTestCase(ctx, db, fmt.Sprintf("test%d", i), "abc123")
+		require.NoError(t, err)
+		chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+		require.NoError(t, err)
+		data := fmt.Sprintf(`{"memo":%q}`, strings.Repeat("a", txSize))
+		require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{{Data: []byte(data)}}))
+		require.NoError(t, tc.Finish(ctx))
+		tcs[i] = tc
+	}
+	return tcs
+}
+
+func testCaseIDs(t *testing.T, db *sql.DB) []int64 {
+	t.Helper()
+
+	rows, err := db.Query(`SELECT id FROM test_case ORDER BY id ASC`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	return ids
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM `+table).Scan(&n))
+	return n
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("zero policy", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		createPruneTestCases(t, db, 3, 10)
+
+		n, err := Prune(ctx, db, RetentionPolicy{})
+		require.NoError(t, err)
+		require.Zero(t, n)
+		require.Len(t, testCaseIDs(t, db), 3)
+	})
+
+	t.Run("max test cases", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		tcs := createPruneTestCases(t, db, 5, 10)
+
+		n, err := Prune(ctx, db, RetentionPolicy{MaxTestCases: 2})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []int64{tcs[3].ID(), tcs[4].ID()}, testCaseIDs(t, db))
+
+		// Chains, blocks, and txs of pruned test cases are deleted too.
+		require.Equal(t, 2, countRows(t, db, "chain"))
+		require.Equal(t, 2, countRows(t, db, "block"))
+		require.Equal(t, 2, countRows(t, db, "tx"))
+
+		// Search index does not reference pruned txs.
+		results, err := NewQuery(db).SearchTransactions(ctx, tcs[0].ID(), "memo")
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		tcs := createPruneTestCases(t, db, 3, 10)
+		old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+		_, err := db.Exec(`UPDATE test_case SET created_at = ? WHERE id IN (?, ?)`, old, tcs[0].ID(), tcs[1].ID())
+		require.NoError(t, err)
+
+		n, err := Prune(ctx, db, RetentionPolicy{MaxAge: 24 * time.Hour})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []int64{tcs[2].ID()}, testCaseIDs(t, db))
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "blocks.db")
+		db, err := ConnectDB(ctx, dbPath)
+		require.NoError(t, err)
+		defer db.Close()
+		require.NoError(t, Migrate(db, "test"))
+
+		const txSize = 100_000
+		tcs := createPruneTestCases(t, db, 10, txSize)
+
+		before, err := usedSize(ctx, db)
+		require.NoError(t, err)
+		require.Greater(t, before, int64(10*txSize))
+
+		const maxSize = 4 * txSize
+		n, err := Prune(ctx, db, RetentionPolicy{MaxSize: maxSize})
+		require.NoError(t, err)
+		require.Greater(t, n, 5)
+		require.Less(t, n, 10)
+
+		ids := testCaseIDs(t, db)
+		require.Equal(t, tcs[len(tcs)-1].ID(), ids[len(ids)-1], "most recent test case kept")
+
+		after, err := usedSize(ctx, db)
+		require.NoError(t, err)
+		require.LessOrEqual(t, after, int64(maxSize))
+
+		// Vacuum shrinks the file.
+		_, err = db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`)
+		require.NoError(t, err)
+		info, err := os.Stat(dbPath)
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), int64(maxSize))
+	})
+
+	t.Run("unfinished test cases", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		tcs := createPruneTestCases(t, db, 2, 10)
+		// E.g. the test cases of tests running in other processes, and of a test that crashed a day ago.
+		var unfinished []*TestCase
+		for i := 0; i < 3; i++ {
+			tc, err := CreateTestCase(ctx, db, fmt.Sprintf("unfinished%d", i), "abc123")
+			require.NoError(t, err)
+			unfinished = append(unfinished, tc)
+		}
+		old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+		_, err := db.Exec(`UPDATE test_case SET created_at = ? WHERE id IN (?, ?, ?)`, old, tcs[0].ID(), unfinished[0].ID(), unfinished[1].ID())
+		require.NoError(t, err)
+		// A recently finished test case.
+		require.NoError(t, unfinished[1].Finish(ctx))
+
+		n, err := Prune(ctx, db, RetentionPolicy{MaxAge: 24 * time.Hour})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []int64{tcs[1].ID(), unfinished[2].ID()}, testCaseIDs(t, db))
+
+		n, err = Prune(ctx, db, RetentionPolicy{MaxSize: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Equal(t, []int64{unfinished[2].ID()}, testCaseIDs(t, db))
+
+		require.NoError(t, unfinished[2].Finish(ctx))
+		n, err = Prune(ctx, db, RetentionPolicy{MaxSize: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Empty(t, testCaseIDs(t, db))
+	})
+
+	t.Run("max size smaller than empty database", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		createPruneTestCases(t, db, 2, 10)
+
+		n, err := Prune(ctx, db, RetentionPolicy{MaxSize: 1})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Empty(t, testCaseIDs(t, db))
+	})
+}
@@ -3,7 +3,9 @@ package blockdb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -71,6 +73,25 @@ func (q *Query) RecentTestCases(ctx context.Context, limit int) ([]TestCaseResul
 	if err != nil {
 		return nil, err
 	}
+	return scanTestCaseResults(rows)
+}
+
+// TestCase returns aggregated data for each chain of the test case.
+// testCaseID is the test case primary key "test_case.id".
+// If the test case has no chains, the result is empty.
+func (q *Query) TestCase(ctx context.Context, testCaseID int64) ([]TestCaseResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT 
+        test_case_id, test_case_created_at, test_case_name, test_case_git_sha, chain_kid, chain_id, chain_type, chain_height, tx_total
+    FROM v_tx_agg 
+    WHERE chain_kid IS NOT NULL AND test_case_id = ?
+    ORDER BY chain_id ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTestCaseResults(rows)
+}
+
+func scanTestCaseResults(rows *sql.Rows) ([]TestCaseResult, error) {
 	defer rows.Close()
 	var results []TestCaseResult
 	for rows.Next() {
@@ -78,7 +99,7 @@ func (q *Query) RecentTestCases(ctx context.Context, limit int) ([]TestCaseResul
 			res       TestCaseResult
 			createdAt string
 		)
-		if err = rows.Scan(
+		if err := rows.Scan(
 			&res.ID,
 			&createdAt,
 			&res.Name,
@@ -169,15 +190,265 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
 	return results, nil
 }
 
+type GasStatsResult struct {
+	ChainPKey int64  // chain primary key
+	ChainID   string // E.g. osmosis-1001
+	MsgType   string // E.g. /ibc.applications.transfer.v1.MsgTransfer
+
+	TxCount        int64
+	GasWantedTotal int64
+	GasUsedTotal   int64
+	GasUsedAvg     float64
+	GasUsedMin     int64
+	GasUsedMax     int64
+}
+
+// GasStats returns gas statistics of each chain and message type in the test case with testCaseID.
+// Gas is recorded per transaction, so a transaction with many message types counts towards each of its types.
+// Transactions without recorded gas, such as those saved before gas was recorded, are ignored.
+func (q *Query) GasStats(ctx context.Context, testCaseID int64) ([]GasStatsResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        chain_kid, chain_id, type, COUNT(*), SUM(gas_wanted), SUM(gas_used), AVG(gas_used), MIN(gas_used), MAX(gas_used)
+    FROM (
+        SELECT DISTINCT msg.chain_kid, msg.chain_id, msg.type, tx.id, tx.gas_wanted, tx.gas_used
+        FROM v_cosmos_messages msg
+        INNER JOIN tx ON msg.tx_id = tx.id
+        WHERE msg.test_case_id = ? AND tx.gas_used > 0
+    )
+    GROUP BY chain_kid, type
+    ORDER BY chain_kid ASC, SUM(gas_used) DESC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var results []GasStatsResult
+	for rows.Next() {
+		var res GasStatsResult
+		if err = rows.Scan(
+			&res.ChainPKey,
+			&res.ChainID,
+			&res.MsgType,
+			&res.TxCount,
+			&res.GasWantedTotal,
+			&res.GasUsedTotal,
+			&res.GasUsedAvg,
+			&res.GasUsedMin,
+			&res.GasUsedMax,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+type BlockResult struct {
+	Height int64
+	// Always set to user's local time zone.
+	CreatedAt time.Time
+	TxTotal   int64
+
+	// Empty if unknown.
+	ProposerAddress string
+	ValidatorsHash  string
+}
+
+// Blocks returns the saved blocks, with and without transactions, ordered by height.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) Blocks(ctx context.Context, chainPkey int64) ([]BlockResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT block.height, block.created_at, COUNT(tx.id), block.proposer_address, block.validators_hash FROM block
+    LEFT JOIN tx on tx.fk_block_id = block.id
+    WHERE block.fk_chain_id = ?
+    GROUP BY block.id
+    ORDER BY block.height ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []BlockResult
+	for rows.Next() {
+		var (
+			res       BlockResult
+			createdAt string
+		)
+		if err := rows.Scan(&res.Height, &createdAt, &res.TxTotal, &res.ProposerAddress, &res.ValidatorsHash); err != nil {
+			return nil, err
+		}
+		t, err := timeToLocal(createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse createdAt: %w", err)
+		}
+		res.CreatedAt = t
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// ValidatorResult is a validator's voting power.
+type ValidatorResult struct {
+	Address     string // Hex encoded consensus address.
+	VotingPower int64
+}
+
+// ValidatorSet returns the validator set of the chain at height, ordered by descending voting power.
+// The result is empty if no validator sets were saved at or before height.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) ValidatorSet(ctx context.Context, chainPkey int64, height int64) ([]ValidatorResult, error) {
+	return validatorSet(ctx, q.db, dialectSQLite, chainPkey, height)
+}
+
+func validatorSet(ctx context.Context, db execQueryer, d dialect, chainPkey int64, height int64) ([]ValidatorResult, error) {
+	rows, err := db.QueryContext(ctx, d.rebind(`SELECT address, voting_power FROM (
+        SELECT
+            validator_update.address
+            , validator_update.voting_power
+            , ROW_NUMBER() OVER (PARTITION BY validator_update.address ORDER BY block.height DESC) AS n
+        FROM validator_update
+        INNER JOIN block ON validator_update.fk_block_id = block.id
+        WHERE block.fk_chain_id = ? AND block.height <= ?
+    ) AS latest
+    WHERE n = 1 AND voting_power > 0
+    ORDER BY voting_power DESC, address ASC`), chainPkey, height)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []ValidatorResult
+	for rows.Next() {
+		var res ValidatorResult
+		if err := rows.Scan(&res.Address, &res.VotingPower); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// ValidatorUpdateResult is a change to a chain's validator set.
+type ValidatorUpdateResult struct {
+	Height      int64
+	Address     string // Hex encoded consensus address.
+	VotingPower int64  // 0 if the validator left the validator set, e.g. when jailed.
+}
+
+// ValidatorUpdates returns the changes to the validator set of the chain, ordered by height.
+// The first saved validator set is the updates at the lowest height.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) ValidatorUpdates(ctx context.Context, chainPkey int64) ([]ValidatorUpdateResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT block.height, validator_update.address, validator_update.voting_power
+    FROM validator_update
+    INNER JOIN block ON validator_update.fk_block_id = block.id
+    WHERE block.fk_chain_id = ?
+    ORDER BY block.height ASC, validator_update.id ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []ValidatorUpdateResult
+	for rows.Next() {
+		var res ValidatorUpdateResult
+		if err := rows.Scan(&res.Height, &res.Address, &res.VotingPower); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+type UpgradeResult struct {
+	Height     int64 // The first block of the new version.
+	OldVersion string
+	NewVersion string
+	Image      string // Empty if unknown.
+}
+
+// Upgrades returns the upgrades of the chain's software, ordered by height.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) Upgrades(ctx context.Context, chainPkey int64) ([]UpgradeResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT block.height, chain_upgrade.old_version, chain_upgrade.new_version, chain_upgrade.image
+    FROM chain_upgrade
+    INNER JOIN block ON chain_upgrade.fk_block_id = block.id
+    WHERE block.fk_chain_id = ?
+    ORDER BY block.height ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []UpgradeResult
+	for rows.Next() {
+		var res UpgradeResult
+		if err := rows.Scan(&res.Height, &res.OldVersion, &res.NewVersion, &res.Image); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// StateExportHeights returns the heights of the chain's saved state exports in ascending order.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) StateExportHeights(ctx context.Context, chainPkey int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT DISTINCT height FROM state_export WHERE fk_chain_id = ? ORDER BY height ASC`, chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var heights []int64
+	for rows.Next() {
+		var h int64
+		if err := rows.Scan(&h); err != nil {
+			return nil, err
+		}
+		heights = append(heights, h)
+	}
+	return heights, nil
+}
+
+// StateExport returns the JSON encoded app state of each module exported at height.
+// The result is empty if no state was exported at the height.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) StateExport(ctx context.Context, chainPkey, height int64) (map[string]json.RawMessage, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT module, data FROM state_export WHERE fk_chain_id = ? AND height = ?`, chainPkey, height)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	state := make(map[string]json.RawMessage)
+	for rows.Next() {
+		var (
+			module string
+			data   []byte
+		)
+		if err := rows.Scan(&module, &data); err != nil {
+			return nil, err
+		}
+		state[module] = data
+	}
+	return state, nil
+}
+
 type TxResult struct {
+	ID     int64 // tx primary key
 	Height int64
 	Tx     []byte
+	Hash   string // Upper case hex. Empty if unknown.
+
+	// Code is 0 if the tx succeeded, or if the tx was saved before codes were recorded.
+	Code      uint32
+	Codespace string
+	RawLog    string
 }
 
 // Transactions returns TxResults only for blocks with transactions present.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
 func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data FROM tx 
+	rows, err := q.db.QueryContext(ctx, `SELECT tx.id, block.height, tx.data, tx.data_encoding, tx.hash, tx.code, tx.codespace, tx.raw_log FROM tx
     INNER JOIN block on tx.fk_block_id = block.id
     INNER JOIN chain on block.fk_chain_id = chain.id
     WHERE chain.id = ?
@@ -189,12 +460,364 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 
 	var results []TxResult
 	for rows.Next() {
-		var res TxResult
-		if err := rows.Scan(&res.Height, &res.Tx); err != nil {
+		var (
+			res      TxResult
+			encoding string
+		)
+		if err := rows.Scan(&res.ID, &res.Height, &res.Tx, &encoding, &res.Hash, &res.Code, &res.Codespace, &res.RawLog); err != nil {
+			return nil, err
+		}
+		if err := res.decodeData(encoding); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// Tx returns the transaction with primary key txID.
+func (q *Query) Tx(ctx context.Context, txID int64) (TxResult, error) {
+	row := q.db.QueryRowContext(ctx, `SELECT tx.id, block.height, tx.data, tx.data_encoding, tx.hash, tx.code, tx.codespace, tx.raw_log FROM tx
+    INNER JOIN block on tx.fk_block_id = block.id
+    WHERE tx.id = ?`, txID)
+	var (
+		res      TxResult
+		encoding string
+	)
+	if err := row.Scan(&res.ID, &res.Height, &res.Tx, &encoding, &res.Hash, &res.Code, &res.Codespace, &res.RawLog); err != nil {
+		return res, err
+	}
+	return res, res.decodeData(encoding)
+}
+
+// decodeData decompresses the tx data saved with encoding.
+func (res *TxResult) decodeData(encoding string) error {
+	data, err := decodeTxData(res.Tx, encoding)
+	if err != nil {
+		return fmt.Errorf("tx %d: %w", res.ID, err)
+	}
+	res.Tx = data
+	return nil
+}
+
+// TxHashResult is a transaction found by its hash.
+type TxHashResult struct {
+	TestCaseID int64
+	ChainPKey  int64  // chain primary key
+	ChainID    string // E.g. osmosis-1001
+	TxResult
+}
+
+// TxsByHash returns the transactions with the hex encoded hash, of any case, most recent test case first.
+// More than one transaction is returned if the same transaction was saved by more than one test case.
+func (q *Query) TxsByHash(ctx context.Context, hash string) ([]TxHashResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT chain.fk_test_id, chain.id, chain.chain_id, tx.id, block.height, tx.data, tx.data_encoding, tx.hash, tx.code, tx.codespace, tx.raw_log FROM tx
+    INNER JOIN block on tx.fk_block_id = block.id
+    INNER JOIN chain on block.fk_chain_id = chain.id
+    WHERE tx.hash = ?
+    ORDER BY chain.fk_test_id DESC, tx.id ASC`, strings.ToUpper(hash))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TxHashResult
+	for rows.Next() {
+		var (
+			res      TxHashResult
+			encoding string
+		)
+		if err := rows.Scan(&res.TestCaseID, &res.ChainPKey, &res.ChainID, &res.ID, &res.Height, &res.Tx, &encoding, &res.Hash, &res.Code, &res.Codespace, &res.RawLog); err != nil {
+			return nil, err
+		}
+		if err := res.decodeData(encoding); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// DecodedTx returns the transaction with primary key txID, decoded by dec if needed; see DecodeTx.
+func (q *Query) DecodedTx(ctx context.Context, txID int64, dec TxDecoder) (TxResult, error) {
+	res, err := q.Tx(ctx, txID)
+	if err != nil {
+		return res, err
+	}
+	return DecodeTx(res, dec)
+}
+
+// TxSearchResult is a transaction matching a full-text search.
+type TxSearchResult struct {
+	ChainPKey int64  // chain primary key
+	ChainID   string // E.g. osmosis-1001
+	TxResult
+}
+
+// SearchTransactions returns the transactions of all chains in the test case whose data contains every word of term,
+// ordered by chain and height.
+// Words match whole tokens of the tx JSON, case-insensitively,
+// e.g. "MsgTransfer" matches the message type "/ibc.applications.transfer.v1.MsgTransfer".
+// testCaseID is the test case primary key "test_case.id".
+func (q *Query) SearchTransactions(ctx context.Context, testCaseID int64, term string) ([]TxSearchResult, error) {
+	match := ftsQuery(term)
+	if match == "" {
+		return nil, nil
+	}
+	rows, err := q.db.QueryContext(ctx, `SELECT chain.id, chain.chain_id, tx.id, block.height, tx.data FROM tx_fts
+    INNER JOIN tx on tx_fts.rowid = tx.id
+    INNER JOIN block on tx.fk_block_id = block.id
+    INNER JOIN chain on block.fk_chain_id = chain.id
+    WHERE tx_fts MATCH ? AND chain.fk_test_id = ?
+    ORDER BY chain.chain_id ASC, block.height ASC, tx.id ASC`, match, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TxSearchResult
+	for rows.Next() {
+		var res TxSearchResult
+		if err := rows.Scan(&res.ChainPKey, &res.ChainID, &res.ID, &res.Height, &res.Tx); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// ftsQuery converts user input into an FTS5 query matching all words of term.
+// Each word is quoted, so that characters with special meaning in FTS5 queries are matched literally.
+func ftsQuery(term string) string {
+	words := strings.Fields(term)
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+	return strings.Join(words, " ")
+}
+
+// EventResult is an ABCI event emitted by a block.
+type EventResult struct {
+	Height int64
+	TxID   sql.NullInt64 // tx primary key, only valid for events of txs
+	Phase  string        // One of begin_block, tx, or end_block.
+	Index  int           // Position of the event within the phase, or within the tx.
+	Type   string        // E.g. slash, rewards, transfer
+
+	Attributes []EventAttribute
+}
+
+// Events returns the ABCI events of a chain, ordered by height and the order they were emitted within the block.
+// If eventType is not empty, only events of that type are returned.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) Events(ctx context.Context, chainPkey int64, eventType string) ([]EventResult, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT block_height, tx_id, phase, event_n, type, attributes
+    FROM v_abci_events
+    WHERE chain_kid = ? AND (? = '' OR type = ?)
+    ORDER BY block_height ASC
+        , CASE phase WHEN 'begin_block' THEN 0 WHEN 'tx' THEN 1 ELSE 2 END ASC
+        , tx_id ASC
+        , event_n ASC`, chainPkey, eventType, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []EventResult
+	for rows.Next() {
+		var (
+			res   EventResult
+			attrs string
+		)
+		if err := rows.Scan(&res.Height, &res.TxID, &res.Phase, &res.Index, &res.Type, &attrs); err != nil {
 			return nil, err
 		}
+		if err := json.Unmarshal([]byte(attrs), &res.Attributes); err != nil {
+			return nil, fmt.Errorf("unmarshal attributes of %s event at height %d: %w", res.Type, res.Height, err)
+		}
 		results = append(results, res)
 	}
+	return results, nil
+}
+
+// PacketResult is an IBC packet sent by a chain, with the heights of the later stages of its lifecycle.
+// A stage's height is invalid if the stage was not observed.
+type PacketResult struct {
+	ChainPKey int64  // primary key of the sending chain
+	ChainID   string // E.g. osmosis-1001
+	Sequence  uint64
+
+	SrcPort, SrcChannel string
+	DstPort, DstChannel string
 
+	SendHeight    int64
+	RecvChainID   sql.NullString // The chain that received the packet.
+	RecvHeight    sql.NullInt64
+	AckHeight     sql.NullInt64
+	TimeoutHeight sql.NullInt64
+}
+
+// Packets returns the IBC packets sent by chains of the test case, ordered by sending chain, source channel, and sequence.
+// testCaseID is the test case primary key "test_case.id".
+func (q *Query) Packets(ctx context.Context, testCaseID int64) ([]PacketResult, error) {
+	// A recv on any other chain, and an ack or timeout on the sending chain, of the same packet completes the lifecycle.
+	rows, err := q.db.QueryContext(ctx, `SELECT
+        send.chain_kid
+        , send.chain_id
+        , send.sequence
+        , send.src_port
+        , send.src_channel
+        , send.dst_port
+        , send.dst_channel
+        , send.block_height
+        , MIN(recv.chain_id)
+        , MIN(recv.block_height)
+        , MIN(ack.block_height)
+        , MIN(timeout.block_height)
+    FROM v_ibc_packet_events AS send
+    LEFT JOIN v_ibc_packet_events AS recv ON recv.type = 'recv_packet'
+        AND recv.test_case_id = send.test_case_id AND recv.chain_kid != send.chain_kid
+        AND recv.sequence = send.sequence
+        AND recv.src_port = send.src_port AND recv.src_channel = send.src_channel
+        AND recv.dst_port = send.dst_port AND recv.dst_channel = send.dst_channel
+    LEFT JOIN v_ibc_packet_events AS ack ON ack.type = 'acknowledge_packet'
+        AND ack.chain_kid = send.chain_kid
+        AND ack.sequence = send.sequence
+        AND ack.src_port = send.src_port AND ack.src_channel = send.src_channel
+    LEFT JOIN v_ibc_packet_events AS timeout ON timeout.type = 'timeout_packet'
+        AND timeout.chain_kid = send.chain_kid
+        AND timeout.sequence = send.sequence
+        AND timeout.src_port = send.src_port AND timeout.src_channel = send.src_channel
+    WHERE send.type = 'send_packet' AND send.test_case_id = ?
+    GROUP BY send.event_id
+    ORDER BY send.chain_id ASC, send.src_channel ASC, send.sequence ASC`, testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []PacketResult
+	for rows.Next() {
+		var res PacketResult
+		if err := rows.Scan(
+			&res.ChainPKey,
+			&res.ChainID,
+			&res.Sequence,
+			&res.SrcPort,
+			&res.SrcChannel,
+			&res.DstPort,
+			&res.DstChannel,
+			&res.SendHeight,
+			&res.RecvChainID,
+			&res.RecvHeight,
+			&res.AckHeight,
+			&res.TimeoutHeight,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// PacketEventResult is an IBC packet event observed on a chain.
+type PacketEventResult struct {
+	ChainPKey int64  // chain primary key
+	ChainID   string // E.g. osmosis-1001
+	Height    int64
+	// When the block was saved, which trails the block time. Always set to user's local time zone.
+	BlockTime time.Time
+	TxID      int64  // tx primary key
+	Tx        []byte // Data of the tx that emitted the event.
+	Type      string // E.g. send_packet, recv_packet
+	Sequence  uint64
+
+	SrcPort, SrcChannel string
+	DstPort, DstChannel string
+
+	TimeoutHeight    string // E.g. 1-1500
+	TimeoutTimestamp string // Nanoseconds since the unix epoch.
+}
+
+// PacketLifecycle returns the events of the packet sent by a chain with the source port, channel, and sequence,
+// in lifecycle order: the send, recv on the counterparty chain, then ack or timeout.
+// The result is empty if the send was not saved.
+// chainPkey is the primary key "chain.id" of the sending chain, not to be confused with the column "chain_id".
+func (q *Query) PacketLifecycle(ctx context.Context, chainPkey int64, srcPort, srcChannel string, sequence uint64) ([]PacketEventResult, error) {
+	rows, err := q.db.QueryContext(ctx, `WITH send AS (
+        SELECT * FROM v_ibc_packet_events
+        WHERE type = 'send_packet' AND chain_kid = ? AND src_port = ? AND src_channel = ? AND sequence = ?
+        LIMIT 1
+    )
+    SELECT
+        event.chain_kid
+        , event.chain_id
+        , event.block_height
+        , event.block_created_at
+        , event.tx_id
+        , event.tx
+        , event.tx_data_encoding
+        , event.type
+        , event.sequence
+        , event.src_port
+        , event.src_channel
+        , event.dst_port
+        , event.dst_channel
+        , event.timeout_height
+        , event.timeout_timestamp
+    FROM v_ibc_packet_events AS event, send
+    WHERE event.test_case_id = send.test_case_id
+        AND event.sequence = send.sequence
+        AND event.src_port = send.src_port AND event.src_channel = send.src_channel
+        AND event.dst_port = send.dst_port AND event.dst_channel = send.dst_channel
+        AND (
+            (event.chain_kid = send.chain_kid AND event.type != 'recv_packet')
+            OR (event.chain_kid != send.chain_kid AND event.type = 'recv_packet')
+        )
+    ORDER BY CASE event.type WHEN 'send_packet' THEN 0 WHEN 'recv_packet' THEN 1 ELSE 2 END ASC, event.event_id ASC`,
+		chainPkey, srcPort, srcChannel, sequence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []PacketEventResult
+	for rows.Next() {
+		var (
+			res       PacketEventResult
+			createdAt string
+			encoding  string
+		)
+		if err := rows.Scan(
+			&res.ChainPKey,
+			&res.ChainID,
+			&res.Height,
+			&createdAt,
+			&res.TxID,
+			&res.Tx,
+			&encoding,
+			&res.Type,
+			&res.Sequence,
+			&res.SrcPort,
+			&res.SrcChannel,
+			&res.DstPort,
+			&res.DstChannel,
+			&res.TimeoutHeight,
+			&res.TimeoutTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		t, err := timeToLocal(createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse block createdAt: %w", err)
+		}
+		res.BlockTime = t
+		if res.Tx, err = decodeTxData(res.Tx, encoding); err != nil {
+			return nil, fmt.Errorf("tx %d: %w", res.TxID, err)
+		}
+		results = append(results, res)
+	}
 	return results, nil
 }
@@ -2,8 +2,10 @@ package blockdb
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -201,6 +203,47 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Equal(t, "3", string(results[2].Tx))
 	})
 
+	t.Run("failed txs", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		tc, err := CreateTestCase(ctx, db, "test", "abc123")
+		require.NoError(t, err)
+		chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+		require.NoError(t, err)
+
+		msg := func(typ string) []byte {
+			return []byte(fmt.Sprintf(`{"body":{"messages":[{"@type":%q}]}}`, typ))
+		}
+		require.NoError(t, chain.SaveBlock(ctx, 12, []Tx{
+			{Data: msg("/ibc.core.channel.v1.MsgRecvPacket")},
+			{Data: msg("/ibc.core.channel.v1.MsgRecvPacket"), Code: 18, Codespace: "sdk", Log: "packet already received"},
+			{Data: msg("/cosmos.bank.v1beta1.MsgSend"), Code: 5, Codespace: "sdk", Log: "insufficient funds"},
+		}))
+
+		results, err := NewQuery(db).Transactions(ctx, chain.id)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		require.Zero(t, results[0].Code)
+		require.Empty(t, results[0].Codespace)
+		require.Empty(t, results[0].RawLog)
+
+		require.EqualValues(t, 18, results[1].Code)
+		require.Equal(t, "sdk", results[1].Codespace)
+		require.Equal(t, "packet already received", results[1].RawLog)
+
+		// Failed messages of a type may be queried directly from the view.
+		var (
+			txID   int64
+			rawLog string
+		)
+		require.NoError(t, db.QueryRow(`SELECT tx_id, tx_raw_log FROM v_cosmos_messages
+    WHERE type = '/ibc.core.channel.v1.MsgRecvPacket' AND tx_code != 0`).Scan(&txID, &rawLog))
+		require.Equal(t, results[1].ID, txID)
+		require.Equal(t, "packet already received", rawLog)
+	})
+
 	t.Run("no txs", func(t *testing.T) {
 		db := migratedDB()
 		defer db.Close()
@@ -216,3 +259,548 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Len(t, results, 0)
 	})
 }
+
+func TestQuery_TestCase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	other, err := CreateTestCase(ctx, db, "test2", "sha2")
+	require.NoError(t, err)
+	_, err = other.AddChain(ctx, "chain-c", "cosmos")
+	require.NoError(t, err)
+
+	results, err := NewQuery(db).TestCase(ctx, tc.ID())
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	require.Equal(t, "test1", results[0].Name)
+	require.Equal(t, "chain-a", results[0].ChainID)
+	require.Equal(t, "chain-b", results[1].ChainID)
+
+	results, err = NewQuery(db).TestCase(ctx, 999)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_Blocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	require.NoError(t, chain.SaveBlock(ctx, 14, []Tx{{Data: []byte(`2`)}, {Data: []byte(`3`)}}))
+	require.NoError(t, chain.SaveBlock(ctx, 12, nil))
+
+	results, err := NewQuery(db).Blocks(ctx, chain.id)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+
+	require.EqualValues(t, 12, results[0].Height)
+	require.Zero(t, results[0].TxTotal)
+	require.WithinDuration(t, time.Now(), results[0].CreatedAt, 10*time.Second)
+
+	require.EqualValues(t, 14, results[1].Height)
+	require.EqualValues(t, 2, results[1].TxTotal)
+}
+
+func TestQuery_Tx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 12, []Tx{{Data: []byte(`1`)}, {Data: []byte(`2`)}}))
+
+	txs, err := NewQuery(db).Transactions(ctx, chain.id)
+	require.NoError(t, err)
+
+	res, err := NewQuery(db).Tx(ctx, txs[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, txs[1], res)
+
+	_, err = NewQuery(db).Tx(ctx, 999)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestQuery_TxsByHash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	q := NewQuery(db)
+	for _, name := range []string{"test1", "test2"} {
+		tc, err := CreateTestCase(ctx, db, name, "abc123")
+		require.NoError(t, err)
+		chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+		require.NoError(t, err)
+		require.NoError(t, chain.SaveBlock(ctx, 12, []Tx{
+			{Data: []byte(`{"tx":1}`), Hash: "abc1"},
+			{Data: []byte(`{"tx":2}`), Hash: "ABC2"},
+		}))
+	}
+
+	results, err := q.TxsByHash(ctx, "ABC1")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	got := results[0]
+	require.Equal(t, "chain-a", got.ChainID)
+	require.Equal(t, "ABC1", got.Hash)
+	require.EqualValues(t, 12, got.Height)
+	require.Equal(t, `{"tx":1}`, string(got.Tx))
+	require.Greater(t, got.TestCaseID, results[1].TestCaseID, "most recent test case first")
+
+	tx, err := q.Tx(ctx, got.ID)
+	require.NoError(t, err)
+	require.Equal(t, got.TxResult, tx)
+
+	results, err = q.TxsByHash(ctx, "abc2")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	results, err = q.TxsByHash(ctx, "nope")
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_GasStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	msgs := func(types ...string) []byte {
+		var b strings.Builder
+		for i, typ := range types {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(&b, `{"@type":%q}`, typ)
+		}
+		return []byte(`{"body":{"messages":[` + b.String() + `]}}`)
+	}
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{
+		{Data: msgs("/MsgSend"), GasWanted: 200, GasUsed: 100},
+		{Data: msgs("/MsgSend"), GasWanted: 400, GasUsed: 300},
+	}))
+	require.NoError(t, chain.SaveBlock(ctx, 2, []Tx{
+		// Counts once towards each message type.
+		{Data: msgs("/MsgSend", "/MsgSend", "/MsgVote"), GasWanted: 600, GasUsed: 500},
+		// Unknown gas is ignored.
+		{Data: msgs("/MsgSend")},
+	}))
+
+	// Other test cases are ignored.
+	tc2, err := CreateTestCase(ctx, db, "test2", "abc123")
+	require.NoError(t, err)
+	chain2, err := tc2.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain2.SaveBlock(ctx, 1, []Tx{{Data: msgs("/MsgSend"), GasWanted: 1, GasUsed: 1}}))
+
+	results, err := NewQuery(db).GasStats(ctx, tc.ID())
+	require.NoError(t, err)
+	require.Equal(t, []GasStatsResult{
+		{
+			ChainPKey:      chain.id,
+			ChainID:        "chain-a",
+			MsgType:        "/MsgSend",
+			TxCount:        3,
+			GasWantedTotal: 1200,
+			GasUsedTotal:   900,
+			GasUsedAvg:     300,
+			GasUsedMin:     100,
+			GasUsedMax:     500,
+		},
+		{
+			ChainPKey:      chain.id,
+			ChainID:        "chain-a",
+			MsgType:        "/MsgVote",
+			TxCount:        1,
+			GasWantedTotal: 600,
+			GasUsedTotal:   500,
+			GasUsedAvg:     500,
+			GasUsedMin:     500,
+			GasUsedMax:     500,
+		},
+	}, results)
+}
+
+func TestQuery_SearchTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	const (
+		transfer = `{"body":{"messages":[{"@type":"/ibc.applications.transfer.v1.MsgTransfer","source_channel":"channel-0"}]}}`
+		send     = `{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}`
+	)
+	require.NoError(t, chainA.SaveBlock(ctx, 5, []Tx{{Data: []byte(send)}, {Data: []byte(transfer)}}))
+	require.NoError(t, chainB.SaveBlock(ctx, 3, []Tx{{Data: []byte(transfer)}}))
+	require.NoError(t, chainB.SaveBlock(ctx, 4, []Tx{{Data: []byte(send)}}))
+
+	// Another test case's transactions are excluded.
+	other, err := CreateTestCase(ctx, db, "other", "abc123")
+	require.NoError(t, err)
+	otherChain, err := other.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, otherChain.SaveBlock(ctx, 1, []Tx{{Data: []byte(transfer)}}))
+
+	q := NewQuery(db)
+
+	results, err := q.SearchTransactions(ctx, tc.ID(), "msgtransfer")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, "chain-a", results[0].ChainID)
+	require.Equal(t, chainA.id, results[0].ChainPKey)
+	require.EqualValues(t, 5, results[0].Height)
+	require.Equal(t, transfer, string(results[0].Tx))
+	require.NotZero(t, results[0].ID)
+
+	require.Equal(t, "chain-b", results[1].ChainID)
+	require.EqualValues(t, 3, results[1].Height)
+
+	// All words must match.
+	results, err = q.SearchTransactions(ctx, tc.ID(), `MsgTransfer "channel-0`)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	results, err = q.SearchTransactions(ctx, tc.ID(), "MsgTransfer MsgSend")
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	results, err = q.SearchTransactions(ctx, tc.ID(), "  ")
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_Events(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	require.NoError(t, chain.SaveFullBlock(ctx, 11, Block{
+		EndBlockEvents: []Event{{Type: "complete_unbonding"}},
+		Txs: []Tx{
+			{Data: []byte(`1`), Events: []Event{{Type: "transfer"}, {Type: "message"}}},
+			{Data: []byte(`2`), Events: []Event{{Type: "transfer"}}},
+		},
+		BeginBlockEvents: []Event{{Type: "slash", Attributes: []EventAttribute{{Key: "address", Value: "val1"}}}},
+	}))
+	require.NoError(t, chain.SaveFullBlock(ctx, 10, Block{
+		BeginBlockEvents: []Event{{Type: "rewards"}},
+	}))
+
+	q := NewQuery(db)
+	results, err := q.Events(ctx, chain.id, "")
+	require.NoError(t, err)
+
+	type event struct {
+		Height int64
+		Phase  string
+		Index  int
+		Type   string
+	}
+	got := make([]event, len(results))
+	for i, res := range results {
+		got[i] = event{res.Height, res.Phase, res.Index, res.Type}
+	}
+	require.Equal(t, []event{
+		{10, "begin_block", 0, "rewards"},
+		{11, "begin_block", 0, "slash"},
+		{11, "tx", 0, "transfer"},
+		{11, "tx", 1, "message"},
+		{11, "tx", 0, "transfer"},
+		{11, "end_block", 0, "complete_unbonding"},
+	}, got)
+
+	require.Equal(t, []EventAttribute{{Key: "address", Value: "val1"}}, results[1].Attributes)
+	require.False(t, results[1].TxID.Valid)
+	require.True(t, results[2].TxID.Valid)
+	require.Less(t, results[2].TxID.Int64, results[4].TxID.Int64)
+
+	results, err = q.Events(ctx, chain.id, "transfer")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	results, err = q.Events(ctx, 999, "")
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_Packets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	// Sequence 1 is acknowledged, 2 times out, 3 is in flight.
+	// Chain B sends its own sequence 1 over identically named channels.
+	require.NoError(t, chainA.SaveBlock(ctx, 10, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventSend, "1", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventSend, "2", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventSend, "3", "channel-0", "channel-0"),
+	}}}))
+	require.NoError(t, chainB.SaveBlock(ctx, 20, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventRecv, "1", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventSend, "1", "channel-0", "channel-0"),
+	}}}))
+	require.NoError(t, chainA.SaveBlock(ctx, 12, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventAck, "1", "channel-0", "channel-0"),
+		packetTestEvent(PacketEventTimeout, "2", "channel-0", "channel-0"),
+	}}}))
+
+	q := NewQuery(db)
+	results, err := q.Packets(ctx, tc.ID())
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	got := results[0]
+	require.Equal(t, chainA.id, got.ChainPKey)
+	require.Equal(t, "chain-a", got.ChainID)
+	require.EqualValues(t, 1, got.Sequence)
+	require.Equal(t, "transfer", got.SrcPort)
+	require.Equal(t, "channel-0", got.SrcChannel)
+	require.Equal(t, "transfer", got.DstPort)
+	require.Equal(t, "channel-0", got.DstChannel)
+	require.EqualValues(t, 10, got.SendHeight)
+	require.Equal(t, "chain-b", got.RecvChainID.String)
+	require.EqualValues(t, 20, got.RecvHeight.Int64)
+	require.EqualValues(t, 12, got.AckHeight.Int64)
+	require.False(t, got.TimeoutHeight.Valid)
+
+	got = results[1]
+	require.EqualValues(t, 2, got.Sequence)
+	require.False(t, got.RecvHeight.Valid)
+	require.False(t, got.AckHeight.Valid)
+	require.EqualValues(t, 12, got.TimeoutHeight.Int64)
+
+	got = results[2]
+	require.EqualValues(t, 3, got.Sequence)
+	require.False(t, got.RecvHeight.Valid)
+	require.False(t, got.AckHeight.Valid)
+	require.False(t, got.TimeoutHeight.Valid)
+
+	// Chain A did not receive chain B's packet.
+	got = results[3]
+	require.Equal(t, "chain-b", got.ChainID)
+	require.EqualValues(t, 1, got.Sequence)
+	require.EqualValues(t, 20, got.SendHeight)
+	require.False(t, got.RecvHeight.Valid)
+	require.False(t, got.AckHeight.Valid)
+
+	t.Run("lifecycle", func(t *testing.T) {
+		events, err := q.PacketLifecycle(ctx, chainA.id, "transfer", "channel-0", 1)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+
+		require.Equal(t, PacketEventSend, events[0].Type)
+		require.Equal(t, "chain-a", events[0].ChainID)
+		require.EqualValues(t, 10, events[0].Height)
+		require.Equal(t, "1-1500", events[0].TimeoutHeight)
+		require.Equal(t, "1690000000000000000", events[0].TimeoutTimestamp)
+		require.Equal(t, []byte(`{}`), events[0].Tx)
+		require.WithinDuration(t, time.Now(), events[0].BlockTime, 10*time.Second)
+
+		require.Equal(t, PacketEventRecv, events[1].Type)
+		require.Equal(t, "chain-b", events[1].ChainID)
+		require.EqualValues(t, 20, events[1].Height)
+
+		require.Equal(t, PacketEventAck, events[2].Type)
+		require.Equal(t, "chain-a", events[2].ChainID)
+		require.EqualValues(t, 12, events[2].Height)
+
+		events, err = q.PacketLifecycle(ctx, chainB.id, "transfer", "channel-0", 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, PacketEventSend, events[0].Type)
+		require.Equal(t, "chain-b", events[0].ChainID)
+
+		events, err = q.PacketLifecycle(ctx, chainA.id, "transfer", "channel-0", 99)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+}
+
+func TestMigrate_BackfillsSearchIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{{Data: []byte(`{"memo":"needle"}`)}}))
+
+	// Simulate a database created before the search index existed.
+	for _, stmt := range []string{
+		`DROP TRIGGER tx_fts_insert`,
+		`DROP TRIGGER tx_fts_delete`,
+		`DROP TABLE tx_fts`,
+		`PRAGMA user_version = 0`,
+	} {
+		_, err = db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	// The backfill is part of the baseline; later steps were already applied.
+	require.NoError(t, migrate(db, "new-sha", schemaMigrations[:1]))
+
+	results, err := NewQuery(db).SearchTransactions(ctx, tc.ID(), "needle")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+}
+
+func TestMigrate_BackfillsPacketEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{{Data: []byte(`{}`), Events: []Event{
+		packetTestEvent(PacketEventSend, "1", "channel-0", "channel-1"),
+		packetTestEvent(PacketEventSend, "bad", "channel-0", "channel-1"),
+		{Type: PacketEventSend},
+		{Type: "transfer", Attributes: []EventAttribute{{Key: "packet_sequence", Value: "2"}}},
+	}}}))
+
+	// Simulate a database created before the packet table existed.
+	_, err = db.Exec(`DROP TABLE ibc_packet_event; PRAGMA user_version = 0`)
+	require.NoError(t, err)
+
+	// The backfill is part of the baseline; later steps were already applied.
+	require.NoError(t, migrate(db, "new-sha", schemaMigrations[:1]))
+
+	results, err := NewQuery(db).Packets(ctx, tc.ID())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.EqualValues(t, 1, results[0].Sequence)
+	require.Equal(t, "channel-1", results[0].DstChannel)
+
+	events, err := NewQuery(db).PacketLifecycle(ctx, chain.id, "transfer", "channel-0", 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "1-1500", events[0].TimeoutHeight)
+}
+
+func TestMigrate_BackfillsABCIEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	// Begin block events used to be saved as events of an artificial tx.
+	require.NoError(t, chain.SaveBlock(ctx, 1, []Tx{
+		{Data: []byte(`{}`), Events: []Event{
+			{Type: "transfer", Attributes: []EventAttribute{{Key: "amount", Value: "1stake"}, {Key: "sender", Value: "me"}}},
+			{Type: "message"},
+		}},
+		{Data: []byte(`{"data":"begin_block","note":"this is a transaction artificially created for debugging purposes"}`), Events: []Event{
+			{Type: "rewards"},
+		}},
+	}))
+
+	// Simulate a database created before the table existed.
+	_, err = db.Exec(`DROP TABLE abci_event; PRAGMA user_version = 0`)
+	require.NoError(t, err)
+
+	// The backfill is part of the baseline; later steps were already applied.
+	require.NoError(t, migrate(db, "new-sha", schemaMigrations[:1]))
+
+	results, err := NewQuery(db).Events(ctx, chain.id, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.Equal(t, "begin_block", results[0].Phase)
+	require.Equal(t, "rewards", results[0].Type)
+	require.False(t, results[0].TxID.Valid)
+	require.Empty(t, results[0].Attributes)
+
+	require.Equal(t, "tx", results[1].Phase)
+	require.Equal(t, 0, results[1].Index)
+	require.Equal(t, "transfer", results[1].Type)
+	require.Equal(t, []EventAttribute{{Key: "amount", Value: "1stake"}, {Key: "sender", Value: "me"}}, results[1].Attributes)
+	require.True(t, results[1].TxID.Valid)
+
+	require.Equal(t, "tx", results[2].Phase)
+	require.Equal(t, 1, results[2].Index)
+	require.Equal(t, "message", results[2].Type)
+}
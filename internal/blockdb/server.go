@@ -0,0 +1,467 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/version"
+)
+
+// defaultTestCaseLimit is the number of test case and chain combinations listed when the request has no limit.
+const defaultTestCaseLimit = 100
+
+// Serve serves a read-only JSON API over the sqlite database at databasePath on addr, until ctx is canceled.
+// See NewHandler for the endpoints.
+// Unlike ConnectDB, Serve returns an error if the database file does not exist.
+func Serve(ctx context.Context, databasePath, addr string) error {
+	return serve(ctx, databasePath, addr, NewHandler)
+}
+
+// ServeMetrics serves Prometheus metrics of the sqlite database at databasePath on addr at /metrics,
+// until ctx is canceled. See MetricsCollector for the metrics.
+// Like Serve, ServeMetrics returns an error if the database file does not exist.
+func ServeMetrics(ctx context.Context, databasePath, addr string) error {
+	return serve(ctx, databasePath, addr, func(q *Query) http.Handler {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", NewMetricsHandler(q))
+		return mux
+	})
+}
+
+// serve serves the handler returned by newHandler on addr, until ctx is canceled.
+func serve(ctx context.Context, databasePath, addr string, newHandler func(*Query) http.Handler) error {
+	// Explicitly check for file existence otherwise ConnectDB implicitly creates a sqlite file.
+	if _, err := os.Stat(databasePath); err != nil {
+		return err
+	}
+
+	db, err := ConnectDB(ctx, databasePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := Migrate(db, version.GitSha); err != nil {
+		return fmt.Errorf("migrate database %s: %w", databasePath, err)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:           newHandler(NewQuery(db)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// NewHandler returns a handler serving a read-only JSON API through q, with the endpoints:
+//
+//	GET /api/test_cases?limit=100          Recent test cases and their chains.
+//	GET /api/test_cases/{id}               A test case and its chains.
+//	GET /api/test_cases/{id}/search?q=...  Full-text search of the test case's txs; see Query.SearchTransactions.
+//	GET /api/test_cases/{id}/packets       IBC packets sent by the test case's chains; see Query.Packets.
+//	GET /api/chains/{id}/blocks            Blocks of a chain.
+//	GET /api/chains/{id}/txs               Transactions of a chain.
+//	GET /api/chains/{id}/messages          Cosmos messages of a chain.
+//	GET /api/chains/{id}/events?type=...   ABCI events of a chain, optionally of one type.
+//	GET /api/chains/{id}/validators?height=N  Validator set of a chain at a height, by default the latest.
+//	GET /api/chains/{id}/validator_updates    Changes to the validator set of a chain.
+//	GET /api/txs/{id}                      A single transaction.
+//
+// Chain IDs in paths are the chain primary key "chain.id", not to be confused with the column "chain_id".
+func NewHandler(q *Query) http.Handler {
+	return apiHandler{q: q}
+}
+
+type apiHandler struct {
+	q *Query
+}
+
+func (h apiHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, errors.New("only GET is supported"))
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, "/api"), "/")
+	parts := strings.Split(path, "/")
+
+	var (
+		v   any
+		err error
+	)
+	ctx := req.Context()
+	switch {
+	case len(parts) == 1 && parts[0] == "test_cases":
+		limit := defaultTestCaseLimit
+		if s := req.URL.Query().Get("limit"); s != "" {
+			if limit, err = strconv.Atoi(s); err != nil || limit <= 0 {
+				writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", s))
+				return
+			}
+		}
+		var results []TestCaseResult
+		results, err = h.q.RecentTestCases(ctx, limit)
+		v = newAPITestCases(results)
+
+	case len(parts) == 2 && parts[0] == "test_cases":
+		id, ok := parseAPIID(w, parts[1])
+		if !ok {
+			return
+		}
+		var results []TestCaseResult
+		if results, err = h.q.TestCase(ctx, id); err == nil && len(results) == 0 {
+			err = sql.ErrNoRows
+		}
+		if err == nil {
+			v = newAPITestCases(results)[0]
+		}
+
+	case len(parts) == 3 && parts[0] == "test_cases" && parts[2] == "search":
+		id, ok := parseAPIID(w, parts[1])
+		if !ok {
+			return
+		}
+		var results []TxSearchResult
+		results, err = h.q.SearchTransactions(ctx, id, req.URL.Query().Get("q"))
+		txs := make([]apiSearchResult, len(results))
+		for i, res := range results {
+			txs[i] = apiSearchResult{ChainPKey: res.ChainPKey, ChainID: res.ChainID, apiTx: newAPITx(res.TxResult)}
+		}
+		v = txs
+
+	case len(parts) == 3 && parts[0] == "test_cases" && parts[2] == "packets":
+		id, ok := parseAPIID(w, parts[1])
+		if !ok {
+			return
+		}
+		var results []PacketResult
+		results, err = h.q.Packets(ctx, id)
+		packets := make([]apiPacket, len(results))
+		for i, res := range results {
+			packets[i] = newAPIPacket(res)
+		}
+		v = packets
+
+	case len(parts) == 3 && parts[0] == "chains":
+		id, ok := parseAPIID(w, parts[1])
+		if !ok {
+			return
+		}
+		switch parts[2] {
+		case "blocks":
+			var results []BlockResult
+			results, err = h.q.Blocks(ctx, id)
+			blocks := make([]apiBlock, len(results))
+			for i, res := range results {
+				blocks[i] = apiBlock{
+					Height:          res.Height,
+					CreatedAt:       res.CreatedAt,
+					TxTotal:         res.TxTotal,
+					ProposerAddress: res.ProposerAddress,
+					ValidatorsHash:  res.ValidatorsHash,
+				}
+			}
+			v = blocks
+		case "txs":
+			var results []TxResult
+			results, err = h.q.Transactions(ctx, id)
+			txs := make([]apiTx, len(results))
+			for i, res := range results {
+				txs[i] = newAPITx(res)
+			}
+			v = txs
+		case "messages":
+			var results []CosmosMessageResult
+			results, err = h.q.CosmosMessages(ctx, id)
+			msgs := make([]apiCosmosMessage, len(results))
+			for i, res := range results {
+				msgs[i] = newAPICosmosMessage(res)
+			}
+			v = msgs
+		case "events":
+			var results []EventResult
+			results, err = h.q.Events(ctx, id, req.URL.Query().Get("type"))
+			events := make([]apiEvent, len(results))
+			for i, res := range results {
+				events[i] = newAPIEvent(res)
+			}
+			v = events
+		case "validators":
+			height := int64(math.MaxInt64)
+			if s := req.URL.Query().Get("height"); s != "" {
+				height, err = strconv.ParseInt(s, 10, 64)
+				if err != nil {
+					writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid height %q", s))
+					return
+				}
+			}
+			var results []ValidatorResult
+			results, err = h.q.ValidatorSet(ctx, id, height)
+			vals := make([]apiValidator, len(results))
+			for i, res := range results {
+				vals[i] = apiValidator{Address: res.Address, VotingPower: res.VotingPower}
+			}
+			v = vals
+		case "validator_updates":
+			var results []ValidatorUpdateResult
+			results, err = h.q.ValidatorUpdates(ctx, id)
+			updates := make([]apiValidatorUpdate, len(results))
+			for i, res := range results {
+				updates[i] = apiValidatorUpdate{Height: res.Height, Address: res.Address, VotingPower: res.VotingPower}
+			}
+			v = updates
+		default:
+			writeAPIError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", req.URL.Path))
+			return
+		}
+
+	case len(parts) == 2 && parts[0] == "txs":
+		id, ok := parseAPIID(w, parts[1])
+		if !ok {
+			return
+		}
+		var res TxResult
+		res, err = h.q.Tx(ctx, id)
+		v = newAPITx(res)
+
+	default:
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", req.URL.Path))
+		return
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		writeAPIError(w, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func parseAPIID(w http.ResponseWriter, s string) (int64, bool) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid id %q", s))
+		return 0, false
+	}
+	return id, true
+}
+
+func writeAPIError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+type apiTestCase struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	GitSha    string     `json:"git_sha"`
+	CreatedAt time.Time  `json:"created_at"`
+	Chains    []apiChain `json:"chains"`
+}
+
+type apiChain struct {
+	ID        int64  `json:"id"`
+	ChainID   string `json:"chain_id"`
+	ChainType string `json:"chain_type"`
+	Height    int64  `json:"height"`
+	TxTotal   int64  `json:"tx_total"`
+}
+
+// newAPITestCases groups the results, which are ordered by test case, into test cases with their chains.
+func newAPITestCases(results []TestCaseResult) []apiTestCase {
+	tcs := []apiTestCase{}
+	for _, res := range results {
+		if len(tcs) == 0 || tcs[len(tcs)-1].ID != res.ID {
+			tcs = append(tcs, apiTestCase{
+				ID:        res.ID,
+				Name:      res.Name,
+				GitSha:    res.GitSha,
+				CreatedAt: res.CreatedAt,
+			})
+		}
+		tc := &tcs[len(tcs)-1]
+		tc.Chains = append(tc.Chains, apiChain{
+			ID:        res.ChainPKey,
+			ChainID:   res.ChainID,
+			ChainType: res.ChainType,
+			Height:    res.ChainHeight.Int64,
+			TxTotal:   res.TxTotal.Int64,
+		})
+	}
+	return tcs
+}
+
+type apiBlock struct {
+	Height          int64     `json:"height"`
+	CreatedAt       time.Time `json:"created_at"`
+	TxTotal         int64     `json:"tx_total"`
+	ProposerAddress string    `json:"proposer_address"`
+	ValidatorsHash  string    `json:"validators_hash"`
+}
+
+type apiValidator struct {
+	Address     string `json:"address"`
+	VotingPower int64  `json:"voting_power"`
+}
+
+type apiValidatorUpdate struct {
+	Height      int64  `json:"height"`
+	Address     string `json:"address"`
+	VotingPower int64  `json:"voting_power"`
+}
+
+type apiTx struct {
+	ID     int64  `json:"id"`
+	Height int64  `json:"height"`
+	Hash   string `json:"hash,omitempty"`
+	// Tx is the tx JSON, or a JSON string of the data if it is not valid JSON.
+	Tx json.RawMessage `json:"tx"`
+	// Code is 0 if the tx succeeded.
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace,omitempty"`
+	RawLog    string `json:"raw_log,omitempty"`
+}
+
+func newAPITx(res TxResult) apiTx {
+	data := json.RawMessage(res.Tx)
+	if !json.Valid(data) {
+		// Marshaling a string should never fail.
+		data, _ = json.Marshal(string(res.Tx))
+	}
+	return apiTx{ID: res.ID, Height: res.Height, Hash: res.Hash, Tx: data, Code: res.Code, Codespace: res.Codespace, RawLog: res.RawLog}
+}
+
+type apiSearchResult struct {
+	ChainPKey int64  `json:"chain_pkey"`
+	ChainID   string `json:"chain_id"`
+	apiTx
+}
+
+type apiEvent struct {
+	Height     int64            `json:"height"`
+	TxID       *int64           `json:"tx_id,omitempty"`
+	Phase      string           `json:"phase"`
+	Index      int              `json:"index"`
+	Type       string           `json:"type"`
+	Attributes []EventAttribute `json:"attributes"`
+}
+
+func newAPIEvent(res EventResult) apiEvent {
+	e := apiEvent{
+		Height:     res.Height,
+		Phase:      res.Phase,
+		Index:      res.Index,
+		Type:       res.Type,
+		Attributes: res.Attributes,
+	}
+	if res.TxID.Valid {
+		e.TxID = &res.TxID.Int64
+	}
+	return e
+}
+
+type apiPacket struct {
+	ChainPKey  int64  `json:"chain_pkey"`
+	ChainID    string `json:"chain_id"`
+	Sequence   uint64 `json:"sequence"`
+	SrcPort    string `json:"src_port"`
+	SrcChannel string `json:"src_channel"`
+	DstPort    string `json:"dst_port"`
+	DstChannel string `json:"dst_channel"`
+	SendHeight int64  `json:"send_height"`
+
+	// Heights of later lifecycle stages are omitted if the stage was not observed.
+	RecvChainID   string `json:"recv_chain_id,omitempty"`
+	RecvHeight    *int64 `json:"recv_height,omitempty"`
+	AckHeight     *int64 `json:"ack_height,omitempty"`
+	TimeoutHeight *int64 `json:"timeout_height,omitempty"`
+}
+
+func newAPIPacket(res PacketResult) apiPacket {
+	height := func(h sql.NullInt64) *int64 {
+		if !h.Valid {
+			return nil
+		}
+		return &h.Int64
+	}
+	return apiPacket{
+		ChainPKey:     res.ChainPKey,
+		ChainID:       res.ChainID,
+		Sequence:      res.Sequence,
+		SrcPort:       res.SrcPort,
+		SrcChannel:    res.SrcChannel,
+		DstPort:       res.DstPort,
+		DstChannel:    res.DstChannel,
+		SendHeight:    res.SendHeight,
+		RecvChainID:   res.RecvChainID.String,
+		RecvHeight:    height(res.RecvHeight),
+		AckHeight:     height(res.AckHeight),
+		TimeoutHeight: height(res.TimeoutHeight),
+	}
+}
+
+type apiCosmosMessage struct {
+	Height int64  `json:"height"`
+	Index  int    `json:"index"`
+	Type   string `json:"type"`
+
+	ClientChainID         string `json:"client_chain_id,omitempty"`
+	ClientID              string `json:"client_id,omitempty"`
+	CounterpartyClientID  string `json:"counterparty_client_id,omitempty"`
+	ConnID                string `json:"conn_id,omitempty"`
+	CounterpartyConnID    string `json:"counterparty_conn_id,omitempty"`
+	PortID                string `json:"port_id,omitempty"`
+	CounterpartyPortID    string `json:"counterparty_port_id,omitempty"`
+	ChannelID             string `json:"channel_id,omitempty"`
+	CounterpartyChannelID string `json:"counterparty_channel_id,omitempty"`
+}
+
+func newAPICosmosMessage(res CosmosMessageResult) apiCosmosMessage {
+	return apiCosmosMessage{
+		Height:                res.Height,
+		Index:                 res.Index,
+		Type:                  res.Type,
+		ClientChainID:         res.ClientChainID.String,
+		ClientID:              res.ClientID.String,
+		CounterpartyClientID:  res.CounterpartyClientID.String,
+		ConnID:                res.ConnID.String,
+		CounterpartyConnID:    res.CounterpartyConnID.String,
+		PortID:                res.PortID.String,
+		CounterpartyPortID:    res.CounterpartyPortID.String,
+		ChannelID:             res.ChannelID.String,
+		CounterpartyChannelID: res.CounterpartyChannelID.String,
+	}
+}
@@ -0,0 +1,239 @@
+package blockdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getJSON(t *testing.T, srv *httptest.Server, path string, wantCode int, v any) {
+	t.Helper()
+
+	res, err := http.Get(srv.URL + path)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, wantCode, res.StatusCode, path)
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(res.Body).Decode(v))
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "SomeTest", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, chain.SaveBlock(ctx, 5, nil))
+	require.NoError(t, chain.SaveBlock(ctx, 6, []Tx{
+		{Data: []byte(`{"body":{"messages":[{"@type":"/ibc.applications.transfer.v1.MsgTransfer","source_channel":"channel-0"}]}}`)},
+		{Data: []byte(`{"body":{"messages":[]}}`)},
+	}))
+	require.NoError(t, chainB.SaveBlock(ctx, 1, []Tx{{Data: []byte(`not json`)}}))
+
+	srv := httptest.NewServer(NewHandler(NewQuery(db)))
+	defer srv.Close()
+
+	t.Run("test cases", func(t *testing.T) {
+		var tcs []apiTestCase
+		getJSON(t, srv, "/api/test_cases", http.StatusOK, &tcs)
+		require.Len(t, tcs, 1)
+		require.Equal(t, tc.ID(), tcs[0].ID)
+		require.Equal(t, "SomeTest", tcs[0].Name)
+		require.Len(t, tcs[0].Chains, 2)
+		require.Equal(t, apiChain{ID: chain.id, ChainID: "chain-a", ChainType: "cosmos", Height: 6, TxTotal: 2}, tcs[0].Chains[0])
+
+		var one apiTestCase
+		getJSON(t, srv, fmt.Sprintf("/api/test_cases/%d", tc.ID()), http.StatusOK, &one)
+		require.Equal(t, tcs[0], one)
+
+		var apiErr struct{ Error string }
+		getJSON(t, srv, "/api/test_cases/999", http.StatusNotFound, &apiErr)
+		require.Equal(t, "not found", apiErr.Error)
+		getJSON(t, srv, "/api/test_cases?limit=abc", http.StatusBadRequest, &apiErr)
+	})
+
+	t.Run("blocks", func(t *testing.T) {
+		var blocks []apiBlock
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/blocks", chain.id), http.StatusOK, &blocks)
+		require.Len(t, blocks, 2)
+		require.EqualValues(t, 5, blocks[0].Height)
+		require.EqualValues(t, 0, blocks[0].TxTotal)
+		require.EqualValues(t, 6, blocks[1].Height)
+		require.EqualValues(t, 2, blocks[1].TxTotal)
+	})
+
+	t.Run("txs", func(t *testing.T) {
+		var txs []apiTx
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/txs", chain.id), http.StatusOK, &txs)
+		require.Len(t, txs, 2)
+		require.Contains(t, string(txs[0].Tx), "MsgTransfer")
+		require.JSONEq(t, `{"body":{"messages":[]}}`, string(txs[1].Tx))
+
+		var tx apiTx
+		getJSON(t, srv, fmt.Sprintf("/api/txs/%d", txs[0].ID), http.StatusOK, &tx)
+		require.Equal(t, txs[0], tx)
+
+		var results []apiSearchResult
+		getJSON(t, srv, fmt.Sprintf("/api/test_cases/%d/search?q=msgtransfer", tc.ID()), http.StatusOK, &results)
+		require.Len(t, results, 1)
+		require.Equal(t, "chain-a", results[0].ChainID)
+		require.Equal(t, txs[0].ID, results[0].ID)
+
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/txs", chainB.id), http.StatusOK, &txs)
+		require.Len(t, txs, 1)
+		require.JSONEq(t, `"not json"`, string(txs[0].Tx), "invalid JSON is a string")
+	})
+
+	t.Run("messages", func(t *testing.T) {
+		var msgs []apiCosmosMessage
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/messages", chain.id), http.StatusOK, &msgs)
+		require.Len(t, msgs, 1)
+		require.Equal(t, "/ibc.applications.transfer.v1.MsgTransfer", msgs[0].Type)
+		require.Equal(t, "channel-0", msgs[0].ChannelID)
+	})
+
+	t.Run("packets", func(t *testing.T) {
+		require.NoError(t, chain.SaveBlock(ctx, 7, []Tx{{Data: []byte(`{}`), Events: []Event{
+			packetTestEvent(PacketEventSend, "1", "channel-0", "channel-1"),
+		}}}))
+		require.NoError(t, chainB.SaveBlock(ctx, 2, []Tx{{Data: []byte(`{}`), Events: []Event{
+			packetTestEvent(PacketEventRecv, "1", "channel-0", "channel-1"),
+		}}}))
+
+		var packets []apiPacket
+		getJSON(t, srv, fmt.Sprintf("/api/test_cases/%d/packets", tc.ID()), http.StatusOK, &packets)
+		require.Len(t, packets, 1)
+		got := packets[0]
+		require.Equal(t, "chain-a", got.ChainID)
+		require.EqualValues(t, 1, got.Sequence)
+		require.EqualValues(t, 7, got.SendHeight)
+		require.Equal(t, "chain-b", got.RecvChainID)
+		require.EqualValues(t, 2, *got.RecvHeight)
+		require.Nil(t, got.AckHeight)
+		require.Nil(t, got.TimeoutHeight)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		require.NoError(t, chain.SaveFullBlock(ctx, 8, Block{
+			BeginBlockEvents: []Event{{Type: "slash", Attributes: []EventAttribute{{Key: "address", Value: "val1"}}}},
+		}))
+
+		var events []apiEvent
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/events?type=slash", chain.id), http.StatusOK, &events)
+		require.Equal(t, []apiEvent{{
+			Height:     8,
+			Phase:      "begin_block",
+			Type:       "slash",
+			Attributes: []EventAttribute{{Key: "address", Value: "val1"}},
+		}}, events)
+	})
+
+	t.Run("validators", func(t *testing.T) {
+		require.NoError(t, chainB.SaveFullBlock(ctx, 10, Block{
+			ProposerAddress: "AAAA",
+			ValidatorsHash:  "HASH1",
+			Validators:      []Validator{{Address: "AAAA", VotingPower: 10}, {Address: "BBBB", VotingPower: 5}},
+		}))
+		require.NoError(t, chainB.SaveFullBlock(ctx, 12, Block{
+			ValidatorsHash: "HASH2",
+			Validators:     []Validator{{Address: "AAAA", VotingPower: 10}},
+		}))
+
+		var blocks []apiBlock
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/blocks", chainB.id), http.StatusOK, &blocks)
+		got := blocks[len(blocks)-2]
+		require.EqualValues(t, 10, got.Height)
+		require.Equal(t, "AAAA", got.ProposerAddress)
+		require.Equal(t, "HASH1", got.ValidatorsHash)
+
+		var vals []apiValidator
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/validators?height=11", chainB.id), http.StatusOK, &vals)
+		require.Equal(t, []apiValidator{{Address: "AAAA", VotingPower: 10}, {Address: "BBBB", VotingPower: 5}}, vals)
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/validators", chainB.id), http.StatusOK, &vals)
+		require.Equal(t, []apiValidator{{Address: "AAAA", VotingPower: 10}}, vals)
+
+		var updates []apiValidatorUpdate
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/validator_updates", chainB.id), http.StatusOK, &updates)
+		require.Equal(t, []apiValidatorUpdate{
+			{Height: 10, Address: "AAAA", VotingPower: 10},
+			{Height: 10, Address: "BBBB", VotingPower: 5},
+			{Height: 12, Address: "BBBB", VotingPower: 0},
+		}, updates)
+
+		var apiErr struct{ Error string }
+		getJSON(t, srv, fmt.Sprintf("/api/chains/%d/validators?height=abc", chainB.id), http.StatusBadRequest, &apiErr)
+	})
+
+	t.Run("empty results are arrays", func(t *testing.T) {
+		res, err := http.Get(srv.URL + "/api/chains/999/blocks")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		var raw json.RawMessage
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&raw))
+		require.Equal(t, "[]", string(raw))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var apiErr struct{ Error string }
+		getJSON(t, srv, "/api/unknown", http.StatusNotFound, &apiErr)
+		getJSON(t, srv, "/api/chains/abc/txs", http.StatusBadRequest, &apiErr)
+
+		res, err := http.Post(srv.URL+"/api/test_cases", "application/json", nil)
+		require.NoError(t, err)
+		res.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dbPath := filepath.Join(t.TempDir(), "blocks.db")
+	require.Error(t, Serve(ctx, dbPath, "localhost:0"), "database must exist")
+
+	db, err := ConnectDB(ctx, dbPath)
+	require.NoError(t, err)
+	require.NoError(t, Migrate(db, "abc123"))
+	require.NoError(t, db.Close())
+
+	// Reserve a free port.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- Serve(ctx, dbPath, addr) }()
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get("http://" + addr + "/api/test_cases")
+		if err != nil {
+			return false
+		}
+		res.Body.Close()
+		return res.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
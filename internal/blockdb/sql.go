@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"time"
@@ -11,17 +12,37 @@ import (
 	_ "modernc.org/sqlite"
 )
 
+// busyTimeout is how long a connection waits for another connection, such as of a test binary
+// running in parallel against the same file, to release its lock before failing with "database is locked".
+const busyTimeout = 10 * time.Second
+
 // ConnectDB connects to the sqlite database at databasePath.
 // Auto-creates directory path via MkdirAll.
 // Pings database once to ensure connection.
 // Pass :memory: as databasePath for in-memory database.
+//
+// Several processes, e.g. test binaries of packages run in parallel, may connect to the same file.
+// Each connection waits for the locks of the others; see also Migrate and TestCase.Finish.
 func ConnectDB(ctx context.Context, databasePath string) (*sql.DB, error) {
 	if databasePath != ":memory:" {
 		if err := os.MkdirAll(filepath.Dir(databasePath), 0755); err != nil {
 			return nil, err
 		}
 	}
-	db, err := sql.Open("sqlite", databasePath)
+	params := url.Values{
+		// If locked, sleep and try again, up until the timeout.
+		// Set per connection, so that connections reopened by database/sql wait too.
+		//
+		// https://www.sqlite.org/pragma.html#pragma_busy_timeout
+		"_pragma": {fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds())},
+		// Transactions take the write lock when they begin, rather than upgrading from a read lock
+		// when they first write. In WAL mode, an upgrade fails immediately, without waiting for the busy timeout,
+		// if another connection wrote since the transaction began.
+		//
+		// https://www.sqlite.org/lang_transaction.html#deferred_immediate_and_exclusive_transactions
+		"_txlock": {"immediate"},
+	}
+	db, err := sql.Open("sqlite", databasePath+"?"+params.Encode())
 	if err != nil {
 		return nil, fmt.Errorf("open db %s: %w", databasePath, err)
 	}
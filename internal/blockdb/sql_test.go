@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -146,3 +147,81 @@ func TestDB_Concurrency(t *testing.T) {
 
 	require.Len(t, tcs, nTestCases*nWriters, "incorrect count on final written test cases")
 }
+
+// Test that test binaries of packages run in parallel can save to the same database file,
+// each with its own connection, as the chain sets of those binaries do.
+func TestDB_ConcurrentTestPackages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "packages.db")
+
+	const nPackages = 4
+	const nBlocks = 200
+
+	dbs := make([]*sql.DB, nPackages)
+	for i := range dbs {
+		// Connecting in the main goroutine, as in TestDB_Concurrency.
+		db, err := ConnectDB(ctx, dbPath)
+		require.NoError(t, err)
+		defer db.Close()
+		dbs[i] = db
+	}
+
+	var eg errgroup.Group
+	tcs := make([]*TestCase, nPackages)
+	for i, db := range dbs {
+		i, db := i, db
+		eg.Go(func() error {
+			// Each package is built at a different commit, and prunes before creating its test case.
+			if err := Migrate(db, fmt.Sprintf("sha%d", i)); err != nil {
+				return fmt.Errorf("package %d: migrate: %w", i, err)
+			}
+			if _, err := Prune(ctx, db, RetentionPolicy{MaxTestCases: 1}); err != nil {
+				return fmt.Errorf("package %d: prune: %w", i, err)
+			}
+			tc, err := CreateTestCase(ctx, db, fmt.Sprintf("TestPackage%d", i), "abc123")
+			if err != nil {
+				return fmt.Errorf("package %d: create test case: %w", i, err)
+			}
+			tcs[i] = tc
+			chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+			if err != nil {
+				return fmt.Errorf("package %d: add chain: %w", i, err)
+			}
+
+			w := NewBatchWriter(zap.NewNop(), chain, 10)
+			for h := uint64(1); h <= nBlocks; h++ {
+				block := Block{Txs: []Tx{{Data: []byte(fmt.Sprintf(`{"height":%d}`, h))}}}
+				if err := w.SaveFullBlock(ctx, h, block); err != nil {
+					return fmt.Errorf("package %d: save block %d: %w", i, h, err)
+				}
+				if _, err := Prune(ctx, db, RetentionPolicy{MaxTestCases: 1}); err != nil {
+					return fmt.Errorf("package %d: prune: %w", i, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				return fmt.Errorf("package %d: %w", i, err)
+			}
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	// No package pruned the test cases of the others while they were running.
+	db := dbs[0]
+	require.Equal(t, nPackages, countRows(t, db, "test_case"))
+	require.Equal(t, nPackages*nBlocks, countRows(t, db, "block"))
+	require.Equal(t, nPackages*nBlocks, countRows(t, db, "tx"))
+
+	for _, tc := range tcs {
+		require.NoError(t, tc.Finish(ctx))
+	}
+	n, err := Prune(ctx, db, RetentionPolicy{MaxTestCases: 1})
+	require.NoError(t, err)
+	require.Equal(t, nPackages-1, n)
+}
@@ -0,0 +1,55 @@
+package blockdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SaveStateExport saves the app state exported at height, replacing any state previously saved for the height.
+// The export is a genesis document with an "app_state" of modules,
+// such as the output of ibc.Chain.ExportState or a chain binary's "export" command.
+func (chain *Chain) SaveStateExport(ctx context.Context, height int64, export []byte) error {
+	var doc struct {
+		AppState map[string]json.RawMessage `json:"app_state"`
+	}
+	if err := json.Unmarshal(export, &doc); err != nil {
+		return fmt.Errorf("decode state export: %w", err)
+	}
+	if len(doc.AppState) == 0 {
+		return errors.New("state export has no app_state")
+	}
+
+	modules := make([]string, 0, len(doc.AppState))
+	for module := range doc.AppState {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	dbTx, err := chain.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	d := chain.dialect
+	if _, err := d.exec(ctx, dbTx, `DELETE FROM state_export WHERE height = ? AND fk_chain_id = ?`, height, chain.id); err != nil {
+		return fmt.Errorf("delete from state_export: %w", err)
+	}
+	for _, module := range modules {
+		var data bytes.Buffer
+		if err := json.Compact(&data, doc.AppState[module]); err != nil {
+			return fmt.Errorf("compact module %s state: %w", module, err)
+		}
+		_, err := d.exec(ctx, dbTx, `INSERT INTO state_export(height, module, data, fk_chain_id) VALUES (?, ?, ?, ?)`,
+			height, module, data.String(), chain.id)
+		if err != nil {
+			return fmt.Errorf("insert into state_export: %w", err)
+		}
+	}
+
+	return dbTx.Commit()
+}
@@ -0,0 +1,45 @@
+package blockdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_SaveStateExport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	chain := validChain(t, db)
+	q := NewQuery(db)
+
+	require.Error(t, chain.SaveStateExport(ctx, 10, []byte(`not json`)))
+	require.Error(t, chain.SaveStateExport(ctx, 10, []byte(`{"chain_id":"chain1"}`)))
+
+	require.NoError(t, chain.SaveStateExport(ctx, 10, []byte(`{"app_state":{"bank":{"supply":[]},"gov":{}}}`)))
+	// Saving again replaces the state at the height.
+	require.NoError(t, chain.SaveStateExport(ctx, 10, []byte(`{"chain_id":"chain1","app_state":{
+  "bank": {"supply": [{"denom": "uatom", "amount": "10"}]},
+  "staking": {"params": {}}
+}}`)))
+	require.NoError(t, chain.SaveStateExport(ctx, 20, []byte(`{"app_state":{"bank":{"supply":[]}}}`)))
+
+	heights, err := q.StateExportHeights(ctx, chain.id)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 20}, heights)
+
+	state, err := q.StateExport(ctx, chain.id, 10)
+	require.NoError(t, err)
+	require.Len(t, state, 2)
+	require.JSONEq(t, `{"supply":[{"denom":"uatom","amount":"10"}]}`, string(state["bank"]))
+	require.JSONEq(t, `{"params":{}}`, string(state["staking"]))
+
+	state, err = q.StateExport(ctx, chain.id, 15)
+	require.NoError(t, err)
+	require.Empty(t, state)
+}
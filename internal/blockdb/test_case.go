@@ -7,40 +7,52 @@ import (
 
 // TestCase is a single test invocation.
 type TestCase struct {
-	db *sql.DB
-	id int64
+	db      *sql.DB
+	dialect dialect
+	id      int64
 }
 
 // CreateTestCase starts tracking new test case with testName.
 func CreateTestCase(ctx context.Context, db *sql.DB, testName, gitSha string) (*TestCase, error) {
-	res, err := db.ExecContext(ctx, `INSERT INTO test_case(name, created_at, git_sha) VALUES(?, ?, ?)`, testName, nowRFC3339(), gitSha)
-	if err != nil {
-		return nil, err
-	}
-	id, err := res.LastInsertId()
+	return createTestCase(ctx, db, dialectSQLite, testName, gitSha)
+}
+
+func createTestCase(ctx context.Context, db *sql.DB, d dialect, testName, gitSha string) (*TestCase, error) {
+	id, err := d.insert(ctx, db, `INSERT INTO test_case(name, created_at, git_sha) VALUES(?, ?, ?)`, testName, nowRFC3339(), gitSha)
 	if err != nil {
 		return nil, err
 	}
 	return &TestCase{
-		db: db,
-		id: id,
+		db:      db,
+		dialect: d,
+		id:      id,
 	}, nil
 }
 
+// ID is the primary key of the test case in the test_case table.
+func (tc *TestCase) ID() int64 {
+	return tc.id
+}
+
+// Finish records that the test case finished, e.g. when the test ends and its blocks are saved.
+// Until then, Prune keeps the test case for a day after it was created, because its test may still be running,
+// such as in another test package saving to the same database.
+func (tc *TestCase) Finish(ctx context.Context) error {
+	_, err := tc.dialect.exec(ctx, tc.db, `UPDATE test_case SET finished_at = ? WHERE id = ?`, nowRFC3339(), tc.id)
+	return err
+}
+
 // AddChain tracks and attaches a chain to the test case.
 // The chainID must be unique per test case. E.g. osmosis-1001, cosmos-1004
 // The chainType denotes which ecosystem the chain belongs to. E.g. cosmos, penumbra, composable, etc.
 func (tc *TestCase) AddChain(ctx context.Context, chainID, chainType string) (*Chain, error) {
-	res, err := tc.db.ExecContext(ctx, `INSERT INTO chain(chain_id, chain_type, fk_test_id) VALUES(?, ?, ?)`, chainID, chainType, tc.id)
-	if err != nil {
-		return nil, err
-	}
-	id, err := res.LastInsertId()
+	id, err := tc.dialect.insert(ctx, tc.db, `INSERT INTO chain(chain_id, chain_type, fk_test_id) VALUES(?, ?, ?)`, chainID, chainType, tc.id)
 	if err != nil {
 		return nil, err
 	}
 	return &Chain{
-		id: id,
-		db: tc.db,
+		id:      id,
+		db:      tc.db,
+		dialect: tc.dialect,
 	}, nil
 }
@@ -21,15 +21,17 @@ func TestCreateTestCase(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, tc)
 
-		row := db.QueryRow(`SELECT name, created_at, git_sha FROM test_case LIMIT 1`)
+		row := db.QueryRow(`SELECT id, name, created_at, git_sha FROM test_case LIMIT 1`)
 		var (
+			gotID   int64
 			gotName string
 			gotTime string
 			gotSha  string
 		)
-		err = row.Scan(&gotName, &gotTime, &gotSha)
+		err = row.Scan(&gotID, &gotName, &gotTime, &gotSha)
 		require.NoError(t, err)
 
+		require.Equal(t, gotID, tc.ID())
 		require.Equal(t, "SomeTest", gotName)
 		require.Equal(t, "abc123", gotSha)
 
@@ -0,0 +1,43 @@
+-- The schema of the first released block database, before chains had a type or txs had events.
+CREATE TABLE schema_version(
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    git_sha TEXT NOT NULL CHECK (length(git_sha) > 0),
+    UNIQUE(git_sha)
+);
+CREATE TABLE test_case (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL CHECK ( length(name) > 0 ),
+    git_sha TEXT NOT NULL CHECK ( length(git_sha) > 0 ),
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    UNIQUE(name,created_at)
+);
+CREATE TABLE chain (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    chain_id TEXT NOT NULL CHECK ( length(chain_id) > 0 ),
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE,
+    UNIQUE(chain_id,fk_test_id)
+);
+CREATE TABLE block (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    height INTEGER NOT NULL CHECK (length(height > 0)),
+    fk_chain_id INTEGER,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    FOREIGN KEY(fk_chain_id) REFERENCES chain(id) ON DELETE CASCADE,
+    UNIQUE(height,fk_chain_id)
+);
+CREATE TABLE tx (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    data TEXT NOT NULL CHECK (length(data > 0)),
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+);
+
+INSERT INTO schema_version(created_at, git_sha) VALUES ('2022-06-01T00:00:00Z', 'initial');
+INSERT INTO test_case(name, git_sha, created_at) VALUES ('TestInitial', 'initial', '2022-06-01T00:00:00Z');
+INSERT INTO chain(chain_id, fk_test_id) VALUES ('gaia-1', 1);
+INSERT INTO block(height, fk_chain_id, created_at) VALUES (1, 1, '2022-06-01T00:00:01Z');
+INSERT INTO block(height, fk_chain_id, created_at) VALUES (2, 1, '2022-06-01T00:00:02Z');
+INSERT INTO tx(data, fk_block_id) VALUES ('{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}],"memo":"needle"}}', 1);
+INSERT INTO tx(data, fk_block_id) VALUES ('{"body":{"messages":[{"@type":"/ibc.applications.transfer.v1.MsgTransfer"}]}}', 2);
@@ -0,0 +1,69 @@
+-- The schema of the last block database written before migrations were versioned, i.e. with user_version 0,
+-- with tendermint events of txs and begin block events saved as events of an artificial tx.
+CREATE TABLE schema_version(
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    git_sha TEXT NOT NULL CHECK (length(git_sha) > 0),
+    UNIQUE(git_sha)
+);
+CREATE TABLE test_case (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL CHECK ( length(name) > 0 ),
+    git_sha TEXT NOT NULL CHECK ( length(git_sha) > 0 ),
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    UNIQUE(name,created_at)
+);
+CREATE TABLE chain (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    chain_id TEXT NOT NULL CHECK ( length(chain_id) > 0 ),
+    fk_test_id INTEGER, chain_type TEXT NOT NULL check(length(chain_type) > 0) DEFAULT "unknown",
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE,
+    UNIQUE(chain_id,fk_test_id)
+);
+CREATE TABLE block (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    height INTEGER NOT NULL CHECK (length(height > 0)),
+    fk_chain_id INTEGER,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    FOREIGN KEY(fk_chain_id) REFERENCES chain(id) ON DELETE CASCADE,
+    UNIQUE(height,fk_chain_id)
+);
+CREATE TABLE tx (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    data TEXT NOT NULL CHECK (length(data > 0)),
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+);
+CREATE TABLE tendermint_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    fk_tx_id INTEGER,
+    FOREIGN KEY(fk_tx_id) REFERENCES tx(id) ON DELETE CASCADE
+);
+CREATE TABLE tendermint_event_attr (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    key TEXT NOT NULL CHECK (length(key) > 0),
+    value TEXT NOT NULL,
+    fk_event_id INTEGER,
+    FOREIGN KEY(fk_event_id) REFERENCES tendermint_event(id) ON DELETE CASCADE
+);
+-- Views are recreated by each migration.
+CREATE VIEW v_tx_flattened AS SELECT tx.id AS tx_id, tx.data AS tx FROM tx;
+
+INSERT INTO schema_version(created_at, git_sha) VALUES ('2023-01-01T00:00:00Z', 'unversioned');
+INSERT INTO test_case(name, git_sha, created_at) VALUES ('TestUnversioned', 'unversioned', '2023-01-01T00:00:00Z');
+INSERT INTO chain(chain_id, chain_type, fk_test_id) VALUES ('gaia-1', 'cosmos', 1);
+INSERT INTO block(height, fk_chain_id, created_at) VALUES (1, 1, '2023-01-01T00:00:01Z');
+INSERT INTO block(height, fk_chain_id, created_at) VALUES (2, 1, '2023-01-01T00:00:02Z');
+INSERT INTO tx(data, fk_block_id) VALUES ('{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}],"memo":"needle"}}', 1);
+INSERT INTO tx(data, fk_block_id) VALUES ('{"body":{"messages":[{"@type":"/ibc.applications.transfer.v1.MsgTransfer"}]}}', 2);
+INSERT INTO tx(data, fk_block_id) VALUES ('{"data":"begin_block","note":"this is a transaction artificially created for debugging purposes"}', 2);
+INSERT INTO tendermint_event(type, fk_tx_id) VALUES ('send_packet', 2);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_sequence', '1', 1);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_src_port', 'transfer', 1);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_src_channel', 'channel-0', 1);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_dst_port', 'transfer', 1);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_dst_channel', 'channel-1', 1);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_timeout_height', '1-1500', 1);
+INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES ('packet_timeout_timestamp', '0', 1);
+INSERT INTO tendermint_event(type, fk_tx_id) VALUES ('rewards', 3);
@@ -29,25 +29,54 @@ var (
 	tableNavKeys = []keyBinding{
 		{fmt.Sprintf("%c/k", tcell.RuneUArrow), "move up"},
 		{fmt.Sprintf("%c/j", tcell.RuneDArrow), "move down"},
+		{"gg", "go to top"},
+		{"shift+g", "go to bottom"},
+	}
+	listSearchKeys = []keyBinding{
+		{"/", "search list"},
+		{"n/shift+n", "next/prev match"},
 	}
 	textNavKeys = []keyBinding{
 		{fmt.Sprintf("%c/k", tcell.RuneUArrow), "scroll up"},
 		{fmt.Sprintf("%c/j", tcell.RuneDArrow), "scroll down"},
-		{"g", "go to top"},
+		{"gg", "go to top"},
 		{"shift+g", "go to bottom"},
 		{"ctrl+b", "page up"},
 		{"ctrl+f", "page down"},
 	}
 
 	keyMap = map[mainContent][]keyBinding{
-		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"enter", "view txs"}}, tableNavKeys),
-		cosmosMessagesMain: bindingsWithBase(tableNavKeys),
+		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"p", "ibc packets"}, {"s", "state diff"}, {"b", "bookmarks"}, {"enter", "view txs"}, {"/", "search txs"}}, tableNavKeys),
+		cosmosMessagesMain: bindingsWithBase(listSearchKeys, tableNavKeys),
 		txDetailMain: bindingsWithBase([]keyBinding{
 			{"[", "previous tx"},
 			{"]", "next tx"},
 			{"/", "toggle search"},
-			{"c", "copy all txs"},
+			{"n/shift+n", "next/prev match"},
+			{"t", "filter txs"},
+			{"c", "copy shown txs"},
+			{"f", "toggle follow"},
+			{":", "go to height"},
+			{"b", "bookmark tx"},
+			{"shift+b", "bookmark block"},
 		}, textNavKeys),
+		txSearchMain: bindingsWithBase([]keyBinding{
+			{"enter", "search or view tx"},
+			{"/", "edit search"},
+		}, tableNavKeys),
+		packetsMain: bindingsWithBase([]keyBinding{
+			{"enter", "view lifecycle"},
+			{"x", "correlate txs"},
+		}, listSearchKeys, tableNavKeys),
+		packetLifecycleMain:   bindingsWithBase(listSearchKeys, tableNavKeys),
+		packetCorrelationMain: bindingsWithBase(textNavKeys),
+		stateDiffMain: bindingsWithBase([]keyBinding{
+			{"enter", "select height"},
+			{"tab", "switch table"},
+		}, listSearchKeys, tableNavKeys),
+		bookmarksMain: bindingsWithBase([]keyBinding{
+			{"enter", "view txs"},
+		}, listSearchKeys, tableNavKeys),
 		errorModalMain: bindingsWithBase(nil),
 	}
 )
@@ -11,12 +11,18 @@ func _() {
 	_ = x[testCasesMain-0]
 	_ = x[cosmosMessagesMain-1]
 	_ = x[txDetailMain-2]
-	_ = x[errorModalMain-3]
+	_ = x[txSearchMain-3]
+	_ = x[packetsMain-4]
+	_ = x[packetLifecycleMain-5]
+	_ = x[packetCorrelationMain-6]
+	_ = x[stateDiffMain-7]
+	_ = x[bookmarksMain-8]
+	_ = x[errorModalMain-9]
 }
 
-const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMainerrorModalMain"
+const _mainContent_name = "testCasesMaincosmosMessagesMaintxDetailMaintxSearchMainpacketsMainpacketLifecycleMainpacketCorrelationMainstateDiffMainbookmarksMainerrorModalMain"
 
-var _mainContent_index = [...]uint8{0, 13, 31, 43, 57}
+var _mainContent_index = [...]uint8{0, 13, 31, 43, 55, 66, 85, 106, 119, 132, 146}
 
 func (i mainContent) String() string {
 	if i < 0 || i >= mainContent(len(_mainContent_index)-1) {
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/atotto/clipboard"
@@ -18,6 +19,12 @@ const (
 	testCasesMain mainContent = iota
 	cosmosMessagesMain
 	txDetailMain
+	txSearchMain
+	packetsMain
+	packetLifecycleMain
+	packetCorrelationMain
+	stateDiffMain
+	bookmarksMain
 	errorModalMain
 )
 
@@ -31,6 +38,14 @@ func (stack mainStack) Pop() []mainContent               { return stack[:len(sta
 type QueryService interface {
 	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
 	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	SearchTransactions(ctx context.Context, testCaseID int64, term string) ([]blockdb.TxSearchResult, error)
+	Packets(ctx context.Context, testCaseID int64) ([]blockdb.PacketResult, error)
+	PacketLifecycle(ctx context.Context, chainPkey int64, srcPort, srcChannel string, sequence uint64) ([]blockdb.PacketEventResult, error)
+	StateExportHeights(ctx context.Context, chainPkey int64) ([]int64, error)
+	StateExport(ctx context.Context, chainPkey, height int64) (map[string]json.RawMessage, error)
+	Upgrades(ctx context.Context, chainPkey int64) ([]blockdb.UpgradeResult, error)
+	Bookmarks(ctx context.Context, testCaseID int64) ([]blockdb.BookmarkResult, error)
+	ToggleBookmark(ctx context.Context, chainPkey, height, txID int64) (bool, error)
 }
 
 // Model encapsulates state that updates a view.
@@ -48,6 +63,18 @@ type Model struct {
 
 	// write to the system clipboard
 	clipboard func(text string) error
+
+	// decodes txs that were saved undecoded, if set
+	txDecoder blockdb.TxDecoder
+
+	// schedules a function on the main goroutine, required for follow mode
+	queueUpdate    func(func())
+	followInterval time.Duration
+
+	// vim-style navigation: whether "g" was pressed, awaiting the second "g" of "gg",
+	// and the incremental search of the current list, if any
+	pendingG   bool
+	listSearch *listSearch
 }
 
 // NewModel returns a valid *Model.
@@ -66,6 +93,8 @@ func NewModel(
 		testCases:     testCases,
 		stack:         mainStack{testCasesMain},
 		clipboard:     clipboard.WriteAll,
+
+		followInterval: time.Second,
 	}
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
@@ -84,6 +113,19 @@ func NewModel(
 	return m
 }
 
+// SetTxDecoder sets the decoder of txs that could not be decoded when they were collected,
+// such as txs with messages of custom modules.
+func (m *Model) SetTxDecoder(dec blockdb.TxDecoder) {
+	m.txDecoder = dec
+}
+
+// SetQueueUpdate sets the function used to run view updates on the main goroutine,
+// typically wrapping *(tview.Application).QueueUpdateDraw.
+// Follow mode, which polls for txs saved by in-progress tests, is only available after calling SetQueueUpdate.
+func (m *Model) SetQueueUpdate(queueUpdate func(func())) {
+	m.queueUpdate = queueUpdate
+}
+
 // RootView is a root view for a tview.Application.
 func (m *Model) RootView() *tview.Flex {
 	return m.layout
@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Bookmark presents a blockdb.BookmarkResult.
+type Bookmark struct {
+	Result blockdb.BookmarkResult
+}
+
+func (b Bookmark) ChainID() string { return b.Result.ChainID }
+func (b Bookmark) Height() string  { return strconv.FormatInt(b.Result.Height, 10) }
+
+// Tx is the hash of the bookmarked tx, e.g. tx 1A2B, or "block" if the block is bookmarked.
+func (b Bookmark) Tx() string {
+	switch {
+	case !b.Result.TxID.Valid:
+		return "block"
+	case b.Result.TxHash == "":
+		return "tx"
+	default:
+		return "tx " + b.Result.TxHash
+	}
+}
+
+func (b Bookmark) CreatedAt() string { return FormatTime(b.Result.CreatedAt) }
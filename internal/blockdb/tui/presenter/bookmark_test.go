@@ -0,0 +1,28 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmark(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2023, 1, 2, 15, 4, 0, 0, time.UTC)
+	pres := Bookmark{blockdb.BookmarkResult{ChainID: "chain-a", Height: 42, CreatedAt: createdAt}}
+
+	require.Equal(t, "chain-a", pres.ChainID())
+	require.Equal(t, "42", pres.Height())
+	require.Equal(t, "block", pres.Tx())
+	require.Equal(t, "01-02 03:04PM UTC", pres.CreatedAt())
+
+	pres.Result.TxID = sql.NullInt64{Int64: 7, Valid: true}
+	require.Equal(t, "tx", pres.Tx())
+
+	pres.Result.TxHash = "1A2B"
+	require.Equal(t, "tx 1A2B", pres.Tx())
+}
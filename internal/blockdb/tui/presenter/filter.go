@@ -0,0 +1,140 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// TxFilter narrows txs to those with a message type within a block height range.
+// The zero value matches all txs.
+type TxFilter struct {
+	// MsgType matches a message type URL, e.g. /ibc.core.channel.v1.MsgRecvPacket, or its last segment, e.g. MsgRecvPacket.
+	// If empty, matches all message types.
+	MsgType string
+
+	// Inclusive height range. A zero value is unbounded.
+	MinHeight, MaxHeight int64
+}
+
+// ParseTxFilter parses a filter of an optional message type and an optional height range, separated by spaces,
+// e.g. "MsgRecvPacket", "MsgRecvPacket 100-200", "100-", "-200", or "150".
+func ParseTxFilter(s string) (TxFilter, error) {
+	var f TxFilter
+	for _, field := range strings.Fields(s) {
+		if strings.Trim(field, "0123456789-") != "" {
+			if f.MsgType != "" {
+				return TxFilter{}, fmt.Errorf("filter has more than one message type: %q and %q", f.MsgType, field)
+			}
+			f.MsgType = field
+			continue
+		}
+
+		if f.MinHeight != 0 || f.MaxHeight != 0 {
+			return TxFilter{}, fmt.Errorf("filter has more than one height range: %q", field)
+		}
+		lo, hi, found := strings.Cut(field, "-")
+		if !found {
+			hi = lo
+		}
+		if lo == "" && hi == "" {
+			return TxFilter{}, fmt.Errorf("invalid height range %q", field)
+		}
+		var err error
+		if f.MinHeight, err = parseFilterHeight(lo); err != nil {
+			return TxFilter{}, err
+		}
+		if f.MaxHeight, err = parseFilterHeight(hi); err != nil {
+			return TxFilter{}, err
+		}
+		if f.MaxHeight != 0 && f.MinHeight > f.MaxHeight {
+			return TxFilter{}, fmt.Errorf("invalid height range %q: start is after end", field)
+		}
+	}
+	return f, nil
+}
+
+func parseFilterHeight(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	h, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || h <= 0 {
+		return 0, fmt.Errorf("invalid height %q", s)
+	}
+	return h, nil
+}
+
+// IsZero returns true if the filter matches all txs.
+func (f TxFilter) IsZero() bool { return f == TxFilter{} }
+
+// String is the filter in the format accepted by ParseTxFilter.
+func (f TxFilter) String() string {
+	var fields []string
+	if f.MsgType != "" {
+		fields = append(fields, f.MsgType)
+	}
+	switch {
+	case f.MinHeight == 0 && f.MaxHeight == 0:
+	case f.MinHeight == f.MaxHeight:
+		fields = append(fields, strconv.FormatInt(f.MinHeight, 10))
+	default:
+		r := "-"
+		if f.MinHeight != 0 {
+			r = strconv.FormatInt(f.MinHeight, 10) + r
+		}
+		if f.MaxHeight != 0 {
+			r += strconv.FormatInt(f.MaxHeight, 10)
+		}
+		fields = append(fields, r)
+	}
+	return strings.Join(fields, " ")
+}
+
+// Match returns true if the tx is within the height range and has a message of the message type.
+// Txs that are not JSON cosmos txs, e.g. undecoded txs, never match a message type.
+func (f TxFilter) Match(tx blockdb.TxResult) bool {
+	if f.MinHeight != 0 && tx.Height < f.MinHeight {
+		return false
+	}
+	if f.MaxHeight != 0 && tx.Height > f.MaxHeight {
+		return false
+	}
+	if f.MsgType == "" {
+		return true
+	}
+
+	var cosmosTx struct {
+		Body struct {
+			Messages []struct {
+				Type string `json:"@type"`
+			} `json:"messages"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(tx.Tx, &cosmosTx); err != nil {
+		return false
+	}
+	for _, msg := range cosmosTx.Body.Messages {
+		if msg.Type == f.MsgType || strings.HasSuffix(msg.Type, "."+f.MsgType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the txs matching the filter.
+func (f TxFilter) Filter(txs []blockdb.TxResult) []blockdb.TxResult {
+	if f.IsZero() {
+		return txs
+	}
+	var matched []blockdb.TxResult
+	for _, tx := range txs {
+		if f.Match(tx) {
+			matched = append(matched, tx)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,84 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTxFilter(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		In   string
+		Want TxFilter
+	}{
+		{"", TxFilter{}},
+		{"  ", TxFilter{}},
+		{"MsgRecvPacket", TxFilter{MsgType: "MsgRecvPacket"}},
+		{"/ibc.core.channel.v1.MsgRecvPacket", TxFilter{MsgType: "/ibc.core.channel.v1.MsgRecvPacket"}},
+		{"MsgRecvPacket 100-200", TxFilter{MsgType: "MsgRecvPacket", MinHeight: 100, MaxHeight: 200}},
+		{"100-200 MsgRecvPacket", TxFilter{MsgType: "MsgRecvPacket", MinHeight: 100, MaxHeight: 200}},
+		{"100-", TxFilter{MinHeight: 100}},
+		{"-200", TxFilter{MaxHeight: 200}},
+		{"150", TxFilter{MinHeight: 150, MaxHeight: 150}},
+	} {
+		got, err := ParseTxFilter(tt.In)
+		require.NoError(t, err, tt.In)
+		require.Equal(t, tt.Want, got, tt.In)
+	}
+
+	for _, in := range []string{
+		"MsgA MsgB",
+		"1-2 3-4",
+		"200-100",
+		"0-10",
+		"1-2-3",
+		"-",
+	} {
+		_, err := ParseTxFilter(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestTxFilter_String(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"", "MsgRecvPacket", "MsgRecvPacket 100-200", "100-", "-200", "150"} {
+		f, err := ParseTxFilter(in)
+		require.NoError(t, err)
+		require.Equal(t, in, f.String())
+	}
+}
+
+func TestTxFilter_Filter(t *testing.T) {
+	t.Parallel()
+
+	txs := []blockdb.TxResult{
+		{Height: 10, Tx: []byte(`{"body":{"messages":[{"@type":"/ibc.core.channel.v1.MsgRecvPacket"},{"@type":"/ibc.core.client.v1.MsgUpdateClient"}]}}`)},
+		{Height: 11, Tx: []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}`)},
+		{Height: 12, Tx: []byte(`{"body":{"messages":[{"@type":"/ibc.core.channel.v1.MsgRecvPacket"}]}}`)},
+		{Height: 13, Tx: []byte(`{"data":"0a0b"}`)},
+		{Height: 14, Tx: []byte(`not json`)},
+	}
+
+	filter := func(s string) []int64 {
+		f, err := ParseTxFilter(s)
+		require.NoError(t, err)
+		var heights []int64
+		for _, tx := range f.Filter(txs) {
+			heights = append(heights, tx.Height)
+		}
+		return heights
+	}
+
+	require.Equal(t, []int64{10, 11, 12, 13, 14}, filter(""))
+	require.Equal(t, []int64{10, 12}, filter("MsgRecvPacket"))
+	require.Equal(t, []int64{10, 12}, filter("/ibc.core.channel.v1.MsgRecvPacket"))
+	require.Equal(t, []int64{12}, filter("MsgRecvPacket 11-"))
+	require.Equal(t, []int64{10}, filter("MsgUpdateClient"))
+	require.Equal(t, []int64{11, 12, 13}, filter("11-13"))
+	require.Empty(t, filter("RecvPacket"))
+	require.Empty(t, filter("MsgRecvPacket 13-"))
+}
@@ -41,3 +41,9 @@ func (h *Highlight) Text(text string) (string, []string) {
 	})
 	return final, regionIDs
 }
+
+// Matches reports whether text contains the "searchTerm" from NewHighlight, ignoring case.
+// A missing search term matches nothing.
+func (h *Highlight) Matches(text string) bool {
+	return h.rx != nil && h.rx.MatchString(text)
+}
@@ -44,6 +44,14 @@ The way to dusty death. Out, out, brief candle!`
 	})
 }
 
+func TestHighlighter_Matches(t *testing.T) {
+	require.True(t, NewHighlight("DAY").Matches(highlighterFixture))
+	require.True(t, NewHighlight("(one paren").Matches("(one paren"))
+	require.False(t, NewHighlight("night").Matches(highlighterFixture))
+	require.False(t, NewHighlight("").Matches(highlighterFixture))
+	require.False(t, NewHighlight(" \t").Matches(highlighterFixture))
+}
+
 const highlighterFixture = `Tomorrow, and tomorrow, and tomorrow,
 Creeps in this petty pace from day to day,
 To the last syllable of recorded time;
@@ -0,0 +1,91 @@
+package presenter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Packet presents a blockdb.PacketResult.
+type Packet struct {
+	Result blockdb.PacketResult
+}
+
+func (p Packet) ChainID() string  { return p.Result.ChainID }
+func (p Packet) Sequence() string { return strconv.FormatUint(p.Result.Sequence, 10) }
+
+// Source is the sending port and channel, e.g. transfer/channel-0.
+func (p Packet) Source() string { return p.Result.SrcPort + "/" + p.Result.SrcChannel }
+
+// Destination is the receiving port and channel, e.g. transfer/channel-1.
+func (p Packet) Destination() string { return p.Result.DstPort + "/" + p.Result.DstChannel }
+
+func (p Packet) SendHeight() string { return strconv.FormatInt(p.Result.SendHeight, 10) }
+
+// Received is the receiving chain and height, e.g. osmosis-1001 @ 12, or empty if not received.
+func (p Packet) Received() string {
+	if !p.Result.RecvHeight.Valid {
+		return ""
+	}
+	return p.Result.RecvChainID.String + " @ " + strconv.FormatInt(p.Result.RecvHeight.Int64, 10)
+}
+
+// Status is the last observed stage of the packet's lifecycle.
+// A packet that was both acknowledged and timed out, which should never happen, shows both.
+func (p Packet) Status() string {
+	var stages []string
+	if p.Result.AckHeight.Valid {
+		stages = append(stages, "acknowledged @ "+strconv.FormatInt(p.Result.AckHeight.Int64, 10))
+	}
+	if p.Result.TimeoutHeight.Valid {
+		stages = append(stages, "timed out @ "+strconv.FormatInt(p.Result.TimeoutHeight.Int64, 10))
+	}
+	switch {
+	case len(stages) > 0:
+		return strings.Join(stages, ", ")
+	case p.Result.RecvHeight.Valid:
+		return "received"
+	default:
+		return "sent"
+	}
+}
+
+// PacketEvent presents a blockdb.PacketEventResult.
+type PacketEvent struct {
+	Result blockdb.PacketEventResult
+}
+
+func (p PacketEvent) ChainID() string { return p.Result.ChainID }
+func (p PacketEvent) Height() string  { return strconv.FormatInt(p.Result.Height, 10) }
+
+// Type is the packet event type, e.g. send_packet.
+func (p PacketEvent) Type() string { return p.Result.Type }
+
+// Tx is the data of the tx that emitted the event, pretty printed if JSON.
+func (p PacketEvent) Tx() string {
+	return Tx{Result: blockdb.TxResult{Height: p.Result.Height, Tx: p.Result.Tx}}.Data()
+}
+
+// SinceSend is the time elapsed between the send event and the event, e.g. +1.5s.
+// Block times are when blocks were saved, so the delta is approximate.
+func (p PacketEvent) SinceSend(send blockdb.PacketEventResult) string {
+	d := p.Result.BlockTime.Sub(send.BlockTime).Round(time.Millisecond)
+	if d < 0 {
+		return d.String()
+	}
+	return "+" + d.String()
+}
+
+// Timeout is the packet's timeout height and timestamp, omitting either if unset.
+func (p PacketEvent) Timeout() string {
+	var parts []string
+	if h := p.Result.TimeoutHeight; h != "" && h != "0-0" {
+		parts = append(parts, "height "+h)
+	}
+	if ts := p.Result.TimeoutTimestamp; ts != "" && ts != "0" {
+		parts = append(parts, "timestamp "+ts)
+	}
+	return strings.Join(parts, ", ")
+}
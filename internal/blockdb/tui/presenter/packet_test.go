@@ -0,0 +1,97 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacket(t *testing.T) {
+	t.Parallel()
+
+	res := blockdb.PacketResult{
+		ChainID:    "chain-a",
+		Sequence:   7,
+		SrcPort:    "transfer",
+		SrcChannel: "channel-0",
+		DstPort:    "transfer",
+		DstChannel: "channel-1",
+		SendHeight: 10,
+	}
+	pres := Packet{res}
+
+	require.Equal(t, "chain-a", pres.ChainID())
+	require.Equal(t, "7", pres.Sequence())
+	require.Equal(t, "transfer/channel-0", pres.Source())
+	require.Equal(t, "transfer/channel-1", pres.Destination())
+	require.Equal(t, "10", pres.SendHeight())
+	require.Empty(t, pres.Received())
+	require.Equal(t, "sent", pres.Status())
+
+	res.RecvChainID = sql.NullString{String: "chain-b", Valid: true}
+	res.RecvHeight = sql.NullInt64{Int64: 20, Valid: true}
+	pres = Packet{res}
+	require.Equal(t, "chain-b @ 20", pres.Received())
+	require.Equal(t, "received", pres.Status())
+
+	res.AckHeight = sql.NullInt64{Int64: 12, Valid: true}
+	require.Equal(t, "acknowledged @ 12", Packet{res}.Status())
+
+	res.AckHeight = sql.NullInt64{}
+	res.TimeoutHeight = sql.NullInt64{Int64: 15, Valid: true}
+	require.Equal(t, "timed out @ 15", Packet{res}.Status())
+}
+
+func TestPacketEvent(t *testing.T) {
+	t.Parallel()
+
+	pres := PacketEvent{blockdb.PacketEventResult{
+		ChainID:          "chain-a",
+		Height:           10,
+		Type:             "send_packet",
+		TimeoutHeight:    "1-1500",
+		TimeoutTimestamp: "0",
+	}}
+
+	require.Equal(t, "chain-a", pres.ChainID())
+	require.Equal(t, "10", pres.Height())
+	require.Equal(t, "send_packet", pres.Type())
+	require.Equal(t, "height 1-1500", pres.Timeout())
+
+	pres.Result.TimeoutHeight = "0-0"
+	pres.Result.TimeoutTimestamp = "1690000000000000000"
+	require.Equal(t, "timestamp 1690000000000000000", pres.Timeout())
+}
+
+func TestPacketEvent_Tx(t *testing.T) {
+	t.Parallel()
+
+	pres := PacketEvent{blockdb.PacketEventResult{Tx: []byte(`{"a":1}`)}}
+	require.Equal(t, "{\n  \"a\": 1\n}", pres.Tx())
+
+	pres.Result.Tx = []byte("not json")
+	require.Equal(t, "not json", pres.Tx())
+}
+
+func TestPacketEvent_SinceSend(t *testing.T) {
+	t.Parallel()
+
+	sent := time.Date(2022, 6, 22, 10, 0, 0, 0, time.UTC)
+	send := blockdb.PacketEventResult{BlockTime: sent}
+
+	for _, tt := range []struct {
+		BlockTime time.Time
+		Want      string
+	}{
+		{sent, "+0s"},
+		{sent.Add(1500 * time.Millisecond), "+1.5s"},
+		{sent.Add(2*time.Minute + 1234567*time.Microsecond), "+2m1.235s"},
+		{sent.Add(-time.Second), "-1s"},
+	} {
+		pres := PacketEvent{blockdb.PacketEventResult{BlockTime: tt.BlockTime}}
+		require.Equal(t, tt.Want, pres.SinceSend(send), tt)
+	}
+}
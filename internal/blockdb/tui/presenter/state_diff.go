@@ -0,0 +1,135 @@
+package presenter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// StateChange is a difference between two exported app states at a JSON path,
+// e.g. bank.supply[0].amount.
+type StateChange struct {
+	Path string
+	// Compact JSON values. Old is empty if the value was added; New is empty if the value was removed.
+	Old, New string
+}
+
+// Kind is one of added, removed, or changed.
+func (c StateChange) Kind() string {
+	switch {
+	case c.Old == "":
+		return "added"
+	case c.New == "":
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+// StateDiff returns the changes from the old to the new app state, given as the JSON state of each module,
+// such as returned by blockdb.Query.StateExport.
+// Objects are compared by key and arrays by index, so changes are reported at the deepest differing values,
+// ordered by module, object key, and array index.
+func StateDiff(old, new map[string]json.RawMessage) ([]StateChange, error) {
+	oldState, err := decodeModuleStates(old)
+	if err != nil {
+		return nil, fmt.Errorf("decode old state: %w", err)
+	}
+	newState, err := decodeModuleStates(new)
+	if err != nil {
+		return nil, fmt.Errorf("decode new state: %w", err)
+	}
+	var changes []StateChange
+	diffState("", oldState, newState, &changes)
+	return changes, nil
+}
+
+func decodeModuleStates(modules map[string]json.RawMessage) (map[string]any, error) {
+	state := make(map[string]any, len(modules))
+	for module, data := range modules {
+		// Decode numbers as json.Number, so large integers keep their precision.
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("module %s: %w", module, err)
+		}
+		state[module] = v
+	}
+	return state, nil
+}
+
+func diffState(path string, old, new any, changes *[]StateChange) {
+	switch oldV := old.(type) {
+	case map[string]any:
+		if newV, ok := new.(map[string]any); ok {
+			diffObjects(path, oldV, newV, changes)
+			return
+		}
+	case []any:
+		if newV, ok := new.([]any); ok {
+			diffArrays(path, oldV, newV, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, StateChange{Path: path, Old: compactJSON(old), New: compactJSON(new)})
+	}
+}
+
+func diffObjects(path string, old, new map[string]any, changes *[]StateChange) {
+	keys := make([]string, 0, len(old)+len(new))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		oldV, inOld := old[k]
+		newV, inNew := new[k]
+		switch {
+		case !inOld:
+			*changes = append(*changes, StateChange{Path: p, New: compactJSON(newV)})
+		case !inNew:
+			*changes = append(*changes, StateChange{Path: p, Old: compactJSON(oldV)})
+		default:
+			diffState(p, oldV, newV, changes)
+		}
+	}
+}
+
+func diffArrays(path string, old, new []any, changes *[]StateChange) {
+	n := len(old)
+	if len(new) > n {
+		n = len(new)
+	}
+	for i := 0; i < n; i++ {
+		p := path + "[" + strconv.Itoa(i) + "]"
+		switch {
+		case i >= len(old):
+			*changes = append(*changes, StateChange{Path: p, New: compactJSON(new[i])})
+		case i >= len(new):
+			*changes = append(*changes, StateChange{Path: p, Old: compactJSON(old[i])})
+		default:
+			diffState(p, old[i], new[i], changes)
+		}
+	}
+}
+
+func compactJSON(v any) string {
+	// Values were decoded from JSON, so marshaling should never fail.
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateDiff(t *testing.T) {
+	t.Parallel()
+
+	old := map[string]json.RawMessage{
+		"bank": json.RawMessage(`{"supply":[{"denom":"uatom","amount":"10"}],"params":{"default_send_enabled":true}}`),
+		"gov":  json.RawMessage(`{"starting_proposal_id":"1"}`),
+		"mint": json.RawMessage(`{"minter":{"inflation":"0.13"}}`),
+	}
+	new := map[string]json.RawMessage{
+		"bank":    json.RawMessage(`{"supply":[{"denom":"uatom","amount":"12"},{"denom":"ustake","amount":"5"}],"params":{"default_send_enabled":true}}`),
+		"mint":    json.RawMessage(`{"minter":{"inflation":"0.13"}}`),
+		"staking": json.RawMessage(`{"last_total_power":"18446744073709551617"}`),
+	}
+
+	changes, err := StateDiff(old, new)
+	require.NoError(t, err)
+	require.Equal(t, []StateChange{
+		{Path: "bank.supply[0].amount", Old: `"10"`, New: `"12"`},
+		{Path: "bank.supply[1]", New: `{"amount":"5","denom":"ustake"}`},
+		{Path: "gov", Old: `{"starting_proposal_id":"1"}`},
+		{Path: "staking", New: `{"last_total_power":"18446744073709551617"}`},
+	}, changes)
+
+	require.Equal(t, "changed", changes[0].Kind())
+	require.Equal(t, "added", changes[1].Kind())
+	require.Equal(t, "removed", changes[2].Kind())
+
+	changes, err = StateDiff(old, old)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Numbers keep their precision, and type changes are reported at the value.
+	changes, err = StateDiff(
+		map[string]json.RawMessage{"m": json.RawMessage(`{"n":18446744073709551616,"v":{"a":1}}`)},
+		map[string]json.RawMessage{"m": json.RawMessage(`{"n":18446744073709551617,"v":[1]}`)},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []StateChange{
+		{Path: "m.n", Old: "18446744073709551616", New: "18446744073709551617"},
+		{Path: "m.v", Old: `{"a":1}`, New: `[1]`},
+	}, changes)
+
+	_, err = StateDiff(map[string]json.RawMessage{"m": json.RawMessage(`{`)}, nil)
+	require.Error(t, err)
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 )
@@ -63,3 +65,52 @@ func (txs Txs) ToJSON() []byte {
 	}
 	return b
 }
+
+// snippetContext is the number of bytes shown on either side of the search match in a snippet.
+const snippetContext = 40
+
+// TxSearch presents a blockdb.TxSearchResult.
+type TxSearch struct {
+	Result blockdb.TxSearchResult
+}
+
+func (p TxSearch) Height() string { return strconv.FormatInt(p.Result.Height, 10) }
+
+// Snippet returns a single line excerpt of the tx data around the first word of searchTerm.
+// If the word is not found, the excerpt is the start of the tx data.
+func (p TxSearch) Snippet(searchTerm string) string {
+	data := string(p.Result.Tx)
+
+	var start int
+	if words := strings.Fields(searchTerm); len(words) > 0 {
+		start = strings.Index(strings.ToLower(data), strings.ToLower(words[0]))
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	from := start - snippetContext
+	if from < 0 {
+		from = 0
+	}
+	to := start + 2*snippetContext
+	if to > len(data) {
+		to = len(data)
+	}
+	// Widen the excerpt to whole runes, so that multi-byte characters at its ends are not split.
+	for from > 0 && !utf8.RuneStart(data[from]) {
+		from--
+	}
+	for to < len(data) && !utf8.RuneStart(data[to]) {
+		to++
+	}
+
+	snippet := strings.Join(strings.Fields(data[from:to]), " ")
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(data) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -2,7 +2,9 @@ package presenter
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/stretchr/testify/require"
@@ -67,3 +69,42 @@ func TestTxs_ToJSON(t *testing.T) {
 		require.JSONEq(t, want, string(txs.ToJSON()))
 	})
 }
+
+func TestTxSearch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("short tx", func(t *testing.T) {
+		pres := TxSearch{blockdb.TxSearchResult{TxResult: blockdb.TxResult{Height: 7, Tx: []byte(`{"memo": "needle"}`)}}}
+
+		require.Equal(t, "7", pres.Height())
+		require.Equal(t, `{"memo": "needle"}`, pres.Snippet("NEEDLE"))
+		require.Equal(t, `{"memo": "needle"}`, pres.Snippet(""))
+	})
+
+	t.Run("long tx", func(t *testing.T) {
+		data := strings.Repeat("a", 100) + "\n needle \n" + strings.Repeat("b", 100)
+		pres := TxSearch{blockdb.TxSearchResult{TxResult: blockdb.TxResult{Tx: []byte(data)}}}
+
+		got := pres.Snippet("needle other")
+		require.True(t, strings.HasPrefix(got, "…a"), got)
+		require.True(t, strings.HasSuffix(got, "b…"), got)
+		require.Contains(t, got, "a needle b")
+
+		// Without a match, the snippet is the start of the tx.
+		got = pres.Snippet("missing")
+		require.True(t, strings.HasPrefix(got, "aaa"), got)
+		require.True(t, strings.HasSuffix(got, "a…"), got)
+	})
+
+	t.Run("multi-byte runes", func(t *testing.T) {
+		data := strings.Repeat("é", 100) + " needle " + strings.Repeat("世", 100)
+		pres := TxSearch{blockdb.TxSearchResult{TxResult: blockdb.TxResult{Tx: []byte(data)}}}
+
+		// The context on either side of the match ends within a rune.
+		got := pres.Snippet("needle")
+		require.True(t, utf8.ValidString(got), got)
+		require.True(t, strings.HasPrefix(got, "…é"), got)
+		require.True(t, strings.HasSuffix(got, "世…"), got)
+		require.Contains(t, got, "é needle 世")
+	})
+}
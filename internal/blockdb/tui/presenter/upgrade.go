@@ -0,0 +1,23 @@
+package presenter
+
+import (
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+)
+
+// Upgrade presents a blockdb.UpgradeResult.
+type Upgrade struct {
+	Result blockdb.UpgradeResult
+}
+
+// Height is the first block of the new version.
+func (u Upgrade) Height() string { return strconv.FormatInt(u.Result.Height, 10) }
+
+// Versions is the version change, e.g. Upgrade v7.0.0 -> v8.0.0
+func (u Upgrade) Versions() string {
+	return "Upgrade " + u.Result.OldVersion + " -> " + u.Result.NewVersion
+}
+
+// Image is the container image of the new version, if known.
+func (u Upgrade) Image() string { return u.Result.Image }
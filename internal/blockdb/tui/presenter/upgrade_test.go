@@ -0,0 +1,23 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgrade(t *testing.T) {
+	t.Parallel()
+
+	pres := Upgrade{blockdb.UpgradeResult{
+		Height:     42,
+		OldVersion: "v7.0.0",
+		NewVersion: "v8.0.0",
+		Image:      "ghcr.io/strangelove-ventures/heighliner/gaia:v8.0.0",
+	}}
+
+	require.Equal(t, "42", pres.Height())
+	require.Equal(t, "Upgrade v7.0.0 -> v8.0.0", pres.Versions())
+	require.Equal(t, "ghcr.io/strangelove-ventures/heighliner/gaia:v8.0.0", pres.Image())
+}
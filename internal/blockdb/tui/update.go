@@ -2,11 +2,14 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/blockdb/tui/presenter"
 )
 
@@ -19,9 +22,74 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 		oldMain := m.stack.Current()
 		defer m.updateHelp(oldMain)
 
+		// A "g" only completes "gg" if it immediately follows another "g".
+		pendingG := m.pendingG
+		m.pendingG = false
+
 		switch {
+		case m.stack.Current() == txDetailMain && m.txDetailView().Filter.HasFocus():
+			detail := m.txDetailView()
+			switch event.Key() {
+			case tcell.KeyEnter:
+				if err := detail.ApplyFilter(); err != nil {
+					m.pushErrorModal(fmt.Errorf("filter txs: %w", err))
+				}
+				return nil
+			case tcell.KeyESC:
+				detail.DeactivateFilter()
+				return nil
+			}
+			// Let the filter input handle typing.
+			return event
+
+		case m.stack.Current() == txDetailMain && m.txDetailView().Goto.HasFocus():
+			detail := m.txDetailView()
+			switch event.Key() {
+			case tcell.KeyEnter:
+				if err := detail.ApplyGoto(); err != nil {
+					m.pushErrorModal(fmt.Errorf("go to height: %w", err))
+				}
+				return nil
+			case tcell.KeyESC:
+				detail.DeactivateGoto()
+				return nil
+			}
+			// Let the goto input handle typing.
+			return event
+
+		case m.listSearch != nil && m.listSearch.typing:
+			switch event.Key() {
+			case tcell.KeyEnter:
+				if !m.listSearch.Enter() {
+					m.listSearch = nil
+				}
+			case tcell.KeyESC:
+				m.listSearch.Cancel()
+				m.listSearch = nil
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				m.listSearch.Backspace()
+			case tcell.KeyRune:
+				m.listSearch.Type(event.Rune())
+			}
+			return nil
+
+		case event.Rune() == 'g' && !m.inputFocused():
+			if !pendingG {
+				m.pendingG = true
+				return nil
+			}
+			if m.goToTop() {
+				return nil
+			}
+			// Let the view go to the top, e.g. a focused text view.
+			return event
+
 		case event.Key() == tcell.KeyESC:
 			if len(m.stack) > 1 { // Stack must be at least 1, so we don't remove all main content views.
+				if m.stack.Current() == txDetailMain {
+					m.stopFollow(m.txDetailView())
+				}
+				m.listSearch = nil
 				m.mainContentView().RemovePage(m.stack.Current().String())
 				m.stack = m.stack.Pop()
 				return nil
@@ -30,12 +98,27 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 		case event.Key() == tcell.KeyEnter && m.stack.Current() == testCasesMain:
 			// Show tx detail.
 			tc := m.testCases[m.selectedRow()]
-			results, err := m.querySvc.Transactions(ctx, tc.ChainPKey)
+			detail, err := m.newTxDetail(ctx, tc.ID, tc.ChainPKey, tc.ChainID)
+			if err != nil {
+				m.pushErrorModal(err)
+				return nil
+			}
+			m.pushMainView(txDetailMain, detail)
+			return nil
+
+		case event.Rune() == 'b' && m.stack.Current() == testCasesMain:
+			// Show bookmarks.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.Bookmarks(ctx, tc.ID)
 			if err != nil {
-				m.pushErrorModal(fmt.Errorf("query transactions: %w", err))
+				m.pushErrorModal(fmt.Errorf("query bookmarks: %w", err))
 				return nil
 			}
-			m.pushMainView(txDetailMain, newTxDetailView(tc.ChainID, results))
+			m.pushMainView(bookmarksMain, newBookmarksView(tc, results))
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == bookmarksMain:
+			m.jumpToBookmark(ctx, m.bookmarksView())
 			return nil
 
 		case event.Rune() == 'm' && m.stack.Current() == testCasesMain:
@@ -46,7 +129,98 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 				m.pushErrorModal(fmt.Errorf("query cosmos messages: %w", err))
 				return nil
 			}
-			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results))
+			upgrades, err := m.querySvc.Upgrades(ctx, tc.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query upgrades: %w", err))
+				return nil
+			}
+			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results, upgrades))
+			return nil
+
+		case event.Rune() == 'p' && m.stack.Current() == testCasesMain:
+			// Show ibc packets.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.Packets(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query packets: %w", err))
+				return nil
+			}
+			m.pushMainView(packetsMain, newPacketsView(tc, results))
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == packetsMain:
+			// Show the lifecycle of the selected packet.
+			pkt, ok := m.packetsView().SelectedPacket()
+			if !ok {
+				return nil
+			}
+			results, err := m.querySvc.PacketLifecycle(ctx, pkt.ChainPKey, pkt.SrcPort, pkt.SrcChannel, pkt.Sequence)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query packet lifecycle: %w", err))
+				return nil
+			}
+			m.pushMainView(packetLifecycleMain, packetLifecycleView(pkt, results))
+			return nil
+
+		case event.Rune() == 'x' && m.stack.Current() == packetsMain:
+			// Show the txs of the selected packet on both chains.
+			pkt, ok := m.packetsView().SelectedPacket()
+			if !ok {
+				return nil
+			}
+			results, err := m.querySvc.PacketLifecycle(ctx, pkt.ChainPKey, pkt.SrcPort, pkt.SrcChannel, pkt.Sequence)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query packet lifecycle: %w", err))
+				return nil
+			}
+			m.pushMainView(packetCorrelationMain, packetCorrelationView(pkt, results))
+			return nil
+
+		case event.Rune() == 's' && m.stack.Current() == testCasesMain:
+			// Diff the chain's exported state between heights.
+			tc := m.testCases[m.selectedRow()]
+			heights, err := m.querySvc.StateExportHeights(ctx, tc.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query state export heights: %w", err))
+				return nil
+			}
+			if len(heights) < 2 {
+				m.pushErrorModal(fmt.Errorf("chain %s has %d state exports; at least 2 are required to diff", tc.ChainID, len(heights)))
+				return nil
+			}
+			m.pushMainView(stateDiffMain, newStateDiffView(tc, heights))
+			return nil
+
+		case event.Key() == tcell.KeyTab && m.stack.Current() == stateDiffMain:
+			m.stateDiffView().ToggleFocus()
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == stateDiffMain:
+			m.selectStateDiffHeight(ctx, m.stateDiffView())
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == testCasesMain:
+			// Search txs of the test case.
+			tc := m.testCases[m.selectedRow()]
+			m.pushMainView(txSearchMain, newTxSearchView(tc))
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == txSearchMain && !m.txSearchView().Search.HasFocus():
+			m.txSearchView().activateSearch()
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == txSearchMain:
+			search := m.txSearchView()
+			if search.Search.HasFocus() {
+				results, err := m.querySvc.SearchTransactions(ctx, search.testCase.ID, search.Search.GetText())
+				if err != nil {
+					m.pushErrorModal(fmt.Errorf("search transactions: %w", err))
+					return nil
+				}
+				search.SetResults(results)
+				return nil
+			}
+			m.jumpToSearchResult(ctx, search)
 			return nil
 
 		case event.Rune() == '[' && m.stack.Current() == txDetailMain:
@@ -61,12 +235,57 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			m.txDetailView().ToggleSearch()
 			return nil
 
+		case event.Rune() == '/' && listSearchMains[m.stack.Current()]:
+			if m.listSearch != nil {
+				// Replace the previous search, keeping the selected row.
+				m.listSearch.table.SetTitle(m.listSearch.title)
+			}
+			if tbl, ok := m.focusedTable(); ok {
+				m.listSearch = newListSearch(tbl)
+			}
+			return nil
+
+		case (event.Rune() == 'n' || event.Rune() == 'N') && m.listSearch != nil:
+			if tbl, ok := m.focusedTable(); ok && tbl == m.listSearch.table {
+				m.listSearch.Next(event.Rune() == 'n')
+			}
+			return nil
+
+		case (event.Rune() == 'n' || event.Rune() == 'N') && m.stack.Current() == txDetailMain && !m.txDetailView().Search.HasFocus():
+			m.txDetailView().ShowMatch(event.Rune() == 'n')
+			return nil
+
+		case event.Rune() == 't' && m.stack.Current() == txDetailMain && !m.txDetailView().Search.HasFocus():
+			m.txDetailView().ActivateFilter()
+			return nil
+
 		case event.Rune() == 'c' && m.stack.Current() == txDetailMain:
-			if err := m.clipboard(string(presenter.Txs(m.txDetailView().Txs).ToJSON())); err != nil {
+			if err := m.clipboard(string(presenter.Txs(m.txDetailView().VisibleTxs()).ToJSON())); err != nil {
 				m.pushErrorModal(fmt.Errorf("copy to clipboard: %w", err))
 			}
 			return nil
 
+		case event.Rune() == 'f' && m.stack.Current() == txDetailMain && !m.txDetailView().Search.HasFocus():
+			detail := m.txDetailView()
+			if detail.Following() {
+				m.stopFollow(detail)
+			} else {
+				m.startFollow(ctx, detail)
+			}
+			return nil
+
+		case event.Rune() == ':' && m.stack.Current() == txDetailMain && !m.txDetailView().Search.HasFocus():
+			m.txDetailView().ActivateGoto()
+			return nil
+
+		case event.Rune() == 'b' && m.stack.Current() == txDetailMain && !m.txDetailView().Search.HasFocus():
+			m.toggleBookmark(ctx, m.txDetailView(), false)
+			return nil
+
+		case event.Rune() == 'B' && m.stack.Current() == txDetailMain && !m.txDetailView().Search.HasFocus():
+			m.toggleBookmark(ctx, m.txDetailView(), true)
+			return nil
+
 		case event.Key() == tcell.KeyEnter && m.stack.Current() == txDetailMain:
 			// Search tx detail.
 			m.txDetailView().DoSearch()
@@ -77,6 +296,188 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 	}
 }
 
+// jumpToSearchResult shows the tx detail of the selected search result's chain,
+// starting at the matching tx, with the search term highlighted.
+func (m *Model) jumpToSearchResult(ctx context.Context, search *txSearchView) {
+	row, _ := search.Table.GetSelection()
+	// Offset by 1 to account for header row.
+	row--
+	if row < 0 || row >= len(search.Results) {
+		return
+	}
+	res := search.Results[row]
+
+	detail, err := m.newTxDetail(ctx, search.testCase.ID, res.ChainPKey, res.ChainID)
+	if err != nil {
+		m.pushErrorModal(err)
+		return
+	}
+	detail.Search.SetText(search.term)
+	detail.replacePages(search.term, "0")
+	detail.ShowTx(res.ID)
+	m.pushMainView(txDetailMain, detail)
+}
+
+// jumpToBookmark shows the tx detail of the selected bookmark's chain, starting at the bookmarked tx,
+// or for a bookmarked block, the first tx at or after the block.
+func (m *Model) jumpToBookmark(ctx context.Context, view *bookmarksView) {
+	b, ok := view.SelectedBookmark()
+	if !ok {
+		return
+	}
+	detail, err := m.newTxDetail(ctx, view.testCase.ID, b.ChainPKey, b.ChainID)
+	if err != nil {
+		m.pushErrorModal(err)
+		return
+	}
+	if b.TxID.Valid {
+		detail.ShowTx(b.TxID.Int64)
+	} else {
+		detail.ShowHeight(b.Height)
+	}
+	m.pushMainView(txDetailMain, detail)
+}
+
+// newTxDetail returns a tx detail of the chain's txs, marking the txs and blocks bookmarked in the test case.
+func (m *Model) newTxDetail(ctx context.Context, testCaseID, chainPKey int64, chainID string) (*txDetailView, error) {
+	txs, err := m.querySvc.Transactions(ctx, chainPKey)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+	bookmarks, err := m.querySvc.Bookmarks(ctx, testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query bookmarks: %w", err)
+	}
+	detail := newTxDetailView(chainPKey, chainID, m.decodeTxs(txs))
+	detail.SetBookmarks(bookmarks)
+	return detail, nil
+}
+
+// toggleBookmark bookmarks the shown tx, or its block if block is true, persisting the bookmark in the database.
+func (m *Model) toggleBookmark(ctx context.Context, detail *txDetailView, block bool) {
+	tx, ok := detail.CurrentTx()
+	if !ok {
+		return
+	}
+	var txID int64
+	if !block {
+		txID = tx.ID
+	}
+	bookmarked, err := m.querySvc.ToggleBookmark(ctx, detail.chainPKey, tx.Height, txID)
+	if err != nil {
+		m.pushErrorModal(fmt.Errorf("toggle bookmark: %w", err))
+		return
+	}
+	if block {
+		detail.SetBlockBookmarked(tx.Height, bookmarked)
+	} else {
+		detail.SetTxBookmarked(tx, bookmarked)
+	}
+}
+
+// selectStateDiffHeight selects the height of the selected row. Selecting a second height shows the state diff
+// from the lower to the higher height.
+func (m *Model) selectStateDiffHeight(ctx context.Context, view *stateDiffView) {
+	to, ok := view.SelectedHeight()
+	if !ok || to == view.from {
+		return
+	}
+	from := view.from
+	if from == 0 {
+		view.SelectFrom(to)
+		return
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	oldState, err := m.querySvc.StateExport(ctx, view.chainPKey, from)
+	if err != nil {
+		m.pushErrorModal(fmt.Errorf("query state export at height %d: %w", from, err))
+		return
+	}
+	newState, err := m.querySvc.StateExport(ctx, view.chainPKey, to)
+	if err != nil {
+		m.pushErrorModal(fmt.Errorf("query state export at height %d: %w", to, err))
+		return
+	}
+	changes, err := presenter.StateDiff(oldState, newState)
+	if err != nil {
+		m.pushErrorModal(fmt.Errorf("diff state: %w", err))
+		return
+	}
+	view.SetDiff(from, to, changes)
+}
+
+// startFollow polls for new txs of the detail's chain, appending them to the detail as they are saved,
+// so that an in-progress test can be watched.
+func (m *Model) startFollow(ctx context.Context, detail *txDetailView) {
+	if m.queueUpdate == nil {
+		m.pushErrorModal(errors.New("follow mode is unavailable"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	detail.stopFollow = cancel
+	detail.rerender()
+
+	go func() {
+		tick := time.NewTicker(m.followInterval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+				m.queueUpdate(func() { m.pollFollow(ctx, detail) })
+			}
+		}
+	}()
+}
+
+func (m *Model) stopFollow(detail *txDetailView) {
+	if !detail.Following() {
+		return
+	}
+	detail.stopFollow()
+	detail.stopFollow = nil
+	detail.rerender()
+}
+
+// pollFollow appends txs saved since the detail was last updated.
+// Like Update, it must be called from the main goroutine.
+func (m *Model) pollFollow(ctx context.Context, detail *txDetailView) {
+	if ctx.Err() != nil {
+		// Follow mode stopped while this poll was queued.
+		return
+	}
+	txs, err := m.querySvc.Transactions(ctx, detail.chainPKey)
+	if err != nil {
+		m.stopFollow(detail)
+		m.pushErrorModal(fmt.Errorf("follow transactions: %w", err))
+		return
+	}
+	if len(txs) > len(detail.Txs) {
+		detail.AppendTxs(m.decodeTxs(txs[len(detail.Txs):]))
+	}
+}
+
+// decodeTxs decodes txs that were saved undecoded, if the model has a tx decoder.
+// Txs that fail to decode are shown as saved.
+func (m *Model) decodeTxs(txs []blockdb.TxResult) []blockdb.TxResult {
+	if m.txDecoder == nil {
+		return txs
+	}
+	decoded := make([]blockdb.TxResult, len(txs))
+	for i, tx := range txs {
+		if dtx, err := blockdb.DecodeTx(tx, m.txDecoder); err == nil {
+			tx = dtx
+		}
+		decoded[i] = tx
+	}
+	return decoded
+}
+
 func (m *Model) updateHelp(oldMainContent mainContent) {
 	// Prevent redrawing if nothing has changed.
 	if oldMainContent == m.stack.Current() {
@@ -91,6 +492,7 @@ func (m *Model) mainContentView() *tview.Pages {
 }
 
 func (m *Model) pushMainView(main mainContent, view tview.Primitive) {
+	m.listSearch = nil
 	m.stack = m.stack.Push(main)
 	m.mainContentView().AddAndSwitchToPage(main.String(), view, true)
 }
@@ -99,6 +501,70 @@ func (m *Model) pushErrorModal(err error) {
 	m.pushMainView(errorModalMain, errorModalView(err))
 }
 
+// listSearchMains are the lists searched incrementally with "/". The other main content binds "/" to its own search.
+var listSearchMains = map[mainContent]bool{
+	cosmosMessagesMain:  true,
+	packetsMain:         true,
+	packetLifecycleMain: true,
+	stateDiffMain:       true,
+	bookmarksMain:       true,
+}
+
+// inputFocused reports whether an input of the main content has focus, so that keys are typed rather than bound.
+func (m *Model) inputFocused() bool {
+	switch m.stack.Current() {
+	case txDetailMain:
+		detail := m.txDetailView()
+		return detail.Search.HasFocus() || detail.Filter.HasFocus() || detail.Goto.HasFocus()
+	case txSearchMain:
+		return m.txSearchView().Search.HasFocus()
+	}
+	return false
+}
+
+// focusedTable returns the focused table of the main content, if any.
+func (m *Model) focusedTable() (*tview.Table, bool) {
+	_, primitive := m.mainContentView().GetFrontPage()
+	switch view := primitive.(type) {
+	case *tview.Table:
+		return view, true
+	case *packetsView:
+		return view.Table, true
+	case *bookmarksView:
+		return view.Table, true
+	case *txSearchView:
+		return view.Table, !view.Search.HasFocus()
+	case *stateDiffView:
+		if view.Diff.HasFocus() {
+			return view.Diff, true
+		}
+		return view.HeightsTable, true
+	}
+	return nil, false
+}
+
+// goToTop selects the first row of the focused table, or scrolls the tx detail to the top.
+// It reports false if the main content has neither.
+func (m *Model) goToTop() bool {
+	if m.stack.Current() == txDetailMain {
+		_, primitive := m.txDetailView().Pages.GetFrontPage()
+		if textView, ok := primitive.(*tview.TextView); ok {
+			textView.ScrollToBeginning()
+		}
+		return true
+	}
+	tbl, ok := m.focusedTable()
+	if !ok {
+		return false
+	}
+	// Offset by 1 to account for header row.
+	if tbl.GetRowCount() > 1 {
+		tbl.Select(1, 0)
+	}
+	tbl.ScrollToBeginning()
+	return true
+}
+
 func (m *Model) selectedRow() int {
 	_, view := m.mainContentView().GetFrontPage()
 	row, _ := view.(*tview.Table).GetSelection()
@@ -106,6 +572,26 @@ func (m *Model) selectedRow() int {
 	return row - 1
 }
 
+func (m *Model) txSearchView() *txSearchView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*txSearchView)
+}
+
+func (m *Model) packetsView() *packetsView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*packetsView)
+}
+
+func (m *Model) bookmarksView() *bookmarksView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*bookmarksView)
+}
+
+func (m *Model) stateDiffView() *stateDiffView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*stateDiffView)
+}
+
 func (m *Model) txDetailView() *txDetailView {
 	_, primitive := m.mainContentView().GetFrontPage()
 	return primitive.(*txDetailView)
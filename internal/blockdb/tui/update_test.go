@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -29,10 +30,22 @@ func draw(view tview.Primitive) {
 }
 
 type mockQueryService struct {
-	GotChainPkey int64
-	Messages     []blockdb.CosmosMessageResult
-	Txs          []blockdb.TxResult
-	Err          error
+	GotChainPkey  int64
+	GotTestCaseID int64
+	GotTerm       string
+	Messages      []blockdb.CosmosMessageResult
+	Txs           []blockdb.TxResult
+	SearchResults []blockdb.TxSearchResult
+	PacketResults []blockdb.PacketResult
+	PacketEvents  []blockdb.PacketEventResult
+	GotPacket     blockdb.PacketResult
+	StateHeights  []int64
+	States        map[int64]map[string]json.RawMessage
+	GotHeights    []int64
+	UpgradeList   []blockdb.UpgradeResult
+	BookmarkList  []blockdb.BookmarkResult
+	GotBookmark   blockdb.BookmarkResult
+	Err           error
 }
 
 func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error) {
@@ -51,6 +64,72 @@ func (m *mockQueryService) CosmosMessages(ctx context.Context, chainPkey int64)
 	return m.Messages, m.Err
 }
 
+func (m *mockQueryService) SearchTransactions(ctx context.Context, testCaseID int64, term string) ([]blockdb.TxSearchResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	m.GotTerm = term
+	return m.SearchResults, m.Err
+}
+
+func (m *mockQueryService) Packets(ctx context.Context, testCaseID int64) ([]blockdb.PacketResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	return m.PacketResults, m.Err
+}
+
+func (m *mockQueryService) PacketLifecycle(ctx context.Context, chainPkey int64, srcPort, srcChannel string, sequence uint64) ([]blockdb.PacketEventResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotPacket = blockdb.PacketResult{ChainPKey: chainPkey, SrcPort: srcPort, SrcChannel: srcChannel, Sequence: sequence}
+	return m.PacketEvents, m.Err
+}
+
+func (m *mockQueryService) StateExportHeights(ctx context.Context, chainPkey int64) ([]int64, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.StateHeights, m.Err
+}
+
+func (m *mockQueryService) StateExport(ctx context.Context, chainPkey, height int64) (map[string]json.RawMessage, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	m.GotHeights = append(m.GotHeights, height)
+	return m.States[height], m.Err
+}
+
+func (m *mockQueryService) Upgrades(ctx context.Context, chainPkey int64) ([]blockdb.UpgradeResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.UpgradeList, m.Err
+}
+
+func (m *mockQueryService) Bookmarks(ctx context.Context, testCaseID int64) ([]blockdb.BookmarkResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	return m.BookmarkList, m.Err
+}
+
+func (m *mockQueryService) ToggleBookmark(ctx context.Context, chainPkey, height, txID int64) (bool, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotBookmark = blockdb.BookmarkResult{ChainPKey: chainPkey, Height: height, TxID: sql.NullInt64{Int64: txID, Valid: txID != 0}}
+	return true, m.Err
+}
+
 func TestModel_Update(t *testing.T) {
 	ctx := context.Background()
 
@@ -95,11 +174,45 @@ func TestModel_Update(t *testing.T) {
 		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1")
 	})
 
+	t.Run("cosmos summary view with upgrades", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Messages: []blockdb.CosmosMessageResult{
+				{Height: 10},
+				{Height: 11},
+				{Height: 12},
+			},
+			UpgradeList: []blockdb.UpgradeResult{
+				{Height: 11, OldVersion: "v1", NewVersion: "v2"},
+				{Height: 20, OldVersion: "v2", NewVersion: "v3", Image: "chain:v3"},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('m'))
+
+		_, table := model.mainContentView().GetFrontPage()
+		tbl := table.(*tview.Table)
+
+		// 6 rows: 1 header + 3 blockdb.CosmosMessageResult + 2 blockdb.UpgradeResult
+		require.Equal(t, 6, tbl.GetRowCount())
+		require.Equal(t, "10", tbl.GetCell(1, 0).Text)
+		require.Equal(t, "11", tbl.GetCell(2, 0).Text)
+		require.Equal(t, "Upgrade v1 -> v2", tbl.GetCell(2, 2).Text)
+		require.Equal(t, "11", tbl.GetCell(3, 0).Text)
+		require.Equal(t, "Upgrade v2 -> v3", tbl.GetCell(5, 2).Text)
+		require.Equal(t, "chain:v3", tbl.GetCell(5, 3).Text)
+	})
+
 	t.Run("tx detail", func(t *testing.T) {
 		querySvc := &mockQueryService{
 			Txs: []blockdb.TxResult{
 				{Height: 12, Tx: []byte(`{"tx":1}`)},
-				{Height: 13, Tx: []byte(`{"tx":2}`)},
+				{Height: 13, Tx: []byte(`{"tx":2}`), Code: 5, Codespace: "sdk"},
 				{Height: 14, Tx: []byte(`{"tx":3}`)},
 			},
 		}
@@ -130,6 +243,7 @@ func TestModel_Update(t *testing.T) {
 
 		require.Contains(t, textView.GetTitle(), "Tx 1 of 3")
 		require.Contains(t, textView.GetTitle(), "my-chain1 @ Height 12")
+		require.NotContains(t, textView.GetTitle(), "failed")
 		const wantFirstPage = `{
   "tx": 1
 }`
@@ -142,7 +256,7 @@ func TestModel_Update(t *testing.T) {
 		textView = primitive.(*tview.TextView)
 
 		require.Contains(t, textView.GetTitle(), "Tx 2 of 3")
-		require.Contains(t, textView.GetTitle(), "my-chain1 @ Height 13")
+		require.Contains(t, textView.GetTitle(), "my-chain1 @ Height 13 [Tx 2 of 3] (failed: sdk code 5)")
 		const wantSecondPage = `{
   "tx": 2
 }`
@@ -214,4 +328,667 @@ func TestModel_Update(t *testing.T) {
 		// properly with the nested flex views.
 		require.IsType(t, &tview.Modal{}, primative.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
 	})
+
+	t.Run("tx detail filter", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{Height: 12, Tx: []byte(`{"body":{"messages":[{"@type":"/ibc.core.channel.v1.MsgRecvPacket"}]}}`)},
+				{Height: 13, Tx: []byte(`{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"}]}}`)},
+				{Height: 14, Tx: []byte(`{"body":{"messages":[{"@type":"/ibc.core.channel.v1.MsgRecvPacket"}]}}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		var gotText string
+		model.clipboard = func(text string) error {
+			gotText = text
+			return nil
+		}
+
+		update := model.Update(ctx)
+
+		update(enterKey)
+		txDetail := model.txDetailView()
+
+		update(runeKey('t'))
+		require.True(t, txDetail.Filter.HasFocus())
+
+		// Typing passes through to the filter input.
+		require.NotNil(t, update(runeKey('c')))
+
+		txDetail.Filter.SetText("MsgRecvPacket 13-")
+		update(enterKey)
+		require.False(t, txDetail.Filter.HasFocus())
+
+		require.Equal(t, 1, txDetail.Pages.GetPageCount())
+		_, primitive := txDetail.Pages.GetFrontPage()
+		textView := primitive.(*tview.TextView)
+		require.Contains(t, textView.GetTitle(), "my-chain1 @ Height 14 [Tx 1 of 1]")
+		require.Contains(t, textView.GetTitle(), `[Filter "MsgRecvPacket 13-" of 3 txs]`)
+
+		// Copy only copies the filtered txs.
+		update(runeKey('c'))
+		var gotTxs []any
+		require.NoError(t, json.Unmarshal([]byte(gotText), &gotTxs))
+		require.Len(t, gotTxs, 1)
+
+		// No matches.
+		update(runeKey('t'))
+		txDetail.Filter.SetText("MsgTransfer")
+		update(enterKey)
+
+		require.Equal(t, 1, txDetail.Pages.GetPageCount())
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetText(true), `No txs match filter "MsgTransfer".`)
+
+		// Clearing the filter shows all txs.
+		update(runeKey('t'))
+		txDetail.Filter.SetText("")
+		update(enterKey)
+
+		require.Equal(t, 3, txDetail.Pages.GetPageCount())
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "Tx 1 of 3")
+
+		// Invalid filter.
+		update(runeKey('t'))
+		txDetail.Filter.SetText("200-100")
+		update(enterKey)
+
+		_, primitive = model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Modal{}, primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
+	})
+
+	t.Run("tx detail goto height", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{ID: 1, Height: 12, Tx: []byte(`{"tx":1}`)},
+				{ID: 2, Height: 15, Tx: []byte(`{"tx":2}`)},
+				{ID: 3, Height: 20, Tx: []byte(`{"tx":3}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+		txDetail := model.txDetailView()
+
+		update(runeKey(':'))
+		require.True(t, txDetail.Goto.HasFocus())
+
+		// Typing passes through to the goto input.
+		require.NotNil(t, update(runeKey('1')))
+
+		// Goes to the first tx at or after the height.
+		txDetail.Goto.SetText("13")
+		update(enterKey)
+		require.False(t, txDetail.Goto.HasFocus())
+		_, primitive := txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "my-chain1 @ Height 15 [Tx 2 of 3]")
+
+		update(runeKey(':'))
+		update(escKey)
+		require.False(t, txDetail.Goto.HasFocus())
+		require.Same(t, txDetail, model.txDetailView())
+
+		for _, text := range []string{"21", "nope"} {
+			update(runeKey(':'))
+			txDetail.Goto.SetText(text)
+			update(enterKey)
+
+			_, primitive = model.mainContentView().GetFrontPage()
+			require.IsType(t, &tview.Modal{}, primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
+			update(escKey)
+		}
+	})
+
+	t.Run("vim navigation", func(t *testing.T) {
+		model := NewModel(&mockQueryService{}, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5}, {ChainPKey: 6}, {ChainPKey: 7},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		_, primitive := model.mainContentView().GetFrontPage()
+		tbl := primitive.(*tview.Table)
+		tbl.Select(3, 0)
+
+		// A single g does nothing.
+		require.Nil(t, update(runeKey('g')))
+		update(runeKey('x'))
+		require.Nil(t, update(runeKey('g')))
+		row, _ := tbl.GetSelection()
+		require.Equal(t, 3, row)
+
+		require.Nil(t, update(runeKey('g')))
+		row, _ = tbl.GetSelection()
+		require.Equal(t, 1, row)
+
+		// The table handles other vim keys.
+		require.NotNil(t, update(runeKey('G')))
+		require.NotNil(t, update(runeKey('j')))
+	})
+
+	t.Run("list search", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Messages: []blockdb.CosmosMessageResult{
+				{Height: 10, Type: "/ibc.core.client.v1.MsgCreateClient"},
+				{Height: 11, Type: "/ibc.core.channel.v1.MsgRecvPacket"},
+				{Height: 12, Type: "/cosmos.bank.v1beta1.MsgSend"},
+				{Height: 13, Type: "/ibc.core.channel.v1.MsgRecvPacket"},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('m'))
+		_, primitive := model.mainContentView().GetFrontPage()
+		tbl := primitive.(*tview.Table)
+		draw(tbl)
+		title := tbl.GetTitle()
+
+		typeTerm := func(term string) {
+			for _, r := range term {
+				require.Nil(t, update(runeKey(r)))
+			}
+		}
+		selected := func() int {
+			row, _ := tbl.GetSelection()
+			return row
+		}
+
+		// Selects matches incrementally.
+		update(runeKey('/'))
+		typeTerm("msgs")
+		require.Equal(t, 3, selected())
+		require.Equal(t, title+" [/msgs]", tbl.GetTitle())
+		typeTerm("x")
+		require.Equal(t, title+" [/msgsx] (no match)", tbl.GetTitle())
+		update(tcell.NewEventKey(tcell.KeyBackspace2, 0, 0))
+		require.Equal(t, 3, selected())
+
+		// Esc cancels the search, without leaving the view.
+		update(escKey)
+		require.Equal(t, 1, selected())
+		require.Equal(t, title, tbl.GetTitle())
+		require.Equal(t, cosmosMessagesMain, model.stack.Current())
+
+		update(runeKey('/'))
+		typeTerm("RECV")
+		require.Equal(t, 2, selected())
+		update(enterKey)
+		require.Equal(t, title+" [/RECV]", tbl.GetTitle())
+
+		// Keys are no longer typed, so n and N move between matches, wrapping around.
+		update(runeKey('n'))
+		require.Equal(t, 4, selected())
+		update(runeKey('n'))
+		require.Equal(t, 2, selected())
+		update(runeKey('N'))
+		require.Equal(t, 4, selected())
+
+		// Entering an empty term cancels the search.
+		update(runeKey('/'))
+		update(enterKey)
+		require.Equal(t, title, tbl.GetTitle())
+		update(runeKey('n'))
+		require.Equal(t, 4, selected())
+
+		update(escKey)
+		require.Equal(t, testCasesMain, model.stack.Current())
+	})
+
+	t.Run("tx detail next match", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{ID: 1, Height: 12, Tx: []byte(`{"memo":"hello"}`)},
+				{ID: 2, Height: 15, Tx: []byte(`{"memo":"other"}`)},
+				{ID: 3, Height: 20, Tx: []byte(`{"memo":"hello again"}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+		txDetail := model.txDetailView()
+
+		update(runeKey('/'))
+		txDetail.Search.SetText("hello")
+		update(enterKey)
+
+		title := func() string {
+			_, primitive := txDetail.Pages.GetFrontPage()
+			return primitive.(*tview.TextView).GetTitle()
+		}
+		update(runeKey('n'))
+		require.Contains(t, title(), "[Tx 3 of 3]")
+		update(runeKey('n'))
+		require.Contains(t, title(), "[Tx 1 of 3]")
+		update(runeKey('N'))
+		require.Contains(t, title(), "[Tx 3 of 3]")
+
+		// Typed while searching.
+		update(runeKey('/'))
+		require.NotNil(t, update(runeKey('n')))
+	})
+
+	t.Run("bookmarks", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{ID: 1, Height: 12, Tx: []byte(`{"tx":1}`)},
+				{ID: 2, Height: 15, Tx: []byte(`{"tx":2}`)},
+				{ID: 3, Height: 20, Tx: []byte(`{"tx":3}`)},
+			},
+			BookmarkList: []blockdb.BookmarkResult{
+				{ChainPKey: 5, ChainID: "my-chain1", Height: 14},
+				{ChainPKey: 5, ChainID: "my-chain1", Height: 20, TxID: sql.NullInt64{Int64: 3, Valid: true}, TxHash: "CC"},
+				{ChainPKey: 6, ChainID: "my-chain2", Height: 12, TxID: sql.NullInt64{Int64: 1, Valid: true}},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 9, ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('b'))
+
+		require.EqualValues(t, 9, querySvc.GotTestCaseID)
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		bookmarks := model.bookmarksView()
+		// 4 rows: 1 header + 3 blockdb.BookmarkResult
+		require.Equal(t, 4, bookmarks.GetRowCount())
+
+		// A bookmarked block shows the first tx at or after the block.
+		draw(model.RootView())
+		update(enterKey)
+		txDetail := model.txDetailView()
+		_, primitive := txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "my-chain1 @ Height 15 [Tx 2 of 3]")
+		require.NotContains(t, primitive.(*tview.TextView).GetTitle(), "bookmarked")
+
+		// A bookmarked tx shows the tx. Only bookmarks of the chain are marked.
+		update(escKey)
+		bookmarks.Select(2, 0)
+		update(enterKey)
+		txDetail = model.txDetailView()
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "my-chain1 @ Height 20 [Tx 3 of 3] (bookmarked)")
+		update(runeKey('['))
+		update(runeKey('['))
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.NotContains(t, primitive.(*tview.TextView).GetTitle(), "bookmarked")
+
+		// Bookmark the shown tx, then its block.
+		update(runeKey('b'))
+		require.Equal(t, blockdb.BookmarkResult{ChainPKey: 5, Height: 12, TxID: sql.NullInt64{Int64: 1, Valid: true}}, querySvc.GotBookmark)
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "[Tx 1 of 3] (bookmarked)")
+
+		update(runeKey(']'))
+		update(runeKey('B'))
+		require.Equal(t, blockdb.BookmarkResult{ChainPKey: 5, Height: 15}, querySvc.GotBookmark)
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "[Tx 2 of 3] (block bookmarked)")
+
+		querySvc.Err = errors.New("boom")
+		update(runeKey('b'))
+		_, primitive = model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Modal{}, primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
+	})
+
+	t.Run("ibc packets", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			PacketResults: []blockdb.PacketResult{
+				{ChainPKey: 5, ChainID: "my-chain1", Sequence: 1, SrcPort: "transfer", SrcChannel: "channel-0"},
+				{ChainPKey: 5, ChainID: "my-chain1", Sequence: 2, SrcPort: "transfer", SrcChannel: "channel-0"},
+			},
+			PacketEvents: []blockdb.PacketEventResult{
+				{ChainID: "my-chain1", Type: blockdb.PacketEventSend},
+				{ChainID: "my-chain2", Type: blockdb.PacketEventRecv},
+				{ChainID: "my-chain1", Type: blockdb.PacketEventAck},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "test1", ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+		require.Equal(t, packetsMain, model.stack.Current())
+
+		_, primitive := model.mainContentView().GetFrontPage()
+		packets := primitive.(*packetsView)
+		// 3 rows: 1 header + 2 blockdb.PacketResult
+		require.Equal(t, 3, packets.GetRowCount())
+		require.Contains(t, packets.GetTitle(), "test1")
+
+		packets.Select(2, 0)
+		update(enterKey)
+
+		require.Equal(t, blockdb.PacketResult{ChainPKey: 5, Sequence: 2, SrcPort: "transfer", SrcChannel: "channel-0"}, querySvc.GotPacket)
+		require.Equal(t, packetLifecycleMain, model.stack.Current())
+		_, primitive = model.mainContentView().GetFrontPage()
+		// 4 rows: 1 header + 3 blockdb.PacketEventResult
+		require.Equal(t, 4, primitive.(*tview.Table).GetRowCount())
+		require.Contains(t, primitive.(*tview.Table).GetTitle(), "#2")
+
+		update(escKey)
+		update(escKey)
+		require.Equal(t, testCasesMain, model.stack.Current())
+	})
+
+	t.Run("ibc packet correlation", func(t *testing.T) {
+		sent := time.Now()
+		querySvc := &mockQueryService{
+			PacketResults: []blockdb.PacketResult{
+				{ChainPKey: 5, ChainID: "my-chain1", Sequence: 1, SrcPort: "transfer", SrcChannel: "channel-0", DstPort: "transfer", DstChannel: "channel-1"},
+			},
+			PacketEvents: []blockdb.PacketEventResult{
+				{ChainID: "my-chain1", Height: 10, BlockTime: sent, Type: blockdb.PacketEventSend, Tx: []byte(`{"tx":"send"}`)},
+				{ChainID: "my-chain2", Height: 20, BlockTime: sent.Add(2 * time.Second), Type: blockdb.PacketEventRecv, Tx: []byte(`{"tx":"recv"}`)},
+				{ChainID: "my-chain1", Height: 13, BlockTime: sent.Add(5 * time.Second), Type: blockdb.PacketEventAck, Tx: []byte(`{"tx":"ack"}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "test1", ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+		_, primitive := model.mainContentView().GetFrontPage()
+		primitive.(*packetsView).Select(1, 0)
+		update(runeKey('x'))
+
+		require.EqualValues(t, 1, querySvc.GotPacket.Sequence)
+		require.Equal(t, packetCorrelationMain, model.stack.Current())
+
+		_, primitive = model.mainContentView().GetFrontPage()
+		flex := primitive.(*tview.Flex)
+
+		events := flex.GetItem(0).(*tview.Table)
+		// 4 rows: 1 header + 3 blockdb.PacketEventResult
+		require.Equal(t, 4, events.GetRowCount())
+		require.Equal(t, "+2s", events.GetCell(2, 3).Text)
+		require.Equal(t, "+5s", events.GetCell(3, 3).Text)
+
+		txs := flex.GetItem(1).(*tview.Flex)
+		src := txs.GetItem(0).(*tview.TextView)
+		require.Equal(t, "my-chain1 transfer/channel-0", src.GetTitle())
+		require.Contains(t, src.GetText(true), "send_packet @ Height 10 (+0s)")
+		require.Contains(t, src.GetText(true), `"tx": "send"`)
+		require.Contains(t, src.GetText(true), "acknowledge_packet @ Height 13 (+5s)")
+		require.Contains(t, src.GetText(true), `"tx": "ack"`)
+
+		dst := txs.GetItem(1).(*tview.TextView)
+		require.Contains(t, dst.GetText(true), "recv_packet @ Height 20 (+2s)")
+		require.Contains(t, dst.GetText(true), `"tx": "recv"`)
+		require.NotContains(t, dst.GetText(true), `"tx": "send"`)
+
+		// Packet not received.
+		update(escKey)
+		querySvc.PacketEvents = querySvc.PacketEvents[:1]
+		update(runeKey('x'))
+
+		_, primitive = model.mainContentView().GetFrontPage()
+		dst = primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1).(*tview.TextView)
+		require.Equal(t, "Destination transfer/channel-1", dst.GetTitle())
+		require.Equal(t, "Not received.", dst.GetText(true))
+	})
+
+	t.Run("ibc packets error", func(t *testing.T) {
+		querySvc := &mockQueryService{Err: errors.New("boom")}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ID: 3}})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+
+		_, primitive := model.mainContentView().GetFrontPage()
+		modal := primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1)
+		require.IsType(t, &tview.Modal{}, modal)
+	})
+
+	t.Run("state diff", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			StateHeights: []int64{10, 20, 30},
+			States: map[int64]map[string]json.RawMessage{
+				10: {"bank": json.RawMessage(`{"supply":"1"}`)},
+				30: {"bank": json.RawMessage(`{"supply":"3"}`), "gov": json.RawMessage(`{}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('s'))
+
+		require.EqualValues(t, 5, querySvc.GotChainPkey)
+		require.Equal(t, stateDiffMain, model.stack.Current())
+
+		view := model.stateDiffView()
+		// 4 rows: 1 header + 3 heights
+		require.Equal(t, 4, view.HeightsTable.GetRowCount())
+
+		// Select the higher height first; the diff is always from the lower height.
+		view.HeightsTable.Select(3, 0)
+		update(enterKey)
+		require.Empty(t, querySvc.GotHeights)
+		require.Contains(t, view.Diff.GetTitle(), "From height 30")
+
+		view.HeightsTable.Select(1, 0)
+		update(enterKey)
+		require.Equal(t, []int64{10, 30}, querySvc.GotHeights)
+		require.Contains(t, view.Diff.GetTitle(), "Height 10 -> 30: 2 changes")
+		// 3 rows: 1 header + 2 changes
+		require.Equal(t, 3, view.Diff.GetRowCount())
+		require.Equal(t, "bank.supply", view.Diff.GetCell(1, 0).Text)
+		require.Equal(t, "changed", view.Diff.GetCell(1, 1).Text)
+		require.Equal(t, `"1"`, view.Diff.GetCell(1, 2).Text)
+		require.Equal(t, `"3"`, view.Diff.GetCell(1, 3).Text)
+		require.Equal(t, "gov", view.Diff.GetCell(2, 0).Text)
+		require.Equal(t, "added", view.Diff.GetCell(2, 1).Text)
+
+		update(tcell.NewEventKey(tcell.KeyTab, ' ', 0))
+		require.True(t, view.Diff.HasFocus())
+		require.False(t, view.HeightsTable.HasFocus())
+
+		// Too few exports.
+		update(escKey)
+		querySvc.StateHeights = []int64{10}
+		update(runeKey('s'))
+		_, primitive := model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Modal{}, primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
+	})
+
+	t.Run("tx search", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{ID: 1, Height: 12, Tx: []byte(`{"tx":1}`)},
+				{ID: 2, Height: 13, Tx: []byte(`{"tx":"MsgTransfer"}`)},
+				{ID: 3, Height: 14, Tx: []byte(`{"tx":3}`)},
+			},
+			SearchResults: []blockdb.TxSearchResult{
+				{ChainPKey: 6, ChainID: "my-chain2", TxResult: blockdb.TxResult{ID: 2, Height: 13, Tx: []byte(`{"tx":"MsgTransfer"}`)}},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 9, ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('/'))
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		search := model.txSearchView()
+		require.True(t, search.Search.HasFocus())
+
+		search.Search.SetText("MsgTransfer")
+		update(enterKey)
+
+		require.EqualValues(t, 9, querySvc.GotTestCaseID)
+		require.Equal(t, "MsgTransfer", querySvc.GotTerm)
+		require.False(t, search.Search.HasFocus())
+		// 2 rows: 1 header + 1 blockdb.TxSearchResult
+		require.Equal(t, 2, search.Table.GetRowCount())
+		require.Contains(t, search.Table.GetTitle(), `1 txs matching "MsgTransfer"`)
+
+		// Jump to the selected result.
+		draw(model.RootView())
+		update(enterKey)
+
+		require.EqualValues(t, 6, querySvc.GotChainPkey)
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+
+		txDetail := model.txDetailView()
+		_, primitive := txDetail.Pages.GetFrontPage()
+		textView := primitive.(*tview.TextView)
+		require.Contains(t, textView.GetTitle(), "my-chain2 @ Height 13 [Tx 2 of 3]")
+
+		// Going back returns to the search results.
+		update(escKey)
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		require.Same(t, search, model.txSearchView())
+
+		// Edit the search.
+		update(runeKey('/'))
+		require.True(t, search.Search.HasFocus())
+
+		querySvc.Err = errors.New("boom")
+		update(enterKey)
+		_, primitive = model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Modal{}, primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
+	})
+
+	t.Run("tx detail decodes raw txs", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{ID: 1, Height: 12, Tx: blockdb.RawTxData([]byte("raw"))},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+		model.SetTxDecoder(mockTxDecoder{})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+
+		_, primitive := model.txDetailView().Pages.GetFrontPage()
+		textView := primitive.(*tview.TextView)
+		const want = `{
+  "decoded": "raw"
+}`
+		require.Equal(t, want, textView.GetText(true))
+	})
+
+	t.Run("tx detail follow", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Txs: []blockdb.TxResult{
+				{ID: 1, Height: 12, Tx: []byte(`{"tx":1}`)},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+		model.followInterval = time.Millisecond
+		queued := make(chan func())
+		model.SetQueueUpdate(func(f func()) { queued <- f })
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+		txDetail := model.txDetailView()
+
+		update(runeKey('f'))
+		require.True(t, txDetail.Following())
+
+		_, primitive := txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "Tx 1 of 1] (following)")
+
+		// Simulate more blocks saved by the running test.
+		querySvc.Txs = append(querySvc.Txs,
+			blockdb.TxResult{ID: 2, Height: 13, Tx: []byte(`{"tx":2}`)},
+			blockdb.TxResult{ID: 3, Height: 14, Tx: []byte(`{"tx":3}`)},
+		)
+		(<-queued)()
+
+		require.Len(t, txDetail.Txs, 3)
+		require.Equal(t, 3, txDetail.Pages.GetPageCount())
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.Contains(t, primitive.(*tview.TextView).GetTitle(), "my-chain1 @ Height 14 [Tx 3 of 3] (following)")
+
+		update(runeKey('f'))
+		require.False(t, txDetail.Following())
+		_, primitive = txDetail.Pages.GetFrontPage()
+		require.NotContains(t, primitive.(*tview.TextView).GetTitle(), "following")
+
+		// A poll queued before follow mode stopped is ignored.
+		select {
+		case f := <-queued:
+			querySvc.Txs = append(querySvc.Txs, blockdb.TxResult{ID: 4, Height: 15, Tx: []byte(`{"tx":4}`)})
+			f()
+			require.Len(t, txDetail.Txs, 3)
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		// Going back stops follow mode.
+		update(runeKey('f'))
+		update(escKey)
+		require.False(t, txDetail.Following())
+	})
+
+	t.Run("tx detail follow unavailable", func(t *testing.T) {
+		model := NewModel(&mockQueryService{}, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 5, ChainID: "my-chain1"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(enterKey)
+		update(runeKey('f'))
+
+		_, primitive := model.mainContentView().GetFrontPage()
+		require.IsType(t, &tview.Modal{}, primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1))
+	})
+}
+
+type mockTxDecoder struct{}
+
+func (mockTxDecoder) DecodeTx(raw []byte) ([]byte, error) {
+	return []byte(`{"decoded":"` + string(raw) + `"}`), nil
 }
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -121,7 +122,9 @@ func testCasesView(m *Model) *tview.Table {
 	return detailTableView("Test Cases", headers, rows)
 }
 
-func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageResult) *tview.Table {
+// cosmosMessagesView annotates the messages with a row for each upgrade, placed before the messages of the
+// upgrade height. Both msgs and upgrades must be ordered by height.
+func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageResult, upgrades []blockdb.UpgradeResult) *tview.Table {
 	headers := []string{
 		"Height",
 		"Index",
@@ -132,10 +135,19 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 		"Channel:Port",
 	}
 
-	rows := make([][]string, len(msgs))
-	for i, msg := range msgs {
+	upgradeRow := func(upgrade blockdb.UpgradeResult) []string {
+		pres := presenter.Upgrade{Result: upgrade}
+		return []string{pres.Height(), "", pres.Versions(), pres.Image(), "", "", ""}
+	}
+
+	rows := make([][]string, 0, len(msgs)+len(upgrades))
+	for _, msg := range msgs {
+		for len(upgrades) > 0 && upgrades[0].Height <= msg.Height {
+			rows = append(rows, upgradeRow(upgrades[0]))
+			upgrades = upgrades[1:]
+		}
 		pres := presenter.CosmosMessage{Result: msg}
-		rows[i] = []string{
+		rows = append(rows, []string{
 			pres.Height(),
 			pres.Index(),
 			pres.Type(),
@@ -143,7 +155,10 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 			pres.Clients(),
 			pres.Connections(),
 			pres.Channels(),
-		}
+		})
+	}
+	for _, upgrade := range upgrades {
+		rows = append(rows, upgradeRow(upgrade))
 	}
 
 	title := fmt.Sprintf("%s [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
@@ -176,26 +191,52 @@ const (
 type txDetailView struct {
 	*tview.Flex
 
-	chainID string
+	chainPKey  int64
+	chainID    string
+	searchTerm string
+
+	// filter narrows Txs to the visible txs shown in Pages.
+	filter  presenter.TxFilter
+	visible []blockdb.TxResult
+
+	// stopFollow is set while in follow mode.
+	stopFollow context.CancelFunc
+
+	// Bookmarked tx primary keys and block heights of the chain.
+	bookmarkedTxs    map[int64]bool
+	bookmarkedBlocks map[int64]bool
 
 	Txs    []blockdb.TxResult
 	Pages  *tview.Pages
 	Search *tview.InputField
+	Filter *tview.InputField
+	Goto   *tview.InputField
 }
 
-func newTxDetailView(chainID string, txs []blockdb.TxResult) *txDetailView {
+func newTxDetailView(chainPKey int64, chainID string, txs []blockdb.TxResult) *txDetailView {
 	detail := &txDetailView{
-		chainID: chainID,
-		Txs:     txs,
+		chainPKey:        chainPKey,
+		chainID:          chainID,
+		bookmarkedTxs:    make(map[int64]bool),
+		bookmarkedBlocks: make(map[int64]bool),
+		Txs:              txs,
 	}
 
 	detail.Pages = tview.NewPages()
 	detail.replacePages("", "0")
-	detail.Search = detail.buildSearchInput()
+	detail.Search = searchInputView()
+	detail.Filter = filterInputView()
+	detail.Goto = gotoInputView()
+
+	inputs := tview.NewFlex().SetDirection(tview.FlexColumn)
+	inputs.SetBorder(false)
+	inputs.AddItem(detail.Search, 0, 2, false)
+	inputs.AddItem(detail.Filter, 0, 2, false)
+	inputs.AddItem(detail.Goto, 0, 1, false)
 
 	flex := tview.NewFlex().SetDirection(tview.FlexRow)
 	flex.SetBorder(false)
-	flex.AddItem(detail.Search, 3, 1, false)
+	flex.AddItem(inputs, 3, 1, false)
 	flex.AddItem(detail.Pages, 0, 9, true)
 
 	detail.Flex = flex
@@ -234,12 +275,199 @@ func (detail *txDetailView) DoSearch() {
 	detail.replacePages(term, idx)
 }
 
-// "pageIdx" is an integer string, e.g. "0", "1".
+func (detail *txDetailView) ActivateFilter() {
+	detail.deactivateSearch()
+	detail.Filter.SetBorderColor(searchActiveColor)
+	detail.Filter.SetFieldTextColor(searchActiveColor)
+	detail.Filter.SetTitleColor(searchActiveColor)
+	detail.Filter.Focus(nil)
+	detail.Pages.Blur()
+}
+
+func (detail *txDetailView) DeactivateFilter() {
+	detail.Filter.SetBorderColor(searchInactiveColor)
+	detail.Filter.SetFieldTextColor(searchInactiveColor)
+	detail.Filter.SetTitleColor(searchInactiveColor)
+	detail.Filter.Blur()
+	detail.Pages.Focus(nil)
+}
+
+// ApplyFilter parses the filter input and shows only the txs matching it, starting at the first match.
+func (detail *txDetailView) ApplyFilter() error {
+	filter, err := presenter.ParseTxFilter(detail.Filter.GetText())
+	if err != nil {
+		return err
+	}
+	detail.DeactivateFilter()
+	detail.filter = filter
+	detail.replacePages(detail.searchTerm, "0")
+	return nil
+}
+
+func (detail *txDetailView) ActivateGoto() {
+	detail.deactivateSearch()
+	detail.Goto.SetBorderColor(searchActiveColor)
+	detail.Goto.SetFieldTextColor(searchActiveColor)
+	detail.Goto.SetTitleColor(searchActiveColor)
+	detail.Goto.Focus(nil)
+	detail.Pages.Blur()
+}
+
+func (detail *txDetailView) DeactivateGoto() {
+	detail.Goto.SetBorderColor(searchInactiveColor)
+	detail.Goto.SetFieldTextColor(searchInactiveColor)
+	detail.Goto.SetTitleColor(searchInactiveColor)
+	detail.Goto.Blur()
+	detail.Pages.Focus(nil)
+}
+
+// ApplyGoto parses the height of the goto input and shows the first visible tx at or after the height.
+func (detail *txDetailView) ApplyGoto() error {
+	height, err := strconv.ParseInt(strings.TrimSpace(detail.Goto.GetText()), 10, 64)
+	if err != nil || height <= 0 {
+		return fmt.Errorf("invalid height %q", detail.Goto.GetText())
+	}
+	detail.DeactivateGoto()
+	detail.Goto.SetText("")
+	if !detail.ShowHeight(height) {
+		return fmt.Errorf("no txs at or after height %d", height)
+	}
+	return nil
+}
+
+// ShowHeight shows the first visible tx at or after height, reporting whether there is one.
+func (detail *txDetailView) ShowHeight(height int64) bool {
+	for i, tx := range detail.visible {
+		if tx.Height >= height {
+			detail.Pages.SwitchToPage(strconv.Itoa(i))
+			return true
+		}
+	}
+	return false
+}
+
+// ShowTx shows the visible tx with primary key id, reporting whether it is visible.
+func (detail *txDetailView) ShowTx(id int64) bool {
+	for i, tx := range detail.visible {
+		if tx.ID == id {
+			detail.Pages.SwitchToPage(strconv.Itoa(i))
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentTx returns the shown tx, if any.
+func (detail *txDetailView) CurrentTx() (blockdb.TxResult, bool) {
+	idx, _ := detail.Pages.GetFrontPage()
+	i, err := strconv.Atoi(idx)
+	if err != nil || i < 0 || i >= len(detail.visible) {
+		return blockdb.TxResult{}, false
+	}
+	return detail.visible[i], true
+}
+
+// SetBookmarks marks the txs and blocks of the bookmarks of the detail's chain as bookmarked.
+func (detail *txDetailView) SetBookmarks(bookmarks []blockdb.BookmarkResult) {
+	for _, b := range bookmarks {
+		if b.ChainPKey != detail.chainPKey {
+			continue
+		}
+		if b.TxID.Valid {
+			detail.bookmarkedTxs[b.TxID.Int64] = true
+		} else {
+			detail.bookmarkedBlocks[b.Height] = true
+		}
+	}
+	detail.rerender()
+}
+
+// SetTxBookmarked marks the tx as bookmarked or not.
+func (detail *txDetailView) SetTxBookmarked(tx blockdb.TxResult, bookmarked bool) {
+	detail.bookmarkedTxs[tx.ID] = bookmarked
+	detail.rerender()
+}
+
+// SetBlockBookmarked marks the block at height as bookmarked or not.
+func (detail *txDetailView) SetBlockBookmarked(height int64, bookmarked bool) {
+	detail.bookmarkedBlocks[height] = bookmarked
+	detail.rerender()
+}
+
+// ShowMatch shows the next visible tx, or the previous tx if forward is false, whose data matches the search term,
+// wrapping around at the last or first tx. It reports whether a tx matches.
+func (detail *txDetailView) ShowMatch(forward bool) bool {
+	highlight := presenter.NewHighlight(detail.searchTerm)
+	idx, _ := detail.Pages.GetFrontPage()
+	cur, err := strconv.Atoi(idx)
+	if err != nil {
+		return false
+	}
+	n := len(detail.visible)
+	for step := 1; step <= n; step++ {
+		i := cur + step
+		if !forward {
+			i = cur - step
+		}
+		i = (i%n + n) % n
+		if highlight.Matches(presenter.Tx{Result: detail.visible[i]}.Data()) {
+			detail.Pages.SwitchToPage(strconv.Itoa(i))
+			return true
+		}
+	}
+	return false
+}
+
+// VisibleTxs are the txs matching the filter.
+func (detail *txDetailView) VisibleTxs() []blockdb.TxResult {
+	return detail.visible
+}
+
+// Following reports whether the view is in follow mode.
+func (detail *txDetailView) Following() bool {
+	return detail.stopFollow != nil
+}
+
+// AppendTxs adds txs after the existing txs and shows the last visible tx.
+func (detail *txDetailView) AppendTxs(txs []blockdb.TxResult) {
+	detail.Txs = append(detail.Txs, txs...)
+	detail.replacePages(detail.searchTerm, strconv.Itoa(len(detail.filter.Filter(detail.Txs))-1))
+}
+
+// rerender renders the pages again, keeping the current page.
+func (detail *txDetailView) rerender() {
+	idx, _ := detail.Pages.GetFrontPage()
+	detail.replacePages(detail.searchTerm, idx)
+}
+
+// noTxsPage is the page shown when no txs match the filter.
+const noTxsPage = "none"
+
+// "pageIdx" is an integer string, e.g. "0", "1", indexing the visible txs.
 func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
+	detail.searchTerm = searchTerm
+	// All pages but noTxsPage are named by index.
+	for i := detail.Pages.GetPageCount() - 1; i >= 0; i-- {
+		detail.Pages.RemovePage(strconv.Itoa(i))
+	}
+	detail.Pages.RemovePage(noTxsPage)
+
+	detail.visible = detail.filter.Filter(detail.Txs)
+	if len(detail.visible) == 0 && !detail.filter.IsZero() {
+		textView := tview.NewTextView().
+			SetText(fmt.Sprintf("No txs match filter %q.", detail.filter.String())).
+			SetTextColor(textColor)
+		textView.SetBorder(true).
+			SetBorderPadding(0, 0, 1, 1).
+			SetBorderAttributes(tcell.AttrDim)
+		textView.SetTitle(fmt.Sprintf("%s [Filter %q of %d txs]", detail.chainID, detail.filter.String(), len(detail.Txs)))
+		detail.Pages.AddAndSwitchToPage(noTxsPage, textView, true)
+		return
+	}
+
 	highlight := presenter.NewHighlight(searchTerm)
-	for i, tx := range detail.Txs {
+	for i, tx := range detail.visible {
 		idx := strconv.Itoa(i)
-		detail.Pages.RemovePage(idx)
 
 		pres := presenter.Tx{Result: tx}
 		text, regions := highlight.Text(pres.Data())
@@ -259,7 +487,23 @@ func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
 			SetBorderPadding(0, 0, 1, 1).
 			SetBorderAttributes(tcell.AttrDim)
 
-		textView.SetTitle(fmt.Sprintf("%s @ Height %d [Tx %d of %d]", detail.chainID, tx.Height, i+1, len(detail.Txs)))
+		title := fmt.Sprintf("%s @ Height %d [Tx %d of %d]", detail.chainID, tx.Height, i+1, len(detail.visible))
+		if !detail.filter.IsZero() {
+			title += fmt.Sprintf(" [Filter %q of %d txs]", detail.filter.String(), len(detail.Txs))
+		}
+		if tx.Code != 0 {
+			title += fmt.Sprintf(" (failed: %s code %d)", tx.Codespace, tx.Code)
+		}
+		switch {
+		case detail.bookmarkedTxs[tx.ID]:
+			title += " (bookmarked)"
+		case detail.bookmarkedBlocks[tx.Height]:
+			title += " (block bookmarked)"
+		}
+		if detail.Following() {
+			title += " (following)"
+		}
+		textView.SetTitle(title)
 
 		detail.Pages.AddPage(idx, textView, true, false)
 	}
@@ -267,7 +511,7 @@ func (detail *txDetailView) replacePages(searchTerm, pageIdx string) {
 	detail.Pages.SwitchToPage(pageIdx)
 }
 
-func (*txDetailView) buildSearchInput() *tview.InputField {
+func searchInputView() *tview.InputField {
 	input := tview.NewInputField().
 		SetFieldTextColor(searchInactiveColor).
 		SetFieldBackgroundColor(backgroundColor)
@@ -280,3 +524,488 @@ func (*txDetailView) buildSearchInput() *tview.InputField {
 		SetBorderColor(searchInactiveColor)
 	return input
 }
+
+func filterInputView() *tview.InputField {
+	input := searchInputView()
+	input.SetTitle("Filter (msg type and heights, e.g. MsgRecvPacket 100-200)")
+	return input
+}
+
+func gotoInputView() *tview.InputField {
+	input := searchInputView()
+	input.SetTitle("Go to height")
+	return input
+}
+
+// txSearchView runs a full-text search over the txs of a test case,
+// listing the matching txs so the user may jump to one of them.
+type txSearchView struct {
+	*tview.Flex
+
+	testCase blockdb.TestCaseResult
+	// term is the search term of the displayed results.
+	term string
+
+	Results []blockdb.TxSearchResult
+	Search  *tview.InputField
+	Table   *tview.Table
+}
+
+func newTxSearchView(tc blockdb.TestCaseResult) *txSearchView {
+	search := &txSearchView{testCase: tc}
+
+	search.Search = searchInputView()
+	search.Table = search.buildResultsTable()
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorder(false)
+	flex.AddItem(search.Search, 3, 1, true)
+	flex.AddItem(search.Table, 0, 9, false)
+
+	search.Flex = flex
+	search.activateSearch()
+	return search
+}
+
+func (search *txSearchView) activateSearch() {
+	search.Search.SetBorderColor(searchActiveColor)
+	search.Search.SetFieldTextColor(searchActiveColor)
+	search.Search.SetTitleColor(searchActiveColor)
+	search.Search.Focus(nil)
+	search.Table.Blur()
+}
+
+func (search *txSearchView) deactivateSearch() {
+	search.Search.SetBorderColor(searchInactiveColor)
+	search.Search.SetFieldTextColor(searchInactiveColor)
+	search.Search.SetTitleColor(searchInactiveColor)
+	search.Search.Blur()
+	search.Table.Focus(nil)
+}
+
+// SetResults displays results and moves focus to the results table.
+func (search *txSearchView) SetResults(results []blockdb.TxSearchResult) {
+	search.term = search.Search.GetText()
+	search.Results = results
+	search.Table = search.buildResultsTable()
+	search.Flex.RemoveItem(search.Flex.GetItem(1))
+	search.Flex.AddItem(search.Table, 0, 9, false)
+	search.deactivateSearch()
+}
+
+func (search *txSearchView) buildResultsTable() *tview.Table {
+	headers := []string{
+		"Chain",
+		"Height",
+		"Tx",
+	}
+
+	rows := make([][]string, len(search.Results))
+	for i, res := range search.Results {
+		pres := presenter.TxSearch{Result: res}
+		rows[i] = []string{
+			res.ChainID,
+			pres.Height(),
+			pres.Snippet(search.term),
+		}
+	}
+
+	title := fmt.Sprintf("Search %s [%s]", search.testCase.Name, presenter.FormatTime(search.testCase.CreatedAt))
+	if search.term != "" {
+		title = fmt.Sprintf("%s: %d txs matching %q", title, len(search.Results), search.term)
+	}
+	return detailTableView(title, headers, rows)
+}
+
+// packetsView lists the IBC packets sent by chains of a test case.
+type packetsView struct {
+	*tview.Table
+
+	Packets []blockdb.PacketResult
+}
+
+func newPacketsView(tc blockdb.TestCaseResult, packets []blockdb.PacketResult) *packetsView {
+	headers := []string{
+		"Chain",
+		"Sequence",
+		"Source",
+		"Destination",
+		"Sent",
+		"Received",
+		"Status",
+	}
+
+	rows := make([][]string, len(packets))
+	for i, pkt := range packets {
+		pres := presenter.Packet{Result: pkt}
+		rows[i] = []string{
+			pres.ChainID(),
+			pres.Sequence(),
+			pres.Source(),
+			pres.Destination(),
+			pres.SendHeight(),
+			pres.Received(),
+			pres.Status(),
+		}
+	}
+
+	title := fmt.Sprintf("IBC Packets %s [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	return &packetsView{
+		Table:   detailTableView(title, headers, rows),
+		Packets: packets,
+	}
+}
+
+// SelectedPacket returns the packet of the selected row, if any.
+func (view *packetsView) SelectedPacket() (blockdb.PacketResult, bool) {
+	row, _ := view.GetSelection()
+	// Offset by 1 to account for header row.
+	row--
+	if row < 0 || row >= len(view.Packets) {
+		return blockdb.PacketResult{}, false
+	}
+	return view.Packets[row], true
+}
+
+// bookmarksView lists the bookmarked blocks and txs of a test case's chains.
+type bookmarksView struct {
+	*tview.Table
+
+	testCase  blockdb.TestCaseResult
+	Bookmarks []blockdb.BookmarkResult
+}
+
+func newBookmarksView(tc blockdb.TestCaseResult, bookmarks []blockdb.BookmarkResult) *bookmarksView {
+	headers := []string{
+		"Chain",
+		"Height",
+		"Bookmark",
+		"Created",
+	}
+
+	rows := make([][]string, len(bookmarks))
+	for i, b := range bookmarks {
+		pres := presenter.Bookmark{Result: b}
+		rows[i] = []string{
+			pres.ChainID(),
+			pres.Height(),
+			pres.Tx(),
+			pres.CreatedAt(),
+		}
+	}
+
+	title := fmt.Sprintf("Bookmarks %s [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	return &bookmarksView{
+		Table:     detailTableView(title, headers, rows),
+		testCase:  tc,
+		Bookmarks: bookmarks,
+	}
+}
+
+// SelectedBookmark returns the bookmark of the selected row, if any.
+func (view *bookmarksView) SelectedBookmark() (blockdb.BookmarkResult, bool) {
+	row, _ := view.GetSelection()
+	// Offset by 1 to account for header row.
+	row--
+	if row < 0 || row >= len(view.Bookmarks) {
+		return blockdb.BookmarkResult{}, false
+	}
+	return view.Bookmarks[row], true
+}
+
+func packetLifecycleView(pkt blockdb.PacketResult, events []blockdb.PacketEventResult) *tview.Table {
+	headers := []string{
+		"Chain",
+		"Height",
+		"Event",
+		"Timeout",
+	}
+
+	rows := make([][]string, len(events))
+	for i, e := range events {
+		pres := presenter.PacketEvent{Result: e}
+		rows[i] = []string{
+			pres.ChainID(),
+			pres.Height(),
+			pres.Type(),
+			pres.Timeout(),
+		}
+	}
+
+	pres := presenter.Packet{Result: pkt}
+	title := fmt.Sprintf("Packet %s %s #%s -> %s", pres.ChainID(), pres.Source(), pres.Sequence(), pres.Destination())
+	return detailTableView(title, headers, rows)
+}
+
+// packetCorrelationView shows the packet's txs on the sending chain, i.e. send and ack or timeout, side by side with
+// its recv txs on the receiving chain, with the time elapsed since the send.
+func packetCorrelationView(pkt blockdb.PacketResult, events []blockdb.PacketEventResult) *tview.Flex {
+	var send blockdb.PacketEventResult
+	for _, e := range events {
+		if e.Type == blockdb.PacketEventSend {
+			send = e
+			break
+		}
+	}
+
+	headers := []string{
+		"Chain",
+		"Height",
+		"Event",
+		"Since Send",
+	}
+	rows := make([][]string, len(events))
+	var src, dst strings.Builder
+	for i, e := range events {
+		pres := presenter.PacketEvent{Result: e}
+		rows[i] = []string{
+			pres.ChainID(),
+			pres.Height(),
+			pres.Type(),
+			pres.SinceSend(send),
+		}
+
+		side := &src
+		if e.Type == blockdb.PacketEventRecv {
+			side = &dst
+		}
+		fmt.Fprintf(side, "%s @ Height %s (%s)\n%s\n\n", pres.Type(), pres.Height(), pres.SinceSend(send), pres.Tx())
+	}
+
+	pres := presenter.Packet{Result: pkt}
+	dstTitle := "Destination " + pres.Destination()
+	if rc := pkt.RecvChainID; rc.Valid {
+		dstTitle = fmt.Sprintf("%s %s", rc.String, pres.Destination())
+	}
+	if dst.Len() == 0 {
+		dst.WriteString("Not received.")
+	}
+
+	txs := tview.NewFlex().SetDirection(tview.FlexColumn)
+	txs.SetBorder(false)
+	txs.AddItem(correlationTextView(fmt.Sprintf("%s %s", pres.ChainID(), pres.Source()), src.String()), 0, 1, true)
+	txs.AddItem(correlationTextView(dstTitle, dst.String()), 0, 1, false)
+
+	title := fmt.Sprintf("Packet %s %s #%s -> %s", pres.ChainID(), pres.Source(), pres.Sequence(), pres.Destination())
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.SetBorder(false)
+	// Height of 2 borders, header row, and events.
+	flex.AddItem(detailTableView(title, headers, rows), len(events)+3, 1, false)
+	flex.AddItem(txs, 0, 1, true)
+	return flex
+}
+
+func correlationTextView(title, text string) *tview.TextView {
+	textView := tview.NewTextView().
+		SetText(strings.TrimSpace(text)).
+		SetTextColor(textColor).
+		SetWrap(true).
+		SetWordWrap(true).
+		SetTextAlign(tview.AlignLeft).
+		SetScrollable(true)
+
+	textView.SetBorder(true).
+		SetBorderPadding(0, 0, 1, 1).
+		SetBorderAttributes(tcell.AttrDim)
+	textView.SetTitle(title)
+	return textView
+}
+
+// stateDiffView compares the app state of a chain exported at two selected heights.
+type stateDiffView struct {
+	*tview.Flex
+
+	chainPKey int64
+	chainID   string
+	// from is the first selected height, or 0 if no height is selected.
+	from int64
+
+	Heights      []int64
+	HeightsTable *tview.Table
+	Diff         *tview.Table
+}
+
+func newStateDiffView(tc blockdb.TestCaseResult, heights []int64) *stateDiffView {
+	rows := make([][]string, len(heights))
+	for i, h := range heights {
+		rows[i] = []string{strconv.FormatInt(h, 10)}
+	}
+
+	view := &stateDiffView{
+		chainPKey:    tc.ChainPKey,
+		chainID:      tc.ChainID,
+		Heights:      heights,
+		HeightsTable: detailTableView("Exports", []string{"Height"}, rows),
+		Diff:         stateDiffTableView(fmt.Sprintf("%s State Diff [Select two heights]", tc.ChainID), "Old", "New", nil),
+	}
+
+	flex := tview.NewFlex().SetDirection(tview.FlexColumn)
+	flex.SetBorder(false)
+	flex.AddItem(view.HeightsTable, 16, 1, true)
+	flex.AddItem(view.Diff, 0, 1, false)
+	view.Flex = flex
+	return view
+}
+
+func stateDiffTableView(title, oldHeader, newHeader string, changes []presenter.StateChange) *tview.Table {
+	headers := []string{
+		"Path",
+		"Change",
+		oldHeader,
+		newHeader,
+	}
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		rows[i] = []string{c.Path, c.Kind(), c.Old, c.New}
+	}
+	return detailTableView(title, headers, rows)
+}
+
+// SelectedHeight returns the height of the selected row, if any.
+func (view *stateDiffView) SelectedHeight() (int64, bool) {
+	row, _ := view.HeightsTable.GetSelection()
+	// Offset by 1 to account for header row.
+	row--
+	if row < 0 || row >= len(view.Heights) {
+		return 0, false
+	}
+	return view.Heights[row], true
+}
+
+// SelectFrom selects the older height of the diff. The next selected height is compared against it.
+func (view *stateDiffView) SelectFrom(height int64) {
+	view.from = height
+	view.Diff.SetTitle(fmt.Sprintf("%s State Diff [From height %d, select another height]", view.chainID, height))
+}
+
+// SetDiff shows the changes between the heights, then waits for the next two heights to be selected.
+func (view *stateDiffView) SetDiff(from, to int64, changes []presenter.StateChange) {
+	view.from = 0
+	title := fmt.Sprintf("%s State Diff [Height %d -> %d: %d changes]", view.chainID, from, to, len(changes))
+	diff := stateDiffTableView(title, fmt.Sprintf("Height %d", from), fmt.Sprintf("Height %d", to), changes)
+	view.Flex.RemoveItem(view.Diff)
+	view.Flex.AddItem(diff, 0, 1, false)
+	view.Diff = diff
+}
+
+// ToggleFocus switches focus between the heights and the diff, so a long diff can be scrolled.
+func (view *stateDiffView) ToggleFocus() {
+	if view.Diff.HasFocus() {
+		view.Diff.Blur()
+		view.HeightsTable.Focus(nil)
+		return
+	}
+	view.HeightsTable.Blur()
+	view.Diff.Focus(nil)
+}
+
+// listSearch is a vim-style incremental search of the rows of a table.
+// A row matches if the text of any of its cells contains the search term, ignoring case.
+type listSearch struct {
+	table *tview.Table
+	title string // The table's title before the search.
+	start int    // The row selected before the search.
+
+	term string
+	// typing is true while keys edit the term, until the term is entered.
+	typing  bool
+	matched bool
+}
+
+func newListSearch(tbl *tview.Table) *listSearch {
+	row, _ := tbl.GetSelection()
+	s := &listSearch{table: tbl, title: tbl.GetTitle(), start: row, typing: true}
+	s.render()
+	return s
+}
+
+// Type adds r to the term and selects the first matching row at or after the row selected before the search.
+func (s *listSearch) Type(r rune) {
+	s.term += string(r)
+	s.find(s.start, true, true)
+}
+
+// Backspace removes the last character of the term.
+func (s *listSearch) Backspace() {
+	if s.term == "" {
+		return
+	}
+	runes := []rune(s.term)
+	s.term = string(runes[:len(runes)-1])
+	if s.term == "" {
+		s.table.Select(s.start, 0)
+		s.matched = false
+		s.render()
+		return
+	}
+	s.find(s.start, true, true)
+}
+
+// Enter stops editing the term, keeping the term for Next.
+// It reports whether the search is still active, i.e. whether the term is not empty.
+func (s *listSearch) Enter() bool {
+	if s.term == "" {
+		s.Cancel()
+		return false
+	}
+	s.typing = false
+	s.render()
+	return true
+}
+
+// Cancel restores the selection and the title of the table before the search.
+func (s *listSearch) Cancel() {
+	s.table.Select(s.start, 0)
+	s.table.SetTitle(s.title)
+}
+
+// Next selects the next matching row after the selected row, or the previous matching row if forward is false,
+// wrapping around at the last or first row.
+func (s *listSearch) Next(forward bool) {
+	row, _ := s.table.GetSelection()
+	s.find(row, forward, false)
+}
+
+func (s *listSearch) find(from int, forward, inclusive bool) {
+	highlight := presenter.NewHighlight(s.term)
+	// Offset by 1 to account for header row.
+	n := s.table.GetRowCount() - 1
+	if from < 1 {
+		from = 1
+	}
+	s.matched = false
+	for step := 0; step < n; step++ {
+		offset := step
+		if !inclusive {
+			offset++
+		}
+		if !forward {
+			offset = -offset
+		}
+		row := ((from-1+offset)%n+n)%n + 1
+		if s.rowMatches(highlight, row) {
+			s.table.Select(row, 0)
+			s.matched = true
+			break
+		}
+	}
+	s.render()
+}
+
+func (s *listSearch) rowMatches(highlight *presenter.Highlight, row int) bool {
+	for col := 0; col < s.table.GetColumnCount(); col++ {
+		if cell := s.table.GetCell(row, col); cell != nil && highlight.Matches(cell.Text) {
+			return true
+		}
+	}
+	return false
+}
+
+// render shows the search term in the table's title.
+func (s *listSearch) render() {
+	title := fmt.Sprintf("%s [/%s]", s.title, s.term)
+	if s.term != "" && !s.matched {
+		title += " (no match)"
+	}
+	s.table.SetTitle(title)
+}
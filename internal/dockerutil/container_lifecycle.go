@@ -42,6 +42,7 @@ func (c *ContainerLifecycle) CreateContainer(
 	volumeBinds []string,
 	hostName string,
 	cmd []string,
+	env []string,
 ) error {
 	imageRef := image.Ref()
 	c.log.Info(
@@ -65,6 +66,7 @@ func (c *ContainerLifecycle) CreateContainer(
 
 			Entrypoint: []string{},
 			Cmd:        cmd,
+			Env:        env,
 
 			Hostname: hostName,
 
@@ -119,6 +121,24 @@ func (c *ContainerLifecycle) StopContainer(ctx context.Context) error {
 	return c.client.ContainerStop(ctx, c.id, &timeout)
 }
 
+// PauseContainer freezes the processes of the container, which keeps running, unlike a stopped container.
+func (c *ContainerLifecycle) PauseContainer(ctx context.Context) error {
+	if err := c.client.ContainerPause(ctx, c.id); err != nil {
+		return fmt.Errorf("pause container %s: %w", c.containerName, err)
+	}
+	c.log.Info("Container paused", zap.String("container", c.containerName))
+	return nil
+}
+
+// UnpauseContainer resumes the processes of the container paused by PauseContainer.
+func (c *ContainerLifecycle) UnpauseContainer(ctx context.Context) error {
+	if err := c.client.ContainerUnpause(ctx, c.id); err != nil {
+		return fmt.Errorf("unpause container %s: %w", c.containerName, err)
+	}
+	c.log.Info("Container unpaused", zap.String("container", c.containerName))
+	return nil
+}
+
 func (c *ContainerLifecycle) RemoveContainer(ctx context.Context) error {
 	err := c.client.ContainerRemove(ctx, c.id, dockertypes.ContainerRemoveOptions{
 		Force:         true,
@@ -4,27 +4,32 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
 
 	"github.com/cosmos/cosmos-sdk/codec"
-	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
-	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/docker/docker/client"
 )
 
 // NewLocalKeyringFromDockerContainer copies the contents of the given container directory into a specified local directory.
 // This allows test hosts to sign transactions on behalf of test users.
-func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client, localDirectory, containerKeyringDir, containerId string) (keyring.Keyring, error) {
+// The keyring is of backend, either test or file, whose passphrase is read from userInput,
+// and decodes keys with cdc, which must have the key types of the keyring registered.
+func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client, localDirectory, containerKeyringDir, containerId, backend string, userInput io.Reader, cdc codec.Codec) (keyring.Keyring, error) {
+	if backend != keyring.BackendTest && backend != keyring.BackendFile {
+		return nil, fmt.Errorf("keyring backend %q cannot be copied from a container", backend)
+	}
+
 	reader, _, err := dc.CopyFromContainer(ctx, containerId, containerKeyringDir)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := os.Mkdir(filepath.Join(localDirectory, "keyring-test"), os.ModePerm); err != nil {
+	if err := os.Mkdir(filepath.Join(localDirectory, "keyring-"+backend), os.ModePerm); err != nil {
 		return nil, err
 	}
 	tr := tar.NewReader(reader)
@@ -49,15 +54,11 @@ func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client,
 			continue
 		}
 
-		filePath := filepath.Join(localDirectory, "keyring-test", extractedFileName)
+		filePath := filepath.Join(localDirectory, "keyring-"+backend, extractedFileName)
 		if err := os.WriteFile(filePath, fileBuff.Bytes(), os.ModePerm); err != nil {
 			return nil, err
 		}
 	}
 
-	registry := codectypes.NewInterfaceRegistry()
-	cryptocodec.RegisterInterfaces(registry)
-	cdc := codec.NewProtoCodec(registry)
-
-	return keyring.New("", keyring.BackendTest, localDirectory, os.Stdin, cdc)
+	return keyring.New("", backend, localDirectory, userInput, cdc)
 }
@@ -237,6 +237,17 @@ func (r *DockerRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecRepor
 	return res.Err
 }
 
+// UpdatePathClients implements ibc.PathClientsUpdater, if the relayer's commander implements PathClientsCommander.
+func (r *DockerRelayer) UpdatePathClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcClientID, dstClientID string) error {
+	c, ok := r.c.(PathClientsCommander)
+	if !ok {
+		return fmt.Errorf("relayer %s cannot set the clients of paths", r.c.Name())
+	}
+	cmd := c.UpdatePathClients(pathName, r.HomeDir(), srcClientID, dstClientID)
+	res := r.Exec(ctx, rep, cmd, nil)
+	return res.Err
+}
+
 func (r *DockerRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
 	cmd := r.c.GetChannels(chainID, r.HomeDir())
 
@@ -369,13 +380,13 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 		return fmt.Errorf("StopRelayer: inspecting container: %w", err)
 	}
 
-	startedAt, err := time.Parse(c.State.StartedAt, time.RFC3339Nano)
+	startedAt, err := time.Parse(time.RFC3339Nano, c.State.StartedAt)
 	if err != nil {
 		r.log.Info("Failed to parse container StartedAt", zap.Error(err))
 		startedAt = time.Unix(0, 0)
 	}
 
-	finishedAt, err := time.Parse(c.State.FinishedAt, time.RFC3339Nano)
+	finishedAt, err := time.Parse(time.RFC3339Nano, c.State.FinishedAt)
 	if err != nil {
 		r.log.Info("Failed to parse container FinishedAt", zap.Error(err))
 		finishedAt = time.Now().UTC()
@@ -555,3 +566,9 @@ type RelayerCommander interface {
 	UpdateClients(pathName, homeDir string) []string
 	CreateWallet(keyName, address, mnemonic string) ibc.Wallet
 }
+
+// PathClientsCommander is implemented by commanders of relayers that can set the existing clients of paths,
+// which DockerRelayer.UpdatePathClients requires.
+type PathClientsCommander interface {
+	UpdatePathClients(pathName, homeDir, srcClientID, dstClientID string) []string
+}
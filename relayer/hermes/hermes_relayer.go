@@ -155,6 +155,18 @@ func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter
 	return r.Exec(ctx, rep, updateChainBCmd, nil).Err
 }
 
+// UpdatePathClients implements ibc.PathClientsUpdater, setting the client IDs of the in memory path,
+// e.g. to create connections on existing clients.
+func (r *Relayer) UpdatePathClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcClientID, dstClientID string) error {
+	pathConfig, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+	pathConfig.chainA.clientID = srcClientID
+	pathConfig.chainB.clientID = dstClientID
+	return nil
+}
+
 // CreateClients creates clients on both chains.
 // Note: in the go relayer this can be done with a single command using the path reference,
 // however in Hermes this needs to be done as two separate commands.
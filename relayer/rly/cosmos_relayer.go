@@ -45,18 +45,19 @@ func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 }
 
 type CosmosRelayerChainConfigValue struct {
-	AccountPrefix  string  `json:"account-prefix"`
-	ChainID        string  `json:"chain-id"`
-	Debug          bool    `json:"debug"`
-	GRPCAddr       string  `json:"grpc-addr"`
-	GasAdjustment  float64 `json:"gas-adjustment"`
-	GasPrices      string  `json:"gas-prices"`
-	Key            string  `json:"key"`
-	KeyringBackend string  `json:"keyring-backend"`
-	OutputFormat   string  `json:"output-format"`
-	RPCAddr        string  `json:"rpc-addr"`
-	SignMode       string  `json:"sign-mode"`
-	Timeout        string  `json:"timeout"`
+	AccountPrefix  string   `json:"account-prefix"`
+	ChainID        string   `json:"chain-id"`
+	Debug          bool     `json:"debug"`
+	ExtraCodecs    []string `json:"extra-codecs,omitempty"`
+	GRPCAddr       string   `json:"grpc-addr"`
+	GasAdjustment  float64  `json:"gas-adjustment"`
+	GasPrices      string   `json:"gas-prices"`
+	Key            string   `json:"key"`
+	KeyringBackend string   `json:"keyring-backend"`
+	OutputFormat   string   `json:"output-format"`
+	RPCAddr        string   `json:"rpc-addr"`
+	SignMode       string   `json:"sign-mode"`
+	Timeout        string   `json:"timeout"`
 }
 
 type CosmosRelayerChainConfig struct {
@@ -83,6 +84,10 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 	if chainType == "polkadot" || chainType == "parachain" || chainType == "relaychain" {
 		chainType = "substrate"
 	}
+	var extraCodecs []string
+	if chainConfig.SigningAlgorithm == ibc.SigningAlgorithmEthSecp256k1 {
+		extraCodecs = []string{"ethermint"}
+	}
 	return CosmosRelayerChainConfig{
 		Type: chainType,
 		Value: CosmosRelayerChainConfigValue{
@@ -98,6 +103,7 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 			Timeout:        "10s",
 			OutputFormat:   "json",
 			SignMode:       "direct",
+			ExtraCodecs:    extraCodecs,
 		},
 	}
 }
@@ -192,6 +198,15 @@ func (commander) UpdatePath(pathName, homeDir string, filter ibc.ChannelFilter)
 	}
 }
 
+func (commander) UpdatePathClients(pathName, homeDir, srcClientID, dstClientID string) []string {
+	return []string{
+		"rly", "paths", "update", pathName,
+		"--home", homeDir,
+		"--src-client-id", srcClientID,
+		"--dst-client-id", dstClientID,
+	}
+}
+
 func (commander) GetChannels(chainID, homeDir string) []string {
 	return []string{
 		"rly", "q", "channels", chainID,
@@ -30,6 +30,28 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+var blockDatabaseRetention BlockDatabaseRetention
+
+// SetBlockDatabaseRetention sets the retention that StartChainPair applies to the default block database,
+// pruning the oldest test cases before each test's blocks are tracked.
+//
+// The zero value, the default, keeps all history.
+// Like KeepDockerVolumesOnFailure, it should be called before any tests start.
+func SetBlockDatabaseRetention(r BlockDatabaseRetention) {
+	blockDatabaseRetention = r
+}
+
+var blockDatabaseURL string
+
+// SetBlockDatabaseURL sets a postgres connection URL that StartChainPair saves block history to,
+// instead of the default block database file.
+//
+// The empty string, the default, uses the default block database file.
+// Like KeepDockerVolumesOnFailure, it should be called before any tests start.
+func SetBlockDatabaseURL(u string) {
+	blockDatabaseURL = u
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
@@ -53,6 +75,11 @@ func StartChainPair(
 	f RelayerFactory,
 	preRelayerStartFuncs []func([]ibc.ChannelOutput),
 ) (ibc.Relayer, error) {
+	// Include container logs in the report when the test fails,
+	// and the resource usage of the test's containers.
+	rep.TrackContainerLogs(t, cli)
+	rep.TrackContainerStats(t, cli)
+
 	relayerImpl := f.Build(t, cli, networkID)
 
 	ic := NewInterchain().
@@ -67,15 +94,19 @@ func StartChainPair(
 		})
 
 	blockSqlite := DefaultBlockDatabaseFilepath()
-	t.Logf("View block history using sqlite console at %s", blockSqlite)
+	if blockDatabaseURL == "" {
+		t.Logf("View block history using sqlite console at %s", blockSqlite)
+	}
 
 	eRep := rep.RelayerExecReporter(t)
 	if err := ic.Build(ctx, eRep, InterchainBuildOptions{
-		TestName:          t.Name(),
-		Client:            cli,
-		NetworkID:         networkID,
-		GitSha:            version.GitSha,
-		BlockDatabaseFile: blockSqlite,
+		TestName:               t.Name(),
+		Client:                 cli,
+		NetworkID:              networkID,
+		GitSha:                 version.GitSha,
+		BlockDatabaseFile:      blockSqlite,
+		BlockDatabaseURL:       blockDatabaseURL,
+		BlockDatabaseRetention: blockDatabaseRetention,
 	}); err != nil {
 		return nil, err
 	}
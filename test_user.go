@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
 	"github.com/strangelove-ventures/interchaintest/v7/ibc"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v7/testutil"
@@ -20,6 +21,18 @@ func GetAndFundTestUserWithMnemonic(
 	keyNamePrefix, mnemonic string,
 	amount int64,
 	chain ibc.Chain,
+) (ibc.Wallet, error) {
+	return getAndFundTestUser(ctx, keyNamePrefix, mnemonic, amount, chain, chain.Config().Denom)
+}
+
+// getAndFundTestUser restores a user using the given mnemonic, or generates one if empty,
+// and funds it with amount of each of denoms from the faucet.
+func getAndFundTestUser(
+	ctx context.Context,
+	keyNamePrefix, mnemonic string,
+	amount int64,
+	chain ibc.Chain,
+	denoms ...string,
 ) (ibc.Wallet, error) {
 	chainCfg := chain.Config()
 	keyName := fmt.Sprintf("%s-%s-%s", keyNamePrefix, chainCfg.ChainID, dockerutil.RandLowerCaseLetterString(3))
@@ -28,13 +41,15 @@ func GetAndFundTestUserWithMnemonic(
 		return nil, fmt.Errorf("failed to get source user wallet: %w", err)
 	}
 
-	err = chain.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
-		Address: user.FormattedAddress(),
-		Amount:  amount,
-		Denom:   chainCfg.Denom,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get funds from faucet: %w", err)
+	for _, denom := range denoms {
+		err = chain.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
+			Address: user.FormattedAddress(),
+			Amount:  amount,
+			Denom:   denom,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s funds from faucet: %w", denom, err)
+		}
 	}
 	return user, nil
 }
@@ -71,3 +86,41 @@ func GetAndFundTestUsers(
 	}
 	return users
 }
+
+// GetAndFundTestUsersWithDenoms generates chain users, and funds each with amount of the native chain denom,
+// and of each of the chain's FaucetDenoms. IBC denom traces of FaucetDenoms, like transfer/channel-0/uatom,
+// are funded as their IBC vouchers.
+// The caller should wait for some blocks to complete before the funds will be accessible.
+func GetAndFundTestUsersWithDenoms(
+	t *testing.T,
+	ctx context.Context,
+	keyNamePrefix string,
+	amount int64,
+	chains ...ibc.Chain,
+) []ibc.Wallet {
+	users := make([]ibc.Wallet, len(chains))
+	var eg errgroup.Group
+	for i, chain := range chains {
+		i := i
+		chain := chain
+		eg.Go(func() error {
+			user, err := getAndFundTestUser(ctx, keyNamePrefix, "", amount, chain, faucetDenoms(chain.Config())...)
+			if err != nil {
+				return err
+			}
+			users[i] = user
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+	return users
+}
+
+// faucetDenoms returns the denoms held by the faucet of a chain with chainCfg, with IBC denom traces as their IBC denoms.
+func faucetDenoms(chainCfg ibc.ChainConfig) []string {
+	denoms := []string{chainCfg.Denom}
+	for _, denom := range chainCfg.FaucetDenoms {
+		denoms = append(denoms, transfertypes.ParseDenomTrace(denom).IBCDenom())
+	}
+	return denoms
+}
@@ -0,0 +1,17 @@
+package interchaintest
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaucetDenoms(t *testing.T) {
+	require.Equal(t, []string{"uatom"}, faucetDenoms(ibc.ChainConfig{Denom: "uatom"}))
+	require.Equal(t, []string{
+		"uatom",
+		"utest",
+		"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+	}, faucetDenoms(ibc.ChainConfig{Denom: "uatom", FaucetDenoms: []string{"utest", "transfer/channel-0/uatom"}}))
+}
@@ -0,0 +1,96 @@
+package testreporter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// maxInlineAttachment is the largest attachment, in bytes, stored inline in the report.
+// Larger attachments are written to the artifacts directory.
+const maxInlineAttachment = 64 * 1024
+
+// Attach stores the contents of r, such as a genesis file, exported state, or profile,
+// as an attachment named name on t's report entry.
+//
+// Small attachments are stored inline in the report.
+// Larger attachments are written to the directory configured with the ArtifactsDir option,
+// and the report references the written file.
+// Attach returns an error if the attachment is too large to store inline
+// and no artifacts directory is configured.
+func (r *Reporter) Attach(t T, name string, rd io.Reader) error {
+	now := time.Now()
+
+	// Read one byte past the inline limit to detect whether the attachment fits.
+	head, err := io.ReadAll(io.LimitReader(rd, maxInlineAttachment+1))
+	if err != nil {
+		return fmt.Errorf("failed to read attachment %q: %w", name, err)
+	}
+
+	m := TestAttachmentMessage{
+		Name:       t.Name(),
+		When:       now,
+		Attachment: name,
+	}
+
+	if len(head) <= maxInlineAttachment {
+		m.Size = int64(len(head))
+		m.Data = head
+		r.in <- m
+		return nil
+	}
+
+	if r.artifactsDir == "" {
+		return fmt.Errorf("attachment %q exceeds %d bytes and no artifacts directory is configured", name, maxInlineAttachment)
+	}
+
+	dir := filepath.Join(r.artifactsDir, dockerutil.SanitizeContainerName(t.Name()))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	f, err := createArtifactFile(dir, dockerutil.SanitizeContainerName(name))
+	if err != nil {
+		return fmt.Errorf("failed to create artifact file: %w", err)
+	}
+	p := f.Name()
+	n, err := io.Copy(f, io.MultiReader(bytes.NewReader(head), rd))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write artifact file %s: %w", p, err)
+	}
+
+	m.Size = n
+	m.Path = p
+	r.in <- m
+	return nil
+}
+
+// createArtifactFile creates the file name in dir. If the file exists, such as for a repeated attachment name,
+// or names that sanitize to the same name, a counter is added before the extension, e.g. genesis-1.json,
+// so that earlier attachments are not overwritten.
+func createArtifactFile(dir, name string) (*os.File, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 0; ; i++ {
+		p := filepath.Join(dir, name)
+		if i > 0 {
+			p = filepath.Join(dir, fmt.Sprintf("%s-%d%s", base, i, ext))
+		}
+		f, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+}
@@ -0,0 +1,97 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_Attach(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf}, testreporter.ArtifactsDir(dir))
+
+	mt := mocktesting.NewT("TestFoo/bar")
+	r.TrackTest(mt)
+
+	require.NoError(t, r.Attach(mt, "genesis.json", strings.NewReader(`{"chain_id":"gaia-1"}`)))
+
+	large := strings.Repeat("x", 100*1024)
+	require.NoError(t, r.Attach(mt, "state export.json", strings.NewReader(large)))
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Len(t, rep.Tests[0].Attachments, 2)
+
+	small := rep.Tests[0].Attachments[0]
+	require.Equal(t, "genesis.json", small.Attachment)
+	require.Equal(t, `{"chain_id":"gaia-1"}`, string(small.Data))
+	require.Empty(t, small.Path)
+
+	big := rep.Tests[0].Attachments[1]
+	require.Equal(t, int64(len(large)), big.Size)
+	require.Empty(t, big.Data)
+	require.True(t, strings.HasPrefix(big.Path, dir))
+	got, err := os.ReadFile(big.Path)
+	require.NoError(t, err)
+	require.Equal(t, large, string(got))
+}
+
+func TestReporter_Attach_NoArtifactsDir(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)})
+	mt := mocktesting.NewT("TestFoo")
+	r.TrackTest(mt)
+
+	require.Error(t, r.Attach(mt, "large", strings.NewReader(strings.Repeat("x", 100*1024))))
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+}
+
+func TestReporter_Attach_SameName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf}, testreporter.ArtifactsDir(dir))
+
+	mt := mocktesting.NewT("TestFoo")
+	r.TrackTest(mt)
+
+	// The names sanitize to the same file name.
+	contents := []string{strings.Repeat("a", 100*1024), strings.Repeat("b", 100*1024), strings.Repeat("c", 100*1024)}
+	require.NoError(t, r.Attach(mt, "state export.json", strings.NewReader(contents[0])))
+	require.NoError(t, r.Attach(mt, "state export.json", strings.NewReader(contents[1])))
+	require.NoError(t, r.Attach(mt, "state/export.json", strings.NewReader(contents[2])))
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Len(t, rep.Tests[0].Attachments, 3)
+
+	var paths []string
+	for i, a := range rep.Tests[0].Attachments {
+		got, err := os.ReadFile(a.Path)
+		require.NoError(t, err)
+		require.Equal(t, contents[i], string(got))
+		paths = append(paths, filepath.Base(a.Path))
+	}
+	require.Equal(t, []string{"state_export.json", "state_export-1.json", "state_export-2.json"}, paths)
+}
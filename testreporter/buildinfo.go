@@ -0,0 +1,117 @@
+package testreporter
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/docker/docker/client"
+)
+
+// interchaintestModule is the module path whose version is recorded in BuildInfo.
+const interchaintestModule = "github.com/strangelove-ventures/interchaintest/v7"
+
+// buildInfoEnv are the environment variables recorded in BuildInfo, when set.
+// They are limited to variables that affect how tests run or identify the CI job,
+// so that secrets in the environment are not written to the report.
+var buildInfoEnv = []string{
+	"CI",
+	"GITHUB_REPOSITORY", "GITHUB_REF", "GITHUB_SHA", "GITHUB_RUN_ID", "GITHUB_RUN_ATTEMPT", "GITHUB_JOB",
+	"RUNNER_OS", "RUNNER_ARCH",
+	"DOCKER_HOST",
+	"IBCTEST_CONFIGURED_CHAINS", "IBCTEST_SKIP_FAILURE_CLEANUP",
+	"SHOW_CONTAINER_LOGS", "CONTAINER_LOG_TAIL",
+}
+
+// dockerVersionTimeout bounds how long the reporter waits for the docker daemon's version,
+// before writing the BeginSuiteMessage.
+const dockerVersionTimeout = 2 * time.Second
+
+// BuildInfo describes the environment of a test run,
+// to help explain differing results when comparing reports from different machines.
+type BuildInfo struct {
+	GoVersion string
+
+	// Version of the interchaintest module, or "(devel)" when interchaintest is the main module.
+	InterchaintestVersion string `json:",omitempty"`
+
+	// VCSRevision and VCSModified describe the source tree of the test binary, when available.
+	VCSRevision string `json:",omitempty"`
+	VCSModified bool   `json:",omitempty"`
+
+	// DockerVersion is the version of the docker daemon.
+	// If the version could not be determined, DockerError describes why.
+	DockerVersion string `json:",omitempty"`
+	DockerError   string `json:",omitempty"`
+
+	OS, Arch string
+
+	// Env holds the values of a fixed set of environment variables relevant to the test run.
+	Env map[string]string `json:",omitempty"`
+}
+
+// collectBuildInfo gathers the BuildInfo of the current process.
+func collectBuildInfo() *BuildInfo {
+	bi := &BuildInfo{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Path == interchaintestModule {
+			bi.InterchaintestVersion = info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path != interchaintestModule {
+				continue
+			}
+			bi.InterchaintestVersion = dep.Version
+			if dep.Replace != nil {
+				bi.InterchaintestVersion += " => " + dep.Replace.Path + " " + dep.Replace.Version
+			}
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				bi.VCSRevision = s.Value
+			case "vcs.modified":
+				bi.VCSModified = s.Value == "true"
+			}
+		}
+	}
+
+	bi.DockerVersion, bi.DockerError = dockerServerVersion()
+
+	for _, k := range buildInfoEnv {
+		if v, ok := os.LookupEnv(k); ok {
+			if bi.Env == nil {
+				bi.Env = make(map[string]string)
+			}
+			bi.Env[k] = v
+		}
+	}
+
+	return bi
+}
+
+// dockerServerVersion returns the version of the docker daemon configured through the environment,
+// or a description of the error that prevented retrieving it.
+func dockerServerVersion() (version, errMsg string) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return "", err.Error()
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerVersionTimeout)
+	defer cancel()
+
+	v, err := cli.ServerVersion(ctx)
+	if err != nil {
+		return "", err.Error()
+	}
+	return v.Version, ""
+}
@@ -0,0 +1,76 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_BuildInfo(t *testing.T) {
+	t.Setenv("CI", "true")
+	t.Setenv("IBCTEST_SKIP_FAILURE_CLEANUP", "")
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+
+	bi := rep.BuildInfo
+	require.NotNil(t, bi)
+	require.Equal(t, runtime.Version(), bi.GoVersion)
+	require.Equal(t, runtime.GOOS, bi.OS)
+	require.Equal(t, runtime.GOARCH, bi.Arch)
+
+	// Running the package's own tests, interchaintest is the main module.
+	require.NotEmpty(t, bi.InterchaintestVersion)
+
+	// Empty values are recorded when the variable is set.
+	require.Equal(t, "true", bi.Env["CI"])
+	require.Contains(t, bi.Env, "IBCTEST_SKIP_FAILURE_CLEANUP")
+
+	// Either docker answered, or there is an explanation why not.
+	require.True(t, bi.DockerVersion != "" || bi.DockerError != "")
+}
+
+func TestReporter_BuildInfo_SlowDocker(t *testing.T) {
+	// A docker daemon that accepts connections but never answers.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	t.Setenv("DOCKER_HOST", "tcp://"+ln.Addr().String())
+
+	buf := new(bytes.Buffer)
+	start := time.Now()
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+	require.Less(t, time.Since(start), time.Second, "NewReporter waited for the docker daemon")
+
+	mt := mocktesting.NewT("TestFoo")
+	r.TrackTest(mt)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	// The BeginSuite message is written first, once the version request timed out.
+	msgs := ReporterMessages(t, buf)
+	begin, ok := msgs[0].(testreporter.BeginSuiteMessage)
+	require.True(t, ok, "first message is %T", msgs[0])
+	require.Empty(t, begin.BuildInfo.DockerVersion)
+	require.NotEmpty(t, begin.BuildInfo.DockerError)
+	require.IsType(t, testreporter.BeginTestMessage{}, msgs[1])
+}
@@ -0,0 +1,106 @@
+package testreporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// defaultContainerLogTail is the number of log lines collected per container,
+// unless overridden by the CONTAINER_LOG_TAIL environment variable.
+const defaultContainerLogTail = "200"
+
+// TrackContainerLogs arranges for the logs of every container created for t
+// to be included in the report, if t fails.
+//
+// Containers are associated with t through the same docker labels used by DockerSetup.
+// Because test cleanups run in reverse order, TrackContainerLogs must be called after DockerSetup,
+// so that the logs are collected before DockerSetup's cleanup removes the containers.
+//
+//	client, network := interchaintest.DockerSetup(t)
+//	reporter.TrackContainerLogs(t, client)
+func (r *Reporter) TrackContainerLogs(t T, cli *client.Client) {
+	name := t.Name()
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		for _, m := range collectContainerLogs(ctx, cli, name) {
+			r.in <- m
+		}
+	})
+}
+
+func collectContainerLogs(ctx context.Context, cli *client.Client, testName string) []ContainerLogsMessage {
+	cs, err := cli.ContainerList(ctx, types.ContainerListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", dockerutil.CleanupLabel+"="+testName),
+		),
+	})
+	if err != nil {
+		return []ContainerLogsMessage{{
+			Name:  testName,
+			When:  time.Now(),
+			Error: fmt.Sprintf("failed to list containers: %v", err),
+		}}
+	}
+
+	tail := defaultContainerLogTail
+	if v := os.Getenv("CONTAINER_LOG_TAIL"); v != "" {
+		tail = v
+	}
+
+	msgs := make([]ContainerLogsMessage, 0, len(cs))
+	for _, c := range cs {
+		m := ContainerLogsMessage{
+			Name:          testName,
+			When:          time.Now(),
+			ContainerID:   c.ID,
+			ContainerName: strings.TrimPrefix(strings.Join(c.Names, " "), "/"),
+			Image:         c.Image,
+		}
+
+		logs, err := containerLogs(ctx, cli, c.ID, tail)
+		if err != nil {
+			m.Error = err.Error()
+		}
+		m.Logs = logs
+
+		msgs = append(msgs, m)
+	}
+	return msgs
+}
+
+func containerLogs(ctx context.Context, cli *client.Client, containerID, tail string) (string, error) {
+	rc, err := cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+		Tail:       tail,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer rc.Close()
+
+	// Interleave stdout and stderr, as they would appear in a terminal.
+	buf := new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(buf, buf, rc); err != nil {
+		return buf.String(), fmt.Errorf("failed to read container logs: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,185 @@
+package testreporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v7/internal/dockerutil"
+)
+
+// containerStatsInterval is how often TrackContainerStats samples the test's containers.
+const containerStatsInterval = 5 * time.Second
+
+// TrackContainerStats samples the resource usage of every container created for t
+// while t runs, and includes the totals in the report when t finishes.
+//
+// Containers are associated with t through the same docker labels used by DockerSetup.
+// Because test cleanups run in reverse order, TrackContainerStats must be called after DockerSetup,
+// so that the final sample is taken before DockerSetup's cleanup removes the containers and volumes.
+//
+//	client, network := interchaintest.DockerSetup(t)
+//	reporter.TrackContainerStats(t, client)
+func (r *Reporter) TrackContainerStats(t T, cli *client.Client) {
+	name := t.Name()
+	s := &containerStatsSampler{
+		cli:      cli,
+		testName: name,
+		byID:     make(map[string]containerUsage),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// One last sample, to include containers that started after the previous sample.
+		s.sample(ctx)
+		r.in <- s.message(ctx)
+	})
+}
+
+// containerUsage is the resource usage observed for a single container.
+type containerUsage struct {
+	cpuNanos   uint64
+	peakMemory uint64
+}
+
+type containerStatsSampler struct {
+	cli      *client.Client
+	testName string
+
+	mu      sync.Mutex
+	byID    map[string]containerUsage
+	lastErr error
+}
+
+func (s *containerStatsSampler) run(ctx context.Context) {
+	tick := time.NewTicker(containerStatsInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			s.sample(ctx)
+		}
+	}
+}
+
+func (s *containerStatsSampler) labelFilter() filters.Args {
+	return filters.NewArgs(filters.Arg("label", dockerutil.CleanupLabel+"="+s.testName))
+}
+
+// sample records the current usage of each running container of the test.
+func (s *containerStatsSampler) sample(ctx context.Context) {
+	cs, err := s.cli.ContainerList(ctx, types.ContainerListOptions{Filters: s.labelFilter()})
+	if err != nil {
+		s.setErr(fmt.Errorf("failed to list containers: %w", err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range cs {
+		id := c.ID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, err := s.containerUsage(ctx, id)
+			if err != nil {
+				s.setErr(err)
+				return
+			}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			prev := s.byID[id]
+			// CPU usage is cumulative, so the highest sample is the total so far.
+			if u.cpuNanos < prev.cpuNanos {
+				u.cpuNanos = prev.cpuNanos
+			}
+			if u.peakMemory < prev.peakMemory {
+				u.peakMemory = prev.peakMemory
+			}
+			s.byID[id] = u
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *containerStatsSampler) containerUsage(ctx context.Context, id string) (containerUsage, error) {
+	res, err := s.cli.ContainerStats(ctx, id, false)
+	if err != nil {
+		return containerUsage{}, fmt.Errorf("failed to get stats of container %s: %w", id, err)
+	}
+	defer res.Body.Close()
+
+	var stats types.StatsJSON
+	if err := json.NewDecoder(res.Body).Decode(&stats); err != nil {
+		return containerUsage{}, fmt.Errorf("failed to decode stats of container %s: %w", id, err)
+	}
+
+	// MaxUsage is only reported with cgroup v1; otherwise the current usage is the best available.
+	peak := stats.MemoryStats.MaxUsage
+	if stats.MemoryStats.Usage > peak {
+		peak = stats.MemoryStats.Usage
+	}
+	return containerUsage{
+		cpuNanos:   stats.CPUStats.CPUUsage.TotalUsage,
+		peakMemory: peak,
+	}, nil
+}
+
+func (s *containerStatsSampler) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = err
+}
+
+// message totals the observed usage, along with the disk used by the test's volumes.
+func (s *containerStatsSampler) message(ctx context.Context) ContainerStatsMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := ContainerStatsMessage{
+		Name:       s.testName,
+		When:       time.Now(),
+		Containers: len(s.byID),
+	}
+	for _, u := range s.byID {
+		m.CPUSeconds += time.Duration(u.cpuNanos).Seconds()
+		m.PeakMemoryBytes += int64(u.peakMemory)
+	}
+
+	du, err := s.cli.DiskUsage(ctx)
+	if err != nil {
+		s.lastErr = fmt.Errorf("failed to get disk usage: %w", err)
+	} else {
+		for _, v := range du.Volumes {
+			if v.Labels[dockerutil.CleanupLabel] != s.testName || v.UsageData == nil || v.UsageData.Size < 0 {
+				continue
+			}
+			m.VolumeBytes += v.UsageData.Size
+		}
+	}
+
+	if s.lastErr != nil {
+		m.Error = s.lastErr.Error()
+	}
+	return m
+}
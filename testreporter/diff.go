@@ -0,0 +1,119 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// DiffOptions configures how Diff detects duration regressions.
+type DiffOptions struct {
+	// Threshold is the fractional increase in a test's duration that counts as a regression,
+	// e.g. 0.2 for a test that took 20% longer. A zero Threshold disables duration comparison.
+	Threshold float64
+
+	// MinIncrease is the smallest absolute increase in a test's duration that counts as a regression,
+	// to ignore noise in very short tests.
+	MinIncrease time.Duration
+}
+
+// ReportDiff is the difference in test outcomes between two reports.
+type ReportDiff struct {
+	// NewlyFailing are the tests that failed in the new report
+	// but did not fail in the old report, including tests absent from the old report.
+	NewlyFailing []string
+
+	// NewlyPassing are the tests that passed in the new report but failed in the old report.
+	NewlyPassing []string
+
+	// Slower are the tests that passed in both reports,
+	// whose duration increased beyond the configured threshold.
+	Slower []DurationChange
+}
+
+// DurationChange is the old and new duration of a test.
+type DurationChange struct {
+	Name     string
+	Old, New time.Duration
+}
+
+// Increase is the fractional increase from d.Old to d.New.
+func (d DurationChange) Increase() float64 {
+	if d.Old <= 0 {
+		return 0
+	}
+	return float64(d.New-d.Old) / float64(d.Old)
+}
+
+// Regressed reports whether the diff contains newly failing or slower tests.
+func (d ReportDiff) Regressed() bool {
+	return len(d.NewlyFailing) > 0 || len(d.Slower) > 0
+}
+
+// Diff compares the test outcomes in oldRep and newRep,
+// such as runs before and after a relayer version bump.
+// Tests are matched by name, and the results are in the order the tests began in newRep.
+func Diff(oldRep, newRep *Report, opts DiffOptions) ReportDiff {
+	old := make(map[string]*TestReport, len(oldRep.Tests))
+	for _, tr := range oldRep.Tests {
+		old[tr.Name] = tr
+	}
+
+	var d ReportDiff
+	for _, tr := range newRep.Tests {
+		status := testStatus(tr)
+		prev, ok := old[tr.Name]
+		prevStatus := "unknown"
+		if ok {
+			prevStatus = testStatus(prev)
+		}
+
+		switch {
+		case status == "fail" && prevStatus != "fail":
+			d.NewlyFailing = append(d.NewlyFailing, tr.Name)
+		case status == "pass" && prevStatus == "fail":
+			d.NewlyPassing = append(d.NewlyPassing, tr.Name)
+		case status == "pass" && prevStatus == "pass" && opts.Threshold > 0:
+			c := DurationChange{Name: tr.Name, Old: prev.Duration(), New: tr.Duration()}
+			if c.Increase() > opts.Threshold && c.New-c.Old >= opts.MinIncrease {
+				d.Slower = append(d.Slower, c)
+			}
+		}
+	}
+	return d
+}
+
+// WriteText writes a human-readable summary of d to w.
+func (d ReportDiff) WriteText(w io.Writer) error {
+	if len(d.NewlyFailing) == 0 && len(d.NewlyPassing) == 0 && len(d.Slower) == 0 {
+		_, err := fmt.Fprintln(w, "No differences.")
+		return err
+	}
+
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	if len(d.NewlyFailing) > 0 {
+		printf("Newly failing (%d):\n", len(d.NewlyFailing))
+		for _, name := range d.NewlyFailing {
+			printf("  %s\n", name)
+		}
+	}
+	if len(d.NewlyPassing) > 0 {
+		printf("Newly passing (%d):\n", len(d.NewlyPassing))
+		for _, name := range d.NewlyPassing {
+			printf("  %s\n", name)
+		}
+	}
+	if len(d.Slower) > 0 {
+		printf("Slower (%d):\n", len(d.Slower))
+		for _, c := range d.Slower {
+			printf("  %s: %s -> %s (+%.0f%%)\n", c.Name, c.Old.Round(time.Millisecond), c.New.Round(time.Millisecond), c.Increase()*100)
+		}
+	}
+	return err
+}
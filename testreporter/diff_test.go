@@ -0,0 +1,61 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	test := func(name string, d time.Duration, failed bool) []testreporter.Message {
+		return []testreporter.Message{
+			testreporter.BeginTestMessage{Name: name, StartedAt: now},
+			testreporter.FinishTestMessage{Name: name, FinishedAt: now.Add(d), Failed: failed},
+		}
+	}
+	report := func(tests ...[]testreporter.Message) *testreporter.Report {
+		var msgs []testreporter.Message
+		for _, m := range tests {
+			msgs = append(msgs, m...)
+		}
+		return testreporter.NewReport(msgs)
+	}
+
+	oldRep := report(
+		test("TestStable", time.Minute, false),
+		test("TestBreaks", time.Minute, false),
+		test("TestFixed", time.Minute, true),
+		test("TestSlower", time.Minute, false),
+		test("TestNoisy", time.Millisecond, false),
+	)
+	newRep := report(
+		test("TestStable", time.Minute, false),
+		test("TestBreaks", time.Minute, true),
+		test("TestFixed", time.Minute, false),
+		test("TestSlower", 2*time.Minute, false),
+		test("TestNoisy", 5*time.Millisecond, false),
+		test("TestNewAndFailing", time.Minute, true),
+	)
+
+	d := testreporter.Diff(oldRep, newRep, testreporter.DiffOptions{Threshold: 0.2, MinIncrease: time.Second})
+	require.Equal(t, []string{"TestBreaks", "TestNewAndFailing"}, d.NewlyFailing)
+	require.Equal(t, []string{"TestFixed"}, d.NewlyPassing)
+	require.Equal(t, []testreporter.DurationChange{
+		{Name: "TestSlower", Old: time.Minute, New: 2 * time.Minute},
+	}, d.Slower)
+	require.True(t, d.Regressed())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, d.WriteText(buf))
+	require.Contains(t, buf.String(), "TestSlower: 1m0s -> 2m0s (+100%)")
+
+	same := testreporter.Diff(oldRep, oldRep, testreporter.DiffOptions{Threshold: 0.2})
+	require.False(t, same.Regressed())
+	require.Empty(t, same.NewlyPassing)
+}
@@ -0,0 +1,107 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// shardPattern matches the report files written by reporters created with NewDirReporter.
+	shardPattern = "shard-*.json"
+
+	// CollectedReportName is the name of the merged report
+	// written to a directory shared by reporters created with NewDirReporter.
+	CollectedReportName = "report.json"
+)
+
+// NewDirReporter returns a Reporter that writes its JSON messages to a new, uniquely named file in dir,
+// so that several test packages run by a single "go test ./..." invocation
+// can each use their own Reporter without writing to the same file.
+//
+// When the Reporter is closed, the reports of every Reporter sharing dir are merged
+// into dir/report.json, which is replaced atomically.
+// Because reporters may be closed concurrently, use Collect after all test packages have finished
+// for a merged report that is guaranteed to include every package.
+func NewDirReporter(dir string, opts ...ReporterOption) (*Reporter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, shardPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	opts = append(opts, reporterOptionCollect{dir: dir})
+	return NewReporter(f, opts...), nil
+}
+
+// reporterOptionCollect is added by NewDirReporter to write the merged report of dir on close.
+type reporterOptionCollect struct {
+	dir string
+}
+
+func (reporterOptionCollect) reporterOption() {}
+
+// Collect merges the reports written to dir by reporters created with NewDirReporter.
+// See MergeMessages for how the reports are combined.
+func Collect(dir string) (*Report, error) {
+	msgs, err := collectMessages(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewReport(msgs), nil
+}
+
+func collectMessages(dir string) (msgs []Message, err error) {
+	paths, err := filepath.Glob(filepath.Join(dir, shardPattern))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no reports found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	readers := make([]io.Reader, len(paths))
+	for i, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { err = multierr.Append(err, f.Close()) }()
+		readers[i] = f
+	}
+
+	return MergeMessages(readers...)
+}
+
+// writeCollected writes the merged report of dir to dir/report.json.
+// The merged report is written to a temporary file first and then renamed,
+// so that concurrent readers and writers never observe a partially written report.
+func writeCollected(dir string) error {
+	msgs, err := collectMessages(dir)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".collect-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create merged report file: %w", err)
+	}
+	err = WriteMessages(f, msgs)
+	err = multierr.Append(err, f.Close())
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(dir, CollectedReportName))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("failed to write merged report: %w", err)
+	}
+	return nil
+}
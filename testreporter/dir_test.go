@@ -0,0 +1,62 @@
+package testreporter_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirReporter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// Simulate several test packages reporting to the same directory concurrently.
+	names := []string{"TestA", "TestB", "TestC"}
+	var wg sync.WaitGroup
+	for _, name := range names {
+		r, err := testreporter.NewDirReporter(dir)
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			mt := mocktesting.NewT(name)
+			r.TrackTest(mt)
+			mt.RunCleanups()
+			require.NoError(t, r.Close())
+		}(name)
+	}
+	wg.Wait()
+
+	rep, err := testreporter.Collect(dir)
+	require.NoError(t, err)
+	require.True(t, rep.Complete())
+	require.Len(t, rep.Tests, len(names))
+
+	got := make([]string, len(rep.Tests))
+	for i, tr := range rep.Tests {
+		got[i] = tr.Name
+	}
+	require.ElementsMatch(t, names, got)
+
+	// The merged report written on close is also readable.
+	f, err := os.Open(filepath.Join(dir, testreporter.CollectedReportName))
+	require.NoError(t, err)
+	defer f.Close()
+	merged, err := testreporter.ReadReport(f)
+	require.NoError(t, err)
+	require.NotEmpty(t, merged.Tests)
+}
+
+func TestCollect_Empty(t *testing.T) {
+	t.Parallel()
+
+	_, err := testreporter.Collect(t.TempDir())
+	require.Error(t, err)
+}
@@ -19,6 +19,15 @@
 //	  os.Exit(code)
 //	}
 //
+// The report is a stream of newline-delimited JSON messages.
+// Each test's result is written and synced as soon as the test finishes,
+// and Close writes a final FinishSuite message;
+// so if the test binary is killed, the partial report can still be read with ReadReport.
+//
+// When a single "go test ./..." invocation runs several test packages that each use a Reporter,
+// use NewDirReporter in each package with a shared directory instead of a shared file.
+// Each Reporter writes its own file in the directory, and Collect merges them into one report.
+//
 // Next, every test that needs to be tracked must call TrackTest.
 // If you omit the call to TrackTest, then the test's start and end time,
 // and skip/fail status, will not be reported.
@@ -33,6 +42,10 @@
 // Calling TrackTest tracks the test's start and finish time,
 // including whether the test was skipped or failed.
 //
+// Subtests started with t.Run may also call TrackTest.
+// The report links each tracked subtest to its nearest tracked ancestor,
+// so the results can be viewed as a tree through Report.Roots and TestReport.Subtests.
+//
 // Parallel tests should not call t.Parallel directly,
 // but instead should use TrackParallel.
 // This will track the time the test paused waiting for parallel execution
@@ -56,6 +69,10 @@
 //	  }
 //	}
 //
+// TrackSkipCategory additionally classifies the skip, such as SkipUnsupportedRelayer,
+// so that intentionally unsupported combinations can be told apart from skips
+// caused by a missing environment, which usually warrant an alert.
+//
 // Lastly, and perhaps most importantly, the reporter is designed to integrate
 // with testify's require and assert packages.
 // Plain "go test" runs simply have a stream of log lines and a failure/skip state.
@@ -73,4 +90,15 @@
 //
 // If you use a plain require.NoError(t, err) call,
 // the report will note that the test failed, but the report will not include the error line.
+//
+// The reporter can also emit the same data in other formats, configured through ReporterOption values.
+// For example, CI systems that only ingest JUnit XML can use the JUnitOutput option
+// to write a JUnit report in addition to the JSON report,
+// or NewJUnitReporter to write only the JUnit report.
+// Similarly, the HTMLOutput option renders a self-contained HTML page of the results,
+// the PushGateway option pushes test durations and outcome counts to a Prometheus pushgateway,
+// and the OTLPTraces option exports each test as a span to an OpenTelemetry collector.
+//
+//	junitFile, _ := os.Create("/tmp/report.xml")
+//	reporter := testreporter.NewReporter(f, testreporter.JUnitOutput(junitFile))
 package testreporter
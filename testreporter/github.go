@@ -0,0 +1,74 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteGitHubAnnotations writes a GitHub Actions "::error" workflow command to w
+// for each error of each failed test in rep,
+// so that the failures are shown inline on the pull request diff.
+//
+// Annotated file paths are made relative to GITHUB_WORKSPACE, when it is set.
+// Failed tests without any tracked errors are annotated without a file location.
+func WriteGitHubAnnotations(w io.Writer, rep *Report) error {
+	workspace := os.Getenv("GITHUB_WORKSPACE")
+
+	for _, tr := range rep.Tests {
+		if !tr.Failed {
+			continue
+		}
+
+		if len(tr.Errors) == 0 {
+			if _, err := fmt.Fprintf(w, "::error title=%s::%s\n", escapeGitHubProperty(tr.Name), escapeGitHubData("test failed")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, e := range tr.Errors {
+			var props []string
+			if e.File != "" {
+				file := e.File
+				if workspace != "" {
+					if rel, err := filepath.Rel(workspace, file); err == nil && !strings.HasPrefix(rel, "..") {
+						file = rel
+					}
+				}
+				props = append(props,
+					"file="+escapeGitHubProperty(file),
+					fmt.Sprintf("line=%d", e.Line),
+				)
+			}
+			props = append(props, "title="+escapeGitHubProperty(tr.Name))
+
+			if _, err := fmt.Fprintf(w, "::error %s::%s\n", strings.Join(props, ","), escapeGitHubData(e.Message)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+	).Replace(s)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command.
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+		":", "%3A",
+		",", "%2C",
+	).Replace(s)
+}
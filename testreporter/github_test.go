@@ -0,0 +1,48 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	t.Setenv("GITHUB_WORKSPACE", "/work/repo")
+
+	now := time.Now()
+	rep := testreporter.NewReport([]testreporter.Message{
+		testreporter.BeginTestMessage{Name: "TestPass", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestPass", FinishedAt: now},
+
+		testreporter.BeginTestMessage{Name: "TestFail", StartedAt: now},
+		testreporter.TestErrorMessage{
+			Name: "TestFail", When: now, Message: "Error: not equal\n50% off",
+			File: "/work/repo/conformance/test.go", Line: 42,
+		},
+		testreporter.FinishTestMessage{Name: "TestFail", FinishedAt: now, Failed: true},
+
+		testreporter.BeginTestMessage{Name: "TestFail/plain", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestFail/plain", FinishedAt: now, Failed: true},
+	})
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, testreporter.WriteGitHubAnnotations(buf, rep))
+	require.Equal(t,
+		"::error file=conformance/test.go,line=42,title=TestFail::Error: not equal%0A50%25 off\n"+
+			"::error title=TestFail/plain::test failed\n",
+		buf.String(),
+	)
+}
+
+func TestReporter_GitHubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	annotations := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)}, testreporter.GitHubAnnotations(annotations))
+	require.NoError(t, r.Close())
+
+	require.Empty(t, annotations.String())
+}
@@ -0,0 +1,92 @@
+package testreporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+)
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": func(d time.Duration) string {
+		return d.Round(time.Millisecond).String()
+	},
+	"since": func(start, end time.Time) time.Duration {
+		return end.Sub(start)
+	},
+	"join":   strings.Join,
+	"status": testStatus,
+	"bytes": func(n int64) string {
+		return units.HumanSize(float64(n))
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>interchaintest report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+th { background: #eee; }
+tr.pass td.status { background: #c8e6c9; }
+tr.fail td.status { background: #ffcdd2; }
+tr.skip td.status { background: #fff9c4; }
+tr.unknown td.status { background: #e0e0e0; }
+pre { margin: 0; white-space: pre-wrap; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>interchaintest report</h1>
+<p>
+Started {{.StartedAt.Format "2006-01-02T15:04:05Z07:00"}}, took {{duration .Duration}}.
+{{len .Tests}} tests tracked.
+</p>
+{{with .BuildInfo}}<p>
+Built with {{.GoVersion}} for {{.OS}}/{{.Arch}}{{with .InterchaintestVersion}}, interchaintest {{.}}{{end}}{{with .VCSRevision}} at revision {{.}}{{end}}{{if .VCSModified}} (modified){{end}}.
+{{with .DockerVersion}}Docker {{.}}.{{end}}{{with .DockerError}}Docker version unavailable: {{.}}{{end}}
+{{with .Env}}<br>{{range $k, $v := .}}<code>{{$k}}={{$v}}</code> {{end}}{{end}}
+</p>{{end}}
+<table>
+<thead>
+<tr><th>Test</th><th>Status</th><th>Duration</th><th>Details</th></tr>
+</thead>
+<tbody>
+{{range .Tests}}{{$status := status .}}<tr class="{{$status}}">
+<td>{{.Name}}</td>
+<td class="status">{{$status}}{{if .Flaky}} (flaky, {{len .Attempts}} attempts){{end}}</td>
+<td>{{duration .Duration}}</td>
+<td>{{if .BlockDatabasePath}}<p>Blocks recorded in <code>{{.BlockDatabasePath}}</code> as test case {{.BlockDatabaseTestCaseID}}</p>{{end}}{{with .ContainerStats}}<p>{{.Containers}} containers used {{printf "%.1f" .CPUSeconds}} CPU seconds, {{bytes .PeakMemoryBytes}} peak memory, and {{bytes .VolumeBytes}} of volumes{{with .Error}} ({{.}}){{end}}</p>{{end}}{{with .Metadata}}<p>{{range $k, $v := .}}{{$k}}={{$v}} {{end}}</p>{{end}}{{with .Phases}}<ul>{{range .}}<li>{{.Phase}}: {{duration (since .StartedAt .FinishedAt)}}</li>{{end}}</ul>{{end}}{{if .SkipReason}}<p>Skipped{{with .SkipCategory}} ({{.}}){{end}}: {{.SkipReason}}</p>{{end}}{{range .Errors}}{{if .File}}<p>{{if .Fatal}}Fatal{{else}}Non-fatal{{end}} failure at <code>{{.File}}:{{.Line}}</code></p>{{end}}<pre>{{.Message}}</pre>{{with .Stack}}<details><summary>Stack</summary><pre>{{.}}</pre></details>{{end}}{{end}}{{with .Logs}}<details><summary>{{len .}} log lines</summary><pre>{{range .}}{{.When.Format "15:04:05.000"}} {{.Message}}
+{{end}}</pre></details>{{end}}{{with .RelayerExecs}}<details><summary>{{len .}} relayer commands</summary>{{range .}}<p><code>{{join .Command " "}}</code> exited {{.ExitCode}} after {{duration .Duration}}{{with .Error}}: {{.}}{{end}}</p>{{with .Stdout}}<pre>{{.}}</pre>{{end}}{{with .Stderr}}<pre>{{.}}</pre>{{end}}{{end}}</details>{{end}}{{with .Attachments}}<ul>{{range .}}<li>Attachment {{.Attachment}} ({{.Size}} bytes){{with .Path}}: <code>{{.}}</code>{{end}}</li>{{end}}</ul>{{end}}{{range .ContainerLogs}}<details><summary>Logs of container {{.ContainerName}}</summary><pre>{{.Logs}}{{.Error}}</pre></details>{{end}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes rep to w as a self-contained HTML page,
+// suitable for publishing as a CI artifact.
+func WriteHTML(w io.Writer, rep *Report) error {
+	if err := htmlReportTemplate.Execute(w, rep); err != nil {
+		return fmt.Errorf("failed to render html report: %w", err)
+	}
+	return nil
+}
+
+// testStatus is a short description of the outcome of tr.
+func testStatus(tr *TestReport) string {
+	switch {
+	case tr.FinishedAt.IsZero():
+		return "unknown"
+	case tr.Skipped:
+		return "skip"
+	case tr.Failed:
+		return "fail"
+	default:
+		return "pass"
+	}
+}
@@ -0,0 +1,41 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLOutput(t *testing.T) {
+	t.Parallel()
+
+	htmlBuf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)}, testreporter.HTMLOutput(htmlBuf))
+
+	failing := mocktesting.NewT("TestFoo")
+	failing.Simulate(func() {
+		r.TrackTest(failing)
+		require.Fail(r.TestifyT(failing), "forced <failure>")
+	})
+
+	skipped := mocktesting.NewT("TestBar")
+	skipped.Simulate(func() {
+		r.TrackTest(skipped)
+		r.TrackSkip(skipped, "skipping for reasons")
+	})
+
+	require.NoError(t, r.Close())
+
+	out := htmlBuf.String()
+	require.Contains(t, out, "<!DOCTYPE html>")
+	require.Contains(t, out, `<tr class="fail">`)
+	require.Contains(t, out, `<tr class="skip">`)
+	require.Contains(t, out, "skipping for reasons")
+
+	// Error messages must be escaped.
+	require.Contains(t, out, "forced &lt;failure&gt;")
+	require.NotContains(t, out, "forced <failure>")
+}
@@ -0,0 +1,140 @@
+package testreporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// junitTestSuites is the root element of a JUnit XML document.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	Skipped    *junitSkipped   `xml:"skipped,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnit writes rep to w as a JUnit XML document,
+// with every tracked test as a testcase in a single testsuite.
+func WriteJUnit(w io.Writer, rep *Report) error {
+	suite := junitTestSuite{
+		Name:  "interchaintest",
+		Tests: len(rep.Tests),
+		Time:  junitSeconds(rep.Duration()),
+	}
+	if !rep.StartedAt.IsZero() {
+		suite.Timestamp = rep.StartedAt.UTC().Format(time.RFC3339)
+	}
+
+	for _, tr := range rep.Tests {
+		tc := junitTestCase{
+			Name:      tr.Name,
+			Classname: junitClassname(tr.Name),
+			Time:      junitSeconds(tr.Duration()),
+		}
+
+		for _, k := range sortedKeys(tr.Metadata) {
+			tc.Properties = append(tc.Properties, junitProperty{Name: k, Value: tr.Metadata[k]})
+		}
+
+		switch {
+		case tr.Skipped:
+			suite.Skipped++
+			tc.Skipped = &junitSkipped{Message: tr.SkipReason}
+		case tr.Failed:
+			suite.Failures++
+			f := &junitFailure{Message: "test failed"}
+			if len(tr.Errors) > 0 {
+				// The first error is typically the most helpful summary.
+				f.Message = firstLine(tr.Errors[0].Message)
+				msgs := make([]string, len(tr.Errors))
+				for i, e := range tr.Errors {
+					msgs[i] = e.Message
+					if e.File != "" {
+						msgs[i] = fmt.Sprintf("%s:%d\n%s", e.File, e.Line, e.Message)
+					}
+				}
+				f.Body = strings.Join(msgs, "\n\n")
+			}
+			tc.Failure = f
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return fmt.Errorf("failed to encode junit xml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitClassname returns the top level test name of a possibly nested test name,
+// which is the closest analog to a class in Go tests.
+func junitClassname(name string) string {
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// sortedKeys returns the keys of m in sorted order, for deterministic output.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
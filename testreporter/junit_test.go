@@ -0,0 +1,76 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewJUnitReporter(nopCloser{Writer: buf})
+
+	passing := mocktesting.NewT("TestFoo/passing")
+	r.TrackTest(passing)
+	passing.RunCleanups()
+
+	failing := mocktesting.NewT("TestFoo/failing")
+	failing.Simulate(func() {
+		r.TrackTest(failing)
+		require.Fail(r.TestifyT(failing), "forced failure")
+	})
+
+	skipped := mocktesting.NewT("TestBar")
+	skipped.Simulate(func() {
+		r.TrackTest(skipped)
+		r.TrackSkip(skipped, "skipping %s", "for reasons")
+	})
+
+	require.NoError(t, r.Close())
+
+	var doc struct {
+		Suites []struct {
+			Tests    int `xml:"tests,attr"`
+			Failures int `xml:"failures,attr"`
+			Skipped  int `xml:"skipped,attr"`
+			Cases    []struct {
+				Name      string `xml:"name,attr"`
+				Classname string `xml:"classname,attr"`
+				Failure   *struct {
+					Body string `xml:",chardata"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	require.Len(t, doc.Suites, 1)
+	suite := doc.Suites[0]
+	require.Equal(t, 3, suite.Tests)
+	require.Equal(t, 1, suite.Failures)
+	require.Equal(t, 1, suite.Skipped)
+
+	require.Len(t, suite.Cases, 3)
+
+	require.Equal(t, "TestFoo/passing", suite.Cases[0].Name)
+	require.Equal(t, "TestFoo", suite.Cases[0].Classname)
+	require.Nil(t, suite.Cases[0].Failure)
+	require.Nil(t, suite.Cases[0].Skipped)
+
+	require.Equal(t, "TestFoo/failing", suite.Cases[1].Name)
+	require.NotNil(t, suite.Cases[1].Failure)
+	require.Contains(t, suite.Cases[1].Failure.Body, "forced failure")
+
+	require.Equal(t, "TestBar", suite.Cases[2].Name)
+	require.NotNil(t, suite.Cases[2].Skipped)
+	require.Equal(t, "skipping for reasons", suite.Cases[2].Skipped.Message)
+}
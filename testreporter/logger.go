@@ -0,0 +1,106 @@
+package testreporter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxTestLogBytes is the maximum number of bytes of log messages retained per TestLogger.
+const maxTestLogBytes = 64 * 1024
+
+// LogT is the subset of testing.TB used by LoggerT.
+type LogT interface {
+	Name() string
+	Cleanup(func())
+	Failed() bool
+
+	Helper()
+	Logf(format string, args ...any)
+}
+
+// TestLogger mirrors a test's log messages into the report.
+// Instances of TestLogger must be retrieved through (*Reporter).LoggerT.
+type TestLogger struct {
+	r *Reporter
+	t LogT
+
+	mu        sync.Mutex
+	size      int
+	truncated bool
+
+	// Only used when the Reporter is configured with FailedTestLogsOnly.
+	buffered bool
+	buf      []TestLogMessage
+}
+
+// LoggerT returns a TestLogger whose Logf and Log methods pass through to t,
+// and also record the log message with a timestamp in t's report entry.
+// Call LoggerT once per test; the recorded messages are capped in size,
+// and messages beyond the cap are dropped with a note in the report.
+//
+// If the Reporter is configured with the FailedTestLogsOnly option,
+// the messages are held in memory and only recorded if t fails.
+func (r *Reporter) LoggerT(t LogT) *TestLogger {
+	l := &TestLogger{r: r, t: t, buffered: r.failedLogsOnly}
+	if l.buffered {
+		t.Cleanup(l.flush)
+	}
+	return l
+}
+
+// Logf records the formatted message in the report and then passes through to the underlying test.
+func (l *TestLogger) Logf(format string, args ...any) {
+	l.t.Helper()
+	l.record(fmt.Sprintf(format, args...))
+	l.t.Logf(format, args...)
+}
+
+// Log records the message, formatted like fmt.Sprintln, in the report
+// and then passes through to the underlying test.
+func (l *TestLogger) Log(args ...any) {
+	l.t.Helper()
+	msg := fmt.Sprintln(args...)
+	msg = msg[:len(msg)-1]
+	l.record(msg)
+	l.t.Logf("%s", msg)
+}
+
+func (l *TestLogger) record(msg string) {
+	m := TestLogMessage{
+		Name:    l.t.Name(),
+		When:    time.Now(),
+		Message: msg,
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.truncated {
+		return
+	}
+	if l.size+len(msg) > maxTestLogBytes {
+		l.truncated = true
+		m.Message = fmt.Sprintf("[log truncated after %d bytes]", l.size)
+	}
+	l.size += len(msg)
+
+	if l.buffered {
+		l.buf = append(l.buf, m)
+		return
+	}
+	l.r.in <- m
+}
+
+// flush records the buffered messages if the test failed.
+func (l *TestLogger) flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.t.Failed() {
+		for _, m := range l.buf {
+			l.r.in <- m
+		}
+	}
+	l.buf = nil
+}
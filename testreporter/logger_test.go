@@ -0,0 +1,100 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_LoggerT(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	l := r.LoggerT(mt)
+	l.Logf("starting %d chains", 2)
+	l.Log("chains", "started")
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	// Log lines pass through to the test.
+	require.Equal(t, []string{"starting 2 chains", "chains started"}, mt.Logs)
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+
+	logs := rep.Tests[0].Logs
+	require.Len(t, logs, 2)
+	require.Equal(t, "starting 2 chains", logs[0].Message)
+	require.Equal(t, "chains started", logs[1].Message)
+	require.False(t, logs[0].When.IsZero())
+}
+
+func TestReporter_LoggerT_SizeCap(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	l := r.LoggerT(mt)
+	line := strings.Repeat("x", 1024)
+	for i := 0; i < 100; i++ {
+		l.Log(line)
+	}
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	// Every line still reaches the test.
+	require.Len(t, mt.Logs, 100)
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+
+	logs := rep.Tests[0].Logs
+	require.Len(t, logs, 65)
+	require.Equal(t, "[log truncated after 65536 bytes]", logs[64].Message)
+}
+
+func TestReporter_FailedTestLogsOnly(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf}, testreporter.FailedTestLogsOnly())
+
+	passing := mocktesting.NewT("passing")
+	r.TrackTest(passing)
+	r.LoggerT(passing).Logf("hello from passing test")
+
+	failing := mocktesting.NewT("failing")
+	r.TrackTest(failing)
+	r.LoggerT(failing).Logf("hello from failing test")
+	failing.Fail()
+
+	passing.RunCleanups()
+	failing.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 2)
+
+	require.Equal(t, "passing", rep.Tests[0].Name)
+	require.Empty(t, rep.Tests[0].Logs)
+
+	require.Equal(t, "failing", rep.Tests[1].Name)
+	require.Len(t, rep.Tests[1].Logs, 1)
+	require.Equal(t, "hello from failing test", rep.Tests[1].Logs[0].Message)
+}
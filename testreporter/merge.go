@@ -0,0 +1,94 @@
+package testreporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Merge combines the reports read from each of readers into a single Report,
+// such as the reports produced by separate shards of a CI run.
+// See MergeMessages for how the reports are combined.
+func Merge(readers ...io.Reader) (*Report, error) {
+	msgs, err := MergeMessages(readers...)
+	if err != nil {
+		return nil, err
+	}
+	return NewReport(msgs), nil
+}
+
+// MergeMessages combines the message streams read from each of readers into a single stream.
+//
+// The merged stream begins with one BeginSuiteMessage at the earliest start time,
+// keeping the build info of that input,
+// and ends with a SummaryMessage of the merged results and one FinishSuiteMessage at the latest finish time.
+// All other messages are kept, in the order of their readers.
+// MergeMessages returns an error if the inputs were written with different schema versions.
+// If any input is missing its FinishSuiteMessage, the merged stream is also missing it,
+// so the merged report is still identified as incomplete.
+func MergeMessages(readers ...io.Reader) ([]Message, error) {
+	var (
+		begin    BeginSuiteMessage
+		finish   FinishSuiteMessage
+		complete = len(readers) > 0
+		body     []Message
+	)
+
+	for i, r := range readers {
+		msgs, err := ReadMessages(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report %d: %w", i, err)
+		}
+
+		var sawFinish bool
+		for _, m := range msgs {
+			switch m := m.(type) {
+			case BeginSuiteMessage:
+				if m.SchemaVersion != 0 && begin.SchemaVersion != 0 && m.SchemaVersion != begin.SchemaVersion {
+					return nil, fmt.Errorf("report %d has schema version %d, expected %d", i, m.SchemaVersion, begin.SchemaVersion)
+				}
+				version := begin.SchemaVersion
+				if version == 0 {
+					version = m.SchemaVersion
+				}
+				if begin.StartedAt.IsZero() || m.StartedAt.Before(begin.StartedAt) {
+					begin = m
+				}
+				begin.SchemaVersion = version
+			case SummaryMessage:
+				// Each input's summary only covers that input; the merged summary is recomputed.
+			case FinishSuiteMessage:
+				sawFinish = true
+				if m.FinishedAt.After(finish.FinishedAt) {
+					finish = m
+				}
+			default:
+				body = append(body, m)
+			}
+		}
+		complete = complete && sawFinish
+	}
+
+	merged := make([]Message, 0, len(body)+3)
+	merged = append(merged, begin)
+	merged = append(merged, body...)
+	if complete {
+		rep := NewReport(merged)
+		rep.FinishedAt = finish.FinishedAt
+		merged = append(merged, SummaryMessage{Summary: rep.Summary()}, finish)
+	}
+	return merged, nil
+}
+
+// WriteMessages writes msgs to w as newline-delimited JSON,
+// in the same format written by a Reporter.
+func WriteMessages(w io.Writer, msgs []Message) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, m := range msgs {
+		if err := enc.Encode(JSONMessage(m)); err != nil {
+			return fmt.Errorf("failed to encode message: %w", err)
+		}
+	}
+	return nil
+}
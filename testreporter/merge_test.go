@@ -0,0 +1,72 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	shard := func(offset time.Duration, testName string, complete bool) *bytes.Buffer {
+		msgs := []testreporter.Message{
+			testreporter.BeginSuiteMessage{StartedAt: start.Add(offset)},
+			testreporter.BeginTestMessage{Name: testName, StartedAt: start.Add(offset + time.Second)},
+			testreporter.FinishTestMessage{Name: testName, FinishedAt: start.Add(offset + 2*time.Second)},
+		}
+		if complete {
+			msgs = append(msgs, testreporter.FinishSuiteMessage{FinishedAt: start.Add(offset + 3*time.Second)})
+		}
+		buf := new(bytes.Buffer)
+		require.NoError(t, testreporter.WriteMessages(buf, msgs))
+		return buf
+	}
+
+	rep, err := testreporter.Merge(
+		shard(time.Minute, "TestB", true),
+		shard(0, "TestA", true),
+	)
+	require.NoError(t, err)
+
+	require.True(t, rep.Complete())
+	require.Equal(t, start, rep.StartedAt)
+	require.Equal(t, start.Add(time.Minute+3*time.Second), rep.FinishedAt)
+	require.Len(t, rep.Tests, 2)
+	require.Equal(t, "TestB", rep.Tests[0].Name)
+	require.Equal(t, "TestA", rep.Tests[1].Name)
+	require.Equal(t, 2, rep.Summary().Total)
+
+	// An incomplete shard makes the merged report incomplete.
+	rep, err = testreporter.Merge(
+		shard(0, "TestA", true),
+		shard(time.Minute, "TestB", false),
+	)
+	require.NoError(t, err)
+	require.False(t, rep.Complete())
+	require.Len(t, rep.Tests, 2)
+}
+
+func TestMerge_SchemaVersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	report := func(version int) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		require.NoError(t, testreporter.WriteMessages(buf, []testreporter.Message{
+			testreporter.BeginSuiteMessage{StartedAt: time.Now(), SchemaVersion: version},
+		}))
+		return buf
+	}
+
+	rep, err := testreporter.Merge(report(0), report(testreporter.SchemaVersion))
+	require.NoError(t, err)
+	require.Equal(t, testreporter.SchemaVersion, rep.SchemaVersion)
+
+	_, err = testreporter.Merge(report(testreporter.SchemaVersion), report(testreporter.SchemaVersion+1))
+	require.Error(t, err)
+}
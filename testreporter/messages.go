@@ -13,15 +13,27 @@ type Message interface {
 	typ() string
 }
 
+// SchemaVersion is the version of the report format written by a Reporter.
+//
+// Adding message types or fields is backwards compatible and does not change the version;
+// readers should ignore message types and fields they do not recognize.
+// The version is incremented when an existing message type or field changes meaning or is removed.
+const SchemaVersion = 1
+
 // BeginSuiteMessage indicates when the Reporter was initialized,
 // which should correlate with the beginning of a TestMain function,
 // or an init function in a normal test suite.
 type BeginSuiteMessage struct {
 	StartedAt time.Time
 
-	// TODO: it would be nice to embed the interchaintest commit in this message,
-	// but while https://github.com/golang/go/issues/33976 is outstanding,
-	// we'll have to fall back to ldflags to embed it.
+	// SchemaVersion is the version of the report format; see the SchemaVersion constant.
+	// It is zero in reports written before the format was versioned.
+	SchemaVersion int `json:",omitempty"`
+
+	// BuildInfo describes the environment of the test run,
+	// such as the Go, interchaintest, and docker versions.
+	// It is recorded automatically by NewReporter.
+	BuildInfo *BuildInfo `json:",omitempty"`
 }
 
 func (m BeginSuiteMessage) typ() string {
@@ -37,6 +49,17 @@ func (m FinishSuiteMessage) typ() string {
 	return "FinishSuite"
 }
 
+// SummaryMessage is written by a Reporter immediately before its FinishSuiteMessage,
+// with aggregate statistics of the whole run, so that consumers of the report
+// do not need to recompute them.
+type SummaryMessage struct {
+	Summary
+}
+
+func (m SummaryMessage) typ() string {
+	return "Summary"
+}
+
 // BeginTestMessage indicates the beginning of a single test.
 // If the test uses t.Parallel (via (*Reporter).TrackParallel),
 // the reporter will also track a PauseTestMessage and a ContinueTestMessage.
@@ -44,6 +67,10 @@ type BeginTestMessage struct {
 	Name      string
 	StartedAt time.Time
 	Labels    LabelSet
+
+	// Parent is the name of the nearest tracked ancestor of this test,
+	// if this test is a subtest started through t.Run.
+	Parent string `json:",omitempty"`
 }
 
 // LabelSet is the set of labels that can be associated with a test.
@@ -99,28 +126,148 @@ func (m ContinueTestMessage) typ() string {
 //	req.NoError(foo())
 //
 // If req.NoError fails, then rep will track a TestErrorMessage.
+//
+// File and Line are the call site of the failed assertion,
+// and Stack is the stack trace leading to the call site.
+// Fatal is set when the failed assertion was made through testify's require package,
+// as opposed to assert, meaning the failure stopped the test.
 type TestErrorMessage struct {
 	Name    string
 	When    time.Time
 	Message string
+
+	File  string `json:",omitempty"`
+	Line  int    `json:",omitempty"`
+	Stack string `json:",omitempty"`
+	Fatal bool   `json:",omitempty"`
 }
 
 func (m TestErrorMessage) typ() string {
 	return "TestError"
 }
 
-// TestSkipMessage is tracked when a Reporter's TrackSkip method is called.
+// TestSkipMessage is tracked when a Reporter's TrackSkip or TrackSkipCategory method is called.
 // This allows the report to track the reason a test was skipped.
 type TestSkipMessage struct {
 	Name    string
 	When    time.Time
 	Message string
+
+	// Category is only set through TrackSkipCategory.
+	Category SkipCategory `json:",omitempty"`
 }
 
+// SkipCategory classifies the reason a test was skipped.
+type SkipCategory string
+
+const (
+	// SkipUnsupportedRelayer indicates the relayer under test intentionally does not support the test,
+	// such as a relayer lacking a required capability.
+	SkipUnsupportedRelayer SkipCategory = "unsupported-relayer"
+
+	// SkipUnsupportedChain indicates the chain under test intentionally does not support the test.
+	SkipUnsupportedChain SkipCategory = "unsupported-chain"
+
+	// SkipMissingEnvironment indicates the test could not run because something it needs
+	// from the environment, such as a docker daemon or an external service, was unavailable.
+	// Unlike the unsupported categories, these skips usually warrant attention.
+	SkipMissingEnvironment SkipCategory = "missing-environment"
+
+	// SkipShortMode indicates the test was skipped because of the -short flag.
+	SkipShortMode SkipCategory = "short-mode"
+)
+
 func (m TestSkipMessage) typ() string {
 	return "TestSkip"
 }
 
+// TestLogMessage is a log message of a test, tracked through a TestLogger.
+type TestLogMessage struct {
+	Name    string
+	When    time.Time
+	Message string
+}
+
+func (m TestLogMessage) typ() string {
+	return "TestLog"
+}
+
+// TestRetryMessage is tracked when a Reporter's TrackRetry method is called,
+// indicating that a new attempt of the test has begun.
+type TestRetryMessage struct {
+	Name    string
+	When    time.Time
+	Attempt int
+}
+
+func (m TestRetryMessage) typ() string {
+	return "TestRetry"
+}
+
+// TestMetadataMessage is tracked when a Reporter's TrackMetadata method is called.
+// It associates an arbitrary key-value pair, such as a chain version, with a test.
+type TestMetadataMessage struct {
+	Name  string
+	When  time.Time
+	Key   string
+	Value string
+}
+
+func (m TestMetadataMessage) typ() string {
+	return "TestMetadata"
+}
+
+// TestPhaseMessage records the timing of a named section within a test.
+// This message is populated through the Reporter's TrackPhase method,
+// and it is tracked when the phase finishes.
+type TestPhaseMessage struct {
+	Name  string // Test name, but "Name" for consistency.
+	Phase string
+
+	StartedAt, FinishedAt time.Time
+}
+
+func (m TestPhaseMessage) typ() string {
+	return "TestPhase"
+}
+
+// TestAttachmentMessage is tracked when a Reporter's Attach method is called.
+// Small attachments are stored inline in Data, which is base64-encoded in JSON.
+// Larger attachments are written to the artifacts directory, and Path is the written file.
+type TestAttachmentMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	Attachment string
+	Size       int64
+
+	Data []byte `json:",omitempty"`
+	Path string `json:",omitempty"`
+}
+
+func (m TestAttachmentMessage) typ() string {
+	return "TestAttachment"
+}
+
+// BlockDatabaseMessage links a test to the blocks and transactions recorded for it
+// in the sqlite block database.
+// This message is populated through the RelayerExecReporter's TrackBlockDatabase method,
+// which is called by interchaintest's Interchain.Build.
+type BlockDatabaseMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	// Path of the sqlite database file.
+	Path string
+
+	// TestCaseID is the ID of the test's row in the database's test_case table.
+	TestCaseID int64
+}
+
+func (m BlockDatabaseMessage) typ() string {
+	return "BlockDatabase"
+}
+
 // RelayerExecMessage is the result of executing a relayer command.
 // This message is populated through the RelayerExecReporter type,
 // which is returned by the Reporter's RelayerExecReporter method.
@@ -144,6 +291,57 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// Duration is how long the relayer command took to execute.
+func (m RelayerExecMessage) Duration() time.Duration {
+	return m.FinishedAt.Sub(m.StartedAt)
+}
+
+// ContainerLogsMessage holds the logs of a docker container created for a failed test.
+// This message is populated through the Reporter's TrackContainerLogs method.
+type ContainerLogsMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	ContainerID   string `json:",omitempty"`
+	ContainerName string `json:",omitempty"`
+	Image         string `json:",omitempty"`
+
+	Logs string
+
+	Error string `json:",omitempty"`
+}
+
+func (m ContainerLogsMessage) typ() string {
+	return "ContainerLogs"
+}
+
+// ContainerStatsMessage holds the total resource usage of the docker containers created for a test.
+// This message is populated through the Reporter's TrackContainerStats method.
+type ContainerStatsMessage struct {
+	Name string // Test name, but "Name" for consistency.
+	When time.Time
+
+	// Containers is the number of containers observed.
+	Containers int
+
+	// CPUSeconds is the CPU time used by all the containers.
+	CPUSeconds float64
+
+	// PeakMemoryBytes is the sum of the peak memory usage of each container,
+	// an upper bound of the memory used at once.
+	PeakMemoryBytes int64
+
+	// VolumeBytes is the disk space used by the test's volumes, when the test finished.
+	VolumeBytes int64
+
+	// Error is the last error encountered while sampling, if any.
+	Error string `json:",omitempty"`
+}
+
+func (m ContainerStatsMessage) typ() string {
+	return "ContainerStats"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -178,6 +376,10 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := BeginSuiteMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "Summary":
+		x := SummaryMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	case "FinishSuite":
 		x := FinishSuiteMessage{}
 		err = json.Unmarshal(raw, &x)
@@ -206,10 +408,42 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := TestSkipMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "TestLog":
+		x := TestLogMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "TestRetry":
+		x := TestRetryMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "TestMetadata":
+		x := TestMetadataMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "TestPhase":
+		x := TestPhaseMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "TestAttachment":
+		x := TestAttachmentMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "BlockDatabase":
+		x := BlockDatabaseMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	case "RelayerExec":
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "ContainerStats":
+		x := ContainerStatsMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
+	case "ContainerLogs":
+		x := ContainerLogsMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
@@ -15,8 +15,37 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 	tcs := []struct {
 		Message testreporter.Message
 	}{
-		{Message: testreporter.BeginSuiteMessage{StartedAt: time.Now()}},
+		{Message: testreporter.BeginSuiteMessage{StartedAt: time.Now(), SchemaVersion: testreporter.SchemaVersion}},
+		{
+			Message: testreporter.BeginSuiteMessage{
+				StartedAt:     time.Now(),
+				SchemaVersion: testreporter.SchemaVersion,
+				BuildInfo: &testreporter.BuildInfo{
+					GoVersion:             "go1.19.4",
+					InterchaintestVersion: "v7.0.0",
+					VCSRevision:           "0123456789abcdef",
+					DockerVersion:         "20.10.21",
+					OS:                    "linux",
+					Arch:                  "amd64",
+					Env:                   map[string]string{"CI": "true"},
+				},
+			},
+		},
 		{Message: testreporter.FinishSuiteMessage{FinishedAt: time.Now()}},
+		{
+			Message: testreporter.SummaryMessage{Summary: testreporter.Summary{
+				StartedAt:         time.Now(),
+				FinishedAt:        time.Now().Add(time.Minute),
+				Total:             2,
+				Passed:            1,
+				Failed:            1,
+				FailedTests:       []string{"foo"},
+				SummedTestTime:    90 * time.Second,
+				Slowest:           []testreporter.TestDuration{{Name: "foo", Duration: time.Minute}},
+				FailuresByChains:  map[string]int{"gaia+osmosis": 1},
+				SkippedByCategory: map[testreporter.SkipCategory]int{testreporter.SkipUnsupportedRelayer: 3},
+			}},
+		},
 		{
 			Message: testreporter.BeginTestMessage{
 				Name:      "foo",
@@ -26,13 +55,26 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 					Chain:   []label.Chain{label.Gaia},
 					Test:    []label.Test{label.Timeout},
 				},
+				Parent: "parent",
 			},
 		},
 		{Message: testreporter.PauseTestMessage{Name: "foo", When: time.Now()}},
 		{Message: testreporter.ContinueTestMessage{Name: "foo", When: time.Now()}},
 		{Message: testreporter.FinishTestMessage{Name: "foo", FinishedAt: time.Now(), Skipped: true, Failed: true}},
 		{Message: testreporter.TestErrorMessage{Name: "foo", When: time.Now(), Message: "something failed"}},
+		{Message: testreporter.TestErrorMessage{
+			Name: "foo", When: time.Now(), Message: "something failed",
+			File: "/src/foo_test.go", Line: 42, Stack: "foo.TestFoo\n\t/src/foo_test.go:42\n", Fatal: true,
+		}},
 		{Message: testreporter.TestSkipMessage{Name: "foo", When: time.Now(), Message: "skipped for reasons"}},
+		{Message: testreporter.TestSkipMessage{Name: "foo", When: time.Now(), Message: "missing capabilities", Category: testreporter.SkipUnsupportedRelayer}},
+		{Message: testreporter.TestLogMessage{Name: "foo", When: time.Now(), Message: "starting chains"}},
+		{Message: testreporter.TestRetryMessage{Name: "foo", When: time.Now(), Attempt: 2}},
+		{Message: testreporter.TestMetadataMessage{Name: "foo", When: time.Now(), Key: "gaia_version", Value: "v7.0.1"}},
+		{Message: testreporter.TestAttachmentMessage{Name: "foo", When: time.Now(), Attachment: "genesis.json", Size: 2, Data: []byte("{}")}},
+		{Message: testreporter.ContainerStatsMessage{Name: "foo", When: time.Now(), Containers: 3, CPUSeconds: 12.5, PeakMemoryBytes: 1 << 30, VolumeBytes: 1 << 20}},
+		{Message: testreporter.BlockDatabaseMessage{Name: "foo", When: time.Now(), Path: "/tmp/blocks.db", TestCaseID: 7}},
+		{Message: testreporter.TestPhaseMessage{Name: "foo", Phase: "start-chains", StartedAt: time.Now(), FinishedAt: time.Now().Add(time.Second)}},
 		{
 			Message: testreporter.RelayerExecMessage{
 				Name:          "foo",
@@ -45,6 +87,16 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.ContainerLogsMessage{
+				Name:          "foo",
+				When:          time.Now(),
+				ContainerID:   "abc123",
+				ContainerName: "gaia-1-val-0-foo",
+				Image:         "ghcr.io/strangelove-ventures/heighliner/gaia:v7.0.1",
+				Logs:          "panic: CONSENSUS FAILURE",
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -0,0 +1,96 @@
+package testreporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Notifier is notified with the summary of a test run when a Reporter is closed.
+// Notifiers are configured on a Reporter through the Notify option.
+type Notifier interface {
+	Notify(ctx context.Context, s Summary) error
+}
+
+// maxNotifiedFailures limits the number of failed test names included in a notification,
+// to keep messages readable when many tests fail.
+const maxNotifiedFailures = 20
+
+// SlackNotifier posts the run summary to a Slack incoming webhook.
+type SlackNotifier struct {
+	// WebhookURL is the Slack incoming webhook URL.
+	WebhookURL string
+
+	// ArtifactsURL, if set, is linked from the message, e.g. to the CI job's uploaded reports.
+	ArtifactsURL string
+
+	// OnlyFailures skips posting the message if no tests failed.
+	OnlyFailures bool
+
+	// Client is used to send the request. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify posts s to n's webhook.
+func (n SlackNotifier) Notify(ctx context.Context, s Summary) error {
+	if n.OnlyFailures && s.Failed == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{Text: n.message(s)})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("slack webhook returned status %d: %s", res.StatusCode, b)
+	}
+	return nil
+}
+
+func (n SlackNotifier) message(s Summary) string {
+	var sb strings.Builder
+	if s.Failed > 0 {
+		fmt.Fprintf(&sb, ":x: interchaintest run failed: %d of %d tests failed", s.Failed, s.Total)
+	} else {
+		fmt.Fprintf(&sb, ":white_check_mark: interchaintest run passed: %d tests", s.Total)
+	}
+	fmt.Fprintf(&sb, " (%d passed, %d skipped) in %s\n", s.Passed, s.Skipped, s.WallClock().Round(time.Second))
+
+	for i, name := range s.FailedTests {
+		if i == maxNotifiedFailures {
+			fmt.Fprintf(&sb, "• ...and %d more\n", len(s.FailedTests)-maxNotifiedFailures)
+			break
+		}
+		fmt.Fprintf(&sb, "• `%s`\n", name)
+	}
+
+	if n.ArtifactsURL != "" {
+		fmt.Fprintf(&sb, "<%s|View artifacts>\n", n.ArtifactsURL)
+	}
+	return sb.String()
+}
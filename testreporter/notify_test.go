@@ -0,0 +1,91 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackNotifier(t *testing.T) {
+	t.Parallel()
+
+	// The bodies are sent from the server's goroutines, and decoded on the test's goroutine.
+	bodies := make(chan []byte, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		bodies <- body
+	}))
+	defer srv.Close()
+
+	r := testreporter.NewReporter(
+		nopCloser{Writer: new(bytes.Buffer)},
+		testreporter.Notify(testreporter.SlackNotifier{
+			WebhookURL:   srv.URL,
+			ArtifactsURL: "https://ci.example.com/run/1",
+		}),
+		// OnlyFailures is satisfied, so both notifiers post.
+		testreporter.Notify(testreporter.SlackNotifier{WebhookURL: srv.URL, OnlyFailures: true}),
+	)
+
+	passing := mocktesting.NewT("TestPassing")
+	r.TrackTest(passing)
+	passing.RunCleanups()
+
+	failing := mocktesting.NewT("TestFailing")
+	r.TrackTest(failing)
+	r.TestifyT(failing).Errorf("failed")
+	failing.RunCleanups()
+
+	require.NoError(t, r.Close())
+	// Wait for the handlers to return.
+	srv.Close()
+	close(bodies)
+
+	var texts []string
+	for b := range bodies {
+		var body struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.Unmarshal(b, &body))
+		texts = append(texts, body.Text)
+	}
+	require.Len(t, texts, 2)
+	require.Contains(t, texts[0], "1 of 2 tests failed")
+	require.Contains(t, texts[0], "`TestFailing`")
+	require.NotContains(t, texts[0], "TestPassing")
+	require.Contains(t, texts[0], "https://ci.example.com/run/1")
+}
+
+func TestSlackNotifier_OnlyFailures(t *testing.T) {
+	t.Parallel()
+
+	var called atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called.Store(true)
+	}))
+	defer srv.Close()
+
+	r := testreporter.NewReporter(
+		nopCloser{Writer: new(bytes.Buffer)},
+		testreporter.Notify(testreporter.SlackNotifier{WebhookURL: srv.URL, OnlyFailures: true}),
+	)
+	mt := mocktesting.NewT("TestPassing")
+	r.TrackTest(mt)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+	srv.Close()
+
+	require.False(t, called.Load())
+}
@@ -0,0 +1,151 @@
+package testreporter
+
+import (
+	"io"
+	"regexp"
+)
+
+// ReporterOption is used to customize a Reporter constructed with NewReporter.
+type ReporterOption interface {
+	// reporterOption is a no-op to be more restrictive on what types can be used as ReporterOptions
+	reporterOption()
+}
+
+// ReporterOptionJUnit writes a JUnit XML report to W when the Reporter is closed.
+type ReporterOptionJUnit struct {
+	W io.Writer
+}
+
+// JUnitOutput configures the Reporter to additionally write a JUnit XML report to w
+// when the Reporter is closed.
+// If w is also an io.Closer, it is closed after the report is written.
+func JUnitOutput(w io.Writer) ReporterOption {
+	return ReporterOptionJUnit{W: w}
+}
+
+func (ReporterOptionJUnit) reporterOption() {}
+
+// ReporterOptionHTML writes an HTML report to W when the Reporter is closed.
+type ReporterOptionHTML struct {
+	W io.Writer
+}
+
+// HTMLOutput configures the Reporter to additionally write a self-contained HTML report to w
+// when the Reporter is closed.
+// If w is also an io.Closer, it is closed after the report is written.
+func HTMLOutput(w io.Writer) ReporterOption {
+	return ReporterOptionHTML{W: w}
+}
+
+func (ReporterOptionHTML) reporterOption() {}
+
+// ReporterOptionTAP writes a Test Anything Protocol report to W when the Reporter is closed.
+type ReporterOptionTAP struct {
+	W io.Writer
+}
+
+// TAPOutput configures the Reporter to additionally write a Test Anything Protocol report to w
+// when the Reporter is closed.
+// If w is also an io.Closer, it is closed after the report is written.
+func TAPOutput(w io.Writer) ReporterOption {
+	return ReporterOptionTAP{W: w}
+}
+
+func (ReporterOptionTAP) reporterOption() {}
+
+// ReporterOptionGitHubAnnotations writes GitHub Actions workflow commands to W when the Reporter is closed.
+type ReporterOptionGitHubAnnotations struct {
+	W io.Writer
+}
+
+// GitHubAnnotations configures the Reporter to write an "::error" workflow command for each test failure to w
+// when the Reporter is closed, so that GitHub Actions shows the failures inline on the pull request.
+// Typically w is the job's standard output.
+// If w is also an io.Closer, it is closed after the annotations are written.
+// See WriteGitHubAnnotations for details.
+func GitHubAnnotations(w io.Writer) ReporterOption {
+	return ReporterOptionGitHubAnnotations{W: w}
+}
+
+func (ReporterOptionGitHubAnnotations) reporterOption() {}
+
+// ReporterOptionPushGateway pushes test metrics to a Prometheus pushgateway when the Reporter is closed.
+type ReporterOptionPushGateway struct {
+	URL, Job string
+}
+
+// PushGateway configures the Reporter to push per-test durations and pass, fail, and skip counts
+// to the Prometheus pushgateway at url, under the given job name, when the Reporter is closed.
+// See PushMetrics for the metrics that are pushed.
+func PushGateway(url, job string) ReporterOption {
+	return ReporterOptionPushGateway{URL: url, Job: job}
+}
+
+func (ReporterOptionPushGateway) reporterOption() {}
+
+// ReporterOptionOTLP exports the test run as OpenTelemetry traces when the Reporter is closed.
+type ReporterOptionOTLP struct {
+	Endpoint string
+}
+
+// OTLPTraces configures the Reporter to export the test run as spans to the OTLP/HTTP collector at endpoint,
+// e.g. "http://localhost:4318", when the Reporter is closed.
+// See ExportTraces for the shape of the exported trace.
+func OTLPTraces(endpoint string) ReporterOption {
+	return ReporterOptionOTLP{Endpoint: endpoint}
+}
+
+func (ReporterOptionOTLP) reporterOption() {}
+
+// ReporterOptionNotify calls Notifier with the run summary when the Reporter is closed.
+type ReporterOptionNotify struct {
+	Notifier Notifier
+}
+
+// Notify configures the Reporter to call n with the run summary when the Reporter is closed.
+// For example, use a SlackNotifier to post the results of nightly runs.
+func Notify(n Notifier) ReporterOption {
+	return ReporterOptionNotify{Notifier: n}
+}
+
+func (ReporterOptionNotify) reporterOption() {}
+
+// ReporterOptionArtifactsDir sets the directory where large attachments are written.
+type ReporterOptionArtifactsDir struct {
+	Dir string
+}
+
+// ArtifactsDir configures the directory where attachments too large to store inline in the report are written.
+// Attachments are written to a subdirectory per test.
+func ArtifactsDir(dir string) ReporterOption {
+	return ReporterOptionArtifactsDir{Dir: dir}
+}
+
+func (ReporterOptionArtifactsDir) reporterOption() {}
+
+// ReporterOptionRedact masks text matching any of Patterns before it is written to the report.
+type ReporterOptionRedact struct {
+	Patterns []*regexp.Regexp
+}
+
+// Redact configures the Reporter to mask text matching any of patterns,
+// such as private keys in chain config dumps, in tracked messages
+// before they are written to the report or any other output.
+// Use the Reporter's RegisterSecret method to mask specific values known at runtime.
+func Redact(patterns ...*regexp.Regexp) ReporterOption {
+	return ReporterOptionRedact{Patterns: patterns}
+}
+
+func (ReporterOptionRedact) reporterOption() {}
+
+// ReporterOptionFailedTestLogsOnly configures TestLoggers to only record the logs of failed tests.
+type ReporterOptionFailedTestLogsOnly struct{}
+
+// FailedTestLogsOnly configures the loggers returned by the Reporter's LoggerT method
+// to hold log messages in memory, and only record them in the report if the test fails.
+// This keeps the report small for large, mostly passing runs.
+func FailedTestLogsOnly() ReporterOption {
+	return ReporterOptionFailedTestLogsOnly{}
+}
+
+func (ReporterOptionFailedTestLogsOnly) reporterOption() {}
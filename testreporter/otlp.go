@@ -0,0 +1,251 @@
+package testreporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTLP status codes, as defined by the OpenTelemetry trace protocol.
+const (
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+// The following types are the subset of the OTLP/HTTP JSON encoding needed to export a report.
+// Writing them by hand avoids depending on the OpenTelemetry SDK for a single request.
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func otlpAttr(k, v string) otlpKeyValue {
+	return otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}}
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// ExportTraces sends rep as OpenTelemetry spans to the OTLP/HTTP collector at endpoint,
+// e.g. "http://localhost:4318". The spans are posted to the collector's /v1/traces path.
+//
+// The whole run is a single trace with a root span named "interchaintest".
+// Each test is a span, nested under its parent test if it is a subtest.
+// Time spent waiting for parallel execution and phases tracked through TrackPhase
+// are child spans of the test, and test errors are recorded as span events.
+func ExportTraces(ctx context.Context, endpoint string, rep *Report) error {
+	body, err := json.Marshal(buildTraces(rep))
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build otlp request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export traces to %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("otlp collector returned status %d: %s", res.StatusCode, b)
+	}
+	return nil
+}
+
+func buildTraces(rep *Report) otlpTraces {
+	traceID := randomHex(16)
+
+	// Tests that never finished, e.g. due to a crash, end with the run.
+	end := rep.FinishedAt
+	if end.IsZero() {
+		end = lastTimestamp(rep)
+	}
+
+	root := otlpSpan{
+		TraceID:           traceID,
+		SpanID:            randomHex(8),
+		Name:              "interchaintest",
+		Kind:              1, // Internal.
+		StartTimeUnixNano: otlpTime(rep.StartedAt),
+		EndTimeUnixNano:   otlpTime(end),
+		Status:            otlpStatus{Code: otlpStatusOK},
+	}
+
+	spans := []otlpSpan{root}
+	spanIDs := make(map[string]string, len(rep.Tests))
+	for _, tr := range rep.Tests {
+		parentID := root.SpanID
+		if id, ok := spanIDs[tr.Parent]; ok {
+			parentID = id
+		}
+		span := otlpSpan{
+			TraceID:           traceID,
+			SpanID:            randomHex(8),
+			ParentSpanID:      parentID,
+			Name:              tr.Name,
+			Kind:              1,
+			StartTimeUnixNano: otlpTime(tr.StartedAt),
+			EndTimeUnixNano:   otlpTime(end),
+			Attributes: []otlpKeyValue{
+				otlpAttr("test.name", tr.Name),
+				otlpAttr("test.status", testStatus(tr)),
+			},
+		}
+		spanIDs[tr.Name] = span.SpanID
+
+		if !tr.FinishedAt.IsZero() {
+			span.EndTimeUnixNano = otlpTime(tr.FinishedAt)
+		}
+		if tr.SkipReason != "" {
+			span.Attributes = append(span.Attributes, otlpAttr("test.skip_reason", tr.SkipReason))
+		}
+		for _, k := range sortedKeys(tr.Metadata) {
+			span.Attributes = append(span.Attributes, otlpAttr("test.metadata."+k, tr.Metadata[k]))
+		}
+
+		switch testStatus(tr) {
+		case "fail":
+			span.Status = otlpStatus{Code: otlpStatusError}
+			if len(tr.Errors) > 0 {
+				span.Status.Message = firstLine(tr.Errors[0].Message)
+			}
+		case "unknown":
+			span.Status = otlpStatus{Code: otlpStatusError, Message: "test did not finish"}
+		default:
+			span.Status = otlpStatus{Code: otlpStatusOK}
+		}
+
+		for _, e := range tr.Errors {
+			span.Events = append(span.Events, otlpEvent{
+				TimeUnixNano: otlpTime(e.When),
+				Name:         "error",
+				Attributes:   []otlpKeyValue{otlpAttr("message", e.Message)},
+			})
+		}
+		spans = append(spans, span)
+
+		if !tr.PausedAt.IsZero() && !tr.ContinuedAt.IsZero() {
+			spans = append(spans, otlpSpan{
+				TraceID:           traceID,
+				SpanID:            randomHex(8),
+				ParentSpanID:      span.SpanID,
+				Name:              "parallel-wait",
+				Kind:              1,
+				StartTimeUnixNano: otlpTime(tr.PausedAt),
+				EndTimeUnixNano:   otlpTime(tr.ContinuedAt),
+				Status:            otlpStatus{Code: otlpStatusOK},
+			})
+		}
+		for _, p := range tr.Phases {
+			spans = append(spans, otlpSpan{
+				TraceID:           traceID,
+				SpanID:            randomHex(8),
+				ParentSpanID:      span.SpanID,
+				Name:              p.Phase,
+				Kind:              1,
+				StartTimeUnixNano: otlpTime(p.StartedAt),
+				EndTimeUnixNano:   otlpTime(p.FinishedAt),
+				Status:            otlpStatus{Code: otlpStatusOK},
+			})
+		}
+	}
+
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpKeyValue{
+			otlpAttr("service.name", "interchaintest"),
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/strangelove-ventures/interchaintest/v7/testreporter"},
+			Spans: spans,
+		}},
+	}}}
+}
+
+// lastTimestamp returns the latest time a test in rep started or finished,
+// for reports that are missing a FinishSuite message.
+func lastTimestamp(rep *Report) time.Time {
+	last := rep.StartedAt
+	for _, tr := range rep.Tests {
+		for _, t := range []time.Time{tr.StartedAt, tr.FinishedAt} {
+			if t.After(last) {
+				last = t
+			}
+		}
+	}
+	return last
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Errorf("failed to generate span id: %w", err))
+	}
+	return hex.EncodeToString(b)
+}
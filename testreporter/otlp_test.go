@@ -0,0 +1,87 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// otlpSpan is the subset of an exported span checked in tests.
+type otlpSpan struct {
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Events       []struct {
+		Name string `json:"name"`
+	} `json:"events"`
+	Status struct {
+		Code int `json:"code"`
+	} `json:"status"`
+}
+
+func TestOTLPTraces(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotPath string
+		spans   []otlpSpan
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath = req.URL.Path
+		var body struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []otlpSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		spans = body.ResourceSpans[0].ScopeSpans[0].Spans
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)}, testreporter.OTLPTraces(srv.URL))
+
+	parent := mocktesting.NewT("TestFoo")
+	r.TrackTest(parent)
+	r.TrackParallel(parent)
+
+	sub := mocktesting.NewT("TestFoo/bar")
+	r.TrackTest(sub)
+	r.TrackPhase(sub, "start-chains")()
+	r.TestifyT(sub).Errorf("something went wrong")
+	sub.RunCleanups()
+	parent.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	require.Equal(t, "/v1/traces", gotPath)
+
+	byName := make(map[string]otlpSpan, len(spans))
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+	require.Len(t, byName, 5)
+
+	root := byName["interchaintest"]
+	require.Empty(t, root.ParentSpanID)
+	require.Equal(t, root.SpanID, byName["TestFoo"].ParentSpanID)
+	require.Equal(t, byName["TestFoo"].SpanID, byName["parallel-wait"].ParentSpanID)
+	require.Equal(t, byName["TestFoo"].SpanID, byName["TestFoo/bar"].ParentSpanID)
+	require.Equal(t, byName["TestFoo/bar"].SpanID, byName["start-chains"].ParentSpanID)
+
+	failed := byName["TestFoo/bar"]
+	require.Equal(t, 2, failed.Status.Code)
+	require.Len(t, failed.Events, 1)
+	require.Equal(t, "error", failed.Events[0].Name)
+}
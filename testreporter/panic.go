@@ -0,0 +1,86 @@
+package testreporter
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// RecoverPanic recovers a panic in the calling goroutine,
+// records the panic value and stack as a fatal error in t's report entry, and fails t.
+// It must be called directly with defer:
+//
+//	go func() {
+//	  defer reporter.RecoverPanic(t)
+//	  // Code that may panic...
+//	}()
+//
+// Without RecoverPanic, a panic in a goroutine other than the test's crashes the test binary,
+// and the report only shows the test as unfinished.
+// Because the panic is recovered, the goroutine's function returns normally afterwards.
+func (r *Reporter) RecoverPanic(t TestifyT) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	r.trackPanic(t, v)
+}
+
+// WrapGo returns a function that calls fn, recovering any panic as described in RecoverPanic.
+// It is intended for starting goroutines within a test:
+//
+//	go reporter.WrapGo(t, func() {
+//	  // Code that may panic...
+//	})()
+func (r *Reporter) WrapGo(t TestifyT, fn func()) func() {
+	return func() {
+		defer func() {
+			if v := recover(); v != nil {
+				r.trackPanic(t, v)
+			}
+		}()
+		fn()
+	}
+}
+
+func (r *Reporter) trackPanic(t TestifyT, v any) {
+	msg := fmt.Sprintf("panic: %v", v)
+	file, line := panicSite()
+
+	r.in <- TestErrorMessage{
+		Name:    t.Name(),
+		When:    time.Now(),
+		Message: msg,
+
+		File:  file,
+		Line:  line,
+		Stack: string(debug.Stack()),
+		Fatal: true,
+	}
+
+	t.Errorf("%s\n%s", msg, debug.Stack())
+}
+
+// panicSite returns the location of the panic being recovered,
+// which is the first frame after the runtime's panic function.
+func panicSite() (string, int) {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sawPanic bool
+	for {
+		f, more := frames.Next()
+		if sawPanic && !strings.HasPrefix(f.Function, "runtime.") {
+			return f.File, f.Line
+		}
+		if f.Function == "runtime.gopanic" {
+			sawPanic = true
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
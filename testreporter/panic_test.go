@@ -0,0 +1,83 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func panicky() {
+	var m map[string]int
+	m["boom"]++
+}
+
+func TestReporter_WrapGo(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	// Signal completion once the wrapped func returns, after the panic is tracked.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.WrapGo(mt, panicky)()
+	}()
+	wg.Wait()
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	require.True(t, mt.Failed())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.True(t, rep.Tests[0].Failed)
+	require.Len(t, rep.Tests[0].Errors, 1)
+
+	e := rep.Tests[0].Errors[0]
+	require.Equal(t, "panic: assignment to entry in nil map", e.Message)
+	require.True(t, e.Fatal)
+	require.True(t, strings.HasSuffix(e.File, "panic_test.go"), e.File)
+	require.Contains(t, e.Stack, "panicky")
+}
+
+func TestReporter_RecoverPanic(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer r.RecoverPanic(mt)
+		panic("something unexpected")
+	}()
+	<-done
+
+	// Not panicking records nothing.
+	func() {
+		defer r.RecoverPanic(mt)
+	}()
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests[0].Errors, 1)
+	require.Equal(t, "panic: something unexpected", rep.Tests[0].Errors[0].Message)
+}
@@ -0,0 +1,53 @@
+package testreporter
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushMetrics pushes metrics derived from rep to the Prometheus pushgateway at url,
+// grouped under the given job name.
+//
+// The pushed metrics are:
+//   - interchaintest_test_duration_seconds, a gauge per test with test and status labels
+//   - interchaintest_tests_total, a counter of tests per status (pass, fail, skip, or unknown)
+//   - interchaintest_suite_duration_seconds, a gauge of the wall clock time of the whole run
+func PushMetrics(url, job string, rep *Report) error {
+	reg := prometheus.NewRegistry()
+
+	durations := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "interchaintest",
+		Name:      "test_duration_seconds",
+		Help:      "Duration of a tracked test, excluding time paused for parallel execution.",
+	}, []string{"test", "status"})
+	totals := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "interchaintest",
+		Name:      "tests_total",
+		Help:      "Count of tracked tests by status.",
+	}, []string{"status"})
+	suiteDuration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "interchaintest",
+		Name:      "suite_duration_seconds",
+		Help:      "Wall clock duration of the test run.",
+	})
+	reg.MustRegister(durations, totals, suiteDuration)
+
+	// Initialize the common statuses so that zero counts are still reported.
+	for _, status := range []string{"pass", "fail", "skip"} {
+		totals.WithLabelValues(status)
+	}
+
+	for _, tr := range rep.Tests {
+		status := testStatus(tr)
+		durations.WithLabelValues(tr.Name, status).Set(tr.Duration().Seconds())
+		totals.WithLabelValues(status).Inc()
+	}
+	suiteDuration.Set(rep.Duration().Seconds())
+
+	if err := push.New(url, job).Gatherer(reg).Push(); err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushGateway(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotMethod, gotPath string
+		gotBody            []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotMethod = req.Method
+		gotPath = req.URL.Path
+		gotBody, _ = io.ReadAll(req.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)}, testreporter.PushGateway(srv.URL, "nightly"))
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	require.Equal(t, http.MethodPut, gotMethod)
+	require.Equal(t, "/metrics/job/nightly", gotPath)
+	require.Contains(t, string(gotBody), "interchaintest_test_duration_seconds")
+	require.Contains(t, string(gotBody), "interchaintest_tests_total")
+	require.Contains(t, string(gotBody), "my_test")
+}
+
+func TestPushGateway_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)}, testreporter.PushGateway(srv.URL, "nightly"))
+	require.Error(t, r.Close())
+}
@@ -0,0 +1,97 @@
+package testreporter
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redactedText replaces each redacted secret in the report.
+const redactedText = "[REDACTED]"
+
+// redactor masks secrets in the free-form text of messages before they are written to the report.
+type redactor struct {
+	// Configured through options, so only set before the write goroutine starts.
+	patterns []*regexp.Regexp
+
+	// Registered at any time through RegisterSecret.
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// RegisterSecret arranges for every occurrence of secret, such as a wallet mnemonic or private key,
+// to be masked in any message tracked afterwards.
+// Messages tracked before the call are not affected.
+// Empty secrets are ignored.
+//
+// Only free-form text is redacted: error messages and stack traces, logs, skip reasons, metadata values,
+// relayer commands and output, and container logs.
+// Attachments are stored as provided.
+func (r *Reporter) RegisterSecret(secret string) {
+	if secret == "" {
+		return
+	}
+
+	r.redactor.mu.Lock()
+	defer r.redactor.mu.Unlock()
+	r.redactor.secrets = append(r.redactor.secrets, secret)
+}
+
+func (rd *redactor) redact(s string) string {
+	if s == "" {
+		return s
+	}
+
+	rd.mu.RLock()
+	for _, secret := range rd.secrets {
+		s = strings.ReplaceAll(s, secret, redactedText)
+	}
+	rd.mu.RUnlock()
+
+	for _, p := range rd.patterns {
+		s = p.ReplaceAllLiteralString(s, redactedText)
+	}
+	return s
+}
+
+func (rd *redactor) redactAll(ss []string) []string {
+	if len(ss) == 0 {
+		return ss
+	}
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = rd.redact(s)
+	}
+	return out
+}
+
+// redactMessage returns a copy of m with its free-form text redacted.
+func (rd *redactor) redactMessage(m Message) Message {
+	switch m := m.(type) {
+	case TestErrorMessage:
+		m.Message = rd.redact(m.Message)
+		m.Stack = rd.redact(m.Stack)
+		return m
+	case TestSkipMessage:
+		m.Message = rd.redact(m.Message)
+		return m
+	case TestLogMessage:
+		m.Message = rd.redact(m.Message)
+		return m
+	case TestMetadataMessage:
+		m.Value = rd.redact(m.Value)
+		return m
+	case RelayerExecMessage:
+		m.Command = rd.redactAll(m.Command)
+		m.Stdout = rd.redact(m.Stdout)
+		m.Stderr = rd.redact(m.Stderr)
+		m.Error = rd.redact(m.Error)
+		return m
+	case ContainerLogsMessage:
+		m.Logs = rd.redact(m.Logs)
+		m.Error = rd.redact(m.Error)
+		return m
+	default:
+		return m
+	}
+}
@@ -0,0 +1,51 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_Redact(t *testing.T) {
+	t.Parallel()
+
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(
+		nopCloser{Writer: buf},
+		testreporter.Redact(regexp.MustCompile(`"priv_key":\s*"[^"]*"`)),
+	)
+	r.RegisterSecret(mnemonic)
+	r.RegisterSecret("")
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	r.TestifyT(mt).Errorf("failed to restore key from %q", mnemonic)
+	r.TrackMetadata(mt, "config", `{"priv_key": "c2VjcmV0"}`)
+	r.RelayerExecReporter(mt).TrackRelayerExec(
+		"my_container", []string{"rly", "keys", "restore", mnemonic},
+		"", "", 0,
+		time.Now(), time.Now(), nil,
+	)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	require.NotContains(t, buf.String(), mnemonic)
+	require.NotContains(t, buf.String(), "c2VjcmV0")
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	tr := rep.Tests[0]
+	require.Equal(t, `failed to restore key from "[REDACTED]"`, tr.Errors[0].Message)
+	require.Equal(t, `{[REDACTED]}`, tr.Metadata["config"])
+	require.Equal(t, []string{"rly", "keys", "restore", "[REDACTED]"}, tr.RelayerExecs[0].Command)
+
+	// The underlying test still sees the original message.
+	require.Contains(t, mt.Errors[0], mnemonic)
+}
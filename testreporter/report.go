@@ -0,0 +1,310 @@
+package testreporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Report is an aggregated view of the messages tracked by a Reporter,
+// with the messages for each test grouped together.
+//
+// The JSON stream written by a Reporter is the canonical record of a test run;
+// a Report is a convenience for consumers that need per-test results,
+// such as the alternative output formats.
+type Report struct {
+	// SchemaVersion of the messages the report was built from.
+	SchemaVersion int
+
+	// BuildInfo describes the environment of the test run, if it was recorded.
+	BuildInfo *BuildInfo
+
+	StartedAt, FinishedAt time.Time
+
+	// Tests in the order they began, including subtests.
+	Tests []*TestReport
+}
+
+// Roots returns the tests that do not have a tracked parent, in the order they began.
+// The remaining tests are reachable through the Subtests field of their parents.
+func (r *Report) Roots() []*TestReport {
+	var roots []*TestReport
+	for _, tr := range r.Tests {
+		if tr.Parent == "" {
+			roots = append(roots, tr)
+		}
+	}
+	return roots
+}
+
+// TestReport is the aggregated detail of a single tracked test.
+type TestReport struct {
+	Name   string
+	Labels LabelSet
+
+	// Parent is the name of the nearest tracked ancestor test, if any.
+	Parent string
+
+	// Subtests are the tracked tests whose Parent is this test, in the order they began.
+	Subtests []*TestReport
+
+	StartedAt, FinishedAt time.Time
+
+	// PausedAt and ContinuedAt are only set for tests using TrackParallel.
+	PausedAt, ContinuedAt time.Time
+
+	Failed, Skipped bool
+
+	// SkipReason is set when the test was skipped through TrackSkip or TrackSkipCategory.
+	SkipReason string
+
+	// SkipCategory is only set when the test was skipped through TrackSkipCategory.
+	SkipCategory SkipCategory
+
+	// Attempts are only set for tests using TrackRetry.
+	Attempts []TestAttempt
+
+	// Metadata tracked through TrackMetadata.
+	Metadata map[string]string
+
+	// Phases tracked through TrackPhase, in the order they finished.
+	Phases []TestPhaseMessage
+
+	// Attachments stored through Attach.
+	Attachments []TestAttachmentMessage
+
+	// BlockDatabasePath and BlockDatabaseTestCaseID locate the blocks and transactions
+	// recorded for the test, if the test used a block database.
+	BlockDatabasePath       string
+	BlockDatabaseTestCaseID int64
+
+	Errors []TestErrorMessage
+
+	// Logs recorded through a TestLogger.
+	Logs []TestLogMessage
+
+	RelayerExecs []RelayerExecMessage
+
+	// ContainerLogs are only collected for failed tests using TrackContainerLogs.
+	ContainerLogs []ContainerLogsMessage
+
+	// ContainerStats is only set for tests using TrackContainerStats.
+	ContainerStats *ContainerStatsMessage
+}
+
+// TestAttempt is the outcome of a single attempt of a test using TrackRetry.
+type TestAttempt struct {
+	Attempt   int
+	StartedAt time.Time
+
+	Failed bool
+
+	// Number of errors tracked during the attempt.
+	Errors int
+}
+
+// Flaky reports whether the test passed, but only after more than one attempt.
+func (t *TestReport) Flaky() bool {
+	return len(t.Attempts) > 1 && !t.Failed && !t.Skipped
+}
+
+// Duration is the time the test spent executing,
+// excluding any time spent waiting for parallel execution to resume.
+func (t *TestReport) Duration() time.Duration {
+	if t.FinishedAt.IsZero() {
+		return 0
+	}
+	d := t.FinishedAt.Sub(t.StartedAt)
+	if !t.PausedAt.IsZero() && !t.ContinuedAt.IsZero() {
+		d -= t.ContinuedAt.Sub(t.PausedAt)
+	}
+	return d
+}
+
+// Duration is the wall clock time between the start and finish of the suite.
+func (r *Report) Duration() time.Duration {
+	if r.FinishedAt.IsZero() {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// NewReport aggregates msgs into a Report.
+// Messages referring to tests that were never begun are ignored.
+func NewReport(msgs []Message) *Report {
+	var b reportBuilder
+	for _, m := range msgs {
+		b.Add(m)
+	}
+	return b.Report()
+}
+
+// Complete reports whether the report contains the trailing FinishSuiteMessage.
+// An incomplete report is typical when the test binary was killed before the Reporter was closed.
+func (r *Report) Complete() bool {
+	return !r.FinishedAt.IsZero()
+}
+
+// ReadReport decodes a stream of newline-delimited messages, as written by a Reporter, into a Report.
+//
+// If the stream ends in a truncated line, as happens when the test binary is killed mid-write,
+// the partial line is ignored and the report contains every message before it.
+func ReadReport(r io.Reader) (*Report, error) {
+	msgs, err := ReadMessages(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewReport(msgs), nil
+}
+
+// ReadMessages decodes a stream of newline-delimited messages, as written by a Reporter.
+// Like ReadReport, a truncated final line is ignored.
+func ReadMessages(r io.Reader) ([]Message, error) {
+	var msgs []Message
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read report: %w", err)
+		}
+		atEOF := err != nil
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var wm WrappedMessage
+			if decErr := json.Unmarshal(line, &wm); decErr != nil {
+				if atEOF {
+					// Partially written final line.
+					return msgs, nil
+				}
+				return nil, fmt.Errorf("failed to decode message on line %d: %w", lineNum, decErr)
+			}
+			msgs = append(msgs, wm.Message)
+		}
+
+		if atEOF {
+			return msgs, nil
+		}
+	}
+}
+
+// reportBuilder incrementally folds messages into a Report.
+// The zero value is ready to use.
+type reportBuilder struct {
+	rep    Report
+	byName map[string]*TestReport
+}
+
+// Add folds m into the report under construction.
+func (b *reportBuilder) Add(m Message) {
+	if b.byName == nil {
+		b.byName = make(map[string]*TestReport)
+	}
+
+	switch m := m.(type) {
+	case BeginSuiteMessage:
+		b.rep.SchemaVersion = m.SchemaVersion
+		b.rep.BuildInfo = m.BuildInfo
+		b.rep.StartedAt = m.StartedAt
+	case FinishSuiteMessage:
+		b.rep.FinishedAt = m.FinishedAt
+	case BeginTestMessage:
+		tr := &TestReport{
+			Name:      m.Name,
+			Labels:    m.Labels,
+			Parent:    m.Parent,
+			StartedAt: m.StartedAt,
+		}
+		b.byName[m.Name] = tr
+		b.rep.Tests = append(b.rep.Tests, tr)
+		if parent := b.byName[m.Parent]; parent != nil {
+			parent.Subtests = append(parent.Subtests, tr)
+		}
+	case FinishTestMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.FinishedAt = m.FinishedAt
+			tr.Failed = m.Failed
+			tr.Skipped = m.Skipped
+			if n := len(tr.Attempts); n > 0 {
+				tr.Attempts[n-1].Failed = m.Failed
+			}
+		}
+	case PauseTestMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.PausedAt = m.When
+		}
+	case ContinueTestMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.ContinuedAt = m.When
+		}
+	case TestErrorMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.Errors = append(tr.Errors, m)
+			if n := len(tr.Attempts); n > 0 {
+				tr.Attempts[n-1].Errors++
+			}
+		}
+	case TestLogMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.Logs = append(tr.Logs, m)
+		}
+	case TestMetadataMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			if tr.Metadata == nil {
+				tr.Metadata = make(map[string]string)
+			}
+			tr.Metadata[m.Key] = m.Value
+		}
+	case ContainerStatsMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.ContainerStats = &m
+		}
+	case BlockDatabaseMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.BlockDatabasePath = m.Path
+			tr.BlockDatabaseTestCaseID = m.TestCaseID
+		}
+	case TestAttachmentMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.Attachments = append(tr.Attachments, m)
+		}
+	case TestPhaseMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.Phases = append(tr.Phases, m)
+		}
+	case TestRetryMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			if len(tr.Attempts) == 0 && m.Attempt > 1 {
+				// The first attempt was not tracked, but it must have begun with the test.
+				tr.Attempts = append(tr.Attempts, TestAttempt{Attempt: 1, StartedAt: tr.StartedAt})
+			}
+			if n := len(tr.Attempts); n > 0 {
+				// Starting a new attempt implies that the previous attempt failed.
+				tr.Attempts[n-1].Failed = true
+			}
+			tr.Attempts = append(tr.Attempts, TestAttempt{Attempt: m.Attempt, StartedAt: m.When})
+		}
+	case TestSkipMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.SkipReason = m.Message
+			tr.SkipCategory = m.Category
+		}
+	case RelayerExecMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.RelayerExecs = append(tr.RelayerExecs, m)
+		}
+	case ContainerLogsMessage:
+		if tr := b.byName[m.Name]; tr != nil {
+			tr.ContainerLogs = append(tr.ContainerLogs, m)
+		}
+	}
+}
+
+// Report returns the report built so far.
+func (b *reportBuilder) Report() *Report {
+	rep := b.rep
+	return &rep
+}
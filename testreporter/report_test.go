@@ -0,0 +1,331 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/label"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReport(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	parallelDelay := 50 * time.Millisecond
+	mt := mocktesting.NewT("my_test")
+	mt.ParallelDelay = parallelDelay
+	r.TrackTest(mt)
+	r.TrackParallel(mt)
+	r.TestifyT(mt).Errorf("failed? %t", true)
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+
+	require.False(t, rep.StartedAt.IsZero())
+	require.False(t, rep.FinishedAt.IsZero())
+
+	require.Len(t, rep.Tests, 1)
+	tr := rep.Tests[0]
+	require.Equal(t, "my_test", tr.Name)
+	require.True(t, tr.Failed)
+	require.Len(t, tr.Errors, 1)
+	require.Equal(t, "failed? true", tr.Errors[0].Message)
+
+	// The time paused for parallel execution is excluded from the duration.
+	require.Less(t, tr.Duration(), tr.FinishedAt.Sub(tr.StartedAt))
+	require.GreaterOrEqual(t, tr.ContinuedAt.Sub(tr.PausedAt), parallelDelay)
+}
+
+func TestReadReport_Truncated(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	// Simulate the binary being killed partway through writing the final line.
+	full := buf.Bytes()
+	truncated := full[:len(full)-10]
+
+	rep, err := testreporter.ReadReport(bytes.NewReader(truncated))
+	require.NoError(t, err)
+	require.False(t, rep.Complete())
+	require.Len(t, rep.Tests, 1)
+	require.Equal(t, "my_test", rep.Tests[0].Name)
+
+	// Corruption anywhere other than the final line is still an error.
+	corrupt := append([]byte("{not json}\n"), full...)
+	_, err = testreporter.ReadReport(bytes.NewReader(corrupt))
+	require.Error(t, err)
+}
+
+func TestReport_Subtests(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	root := mocktesting.NewT("TestFoo")
+	r.TrackTest(root)
+
+	child := mocktesting.NewT("TestFoo/child")
+	r.TrackTest(child)
+
+	grandchild := mocktesting.NewT("TestFoo/child/grandchild")
+	r.TrackTest(grandchild)
+
+	// The intermediate test is not tracked, so the nearest tracked ancestor is the root.
+	orphan := mocktesting.NewT("TestFoo/untracked/orphan")
+	r.TrackTest(orphan)
+
+	other := mocktesting.NewT("TestBar")
+	r.TrackTest(other)
+
+	for _, mt := range []*mocktesting.T{grandchild, child, orphan, root, other} {
+		mt.RunCleanups()
+	}
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+
+	roots := rep.Roots()
+	require.Len(t, roots, 2)
+	require.Equal(t, "TestFoo", roots[0].Name)
+	require.Equal(t, "TestBar", roots[1].Name)
+
+	require.Len(t, roots[0].Subtests, 2)
+	require.Equal(t, "TestFoo/child", roots[0].Subtests[0].Name)
+	require.Equal(t, "TestFoo/untracked/orphan", roots[0].Subtests[1].Name)
+	require.Equal(t, "TestFoo", roots[0].Subtests[1].Parent)
+
+	require.Len(t, roots[0].Subtests[0].Subtests, 1)
+	require.Equal(t, "TestFoo/child/grandchild", roots[0].Subtests[0].Subtests[0].Name)
+	require.Equal(t, "TestFoo/child", roots[0].Subtests[0].Subtests[0].Parent)
+}
+
+func TestReport_Retries(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	// Errorf marks the mock test as failed, so the final attempt of this test fails too.
+	flaky := mocktesting.NewT("flaky")
+	r.TrackTest(flaky)
+	r.TrackRetry(flaky, 1)
+	r.TestifyT(flaky).Errorf("attempt 1 failed")
+	r.TrackRetry(flaky, 2)
+	r.TestifyT(flaky).Errorf("attempt 2 failed")
+	r.TrackRetry(flaky, 3)
+
+	flakyPassed := mocktesting.NewT("flaky_passed")
+	r.TrackTest(flakyPassed)
+	r.TrackRetry(flakyPassed, 2)
+
+	flaky.RunCleanups()
+	flakyPassed.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 2)
+
+	tr := rep.Tests[0]
+	require.Len(t, tr.Attempts, 3)
+	require.Equal(t, []int{1, 1, 0}, []int{tr.Attempts[0].Errors, tr.Attempts[1].Errors, tr.Attempts[2].Errors})
+	require.True(t, tr.Attempts[0].Failed)
+	require.True(t, tr.Attempts[1].Failed)
+	require.True(t, tr.Attempts[2].Failed)
+	require.False(t, tr.Flaky())
+
+	// Starting at attempt 2 implies an untracked, failed first attempt.
+	tr = rep.Tests[1]
+	require.Len(t, tr.Attempts, 2)
+	require.Equal(t, 1, tr.Attempts[0].Attempt)
+	require.True(t, tr.Attempts[0].Failed)
+	require.Equal(t, 2, tr.Attempts[1].Attempt)
+	require.False(t, tr.Attempts[1].Failed)
+	require.True(t, tr.Flaky())
+}
+
+func TestReport_Metadata(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	r.TrackMetadata(mt, "gaia_version", "v7.0.0")
+	r.TrackMetadata(mt, "relayer", "rly")
+	r.TrackMetadata(mt, "gaia_version", "v7.0.1")
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Equal(t, map[string]string{
+		"gaia_version": "v7.0.1",
+		"relayer":      "rly",
+	}, rep.Tests[0].Metadata)
+}
+
+func TestReport_Phases(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	beforePhase := time.Now()
+	done := r.TrackPhase(mt, "start-chains")
+	time.Sleep(10 * time.Millisecond)
+	done()
+	afterPhase := time.Now()
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Len(t, rep.Tests[0].Phases, 1)
+
+	phase := rep.Tests[0].Phases[0]
+	require.Equal(t, "my_test", phase.Name)
+	require.Equal(t, "start-chains", phase.Phase)
+	requireTimeInRange(t, phase.StartedAt, beforePhase, afterPhase)
+	requireTimeInRange(t, phase.FinishedAt, phase.StartedAt.Add(10*time.Millisecond), afterPhase)
+}
+
+func TestReport_Summary(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	rep := testreporter.NewReport([]testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: start},
+
+		testreporter.BeginTestMessage{
+			Name: "TestA", StartedAt: start,
+			Labels: testreporter.LabelSet{Chain: []label.Chain{label.Gaia, label.Osmosis}},
+		},
+		testreporter.BeginTestMessage{Name: "TestA/sub", StartedAt: start, Parent: "TestA"},
+		testreporter.FinishTestMessage{Name: "TestA/sub", FinishedAt: start.Add(time.Second), Failed: true},
+		testreporter.FinishTestMessage{Name: "TestA", FinishedAt: start.Add(2 * time.Second), Failed: true},
+
+		testreporter.BeginTestMessage{Name: "TestB", StartedAt: start},
+		testreporter.FinishTestMessage{Name: "TestB", FinishedAt: start.Add(3 * time.Second)},
+
+		testreporter.FinishSuiteMessage{FinishedAt: start.Add(3 * time.Second)},
+	})
+
+	s := rep.Summary()
+	require.Equal(t, 3, s.Total)
+	require.Equal(t, 1, s.Passed)
+	require.Equal(t, 2, s.Failed)
+	require.Equal(t, []string{"TestA", "TestA/sub"}, s.FailedTests)
+	require.Equal(t, 3*time.Second, s.WallClock())
+	require.Equal(t, 5*time.Second, s.SummedTestTime)
+	require.Equal(t, []testreporter.TestDuration{
+		{Name: "TestB", Duration: 3 * time.Second},
+		{Name: "TestA", Duration: 2 * time.Second},
+		{Name: "TestA/sub", Duration: time.Second},
+	}, s.Slowest)
+	require.Equal(t, map[string]int{"gaia+osmosis": 2}, s.FailuresByChains)
+}
+
+func TestReport_BlockDatabase(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	r.RelayerExecReporter(mt).TrackBlockDatabase("/tmp/blocks.db", 42)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Equal(t, "/tmp/blocks.db", rep.Tests[0].BlockDatabasePath)
+	require.Equal(t, int64(42), rep.Tests[0].BlockDatabaseTestCaseID)
+}
+
+func TestReport_ContainerStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	rep := testreporter.NewReport([]testreporter.Message{
+		testreporter.BeginTestMessage{Name: "my_test", StartedAt: now},
+		testreporter.ContainerStatsMessage{Name: "my_test", When: now, Containers: 2, CPUSeconds: 3.5, PeakMemoryBytes: 1 << 30},
+		testreporter.FinishTestMessage{Name: "my_test", FinishedAt: now},
+	})
+
+	require.Len(t, rep.Tests, 1)
+	stats := rep.Tests[0].ContainerStats
+	require.NotNil(t, stats)
+	require.Equal(t, 2, stats.Containers)
+	require.Equal(t, 3.5, stats.CPUSeconds)
+	require.Equal(t, int64(1<<30), stats.PeakMemoryBytes)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, testreporter.WriteHTML(buf, rep))
+	require.Contains(t, buf.String(), "2 containers used 3.5 CPU seconds, 1.074GB peak memory")
+}
+
+func TestReport_SkipCategory(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	for _, name := range []string{"unsupported_1", "unsupported_2", "no_docker", "plain"} {
+		mt := mocktesting.NewT(name)
+		mt.Simulate(func() {
+			r.TrackTest(mt)
+			switch name {
+			case "no_docker":
+				r.TrackSkipCategory(mt, testreporter.SkipMissingEnvironment, "docker unavailable")
+			case "plain":
+				r.TrackSkip(mt, "no reason")
+			default:
+				r.TrackSkipCategory(mt, testreporter.SkipUnsupportedRelayer, "missing capabilities")
+			}
+		})
+		require.True(t, mt.Skipped())
+	}
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 4)
+	require.Equal(t, testreporter.SkipUnsupportedRelayer, rep.Tests[0].SkipCategory)
+	require.Equal(t, testreporter.SkipMissingEnvironment, rep.Tests[2].SkipCategory)
+	require.Empty(t, rep.Tests[3].SkipCategory)
+	require.Equal(t, "no reason", rep.Tests[3].SkipReason)
+
+	s := rep.Summary()
+	require.Equal(t, 4, s.Skipped)
+	require.Equal(t, map[testreporter.SkipCategory]int{
+		testreporter.SkipUnsupportedRelayer: 2,
+		testreporter.SkipMissingEnvironment: 1,
+	}, s.SkippedByCategory)
+}
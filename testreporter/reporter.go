@@ -1,12 +1,17 @@
 package testreporter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/strangelove-ventures/interchaintest/v7/label"
+	"go.uber.org/multierr"
 )
 
 // T is a subset of testing.TB,
@@ -29,18 +34,104 @@ type Reporter struct {
 	in chan Message
 
 	writerDone chan error
+
+	// Names of tests that have begun, to link subtests to their parents.
+	trackedMu sync.Mutex
+	tracked   map[string]bool
+
+	// Aggregated messages, only modified from the write goroutine.
+	// builderMu guards reads of the in-progress report from other goroutines.
+	builderMu sync.Mutex
+	builder   reportBuilder
+
+	// Functions to call with the final report when the Reporter is closed.
+	reportSinks []func(*Report) error
+
+	// Directory for attachments too large to store inline.
+	artifactsDir string
+
+	// Whether TestLoggers only record messages of failed tests.
+	failedLogsOnly bool
+
+	// Masks secrets in messages before they are written.
+	redactor redactor
+
+	// Functions to release resources, such as status servers, when the Reporter is closed.
+	closersMu sync.Mutex
+	closers   []func() error
 }
 
-func NewReporter(w io.WriteCloser) *Reporter {
+// NewReporter returns a Reporter that writes a stream of JSON messages to w.
+// Any provided options may configure additional outputs.
+func NewReporter(w io.WriteCloser, opts ...ReporterOption) *Reporter {
 	r := &Reporter{
 		w: w,
 
 		in:         make(chan Message, 256), // Arbitrary size that seems unlikely to be filled.
 		writerDone: make(chan error, 1),
+
+		tracked: make(map[string]bool),
+	}
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case ReporterOptionJUnit:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				return writeAndClose(o.W, rep, WriteJUnit)
+			})
+		case ReporterOptionHTML:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				return writeAndClose(o.W, rep, WriteHTML)
+			})
+		case ReporterOptionTAP:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				return writeAndClose(o.W, rep, WriteTAP)
+			})
+		case ReporterOptionGitHubAnnotations:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				return writeAndClose(o.W, rep, WriteGitHubAnnotations)
+			})
+		case ReporterOptionPushGateway:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				return PushMetrics(o.URL, o.Job, rep)
+			})
+		case ReporterOptionOTLP:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				return ExportTraces(ctx, o.Endpoint, rep)
+			})
+		case reporterOptionCollect:
+			r.reportSinks = append(r.reportSinks, func(*Report) error {
+				return writeCollected(o.dir)
+			})
+		case ReporterOptionRedact:
+			r.redactor.patterns = append(r.redactor.patterns, o.Patterns...)
+		case ReporterOptionFailedTestLogsOnly:
+			r.failedLogsOnly = true
+		case ReporterOptionArtifactsDir:
+			r.artifactsDir = o.Dir
+		case ReporterOptionNotify:
+			r.reportSinks = append(r.reportSinks, func(rep *Report) error {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				return o.Notifier.Notify(ctx, rep.Summary())
+			})
+		}
 	}
 
-	go r.write()
-	r.in <- BeginSuiteMessage{StartedAt: time.Now()}
+	// Collecting the build info waits for the docker daemon's version,
+	// so it is collected concurrently rather than delaying the start of the tests.
+	startedAt := time.Now()
+	begin := make(chan Message, 1)
+	go func() {
+		begin <- BeginSuiteMessage{
+			StartedAt:     startedAt,
+			SchemaVersion: SchemaVersion,
+			BuildInfo:     collectBuildInfo(),
+		}
+	}()
+	go r.write(begin)
 
 	return r
 }
@@ -48,27 +139,112 @@ func NewReporter(w io.WriteCloser) *Reporter {
 // write runs in its own goroutine to continually output reporting messages.
 // Allowing all writes to happen in a single goroutine avoids any lock contention
 // that could happen with a mutex guarding concurrent writes to the io.Writer.
-func (r *Reporter) write() {
+//
+// The BeginSuiteMessage received from begin is written first,
+// ahead of any messages sent to r.in while it was being built.
+func (r *Reporter) write(begin <-chan Message) {
 	enc := json.NewEncoder(r.w)
 	enc.SetEscapeHTML(false)
 
+	r.record(enc, <-begin)
+
 	for m := range r.in {
-		if err := enc.Encode(JSONMessage(m)); err != nil {
-			panic(fmt.Errorf("reporter failed to encode message; tests cannot continue: %w", err))
+		if f, ok := m.(flushMessage); ok {
+			// Best effort to get the data to stable storage;
+			// writers such as pipes do not support syncing.
+			if s, ok := r.w.(syncer); ok {
+				_ = s.Sync()
+			}
+			close(f.done)
+			continue
+		}
+
+		if fin, ok := m.(FinishSuiteMessage); ok {
+			// Precede the FinishSuite trailer with the aggregate statistics of the run.
+			rep := r.builder.Report()
+			rep.FinishedAt = fin.FinishedAt
+			r.encode(enc, SummaryMessage{Summary: rep.Summary()})
 		}
+
+		r.record(enc, m)
 	}
 
 	r.writerDone <- r.w.Close()
 }
 
+// record adds the redacted m to the in-progress report, and writes it.
+func (r *Reporter) record(enc *json.Encoder, m Message) {
+	m = r.redactor.redactMessage(m)
+	r.builderMu.Lock()
+	r.builder.Add(m)
+	r.builderMu.Unlock()
+	r.encode(enc, m)
+}
+
+func (r *Reporter) encode(enc *json.Encoder, m Message) {
+	if err := enc.Encode(JSONMessage(m)); err != nil {
+		panic(fmt.Errorf("reporter failed to encode message; tests cannot continue: %w", err))
+	}
+}
+
+// syncer is satisfied by *os.File.
+type syncer interface {
+	Sync() error
+}
+
+// flushMessage is an internal message that is never written to the report.
+// The write goroutine closes done once all previously sent messages are written,
+// and the underlying writer has been synced if possible.
+type flushMessage struct {
+	done chan struct{}
+}
+
+func (flushMessage) typ() string {
+	return "flush"
+}
+
+// flush blocks until all messages previously sent to r are written.
+func (r *Reporter) flush() {
+	done := make(chan struct{})
+	r.in <- flushMessage{done: done}
+	<-done
+}
+
 // Close closes the reporter and blocks until its results are flushed
 // to the underlying writer.
+// The last messages written are a SummaryMessage and a FinishSuiteMessage,
+// so a report without that trailer indicates an interrupted test run.
 func (r *Reporter) Close() error {
 	r.in <- FinishSuiteMessage{
 		FinishedAt: time.Now(),
 	}
 	close(r.in)
-	return <-r.writerDone
+	err := <-r.writerDone
+
+	if len(r.reportSinks) > 0 {
+		rep := r.builder.Report()
+		for _, sink := range r.reportSinks {
+			err = multierr.Append(err, sink(rep))
+		}
+	}
+
+	r.closersMu.Lock()
+	defer r.closersMu.Unlock()
+	for _, c := range r.closers {
+		err = multierr.Append(err, c())
+	}
+
+	return err
+}
+
+// writeAndClose calls writeFn with w and rep,
+// and then closes w if it is an io.Closer.
+func writeAndClose(w io.Writer, rep *Report, writeFn func(io.Writer, *Report) error) error {
+	err := writeFn(w, rep)
+	if c, ok := w.(io.Closer); ok {
+		err = multierr.Append(err, c.Close())
+	}
+	return err
 }
 
 // TrackParameters is intended to be called from the outermost layer of tests.
@@ -108,6 +284,7 @@ func (r *Reporter) trackTest(t T, labels LabelSet) {
 		Name:      name,
 		StartedAt: time.Now(),
 		Labels:    labels,
+		Parent:    r.trackParent(name),
 	}
 	t.Cleanup(func() {
 		r.in <- FinishTestMessage{
@@ -117,9 +294,36 @@ func (r *Reporter) trackTest(t T, labels LabelSet) {
 			Failed:  t.Failed(),
 			Skipped: t.Skipped(),
 		}
+
+		// Ensure the test result is persisted as soon as the test finishes,
+		// so that a crash of the test binary still leaves a usable report.
+		r.flush()
 	})
 }
 
+// trackParent records name as a tracked test,
+// and returns the name of its nearest tracked ancestor, if any.
+//
+// Subtest names created through t.Run are their parent's name followed by a slash,
+// so the ancestors are found by trimming trailing path segments.
+func (r *Reporter) trackParent(name string) string {
+	r.trackedMu.Lock()
+	defer r.trackedMu.Unlock()
+
+	r.tracked[name] = true
+
+	for parent := name; ; {
+		i := strings.LastIndexByte(parent, '/')
+		if i < 0 {
+			return ""
+		}
+		parent = parent[:i]
+		if r.tracked[parent] {
+			return parent
+		}
+	}
+}
+
 // TrackParallel tracks when the pause begins for a parallel test
 // and when it continues to resume.
 func (r *Reporter) TrackParallel(t T) {
@@ -138,18 +342,77 @@ func (r *Reporter) TrackParallel(t T) {
 // TrackSkip records a the reason for a test being skipped,
 // and calls t.Skip.
 func (r *Reporter) TrackSkip(t T, format string, args ...any) {
+	r.TrackSkipCategory(t, "", format, args...)
+}
+
+// TrackSkipCategory is like TrackSkip, but also records the category of the skip,
+// so that reports can distinguish intentionally unsupported combinations
+// from skips caused by a missing environment.
+// See the SkipCategory constants for the categories in use.
+func (r *Reporter) TrackSkipCategory(t T, category SkipCategory, format string, args ...any) {
 	now := time.Now()
 	msg := fmt.Sprintf(format, args...)
 
 	r.in <- TestSkipMessage{
-		Name:    t.Name(),
-		When:    now,
-		Message: msg,
+		Name:     t.Name(),
+		When:     now,
+		Message:  msg,
+		Category: category,
 	}
 
 	t.Skip(msg)
 }
 
+// TrackRetry records that attempt number attempt of t is beginning.
+// Attempts are numbered starting at 1.
+//
+// Retry wrappers should call TrackRetry at the start of every attempt,
+// so that the report shows how many attempts the test took and the outcome of each;
+// a test that passes after more than one attempt is reported as flaky.
+// Starting a new attempt implies that the previous attempt failed.
+func (r *Reporter) TrackRetry(t T, attempt int) {
+	r.in <- TestRetryMessage{
+		Name:    t.Name(),
+		When:    time.Now(),
+		Attempt: attempt,
+	}
+}
+
+// TrackMetadata records an arbitrary key-value pair associated with t,
+// such as a chain version, relayer implementation, docker image tag, or commit SHA.
+// If the same key is tracked more than once for t, the report uses the last value.
+func (r *Reporter) TrackMetadata(t T, key, value string) {
+	r.in <- TestMetadataMessage{
+		Name:  t.Name(),
+		When:  time.Now(),
+		Key:   key,
+		Value: value,
+	}
+}
+
+// TrackPhase begins timing a named section of t, such as pulling images or starting chains.
+// Call the returned function when the phase is complete:
+//
+//	done := reporter.TrackPhase(t, "start-chains")
+//	// Start the chains...
+//	done()
+//
+// Or, to time the remainder of the current function:
+//
+//	defer reporter.TrackPhase(t, "transfer")()
+func (r *Reporter) TrackPhase(t T, phase string) func() {
+	name := t.Name()
+	startedAt := time.Now()
+	return func() {
+		r.in <- TestPhaseMessage{
+			Name:       name,
+			Phase:      phase,
+			StartedAt:  startedAt,
+			FinishedAt: time.Now(),
+		}
+	}
+}
+
 // RelayerExecReporter returns a RelayerExecReporter associated with t.
 func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 	return &RelayerExecReporter{r: r, testName: t.Name()}
@@ -162,7 +425,39 @@ type RelayerExecReporter struct {
 	testName string
 }
 
+// TrackBlockDatabase records that the blocks and transactions of r's test
+// are saved in the sqlite database at path, under the given test case ID.
+func (r *RelayerExecReporter) TrackBlockDatabase(path string, testCaseID int64) {
+	r.r.in <- BlockDatabaseMessage{
+		Name:       r.testName,
+		When:       time.Now(),
+		Path:       path,
+		TestCaseID: testCaseID,
+	}
+}
+
+// maxRelayerExecOutput is the maximum number of bytes of stdout or stderr
+// retained for a single relayer command.
+const maxRelayerExecOutput = 16 * 1024
+
+// trimOutput trims surrounding whitespace from out,
+// and truncates it to at most the last maxRelayerExecOutput bytes,
+// as the end of the output is most likely to contain the cause of a failure.
+func trimOutput(out string) string {
+	out = strings.TrimSpace(out)
+	if len(out) <= maxRelayerExecOutput {
+		return out
+	}
+	// Cut on a rune boundary, so that a multi-byte character is not split.
+	cut := len(out) - maxRelayerExecOutput
+	for cut < len(out) && !utf8.RuneStart(out[cut]) {
+		cut++
+	}
+	return fmt.Sprintf("[truncated %d bytes]\n%s", cut, out[cut:])
+}
+
 // TrackRelayerExec tracks the execution of an individual relayer command.
+// Stdout and stderr are trimmed, and truncated if they are very long.
 func (r *RelayerExecReporter) TrackRelayerExec(
 	containerName string,
 	command []string,
@@ -181,8 +476,8 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 		FinishedAt:    finishedAt,
 		ContainerName: containerName,
 		Command:       command,
-		Stdout:        stdout,
-		Stderr:        stderr,
+		Stdout:        trimOutput(stdout),
+		Stderr:        trimOutput(stderr),
 		ExitCode:      exitCode,
 		Error:         errMsg,
 	}
@@ -213,15 +508,21 @@ type TestifyReporter struct {
 	t TestifyT
 }
 
-// Errorf records the error message in r's Reporter
-// and then passes through to r's underlying TestifyT.
+// Errorf records the error message, along with the call site of the failed assertion,
+// in r's Reporter and then passes through to r's underlying TestifyT.
 func (r *TestifyReporter) Errorf(format string, args ...any) {
 	now := time.Now()
+	cs := assertionCallSite()
 
 	r.r.in <- TestErrorMessage{
 		Name:    r.t.Name(),
 		Message: fmt.Sprintf(format, args...),
 		When:    now,
+
+		File:  cs.File,
+		Line:  cs.Line,
+		Stack: cs.Stack,
+		Fatal: cs.Fatal,
 	}
 
 	r.t.Errorf(format, args...)
@@ -234,6 +535,13 @@ func (r *TestifyReporter) FailNow() {
 	r.t.FailNow()
 }
 
+// NewJUnitReporter returns a Reporter that writes only a JUnit XML report to w,
+// instead of the default stream of JSON messages.
+// The report is written and w is closed when the Reporter is closed.
+func NewJUnitReporter(w io.WriteCloser) *Reporter {
+	return NewReporter(newNopWriteCloser(), JUnitOutput(w))
+}
+
 // NewNopReporter returns a reporter that does not write anywhere.
 func NewNopReporter() *Reporter {
 	return NewReporter(newNopWriteCloser())
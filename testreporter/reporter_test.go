@@ -3,14 +3,18 @@ package testreporter_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
 	"github.com/strangelove-ventures/interchaintest/v7/label"
 	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -71,7 +75,7 @@ func TestReporter_TrackPassingSingleTest(t *testing.T) {
 	afterFinishSuite := time.Now()
 
 	msgs := ReporterMessages(t, buf)
-	require.Len(t, msgs, 4)
+	require.Len(t, msgs, 5)
 
 	beginSuiteMsg := msgs[0].(testreporter.BeginSuiteMessage)
 	requireTimeInRange(t, beginSuiteMsg.StartedAt, beforeStartSuite, afterStartSuite)
@@ -87,8 +91,15 @@ func TestReporter_TrackPassingSingleTest(t *testing.T) {
 	require.False(t, finishTestMsg.Skipped)
 	requireTimeInRange(t, finishTestMsg.FinishedAt, beforeFinishTest, afterFinishTest)
 
-	finishSuiteMsg := msgs[3].(testreporter.FinishSuiteMessage)
+	summaryMsg := msgs[3].(testreporter.SummaryMessage)
+	require.Equal(t, 1, summaryMsg.Total)
+	require.Equal(t, 1, summaryMsg.Passed)
+	require.Len(t, summaryMsg.Slowest, 1)
+	require.Equal(t, "my_test", summaryMsg.Slowest[0].Name)
+
+	finishSuiteMsg := msgs[4].(testreporter.FinishSuiteMessage)
 	requireTimeInRange(t, finishSuiteMsg.FinishedAt, beforeFinishSuite, afterFinishSuite)
+	require.Equal(t, finishSuiteMsg.FinishedAt, summaryMsg.FinishedAt)
 }
 
 func TestReporter_TrackFailingSingleTest(t *testing.T) {
@@ -115,13 +126,18 @@ func TestReporter_TrackFailingSingleTest(t *testing.T) {
 	require.NoError(t, r.Close())
 
 	msgs := ReporterMessages(t, buf)
-	require.Len(t, msgs, 5)
+	require.Len(t, msgs, 6)
 
 	testErrorMsg := msgs[2].(testreporter.TestErrorMessage)
 	require.Equal(t, testErrorMsg.Name, "my_test")
 	// require.Fail adds some detail to the error message that complicates a plain string equality check.
 	require.Contains(t, testErrorMsg.Message, "forced failure")
 	requireTimeInRange(t, testErrorMsg.When, beforeFailure, afterFailure)
+	require.True(t, testErrorMsg.Fatal)
+	require.True(t, strings.HasSuffix(testErrorMsg.File, "reporter_test.go"), testErrorMsg.File)
+	require.NotZero(t, testErrorMsg.Line)
+	require.Contains(t, testErrorMsg.Stack, "TestReporter_TrackFailingSingleTest")
+	require.NotContains(t, testErrorMsg.Stack, "stretchr/testify")
 
 	finishTestMsg := msgs[3].(testreporter.FinishTestMessage)
 	require.Equal(t, finishTestMsg.Name, "my_test")
@@ -129,6 +145,32 @@ func TestReporter_TrackFailingSingleTest(t *testing.T) {
 	require.False(t, finishTestMsg.Skipped)
 }
 
+// Check that a test's result is written as soon as the test finishes,
+// without waiting for the Reporter to be closed.
+func TestReporter_FlushesFinishedTest(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	mt.RunCleanups()
+
+	// Not yet closed, so the report is incomplete, but the finished test is present.
+	rep, err := testreporter.ReadReport(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.False(t, rep.Complete())
+	require.Len(t, rep.Tests, 1)
+	require.False(t, rep.Tests[0].FinishedAt.IsZero())
+
+	require.NoError(t, r.Close())
+
+	rep, err = testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.True(t, rep.Complete())
+}
+
 // Check that TrackParallel logs the pause and continue messages.
 func TestReporter_TrackParallel(t *testing.T) {
 	t.Parallel()
@@ -150,7 +192,7 @@ func TestReporter_TrackParallel(t *testing.T) {
 	require.NoError(t, r.Close())
 
 	msgs := ReporterMessages(t, buf)
-	require.Len(t, msgs, 6)
+	require.Len(t, msgs, 7)
 
 	beginTestMsg := msgs[1].(testreporter.BeginTestMessage)
 	require.Equal(t, beginTestMsg.Name, "my_test")
@@ -188,7 +230,7 @@ func TestReporter_TrackSkip(t *testing.T) {
 	require.NoError(t, r.Close())
 
 	msgs := ReporterMessages(t, buf)
-	require.Len(t, msgs, 5)
+	require.Len(t, msgs, 6)
 
 	testSkipMsg := msgs[2].(testreporter.TestSkipMessage)
 	require.Equal(t, testSkipMsg.Name, "my_test")
@@ -219,6 +261,24 @@ func TestReporter_Errorf(t *testing.T) {
 	require.Equal(t, mt.Errors, []string{"failed? true"})
 }
 
+func TestReporter_NonFatalAssertion(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	assert.Equal(r.TestifyT(mt), 1, 2)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	testErrorMsg := msgs[2].(testreporter.TestErrorMessage)
+	require.False(t, testErrorMsg.Fatal)
+	require.True(t, strings.HasSuffix(testErrorMsg.File, "reporter_test.go"), testErrorMsg.File)
+}
+
 func TestReporter_RelayerExec(t *testing.T) {
 	t.Parallel()
 
@@ -245,7 +305,7 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.NoError(t, r.Close())
 
 	msgs := ReporterMessages(t, buf)
-	require.Len(t, msgs, 5)
+	require.Len(t, msgs, 6)
 
 	diff := cmp.Diff(testreporter.RelayerExecMessage{
 		Name:          "my_test",
@@ -261,6 +321,72 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_RelayerExecTruncatesOutput(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	longStdout := strings.Repeat("a", 20*1024) + "the end"
+	r.RelayerExecReporter(mt).TrackRelayerExec(
+		"my_container",
+		[]string{"rly", "tx", "link"},
+		longStdout, "  error: failed\n",
+		1,
+		time.Now(), time.Now(),
+		errors.New("exit code 1"),
+	)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Len(t, rep.Tests[0].RelayerExecs, 1)
+
+	exec := rep.Tests[0].RelayerExecs[0]
+	require.Less(t, len(exec.Stdout), len(longStdout))
+	require.True(t, strings.HasPrefix(exec.Stdout, "[truncated "))
+	require.True(t, strings.HasSuffix(exec.Stdout, "the end"))
+	require.Equal(t, "error: failed", exec.Stderr)
+	require.Equal(t, "exit code 1", exec.Error)
+}
+
+func TestReporter_RelayerExecTruncatesOutput_MultiByte(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+
+	// 18001 bytes, whose last 16 KiB start within a 3 byte rune.
+	longStdout := "x" + strings.Repeat("世", 6000)
+	r.RelayerExecReporter(mt).TrackRelayerExec(
+		"my_container",
+		[]string{"hermes", "create", "channel"},
+		longStdout, "",
+		0,
+		time.Now(), time.Now(),
+		nil,
+	)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := testreporter.ReadReport(buf)
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 1)
+	require.Len(t, rep.Tests[0].RelayerExecs, 1)
+
+	exec := rep.Tests[0].RelayerExecs[0]
+	require.True(t, utf8.ValidString(exec.Stdout))
+	require.Equal(t, "[truncated 1618 bytes]\n"+strings.Repeat("世", 5461), exec.Stdout)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
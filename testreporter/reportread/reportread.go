@@ -0,0 +1,462 @@
+// Package reportread parses the JSON reports written by a testreporter.Reporter.
+//
+// The package only depends on the standard library,
+// so that tools consuming reports do not need to depend on interchaintest's
+// docker, chain, and relayer dependencies.
+//
+// The types in this package mirror the report format at SupportedSchemaVersion.
+// Message types and fields unknown to this package are ignored,
+// so newer reports of the same schema version remain readable.
+package reportread
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SupportedSchemaVersion is the newest report schema version that Parse understands.
+// It corresponds to testreporter.SchemaVersion.
+const SupportedSchemaVersion = 1
+
+// Report is the aggregated result of a test run.
+type Report struct {
+	// SchemaVersion of the parsed report.
+	// It is zero for reports written before the format was versioned.
+	SchemaVersion int
+
+	// BuildInfo describes the environment of the run.
+	// It is nil for reports that predate build info.
+	BuildInfo *BuildInfo
+
+	StartedAt, FinishedAt time.Time
+
+	// Summary is the aggregate statistics written at the end of the run.
+	// It is nil if the run did not finish, or if the report predates summaries.
+	Summary *Summary
+
+	// Tests in the order they began, including subtests.
+	Tests []*Test
+}
+
+// Complete reports whether the run finished, as opposed to the test binary being killed.
+func (r *Report) Complete() bool {
+	return !r.FinishedAt.IsZero()
+}
+
+// BuildInfo describes the environment of a test run.
+type BuildInfo struct {
+	GoVersion             string
+	InterchaintestVersion string
+
+	VCSRevision string
+	VCSModified bool
+
+	DockerVersion string
+	DockerError   string
+
+	OS, Arch string
+
+	Env map[string]string
+}
+
+// Labels are the labels associated with a test.
+type Labels struct {
+	Relayer []string
+	Chain   []string
+	Test    []string
+}
+
+// Test is the result of a single test.
+type Test struct {
+	Name   string
+	Labels Labels
+
+	// Parent is the name of the nearest tracked ancestor test, if any.
+	Parent string
+
+	StartedAt, FinishedAt time.Time
+
+	// PausedAt and ContinuedAt are only set for parallel tests.
+	PausedAt, ContinuedAt time.Time
+
+	Failed, Skipped bool
+	SkipReason      string
+	SkipCategory    string
+
+	// Attempts are only set for tests using TrackRetry.
+	Attempts []Attempt
+
+	Metadata map[string]string
+
+	Errors        []Error
+	Logs          []Log
+	Phases        []Phase
+	Attachments   []Attachment
+	RelayerExecs  []RelayerExec
+	ContainerLogs []ContainerLogs
+
+	ContainerStats *ContainerStats
+
+	BlockDatabasePath       string
+	BlockDatabaseTestCaseID int64
+}
+
+// Duration is the time the test spent executing,
+// excluding any time spent waiting for parallel execution to resume.
+func (t *Test) Duration() time.Duration {
+	if t.FinishedAt.IsZero() {
+		return 0
+	}
+	d := t.FinishedAt.Sub(t.StartedAt)
+	if !t.PausedAt.IsZero() && !t.ContinuedAt.IsZero() {
+		d -= t.ContinuedAt.Sub(t.PausedAt)
+	}
+	return d
+}
+
+// Flaky reports whether the test passed, but only after more than one attempt.
+func (t *Test) Flaky() bool {
+	return len(t.Attempts) > 1 && !t.Failed && !t.Skipped
+}
+
+// Attempt is the outcome of a single attempt of a test using TrackRetry.
+type Attempt struct {
+	Attempt   int
+	StartedAt time.Time
+
+	Failed bool
+
+	// Number of errors tracked during the attempt.
+	Errors int
+}
+
+// Error is a failed assertion.
+type Error struct {
+	When    time.Time
+	Message string
+
+	File  string
+	Line  int
+	Stack string
+
+	// Fatal is set for failures that stopped the test.
+	Fatal bool
+}
+
+// Log is a log message of a test.
+type Log struct {
+	When    time.Time
+	Message string
+}
+
+// Phase is a timed section of a test.
+type Phase struct {
+	Phase                 string
+	StartedAt, FinishedAt time.Time
+}
+
+// Attachment is a file attached to a test.
+// Small attachments are stored inline in Data; larger attachments are stored at Path.
+type Attachment struct {
+	When       time.Time
+	Attachment string
+	Size       int64
+	Data       []byte
+	Path       string
+}
+
+// RelayerExec is a relayer command executed by a test.
+type RelayerExec struct {
+	StartedAt, FinishedAt time.Time
+
+	ContainerName string
+	Command       []string
+
+	Stdout, Stderr string
+
+	ExitCode int
+	Error    string
+}
+
+// ContainerLogs are the logs of a container of a failed test.
+type ContainerLogs struct {
+	When          time.Time
+	ContainerID   string
+	ContainerName string
+	Image         string
+	Logs          string
+	Error         string
+}
+
+// ContainerStats is the total resource usage of a test's containers.
+type ContainerStats struct {
+	Containers      int
+	CPUSeconds      float64
+	PeakMemoryBytes int64
+	VolumeBytes     int64
+	Error           string
+}
+
+// Summary is the aggregate statistics of a test run.
+type Summary struct {
+	Total, Passed, Failed, Skipped int
+
+	FailedTests []string
+
+	SummedTestTime time.Duration
+
+	Slowest []TestDuration
+
+	FailuresByChains map[string]int
+
+	SkippedByCategory map[string]int
+}
+
+// TestDuration is the duration of a single test.
+type TestDuration struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Parse reads a report, as written by a testreporter.Reporter.
+//
+// If the report ends in a truncated line, as happens when the test binary is killed mid-write,
+// the partial line is ignored.
+// Parse returns an error for reports newer than SupportedSchemaVersion.
+func Parse(r io.Reader) (*Report, error) {
+	p := parser{
+		rep:    new(Report),
+		byName: make(map[string]*Test),
+	}
+
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read report: %w", err)
+		}
+		atEOF := err != nil
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var outer struct {
+				Type    string
+				Message json.RawMessage
+			}
+			decErr := json.Unmarshal(line, &outer)
+			if decErr == nil {
+				decErr = p.add(outer.Type, outer.Message)
+			}
+			if decErr != nil {
+				var versionErr schemaVersionError
+				if errors.As(decErr, &versionErr) {
+					return nil, decErr
+				}
+				if atEOF {
+					// Partially written final line.
+					return p.rep, nil
+				}
+				return nil, fmt.Errorf("failed to decode message on line %d: %w", lineNum, decErr)
+			}
+		}
+
+		if atEOF {
+			return p.rep, nil
+		}
+	}
+}
+
+type schemaVersionError struct {
+	version int
+}
+
+func (e schemaVersionError) Error() string {
+	return fmt.Sprintf("unsupported report schema version %d; newest supported version is %d", e.version, SupportedSchemaVersion)
+}
+
+// parser folds the messages of a report into a Report.
+type parser struct {
+	rep    *Report
+	byName map[string]*Test
+}
+
+// testMessage holds the fields common to all test messages.
+type testMessage struct {
+	Name string
+}
+
+func (p *parser) add(typ string, raw json.RawMessage) error {
+	switch typ {
+	case "BeginSuite":
+		var m struct {
+			StartedAt     time.Time
+			SchemaVersion int
+			BuildInfo     *BuildInfo
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if m.SchemaVersion > SupportedSchemaVersion {
+			return schemaVersionError{version: m.SchemaVersion}
+		}
+		p.rep.SchemaVersion = m.SchemaVersion
+		p.rep.BuildInfo = m.BuildInfo
+		p.rep.StartedAt = m.StartedAt
+		return nil
+	case "Summary":
+		var m Summary
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		p.rep.Summary = &m
+		return nil
+	case "FinishSuite":
+		var m struct{ FinishedAt time.Time }
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		p.rep.FinishedAt = m.FinishedAt
+		return nil
+	case "BeginTest":
+		var m struct {
+			Name      string
+			StartedAt time.Time
+			Labels    Labels
+			Parent    string
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t := &Test{Name: m.Name, Labels: m.Labels, Parent: m.Parent, StartedAt: m.StartedAt}
+		p.byName[m.Name] = t
+		p.rep.Tests = append(p.rep.Tests, t)
+		return nil
+	}
+
+	// The remaining messages refer to a test that must have begun.
+	var tm testMessage
+	if err := json.Unmarshal(raw, &tm); err != nil {
+		return err
+	}
+	t := p.byName[tm.Name]
+	if t == nil {
+		return nil
+	}
+
+	switch typ {
+	case "FinishTest":
+		var m struct {
+			FinishedAt      time.Time
+			Failed, Skipped bool
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.FinishedAt, t.Failed, t.Skipped = m.FinishedAt, m.Failed, m.Skipped
+		if n := len(t.Attempts); n > 0 {
+			t.Attempts[n-1].Failed = m.Failed
+		}
+	case "PauseTest", "ContinueTest":
+		var m struct{ When time.Time }
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if typ == "PauseTest" {
+			t.PausedAt = m.When
+		} else {
+			t.ContinuedAt = m.When
+		}
+	case "TestError":
+		var m Error
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.Errors = append(t.Errors, m)
+		if n := len(t.Attempts); n > 0 {
+			t.Attempts[n-1].Errors++
+		}
+	case "TestLog":
+		var m Log
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.Logs = append(t.Logs, m)
+	case "TestRetry":
+		var m struct {
+			When    time.Time
+			Attempt int
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if len(t.Attempts) == 0 && m.Attempt > 1 {
+			// The first attempt was not tracked, but it must have begun with the test.
+			t.Attempts = append(t.Attempts, Attempt{Attempt: 1, StartedAt: t.StartedAt})
+		}
+		if n := len(t.Attempts); n > 0 {
+			// Starting a new attempt implies that the previous attempt failed.
+			t.Attempts[n-1].Failed = true
+		}
+		t.Attempts = append(t.Attempts, Attempt{Attempt: m.Attempt, StartedAt: m.When})
+	case "TestSkip":
+		var m struct{ Message, Category string }
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.SkipReason = m.Message
+		t.SkipCategory = m.Category
+	case "TestMetadata":
+		var m struct{ Key, Value string }
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if t.Metadata == nil {
+			t.Metadata = make(map[string]string)
+		}
+		t.Metadata[m.Key] = m.Value
+	case "TestPhase":
+		var m Phase
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.Phases = append(t.Phases, m)
+	case "TestAttachment":
+		var m Attachment
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.Attachments = append(t.Attachments, m)
+	case "RelayerExec":
+		var m RelayerExec
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.RelayerExecs = append(t.RelayerExecs, m)
+	case "ContainerLogs":
+		var m ContainerLogs
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.ContainerLogs = append(t.ContainerLogs, m)
+	case "ContainerStats":
+		var m ContainerStats
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.ContainerStats = &m
+	case "BlockDatabase":
+		var m struct {
+			Path       string
+			TestCaseID int64
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		t.BlockDatabasePath, t.BlockDatabaseTestCaseID = m.Path, m.TestCaseID
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package reportread_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/label"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter/reportread"
+	"github.com/stretchr/testify/require"
+)
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Buffer: buf})
+
+	mt := mocktesting.NewT("TestFoo")
+	r.TrackParameters(mt, []label.Relayer{label.Rly}, []label.Chain{label.Gaia, label.Osmosis})
+	r.TrackMetadata(mt, "gaia_version", "v7.0.1")
+	r.TrackPhase(mt, "start-chains")()
+	r.TestifyT(mt).Errorf("something went wrong")
+	r.RelayerExecReporter(mt).TrackRelayerExec("rly", []string{"rly", "version"}, "v2.3.0", "", 0, time.Now(), time.Now(), nil)
+
+	sub := mocktesting.NewT("TestFoo/bar")
+	sub.Simulate(func() {
+		r.TrackTest(sub)
+		r.TrackSkip(sub, "not supported")
+	})
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := reportread.Parse(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, testreporter.SchemaVersion, reportread.SupportedSchemaVersion)
+	require.Equal(t, testreporter.SchemaVersion, rep.SchemaVersion)
+	require.True(t, rep.Complete())
+
+	require.Len(t, rep.Tests, 2)
+	foo := rep.Tests[0]
+	require.Equal(t, "TestFoo", foo.Name)
+	require.Equal(t, []string{"gaia", "osmosis"}, foo.Labels.Chain)
+	require.True(t, foo.Failed)
+	require.Equal(t, "v7.0.1", foo.Metadata["gaia_version"])
+	require.Len(t, foo.Phases, 1)
+	require.Len(t, foo.Errors, 1)
+	require.Equal(t, "something went wrong", foo.Errors[0].Message)
+	require.Equal(t, []string{"rly", "version"}, foo.RelayerExecs[0].Command)
+
+	bar := rep.Tests[1]
+	require.Equal(t, "TestFoo", bar.Parent)
+	require.True(t, bar.Skipped)
+	require.Equal(t, "not supported", bar.SkipReason)
+
+	// The parsed report matches the report built by testreporter.
+	want, err := testreporter.ReadReport(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.NotNil(t, rep.Summary)
+	require.Equal(t, want.Summary().Failed, rep.Summary.Failed)
+	require.Equal(t, want.Summary().FailuresByChains, rep.Summary.FailuresByChains)
+	require.Equal(t, want.Tests[0].Duration(), foo.Duration())
+}
+
+func TestParse_Retries(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Buffer: buf})
+
+	flaky := mocktesting.NewT("TestFlaky")
+	r.TrackTest(flaky)
+	r.TrackRetry(flaky, 2)
+	r.TrackRetry(flaky, 3)
+
+	failing := mocktesting.NewT("TestFailing")
+	r.TrackTest(failing)
+	r.TrackRetry(failing, 1)
+	r.TestifyT(failing).Errorf("attempt 1 failed")
+	r.TrackRetry(failing, 2)
+	r.TestifyT(failing).Errorf("attempt 2 failed")
+
+	flaky.RunCleanups()
+	failing.RunCleanups()
+	require.NoError(t, r.Close())
+
+	rep, err := reportread.Parse(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, rep.Tests, 2)
+
+	// Starting at attempt 2 implies an untracked, failed first attempt.
+	tr := rep.Tests[0]
+	require.Len(t, tr.Attempts, 3)
+	require.Equal(t, []int{1, 2, 3}, []int{tr.Attempts[0].Attempt, tr.Attempts[1].Attempt, tr.Attempts[2].Attempt})
+	require.True(t, tr.Attempts[0].Failed)
+	require.True(t, tr.Attempts[1].Failed)
+	require.False(t, tr.Attempts[2].Failed)
+	require.True(t, tr.Flaky())
+
+	tr = rep.Tests[1]
+	require.Len(t, tr.Attempts, 2)
+	require.Equal(t, []int{1, 1}, []int{tr.Attempts[0].Errors, tr.Attempts[1].Errors})
+	require.True(t, tr.Attempts[1].Failed)
+	require.False(t, tr.Flaky())
+
+	// The parsed attempts match the report built by testreporter.
+	want, err := testreporter.ReadReport(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	for i, wt := range want.Tests {
+		require.Equal(t, wt.Flaky(), rep.Tests[i].Flaky())
+		require.Len(t, rep.Tests[i].Attempts, len(wt.Attempts))
+		for j, wa := range wt.Attempts {
+			require.Equal(t, reportread.Attempt{Attempt: wa.Attempt, StartedAt: wa.StartedAt, Failed: wa.Failed, Errors: wa.Errors}, rep.Tests[i].Attempts[j])
+		}
+	}
+}
+
+func TestParse_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := reportread.Parse(strings.NewReader(`{"Type":"BeginSuite","Message":{"StartedAt":"2023-01-01T00:00:00Z","SchemaVersion":99}}` + "\n"))
+	require.ErrorContains(t, err, "unsupported report schema version 99")
+}
+
+func TestParse_UnknownMessages(t *testing.T) {
+	t.Parallel()
+
+	rep, err := reportread.Parse(strings.NewReader(`{"Type":"BeginSuite","Message":{"StartedAt":"2023-01-01T00:00:00Z","SchemaVersion":1}}
+{"Type":"SomethingNew","Message":{"Name":"TestFoo"}}
+{"Type":"BeginTest","Message":{"Name":"TestFoo","StartedAt":"2023-01-01T00:00:01Z","NewField":true}}
+{"Type":"FinishTest","Message":{"Name":"TestFoo","FinishedAt":"2023-01-01T00:00:02Z"}}
+{"Type":"FinishSu`))
+	require.NoError(t, err)
+	require.False(t, rep.Complete())
+	require.Len(t, rep.Tests, 1)
+	require.Equal(t, time.Second, rep.Tests[0].Duration())
+}
@@ -0,0 +1,70 @@
+package testreporter
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxStackFrames limits the number of frames recorded for a test error.
+const maxStackFrames = 32
+
+const (
+	testifyPackagePrefix = "github.com/stretchr/testify/"
+	requirePackagePrefix = testifyPackagePrefix + "require."
+	reporterFuncPrefix   = "github.com/strangelove-ventures/interchaintest/v7/testreporter.(*TestifyReporter)."
+)
+
+// callSite describes where a failed assertion was made.
+type callSite struct {
+	File  string
+	Line  int
+	Stack string
+
+	// Fatal is set when the assertion was made through testify's require package,
+	// which stops the test after the failure.
+	Fatal bool
+}
+
+// assertionCallSite inspects the current goroutine's stack
+// to find the caller of the testify assertion that is calling the TestifyReporter.
+//
+// The testify and TestifyReporter frames are omitted from the recorded stack,
+// as are the Go runtime and testing frames, which are identical for every test.
+func assertionCallSite() callSite {
+	pcs := make([]uintptr, maxStackFrames+16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var (
+		cs     callSite
+		sb     strings.Builder
+		nFrame int
+	)
+	for {
+		f, more := frames.Next()
+		switch {
+		case strings.HasPrefix(f.Function, requirePackagePrefix):
+			cs.Fatal = true
+		case strings.HasPrefix(f.Function, testifyPackagePrefix),
+			strings.HasPrefix(f.Function, reporterFuncPrefix),
+			strings.HasPrefix(f.Function, "runtime."),
+			strings.HasPrefix(f.Function, "testing."):
+			// Omitted from the stack.
+		default:
+			if nFrame == 0 {
+				cs.File, cs.Line = f.File, f.Line
+			}
+			if nFrame < maxStackFrames {
+				fmt.Fprintf(&sb, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+			}
+			nFrame++
+		}
+		if !more {
+			break
+		}
+	}
+
+	cs.Stack = sb.String()
+	return cs
+}
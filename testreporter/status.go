@@ -0,0 +1,190 @@
+package testreporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxStatusErrors is the number of most recent errors shown per test in the status.
+const maxStatusErrors = 3
+
+// Status is a snapshot of the tests tracked by a Reporter, as served by its ServeHTTP method.
+type Status struct {
+	StartedAt time.Time
+	Elapsed   time.Duration
+
+	Running, Passed, Failed, Skipped int
+
+	// Tests in the order they began, including subtests.
+	Tests []TestStatus
+}
+
+// TestStatus is a snapshot of a single test.
+type TestStatus struct {
+	Name string
+
+	// Status is one of "running", "paused" (waiting for parallel execution), "pass", "fail", or "skip".
+	Status string
+
+	StartedAt time.Time
+
+	// Elapsed is the time since the test started, or its duration if it finished.
+	Elapsed time.Duration
+
+	// RecentErrors are the most recent error messages tracked for the test.
+	RecentErrors []string `json:",omitempty"`
+}
+
+// Status returns a snapshot of the current state of the tracked tests.
+func (r *Reporter) Status() Status {
+	now := time.Now()
+
+	r.builderMu.Lock()
+	defer r.builderMu.Unlock()
+
+	rep := r.builder.rep
+	s := Status{
+		StartedAt: rep.StartedAt,
+		Elapsed:   now.Sub(rep.StartedAt),
+		Tests:     make([]TestStatus, len(rep.Tests)),
+	}
+	if rep.Complete() {
+		s.Elapsed = rep.Duration()
+	}
+
+	for i, tr := range rep.Tests {
+		ts := TestStatus{
+			Name:      tr.Name,
+			Status:    testStatus(tr),
+			StartedAt: tr.StartedAt,
+			Elapsed:   tr.Duration(),
+		}
+		if ts.Status == "unknown" {
+			ts.Status = "running"
+			if !tr.PausedAt.IsZero() && tr.ContinuedAt.IsZero() {
+				ts.Status = "paused"
+			}
+			ts.Elapsed = now.Sub(tr.StartedAt)
+		}
+
+		errs := tr.Errors
+		if len(errs) > maxStatusErrors {
+			errs = errs[len(errs)-maxStatusErrors:]
+		}
+		for _, e := range errs {
+			ts.RecentErrors = append(ts.RecentErrors, e.Message)
+		}
+
+		switch ts.Status {
+		case "running", "paused":
+			s.Running++
+		case "pass":
+			s.Passed++
+		case "fail":
+			s.Failed++
+		case "skip":
+			s.Skipped++
+		}
+
+		s.Tests[i] = ts
+	}
+
+	return s
+}
+
+var statusTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"duration": func(d time.Duration) string {
+		return d.Round(time.Second).String()
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>interchaintest status</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+th { background: #eee; }
+tr.pass td.status { background: #c8e6c9; }
+tr.fail td.status { background: #ffcdd2; }
+tr.skip td.status { background: #fff9c4; }
+tr.running td.status, tr.paused td.status { background: #bbdefb; }
+pre { margin: 0; white-space: pre-wrap; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>interchaintest status</h1>
+<p>
+Running for {{duration .Elapsed}}.
+{{.Running}} running, {{.Passed}} passed, {{.Failed}} failed, {{.Skipped}} skipped.
+</p>
+<table>
+<thead>
+<tr><th>Test</th><th>Status</th><th>Elapsed</th><th>Recent errors</th></tr>
+</thead>
+<tbody>
+{{range .Tests}}<tr class="{{.Status}}">
+<td>{{.Name}}</td>
+<td class="status">{{.Status}}</td>
+<td>{{duration .Elapsed}}</td>
+<td>{{range .RecentErrors}}<pre>{{.}}</pre>{{end}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`))
+
+// ServeHTTP serves the current Status of r,
+// as JSON if the request path ends in ".json" or the request accepts application/json,
+// and otherwise as a minimal, self-refreshing HTML page.
+//
+// Use ListenAndServe to serve the status on a dedicated address.
+func (r *Reporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s := r.Status()
+
+	if strings.HasSuffix(req.URL.Path, ".json") || strings.Contains(req.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(s)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = statusTemplate.Execute(w, s)
+}
+
+// ListenAndServe serves the status of the in-progress run on addr, such as "localhost:8080",
+// until the Reporter is closed.
+// It returns the address being listened on, which is useful when addr uses port 0.
+func (r *Reporter) ListenAndServe(addr string) (net.Addr, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for status server: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = srv.Serve(ln) }()
+
+	r.closersMu.Lock()
+	defer r.closersMu.Unlock()
+	r.closers = append(r.closers, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	})
+
+	return ln.Addr(), nil
+}
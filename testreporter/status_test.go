@@ -0,0 +1,82 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/internal/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_Status(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)})
+
+	done := mocktesting.NewT("TestDone")
+	r.TrackTest(done)
+	done.RunCleanups()
+
+	running := mocktesting.NewT("TestRunning")
+	r.TrackTest(running)
+	r.TestifyT(running).Errorf("first")
+	r.TestifyT(running).Errorf("second")
+
+	// Wait for the messages to be processed by the Reporter.
+	require.Eventually(t, func() bool {
+		s := r.Status()
+		return len(s.Tests) == 2 && len(s.Tests[1].RecentErrors) == 2
+	}, time.Second, time.Millisecond)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/status.json")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	var s testreporter.Status
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&s))
+	require.Equal(t, 1, s.Running)
+	require.Equal(t, 1, s.Passed)
+	require.Equal(t, "pass", s.Tests[0].Status)
+	require.Equal(t, "running", s.Tests[1].Status)
+	require.Equal(t, []string{"first", "second"}, s.Tests[1].RecentErrors)
+
+	res, err = http.Get(srv.URL + "/")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "TestRunning")
+	require.Contains(t, string(body), "1 running, 1 passed")
+
+	running.RunCleanups()
+	require.NoError(t, r.Close())
+}
+
+func TestReporter_ListenAndServe(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)})
+	addr, err := r.ListenAndServe("127.0.0.1:0")
+	require.NoError(t, err)
+
+	res, err := http.Get("http://" + addr.String() + "/status.json")
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	require.NoError(t, r.Close())
+
+	// The server is stopped when the Reporter is closed.
+	_, err = http.Get("http://" + addr.String() + "/status.json")
+	require.Error(t, err)
+}
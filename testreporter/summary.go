@@ -0,0 +1,122 @@
+package testreporter
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSlowestTests is the number of tests listed in Summary.Slowest.
+const maxSlowestTests = 10
+
+// Summary is an aggregate overview of a test run.
+type Summary struct {
+	StartedAt, FinishedAt time.Time
+
+	Total, Passed, Failed, Skipped int
+
+	// Names of the failed tests, in the order they began.
+	FailedTests []string
+
+	// SummedTestTime is the sum of the durations of the top-level tests.
+	// Compared with WallClock, it indicates how much the run benefited from parallel tests.
+	SummedTestTime time.Duration
+
+	// Slowest are the tests with the longest durations, slowest first.
+	Slowest []TestDuration `json:",omitempty"`
+
+	// FailuresByChains counts the failed tests for each set of chains under test,
+	// keyed by the chain labels joined with "+", e.g. "gaia+osmosis".
+	// Subtests are counted under the chains of their nearest labeled ancestor.
+	FailuresByChains map[string]int `json:",omitempty"`
+
+	// SkippedByCategory counts the skipped tests for each category tracked through TrackSkipCategory.
+	// Skips without a category are not counted.
+	SkippedByCategory map[SkipCategory]int `json:",omitempty"`
+}
+
+// TestDuration is the duration of a single test.
+type TestDuration struct {
+	Name     string
+	Duration time.Duration
+}
+
+// WallClock is the duration of the whole run.
+func (s Summary) WallClock() time.Duration {
+	if s.FinishedAt.IsZero() {
+		return 0
+	}
+	return s.FinishedAt.Sub(s.StartedAt)
+}
+
+// Summary computes the aggregate overview of r.
+func (r *Report) Summary() Summary {
+	s := Summary{
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		Total:      len(r.Tests),
+	}
+
+	byName := make(map[string]*TestReport, len(r.Tests))
+	for _, tr := range r.Tests {
+		byName[tr.Name] = tr
+	}
+
+	for _, tr := range r.Tests {
+		switch testStatus(tr) {
+		case "pass":
+			s.Passed++
+		case "fail":
+			s.Failed++
+			s.FailedTests = append(s.FailedTests, tr.Name)
+
+			if chains := chainsKey(tr, byName); chains != "" {
+				if s.FailuresByChains == nil {
+					s.FailuresByChains = make(map[string]int)
+				}
+				s.FailuresByChains[chains]++
+			}
+		case "skip":
+			s.Skipped++
+
+			if tr.SkipCategory != "" {
+				if s.SkippedByCategory == nil {
+					s.SkippedByCategory = make(map[SkipCategory]int)
+				}
+				s.SkippedByCategory[tr.SkipCategory]++
+			}
+		}
+
+		if tr.Parent == "" {
+			s.SummedTestTime += tr.Duration()
+		}
+		if !tr.FinishedAt.IsZero() {
+			s.Slowest = append(s.Slowest, TestDuration{Name: tr.Name, Duration: tr.Duration()})
+		}
+	}
+
+	sort.SliceStable(s.Slowest, func(i, j int) bool {
+		return s.Slowest[i].Duration > s.Slowest[j].Duration
+	})
+	if len(s.Slowest) > maxSlowestTests {
+		s.Slowest = s.Slowest[:maxSlowestTests]
+	}
+
+	return s
+}
+
+// chainsKey returns the key of tr's chains in Summary.FailuresByChains,
+// using the labels of tr's nearest ancestor with chain labels.
+func chainsKey(tr *TestReport, byName map[string]*TestReport) string {
+	for tr != nil {
+		if len(tr.Labels.Chain) > 0 {
+			chains := make([]string, len(tr.Labels.Chain))
+			for i, c := range tr.Labels.Chain {
+				chains[i] = string(c)
+			}
+			return strings.Join(chains, "+")
+		}
+		tr = byName[tr.Parent]
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package testreporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTAP writes rep to w in the Test Anything Protocol, version 13,
+// with every tracked test as a test point.
+//
+// Skipped tests are reported with a SKIP directive including the skip reason,
+// and the error messages of failed tests are written as diagnostic lines after the test point.
+func WriteTAP(w io.Writer, rep *Report) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("TAP version 13\n")
+	printf("1..%d\n", len(rep.Tests))
+
+	for i, tr := range rep.Tests {
+		n := i + 1
+		desc := tapEscape(tr.Name)
+
+		switch testStatus(tr) {
+		case "pass":
+			printf("ok %d - %s\n", n, desc)
+		case "skip":
+			printf("ok %d - %s # SKIP %s\n", n, desc, tapEscape(firstLine(tr.SkipReason)))
+		case "fail":
+			printf("not ok %d - %s\n", n, desc)
+			for _, e := range tr.Errors {
+				if e.File != "" {
+					printf("# %s:%d\n", e.File, e.Line)
+				}
+				for _, line := range strings.Split(strings.TrimRight(e.Message, "\n"), "\n") {
+					printf("# %s\n", line)
+				}
+			}
+		default:
+			printf("not ok %d - %s\n", n, desc)
+			printf("# test did not finish\n")
+		}
+	}
+
+	return err
+}
+
+// tapEscape escapes characters with special meaning in a test point description.
+func tapEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "#", `\#`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,53 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v7/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTAP(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	rep := testreporter.NewReport([]testreporter.Message{
+		testreporter.BeginTestMessage{Name: "TestPass", StartedAt: now},
+		testreporter.FinishTestMessage{Name: "TestPass", FinishedAt: now},
+
+		testreporter.BeginTestMessage{Name: "TestSkip", StartedAt: now},
+		testreporter.TestSkipMessage{Name: "TestSkip", When: now, Message: "relayer does not support #123"},
+		testreporter.FinishTestMessage{Name: "TestSkip", FinishedAt: now, Skipped: true},
+
+		testreporter.BeginTestMessage{Name: "TestFail", StartedAt: now},
+		testreporter.TestErrorMessage{Name: "TestFail", When: now, Message: "Error: not equal\nexpected: 1"},
+		testreporter.FinishTestMessage{Name: "TestFail", FinishedAt: now, Failed: true},
+
+		testreporter.BeginTestMessage{Name: "TestUnfinished", StartedAt: now},
+	})
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, testreporter.WriteTAP(buf, rep))
+	require.Equal(t, `TAP version 13
+1..4
+ok 1 - TestPass
+ok 2 - TestSkip # SKIP relayer does not support \#123
+not ok 3 - TestFail
+# Error: not equal
+# expected: 1
+not ok 4 - TestUnfinished
+# test did not finish
+`, buf.String())
+}
+
+func TestReporter_TAPOutput(t *testing.T) {
+	t.Parallel()
+
+	tap := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)}, testreporter.TAPOutput(tap))
+	require.NoError(t, r.Close())
+
+	require.Equal(t, "TAP version 13\n1..0\n", tap.String())
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/docker/docker/client"
@@ -51,6 +53,33 @@ func ModifyTomlConfigFile(
 	volumeName string,
 	filePath string,
 	modifications Toml,
+) error {
+	return modifyTomlConfigFile(ctx, logger, dockerClient, testName, volumeName, filePath, modifications, false)
+}
+
+// ModifyExistingTomlConfigFile is like ModifyTomlConfigFile, but fails if a key of modifications does not exist
+// in the file, so that mistyped keys are caught rather than silently added.
+func ModifyExistingTomlConfigFile(
+	ctx context.Context,
+	logger *zap.Logger,
+	dockerClient *client.Client,
+	testName string,
+	volumeName string,
+	filePath string,
+	modifications Toml,
+) error {
+	return modifyTomlConfigFile(ctx, logger, dockerClient, testName, volumeName, filePath, modifications, true)
+}
+
+func modifyTomlConfigFile(
+	ctx context.Context,
+	logger *zap.Logger,
+	dockerClient *client.Client,
+	testName string,
+	volumeName string,
+	filePath string,
+	modifications Toml,
+	existingKeysOnly bool,
 ) error {
 	fr := dockerutil.NewFileRetriever(logger, dockerClient, testName)
 	config, err := fr.SingleFileContent(ctx, volumeName, filePath)
@@ -63,6 +92,12 @@ func ModifyTomlConfigFile(
 		return fmt.Errorf("failed to unmarshal %s: %w", filePath, err)
 	}
 
+	if existingKeysOnly {
+		if missing := MissingTomlKeys(c, modifications); len(missing) > 0 {
+			return fmt.Errorf("%s has no keys: %s", filePath, strings.Join(missing, ", "))
+		}
+	}
+
 	if err := recursiveModifyToml(c, modifications); err != nil {
 		return err
 	}
@@ -79,3 +114,38 @@ func ModifyTomlConfigFile(
 
 	return nil
 }
+
+// MissingTomlKeys returns the keys of modifications, joined by dots for nested tables, that do not exist in c, sorted.
+func MissingTomlKeys(c map[string]any, modifications Toml) []string {
+	var missing []string
+	var walk func(c map[string]any, modifications map[string]any, prefix string)
+	walk = func(c map[string]any, modifications map[string]any, prefix string) {
+		for key, value := range modifications {
+			existing, ok := c[key]
+			if !ok {
+				missing = append(missing, prefix+key)
+				continue
+			}
+			if table, ok := tomlTable(value); ok {
+				if existingTable, ok := tomlTable(existing); ok {
+					walk(existingTable, table, prefix+key+".")
+				}
+			}
+		}
+	}
+	walk(c, modifications, "")
+	sort.Strings(missing)
+	return missing
+}
+
+// tomlTable returns v as a toml table, if it is one.
+func tomlTable(v any) (map[string]any, bool) {
+	switch t := v.(type) {
+	case Toml:
+		return t, true
+	case map[string]any:
+		return t, true
+	default:
+		return nil, false
+	}
+}
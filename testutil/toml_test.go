@@ -0,0 +1,27 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingTomlKeys(t *testing.T) {
+	t.Parallel()
+
+	c := map[string]any{
+		"minimum-gas-prices": "",
+		"api":                map[string]any{"enable": false, "address": "tcp://0.0.0.0:1317"},
+	}
+
+	require.Empty(t, MissingTomlKeys(c, Toml{
+		"minimum-gas-prices": "0.01uatom",
+		"api":                Toml{"enable": true},
+	}))
+
+	require.Equal(t, []string{"api.enabel", "grpc", "minimum-gas-price"}, MissingTomlKeys(c, Toml{
+		"minimum-gas-price": "0.01uatom",
+		"api":               Toml{"enabel": true},
+		"grpc":              Toml{"enable": true},
+	}))
+}